@@ -11,11 +11,18 @@ import (
 	"time"
 
 	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/buildinfo"
+	"github.com/aida0710/jwt-auth/internal/cli"
 	"github.com/aida0710/jwt-auth/internal/config"
 	"github.com/aida0710/jwt-auth/internal/di"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/handler"
 	"github.com/aida0710/jwt-auth/internal/logger"
 	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/aida0710/jwt-auth/internal/repository"
+	"github.com/aida0710/jwt-auth/internal/usecase"
 	"github.com/labstack/echo/v4"
+	glog "github.com/labstack/gommon/log"
 )
 
 func main() {
@@ -24,6 +31,26 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	for _, warning := range cfg.Warnings() {
+		log.Printf("Config warning: %s", warning)
+	}
+
+	// 運用向けのサブコマンド（サーバーは起動しない）
+	if len(os.Args) > 1 {
+		args := os.Args[2:]
+		switch os.Args[1] {
+		case cli.TokenCommand:
+			// デバッグ用のアクセストークンの発行（本番環境では使用不可）
+			os.Exit(runCommand(cfg, cli.CheckTokenCommand, func(ctx context.Context, container *di.Container) error {
+				return cli.RunToken(ctx, cfg, container.GetAuthUsecase(), args, os.Stdout, os.Stderr)
+			}))
+		case cli.RotateSecretCommand:
+			// 署名鍵のローテーション（稼働中のインスタンスは再起動せずに新しい鍵を読み込む）
+			os.Exit(runCommand(cfg, cli.CheckRotateSecretCommand, func(ctx context.Context, container *di.Container) error {
+				return cli.RunRotateSecret(ctx, cfg, container.GetSigningKeyUsecase(), args, os.Stdout, os.Stderr)
+			}))
+		}
+	}
 
 	// DIコンテナの初期化
 	container, err := di.NewContainer(cfg)
@@ -41,38 +68,143 @@ func main() {
 	// Echoインスタンスの作成
 	e := echo.New()
 
+	// c.RealIP()が転送ヘッダーを参照するのは信頼するプロキシからのリクエストのみ
+	// （監査ログやIPアドレス制限で接続元を偽装されないようにする）
+	ipExtractor, err := middleware.NewIPExtractor(cfg.Server.TrustedProxies)
+	if err != nil {
+		log.Fatalf("Failed to configure trusted proxies: %v", err)
+	}
+	e.IPExtractor = ipExtractor
+
 	// すべてのミドルウェアを設定
-	middleware.Setup(e)
+	middleware.Setup(e, container.GetLogger(), middleware.CompressionConfig{
+		Level:            cfg.Compression.Level,
+		MinLength:        cfg.Compression.MinLength,
+		SkipContentTypes: cfg.Compression.SkipContentTypes,
+	})
+
+	// シャットダウン中は新規リクエストを503で拒否
+	shutdownGate := middleware.NewShutdownGate()
+	e.Use(shutdownGate.Middleware)
+
+	// 同時に処理するリクエスト数の制限（処理待ちのリクエストでコネクションプールを使い切らないようにする）
+	if limit := cfg.InflightLimit(); limit > 0 {
+		e.Use(middleware.NewInflightLimitMiddleware(middleware.InflightLimitConfig{
+			Max:       limit,
+			SkipPaths: []string{"/api/v1/health", "/api/v1/ready"},
+		}))
+	}
+
+	// 管理者エンドポイントのIPアドレス制限（CIDRの設定誤りは起動時に検出）
+	ipAccessMiddleware, err := middleware.NewIPAccessMiddleware(middleware.IPAccessConfig{
+		PathPrefixes: []string{"/api/v1/admin"},
+		Allowlist:    cfg.Admin.IPAllowlist,
+		Denylist:     cfg.Admin.IPDenylist,
+	})
+	if err != nil {
+		log.Fatalf("Failed to configure IP access control: %v", err)
+	}
+	e.Use(ipAccessMiddleware)
+
+	// 認証不要のパス（public apiのみを指定、デフォルトがプライベート）
+	publicPaths := []string{
+		"/",
+		"/api/v1/health",
+		"/api/v1/info",
+		"/api/v1/auth/signup",
+		"/api/v1/auth/login",
+		"/api/v1/auth/refresh",
+		"/api/v1/auth/magic-link",
+		"/api/v1/auth/magic-link/verify",
+		"/api/v1/auth/password-reset",
+		"/api/v1/auth/password-reset/confirm",
+		handler.DiscoveryPath,
+		handler.JWKSPath,
+	}
+
+	// 認証エンドポイントのレート制限（総当たり攻撃の抑止）
+	// 信頼済みのAPIキーと認証済みのアカウントは接続元アドレスではなく主体ごとに制限する（認証不要のパスは常に接続元アドレス）
+	if cfg.RateLimit.Enabled {
+		rateLimitKeys, err := middleware.ParseRateLimitKeys(cfg.RateLimit.TrustedKeys)
+		if err != nil {
+			log.Fatalf("Failed to configure rate limit keys: %v", err)
+		}
+		e.Use(middleware.NewRateLimitMiddleware(middleware.RateLimitConfig{
+			PathPrefixes: cfg.RateLimit.Paths,
+			Requests:     cfg.RateLimit.Requests,
+			Window:       cfg.RateLimit.Window,
+			Keys:         rateLimitKeys,
+			JWTManager:   container.GetJWTManager(),
+			PublicPaths:  publicPaths,
+		}))
+	}
+
+	// 検証のみの構成ではトークンを発行する認証エンドポイントを拒否（トークンの発行は別のサービスが行う）
+	if cfg.JWT.VerifyOnly {
+		e.Use(middleware.NewVerifyOnlyMiddleware(middleware.VerifyOnlyConfig{
+			Paths: []string{
+				"/api/v1/auth/signup",
+				"/api/v1/auth/login",
+				"/api/v1/auth/refresh",
+				"/api/v1/auth/magic-link",
+				"/api/v1/auth/magic-link/verify",
+			},
+		}))
+	}
 
 	// 認証ミドルウェアの設定
 	authMiddleware := middleware.NewAuthMiddleware(middleware.AuthConfig{
-		JWTManager: container.GetJWTManager(),
-		// public apiのみを指定、デフォルトがプライベート
-		PublicPaths: []string{
-			"/",
-			"/api/v1/health",
-			"/api/v1/auth/signup",
-			"/api/v1/auth/login",
-			"/api/v1/auth/refresh",
+		JWTManager:  container.GetJWTManager(),
+		PublicPaths: publicPaths,
+		IDs:         cfg.ID.Domain(),
+		// 管理者のトークンがある場合のみコネクションプールの統計を返す
+		OptionalPaths: []string{
+			"/api/v1/ready",
 		},
 	})
 
 	// 認証ミドルウェアをグローバルに適用
 	e.Use(authMiddleware)
 
+	// 管理者のみ許可するエンドポイント
+	e.Use(middleware.NewRoleMiddleware(middleware.RoleConfig{
+		Rules: map[string]domain.Role{
+			"POST /api/v1/accounts":                            domain.RoleAdmin,
+			"GET /api/v1/admin/accounts":                       domain.RoleAdmin,
+			"GET /api/v1/admin/accounts/search":                domain.RoleAdmin,
+			"PUT /api/v1/admin/accounts/:account_id/status":    domain.RoleAdmin,
+			"PUT /api/v1/admin/accounts/:account_id/audiences": domain.RoleAdmin,
+			"POST /api/v1/admin/invites":                       domain.RoleAdmin,
+			"GET /api/v1/admin/projects":                       domain.RoleAdmin,
+			"POST /api/v1/admin/sessions/revoke":               domain.RoleAdmin,
+		},
+	}))
+
 	// OpenAPIハンドラーの登録
 	// baseURLに/api/v1を指定
-	api.RegisterHandlersWithBaseURL(e, container.GetHandler(), "/api/v1")
+	api.RegisterHandlersWithBaseURL(e, container.GetHandler(), handler.BasePath)
 
 	// ヘルスチェックエンドポイント
 	e.GET("/", func(c echo.Context) error {
 		return c.JSON(http.StatusOK, map[string]string{
 			"service": "JWT Auth API",
 			"status":  "running",
-			"version": "1.0.0",
+			"version": buildinfo.Version,
 		})
 	})
 
+	// OAuth/OIDCクライアントの自動設定用のディスカバリードキュメント（JWT_ISSUER_URLを設定した場合のみ）
+	if cfg.JWT.IssuerURL != "" {
+		discovery := handler.NewDiscoveryHandler(handler.DiscoveryConfig{
+			Issuer:           cfg.JWT.Issuer,
+			IssuerURL:        cfg.JWT.IssuerURL,
+			SigningAlgorithm: cfg.JWT.SigningAlgorithm,
+			SigningKeys:      container.GetSigningKeys(),
+		})
+		e.GET(handler.DiscoveryPath, discovery.GetConfiguration)
+		e.GET(handler.JWKSPath, discovery.GetJWKS)
+	}
+
 	// サーバーの起動
 	srv := &http.Server{
 		Addr:         ":" + cfg.Server.Port,
@@ -93,20 +225,176 @@ func main() {
 		}
 	}()
 
+	// 猶予期間が過ぎた削除予定のアカウントと有効期限切れのリフレッシュトークンを定期的に削除
+	purgeCtx, stopPurge := context.WithCancel(context.Background())
+	defer stopPurge()
+	go runAccountPurge(purgeCtx, container.GetAccountUsecase(), container.GetLogger(), cfg.Deletion.PurgeInterval)
+	go runRefreshTokenCleanup(purgeCtx, container.GetRefreshTokenRepo(), container.GetLogger(), cfg.Cleanup)
+
+	// 保存に失敗したセキュリティ監査ログを定期的に再試行
+	auditBuffer := container.GetSecurityAuditBuffer()
+	if auditBuffer != nil {
+		go runSecurityAuditRetry(purgeCtx, auditBuffer, container.GetLogger(), cfg.Audit.RetryInterval)
+	}
+
+	// 署名鍵を定期的にローテーションし、他のインスタンスが作成した鍵を読み込む
+	if signingKeyUsecase := container.GetSigningKeyUsecase(); signingKeyUsecase != nil {
+		go runSigningKeyRotation(purgeCtx, signingKeyUsecase, container.GetLogger(), cfg.JWT.KeyRotationCheckInterval)
+	}
+
 	// シグナル待機
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	// グレースフルシャットダウンの実行
-	container.GetLogger().Info(context.Background(), "Shutting down server...")
+	// 新規リクエストの受け付けを止め、処理中のリクエストの完了を待つ
+	stopPurge()
+	inFlight := shutdownGate.StartDraining()
+	container.GetLogger().Info(context.Background(), "Shutting down server...",
+		logger.F("in_flight_requests", inFlight),
+		logger.F("timeout", cfg.Server.ShutdownTimeout.String()),
+	)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
 
 	if err := e.Shutdown(ctx); err != nil {
 		container.GetLogger().Error(context.Background(), "Failed to shutdown server", err)
 	}
 
+	// 処理中のリクエストが記録した監査ログを含めて最後に保存を試み、保存できなかったものはログに残す
+	if auditBuffer != nil {
+		if _, err := auditBuffer.Flush(ctx); err != nil {
+			container.GetLogger().Error(context.Background(), "Failed to flush security audit events", err)
+		}
+		auditBuffer.LogPending(context.Background())
+	}
+
 	container.GetLogger().Info(context.Background(), "Server exited")
 }
+
+// runCommand サブコマンドを実行し、終了コードを返す
+// checkで実行できる設定か確認してからDIコンテナを作成する
+// 出力をそのままシェルの変数などに渡せるよう、ログはstderrに出力してstdoutにはコマンドの結果のみを出力する
+func runCommand(cfg *config.Config, check func(*config.Config) error, run func(context.Context, *di.Container) error) int {
+	if err := check(cfg); err != nil {
+		log.Printf("Command failed: %v", err)
+		return 1
+	}
+
+	if cfg.Logger.Output == "" || cfg.Logger.Output == logger.OutputStdout {
+		cfg.Logger.Output = logger.OutputStderr
+	}
+	glog.SetOutput(os.Stderr)
+
+	container, err := di.NewContainer(cfg)
+	if err != nil {
+		log.Printf("Failed to initialize container: %v", err)
+		return 1
+	}
+	defer func() {
+		if err := container.Close(); err != nil {
+			log.Printf("Failed to close container: %v", err)
+		}
+	}()
+
+	if err := run(context.Background(), container); err != nil {
+		log.Printf("Command failed: %v", err)
+		return 1
+	}
+	return 0
+}
+
+// runAccountPurge 起動時とintervalごとに猶予期間が過ぎたアカウントを完全に削除する
+// 失敗した場合はログに出力し、次回の実行で再試行する（ctxがキャンセルされるまで戻らない）
+func runAccountPurge(ctx context.Context, accountUsecase usecase.AccountUsecase, log logger.Logger, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := accountUsecase.PurgeDeletedAccounts(ctx, domain.Now())
+		if err != nil && ctx.Err() == nil {
+			log.Error(ctx, "Failed to purge deleted accounts", err, logger.F("purged", purged))
+		} else if purged > 0 {
+			log.Info(ctx, "Purged deleted accounts", logger.F("purged", purged))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runSecurityAuditRetry intervalごとに保存に失敗したセキュリティ監査ログの保存を再試行する
+// 失敗した場合は次回の実行で再試行する（ctxがキャンセルされるまで戻らない）
+func runSecurityAuditRetry(ctx context.Context, buffer *repository.SecurityAuditBuffer, log logger.Logger, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if buffer.Pending() == 0 {
+			continue
+		}
+		flushed, err := buffer.Flush(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Error(ctx, "Failed to retry security audit events", err,
+				logger.F("flushed", flushed),
+				logger.F("pending", buffer.Pending()),
+				logger.F("dropped", buffer.Dropped()),
+			)
+		}
+	}
+}
+
+// runSigningKeyRotation intervalごとに署名鍵のローテーションと読み込み直しを行う（起動時の読み込みはコンテナの作成時に行う）
+// 失敗した場合はログに出力し、読み込み済みの鍵で署名と検証を続ける（ctxがキャンセルされるまで戻らない）
+func runSigningKeyRotation(ctx context.Context, signingKeyUsecase *usecase.SigningKeyUsecase, log logger.Logger, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		created, err := signingKeyUsecase.Rotate(ctx, domain.Now())
+		if err != nil && ctx.Err() == nil {
+			log.Error(ctx, "Failed to rotate signing keys", err)
+		} else if created {
+			log.Info(ctx, "Rotated signing key")
+		}
+	}
+}
+
+// runRefreshTokenCleanup 起動時と一定間隔ごとに有効期限切れのリフレッシュトークンをバッチに分けて削除する
+// ctxがキャンセルされるとバッチの途中でも終了する
+func runRefreshTokenCleanup(ctx context.Context, refreshTokenRepo domain.RefreshTokenRepository, log logger.Logger, cfg config.TokenCleanupConfig) {
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := refreshTokenRepo.DeleteExpired(ctx, cfg.BatchSize, cfg.BatchPause)
+		if err != nil && ctx.Err() == nil {
+			log.Error(ctx, "Failed to delete expired refresh tokens", err, logger.F("deleted", deleted))
+		} else if deleted > 0 {
+			log.Info(ctx, "Deleted expired refresh tokens", logger.F("deleted", deleted))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -2,18 +2,26 @@
 CREATE TABLE IF NOT EXISTS refresh_tokens (
     id VARCHAR(36) PRIMARY KEY, -- UUID v4
     account_id VARCHAR(36) NOT NULL, -- UUID v4
-    token_hash VARCHAR(255) NOT NULL UNIQUE,
+    family_id VARCHAR(36) NULL, -- ローテーションで引き継がれるファミリーID
+    token_hash VARCHAR(255) NOT NULL,
     expires_at TIMESTAMP NOT NULL,
+    absolute_expires_at TIMESTAMP NULL, -- ファミリーの絶対有効期限
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     used_at TIMESTAMP NULL,
+    successor_id VARCHAR(36) NULL, -- ローテーションで発行した次のトークンのID
     revoked_at TIMESTAMP NULL,
     user_agent VARCHAR(500),
     ip_address VARCHAR(45),
+    device_name VARCHAR(100) NULL, -- ユーザーが指定した端末名（未指定の場合はUser-Agentの要約）
     FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    UNIQUE INDEX uq_refresh_tokens_token_hash (token_hash),
     INDEX idx_account_id (account_id),
-    INDEX idx_token_hash (token_hash),
+    INDEX idx_account_id_expires_at (account_id, expires_at),
+    INDEX idx_family_id (family_id),
     INDEX idx_expires_at (expires_at),
-    INDEX idx_revoked_at (revoked_at)
+    INDEX idx_revoked_at (revoked_at),
+    INDEX idx_ip_address_revoked_at (ip_address, revoked_at), -- 送信元IPアドレスによる一括無効化に使用
+    INDEX idx_user_agent_revoked_at (user_agent, revoked_at) -- User-Agentによる一括無効化に使用
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 -- security_audit_logsテーブルの作成
@@ -30,4 +38,79 @@ CREATE TABLE IF NOT EXISTS security_audit_logs (
     INDEX idx_account_id (account_id),
     INDEX idx_event_type (event_type),
     INDEX idx_created_at (created_at)
-) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
\ No newline at end of file
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- password_historyテーブルの作成（パスワード再利用の防止）
+CREATE TABLE IF NOT EXISTS password_history (
+    id VARCHAR(36) PRIMARY KEY, -- UUID
+    account_id VARCHAR(36) NOT NULL, -- UUID
+    password_hash VARCHAR(255) NOT NULL, -- 変更前のパスワードのハッシュ
+    created_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6), -- 同一秒内の変更も順序付けできるようマイクロ秒精度
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    INDEX idx_account_id_created_at (account_id, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- login_attemptsテーブルの作成（ログイン失敗によるロックアウト）
+CREATE TABLE IF NOT EXISTS login_attempts (
+    account_id VARCHAR(36) PRIMARY KEY, -- UUID
+    failed_count INT NOT NULL DEFAULT 0, -- 直近のロック以降の連続失敗回数
+    lockout_count INT NOT NULL DEFAULT 0, -- 静穏期間内に繰り返されたロックの回数（ロック期間の倍増に使用）
+    locked_until TIMESTAMP NULL DEFAULT NULL,
+    last_failed_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- magic_link_tokensテーブルの作成（メールで送信するパスワード不要のログイン用リンク）
+CREATE TABLE IF NOT EXISTS magic_link_tokens (
+    id VARCHAR(36) PRIMARY KEY, -- UUID
+    account_id VARCHAR(36) NOT NULL, -- UUID
+    token_hash VARCHAR(255) NOT NULL, -- トークンのSHA-256ハッシュ（トークン自体は保存しない）
+    expires_at TIMESTAMP NOT NULL,
+    consumed_at TIMESTAMP NULL DEFAULT NULL, -- 使用済みの場合はその日時（1回のみ使用可能）
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    UNIQUE INDEX uq_magic_link_tokens_token_hash (token_hash),
+    INDEX idx_account_id_created_at (account_id, created_at) -- メールアドレスごとの送信数の制限に使用
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- password_reset_tokensテーブルの作成（メールで送信するパスワード再設定用のトークン）
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id VARCHAR(36) PRIMARY KEY, -- UUID
+    account_id VARCHAR(36) NOT NULL, -- UUID
+    token_hash VARCHAR(255) NOT NULL, -- トークンのSHA-256ハッシュ（トークン自体は保存しない）
+    expires_at TIMESTAMP NOT NULL,
+    consumed_at TIMESTAMP NULL DEFAULT NULL, -- 使用済みまたは新しいトークンの発行で無効にした日時（1回のみ使用可能）
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    UNIQUE INDEX uq_password_reset_tokens_token_hash (token_hash),
+    INDEX idx_account_id_consumed_at (account_id, consumed_at) -- 新しいトークンの発行時に未使用のトークンを無効にするために使用
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- invitesテーブルの作成（招待制のサインアップで使用する招待）
+CREATE TABLE IF NOT EXISTS invites (
+    id VARCHAR(36) PRIMARY KEY, -- UUID
+    token_hash VARCHAR(255) NOT NULL, -- トークンのSHA-256ハッシュ（トークン自体は保存しない）
+    role VARCHAR(20) NOT NULL DEFAULT 'user', -- サインアップしたアカウントに割り当てるロール
+    tenant_id VARCHAR(64) NOT NULL DEFAULT 'default', -- サインアップしたアカウントが属するテナント
+    created_by VARCHAR(36) NOT NULL, -- 招待を作成した管理者
+    expires_at TIMESTAMP NOT NULL,
+    consumed_at TIMESTAMP NULL DEFAULT NULL, -- 使用済みの場合はその日時（1回のみ使用可能）
+    consumed_by VARCHAR(36) NULL DEFAULT NULL, -- 招待を使用してサインアップしたアカウント
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (created_by) REFERENCES accounts(id) ON DELETE CASCADE,
+    FOREIGN KEY (consumed_by) REFERENCES accounts(id) ON DELETE SET NULL,
+    UNIQUE INDEX uq_invites_token_hash (token_hash)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- signing_keysテーブルの作成（ローテーションするアクセストークンの署名鍵、再起動後も発行済みのトークンを検証できるよう保存する）
+CREATE TABLE IF NOT EXISTS signing_keys (
+    id VARCHAR(36) PRIMARY KEY, -- UUID（JWTのkidヘッダー）
+    algorithm VARCHAR(10) NOT NULL, -- HS256 / HS384 / HS512
+    encrypted_secret VARBINARY(255) NOT NULL, -- JWT_ACCESS_TOKEN_SECRETから導出した鍵でAES-GCMにより暗号化した鍵
+    created_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    activates_at TIMESTAMP(6) NOT NULL, -- 署名に使用し始める日時
+    rotates_at TIMESTAMP(6) NULL DEFAULT NULL, -- 次の鍵に署名を引き継ぐ日時
+    retires_at TIMESTAMP(6) NULL DEFAULT NULL, -- 検証に使用しなくなる日時（過ぎた鍵は削除する）
+    INDEX idx_activates_at (activates_at),
+    INDEX idx_retires_at (retires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
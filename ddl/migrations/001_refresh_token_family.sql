@@ -0,0 +1,11 @@
+-- 既存環境向けマイグレーション: リフレッシュトークンのファミリーと絶対有効期限
+-- 新規環境は ddl/auth_schema.sql に反映済み
+ALTER TABLE refresh_tokens
+    ADD COLUMN family_id VARCHAR(36) NULL AFTER account_id,
+    ADD COLUMN absolute_expires_at TIMESTAMP NULL AFTER expires_at,
+    ADD INDEX idx_family_id (family_id);
+
+-- 既存のトークンは単独のファミリーとして扱う
+UPDATE refresh_tokens
+SET family_id = id, absolute_expires_at = expires_at
+WHERE family_id IS NULL;
@@ -0,0 +1,8 @@
+-- 既存環境向けマイグレーション: リフレッシュトークンのインデックス整理
+-- token_hashの一意インデックスに名前を付け、冗長な非一意インデックスを削除する
+-- あわせてクリーンアップ・一覧取得用の複合インデックスを追加する
+ALTER TABLE refresh_tokens
+    DROP INDEX idx_token_hash,
+    DROP INDEX token_hash,
+    ADD UNIQUE INDEX uq_refresh_tokens_token_hash (token_hash),
+    ADD INDEX idx_account_id_expires_at (account_id, expires_at);
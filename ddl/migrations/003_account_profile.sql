@@ -0,0 +1,7 @@
+-- 既存環境向けマイグレーション: アカウントのプロフィール項目
+-- 新規環境は ddl/schema.sql に反映済み
+ALTER TABLE accounts
+    ADD COLUMN display_name VARCHAR(255) NULL AFTER password_hash,
+    ADD COLUMN avatar_url VARCHAR(2048) NULL AFTER display_name,
+    ADD COLUMN locale VARCHAR(35) NULL AFTER avatar_url,
+    ADD COLUMN timezone VARCHAR(64) NULL AFTER locale;
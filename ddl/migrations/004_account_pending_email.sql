@@ -0,0 +1,6 @@
+-- 既存環境向けマイグレーション: メールアドレス変更の確認待ち状態
+-- 新規環境は ddl/schema.sql に反映済み
+ALTER TABLE accounts
+    ADD COLUMN pending_email VARCHAR(255) NULL AFTER timezone,
+    ADD COLUMN email_verification_token_hash VARCHAR(64) NULL AFTER pending_email,
+    ADD COLUMN email_verification_expires_at TIMESTAMP NULL AFTER email_verification_token_hash;
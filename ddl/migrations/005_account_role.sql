@@ -0,0 +1,4 @@
+-- 既存環境向けマイグレーション: アカウントのロール
+-- 新規環境は ddl/schema.sql に反映済み
+ALTER TABLE accounts
+    ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'user' AFTER name;
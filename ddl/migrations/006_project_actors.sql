@@ -0,0 +1,12 @@
+-- 既存環境向けマイグレーション: プロジェクトの作成者・最終更新者
+-- 新規環境は ddl/schema.sql に反映済み
+ALTER TABLE projects
+    ADD COLUMN created_by VARCHAR(36) NULL AFTER status,
+    ADD COLUMN updated_by VARCHAR(36) NULL AFTER created_by,
+    ADD CONSTRAINT fk_projects_created_by FOREIGN KEY (created_by) REFERENCES accounts(id) ON DELETE SET NULL,
+    ADD CONSTRAINT fk_projects_updated_by FOREIGN KEY (updated_by) REFERENCES accounts(id) ON DELETE SET NULL;
+
+-- 既存のプロジェクトは所有者が作成・更新したものとして扱う
+UPDATE projects
+SET created_by = account_id, updated_by = account_id
+WHERE created_by IS NULL;
@@ -0,0 +1,4 @@
+-- 既存環境向けマイグレーション: ローテーション先のトークンID
+-- 新規環境は ddl/auth_schema.sql に反映済み
+ALTER TABLE refresh_tokens
+    ADD COLUMN successor_id VARCHAR(36) NULL AFTER used_at;
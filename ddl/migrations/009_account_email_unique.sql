@@ -0,0 +1,8 @@
+-- 既存環境向けマイグレーション: メールアドレスの一意インデックス
+-- 一意制約は列定義のUNIQUE（暗黙のインデックス名email）で宣言されていたため、
+-- 一意制約違反をインデックス名で判別できるよう uq_accounts_email に付け替え、冗長な非一意インデックスを削除する
+-- 新規環境は ddl/schema.sql に反映済み
+ALTER TABLE accounts
+    DROP INDEX idx_email,
+    DROP INDEX email,
+    ADD UNIQUE INDEX uq_accounts_email (email);
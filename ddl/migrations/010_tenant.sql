@@ -0,0 +1,15 @@
+-- 既存環境向けマイグレーション: アカウントとプロジェクトのテナント
+-- 既存のデータはすべて'default'テナントに属するものとして扱う
+-- 新規環境は ddl/schema.sql に反映済み
+ALTER TABLE accounts
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' AFTER id,
+    ADD INDEX idx_tenant_id (tenant_id);
+
+ALTER TABLE projects
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' AFTER id,
+    ADD INDEX idx_tenant_id (tenant_id);
+
+-- プロジェクトは所有するアカウントのテナントに揃える
+UPDATE projects p
+JOIN accounts a ON a.id = p.account_id
+SET p.tenant_id = a.tenant_id;
@@ -0,0 +1,10 @@
+-- 既存環境向けマイグレーション: ログイン失敗によるロックアウトのための記録テーブル
+-- 新規環境は ddl/auth_schema.sql に反映済み
+CREATE TABLE IF NOT EXISTS login_attempts (
+    account_id VARCHAR(36) PRIMARY KEY,
+    failed_count INT NOT NULL DEFAULT 0,
+    lockout_count INT NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP NULL DEFAULT NULL,
+    last_failed_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
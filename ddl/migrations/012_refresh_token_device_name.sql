@@ -0,0 +1,4 @@
+-- 既存環境向けマイグレーション: セッションの端末名
+-- 新規環境は ddl/auth_schema.sql に反映済み
+ALTER TABLE refresh_tokens
+    ADD COLUMN device_name VARCHAR(100) NULL AFTER ip_address;
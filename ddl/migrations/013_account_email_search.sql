@@ -0,0 +1,5 @@
+-- 既存環境向けマイグレーション: メールアドレスの前方一致検索用インデックス
+-- 管理者向けの検索はテナントで絞り込んだうえで email LIKE 'prefix%' を範囲検索する
+-- 新規環境は ddl/schema.sql に反映済み
+ALTER TABLE accounts
+    ADD INDEX idx_tenant_email (tenant_id, email);
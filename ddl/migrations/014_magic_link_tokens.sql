@@ -0,0 +1,13 @@
+-- 既存環境向けマイグレーション: パスワード不要のログイン用リンク（マジックリンク）のトークンテーブル
+-- 新規環境は ddl/auth_schema.sql に反映済み
+CREATE TABLE IF NOT EXISTS magic_link_tokens (
+    id VARCHAR(36) PRIMARY KEY,
+    account_id VARCHAR(36) NOT NULL,
+    token_hash VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    consumed_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    UNIQUE INDEX uq_magic_link_tokens_token_hash (token_hash),
+    INDEX idx_account_id_created_at (account_id, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
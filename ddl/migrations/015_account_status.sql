@@ -0,0 +1,4 @@
+-- 既存環境向けマイグレーション: アカウントの状態（管理者による停止）
+-- 新規環境は ddl/schema.sql に反映済み
+ALTER TABLE accounts
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'active' AFTER role;
@@ -0,0 +1,16 @@
+-- 既存環境向けマイグレーション: 招待制のサインアップで使用する招待のテーブル
+-- 新規環境は ddl/auth_schema.sql に反映済み
+CREATE TABLE IF NOT EXISTS invites (
+    id VARCHAR(36) PRIMARY KEY,
+    token_hash VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'user',
+    tenant_id VARCHAR(64) NOT NULL DEFAULT 'default',
+    created_by VARCHAR(36) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    consumed_at TIMESTAMP NULL DEFAULT NULL,
+    consumed_by VARCHAR(36) NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (created_by) REFERENCES accounts(id) ON DELETE CASCADE,
+    FOREIGN KEY (consumed_by) REFERENCES accounts(id) ON DELETE SET NULL,
+    UNIQUE INDEX uq_invites_token_hash (token_hash)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
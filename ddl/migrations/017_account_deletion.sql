@@ -0,0 +1,5 @@
+-- 既存環境向けマイグレーション: 削除の猶予期間（削除予定日時を過ぎたアカウントを定期的に完全削除）
+-- 新規環境は ddl/schema.sql に反映済み
+ALTER TABLE accounts
+    ADD COLUMN deletion_scheduled_at TIMESTAMP NULL AFTER status,
+    ADD INDEX idx_status_deletion_scheduled_at (status, deletion_scheduled_at);
@@ -0,0 +1,5 @@
+-- 既存環境向けマイグレーション: 送信元IPアドレス・User-Agentによるセッションの一括無効化のインデックス
+-- 新規環境は ddl/auth_schema.sql に反映済み
+ALTER TABLE refresh_tokens
+    ADD INDEX idx_ip_address_revoked_at (ip_address, revoked_at),
+    ADD INDEX idx_user_agent_revoked_at (user_agent, revoked_at);
@@ -0,0 +1,4 @@
+-- 既存環境向けマイグレーション: アカウントの楽観的排他制御のバージョン（If-Matchによる条件付き更新）
+-- 新規環境は ddl/schema.sql に反映済み
+ALTER TABLE accounts
+    ADD COLUMN version BIGINT NOT NULL DEFAULT 1 AFTER email_verification_expires_at;
@@ -0,0 +1,13 @@
+-- 既存環境向けマイグレーション: ローテーションするアクセストークンの署名鍵
+-- 新規環境は ddl/auth_schema.sql に反映済み
+CREATE TABLE IF NOT EXISTS signing_keys (
+    id VARCHAR(36) PRIMARY KEY,
+    algorithm VARCHAR(10) NOT NULL,
+    encrypted_secret VARBINARY(255) NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    activates_at TIMESTAMP(6) NOT NULL,
+    rotates_at TIMESTAMP(6) NULL DEFAULT NULL,
+    retires_at TIMESTAMP(6) NULL DEFAULT NULL,
+    INDEX idx_activates_at (activates_at),
+    INDEX idx_retires_at (retires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,10 @@
+-- 任意のマイグレーション: アカウント内のプロジェクト名の一意インデックス（PROJECT_UNIQUE_NAMES=true の場合のみ適用）
+-- アプリケーションの事前チェックだけでは並行したリクエストで同じ名前のプロジェクトが作成されるため、最終的なガードにする
+-- 照合順序（utf8mb4_unicode_ci）により大文字・小文字を区別せずに一意になる
+-- 重複が残っているとインデックスの作成に失敗するため、事前に以下で確認して名前を変更しておく
+--   SELECT account_id, name, COUNT(*) FROM projects GROUP BY account_id, name HAVING COUNT(*) > 1;
+-- 無効に戻す場合はインデックスも削除する（残すとPROJECT_UNIQUE_NAMES=falseでも重複は409になる）
+--   ALTER TABLE projects DROP INDEX uq_projects_account_name;
+-- 任意の設定のため ddl/schema.sql には含めない
+ALTER TABLE projects
+    ADD UNIQUE INDEX uq_projects_account_name (account_id, name);
@@ -0,0 +1,5 @@
+-- 既存環境向けマイグレーション: アカウントの最終ログインの日時と接続元
+-- 新規環境は ddl/schema.sql に反映済み
+ALTER TABLE accounts
+    ADD COLUMN last_login_at TIMESTAMP NULL AFTER version,
+    ADD COLUMN last_login_ip VARCHAR(45) NULL AFTER last_login_at;
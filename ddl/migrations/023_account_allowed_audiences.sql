@@ -0,0 +1,4 @@
+-- 既存環境向けマイグレーション: アカウントごとに要求できるアクセストークンのAudience
+-- 新規環境は ddl/schema.sql に反映済み
+ALTER TABLE accounts
+    ADD COLUMN allowed_audiences TEXT NULL AFTER last_login_ip;
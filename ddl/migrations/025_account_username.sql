@@ -0,0 +1,6 @@
+-- 既存環境向けマイグレーション: ログインに使用できるユーザー名（任意、大文字小文字を区別せずに一意）
+-- 新規環境は ddl/schema.sql に反映済み
+-- 小文字に正規化して保存し、照合順序（utf8mb4_unicode_ci）でも大文字小文字を区別しない
+ALTER TABLE accounts
+    ADD COLUMN username VARCHAR(30) NULL AFTER email,
+    ADD UNIQUE INDEX uq_accounts_username (username);
@@ -1,26 +1,52 @@
 -- accounts table
 CREATE TABLE IF NOT EXISTS accounts (
     id VARCHAR(36) PRIMARY KEY, -- UUID v4
-    email VARCHAR(255) NOT NULL UNIQUE,
+    tenant_id VARCHAR(64) NOT NULL DEFAULT 'default', -- 所属するテナント
+    email VARCHAR(255) NOT NULL,
+    username VARCHAR(30) NULL, -- ログインに使用できるユーザー名（小文字に正規化して保存）
     name VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'user', -- user / admin
+    status VARCHAR(20) NOT NULL DEFAULT 'active', -- active / suspended / pending_deletion
+    deletion_scheduled_at TIMESTAMP NULL, -- 完全に削除する日時（削除の猶予期間中のみ）
     password_hash VARCHAR(255) NOT NULL,
+    display_name VARCHAR(255) NULL,
+    avatar_url VARCHAR(2048) NULL,
+    locale VARCHAR(35) NULL, -- BCP 47
+    timezone VARCHAR(64) NULL, -- IANAタイムゾーン名
+    pending_email VARCHAR(255) NULL, -- 確認待ちの新しいメールアドレス
+    email_verification_token_hash VARCHAR(64) NULL, -- SHA-256
+    email_verification_expires_at TIMESTAMP NULL,
+    version BIGINT NOT NULL DEFAULT 1, -- 楽観的排他制御のバージョン（更新のたびに1増える）
+    last_login_at TIMESTAMP NULL, -- 最後にログイン（トークンのリフレッシュを含む）した日時
+    last_login_ip VARCHAR(45) NULL, -- 最後にログインした接続元のIPアドレス
+    allowed_audiences TEXT NULL, -- ログイン・リフレッシュで要求できるAudience（空白区切り、NULLは既定のAudienceのみ）
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
-    INDEX idx_email (email),
+    UNIQUE INDEX uq_accounts_email (email), -- すべてのテナントで一意（ログインなど認証前の処理はテナントを指定せずに検索する）
+    UNIQUE INDEX uq_accounts_username (username), -- NULLは重複として扱わない
+    INDEX idx_tenant_id (tenant_id),
+    INDEX idx_tenant_email (tenant_id, email), -- テナント内のメールアドレスの前方一致検索
+    INDEX idx_status_deletion_scheduled_at (status, deletion_scheduled_at), -- 猶予期間が過ぎたアカウントの完全削除
     INDEX idx_created_at (created_at)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 -- projects table
 CREATE TABLE IF NOT EXISTS projects (
     id VARCHAR(36) PRIMARY KEY, -- UUID v4
+    tenant_id VARCHAR(64) NOT NULL DEFAULT 'default', -- 所有するアカウントと同じテナント
     account_id VARCHAR(36) NOT NULL, -- UUID v4
     name VARCHAR(255) NOT NULL,
     description TEXT,
     status VARCHAR(50) NOT NULL DEFAULT 'active',
+    created_by VARCHAR(36) NULL, -- 作成したアカウント（削除済みの場合はNULL）
+    updated_by VARCHAR(36) NULL, -- 最後に更新したアカウント（削除済みの場合はNULL）
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by) REFERENCES accounts(id) ON DELETE SET NULL,
+    FOREIGN KEY (updated_by) REFERENCES accounts(id) ON DELETE SET NULL,
     INDEX idx_account_id (account_id),
+    INDEX idx_tenant_id (tenant_id),
     INDEX idx_status (status),
     INDEX idx_created_at (created_at)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -15,19 +15,34 @@ import (
 type ServerInterface interface {
 	// List accounts
 	// (GET /accounts)
-	ListAccounts(ctx echo.Context) error
-	// Delete an account
+	ListAccounts(ctx echo.Context, params ListAccountsParams) error
+	// Create an account without issuing tokens (admin only)
+	// (POST /accounts)
+	CreateAccount(ctx echo.Context) error
+	// Delete an account after a grace period
 	// (DELETE /accounts/{account_id})
 	DeleteAccount(ctx echo.Context, accountId AccountID) error
 	// Get an account by ID
 	// (GET /accounts/{account_id})
 	GetAccount(ctx echo.Context, accountId AccountID) error
+	// Partially update an account
+	// (PATCH /accounts/{account_id})
+	PatchAccount(ctx echo.Context, accountId AccountID) error
 	// Update an account
 	// (PUT /accounts/{account_id})
 	UpdateAccount(ctx echo.Context, accountId AccountID) error
+	// Confirm a pending email change
+	// (POST /accounts/{account_id}/email/confirm)
+	ConfirmEmailChange(ctx echo.Context, accountId AccountID) error
+	// Export all data held about an account (self or admin)
+	// (GET /accounts/{account_id}/export)
+	ExportAccount(ctx echo.Context, accountId AccountID) error
+	// Change the account password
+	// (PUT /accounts/{account_id}/password)
+	ChangePassword(ctx echo.Context, accountId AccountID) error
 	// List projects for an account
 	// (GET /accounts/{account_id}/projects)
-	ListProjects(ctx echo.Context, accountId AccountID) error
+	ListProjects(ctx echo.Context, accountId AccountID, params ListProjectsParams) error
 	// Create a new project
 	// (POST /accounts/{account_id}/projects)
 	CreateProject(ctx echo.Context, accountId AccountID) error
@@ -40,21 +55,75 @@ type ServerInterface interface {
 	// Update a project
 	// (PUT /accounts/{account_id}/projects/{project_id})
 	UpdateProject(ctx echo.Context, accountId AccountID, projectId ProjectID) error
+	// Cancel a pending account deletion (self or admin)
+	// (POST /accounts/{account_id}/restore)
+	RestoreAccount(ctx echo.Context, accountId AccountID) error
+	// List accounts with their project counts (admin only)
+	// (GET /admin/accounts)
+	ListAccountProjectCounts(ctx echo.Context, params ListAccountProjectCountsParams) error
+	// Search accounts by email prefix (admin only)
+	// (GET /admin/accounts/search)
+	SearchAccounts(ctx echo.Context, params SearchAccountsParams) error
+	// Replace the audiences an account may request (admin only)
+	// (PUT /admin/accounts/{account_id}/audiences)
+	UpdateAccountAudiences(ctx echo.Context, accountId AccountID) error
+	// Suspend or reactivate an account (admin only)
+	// (PUT /admin/accounts/{account_id}/status)
+	UpdateAccountStatus(ctx echo.Context, accountId AccountID) error
+	// Create a single-use signup invite (admin only)
+	// (POST /admin/invites)
+	CreateInvite(ctx echo.Context) error
+	// List projects across all accounts (admin only)
+	// (GET /admin/projects)
+	ListAllProjects(ctx echo.Context, params ListAllProjectsParams) error
+	// Revoke every session created from an IP address or user agent (admin only)
+	// (POST /admin/sessions/revoke)
+	RevokeSessionsByOrigin(ctx echo.Context) error
 	// Login with email and password
 	// (POST /auth/login)
 	Login(ctx echo.Context) error
 	// Logout and revoke refresh token
 	// (POST /auth/logout)
 	Logout(ctx echo.Context) error
+	// Revoke every session of the authenticated account
+	// (POST /auth/logout-all)
+	LogoutAll(ctx echo.Context) error
+	// Email a one-time sign-in link
+	// (POST /auth/magic-link)
+	RequestMagicLink(ctx echo.Context) error
+	// Sign in with a one-time link token
+	// (POST /auth/magic-link/verify)
+	VerifyMagicLink(ctx echo.Context) error
+	// Get the authenticated account with its role and permissions
+	// (GET /auth/me)
+	GetCurrentAccount(ctx echo.Context) error
+	// Email a one-time password reset token
+	// (POST /auth/password-reset)
+	RequestPasswordReset(ctx echo.Context) error
+	// Set a new password with a reset token
+	// (POST /auth/password-reset/confirm)
+	ResetPassword(ctx echo.Context) error
 	// Refresh access token using refresh token
 	// (POST /auth/refresh)
 	RefreshToken(ctx echo.Context) error
+	// Get metadata of the current session
+	// (GET /auth/session/current)
+	GetCurrentSession(ctx echo.Context, params GetCurrentSessionParams) error
+	// Revoke a single session by its refresh token or token hash
+	// (DELETE /auth/sessions)
+	RevokeSession(ctx echo.Context) error
 	// Sign up a new account
 	// (POST /auth/signup)
 	SignUp(ctx echo.Context) error
 	// Health check
 	// (GET /health)
 	GetHealth(ctx echo.Context) error
+	// Build and security parameter information
+	// (GET /info)
+	GetInfo(ctx echo.Context) error
+	// Readiness check
+	// (GET /ready)
+	GetReadiness(ctx echo.Context) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -68,8 +137,35 @@ func (w *ServerInterfaceWrapper) ListAccounts(ctx echo.Context) error {
 
 	ctx.Set(BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAccountsParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.ListAccounts(ctx)
+	err = w.Handler.ListAccounts(ctx, params)
+	return err
+}
+
+// CreateAccount converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateAccount(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateAccount(ctx)
 	return err
 }
 
@@ -109,6 +205,24 @@ func (w *ServerInterfaceWrapper) GetAccount(ctx echo.Context) error {
 	return err
 }
 
+// PatchAccount converts echo context to params.
+func (w *ServerInterfaceWrapper) PatchAccount(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "account_id" -------------
+	var accountId AccountID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "account_id", ctx.Param("account_id"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter account_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PatchAccount(ctx, accountId)
+	return err
+}
+
 // UpdateAccount converts echo context to params.
 func (w *ServerInterfaceWrapper) UpdateAccount(ctx echo.Context) error {
 	var err error
@@ -127,6 +241,60 @@ func (w *ServerInterfaceWrapper) UpdateAccount(ctx echo.Context) error {
 	return err
 }
 
+// ConfirmEmailChange converts echo context to params.
+func (w *ServerInterfaceWrapper) ConfirmEmailChange(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "account_id" -------------
+	var accountId AccountID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "account_id", ctx.Param("account_id"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter account_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ConfirmEmailChange(ctx, accountId)
+	return err
+}
+
+// ExportAccount converts echo context to params.
+func (w *ServerInterfaceWrapper) ExportAccount(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "account_id" -------------
+	var accountId AccountID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "account_id", ctx.Param("account_id"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter account_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ExportAccount(ctx, accountId)
+	return err
+}
+
+// ChangePassword converts echo context to params.
+func (w *ServerInterfaceWrapper) ChangePassword(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "account_id" -------------
+	var accountId AccountID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "account_id", ctx.Param("account_id"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter account_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ChangePassword(ctx, accountId)
+	return err
+}
+
 // ListProjects converts echo context to params.
 func (w *ServerInterfaceWrapper) ListProjects(ctx echo.Context) error {
 	var err error
@@ -140,8 +308,38 @@ func (w *ServerInterfaceWrapper) ListProjects(ctx echo.Context) error {
 
 	ctx.Set(BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListProjectsParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// ------------- Optional query parameter "after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "after", ctx.QueryParams(), &params.After)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter after: %s", err))
+	}
+
+	// ------------- Optional query parameter "before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "before", ctx.QueryParams(), &params.Before)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter before: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.ListProjects(ctx, accountId)
+	err = w.Handler.ListProjects(ctx, accountId, params)
 	return err
 }
 
@@ -241,6 +439,212 @@ func (w *ServerInterfaceWrapper) UpdateProject(ctx echo.Context) error {
 	return err
 }
 
+// RestoreAccount converts echo context to params.
+func (w *ServerInterfaceWrapper) RestoreAccount(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "account_id" -------------
+	var accountId AccountID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "account_id", ctx.Param("account_id"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter account_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RestoreAccount(ctx, accountId)
+	return err
+}
+
+// ListAccountProjectCounts converts echo context to params.
+func (w *ServerInterfaceWrapper) ListAccountProjectCounts(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAccountProjectCountsParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// ------------- Optional query parameter "after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "after", ctx.QueryParams(), &params.After)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter after: %s", err))
+	}
+
+	// ------------- Optional query parameter "before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "before", ctx.QueryParams(), &params.Before)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter before: %s", err))
+	}
+
+	// ------------- Optional query parameter "role" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "role", ctx.QueryParams(), &params.Role)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter role: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListAccountProjectCounts(ctx, params)
+	return err
+}
+
+// SearchAccounts converts echo context to params.
+func (w *ServerInterfaceWrapper) SearchAccounts(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchAccountsParams
+	// ------------- Required query parameter "email" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "email", ctx.QueryParams(), &params.Email)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter email: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.SearchAccounts(ctx, params)
+	return err
+}
+
+// UpdateAccountAudiences converts echo context to params.
+func (w *ServerInterfaceWrapper) UpdateAccountAudiences(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "account_id" -------------
+	var accountId AccountID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "account_id", ctx.Param("account_id"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter account_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.UpdateAccountAudiences(ctx, accountId)
+	return err
+}
+
+// UpdateAccountStatus converts echo context to params.
+func (w *ServerInterfaceWrapper) UpdateAccountStatus(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "account_id" -------------
+	var accountId AccountID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "account_id", ctx.Param("account_id"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter account_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.UpdateAccountStatus(ctx, accountId)
+	return err
+}
+
+// CreateInvite converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateInvite(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateInvite(ctx)
+	return err
+}
+
+// ListAllProjects converts echo context to params.
+func (w *ServerInterfaceWrapper) ListAllProjects(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAllProjectsParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// ------------- Optional query parameter "after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "after", ctx.QueryParams(), &params.After)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter after: %s", err))
+	}
+
+	// ------------- Optional query parameter "before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "before", ctx.QueryParams(), &params.Before)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter before: %s", err))
+	}
+
+	// ------------- Optional query parameter "account_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "account_id", ctx.QueryParams(), &params.AccountId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter account_id: %s", err))
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", ctx.QueryParams(), &params.Status)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter status: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListAllProjects(ctx, params)
+	return err
+}
+
+// RevokeSessionsByOrigin converts echo context to params.
+func (w *ServerInterfaceWrapper) RevokeSessionsByOrigin(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RevokeSessionsByOrigin(ctx)
+	return err
+}
+
 // Login converts echo context to params.
 func (w *ServerInterfaceWrapper) Login(ctx echo.Context) error {
 	var err error
@@ -261,6 +665,64 @@ func (w *ServerInterfaceWrapper) Logout(ctx echo.Context) error {
 	return err
 }
 
+// LogoutAll converts echo context to params.
+func (w *ServerInterfaceWrapper) LogoutAll(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.LogoutAll(ctx)
+	return err
+}
+
+// RequestMagicLink converts echo context to params.
+func (w *ServerInterfaceWrapper) RequestMagicLink(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RequestMagicLink(ctx)
+	return err
+}
+
+// VerifyMagicLink converts echo context to params.
+func (w *ServerInterfaceWrapper) VerifyMagicLink(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.VerifyMagicLink(ctx)
+	return err
+}
+
+// GetCurrentAccount converts echo context to params.
+func (w *ServerInterfaceWrapper) GetCurrentAccount(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetCurrentAccount(ctx)
+	return err
+}
+
+// RequestPasswordReset converts echo context to params.
+func (w *ServerInterfaceWrapper) RequestPasswordReset(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RequestPasswordReset(ctx)
+	return err
+}
+
+// ResetPassword converts echo context to params.
+func (w *ServerInterfaceWrapper) ResetPassword(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ResetPassword(ctx)
+	return err
+}
+
 // RefreshToken converts echo context to params.
 func (w *ServerInterfaceWrapper) RefreshToken(ctx echo.Context) error {
 	var err error
@@ -270,6 +732,50 @@ func (w *ServerInterfaceWrapper) RefreshToken(ctx echo.Context) error {
 	return err
 }
 
+// GetCurrentSession converts echo context to params.
+func (w *ServerInterfaceWrapper) GetCurrentSession(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCurrentSessionParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Refresh-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Refresh-Token")]; found {
+		var XRefreshToken string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Refresh-Token, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Refresh-Token", valueList[0], &XRefreshToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Refresh-Token: %s", err))
+		}
+
+		params.XRefreshToken = XRefreshToken
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Refresh-Token is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetCurrentSession(ctx, params)
+	return err
+}
+
+// RevokeSession converts echo context to params.
+func (w *ServerInterfaceWrapper) RevokeSession(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RevokeSession(ctx)
+	return err
+}
+
 // SignUp converts echo context to params.
 func (w *ServerInterfaceWrapper) SignUp(ctx echo.Context) error {
 	var err error
@@ -288,6 +794,26 @@ func (w *ServerInterfaceWrapper) GetHealth(ctx echo.Context) error {
 	return err
 }
 
+// GetInfo converts echo context to params.
+func (w *ServerInterfaceWrapper) GetInfo(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetInfo(ctx)
+	return err
+}
+
+// GetReadiness converts echo context to params.
+func (w *ServerInterfaceWrapper) GetReadiness(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetReadiness(ctx)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -317,18 +843,41 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	}
 
 	router.GET(baseURL+"/accounts", wrapper.ListAccounts)
+	router.POST(baseURL+"/accounts", wrapper.CreateAccount)
 	router.DELETE(baseURL+"/accounts/:account_id", wrapper.DeleteAccount)
 	router.GET(baseURL+"/accounts/:account_id", wrapper.GetAccount)
+	router.PATCH(baseURL+"/accounts/:account_id", wrapper.PatchAccount)
 	router.PUT(baseURL+"/accounts/:account_id", wrapper.UpdateAccount)
+	router.POST(baseURL+"/accounts/:account_id/email/confirm", wrapper.ConfirmEmailChange)
+	router.GET(baseURL+"/accounts/:account_id/export", wrapper.ExportAccount)
+	router.PUT(baseURL+"/accounts/:account_id/password", wrapper.ChangePassword)
 	router.GET(baseURL+"/accounts/:account_id/projects", wrapper.ListProjects)
 	router.POST(baseURL+"/accounts/:account_id/projects", wrapper.CreateProject)
 	router.DELETE(baseURL+"/accounts/:account_id/projects/:project_id", wrapper.DeleteProject)
 	router.GET(baseURL+"/accounts/:account_id/projects/:project_id", wrapper.GetProject)
 	router.PUT(baseURL+"/accounts/:account_id/projects/:project_id", wrapper.UpdateProject)
+	router.POST(baseURL+"/accounts/:account_id/restore", wrapper.RestoreAccount)
+	router.GET(baseURL+"/admin/accounts", wrapper.ListAccountProjectCounts)
+	router.GET(baseURL+"/admin/accounts/search", wrapper.SearchAccounts)
+	router.PUT(baseURL+"/admin/accounts/:account_id/audiences", wrapper.UpdateAccountAudiences)
+	router.PUT(baseURL+"/admin/accounts/:account_id/status", wrapper.UpdateAccountStatus)
+	router.POST(baseURL+"/admin/invites", wrapper.CreateInvite)
+	router.GET(baseURL+"/admin/projects", wrapper.ListAllProjects)
+	router.POST(baseURL+"/admin/sessions/revoke", wrapper.RevokeSessionsByOrigin)
 	router.POST(baseURL+"/auth/login", wrapper.Login)
 	router.POST(baseURL+"/auth/logout", wrapper.Logout)
+	router.POST(baseURL+"/auth/logout-all", wrapper.LogoutAll)
+	router.POST(baseURL+"/auth/magic-link", wrapper.RequestMagicLink)
+	router.POST(baseURL+"/auth/magic-link/verify", wrapper.VerifyMagicLink)
+	router.GET(baseURL+"/auth/me", wrapper.GetCurrentAccount)
+	router.POST(baseURL+"/auth/password-reset", wrapper.RequestPasswordReset)
+	router.POST(baseURL+"/auth/password-reset/confirm", wrapper.ResetPassword)
 	router.POST(baseURL+"/auth/refresh", wrapper.RefreshToken)
+	router.GET(baseURL+"/auth/session/current", wrapper.GetCurrentSession)
+	router.DELETE(baseURL+"/auth/sessions", wrapper.RevokeSession)
 	router.POST(baseURL+"/auth/signup", wrapper.SignUp)
 	router.GET(baseURL+"/health", wrapper.GetHealth)
+	router.GET(baseURL+"/info", wrapper.GetInfo)
+	router.GET(baseURL+"/ready", wrapper.GetReadiness)
 
 }
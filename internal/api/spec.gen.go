@@ -18,36 +18,187 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9RabVPjOBL+KyrdfTRxAoFl8ul42Z0LNbdH8XJ7VRRFCbsTa7AljyQzk6Hy37ck+TWR",
-	"SQJJyHyLY6m79Tyt7pbaLzjgScoZMCXx4AWnRJAEFAjzdBIEPGNqeK4fQpCBoKminOFB8QoNz7GHqf4n",
-	"JSrCHmYkATzAxL5/oCH2sIBvGRUQ4oESGXhYBhEkRAsdcZEQhQc4y8xINUn1bKkEZWM8nXr4UvCvEDht",
-	"yF+12pDa9++1Yaony5QzCQaVUxJewbcMpNJPAWcKmPlJ0jSmAdHW+V+lNvGlpuafAkZ4gP/hV4j79q30",
-	"fxeCC6uqucRTEiKRK5t6+IyzUUyDLSguNKHvVEUIflCpKBsjAZJnIgBtzJApEIzE1yCeQVhJG7erUIqk",
-	"0YrADvTwn1z9wTMWbt6EqxwDxLhCI6Nz6uFbRjIVcUF/whZsaGjTr/MZtV1rtrPgKQhFreMGAoiC8IGo",
-	"htuHRMGeognM+76HISE01sPhB0nSWL/MJIh/5Y+dgCfYq2TZ4Q45NGwK6e0fQP/w6Lc9OP70uNfbDw/2",
-	"SP/waK+/f3TU6/d+63e73bpg994stnpd8gWPGDrnztVkabgiAtN65LjDxohikUa3V4e1oeG+FMYfdRzS",
-	"BpxkKrrKg8k8QSQIQMoHxZ+ANRcFk4vo8XNA/0svhrc/h70/6VAO2dVhcDY8Gj6l///f2cWnTqfjWjOp",
-	"3OE1Vyu8RnP+I6UC5ANlzrgPUiJjIjIDjV8jjR6iDEkIOAulRqkw/uCo2y3tokzBGMyOFTASIKM1L9dI",
-	"e7B/10WeAhEgFhLcoGDWxob0Bk4VzC7Wz4yH5Omqljua7DeArpt+E1GJqEQESfMXyhPbcvvhPxN02T5e",
-	"KqIyox5YllgEFH0Gk1HLn0QEEX2GUK+ukly+fh1SY5ILljJlNHGA4u9KkxmJEpCSjBcrtAJcGr/wMWWt",
-	"BKwr1qVEyu9czES84t/e/kFdSjl44apydeWElgXyrN3FNrHlZsxsqnDZWHijK/wVNeOquaK3TK54S/5b",
-	"y6bcXvLb/mZfV1JtHBjyzJrbu1qKvbIOeKP9b7c3wjUds9t04+FotRLprcEroewLsLGK8OB45VDmtSeJ",
-	"W8N2Xp3sFFbTVmvfn+kZyr28CCuoPmfZUHCby9h+/p8BRiuCIBNUTa51vZkfpE1Jpkti/fRonv4oKLr4",
-	"6wbnxxot6XGmfIuUSu3BiLIRn69Tr36/vhllMTq5HKKRrh0II2N9gs3jjMa4BFeHGEWV5fuvG6RN0jOx",
-	"h59BSCux1+l2uhoyngIjKcUDfNDpdg6MH6vIrMgvpOuHMRjqNfGmRh6GeIC/UKlOikEzFwv73e5KJ0eq",
-	"IJEr1PU5dEQIMnEdKrVtiI9KiPScQ2uTS0Npu++6Cahzjgd3Tbbv7qf3HpZZkhAxKTSTChZFxlL7YonU",
-	"vRZXouu/VNliarmPQcE82ufm/wICr3G9dedeVTXEr66/tLUzVPXb78SsNSGSmTlMjLI4nmgs+3bS61iW",
-	"9xhbA9+CpGMOKYFyEOC5HfozqI3g213bJUq5A+Y9vmJMERrLHSbpM6gaQ+hxYm89nTylmYOnRh59N1Um",
-	"sZ3ycLI2lpx5ftqsHJTIYPqxnlKk5fm9vYQX1G6O3+Jp/e6nxRPKK+KtuaZlbmH8aA3gfpmFX0ual1Wq",
-	"/rAos1TCLYqtFRJuCcDuxh9jamGnqaicfJcsmTjEpYPMxmXYDgYi52XdUoGotzYbSh+a95mi6ZUfiT8k",
-	"EG3H5SwRiCAG3+u3K/Outji0+C9VM3CJinEN3uktHFx1NpcrLy/Lk+AvWV6+TmF7dfnxXHS3ua9/kVK0",
-	"vJeYrUSbGaC9Ev0QWjdVtr4lW2zVqz6ybN1uFbpEpshU5Md8bNub7iLFNIrwZlym0YTa9gmn3n92VaTa",
-	"tpqXWMJ7i/lrfpCwPtKbRaixzn6RkhAa2zu8WgOtOHVoD2mSzW0kamVbv98Y3bWW3FJ8O5K/lbJLzCw4",
-	"L1h7NUECnvkToLwfgspWegtZ+bh2tuoNng1x5uoh7dhONbYVoDoj+67s2hxMROqfjmTSfk62nE9IOmZZ",
-	"2u4StpO2IWdotum2fBJc5AbFvdRaj4MfdM3U8BqNOsrS/PjnuFmqXCQCEtseUtsZ4t92xDu3a7OJV+uc",
-	"lR0x/rRUN2yORQ0KDQBRiexiJhabEg27ABREEDzVQMjXdT+1SGpkbRHdFH8OzxDzNAGm8u8msYczEed9",
-	"tIHvxzwgccSlGhx3j7s+San/3DMV9lw9GWaB+eLLIUgOfD21U+u6lqLuS6tnZdbXhoCFKae2C5R/xpsv",
-	"ct4Y7QLAVE6Ya6pxEsfEfNOYpiAYWFyTqzZY21H8dQFl3Tm9n/4dAAD//9KHjHdpLQAA",
+	"H4sIAAAAAAAC/+y9e3cbN5Io/lXw42/viTxLUtTDjh9nzxlFUhJm/NBK8iQ7w1wa7C6SiJoAB0BL5szx",
+	"d7+n8Go0iSYp2VI0Wf9li41uFICqQr3rX61MzOaCA9eq9fJfrSnQHKT57+klneC/OahMsrlmgrdetn6k",
+	"akrEmOgpEAm6lBxyImEuQQHXFEeRnbGQhGaZKLlWbZKDZNeQk7EUM/Oee/SNItcgFRP8SZdcAM8J02RE",
+	"syvCOOmPO28Fh84bqrMp0YJIyIBdAznoHZK3QpM3ImdjBjm5mbICHDxKlDIDwhQpeTalfAJ5mwjpPmi/",
+	"dTMFTsp5TjXjE0K5BwcnyUFDpkkmeFZKCVyTmZkmMwtT3Va7pbIpzChujF7MofWypbRkfNL69Kndei3s",
+	"wNVtO6M6bFsmgWrIA7htAt1Jl+zSOdu93tv1G7f7L/e/Ics/4SLWDtidS/EbZPir+x/+ugngMzqB12zG",
+	"dAriCRDF/onHpUtaFAtC5/MCd1wLs46CKd0mdKxBmr9zGNOy0Lj7Y1YUkOO2U54TSgqcg9CRuLYnNaMf",
+	"2ayc4dCsoLM55ElIGdcwAdn6hLDOqaQz0A47j+zS+yerkLtHpH/SarcY/jKnetpqtzid4VerXWu1WxL+",
+	"UTIJeeulliXEMIyFnFHdetkqSzMysXt2o1MwuEeNMFRn9FkwfMKX1VxwBfGufC/kiOU5GETMBNfAzQmb",
+	"A7QouvubsnhaTfYfEsatl63/f7diCbv2qdo9lVK4c0hv9pQqMgLgRJVqDjyH3NCdIvgHEloOBeA7hh4t",
+	"tf6jBIWEQMucAbd0y4UmtCjEDTIMN9IT6E4mcgijh1zooRv6pPWp7UF5LbIryB9u5UwRDbO5kFSyYkEK",
+	"M70jCwlzS+tjypAgCjFhXL2y5COyK5KLclSAIoIToNnUvUDKORIZJRmdt9oxUz4HLRedI/z4KtJdQCZ4",
+	"jrxPs6KagykioQCqIN9AZNUmXvhT/F0xaLQw/DmfMc6UllTjF9qt72h+bpHn/qH7juYeU3HqY8HHBcse",
+	"YGI/E7lhekrgI1PmvvKXBgLzgGTe56ocj1nGgGsyBzljCi9uhWD0uQbJaXEB8hqk/cQDAGQnJcrMSsAO",
+	"bLfeCv29KPkDIO65FzeQZ43NnHZ+L5qsUmh4BZEdX3NCClEM+R+SLIpdZMKuga+IQXVW4OWzFOxu2K4Z",
+	"Y0C/YBNezk+YoqPiIaj6AopxB8+GZUCUmRwZUe4AQIanp/gDzAuxmCFa7XjBhlBZSUmjRZ0BKMPrL4V4",
+	"Q/nCsQF1/+u5FILMKF94ZqC8OIsiDC0KkF3iobHw41IgR2IhWpbmojs665MrWJCdXzpHZ/3OX2DxpD3g",
+	"OMJfcXjlhRkM5VNyTQuW4whQimhxBbxtxCp8LysMRdI8l/hU6CnIG6agO+B3uDi0IDcUhXAYC2nke7lA",
+	"QWPtrdFu/dI5p9rKkZ0GabLaGne3M25lYSdm3zCeixuys7RTiszogkzpNRBKpmwyBWllySe3gekcZpRx",
+	"XEgzXNKPSUO2+eJ8z2mpp0Kyfz4EedVmM7Orcj4XUkP+BnJGLw2ID3BH4dc7OFsQ3pamQXEv/u1j5+bm",
+	"poOCbaeUBXCU6XJzZG66SI7F/86lmIPUzAq4Dn2GXghUCfHfP6rJjohHjrCQ9xhBzFCRhLEENSU7Emje",
+	"EbxYvApceZX3dMnRSBm0QC0SR9dUn1iSdaCiYgMf6WxeQOvl31sjVhSMTzp0zlq/tltMw0wldLN2C8F5",
+	"x4uFVwrcAColXeBzek01lcNSFvh6mKE11XquXu7uul+6mZjt2rHduSHlSqeQbFWlaLcc4x1SXdNAcqqh",
+	"o9kMUu944X6IZ5iXRXi9fjQ/46ZFGveybkBuWFGQEZB5KSdGWt1yeqbmBV0MrXIV78ZPYsr5IvUO0nux",
+	"CmK/gg71W2U5PGLPCNVWZWR7RT789qc//enP0R5/wJvMLUdwcnR8/O7928vh6/7F5fD0zVH/9fDN0cVf",
+	"+m9/MEhnGIy5Nb5RRIoCrEowLosi8HKmKgOLwbYx6GyK36coLkyKgNw1FGuVCmQMWbyLdtGJ3WB5fd/2",
+	"9g/g8Omzbzvw/MWos7efH3To4dNnncP9Z8/2Dve+Pez1eq32Jv203Sqo0kNDbEmEeE2VJqo0V9u4LCxZ",
+	"tsmMTljWKRi/sr8YlREvviS1ut9AVRYmRWcQNpKaqywTElUKiiYdwwMyvFNmjJcaKrKuVKdwIzOpNIKh",
+	"COPdJpRsoNbkRrD56kYc23u8f1Zd5dZYVFA7O+PRmp90ybsZ09qZWGr4alDFnLNducNZr0s7WaWGMfu9",
+	"g26vu7d30N3rbbUWkdECVhfx3fEZOfyWFJRPSrQeaTqpzfMb7fx0lsKSNN2SE5GkdkdmwwYKfgs3dv0V",
+	"AqBQg4STCT5meHo4MoaMw82tSSbWglaAOB2PIdNor4yGkYmk3Mm0eBZI+PGp1m8Kd6wv8XmrHf68kUxD",
+	"q+0tSP6x/9M+/qybBcHCF4GXMwQEGQoCgDdh69cIRv9kZQalqS4TuxKsCqQS9Xn0x8p9kFGOMkUhjFgm",
+	"pKd1yw1Uqx2ApGa3W+1WsB60Kkzx36tDH15ZgV8Dp9ZAuLKES/OoxiFGUAg+MdJzw2G2nHywDXEhQ/mn",
+	"4Any6h+9PSL4mOBzYogmnuRIMbp7Ka4WIrUmY/G+5bWO5+tpc0n+dE+InlKNx4TXY6kMQ1IaaO75l6VE",
+	"LdwZtonSQpphBMUjmVEFdaEKD1yB/rIs7jcx5cM8zU+cEyIlC2QSZmBIVnAC1yAX1nPg7uvKkWG0dqbq",
+	"vg6mmxDiIGIwjOtnh82YESs1laH47y173wZEbQdG5fDCUHEgxWqVNQGvhha/hjnFCHlJZFM9/Yi6RUIc",
+	"r+T0dQqE+wp+ED5aLeVWeOh9G/hG4GvrJnTm99an8LHA3RRkpWR6MYRr7/RaUYbNACPMa2KHeXR2C24T",
+	"DjegnHTQam8Hlf/yKX5yFbalA453KrD/VrQZq2tZc4JvQE7gzFiRVlb808W7t8QMIGYELraS0l8RjmJp",
+	"VgCVilAyl2KMHrcxg8JoN0soUdNLllQzTlA92VFPyPvz13UK3ai3IBRoO2rknFtoARu/EWSKz5SnbyEi",
+	"dZMy0kZIbycz3epW6TZfKxvA+tSMgI4kjxv0+lszEu9BC+8tSYHlbAQSMdkNVETc8Er2qugpZsobeO4K",
+	"EbrZf91u2a+ZSiw9sI6teEhqNxNcrvBmuLC6/d7q8totDh/1MCulEgmz4LH5PdyrOJbsiCIH+YTM6QRe",
+	"EeGuaMErZQWfpFBQjMcK6jAlQZpLuN4WJBzLRKnIDvLjJrCsCtcElxaaJnjVJf5MeECjIKTOqFPD7acL",
+	"DVLFaHS4v/nuNiftp/anFbYoiU6lnp47V2+SfECpoZGK60wBFj9NRz9k7B37qf/+n/29t6yv+vz8aXbc",
+	"f9a/mv/y1+OfXnS73dTG3OlyZxLUkPGkVz7Yr4kZaINFDOthnChrg64R5LNeEkOcEvCFl2u+NtTOcFp9",
+	"8jugMqXmrPKG6giWYax9vbZP1TanTv27khV5n4/F6pFnYpa0tP/ANLHPDIKOGKdyQW7QuVqyQhvJtMbf",
+	"D8b72R59kdqSiRhGwnH1ykTsdfcPu4epd+ZUqRsh8+GUqqmzua8V1dz4H+1ws9i6UB5ZpbqH3d7Gk4gk",
+	"XbtHtYUkIEzt/LExAXvgInfz0ilYL8HQf7Mm04YfU9c33Gx8aUY/vgY+0dPWy2e9dmvGuP/z+aY9WIFr",
+	"acbkkq115BRlGrv8xmUHylsPhR2WnMvoII51NE7zpaSxW9qXomOp3jDCe0CIvf2D/y+eOj61dcdUWVe8",
+	"SSBYUZrMLeu32K85PmhcbfOm9/k1081H2wjfkuMMbVd1pSi4bI3fEh8wM9X2a9vG8rLdnK+8S0b5aLWa",
+	"I+cbRexMra1EWLtxTuZq3LkauP9KuMqkKNC1JGmmQTpnLRpQOGqTBeNgfQN05EzWM3EN+atgsj47f/fT",
+	"6fHl8OT04vi8f3bZf/d2+Obol+Hr07c/XP4Yf3nHLZ7s93q9uiXqEt0Z6J1CG/m8AC8eb0c2bxbkrHl8",
+	"ZfZbscoxHv5LZTZFG8l2xrgldG/E7ROq6YgqOBOiuNA0pdj7IWgG5pDhr2QuREEQbqY0y1QlOpa8MOJK",
+	"sCeZTXPBSD6EzceefJwLZf2NM0Q3ayiyr63oxywv6pv6NCXiMD4sVX3cQWrcjH4c4heHWSFUKuLkOKxV",
+	"ETuGjCCjpQrUi6/HW+KF0VhKXzFWrYEEBbq7gKOnsMCjWEBuYdICrYZ8cjdYCjaGzwJFAkVnJv7BZIhY",
+	"9Z+Ngdrb3xoqMQc+rDY7gaVv3ESV5oHvRAekyE6PzIByhUiKh4XxjxE4+0mM2jzzqdJ0VDCFi44GtslI",
+	"6CmK6KWyHMqgcDTh89R86HNpUs6XdSvcUGRJ/yjByKpMO3MuJWMJMXbeHhcMHHlptY3hTDVBY/QQNTdm",
+	"aOcuSkLQxp2YoRc/obFsA9ISR0tiReK4Ak9ot9z+RzucWOYqb2ig0TS5pHhsCO1b1kRySOExasnQkUBz",
+	"tBjZCD2Cg18Rg2l4iUuhQnRq3Z1jY7RtNG2lJZmgXxtrV/9txdVTPV7zKHYWpeKKvVl9mAsMD0o+MqGZ",
+	"ygqDLhwTzyoTUqKFKBLMmItZHJqtMD+Y2K5hJiEHrhktVPSrF+383yyP//Cilf/Bmf39n3M6Ydw7Oqsf",
+	"neYjQYEOuml4bC270S8+8jX6RdQGBPeC/2H5m8GFVP10DTKkVITxM9BTkS9tcHzYEeylRVtvgzM8cAgf",
+	"M4C89gDnrY4n/Bp91DAcQw2ixFVKqmHoeGqr3VJgHLe1V1xM47Dk9Joyawxtt2yE49CHNwaFHxVeKWZM",
+	"Rb9Z7R//LuMoLvwzBHENZ5Az6u0FfhOrxThtdpi5KOG6MJXGq1Vjt6fnpRSfckZ5RbczUMpY1TAIxkZG",
+	"kRHoGwBeo9wwu2ET/rXUvA6rklJ+/6TKLjKjUKD6Ryk0WLefBNwgb34zK3hl47AU2PDJOCBYkZ2nHz8+",
+	"aZObqVBActDBaViIiQm8NaM7iuUQuy595M2ypYS+yPag8+3oMO8cwj7tvKBP9zq97Fm+D8/He6NvaXq9",
+	"Wi6GJiNg6C+N28ZgLi0yRlRzJ6fRshIeexsvIs+UDDtPsX+rOCb4/x0CxrwF7DbvsHyLlJjN4Qvrdc60",
+	"WTDlG8bNcOZMLQiyJ/zXMoJXVeiWOTaDupV2avKe7K5t1HhYYByVU7fm9q12subaTZ3ga4wjatRf/QWY",
+	"WKxSJXi1u7LiWis86kXuTWIDsmalQvz1kbSVl3w1ejMmrzg0MhljaBA8HY/wmo6giDwVN8Tx7rohgBJV",
+	"zmZoEHVMBuMYOkcTqHuCUHj5Toirug1ur9dLgNUQhXQaRyC9Ilcwt4SLFwLVbMQKphdtI1Izw6PHDKTn",
+	"QF8ins9/cwNoqMr664Xs+P+pCG3DUxuFxhQBbi65J11y6awv1OjGBhr7kjFCc00ZV+SbP3/TJZf0ChSZ",
+	"S8ggt6himDS+sX2kRtoyN69scg0mufUUttYo+lpMRKmPiqLZ+SLhWlxBPnQYp9Y5I/0YGzdzA4bDm9dv",
+	"54lcmbMZ9mZL3z24UVbAjKdIwfgGQz1fM351z0bgpNk0BVDKH5EIQ58IyfR0VodrlMnFPGkay4RKxUML",
+	"eUXGNNNCBrum/zLZsV8j+GpNv9873OyoDvC5qdet9BwU6Eez/c682BQhMLxDpPLeNpHKd5Fi/DujRXMW",
+	"sg2QswOdz9obUDfCtGRRvpsV975Cux+DdfgWDgNxY7J7Qi7x50dq3imicp5vhzEmlGLmaxzcCm82BQrW",
+	"8t+dbh70+NsEB7rD/hJhLWsC9r6GsnzpUJYQEfX7hLKcA80ZB6XOIR1Vmk0hu9oedzDV9BhfOQeFpJvA",
+	"IXTzbPrMqgfJxcovagddYwYjIQqgPCH34Gttv5L0LhjR6BIloz+kSoZ5GEVNLQsqWZxfaYcwZVUkm1Zn",
+	"cf6uGtmjkGuNULUxfORLR4E0misuLXb4/Cj/eSIRTLKd0OaNEBsDSc6NcnJhT3t79WM5MzfK9PCXuMMg",
+	"Ww8IJ0kKGnbQBvPiyqfaqMUGs81oUcNSPxp4PheMbyW9WWsvBhklrH0/HnX2nz4jU/hIprWSStGqawTw",
+	"Yvz8Wd57vvf8+WH2bf7s6Qu6PwZKe9nTpzTv7T2lB6Px4XhvtD/qjZ7v72f53tP8Wbb3dNQb93q093y7",
+	"SIfa0anvFu8kW2cyYvOhsyMktrrKZYv2W1ml1wvEK9FoVS7at00ZKUM6gZRX8fQjzbQxVxAzYs20GC3y",
+	"eRuyyRiwMSw5AJbW/2/rRqzPm6LKevbBF7HiLuknK89NWkIipPL1ux/6b4ffH/Vfn558hqW3jn0rj2eg",
+	"aU61SW+nec4QTFqcRau2d/kSFiHM3lPwyrEfJFGwyWo2akdBJkGrOFCnldjzOrpuIadHO1YHbKNtd1kK",
+	"Wjlg75BJcFqqBA+3NJYqKmUkegbjtZFmzN2xdDmbBEcUI9EuqF7a/N0h5u8OQ6LeFvqjfT01VlzVRo5p",
+	"oTYLYU61EVcN+2Xor8G+M1KiKDUM636KJX3NDbLRzIvliyU4t1128tbZ7HVKTGTQr1wUJryXKVXeJmd+",
+	"szndLciOJFNR5F5XcGusIcHxVIoZoKIyo9m7i81en61W5l7ZelmpS98dNemfvLJ+Gqbxrq/khEoIWFrd",
+	"LVnQmgtwyQiU2sClNOx0Cnmpbo8Z+CJxTvPtEz+brtn38QXbuKw1nxwyR3PrtDGcxfhlbEB4ilfWTBU1",
+	"N1iKfJM8gE34+/m9hx9bp99wG0+ikdCXa569In7tlher9bWV7isCerOfZevQ50QJHshdIYyp4CZDuPJA",
+	"MVNkzyTvT0pp3U1mD6jC1eOi21Xpo1pBFrc7+GX8hn0B8i7pT+wsdkPZxBgHy7mrucSj0OWN2e7NWdLv",
+	"5lbmqHxoeMli5IEtNOSc+qjBz8NN2+hv65JjdB4i1BlV0GFcAVcMLaPFwgYBpHKrD3s9uypTPXE5Lsft",
+	"sP0VnYEei9rksPcienEpBsW+p+kV8Eb/XaS5HtQ11wPEMa1B4i79378fdf5GO//sdV4Mu51f//M/Wl8y",
+	"6P29sWE622qoDbTR4nKXwkKvsBatBjWnq7UscSMZt9W8SmiuCYQLnQup1UqFoGg3958+TeDhjH7s28H7",
+	"vWUT2LJ/KKxy45Y179T2ib7kiBOYzfWCWGh9MjHuo9mPW6YCb0z9XYHmFrNvUTLoAZODb7l1X6jEyu3S",
+	"hW8J47o6FZ82oeOFcVQ0IuUaJ1MV61lzLVU/b+I67tvNFPPHShBxRbIh9/4CUtdHt3MIvnffePi0kdVD",
+	"qomUq9QnxY0C2SbvLsw2O7VHm9p2fAxSxjXMJb2JTE1d8j2DIveCviiL3HD/UfQqHplTqVerJozs5PXt",
+	"sxpVasvc8GFj6ZI39DchfXl1r8j5Sdo1b/Bhs3YYn0kO6kqLOV7rYmSjhI2+jkc6EjoZWSdUfUENimHq",
+	"sP4Kko0Xm6NDHmlU2HZmeJQ7O4zfyv6+yn6ioiYXqD3ZjbFpy5g2bvDL/PW9v5p++vnSV7Q0FpSlFGe8",
+	"gG29R5YklfPTi0ss14ZVOnF3Z5TTSeRdt5Yy72bskiAH+2LltniIrXAqSu3FophGql0y5UnsYomkFU8M",
+	"tnqqTImSV4Qu3UNMER1rp6YqHMpl7loil2wGStPZ3FryaHFDF5ETgHHy/vIYXzn//pgcHBy8cF9WxFWq",
+	"Ypx8+NsH2/HAIQr5sN/bP+z09jq9/cvewcve4cve0799eNImEiZU5kVU3c3FKof71HiemLb388+XBI8P",
+	"dzmq34NZ0L1uz6f0oJT4soWGgwMjEOupOf3QJAD/mECyIisu0hR0QVkjqnBQL27TJUcr5fsrVWzAfWLU",
+	"Tq3kobl2+m/6l0+iGv9Ia2z5DPFYIX814LalgJloueEAq58Jjvylgw0KbLlZYuvc2pq3yBxMakE/Rw7A",
+	"lD7yW1HvIfD3zZlXAZ21cADUGUdtySen3x+9f31pl0129ntPbLJUbfnpTSI7e/YaZgiHSUqoWgV4j3pV",
+	"qxVNAzPkynspt0CzyyFejrpi84YJnes+njEkA/fa1eypkPJflxoS7Pd6typDe5saKIkKSisVavH846XX",
+	"iyLHSLSplHbVKsNMc9jrNb0RNmA3KlT/qd16us0rqWLqMYc3eBvz9r//ipvuLjC/4mi5mk4Q2VuBCn41",
+	"8RD2Lq0TSy0pvxXyNL4T+eJWh7ju7JKJ/5/qV52WJXxaQaS9LwZDwJ/mZgTeqFnVJi0WddyJe72sw5sw",
+	"7q5oc9jb2/zKcj3ow97B5peq/gHmjReb3wjtDx4MnS2+xEWDvcyA/g7jjzAeJrLjMrNdRF8C7T+1W+ne",
+	"OZbDFaATIuSFK2hcN/rgde/TCbvkMnoC3ChYOHg575BYRWfA8W1/EZycvj41itoP50fHp8Oz0/P+uxOy",
+	"c9AjOYoio4W/cJ68jIpW14vhWmXQXqQDjs9pUVQOZloFmbfJqNTOQFqV2EQ1JaM8A9O0J1RSkGDsiSHm",
+	"oTvgR6HZz0TSDJcomchrW2PuPK2qIDMqfT1nXA01HZ4mErPpyG9ilLq0T8xZVHxo6dZOYVw1ZLfqDJS4",
+	"jQ6bwy6rY3JH7gjpcDOWh54Tj5eO7J7GdGQ71NDaSTbdF06CrB/TD6Dv5Yx6D8nonaP/c1prHGyJIqEt",
+	"yF3Q6mGw5AfQMYqMFraBVVqGSBeTfOdr4jsVz3WG83K7z+ociXxh+2TYWvvdAR/wn5H11JoKxGc/AzmB",
+	"jpn2PxEPyA5qZd8evHj2pI1Qw0ccy/SArylYSXZiW3GbVFbsNrF22faAe/OnleBxQ2x9WvsFpkgBY121",
+	"mOv6etM8NwbRAXd1i0dgFNMuMQtbxuO2eRgrqVS5mcxuhI51TAWnGFXm7jl7f/kSzRaaFq5jjHTqXM1p",
+	"M+DLecIpdmtqfn4pSv7yEmPSG4FYvQ43bs0jovqoW0mjD8qkvCG2Jo3eVZr8Y9xnZ1RiXnvhCzJHbKuR",
+	"YZU6FRvi2jFEVJRoPRc3kNTCz8m0kTcHnI0JF4alQaFsdyffQ4Rp19yJaROMIYHmXRKiNWjV/WzATfuz",
+	"kPThOOYMqGFIyw7ZZdImTA14WIDvMkGrLnXLTOtnJ0hXC5vCgPulqWB9KXkmuA+fKxYpFlKj0X8fHvKV",
+	"zh89nb/fjrobdbtdczXvus4PCPA8mYp5bEpF1hS3pS4SpfLRb1b7MhKBsWiiYhQrXEsl6iL9iwjuDzdF",
+	"RqsVGB8hLTWXiXw8BFVPcXcc7385JblzI3QJvzOPaLcjq9ARYK13IaYDJ4e3l8wDzl7g1+EL7lNFBHfO",
+	"n1xk5Qy4tqaTnGpKcHZqqyiYT6jSOohcNpRDfNUlPgfHxXC3vb0oHcvN4dqUYciKMjdKCdp2/PR4KSot",
+	"gc6M151oWfLMNinEq9/WZMMV283xTV/nVOLVj1qRFOVkmqJ822Dh30OdtrCuVarxhByG1BRrr9udMDUX",
+	"iqUjIajWNJvihr/CpERAleq/Bj5ttROjYRcXMGit7XL96SFtqI9Sq7cHZoyC5mSmqMXSkXG8Vsr+DoZU",
+	"mm59aEi9rQ11N44ZTUraxqPPQNXyqvxbloYNFRo/PfpCLfElRxq5WChNJGTmoU+e9aPUgO+cHV1c/Pzu",
+	"/GT4Y//i8t35/wwv+n87fUIq3dxWEvtyt3etZPRjvLmTNa23urUPUw3i3YF87vV6J9J8lIRmN7h+6VXo",
+	"cDtyilreJK2v6Oc784M+A9fam93g4a4ObvCtXdXBcYwhqS5UwMXM3NWLHUNzT17sdlOuPoakRHUGXgWz",
+	"W1SgQVWti20JN6a75DgwnUzMRox7P4sbYuq/OHhTizG2+rW33FqQozoEG0A2E62F2I7YBLBd11qI71NS",
+	"iStTJOSUMxfxsr41zAOpCw8YFhDWayrbplTqwFHiKIG0aF+3RqWLcVa5g+E6LUxthAFHGxk+mDWyG2cl",
+	"81Gt79/2//v96fDt0ZvTC2PrcmkR7QZI4mSFWgBR7X4fcAeRMdpR/3blBTVBY7Y6WtacfvEkKRDEZeMf",
+	"ozyQKmv/wLEYfndSVOqO4lHFYvxBbAEuqsLI2lHtqFUusFlC2f2Xp7iVUIqUW/8LkEN742A3ydYxAGch",
+	"wr2AFVR7vEzdO/XXH2Gz+/73P4veQzKSr77+FV9/uO+WXf11QaBsSjhuuJxJuJslcDqzTcv9VK4hi7lT",
+	"xRgDlqwdzj/3sdH4PBgn1t293u2lNksCzc6r34UW7svTdZc7/UFJ8aunq9HTddf72IXrNbu4QsQ/j93a",
+	"ayIVTW6M7WIeVFkkbhvN1x3wi6UaNpW4H75kXd4+w1tpuvCDU6R4btfw7x9W5g4jbz1i4/NjlU1NJGrk",
+	"pqLLAZrbW6rx+eekwhAtJmAup6APJsxiQLFn8g3H28VDEMKUa3Gw3rFkP0eJXZgYk153wN/arJswdyZm",
+	"4HNw0NgaW55MqJixxOwIGdt3BpwqR61PbF16bNewIO61qMWBtd9sSJqJ+8x+kQSa39Ny+AD5L3ezHFZH",
+	"/m9jOVwB+QEth+1kzKmFrgLMUSxTJPSsWZ3NParm2rYL4wPcLyuto9dYMuur9pK0/a31qPNOfoesqMDM",
+	"mVzaqsYsEosGiUtlVwFmpm++W8LcphONDb1QmsoIHDJh18CR5sbsY5sImYO0Zmkz3DlE7WPCXPleyEnB",
+	"NEgTC7nz4f98MA7SD8MPNp5BoCmzyDMqc2XDmVcVqNQdcGGWtW3q5JmFyWlu9bgpZLbmY2h1ruVT04Jl",
+	"8OcGyvQ50XW1pZYGuVQQpV5fZiPTeBS31WPLlzzaBk0tBn5lK55KKsTxpOqJ9PbspKbS1YoRJY0w5zAv",
+	"aChJtKlAERE8SiJz6WMotPq8+YIpV7JIVaWpfHFpFaKiHbaHCa3cGw20tQgJUFkwkFbtsxWmSq5ZYRts",
+	"2uJsG8OJj6LK1I86rniluNTjCzCutBi4CaWpKix75Amqj9KM6ihwiQApT9LfZ/KDqlJOOpfBlzKKpHMr",
+	"ixfClKoQsqoOaaQfS65oySlVULcr0K2lxhSJx/vRhmlak89Gsr3wHSUeNc3Wy0k9coJ1x/+VSu9wUVv8",
+	"thRgbJpLWeVbU6YtDKm2MrOyWpNA09YFtI1rnoNUwlaeNGUnp6yATYU9nQKwofU9meAcSKum6qUJlwRO",
+	"uXavW2jiiAhz0xtTsPnVbrqrTYnfMQXqTeyzkGm7rfUn933NzPsLVrBT/E6xCm59CXK1T/yJfJWMowgD",
+	"zJopoFOqCqPtZm1NcVuFQh4VRXM05NcAx68Bjo/MTJkKPWQqishL7lLcuauaeGM/sK0AqeylUVPvVRjC",
+	"w1Wb6aayjY84AvQry14OEXXtC1D0D9rE1izbawm7VoNYJyxZDcOIuIJ3vCM5zsaAa5CLZZd1rUD6N8qJ",
+	"OANu7VZN3RF87Ik1tEYF+m35vlr7mAHfMT8VCyOwWdvmLHTSMp940iW47UbnGhv3WmY67wYpyiexSKZB",
+	"MhqSV6q7Z2XFNuEkEzKHvGm1/kyNxmnUu7Q3PdW75p7ks/Wdgx5Ys9rQtSfBEJZDGb4yBI8/jv4cftY6",
+	"NxG6TEQV+WzFLEo93TUGyWYOgeQTd8hWpBCGYMp5re20ByLVftoVxUIPf6h7H/oFdMkpw//HswjXmtqq",
+	"SRZrbYUa/HrorceUZQPtlUZ835gOfFj5kc0qUIStjUMx6tx/QwnziwutCa0AzFCXkBDaNDrV0FQciz6x",
+	"fXO/V9WyyWHvwNz5A26C5fyYIRd66F6MtcRkoIC4P35S61W/XO7lY+fm5qaDck+nlAWYFeR3/vbDGn1K",
+	"PV3HiAxsUVzco2NEzjRVt/rsPd1mNlXO50JqyN9AzigWeDIv728/62uReea8v0X00qUQbyhfuH1RX5J1",
+	"1kUnc2hGgrZ8I+6qb2ohJ9IAkefW+aAodTMjjEWlunxjpZlEga2uY1j4O/6N9OzKK7cdW5epNoshNyaw",
+	"xDg5quo2yqrIPxdY1MAlcGH3xiaiHvefHDFvive3b61Q2R1I5oFEcwuv9dqZS3m5UeR6rOrQoogxK3VA",
+	"R4V1t98T3wuTrGN+tUTsmiT2aI8mKSR5Oir1FAnIFolIFLBZOizTN6+DffOa2QAWrFKrdjUsI+/szCIR",
+	"hOGlohEUgk/UgGsRWb9tCdNawzVnBnlz9EP/ePi6//Yvw9Nfzvrn/9M2SOg6Tgx49ByrWZ+f/vf704vL",
+	"C4JrsKqML5NThfR7kExHnNonfu6/PXn3s4XGHxEymfDuzdRGYwppIlv0NHxuwA0zmjClTdCM76oNsmN3",
+	"wpYWdxW4smlT/LHhI6HtwD0xrZW2BtvLIMneWUFsvKuw8HnX9yO6iF3hHyK4bW4XaKOwp7mZ8navTeeJ",
+	"dZWiuCpn7iKOSkGNFuTs3cUlWf6gqwKvSlCVv/XIvemq75bKi/yCZ/DKEWHeJpmdzOBzya+4uHFkHmq9",
+	"kcPeXgqVlxpo3BMmN7Tp+CpT30mmDg78PxZRYptF4uXjiDYNdWwSYGbQ6Pf5AfSxrQ4T16z/HZz1yWv+",
+	"cYstmP3XKKLYk6q5kOcgTZdfwdWaw/JqTkeCAn0rIWapJf1mWWbAnTBDtpRlQj2g89OL00snzwz4zh6Z",
+	"ilLGpda7xNGETVzE2Av7QdcnkWr8KF9E7H/ArUeIMAfBLeUXV73Tyy9rhJOqfo/1Tt0HW6/N8VVIuW8h",
+	"JYX7W1PZNrUtt5FY6l91umYVXTrgfgB2BFojvXTJeyuotCsxxoygPB/g8YBUpjaIL8RXFCSIMq5Vqe9S",
+	"6qccGphsB91b1OuioV2BrVGVpioFOiradT++kWiOL1Z3y/LY/+3EdAHasehARU7O2I6YnCFljd1NaMPv",
+	"V+1ukWAfXxJzyqS3vNWCKc27oMjMRESMtQ0K40sttaymOuCuYyw+vGE8FzdtMi6luT6qT3nC2X/hbPkS",
+	"tFwMDeIPFWQC79kQc+0+ZHYKlPNNVtm5sV8DrycJM0A3ZWX9C+3zFPDclr8e8CVDIjozOEDug2Arc6Gp",
+	"OkTUHDI2ZlnwZiSJ0nzs0p3d/dBkNcUjVVsu4y4vyWT5B7XxPyaqd6dXxy9boXlby6jD5V1XYHJjBheG",
+	"J4SkKTIDTU0xzdDJz3yNrFjMB7wGUNW/zi2hY0/ZtbAjl9G3nF8PhVxjsIuvuerWq9aP/Mi8ozQrCryZ",
+	"bRjOqwGP3X6HNbdeJC77Urwm3rOqhrvOA1ipYBeh9f/a4LvzlL/BL1gLwjjyhxAAZbelij5a2ra1+WEP",
+	"GW7kVm86yzZHFwSs+Vor0yigy0TkSNHjw2biVetad8UOszR5IrL3T9qhoe+SM0wOOI64+PGos//0GZnC",
+	"R0sa28QFGSHV+NDHQtbjd2zt6eUOE+bexCICMbwbo3oeIpjncwVWj/z/FpE1jzbLyHiZvMUk4HOExMvI",
+	"6/6DGLuOkExMdrNvEG137+f3hGb24/cU5LH88YdtMblBtHuUfSa3oJULgy4nLiHlTlVk/mB27XLulL+1",
+	"7t0p0EJP11myf7QjPlNGqTdHjzrrh6R70798YwP2lAhj05KYInYxC7s3YTfsAkg2hSz2tNmf3Tb4duIN",
+	"gjYeuFO0S85RmB+VrKhKvkdK8jxu68BQQK7EzhBTl8O8EIsZuExIIxFjwCyKvcc+KI8L3wGiQcA1Qt09",
+	"yo7f4RqbJEfzkDBuY/wtfce7/l3YoBATHDai9lrDiZjat81HUnp5RFOpy7nNUDNHTOiEMk52UH4bUQXW",
+	"rY5soR1CJgdc4w+hCWCbYFNzf4rUlhqjHNokZ0oznulgnjQH8sTaDKVDDJyASFBlodFCb9Wyp70Dlz2H",
+	"BnkLGjbeCFgw4FrSMRocvFlDlNqGglMyYypCKsaVpjafPYqcWg22rlU4xCEfzKMP8fQD7qAyL7mmJgYr",
+	"M1EWOXG5JzeSaQ3cqFujcjw21paxSVPUcmEAidxLRoRVRLh++l1y4nc/E5xDZgbMhTA1GzTuaWaiMAY8",
+	"VJ4ydtogk9r9Vm2UF/BHa8vJaFGArKq52Tb1Ay4RHYx17eQ79J+8uzgdnr1793p4cXl0eeG3hOwwx0g7",
+	"ZrKICp8s76w0Cmb82ZPz/l9Pz/9rBjMhF3Z3A4oZPmAwasDNVpvF+ZA285iL1PqJRaFGHfYcaM44KNW6",
+	"16B0N4lldE3xT25hxkbnJMyDB4VBkwKoKRYBEUJDbjlPJLF+am8SWt1kay4F80nEgpSx4ASuoRDzmdUJ",
+	"cVSr3Spl0XrZmmo9f7m7a1p2ToXSL5/3nvd26ZztXu8lmhqcSZGXljwSH1Ivd/HVrrslu5mYhU/9GqBe",
+	"/mZ84YUmyaqyVbhFrgKzRNCJV4/K9IuhpACnEzDbkno5C3Wfmuocr//AWZUUtQJBpciiFSy87KP9jb/Y",
+	"s/8nEUz4tPXp10//bwC8gPIDceQAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -13,6 +13,31 @@ const (
 	BearerAuthScopes = "BearerAuth.Scopes"
 )
 
+// Defines values for AccountRole.
+const (
+	AccountRoleAdmin AccountRole = "admin"
+	AccountRoleUser  AccountRole = "user"
+)
+
+// Defines values for AccountStatus.
+const (
+	AccountStatusActive          AccountStatus = "active"
+	AccountStatusPendingDeletion AccountStatus = "pending_deletion"
+	AccountStatusSuspended       AccountStatus = "suspended"
+)
+
+// Defines values for CreateAccountRequestRole.
+const (
+	CreateAccountRequestRoleAdmin CreateAccountRequestRole = "admin"
+	CreateAccountRequestRoleUser  CreateAccountRequestRole = "user"
+)
+
+// Defines values for CreateInviteRequestRole.
+const (
+	CreateInviteRequestRoleAdmin CreateInviteRequestRole = "admin"
+	CreateInviteRequestRoleUser  CreateInviteRequestRole = "user"
+)
+
 // Defines values for CreateProjectRequestStatus.
 const (
 	CreateProjectRequestStatusActive   CreateProjectRequestStatus = "active"
@@ -20,6 +45,71 @@ const (
 	CreateProjectRequestStatusInactive CreateProjectRequestStatus = "inactive"
 )
 
+// Defines values for ErrorCode.
+const (
+	ErrorCodeAccountLocked             ErrorCode = "account_locked"
+	ErrorCodeAccountNotFound           ErrorCode = "account_not_found"
+	ErrorCodeAccountNotPendingDeletion ErrorCode = "account_not_pending_deletion"
+	ErrorCodeAccountPendingDeletion    ErrorCode = "account_pending_deletion"
+	ErrorCodeAccountSuspended          ErrorCode = "account_suspended"
+	ErrorCodeAudienceNotAllowed        ErrorCode = "audience_not_allowed"
+	ErrorCodeEmailDomainNotAllowed     ErrorCode = "email_domain_not_allowed"
+	ErrorCodeEmailExists               ErrorCode = "email_exists"
+	ErrorCodeForbidden                 ErrorCode = "forbidden"
+	ErrorCodeIncorrectPassword         ErrorCode = "incorrect_password"
+	ErrorCodeInternalError             ErrorCode = "internal_error"
+	ErrorCodeInvalidCredentials        ErrorCode = "invalid_credentials"
+	ErrorCodeInvalidEmail              ErrorCode = "invalid_email"
+	ErrorCodeInvalidId                 ErrorCode = "invalid_id"
+	ErrorCodeInvalidInvite             ErrorCode = "invalid_invite"
+	ErrorCodeInvalidName               ErrorCode = "invalid_name"
+	ErrorCodeInvalidPagination         ErrorCode = "invalid_pagination"
+	ErrorCodeInvalidPasswordResetToken ErrorCode = "invalid_password_reset_token"
+	ErrorCodeInvalidProfile            ErrorCode = "invalid_profile"
+	ErrorCodeInvalidRequest            ErrorCode = "invalid_request"
+	ErrorCodeInvalidRole               ErrorCode = "invalid_role"
+	ErrorCodeInvalidStatus             ErrorCode = "invalid_status"
+	ErrorCodeInvalidToken              ErrorCode = "invalid_token"
+	ErrorCodeInvalidUsername           ErrorCode = "invalid_username"
+	ErrorCodeInvalidVerificationToken  ErrorCode = "invalid_verification_token"
+	ErrorCodeMethodNotAllowed          ErrorCode = "method_not_allowed"
+	ErrorCodeNotFound                  ErrorCode = "not_found"
+	ErrorCodePasswordReused            ErrorCode = "password_reused"
+	ErrorCodeProjectLimitExceeded      ErrorCode = "project_limit_exceeded"
+	ErrorCodeProjectNameExists         ErrorCode = "project_name_exists"
+	ErrorCodeProjectNotFound           ErrorCode = "project_not_found"
+	ErrorCodeQueryTimeout              ErrorCode = "query_timeout"
+	ErrorCodeRateLimited               ErrorCode = "rate_limited"
+	ErrorCodeServiceUnavailable        ErrorCode = "service_unavailable"
+	ErrorCodeSessionNotFound           ErrorCode = "session_not_found"
+	ErrorCodeSignupDisabled            ErrorCode = "signup_disabled"
+	ErrorCodeTokenCompromised          ErrorCode = "token_compromised"
+	ErrorCodeTokenExpired              ErrorCode = "token_expired"
+	ErrorCodeUnauthorized              ErrorCode = "unauthorized"
+	ErrorCodeUnsupportedMediaType      ErrorCode = "unsupported_media_type"
+	ErrorCodeUsernameExists            ErrorCode = "username_exists"
+	ErrorCodeVersionConflict           ErrorCode = "version_conflict"
+)
+
+// Defines values for InviteRole.
+const (
+	InviteRoleAdmin InviteRole = "admin"
+	InviteRoleUser  InviteRole = "user"
+)
+
+// Defines values for ListAccountProjectCountsParamsRole.
+const (
+	ListAccountProjectCountsParamsRoleAdmin ListAccountProjectCountsParamsRole = "admin"
+	ListAccountProjectCountsParamsRoleUser  ListAccountProjectCountsParamsRole = "user"
+)
+
+// Defines values for ListAllProjectsParamsStatus.
+const (
+	ListAllProjectsParamsStatusActive   ListAllProjectsParamsStatus = "active"
+	ListAllProjectsParamsStatusArchived ListAllProjectsParamsStatus = "archived"
+	ListAllProjectsParamsStatusInactive ListAllProjectsParamsStatus = "inactive"
+)
+
 // Defines values for ProjectStatus.
 const (
 	ProjectStatusActive   ProjectStatus = "active"
@@ -27,6 +117,12 @@ const (
 	ProjectStatusInactive ProjectStatus = "inactive"
 )
 
+// Defines values for UpdateAccountStatusRequestStatus.
+const (
+	UpdateAccountStatusRequestStatusActive    UpdateAccountStatusRequestStatus = "active"
+	UpdateAccountStatusRequestStatusSuspended UpdateAccountStatusRequestStatus = "suspended"
+)
+
 // Defines values for UpdateProjectRequestStatus.
 const (
 	Active   UpdateProjectRequestStatus = "active"
@@ -34,13 +130,116 @@ const (
 	Inactive UpdateProjectRequestStatus = "inactive"
 )
 
+// Defines values for UserAgentInfoDevice.
+const (
+	Bot     UserAgentInfoDevice = "bot"
+	Desktop UserAgentInfoDevice = "desktop"
+	Mobile  UserAgentInfoDevice = "mobile"
+	Tablet  UserAgentInfoDevice = "tablet"
+)
+
 // Account defines model for Account.
 type Account struct {
-	CreatedAt time.Time           `json:"created_at"`
-	Email     openapi_types.Email `json:"email"`
-	Id        openapi_types.UUID  `json:"id"`
-	Name      string              `json:"name"`
-	UpdatedAt time.Time           `json:"updated_at"`
+	// AllowedAudiences Audiences the account may request on login and refresh (read-only; changed by administrators). Absent when only the default audience is allowed.
+	AllowedAudiences *[]string `json:"allowed_audiences,omitempty"`
+	AvatarUrl        *string   `json:"avatar_url,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+
+	// DeletionScheduledAt When an account pending deletion will be purged
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty"`
+	DisplayName         *string    `json:"display_name,omitempty"`
+
+	// Email In account lists this may be masked as `j***@example.com` depending on ACCOUNT_LIST_EMAIL_MASKING and the caller's role; the full address is returned when fetching a single account.
+	Email openapi_types.Email `json:"email"`
+	Id    openapi_types.UUID  `json:"id"`
+
+	// LastLoginAt Last successful login, magic-link login or token refresh (read-only; refreshes from the same address are recorded at most once a minute). Absent until the account first logs in.
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+
+	// LastLoginIp Client IP address of the last login (read-only). Omitted in account lists when emails are masked for the caller.
+	LastLoginIp *string `json:"last_login_ip,omitempty"`
+
+	// Locale BCP 47 language tag
+	Locale *string `json:"locale,omitempty"`
+	Name   string  `json:"name"`
+
+	// PendingEmail New email address awaiting confirmation
+	PendingEmail *openapi_types.Email `json:"pending_email,omitempty"`
+
+	// Permissions Effective permissions granted by the role (read-only)
+	Permissions *[]string   `json:"permissions,omitempty"`
+	Role        AccountRole `json:"role"`
+
+	// Status Suspended accounts and accounts pending deletion cannot log in or refresh tokens
+	Status AccountStatus `json:"status"`
+
+	// TenantId Tenant the account belongs to (read-only)
+	TenantId string `json:"tenant_id"`
+
+	// Timezone IANA time zone name
+	Timezone  *string   `json:"timezone,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Username Username that can be used instead of the email to log in, stored in lowercase. Absent when not set. Omitted in account lists when emails are masked for the caller.
+	Username *string `json:"username,omitempty"`
+
+	// Version Incremented on every update; the account's ETag is derived from it (read-only)
+	Version int64 `json:"version"`
+}
+
+// AccountRole defines model for Account.Role.
+type AccountRole string
+
+// AccountStatus Suspended accounts and accounts pending deletion cannot log in or refresh tokens
+type AccountStatus string
+
+// AccountExport defines model for AccountExport.
+type AccountExport struct {
+	Account    Account   `json:"account"`
+	ExportedAt time.Time `json:"exported_at"`
+	Projects   []Project `json:"projects"`
+
+	// SecurityEvents Security audit events of the account, newest first
+	SecurityEvents []SecurityEvent `json:"security_events"`
+}
+
+// AccountMergePatch JSON Merge Patch of an account; null clears a profile field.
+type AccountMergePatch struct {
+	// AvatarUrl An http(s) URL.
+	AvatarUrl   *string              `json:"avatar_url"`
+	DisplayName *string              `json:"display_name"`
+	Email       *openapi_types.Email `json:"email,omitempty"`
+
+	// Locale BCP 47 language tag.
+	Locale *string `json:"locale"`
+	Name   *string `json:"name,omitempty"`
+
+	// Timezone IANA time zone name.
+	Timezone *string `json:"timezone"`
+}
+
+// AccountProjectCount defines model for AccountProjectCount.
+type AccountProjectCount struct {
+	Account Account `json:"account"`
+
+	// ProjectCount Number of projects owned by the account
+	ProjectCount int `json:"project_count"`
+}
+
+// AccountProjectCountList defines model for AccountProjectCountList.
+type AccountProjectCountList struct {
+	Items []AccountProjectCount `json:"items"`
+	Limit int                   `json:"limit"`
+
+	// NextCursor Cursor for the next (older) page; omitted on the last page
+	NextCursor *string `json:"next_cursor,omitempty"`
+	Offset     int     `json:"offset"`
+
+	// PrevCursor Cursor for the previous (newer) page; omitted on the first page
+	PrevCursor *string `json:"prev_cursor,omitempty"`
+
+	// Total Total number of accounts matching the filters
+	Total int `json:"total"`
 }
 
 // AuthResponse defines model for AuthResponse.
@@ -54,8 +253,52 @@ type AuthResponse struct {
 	TokenType    string `json:"token_type"`
 }
 
+// BuildInfo defines model for BuildInfo.
+type BuildInfo struct {
+	// Commit Git commit the binary was built from
+	Commit          string              `json:"commit"`
+	GoVersion       string              `json:"go_version"`
+	PasswordHashing PasswordHashingInfo `json:"password_hashing"`
+	Version         string              `json:"version"`
+}
+
+// ChangePasswordRequest defines model for ChangePasswordRequest.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// ConfirmEmailChangeRequest defines model for ConfirmEmailChangeRequest.
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token"`
+}
+
+// CreateAccountRequest defines model for CreateAccountRequest.
+type CreateAccountRequest struct {
+	Email    openapi_types.Email       `json:"email"`
+	Name     string                    `json:"name"`
+	Password string                    `json:"password"`
+	Role     *CreateAccountRequestRole `json:"role,omitempty"`
+}
+
+// CreateAccountRequestRole defines model for CreateAccountRequest.Role.
+type CreateAccountRequestRole string
+
+// CreateInviteRequest defines model for CreateInviteRequest.
+type CreateInviteRequest struct {
+	// Role Role of the account created with the invite
+	Role *CreateInviteRequestRole `json:"role,omitempty"`
+
+	// TenantId Tenant of the account created with the invite; defaults to the administrator's tenant
+	TenantId *string `json:"tenant_id,omitempty"`
+}
+
+// CreateInviteRequestRole Role of the account created with the invite
+type CreateInviteRequestRole string
+
 // CreateProjectRequest defines model for CreateProjectRequest.
 type CreateProjectRequest struct {
+	// Description Control characters other than newline and tab are removed; at most PROJECT_DESCRIPTION_MAX_LENGTH characters (default 2000)
 	Description *string                     `json:"description,omitempty"`
 	Name        string                      `json:"name"`
 	Status      *CreateProjectRequestStatus `json:"status,omitempty"`
@@ -64,15 +307,86 @@ type CreateProjectRequest struct {
 // CreateProjectRequestStatus defines model for CreateProjectRequest.Status.
 type CreateProjectRequestStatus string
 
+// DatabasePoolStats Database connection pool statistics; omitted unless the caller is an admin or the server exposes them to every caller
+type DatabasePoolStats struct {
+	Idle  int `json:"idle"`
+	InUse int `json:"in_use"`
+
+	// MaxIdleClosed Connections closed because of the idle connection limit
+	MaxIdleClosed int64 `json:"max_idle_closed"`
+
+	// MaxIdleTimeClosed Connections closed because they stayed idle too long
+	MaxIdleTimeClosed int64 `json:"max_idle_time_closed"`
+
+	// MaxLifetimeClosed Connections closed because they reached their maximum lifetime
+	MaxLifetimeClosed int64 `json:"max_lifetime_closed"`
+
+	// MaxOpenConnections Maximum number of open connections (0 means unlimited)
+	MaxOpenConnections int `json:"max_open_connections"`
+
+	// OpenConnections Established connections, both in use and idle
+	OpenConnections int `json:"open_connections"`
+
+	// WaitCount Total number of times a query waited for a free connection
+	WaitCount int64 `json:"wait_count"`
+
+	// WaitDurationMs Total time spent waiting for a free connection, in milliseconds
+	WaitDurationMs int64 `json:"wait_duration_ms"`
+}
+
 // Error defines model for Error.
 type Error struct {
+	// Code Machine-readable error code; stable across releases
+	Code ErrorCode `json:"code"`
+
+	// Error Human-readable message; may change between releases
 	Error string `json:"error"`
+
+	// RequestId ID of the request to quote when reporting the error; only set for server errors (5xx), whose details are logged server-side instead of returned
+	RequestId *string `json:"request_id,omitempty"`
+
+	// RetryAfterSeconds Seconds to wait before retrying; only set for rate_limited and service_unavailable
+	RetryAfterSeconds *int `json:"retry_after_seconds,omitempty"`
 }
 
+// ErrorCode Machine-readable error code; stable across releases
+type ErrorCode string
+
+// Invite defines model for Invite.
+type Invite struct {
+	CreatedAt time.Time          `json:"created_at"`
+	ExpiresAt time.Time          `json:"expires_at"`
+	Id        openapi_types.UUID `json:"id"`
+	Role      InviteRole         `json:"role"`
+	TenantId  string             `json:"tenant_id"`
+
+	// Token Invite token to pass to signup; returned only when the invite is created
+	Token string `json:"token"`
+}
+
+// InviteRole defines model for Invite.Role.
+type InviteRole string
+
 // LoginRequest defines model for LoginRequest.
 type LoginRequest struct {
-	Email    openapi_types.Email `json:"email"`
-	Password string              `json:"password"`
+	// Audience Issue the access token for this audience only; must be in the account's allowed_audiences
+	Audience *string `json:"audience,omitempty"`
+
+	// DeviceName Label for the new session; defaults to a summary of the User-Agent
+	DeviceName *string `json:"device_name,omitempty"`
+
+	// Email Email address; kept for compatibility, use identifier instead
+	Email *openapi_types.Email `json:"email,omitempty"`
+
+	// Identifier Email address or username (usernames only when username login is enabled). Treated as an email when it contains '@'. Takes precedence over email.
+	Identifier *string `json:"identifier,omitempty"`
+	Password   string  `json:"password"`
+}
+
+// LogoutAllResponse defines model for LogoutAllResponse.
+type LogoutAllResponse struct {
+	// RevokedSessions Number of sessions that were revoked
+	RevokedSessions int `json:"revoked_sessions"`
 }
 
 // LogoutRequest defines model for LogoutRequest.
@@ -80,40 +394,218 @@ type LogoutRequest struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
+// MagicLinkRequest defines model for MagicLinkRequest.
+type MagicLinkRequest struct {
+	Email openapi_types.Email `json:"email"`
+}
+
+// PasswordHashingInfo defines model for PasswordHashingInfo.
+type PasswordHashingInfo struct {
+	Algorithm string `json:"algorithm"`
+
+	// Cost Work factor of the algorithm (bcrypt cost)
+	Cost int `json:"cost"`
+}
+
+// PasswordResetRequest defines model for PasswordResetRequest.
+type PasswordResetRequest struct {
+	Email openapi_types.Email `json:"email"`
+}
+
 // Project defines model for Project.
 type Project struct {
-	AccountId   openapi_types.UUID `json:"account_id"`
-	CreatedAt   time.Time          `json:"created_at"`
-	Description *string            `json:"description,omitempty"`
-	Id          openapi_types.UUID `json:"id"`
-	Name        string             `json:"name"`
-	Status      ProjectStatus      `json:"status"`
-	UpdatedAt   time.Time          `json:"updated_at"`
+	AccountId openapi_types.UUID `json:"account_id"`
+	CreatedAt time.Time          `json:"created_at"`
+
+	// CreatedBy Account that created the project
+	CreatedBy   *openapi_types.UUID `json:"created_by,omitempty"`
+	Description *string             `json:"description,omitempty"`
+	Id          openapi_types.UUID  `json:"id"`
+	Name        string              `json:"name"`
+	Status      ProjectStatus       `json:"status"`
+
+	// TenantId Tenant of the owning account (read-only)
+	TenantId  string    `json:"tenant_id"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// UpdatedBy Account that last modified the project
+	UpdatedBy *openapi_types.UUID `json:"updated_by,omitempty"`
 }
 
 // ProjectStatus defines model for Project.Status.
 type ProjectStatus string
 
+// ProjectList defines model for ProjectList.
+type ProjectList struct {
+	Items []Project `json:"items"`
+	Limit int       `json:"limit"`
+
+	// NextCursor Cursor for the next (older) page; omitted on the last page
+	NextCursor *string `json:"next_cursor,omitempty"`
+	Offset     int     `json:"offset"`
+
+	// PrevCursor Cursor for the previous (newer) page; omitted on the first page
+	PrevCursor *string `json:"prev_cursor,omitempty"`
+
+	// Total Total number of projects matching the filters
+	Total int `json:"total"`
+}
+
+// ReadinessReport defines model for ReadinessReport.
+type ReadinessReport struct {
+	Checks []SelfCheckResult `json:"checks"`
+
+	// Pool Database connection pool statistics; omitted unless the caller is an admin or the server exposes them to every caller
+	Pool  *DatabasePoolStats `json:"pool,omitempty"`
+	Ready bool               `json:"ready"`
+}
+
 // RefreshTokenRequest defines model for RefreshTokenRequest.
 type RefreshTokenRequest struct {
-	RefreshToken string `json:"refresh_token"`
+	// Audience Issue the access token for this audience only; must be in the account's allowed_audiences
+	Audience *string `json:"audience,omitempty"`
+
+	// DeviceName New label for the session; the current label is kept when omitted
+	DeviceName   *string `json:"device_name,omitempty"`
+	RefreshToken string  `json:"refresh_token"`
+}
+
+// ResetPasswordRequest defines model for ResetPasswordRequest.
+type ResetPasswordRequest struct {
+	NewPassword string `json:"new_password"`
+
+	// Token Token from the password reset email
+	Token string `json:"token"`
+}
+
+// RevokeSessionRequest defines model for RevokeSessionRequest.
+type RevokeSessionRequest struct {
+	// RefreshToken Refresh token of the session to revoke
+	RefreshToken *string `json:"refresh_token,omitempty"`
+
+	// SessionId ID of the session to revoke, as returned by the current session endpoint
+	SessionId *openapi_types.UUID `json:"session_id,omitempty"`
+
+	// TokenHash SHA-256 hex hash of the refresh token
+	TokenHash *string `json:"token_hash,omitempty"`
+}
+
+// RevokeSessionsByOriginRequest defines model for RevokeSessionsByOriginRequest.
+type RevokeSessionsByOriginRequest struct {
+	// IpAddress IP address the sessions were created from
+	IpAddress *string `json:"ip_address,omitempty"`
+
+	// UserAgent Exact user agent the sessions were created with
+	UserAgent *string `json:"user_agent,omitempty"`
+}
+
+// RevokeSessionsByOriginResponse defines model for RevokeSessionsByOriginResponse.
+type RevokeSessionsByOriginResponse struct {
+	// RevokedCount Number of sessions revoked
+	RevokedCount int64 `json:"revoked_count"`
+}
+
+// SecurityEvent defines model for SecurityEvent.
+type SecurityEvent struct {
+	CreatedAt   time.Time          `json:"created_at"`
+	Description string             `json:"description"`
+	EventType   string             `json:"event_type"`
+	Id          openapi_types.UUID `json:"id"`
+	IpAddress   *string            `json:"ip_address,omitempty"`
+
+	// Metadata Event details; token hashes and other secrets are removed
+	Metadata  *map[string]interface{} `json:"metadata,omitempty"`
+	UserAgent *string                 `json:"user_agent,omitempty"`
+}
+
+// SelfCheckResult defines model for SelfCheckResult.
+type SelfCheckResult struct {
+	// Message Reason for the failure; omitted when the check passed
+	Message *string `json:"message,omitempty"`
+	Name    string  `json:"name"`
+	Ok      bool    `json:"ok"`
+}
+
+// SessionInfo defines model for SessionInfo.
+type SessionInfo struct {
+	// AbsoluteExpiresAt Absolute expiry of the session across refreshes
+	AbsoluteExpiresAt time.Time `json:"absolute_expires_at"`
+
+	// CreatedAt When the refresh token was issued
+	CreatedAt time.Time `json:"created_at"`
+
+	// DeviceName Label of the device holding the session
+	DeviceName *string `json:"device_name,omitempty"`
+
+	// ExpiresAt When the refresh token expires
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Id Session ID; pass it as session_id to revoke the session
+	Id openapi_types.UUID `json:"id"`
+
+	// IpAddress IP address that created the session
+	IpAddress *string `json:"ip_address,omitempty"`
+
+	// LastUsedAt When the refresh token was last used
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+
+	// UserAgent User agent that created the session
+	UserAgent *string `json:"user_agent,omitempty"`
+
+	// UserAgentInfo Browser, OS and device type inferred from the raw user agent. Fields that could not be inferred are omitted.
+	UserAgentInfo *UserAgentInfo `json:"user_agent_info,omitempty"`
 }
 
 // SignUpRequest defines model for SignUpRequest.
 type SignUpRequest struct {
-	Email    openapi_types.Email `json:"email"`
-	Name     string              `json:"name"`
-	Password string              `json:"password"`
+	Email openapi_types.Email `json:"email"`
+
+	// InviteToken Invite token from an administrator; required when self-service signup is disabled
+	InviteToken *string `json:"invite_token,omitempty"`
+	Name        string  `json:"name"`
+	Password    string  `json:"password"`
+
+	// Role Requested role; honored only when it is configured as self-assignable, otherwise the default signup role is assigned. Ignored when signing up with an invite
+	Role *string `json:"role,omitempty"`
+
+	// Username Optional username for logging in; only accepted when username login is enabled. Compared case-insensitively and stored in lowercase. 400 with code invalid_username when invalid or disabled, 409 with code username_exists when taken.
+	Username *string `json:"username,omitempty"`
 }
 
 // UpdateAccountRequest defines model for UpdateAccountRequest.
 type UpdateAccountRequest struct {
-	Email *openapi_types.Email `json:"email,omitempty"`
-	Name  *string              `json:"name,omitempty"`
+	// AvatarUrl An http(s) URL. An empty string clears the value.
+	AvatarUrl *string `json:"avatar_url,omitempty"`
+
+	// DisplayName An empty string clears the value.
+	DisplayName *string              `json:"display_name,omitempty"`
+	Email       *openapi_types.Email `json:"email,omitempty"`
+
+	// Locale BCP 47 language tag. An empty string clears the value.
+	Locale *string `json:"locale,omitempty"`
+	Name   *string `json:"name,omitempty"`
+
+	// Timezone IANA time zone name. An empty string clears the value.
+	Timezone *string `json:"timezone,omitempty"`
 }
 
+// UpdateAccountAudiencesRequest defines model for UpdateAccountAudiencesRequest.
+type UpdateAccountAudiencesRequest struct {
+	// Audiences Audiences the account may request; whitespace is not allowed within a value
+	Audiences []string `json:"audiences"`
+}
+
+// UpdateAccountStatusRequest defines model for UpdateAccountStatusRequest.
+type UpdateAccountStatusRequest struct {
+	Status UpdateAccountStatusRequestStatus `json:"status"`
+}
+
+// UpdateAccountStatusRequestStatus defines model for UpdateAccountStatusRequest.Status.
+type UpdateAccountStatusRequestStatus string
+
 // UpdateProjectRequest defines model for UpdateProjectRequest.
 type UpdateProjectRequest struct {
+	// Description Control characters other than newline and tab are removed; at most PROJECT_DESCRIPTION_MAX_LENGTH characters (default 2000)
 	Description *string                     `json:"description,omitempty"`
 	Name        *string                     `json:"name,omitempty"`
 	Status      *UpdateProjectRequestStatus `json:"status,omitempty"`
@@ -122,44 +614,216 @@ type UpdateProjectRequest struct {
 // UpdateProjectRequestStatus defines model for UpdateProjectRequest.Status.
 type UpdateProjectRequestStatus string
 
+// UserAgentInfo Browser, OS and device type inferred from the raw user agent. Fields that could not be inferred are omitted.
+type UserAgentInfo struct {
+	Browser *string `json:"browser,omitempty"`
+
+	// BrowserVersion Major version of the browser
+	BrowserVersion *string              `json:"browser_version,omitempty"`
+	Device         *UserAgentInfoDevice `json:"device,omitempty"`
+	Os             *string              `json:"os,omitempty"`
+}
+
+// UserAgentInfoDevice defines model for UserAgentInfo.Device.
+type UserAgentInfoDevice string
+
+// VerifyMagicLinkRequest defines model for VerifyMagicLinkRequest.
+type VerifyMagicLinkRequest struct {
+	// DeviceName Label for the new session; defaults to a summary of the User-Agent
+	DeviceName *string `json:"device_name,omitempty"`
+
+	// Token Token from the sign-in email
+	Token string `json:"token"`
+}
+
 // AccountID defines model for AccountID.
 type AccountID = openapi_types.UUID
 
 // ProjectID defines model for ProjectID.
 type ProjectID = openapi_types.UUID
 
+// AccountLocked defines model for AccountLocked.
+type AccountLocked = Error
+
+// AccountSuspended defines model for AccountSuspended.
+type AccountSuspended = Error
+
 // BadRequest defines model for BadRequest.
 type BadRequest = Error
 
 // Conflict defines model for Conflict.
 type Conflict = Error
 
+// Forbidden defines model for Forbidden.
+type Forbidden = Error
+
 // InternalServerError defines model for InternalServerError.
 type InternalServerError = Error
 
 // NotFound defines model for NotFound.
 type NotFound = Error
 
+// SignupDisabled defines model for SignupDisabled.
+type SignupDisabled = Error
+
+// TooManyRequests defines model for TooManyRequests.
+type TooManyRequests = Error
+
 // Unauthorized defines model for Unauthorized.
 type Unauthorized = Error
 
+// UnsupportedMediaType defines model for UnsupportedMediaType.
+type UnsupportedMediaType = Error
+
+// ListAccountsParams defines parameters for ListAccounts.
+type ListAccountsParams struct {
+	// Limit Maximum number of accounts to return; defaults to ACCOUNT_LIST_DEFAULT_LIMIT (20) and is clamped to ACCOUNT_LIST_MAX_LIMIT (100)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of accounts to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// ListProjectsParams defines parameters for ListProjects.
+type ListProjectsParams struct {
+	// Limit Maximum number of projects to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of projects to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// After Cursor from next_cursor; returns the projects created before it. Cannot be combined with before or offset
+	After *string `form:"after,omitempty" json:"after,omitempty"`
+
+	// Before Cursor from prev_cursor; returns the projects created after it. Cannot be combined with after or offset
+	Before *string `form:"before,omitempty" json:"before,omitempty"`
+}
+
+// ListAccountProjectCountsParams defines parameters for ListAccountProjectCounts.
+type ListAccountProjectCountsParams struct {
+	// Limit Maximum number of accounts to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of accounts to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// After Cursor from next_cursor; returns the accounts created before it. Cannot be combined with before or offset
+	After *string `form:"after,omitempty" json:"after,omitempty"`
+
+	// Before Cursor from prev_cursor; returns the accounts created after it. Cannot be combined with after or offset
+	Before *string `form:"before,omitempty" json:"before,omitempty"`
+
+	// Role Only return accounts with this role
+	Role *ListAccountProjectCountsParamsRole `form:"role,omitempty" json:"role,omitempty"`
+}
+
+// ListAccountProjectCountsParamsRole defines parameters for ListAccountProjectCounts.
+type ListAccountProjectCountsParamsRole string
+
+// SearchAccountsParams defines parameters for SearchAccounts.
+type SearchAccountsParams struct {
+	// Email Prefix of the email address to search for
+	Email string `form:"email" json:"email"`
+
+	// Limit Maximum number of accounts to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListAllProjectsParams defines parameters for ListAllProjects.
+type ListAllProjectsParams struct {
+	// Limit Maximum number of projects to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of projects to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// After Cursor from next_cursor; returns the projects created before it. Cannot be combined with before or offset
+	After *string `form:"after,omitempty" json:"after,omitempty"`
+
+	// Before Cursor from prev_cursor; returns the projects created after it. Cannot be combined with after or offset
+	Before *string `form:"before,omitempty" json:"before,omitempty"`
+
+	// AccountId Only return projects owned by this account
+	AccountId *openapi_types.UUID `form:"account_id,omitempty" json:"account_id,omitempty"`
+
+	// Status Only return projects with this status
+	Status *ListAllProjectsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// ListAllProjectsParamsStatus defines parameters for ListAllProjects.
+type ListAllProjectsParamsStatus string
+
+// GetCurrentSessionParams defines parameters for GetCurrentSession.
+type GetCurrentSessionParams struct {
+	// XRefreshToken Refresh token of the session to inspect
+	XRefreshToken string `json:"X-Refresh-Token"`
+}
+
+// CreateAccountJSONRequestBody defines body for CreateAccount for application/json ContentType.
+type CreateAccountJSONRequestBody = CreateAccountRequest
+
+// PatchAccountJSONRequestBody defines body for PatchAccount for application/json ContentType.
+type PatchAccountJSONRequestBody = UpdateAccountRequest
+
+// PatchAccountApplicationMergePatchPlusJSONRequestBody defines body for PatchAccount for application/merge-patch+json ContentType.
+type PatchAccountApplicationMergePatchPlusJSONRequestBody = AccountMergePatch
+
 // UpdateAccountJSONRequestBody defines body for UpdateAccount for application/json ContentType.
 type UpdateAccountJSONRequestBody = UpdateAccountRequest
 
+// ConfirmEmailChangeJSONRequestBody defines body for ConfirmEmailChange for application/json ContentType.
+type ConfirmEmailChangeJSONRequestBody = ConfirmEmailChangeRequest
+
+// ChangePasswordJSONRequestBody defines body for ChangePassword for application/json ContentType.
+type ChangePasswordJSONRequestBody = ChangePasswordRequest
+
 // CreateProjectJSONRequestBody defines body for CreateProject for application/json ContentType.
 type CreateProjectJSONRequestBody = CreateProjectRequest
 
 // UpdateProjectJSONRequestBody defines body for UpdateProject for application/json ContentType.
 type UpdateProjectJSONRequestBody = UpdateProjectRequest
 
+// UpdateAccountAudiencesJSONRequestBody defines body for UpdateAccountAudiences for application/json ContentType.
+type UpdateAccountAudiencesJSONRequestBody = UpdateAccountAudiencesRequest
+
+// UpdateAccountStatusJSONRequestBody defines body for UpdateAccountStatus for application/json ContentType.
+type UpdateAccountStatusJSONRequestBody = UpdateAccountStatusRequest
+
+// CreateInviteJSONRequestBody defines body for CreateInvite for application/json ContentType.
+type CreateInviteJSONRequestBody = CreateInviteRequest
+
+// RevokeSessionsByOriginJSONRequestBody defines body for RevokeSessionsByOrigin for application/json ContentType.
+type RevokeSessionsByOriginJSONRequestBody = RevokeSessionsByOriginRequest
+
 // LoginJSONRequestBody defines body for Login for application/json ContentType.
 type LoginJSONRequestBody = LoginRequest
 
+// LoginFormdataRequestBody defines body for Login for application/x-www-form-urlencoded ContentType.
+type LoginFormdataRequestBody = LoginRequest
+
 // LogoutJSONRequestBody defines body for Logout for application/json ContentType.
 type LogoutJSONRequestBody = LogoutRequest
 
+// RequestMagicLinkJSONRequestBody defines body for RequestMagicLink for application/json ContentType.
+type RequestMagicLinkJSONRequestBody = MagicLinkRequest
+
+// VerifyMagicLinkJSONRequestBody defines body for VerifyMagicLink for application/json ContentType.
+type VerifyMagicLinkJSONRequestBody = VerifyMagicLinkRequest
+
+// RequestPasswordResetJSONRequestBody defines body for RequestPasswordReset for application/json ContentType.
+type RequestPasswordResetJSONRequestBody = PasswordResetRequest
+
+// ResetPasswordJSONRequestBody defines body for ResetPassword for application/json ContentType.
+type ResetPasswordJSONRequestBody = ResetPasswordRequest
+
 // RefreshTokenJSONRequestBody defines body for RefreshToken for application/json ContentType.
 type RefreshTokenJSONRequestBody = RefreshTokenRequest
 
+// RevokeSessionJSONRequestBody defines body for RevokeSession for application/json ContentType.
+type RevokeSessionJSONRequestBody = RevokeSessionRequest
+
 // SignUpJSONRequestBody defines body for SignUp for application/json ContentType.
 type SignUpJSONRequestBody = SignUpRequest
+
+// SignUpFormdataRequestBody defines body for SignUp for application/x-www-form-urlencoded ContentType.
+type SignUpFormdataRequestBody = SignUpRequest
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrReservedClaim 追加クレームの名前が標準クレームまたはアプリケーションのクレームと衝突する場合のエラー
+var ErrReservedClaim = errors.New("claim name is reserved")
+
+// reservedClaims 追加クレームで上書きできないクレーム名
+// RFC 7519の登録済みクレームとClaimsのフィールド
+var reservedClaims = map[string]struct{}{
+	"iss": {}, "sub": {}, "aud": {}, "exp": {}, "nbf": {}, "iat": {}, "jti": {},
+	"account_id": {}, "email": {}, "role": {}, "tenant_id": {},
+}
+
+// validateExtraClaims 追加クレームに予約済みの名前が含まれていないか検証
+func validateExtraClaims(extra map[string]interface{}) error {
+	for name := range extra {
+		if _, ok := reservedClaims[name]; ok {
+			return fmt.Errorf("%w: %s", ErrReservedClaim, name)
+		}
+	}
+	return nil
+}
+
+// claimsJSON Claimsの標準のJSON表現（MarshalJSON/UnmarshalJSONの再帰を避けるための別名）
+type claimsJSON Claims
+
+// MarshalJSON 追加クレームをトップレベルのクレームとして展開する
+func (c Claims) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(claimsJSON(c))
+	if err != nil || len(c.Extra) == 0 {
+		return data, err
+	}
+
+	merged := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	for name, value := range c.Extra {
+		if _, ok := reservedClaims[name]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrReservedClaim, name)
+		}
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode claim %s: %w", name, err)
+		}
+		merged[name] = raw
+	}
+	return json.Marshal(merged)
+}
+
+// UnmarshalJSON 予約済み以外のクレームをExtraに格納する
+func (c *Claims) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*claimsJSON)(c)); err != nil {
+		return err
+	}
+
+	var all map[string]interface{}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	for name := range reservedClaims {
+		delete(all, name)
+	}
+	c.Extra = nil
+	if len(all) > 0 {
+		c.Extra = all
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/labstack/gommon/log"
+)
+
+// MXResolver MXレコードを解決するインターフェース（テストで差し替え可能）
+type MXResolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+}
+
+// EmailDomainConfig メールアドレスのドメイン検査の設定
+type EmailDomainConfig struct {
+	// Blocklist 登録を拒否するドメイン（サブドメインも対象）
+	Blocklist []string
+	// CheckMX 有効にするとMXレコードの無いドメインを拒否する
+	CheckMX bool
+	// Resolver MXレコードの解決に使うリゾルバー（nilの場合はnet.DefaultResolver）
+	Resolver MXResolver
+}
+
+// EmailDomainChecker 使い捨てメールなどのドメインを拒否する
+type EmailDomainChecker struct {
+	blocked  map[string]struct{}
+	checkMX  bool
+	resolver MXResolver
+}
+
+// NewEmailDomainChecker 新しいEmailDomainCheckerを作成
+func NewEmailDomainChecker(config EmailDomainConfig) *EmailDomainChecker {
+	blocked := make(map[string]struct{}, len(config.Blocklist))
+	for _, d := range config.Blocklist {
+		if d = normalizeDomain(d); d != "" {
+			blocked[d] = struct{}{}
+		}
+	}
+
+	resolver := config.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	return &EmailDomainChecker{
+		blocked:  blocked,
+		checkMX:  config.CheckMX,
+		resolver: resolver,
+	}
+}
+
+// Check メールアドレスのドメインが登録可能か検査
+// MXレコードの確認でDNSエラーが発生した場合は登録を妨げないよう許可する（fail-open）
+func (c *EmailDomainChecker) Check(ctx context.Context, email string) error {
+	at := strings.LastIndex(email, "@")
+	if at < 0 || at == len(email)-1 {
+		return domain.ErrInvalidEmail
+	}
+	emailDomain := normalizeDomain(email[at+1:])
+
+	if c.isBlocked(emailDomain) {
+		return domain.ErrDisallowedEmailDomain
+	}
+
+	if !c.checkMX {
+		return nil
+	}
+
+	records, err := c.resolver.LookupMX(ctx, emailDomain)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return domain.ErrDisallowedEmailDomain
+		}
+		log.Warnf("[EmailDomain] MX lookup failed, allowing signup | Domain: %s | Error: %v", emailDomain, err)
+		return nil
+	}
+	if len(records) == 0 {
+		return domain.ErrDisallowedEmailDomain
+	}
+
+	return nil
+}
+
+// isBlocked ドメインまたはその親ドメインがブロックリストに含まれるか
+func (c *EmailDomainChecker) isBlocked(emailDomain string) bool {
+	for d := emailDomain; d != ""; {
+		if _, ok := c.blocked[d]; ok {
+			return true
+		}
+		dot := strings.Index(d, ".")
+		if dot < 0 {
+			break
+		}
+		d = d[dot+1:]
+	}
+	return false
+}
+
+// LoadDomainBlocklist ファイルからブロックするドメインを読み込む
+// 1行に1ドメインを記述し、空行と#から始まる行は無視する
+func LoadDomainBlocklist(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open email domain blocklist: %w", err)
+	}
+	defer file.Close()
+
+	var domains []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains = append(domains, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read email domain blocklist: %w", err)
+	}
+
+	return domains, nil
+}
+
+// normalizeDomain ドメインを比較用に正規化
+func normalizeDomain(d string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(d)), ".")
+}
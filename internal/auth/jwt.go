@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -21,14 +22,79 @@ type JWTConfig struct {
 	AccessTokenExpiry  time.Duration
 	RefreshTokenExpiry time.Duration
 	Issuer             string
-	Audience           []string
+	Audience           []string          // 発行するトークンのAudience
+	AudienceMatchMode  AudienceMatchMode // Audienceの検証方法（空の場合はexact）
+	// AcceptedAudiences Audienceに加えて検証時に受け入れるAudience
+	// Audienceを変更する際に移行前のAudienceを設定し、発行済みのトークンが失効するまで受け入れる
+	AcceptedAudiences []string
+	// AccessTokenType アクセストークンのtypヘッダー（空の場合はDefaultTokenType）
+	// 検証時は一致しないtypのトークンを拒否する
+	AccessTokenType string
+	// RefreshTokenType リフレッシュトークンのtypヘッダー
+	// 空の場合はDefaultTokenTypeで発行し、検証時にtypを確認しない
+	RefreshTokenType string
+	// SigningAlgorithm 署名に使用するHMACアルゴリズム（HS256/HS384/HS512、空の場合はDefaultSigningAlgorithm）
+	// 検証時はこのアルゴリズムで署名されたトークンのみ許可する
+	SigningAlgorithm string
+	// RefreshTokenIssuer リフレッシュトークンのIssuer（空の場合はIssuer）
+	// リフレッシュトークンは認証サーバーのみが検証するため、アクセストークンと分けると他のサービスでの誤用を防げる
+	RefreshTokenIssuer string
+	// RefreshTokenAudience リフレッシュトークンのAudience（空の場合はAudience）
+	RefreshTokenAudience []string
+	// AcceptedIssuers Issuerに加えて検証時に受け入れるIssuer
+	// Issuerを変更する際に移行前のIssuerを設定し、発行済みのトークンが失効するまで受け入れる
+	AcceptedIssuers []string
+	// VerifyOnly 有効にするとトークンを発行せず、アクセストークンの検証のみを行う
+	// 発行とリフレッシュトークンの検証はErrIssuanceDisabledを返すため、リフレッシュトークンのシークレットは不要
+	VerifyOnly bool
+	// SigningKeys ローテーションするアクセストークンの署名鍵（nilの場合はAccessTokenSecretのみを使用）
+	// 署名に使用できる鍵がある場合はその鍵で署名してkidヘッダーを設定し、kidヘッダーのあるトークンはその鍵で検証する
+	// kidヘッダーの無いトークン（ローテーションを有効にする前に発行したトークン）はAccessTokenSecretで検証する
+	SigningKeys *KeyRing
 }
 
+// ErrIssuanceDisabled 検証のみの構成でトークンの発行またはリフレッシュトークンの検証を行った
+var ErrIssuanceDisabled = errors.New("token issuance is disabled in verify-only mode")
+
+// DefaultTokenType typヘッダーの既定値
+const DefaultTokenType = "JWT"
+
+// DefaultSigningAlgorithm 署名アルゴリズムの既定値
+const DefaultSigningAlgorithm = "HS256"
+
+// signingMethods 設定で選択できる署名アルゴリズム
+var signingMethods = map[string]*jwt.SigningMethodHMAC{
+	jwt.SigningMethodHS256.Alg(): jwt.SigningMethodHS256,
+	jwt.SigningMethodHS384.Alg(): jwt.SigningMethodHS384,
+	jwt.SigningMethodHS512.Alg(): jwt.SigningMethodHS512,
+}
+
+// IsSupportedSigningAlgorithm 署名アルゴリズムが設定で選択できるものか確認
+func IsSupportedSigningAlgorithm(alg string) bool {
+	_, ok := signingMethods[alg]
+	return ok
+}
+
+// AudienceMatchMode Audienceの検証方法
+type AudienceMatchMode string
+
+const (
+	// AudienceMatchExact トークンのAudienceが設定と完全に一致する場合のみ許可
+	AudienceMatchExact AudienceMatchMode = "exact"
+	// AudienceMatchAny トークンのAudienceのいずれかが設定に含まれていれば許可（RFC 7519準拠）
+	AudienceMatchAny AudienceMatchMode = "any"
+)
+
 // Claims JWTのカスタムクレームを定義
 type Claims struct {
 	AccountID string `json:"account_id"` // JWTペイロードは文字列
 	Email     string `json:"email"`
+	Role      string `json:"role,omitempty"`
+	TenantID  string `json:"tenant_id,omitempty"` // 未設定のトークンはdefaultテナントとして扱う
 	jwt.RegisteredClaims
+
+	// Extra プランなどの追加クレーム（トップレベルのクレームとしてエンコードされる）
+	Extra map[string]interface{} `json:"-"`
 }
 
 // RefreshTokenClaims リフレッシュトークンのクレームを定義
@@ -41,6 +107,7 @@ type RefreshTokenClaims struct {
 // JWTManager JWTトークンの管理
 type JWTManager struct {
 	config JWTConfig
+	method *jwt.SigningMethodHMAC // 未対応のアルゴリズムが設定された場合はnil（発行・検証ともに失敗する）
 }
 
 // NewJWTManager 新しいJWTManagerを作成
@@ -52,18 +119,71 @@ func NewJWTManager(config JWTConfig) *JWTManager {
 	if config.RefreshTokenExpiry == 0 {
 		config.RefreshTokenExpiry = time.Hour * 24 * 30
 	}
+	if config.AccessTokenType == "" {
+		config.AccessTokenType = DefaultTokenType
+	}
+	if config.SigningAlgorithm == "" {
+		config.SigningAlgorithm = DefaultSigningAlgorithm
+	}
+	if config.RefreshTokenIssuer == "" {
+		config.RefreshTokenIssuer = config.Issuer
+	}
+	if len(config.RefreshTokenAudience) == 0 {
+		config.RefreshTokenAudience = config.Audience
+	}
 
 	return &JWTManager{
 		config: config,
+		method: signingMethods[config.SigningAlgorithm],
+	}
+}
+
+// signingMethod 設定された署名アルゴリズムを返す
+func (m *JWTManager) signingMethod() (*jwt.SigningMethodHMAC, error) {
+	if m.method == nil {
+		return nil, fmt.Errorf("unsupported signing algorithm: %s", m.config.SigningAlgorithm)
 	}
+	return m.method, nil
 }
 
 // GenerateAccessToken アクセストークンを生成
-func (m *JWTManager) GenerateAccessToken(accountID uuid.UUID, email string) (string, error) {
+func (m *JWTManager) GenerateAccessToken(accountID uuid.UUID, email, role string) (string, error) {
+	return m.GenerateAccessTokenWithClaims(accountID, email, role, nil)
+}
+
+// GenerateAccessTokenWithClaims 追加クレームを含めてアクセストークンを生成
+// 標準クレームやaccount_idなどを上書きする名前はErrReservedClaimとして拒否する
+func (m *JWTManager) GenerateAccessTokenWithClaims(accountID uuid.UUID, email, role string, extra map[string]interface{}) (string, error) {
+	return m.GenerateTenantAccessToken("", accountID, email, role, extra)
+}
+
+// VerifyOnly トークンを発行しない検証のみの構成かどうかを返す
+func (m *JWTManager) VerifyOnly() bool {
+	return m.config.VerifyOnly
+}
+
+// GenerateTenantAccessToken テナントIDと追加クレームを含めてアクセストークンを生成
+func (m *JWTManager) GenerateTenantAccessToken(tenantID string, accountID uuid.UUID, email, role string, extra map[string]interface{}) (string, error) {
+	return m.GenerateTenantAccessTokenForAudience(tenantID, "", accountID, email, role, extra)
+}
+
+// GenerateTenantAccessTokenForAudience audienceのみを対象とするアクセストークンを生成（空の場合は設定のAudience）
+// 特定のサービス向けに発行したトークンは、Audienceの完全一致を要求する他のサービスや認証サーバーでは拒否される
+// 要求されたaudienceをアカウントに許可しているかは呼び出し側で確認する
+func (m *JWTManager) GenerateTenantAccessTokenForAudience(tenantID, audience string, accountID uuid.UUID, email, role string, extra map[string]interface{}) (string, error) {
+	if m.config.VerifyOnly {
+		return "", ErrIssuanceDisabled
+	}
+	if err := validateExtraClaims(extra); err != nil {
+		return "", err
+	}
+
 	now := time.Now()
 	claims := &Claims{
 		AccountID: accountID.String(), // UUID→文字列変換
 		Email:     email,
+		Role:      role,
+		TenantID:  tenantID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			// トークンの有効期限を設定（Missing Expiration Vulnerabilityを防ぐ）
 			// 参照: https://auth0.com/blog/a-look-at-the-latest-draft-for-jwt-bcp/
@@ -75,45 +195,86 @@ func (m *JWTManager) GenerateAccessToken(accountID uuid.UUID, email string) (str
 			ID:        uuid.Must(uuid.NewV7()).String(), // UUID v7を使用
 			Audience:  m.config.Audience,
 		},
+		Extra: extra,
+	}
+	if audience != "" {
+		claims.Audience = jwt.ClaimStrings{audience}
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(m.config.AccessTokenSecret))
+	method, err := m.signingMethod()
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["typ"] = m.config.AccessTokenType
+
+	secret := []byte(m.config.AccessTokenSecret)
+	if m.config.SigningKeys != nil {
+		if kid, key, ok := m.config.SigningKeys.signingKey(now); ok {
+			token.Header["kid"] = kid
+			secret = key
+		}
+	}
+	return token.SignedString(secret)
 }
 
 // GenerateRefreshToken リフレッシュトークンを生成
 func (m *JWTManager) GenerateRefreshToken(accountID uuid.UUID) (string, uuid.UUID, error) {
+	return m.GenerateRefreshTokenWithExpiry(accountID, time.Now().Add(m.config.RefreshTokenExpiry))
+}
+
+// GenerateRefreshTokenWithExpiry 有効期限を指定してリフレッシュトークンを生成
+// スライディング方式でファミリーの絶対有効期限に合わせる場合に使用
+func (m *JWTManager) GenerateRefreshTokenWithExpiry(accountID uuid.UUID, expiresAt time.Time) (string, uuid.UUID, error) {
 	// リフレッシュトークン用のユニークIDを生成（UUID v7）
 	tokenID := uuid.Must(uuid.NewV7())
 
-	now := time.Now()
+	tokenString, err := m.SignRefreshToken(tokenID, accountID, time.Now(), expiresAt)
+	if err != nil {
+		return "", uuid.Nil, err
+	}
+
+	return tokenString, tokenID, nil // tokenIDはUUIDで返す
+}
+
+// SignRefreshToken 指定したトークンID・発行日時・有効期限でリフレッシュトークンに署名
+// 同じ引数からは同じトークン文字列が得られるため、発行済みトークンの再送に使用できる
+// 日時は秒単位に切り捨てられる
+func (m *JWTManager) SignRefreshToken(tokenID, accountID uuid.UUID, issuedAt, expiresAt time.Time) (string, error) {
+	if m.config.VerifyOnly {
+		return "", ErrIssuanceDisabled
+	}
 	claims := &RefreshTokenClaims{
 		TokenID:   tokenID.String(),   // UUID→文字列変換
 		AccountID: accountID.String(), // UUID→文字列変換
 		RegisteredClaims: jwt.RegisteredClaims{
 			// トークンの有効期限を設定（Missing Expiration Vulnerabilityを防ぐ）
 			// 参照: https://auth0.com/blog/a-look-at-the-latest-draft-for-jwt-bcp/
-			ExpiresAt: jwt.NewNumericDate(now.Add(m.config.RefreshTokenExpiry)),
-			IssuedAt:  jwt.NewNumericDate(now),
-			NotBefore: jwt.NewNumericDate(now),
-			Issuer:    m.config.Issuer,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			NotBefore: jwt.NewNumericDate(issuedAt),
+			Issuer:    m.config.RefreshTokenIssuer,
 			Subject:   accountID.String(),
 			ID:        tokenID.String(),
-			Audience:  m.config.Audience,
+			Audience:  m.config.RefreshTokenAudience,
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(m.config.RefreshTokenSecret)) // ここで署名
+	method, err := m.signingMethod()
 	if err != nil {
-		return "", uuid.Nil, err
+		return "", err
 	}
-
-	return tokenString, tokenID, nil // tokenIDはUUIDで返す
+	token := jwt.NewWithClaims(method, claims)
+	if m.config.RefreshTokenType != "" {
+		token.Header["typ"] = m.config.RefreshTokenType
+	}
+	return token.SignedString([]byte(m.config.RefreshTokenSecret)) // ここで署名
 }
 
 // validateToken 汎用的なトークン検証
-func (m *JWTManager) validateToken(tokenString string, claims jwt.Claims, secret []byte, tokenType string) error {
+// expectedTyp が空でない場合はtypヘッダーの一致も確認する
+// keysがnilでない場合、kidヘッダーのあるトークンはsecretではなくkidの署名鍵で検証する
+func (m *JWTManager) validateToken(tokenString string, claims jwt.Claims, secret []byte, keys *KeyRing, tokenType, expectedTyp string) error {
 	// トークンの基本的な構造をチェック（3つのパートがあるか）
 	// Malformed Token Attack / Token Manipulation Attackを防ぐ
 	// 参照: https://portswigger.net/web-security/jwt
@@ -132,12 +293,12 @@ func (m *JWTManager) validateToken(tokenString string, claims jwt.Claims, secret
 	}
 
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		// アルゴリズムを厳密にチェック（HS256のみ許可）
+		// アルゴリズムを厳密にチェック（設定したアルゴリズムのみ許可し、他のHMACのサイズも拒否）
 		// Algorithm Confusion Attack（RS256をHS256に偽装する攻撃）を防ぐ
 		// 参照: https://auth0.com/blog/critical-vulnerabilities-in-json-web-token-libraries/
 		// 参照: https://portswigger.net/web-security/jwt/algorithm-confusion
-		if token.Method.Alg() != jwt.SigningMethodHS256.Alg() {
-			return nil, fmt.Errorf("invalid signing algorithm: %v (expected HS256)", token.Header["alg"])
+		if m.method == nil || token.Method.Alg() != m.method.Alg() {
+			return nil, fmt.Errorf("invalid signing algorithm: %v (expected %s)", token.Header["alg"], m.config.SigningAlgorithm)
 		}
 
 		// Noneアルゴリズムを明示的に拒否
@@ -155,6 +316,25 @@ func (m *JWTManager) validateToken(tokenString string, claims jwt.Claims, secret
 			return nil, fmt.Errorf("unexpected signing method type: %T", token.Method)
 		}
 
+		// typヘッダーをチェック
+		// 同じシークレットで署名された別の種類のトークンを取り違えるToken Confusion Attackを防ぐ
+		// 参照: https://www.rfc-editor.org/rfc/rfc8725#section-3.11
+		if expectedTyp != "" {
+			typ, _ := token.Header["typ"].(string)
+			if !tokenTypeMatches(typ, expectedTyp) {
+				return nil, fmt.Errorf("unexpected token type: %q (expected %s)", typ, expectedTyp)
+			}
+		}
+
+		// kidヘッダーの署名鍵で検証する（引退した鍵や未知の鍵のトークンは拒否）
+		if kid, ok := token.Header["kid"].(string); ok && keys != nil {
+			key, ok := keys.verificationKey(kid, time.Now())
+			if !ok {
+				return nil, fmt.Errorf("unknown or retired signing key: %q", kid)
+			}
+			return key, nil
+		}
+
 		return secret, nil
 	})
 
@@ -189,37 +369,36 @@ func (m *JWTManager) validateToken(tokenString string, claims jwt.Claims, secret
 }
 
 // validateStandardClaims 標準的なクレームの検証
-func (m *JWTManager) validateStandardClaims(issuer string, audience []string) error {
+// expectedIssuer・expectedAudienceはトークンの種類ごとに発行時に設定した値
+func (m *JWTManager) validateStandardClaims(issuer string, audience []string, expectedIssuer string, expectedAudience []string) error {
 	// Issuerの検証
 	// Token Substitution Attack（異なる発行者のトークンを使用する攻撃）を防ぐ
 	// 参照: https://datatracker.ietf.org/doc/html/rfc8725#section-3.5
-	if issuer != m.config.Issuer {
-		return fmt.Errorf("invalid issuer: expected %s, got %s", m.config.Issuer, issuer)
+	if issuer != expectedIssuer && !slices.Contains(m.config.AcceptedIssuers, issuer) {
+		return fmt.Errorf("invalid issuer: expected %s, got %s", expectedIssuer, issuer)
 	}
 
 	// Audienceの検証
 	// Token Confusion Attack（異なる対象者向けのトークンを誤用する攻撃）を防ぐ
 	// 参照: https://datatracker.ietf.org/doc/html/rfc8725#section-3.9
 	// 参照: https://www.rfc-editor.org/rfc/rfc7519#section-4.1.3
-	/*if len(m.config.Audience) > 0 {
-		validAudience := false
-		for _, tokenAud := range audience {
-			if slices.Contains(m.config.Audience, tokenAud) {
-				validAudience = true //一つでも見つかればいいらしい。rfc7519
-				break
+	if len(expectedAudience) > 0 {
+		switch m.config.AudienceMatchMode {
+		case AudienceMatchAny:
+			// RFC 7519の規定どおり、トークンのAudienceのいずれかが一致すればよい
+			accepted := append(slices.Clone(expectedAudience), m.config.AcceptedAudiences...)
+			if !audienceAnyMatch(audience, accepted) {
+				return fmt.Errorf("invalid audience: token audience %v does not match any of %v",
+					audience, accepted)
+			}
+		default:
+			// rfcの推奨ではないが、完全一致のほうが堅牢なのでデフォルトは完全一致
+			// マイクロサービスで同一のシークレットを使用する場合、Audienceの完全一致を要求することで、トークンの誤用を防げる
+			// 移行中は、すべての値がAcceptedAudiencesに含まれるトークン（移行前に発行したトークン）も許可する
+			if !audienceExactMatch(audience, expectedAudience) && !audienceAllAccepted(audience, m.config.AcceptedAudiences) {
+				return fmt.Errorf("audience mismatch: token has %v, expected exactly %v",
+					audience, expectedAudience)
 			}
-		}
-		if !validAudience {
-			return fmt.Errorf("invalid audience: token audience %v does not match expected %v", audience, m.config.Audience)
-		}
-	}*/
-
-	// rfcの推奨ではないが、完全一致のほうが堅牢なので完全一致で実装。
-	// マイクロサービスで同一のシークレットを使用する場合、Audienceの完全一致を要求することで、トークンの誤用を防げるかな？
-	if len(m.config.Audience) > 0 {
-		if !audienceExactMatch(audience, m.config.Audience) {
-			return fmt.Errorf("audience mismatch: token has %v, expected exactly %v",
-				audience, m.config.Audience)
 		}
 	}
 
@@ -231,7 +410,7 @@ func (m *JWTManager) ValidateAccessToken(tokenString string) (*Claims, error) {
 	claims := &Claims{}
 
 	// 共通のトークン検証
-	if err := m.validateToken(tokenString, claims, []byte(m.config.AccessTokenSecret), "token"); err != nil {
+	if err := m.validateToken(tokenString, claims, []byte(m.config.AccessTokenSecret), m.config.SigningKeys, "token", m.config.AccessTokenType); err != nil {
 		return nil, err
 	}
 
@@ -246,7 +425,7 @@ func (m *JWTManager) ValidateAccessToken(tokenString string) (*Claims, error) {
 	}
 
 	// 標準クレームの検証
-	if err := m.validateStandardClaims(claims.Issuer, claims.Audience); err != nil {
+	if err := m.validateStandardClaims(claims.Issuer, claims.Audience, m.config.Issuer, m.config.Audience); err != nil {
 		return nil, err
 	}
 
@@ -258,6 +437,16 @@ func (m *JWTManager) ValidateAccessToken(tokenString string) (*Claims, error) {
 	return claims, nil
 }
 
+// tokenTypeMatches typヘッダーが期待するメディアタイプか確認
+// RFC 7515 4.1.9に従い大文字小文字を区別せず、"application/"の省略を同一とみなす
+func tokenTypeMatches(typ, expected string) bool {
+	normalize := func(v string) string {
+		v = strings.ToLower(strings.TrimSpace(v))
+		return strings.TrimPrefix(v, "application/")
+	}
+	return typ != "" && normalize(typ) == normalize(expected)
+}
+
 // audienceExactMatch 2つのaudienceスライスが完全一致するか確認
 func audienceExactMatch(tokenAud, configAud []string) bool {
 	if len(tokenAud) != len(configAud) {
@@ -282,12 +471,39 @@ func audienceExactMatch(tokenAud, configAud []string) bool {
 	return true
 }
 
+// audienceAnyMatch トークンのaudienceのいずれかが設定に含まれるか確認
+func audienceAnyMatch(tokenAud, configAud []string) bool {
+	for _, aud := range tokenAud {
+		if slices.Contains(configAud, aud) {
+			return true
+		}
+	}
+	return false
+}
+
+// audienceAllAccepted トークンのaudienceがすべて受け入れるaudienceに含まれるか確認
+func audienceAllAccepted(tokenAud, acceptedAud []string) bool {
+	if len(tokenAud) == 0 || len(acceptedAud) == 0 {
+		return false
+	}
+	for _, aud := range tokenAud {
+		if !slices.Contains(acceptedAud, aud) {
+			return false
+		}
+	}
+	return true
+}
+
 // ValidateRefreshToken はリフレッシュトークンを検証します
+// 検証のみの構成ではリフレッシュトークンのシークレットを持たないため、ErrIssuanceDisabledを返す
 func (m *JWTManager) ValidateRefreshToken(tokenString string) (*RefreshTokenClaims, error) {
+	if m.config.VerifyOnly {
+		return nil, ErrIssuanceDisabled
+	}
 	claims := &RefreshTokenClaims{}
 
 	// 共通のトークン検証
-	if err := m.validateToken(tokenString, claims, []byte(m.config.RefreshTokenSecret), "refresh token"); err != nil {
+	if err := m.validateToken(tokenString, claims, []byte(m.config.RefreshTokenSecret), nil, "refresh token", m.config.RefreshTokenType); err != nil {
 		return nil, err
 	}
 
@@ -312,7 +528,7 @@ func (m *JWTManager) ValidateRefreshToken(tokenString string) (*RefreshTokenClai
 	}
 
 	// 標準クレームの検証
-	if err := m.validateStandardClaims(claims.Issuer, claims.Audience); err != nil {
+	if err := m.validateStandardClaims(claims.Issuer, claims.Audience, m.config.RefreshTokenIssuer, m.config.RefreshTokenAudience); err != nil {
 		return nil, err
 	}
 
@@ -330,7 +546,6 @@ func HashToken(token string) string {
 // GenerateSecureToken はセキュアなランダムトークンを生成します
 // Weak Random Generation Vulnerabilityを防ぐ
 // 参照: https://cheatsheetseries.owasp.org/cheatsheets/Cryptographic_Storage_Cheat_Sheet.html#secure-random-number-generation
-// TODO: 現在未使用だが、今後の機能拡張（パスワードリセット、メール確認など）で使用予定
 func GenerateSecureToken() (string, error) {
 	b := make([]byte, 32)
 	if _, err := rand.Read(b); err != nil {
@@ -1,23 +1,50 @@
 package auth
 
 import (
+	"strconv"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
-// HashPassword パスワードをハッシュ化します
-func HashPassword(password string) (string, error) {
+const (
+	// PasswordHashAlgorithm パスワードのハッシュアルゴリズム
+	PasswordHashAlgorithm = "bcrypt"
+	// PasswordHashCost bcryptのコスト
 	// bcrypt costは通常10〜12の範囲で設定するらしい。
 	// 以下のサイトに仕組みが簡単に記載されていた。
 	// https://qiita.com/iheuko/items/e1be4b646be11e329cd8
-	//hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
+	PasswordHashCost = 14
+)
+
+// Hash パスワードをハッシュ化します
+// ペッパーが設定されている場合は、現在のペッパーを適用してそのバージョンをハッシュに記録します
+func (h PasswordHasher) Hash(password string) (string, error) {
+	prefix := ""
+	if current := h.current; current != nil {
+		password = applyPepper(password, current.Secret)
+		prefix = pepperHashPrefix + strconv.Itoa(current.Version)
+	}
+
+	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), PasswordHashCost)
 	if err != nil {
 		return "", err
 	}
-	return string(hashedBytes), nil
+	return prefix + string(hashedBytes), nil
 }
 
-// VerifyPassword パスワードとハッシュを検証します
-func VerifyPassword(password, hash string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+// Verify パスワードとハッシュを検証します
+// ハッシュに記録されたバージョンのペッパーを適用して比較します
+func (h PasswordHasher) Verify(password, hash string) error {
+	version, bcryptHash, err := splitPepperVersion(hash)
+	if err != nil {
+		return err
+	}
+	if version > 0 {
+		secret, ok := h.versions[version]
+		if !ok {
+			return ErrUnknownPepperVersion
+		}
+		password = applyPepper(password, secret)
+	}
+	return bcrypt.CompareHashAndPassword([]byte(bcryptHash), []byte(password))
 }
@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// minPepperLength ペッパーの最小文字数
+const minPepperLength = 32
+
+// pepperHashPrefix ペッパーを適用したハッシュの接頭辞（"$pepper-v<バージョン>" に続けてbcryptのハッシュを保存する）
+const pepperHashPrefix = "$pepper-v"
+
+// ErrUnknownPepperVersion ハッシュのペッパーのバージョンが設定に無い
+var ErrUnknownPepperVersion = errors.New("unknown password pepper version")
+
+// Pepper パスワードのハッシュ化の前にHMACで適用するサーバー側の秘密の値
+type Pepper struct {
+	Version int
+	Secret  string
+}
+
+// PasswordHasher ペッパーを適用してパスワードをハッシュ化・検証する
+// ゼロ値はペッパーを適用しない
+type PasswordHasher struct {
+	// current 新しいハッシュに使用する現在のペッパー
+	current *Pepper
+	// versions ハッシュの検証に使用するバージョンごとの秘密の値
+	versions map[int]string
+}
+
+// ParsePeppers "バージョン:秘密の値" の一覧をペッパーに変換
+// 先頭が現在のペッパーで、以降はローテーション前のハッシュの検証にのみ使用する
+func ParsePeppers(entries []string) ([]Pepper, error) {
+	result := make([]Pepper, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		// 秘密の値に":"が含まれていても分割できるよう、バージョンは先頭から取り出す
+		versionText, secret, ok := strings.Cut(entry, ":")
+		version, err := strconv.Atoi(versionText)
+		if !ok || err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid password pepper: expected version:secret with a positive integer version")
+		}
+		result = append(result, Pepper{Version: version, Secret: secret})
+	}
+	return result, nil
+}
+
+// NewPasswordHasher ペッパーを指定してパスワードのハッシュ化を作成
+// 先頭が新しいハッシュに使用する現在のペッパーで、空の場合はペッパーを適用しない
+func NewPasswordHasher(peppers []Pepper) (PasswordHasher, error) {
+	hasher := PasswordHasher{versions: make(map[int]string, len(peppers))}
+	for i, pepper := range peppers {
+		if pepper.Version <= 0 {
+			return PasswordHasher{}, fmt.Errorf("password pepper version must be positive: %d", pepper.Version)
+		}
+		if len(pepper.Secret) < minPepperLength {
+			return PasswordHasher{}, fmt.Errorf("password pepper version %d must be at least %d characters long", pepper.Version, minPepperLength)
+		}
+		if _, ok := hasher.versions[pepper.Version]; ok {
+			return PasswordHasher{}, fmt.Errorf("duplicate password pepper version %d", pepper.Version)
+		}
+		hasher.versions[pepper.Version] = pepper.Secret
+		if i == 0 {
+			current := pepper
+			hasher.current = &current
+		}
+	}
+	return hasher, nil
+}
+
+// applyPepper パスワードとペッパーのHMAC-SHA256をBase64で返す
+// bcryptが先頭72バイトしか使わないため、固定長の44文字にしてから渡す
+func applyPepper(password, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(password))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// splitPepperVersion ハッシュからペッパーのバージョンとbcryptのハッシュを取り出す
+// ペッパーを適用していないハッシュの場合はバージョン0を返す
+func splitPepperVersion(hash string) (int, string, error) {
+	rest, ok := strings.CutPrefix(hash, pepperHashPrefix)
+	if !ok {
+		return 0, hash, nil
+	}
+	end := strings.IndexByte(rest, '$')
+	if end <= 0 {
+		return 0, "", fmt.Errorf("malformed peppered password hash")
+	}
+	version, err := strconv.Atoi(rest[:end])
+	if err != nil || version <= 0 {
+		return 0, "", fmt.Errorf("malformed peppered password hash")
+	}
+	return version, rest[end:], nil
+}
+
+// NeedsRehash ハッシュが現在のペッパーで作成されていないか返す
+// ペッパーのローテーション後は、ログインに成功したときに現在のペッパーでハッシュし直す
+func (h PasswordHasher) NeedsRehash(hash string) bool {
+	version, _, err := splitPepperVersion(hash)
+	if err != nil {
+		return false
+	}
+	if h.current == nil {
+		return false
+	}
+	return version != h.current.Version
+}
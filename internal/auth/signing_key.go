@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+)
+
+// signingKeySizes 署名アルゴリズムごとに生成する鍵のバイト数（ハッシュ長と同じ）
+var signingKeySizes = map[string]int{
+	"HS256": 32,
+	"HS384": 48,
+	"HS512": 64,
+}
+
+// signingKeyEncryptionContext 署名鍵の暗号化に使用する鍵を導出する際の文脈
+// 同じ秘密の値から導出する他の用途の鍵と区別する
+const signingKeyEncryptionContext = "jwt-auth signing key encryption:"
+
+// ErrSigningKeyDecryption 保存された署名鍵を復号できない（暗号化に使用した秘密の値が変更された場合など）
+var ErrSigningKeyDecryption = errors.New("failed to decrypt signing key")
+
+// GenerateSigningKey 新しい署名鍵を生成し、encryptionSecretで暗号化した鍵を返す
+// 鍵はactivatesAtから署名に使用する
+func GenerateSigningKey(algorithm string, activatesAt time.Time, encryptionSecret string) (*domain.SigningKey, error) {
+	size, ok := signingKeySizes[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("unsupported signing algorithm: %s", algorithm)
+	}
+	secret := make([]byte, size)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	aead, err := signingKeyCipher(encryptionSecret)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	id := domain.NewID()
+	return &domain.SigningKey{
+		ID:        id,
+		Algorithm: algorithm,
+		// 鍵のIDを追加データにして、暗号文を他の鍵の行に付け替えられないようにする
+		EncryptedSecret: aead.Seal(nonce, nonce, secret, []byte(id.String())),
+		CreatedAt:       domain.Now(),
+		ActivatesAt:     activatesAt,
+	}, nil
+}
+
+// DecryptSigningKey 保存された署名鍵を復号する
+func DecryptSigningKey(key *domain.SigningKey, encryptionSecret string) ([]byte, error) {
+	aead, err := signingKeyCipher(encryptionSecret)
+	if err != nil {
+		return nil, err
+	}
+	if len(key.EncryptedSecret) < aead.NonceSize() {
+		return nil, fmt.Errorf("%w %s", ErrSigningKeyDecryption, key.ID)
+	}
+	nonce, ciphertext := key.EncryptedSecret[:aead.NonceSize()], key.EncryptedSecret[aead.NonceSize():]
+	secret, err := aead.Open(nil, nonce, ciphertext, []byte(key.ID.String()))
+	if err != nil {
+		return nil, fmt.Errorf("%w %s", ErrSigningKeyDecryption, key.ID)
+	}
+	return secret, nil
+}
+
+// signingKeyCipher 秘密の値から導出したAES-256-GCMを返す
+func signingKeyCipher(encryptionSecret string) (cipher.AEAD, error) {
+	sum := sha256.Sum256([]byte(signingKeyEncryptionContext + encryptionSecret))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// KeyRingEntry 鍵リングに読み込む署名鍵と復号した鍵
+type KeyRingEntry struct {
+	Key    *domain.SigningKey
+	Secret []byte
+}
+
+// KeyRing アクセストークンの署名と検証に使用するローテーションする署名鍵の一覧
+// 保存された鍵を定期的に読み込み直して置き換え、署名と検証ではその時点の状態の鍵を使用する
+type KeyRing struct {
+	mu      sync.RWMutex
+	entries []KeyRingEntry
+}
+
+// NewKeyRing 空の鍵リングを作成
+func NewKeyRing() *KeyRing {
+	return &KeyRing{}
+}
+
+// Replace 鍵リングの鍵を置き換える
+func (r *KeyRing) Replace(entries []KeyRingEntry) {
+	copied := make([]KeyRingEntry, len(entries))
+	copy(copied, entries)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = copied
+}
+
+// Keys nowの時点で検証に使用できる鍵の一覧を返す（引退した鍵は含めない）
+func (r *KeyRing) Keys(now time.Time) []*domain.SigningKey {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	keys := make([]*domain.SigningKey, 0, len(r.entries))
+	for _, entry := range r.entries {
+		if entry.Key.Verifies(now) {
+			copied := *entry.Key
+			keys = append(keys, &copied)
+		}
+	}
+	return keys
+}
+
+// signingKey nowの時点で署名に使用する鍵のkidと鍵を返す（無い場合はfalse）
+func (r *KeyRing) signingKey(now time.Time) (string, []byte, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	keys := make([]*domain.SigningKey, len(r.entries))
+	for i, entry := range r.entries {
+		keys[i] = entry.Key
+	}
+	current := domain.CurrentSigningKey(keys, now)
+	if current == nil {
+		return "", nil, false
+	}
+	for _, entry := range r.entries {
+		if entry.Key == current {
+			return current.ID.String(), entry.Secret, true
+		}
+	}
+	return "", nil, false
+}
+
+// verificationKey nowの時点でkidの鍵が検証に使用できる場合はその鍵を返す
+func (r *KeyRing) verificationKey(kid string, now time.Time) ([]byte, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, entry := range r.entries {
+		if entry.Key.ID.String() == kid && entry.Key.Verifies(now) {
+			return entry.Secret, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,51 @@
+// Package buildinfo ビルド時に埋め込むバージョン情報を提供します
+//
+// リリースビルドではldflagsで値を設定する:
+//
+//	go build -ldflags "-X github.com/aida0710/jwt-auth/internal/buildinfo.Version=1.4.0 \
+//	  -X github.com/aida0710/jwt-auth/internal/buildinfo.Commit=$(git rev-parse --short HEAD)" ./cmd
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// ビルド時にldflagsで上書きされる値
+var (
+	// Version アプリケーションのバージョン
+	Version = "dev"
+	// Commit ビルド元のGitコミット（未設定の場合はGoのVCS情報から補完）
+	Commit = ""
+)
+
+// Info ビルド情報
+type Info struct {
+	Version   string
+	Commit    string
+	GoVersion string
+}
+
+// Get 現在のバイナリのビルド情報を返す
+func Get() Info {
+	return Info{
+		Version:   Version,
+		Commit:    commit(),
+		GoVersion: runtime.Version(),
+	}
+}
+
+// commit ldflagsで設定されたコミット、無ければgo buildが埋め込んだvcs.revisionを返す
+func commit() string {
+	if Commit != "" {
+		return Commit
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" && setting.Value != "" {
+				return setting.Value
+			}
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/config"
+	"github.com/aida0710/jwt-auth/internal/domain"
+)
+
+// RotateSecretCommand アクセストークンの署名鍵をローテーションするサブコマンドの名前
+const RotateSecretCommand = "rotate-secret"
+
+// ErrKeyRotationDisabled 署名鍵のローテーション（JWT_KEY_ROTATION_INTERVAL）が無効の状態でrotate-secretサブコマンドを実行した
+var ErrKeyRotationDisabled = errors.New("signing key rotation is disabled (set JWT_KEY_ROTATION_INTERVAL)")
+
+// SecretRotator 署名鍵のローテーションを予定する（usecase.SigningKeyUsecase）
+type SecretRotator interface {
+	RotateNow(ctx context.Context, now time.Time) (*domain.SigningKey, error)
+}
+
+// CheckRotateSecretCommand rotate-secretサブコマンドを実行できる設定か確認する
+// ローテーションが無効のサーバーは保存された鍵を読み込まないため、鍵を追加しても使用されない
+func CheckRotateSecretCommand(cfg *config.Config) error {
+	if cfg.JWT.KeyRotationInterval <= 0 {
+		return ErrKeyRotationDisabled
+	}
+	return nil
+}
+
+// RunRotateSecret rotate-secretサブコマンドを実行する
+// 新しい署名鍵を保存して、稼働中のインスタンスが読み込んだ後に署名に使用するよう予定し、
+// 新しい鍵のkidと現在の鍵を検証に使用しなくなる日時をstdoutに出力する
+//
+//	jwt-auth rotate-secret
+func RunRotateSecret(ctx context.Context, cfg *config.Config, rotator SecretRotator, args []string, stdout, stderr io.Writer) error {
+	if err := CheckRotateSecretCommand(cfg); err != nil {
+		return err
+	}
+
+	flags := flag.NewFlagSet(RotateSecretCommand, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	key, err := rotator.RotateNow(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(stdout, "kid: %s\nactivates_at: %s\nprevious_key_retires_at: %s\n",
+		key.ID,
+		key.ActivatesAt.Format(time.RFC3339),
+		key.ActivatesAt.Add(cfg.KeyRotationOverlap()).Format(time.RFC3339),
+	)
+	return err
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/aida0710/jwt-auth/internal/config"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/google/uuid"
+)
+
+// TokenCommand デバッグ用のアクセストークンを発行するサブコマンドの名前
+const TokenCommand = "token"
+
+// ErrTokenCommandInProduction 本番環境（APP_ENV=production）でtokenサブコマンドを実行した
+var ErrTokenCommandInProduction = errors.New("the token command is disabled in production")
+
+// TokenIssuer デバッグ用のアクセストークンを発行する（usecase.AuthUsecase）
+type TokenIssuer interface {
+	IssueDebugAccessToken(ctx context.Context, input usecase.DebugTokenInput) (string, error)
+}
+
+// CheckTokenCommand tokenサブコマンドを実行できる環境か確認する
+// 本番環境ではログインを経ずにトークンを発行できないよう、コンテナの作成前に拒否する
+func CheckTokenCommand(cfg *config.Config) error {
+	if cfg.IsProduction() {
+		return ErrTokenCommandInProduction
+	}
+	return nil
+}
+
+// RunToken tokenサブコマンドを実行する
+// -account-id または -email で指定したアカウントのアクセストークンをstdoutに出力し、警告をstderrに出力する
+//
+//	jwt-auth token -email user@example.com
+func RunToken(ctx context.Context, cfg *config.Config, issuer TokenIssuer, args []string, stdout, stderr io.Writer) error {
+	if err := CheckTokenCommand(cfg); err != nil {
+		return err
+	}
+
+	flags := flag.NewFlagSet(TokenCommand, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	accountID := flags.String("account-id", "", "トークンを発行するアカウントのID")
+	email := flags.String("email", "", "トークンを発行するアカウントのメールアドレス")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if (*accountID == "") == (*email == "") {
+		return errors.New("specify exactly one of -account-id or -email")
+	}
+
+	var input usecase.DebugTokenInput
+	if *accountID != "" {
+		id, err := uuid.Parse(*accountID)
+		if err != nil {
+			return fmt.Errorf("invalid account ID: %w", err)
+		}
+		input.AccountID = id
+	} else {
+		input.Email = *email
+	}
+
+	token, err := issuer.IssueDebugAccessToken(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(stderr, "WARNING: this token was issued without authentication and is valid for %s. Use it only for debugging.\n", cfg.JWT.AccessTokenExpiry)
+	_, err = fmt.Fprintln(stdout, token)
+	return err
+}
@@ -2,21 +2,58 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/aida0710/jwt-auth/internal/domain"
 	"github.com/joho/godotenv"
 )
 
+// DefaultJWTIssuer JWT_ISSUERの既定値
+// すべての環境で共通のため、本番環境では環境ごとに異なる値の設定を必須とする
+const DefaultJWTIssuer = "jwt-auth-api"
+
+// データの保存先（DB_DRIVER）
+const (
+	DatabaseDriverMySQL  = "mysql"
+	DatabaseDriverMemory = "memory"
+)
+
+// minSecretLengths 署名アルゴリズムごとのシークレットの最小バイト数
+// HMACの鍵はハッシュの出力長未満だと強度がハッシュ長に見合わないため、出力長以上を要求する
+var minSecretLengths = map[string]int{
+	"HS256": 32,
+	"HS384": 48,
+	"HS512": 64,
+}
+
 // Config アプリケーション全体の設定を保持
 type Config struct {
-	Env      string
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	Logger   LoggerConfig
+	Env           string
+	Server        ServerConfig
+	Database      DatabaseConfig
+	JWT           JWTConfig
+	Logger        LoggerConfig
+	Signup        SignupConfig
+	Name          AccountNameConfig
+	Project       ProjectConfig
+	Privacy       PrivacyConfig
+	AccountList   AccountListConfig
+	Password      PasswordConfig
+	Deletion      AccountDeletionConfig
+	Cleanup       TokenCleanupConfig
+	Lockout       LockoutConfig
+	LoginAlert    LoginAlertConfig
+	Audit         SecurityAuditConfig
+	MagicLink     MagicLinkConfig
+	PasswordReset PasswordResetConfig
+	Admin         AdminConfig
+	ID            IDConfig
+	RateLimit     RateLimitConfig
+	Compression   CompressionConfig
 }
 
 // ServerConfig サーバー関連の設定
@@ -25,10 +62,25 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	IdleTimeout  time.Duration
+
+	// ShutdownTimeout グレースフルシャットダウンで処理中のリクエストを待つ最大時間
+	ShutdownTimeout time.Duration
+
+	// TrustedProxies X-Forwarded-Forを信頼するプロキシのCIDR（空の場合は接続元アドレスを使用）
+	TrustedProxies []string
+
+	// MaxInflightRequests 同時に処理するリクエストの上限（0の場合はコネクションプールの大きさから決める、負の値で無効）
+	MaxInflightRequests int
+	// InflightPerConnection 上限を省略した場合の、データベースのコネクション1つあたりの同時リクエスト数
+	InflightPerConnection int
 }
 
 // DatabaseConfig データベース関連の設定
 type DatabaseConfig struct {
+	// Driver データの保存先（mysql / memory）
+	// memoryはプロセス内にのみ保持するため、テストやローカル開発でのみ使用する
+	Driver string
+
 	Host            string
 	Port            int
 	User            string
@@ -37,6 +89,13 @@ type DatabaseConfig struct {
 	MaxOpenConns    int
 	MaxIdleConns    int
 	ConnMaxLifetime time.Duration
+
+	// QueryTimeout 1クエリあたりのタイムアウト（0で無効）
+	QueryTimeout time.Duration
+
+	// ExposePoolStats レディネスチェックでコネクションプールの統計を誰にでも返すかどうか
+	// 内部ネットワークからのみ到達できる環境向け（無効の場合は管理者のみ）
+	ExposePoolStats bool
 }
 
 // JWTConfig JWT関連の設定
@@ -46,13 +105,236 @@ type JWTConfig struct {
 	AccessTokenExpiry  time.Duration
 	RefreshTokenExpiry time.Duration
 	Issuer             string   // JWT発行者
-	Audience           []string // JWT受信者リスト
+	Audience           []string // JWT受信者リスト（発行するトークンに設定）
+	// IssuerURL 外部から到達できるこのサーバーのURL（設定した場合のみディスカバリードキュメントを公開）
+	IssuerURL string
+	// AudienceMatchMode Audienceの検証方法（exact: 完全一致, any: いずれかが一致）
+	AudienceMatchMode string
+	// AcceptedAudiences Audienceに加えて検証時に受け入れるAudience（Audienceの移行中に移行前の値を設定）
+	AcceptedAudiences []string
+	// AccessTokenType アクセストークンに要求するtypヘッダー（例: JWT, at+jwt）
+	AccessTokenType string
+	// RefreshTokenType リフレッシュトークンに要求するtypヘッダー（空の場合は検証しない）
+	RefreshTokenType string
+	// SigningAlgorithm 署名アルゴリズム（HS256/HS384/HS512）
+	SigningAlgorithm string
+	// RefreshTokenIssuer / RefreshTokenAudience リフレッシュトークンのIssuerとAudience（空の場合はアクセストークンと同じ）
+	RefreshTokenIssuer   string
+	RefreshTokenAudience []string
+	// AcceptedIssuers Issuerに加えて検証時に受け入れるIssuer（Issuerの移行中に移行前の値を設定）
+	AcceptedIssuers []string
+	// VerifyOnly 有効にするとトークンを発行せず、アクセストークンの検証のみを行う（リフレッシュトークンのシークレットは不要）
+	VerifyOnly bool
+	// KeyRotationInterval アクセストークンの署名鍵をローテーションする間隔（0で無効、AccessTokenSecretで署名する）
+	KeyRotationInterval time.Duration
+	// KeyRotationOverlap 署名に使用しなくなった鍵を検証に使用し続ける期間（0の場合はAccessTokenExpiry）
+	KeyRotationOverlap time.Duration
+	// KeyRotationCheckInterval ローテーションの確認と保存された鍵の読み込み直しの間隔
+	// 新しい鍵は作成してからこの期間が過ぎてから署名に使用し、その間にすべてのインスタンスが読み込む
+	KeyRotationCheckInterval time.Duration
+
+	// RefreshTokenSliding 有効にするとリフレッシュのたびに有効期限を延長する（最大RefreshTokenMaxLifetimeまで）
+	RefreshTokenSliding bool
+	// RefreshTokenMaxLifetime スライディング方式でのトークンファミリーの絶対有効期限
+	RefreshTokenMaxLifetime time.Duration
+	// RefreshTokenReuseGrace ローテーション直後の使用済みトークンの再提示を再試行として許可する期間（0で無効）
+	RefreshTokenReuseGrace time.Duration
+	// RefreshRateLimit RefreshRateWindow内に1つのアカウントで許可するリフレッシュの上限（0で無効）
+	// 盗まれたリフレッシュトークンによるトークンの大量発行を検知して一時的に拒否する
+	RefreshRateLimit int
+	// RefreshRateWindow リフレッシュの回数を数える期間
+	RefreshRateWindow time.Duration
+}
+
+// SignupConfig サインアップ関連の設定
+type SignupConfig struct {
+	// Enabled 無効にすると招待の無いサインアップを拒否する（招待制、管理者によるアカウント作成は可能）
+	Enabled bool
+	// InviteExpiry 管理者が作成する招待の有効期限
+	InviteExpiry time.Duration
+	// EmailDomainBlocklistFile 登録を拒否するメールドメインの一覧ファイル（空の場合は使用しない）
+	EmailDomainBlocklistFile string
+	// EmailDomainCheckMX 有効にするとMXレコードの無いドメインを拒否する
+	EmailDomainCheckMX bool
+	// DefaultRole 招待の無いサインアップで割り当てるロール
+	DefaultRole string
+	// SelfAssignableRoles サインアップのリクエストで自分で選べるロール（空の場合はロールの指定を無視する）
+	SelfAssignableRoles []string
+	// UsernameLogin 有効にするとサインアップでユーザー名を設定でき、メールアドレスの代わりにユーザー名でログインできる
+	UsernameLogin bool
+}
+
+// AccountNameConfig アカウント名の検証ルールの設定（サインアップと更新に適用）
+type AccountNameConfig struct {
+	// MinLength / MaxLength 前後の空白を除いた文字数の範囲
+	MinLength int
+	MaxLength int
+	// AllowedClasses 使用できる文字の種類（letter, mark, number, punctuation, symbol, space）
+	AllowedClasses []string
+	// RejectMarkup 有効にするとHTMLとして解釈されうる文字を拒否する
+	RejectMarkup bool
+	// BlockedWords 名前に含めることを禁止する語
+	BlockedWords []string
+}
+
+// PrivacyConfig レスポンスに含める個人情報の設定
+type PrivacyConfig struct {
+	// ListEmailMasking アカウント一覧でメールアドレスを伏せる対象
+	// off: 伏せない、unprivileged: 管理者以外、all: 管理者を含むすべて（完全なメールアドレスは個別の取得でのみ返す）
+	ListEmailMasking string
+}
+
+// AccountListConfig アカウント一覧（GET /api/v1/accounts）のページングの設定
+type AccountListConfig struct {
+	// DefaultLimit 件数の指定が無い場合に返す件数
+	DefaultLimit int
+	// MaxLimit 1回に返す件数の上限（超える指定は上限に切り詰める）
+	MaxLimit int
+}
+
+// ProjectConfig プロジェクト関連の設定
+type ProjectConfig struct {
+	// DescriptionMaxLength 説明の最大文字数（制御文字を取り除いた後の文字数）
+	DescriptionMaxLength int
+	// UniqueNames 有効にするとアカウント内でプロジェクト名の重複を禁止する
+	UniqueNames bool
+}
+
+// PasswordConfig パスワード関連の設定
+type PasswordConfig struct {
+	// HistorySize パスワード変更時に再利用を禁止する過去のパスワード数（0で現在のパスワードのみ禁止）
+	HistorySize int
+	// Peppers ハッシュ化の前に適用するペッパー（"バージョン:秘密の値"、先頭が現在のペッパー、空の場合は適用しない）
+	Peppers []string
+}
+
+// AccountDeletionConfig アカウント削除の猶予期間の設定
+type AccountDeletionConfig struct {
+	// GracePeriod 削除を予約してから完全に削除するまでの猶予期間（期間中はログインを拒否し、復元できる）
+	GracePeriod time.Duration
+	// PurgeInterval 猶予期間が過ぎたアカウントを完全に削除する間隔
+	PurgeInterval time.Duration
+}
+
+// TokenCleanupConfig 有効期限切れのリフレッシュトークンを削除する設定
+type TokenCleanupConfig struct {
+	// Interval 有効期限切れのトークンを削除する間隔
+	Interval time.Duration
+	// BatchSize 1回のDELETEで削除する最大件数
+	BatchSize int
+	// BatchPause バッチの間に待つ時間（データベースの負荷とレプリケーション遅延を抑える）
+	BatchPause time.Duration
+}
+
+// LockoutConfig ログイン失敗によるロックアウトの設定
+type LockoutConfig struct {
+	// MaxFailedAttempts ロックするまでに許容する連続失敗回数（0で無効）
+	MaxFailedAttempts int
+	// BaseCooldown 最初のロックの期間（ロックを繰り返すたびに倍増する）
+	BaseCooldown time.Duration
+	// MaxCooldown ロック期間の上限
+	MaxCooldown time.Duration
+	// QuietPeriod 失敗の無い状態がこの期間続くとロック期間の倍増をリセットする
+	QuietPeriod time.Duration
+}
+
+// LoginAlertConfig 新しい端末・場所からのログインの通知の設定
+type LoginAlertConfig struct {
+	// NewDeviceMatch 過去のセッションと比較する厳しさ
+	// off: 判定しない、lenient: 端末またはサブネットが一致すれば既知、strict: 端末とサブネットの両方が一致する場合のみ既知
+	NewDeviceMatch string
+}
+
+// SecurityAuditConfig セキュリティ監査ログの設定
+type SecurityAuditConfig struct {
+	// Enabled 無効にすると監査ログを保存しない（アラートのログ出力は行う）
+	Enabled bool
+	// BufferSize 保存に失敗した監査ログをメモリに保持して再試行する最大件数（超過すると古いものから破棄する）
+	BufferSize int
+	// RetryInterval 保持した監査ログの保存を再試行する間隔
+	RetryInterval time.Duration
+}
+
+// MagicLinkConfig パスワード不要のログイン用リンク（マジックリンク）の設定
+type MagicLinkConfig struct {
+	// Expiry リンクの有効期限
+	Expiry time.Duration
+	// MaxRequests Window内に1つのメールアドレスへ送信するリンクの上限（超過した要求は送信せずに成功として扱う）
+	MaxRequests int
+	// Window 送信数を数える期間
+	Window time.Duration
+	// URL メールに記載するリンクのURL（tokenクエリを付与する、空の場合はトークンのみを記載）
+	URL string
+}
+
+// PasswordResetConfig メールで送信するパスワード再設定用のトークンの設定
+type PasswordResetConfig struct {
+	// Expiry トークンの有効期限（新しいトークンを発行すると以前のトークンは期限内でも無効になる）
+	Expiry time.Duration
+	// URL メールに記載するリンクのURL（tokenクエリを付与する、空の場合はトークンのみを記載）
+	URL string
+}
+
+// AdminConfig 起動時に作成する管理者アカウントの設定
+type AdminConfig struct {
+	// Email 管理者のメールアドレス（空の場合は作成しない）
+	Email    string
+	Password string
+	Name     string
+
+	// IPAllowlist 管理者エンドポイントへのアクセスを許可するCIDR（空の場合はすべて許可）
+	IPAllowlist []string
+	// IPDenylist 管理者エンドポイントへのアクセスを拒否するCIDR（許可より優先）
+	IPDenylist []string
+}
+
+// IDConfig エンティティIDの設定
+type IDConfig struct {
+	// UUIDVersion 新規IDに使用するUUIDのバージョン（4または7）
+	UUIDVersion int
+	// StrictVersion 有効にするとUUIDVersion以外のIDを拒否する（無効時はv4とv7を両方受け付ける）
+	StrictVersion bool
+}
+
+// RateLimitConfig レート制限の設定
+type RateLimitConfig struct {
+	// Enabled 有効にすると対象パスへのリクエストをAPIキー・アカウント・接続元アドレスごとに制限する
+	Enabled bool
+	// Requests Window内に許可するリクエスト数
+	Requests int
+	// Window リクエスト数を数える期間
+	Window time.Duration
+	// Paths 制限を適用するパス（前方一致）
+	Paths []string
+	// TrustedKeys 信頼済みのサービスアカウントのAPIキー（"名前:キー:リクエスト数"、キーごとの上限で制限する）
+	TrustedKeys []string
+}
+
+// CompressionConfig レスポンス圧縮の設定
+type CompressionConfig struct {
+	// Level gzipの圧縮レベル（-1で既定、1〜9）
+	Level int
+	// MinLength 圧縮するレスポンスボディの最小バイト数
+	MinLength int
+	// SkipContentTypes 圧縮しないContent-Type（/で終わる場合は前方一致）
+	SkipContentTypes []string
 }
 
 // LoggerConfig ロガー関連の設定
 type LoggerConfig struct {
 	Level  string
 	Format string // jsonまたはtext
+	Output string // stdout、stderr、またはファイルパス
+
+	// ファイル出力時のローテーション設定
+	MaxSizeMB  int           // ローテーションするファイルサイズ（MB）
+	MaxAge     time.Duration // ローテーション済みファイルの保持期間
+	MaxBackups int           // ローテーション済みファイルの保持数
+
+	// RedactKeys 値をマスクするフィールドのキー
+	RedactKeys []string
+	// PrivacyMode 有効にするとメールアドレスをハッシュ化して出力する
+	PrivacyMode bool
 }
 
 // LoadConfig 環境変数から設定を読み込む
@@ -67,8 +349,15 @@ func LoadConfig() (*Config, error) {
 			ReadTimeout:  getDurationEnv("SERVER_READ_TIMEOUT", 10*time.Second),
 			WriteTimeout: getDurationEnv("SERVER_WRITE_TIMEOUT", 10*time.Second),
 			IdleTimeout:  getDurationEnv("SERVER_IDLE_TIMEOUT", 60*time.Second),
+
+			ShutdownTimeout: getDurationEnv("SERVER_SHUTDOWN_TIMEOUT", 10*time.Second),
+			TrustedProxies:  getSliceEnv("TRUSTED_PROXIES", nil),
+
+			MaxInflightRequests:   getIntEnv("SERVER_MAX_INFLIGHT_REQUESTS", 0),
+			InflightPerConnection: getIntEnv("SERVER_INFLIGHT_PER_CONNECTION", 4),
 		},
 		Database: DatabaseConfig{
+			Driver:          getEnv("DB_DRIVER", DatabaseDriverMySQL),
 			Host:            getEnv("DB_HOST", "localhost"),
 			Port:            getIntEnv("DB_PORT", 3306),
 			User:            getEnv("DB_USER", "root"),
@@ -77,18 +366,138 @@ func LoadConfig() (*Config, error) {
 			MaxOpenConns:    getIntEnv("DB_MAX_OPEN_CONNS", 25),
 			MaxIdleConns:    getIntEnv("DB_MAX_IDLE_CONNS", 25),
 			ConnMaxLifetime: getDurationEnv("DB_CONN_MAX_LIFETIME", 5*time.Minute),
+			QueryTimeout:    getDurationEnv("DB_QUERY_TIMEOUT", 5*time.Second),
+			ExposePoolStats: getBoolEnv("DB_EXPOSE_POOL_STATS", false),
 		},
 		JWT: JWTConfig{
 			AccessTokenSecret:  getEnv("JWT_ACCESS_TOKEN_SECRET", getEnv("JWT_SECRET", "secret")),
 			RefreshTokenSecret: getEnv("JWT_REFRESH_TOKEN_SECRET", getEnv("JWT_SECRET", "secret")),
 			AccessTokenExpiry:  getDurationEnv("JWT_ACCESS_TOKEN_EXPIRY", 1*time.Hour),
 			RefreshTokenExpiry: getDurationEnv("JWT_REFRESH_TOKEN_EXPIRY", 30*24*time.Hour),
-			Issuer:             getEnv("JWT_ISSUER", "jwt-auth-api"),
+			Issuer:             getEnv("JWT_ISSUER", DefaultJWTIssuer),
+			IssuerURL:          getEnv("JWT_ISSUER_URL", ""),
 			Audience:           getSliceEnv("JWT_AUDIENCE", []string{"jwt-auth-api"}),
+			AudienceMatchMode:  getEnv("JWT_AUDIENCE_MATCH_MODE", "exact"),
+			AcceptedAudiences:  getSliceEnv("JWT_ACCEPTED_AUDIENCES", nil),
+			AccessTokenType:    getEnv("JWT_ACCESS_TOKEN_TYPE", "JWT"),
+			RefreshTokenType:   getEnv("JWT_REFRESH_TOKEN_TYPE", ""),
+			SigningAlgorithm:   getEnv("JWT_SIGNING_ALGORITHM", "HS256"),
+
+			RefreshTokenIssuer:   getEnv("JWT_REFRESH_TOKEN_ISSUER", ""),
+			RefreshTokenAudience: getSliceEnv("JWT_REFRESH_TOKEN_AUDIENCE", nil),
+			AcceptedIssuers:      getSliceEnv("JWT_ACCEPTED_ISSUERS", nil),
+			VerifyOnly:           getBoolEnv("JWT_VERIFY_ONLY", false),
+
+			KeyRotationInterval:      getDurationEnv("JWT_KEY_ROTATION_INTERVAL", 0),
+			KeyRotationOverlap:       getDurationEnv("JWT_KEY_ROTATION_OVERLAP", 0),
+			KeyRotationCheckInterval: getDurationEnv("JWT_KEY_ROTATION_CHECK_INTERVAL", time.Minute),
+
+			RefreshTokenSliding:     getBoolEnv("JWT_REFRESH_TOKEN_SLIDING", false),
+			RefreshTokenMaxLifetime: getDurationEnv("JWT_REFRESH_TOKEN_MAX_LIFETIME", 90*24*time.Hour),
+			RefreshTokenReuseGrace:  getDurationEnv("JWT_REFRESH_TOKEN_REUSE_GRACE", 0),
+			RefreshRateLimit:        getIntEnv("JWT_REFRESH_RATE_LIMIT", 0),
+			RefreshRateWindow:       getDurationEnv("JWT_REFRESH_RATE_WINDOW", time.Minute),
 		},
 		Logger: LoggerConfig{
 			Level:  getEnv("LOG_LEVEL", "info"),
 			Format: getEnv("LOG_FORMAT", "json"),
+			Output: getEnv("LOG_OUTPUT", "stdout"),
+
+			MaxSizeMB:  getIntEnv("LOG_MAX_SIZE_MB", 100),
+			MaxAge:     getDurationEnv("LOG_MAX_AGE", 7*24*time.Hour),
+			MaxBackups: getIntEnv("LOG_MAX_BACKUPS", 5),
+
+			RedactKeys:  getSliceEnv("LOG_REDACT_KEYS", []string{"password", "token", "access_token", "refresh_token", "authorization"}),
+			PrivacyMode: getBoolEnv("LOG_PRIVACY_MODE", false),
+		},
+		Signup: SignupConfig{
+			Enabled:                  getBoolEnv("SIGNUP_ENABLED", true),
+			InviteExpiry:             getDurationEnv("INVITE_EXPIRY", 7*24*time.Hour),
+			EmailDomainBlocklistFile: getEnv("EMAIL_DOMAIN_BLOCKLIST_FILE", ""),
+			EmailDomainCheckMX:       getBoolEnv("EMAIL_DOMAIN_CHECK_MX", false),
+			DefaultRole:              getEnv("SIGNUP_DEFAULT_ROLE", "user"),
+			SelfAssignableRoles:      getSliceEnv("SIGNUP_SELF_ASSIGNABLE_ROLES", nil),
+			UsernameLogin:            getBoolEnv("USERNAME_LOGIN_ENABLED", false),
+		},
+		Name: AccountNameConfig{
+			MinLength:      getIntEnv("ACCOUNT_NAME_MIN_LENGTH", 1),
+			MaxLength:      getIntEnv("ACCOUNT_NAME_MAX_LENGTH", 255),
+			AllowedClasses: getSliceEnv("ACCOUNT_NAME_ALLOWED_CLASSES", []string{"letter", "mark", "number", "punctuation", "symbol", "space"}),
+			RejectMarkup:   getBoolEnv("ACCOUNT_NAME_REJECT_MARKUP", false),
+			BlockedWords:   getSliceEnv("ACCOUNT_NAME_BLOCKED_WORDS", nil),
+		},
+		Project: ProjectConfig{
+			DescriptionMaxLength: getIntEnv("PROJECT_DESCRIPTION_MAX_LENGTH", 2000),
+			UniqueNames:          getBoolEnv("PROJECT_UNIQUE_NAMES", false),
+		},
+		Privacy: PrivacyConfig{
+			ListEmailMasking: getEnv("ACCOUNT_LIST_EMAIL_MASKING", "off"),
+		},
+		AccountList: AccountListConfig{
+			DefaultLimit: getIntEnv("ACCOUNT_LIST_DEFAULT_LIMIT", 20),
+			MaxLimit:     getIntEnv("ACCOUNT_LIST_MAX_LIMIT", 100),
+		},
+		Password: PasswordConfig{
+			HistorySize: getIntEnv("PASSWORD_HISTORY_SIZE", 5),
+			Peppers:     getSliceEnv("PASSWORD_PEPPERS", nil),
+		},
+		Deletion: AccountDeletionConfig{
+			GracePeriod:   getDurationEnv("ACCOUNT_DELETION_GRACE_PERIOD", 30*24*time.Hour),
+			PurgeInterval: getDurationEnv("ACCOUNT_PURGE_INTERVAL", time.Hour),
+		},
+		Cleanup: TokenCleanupConfig{
+			Interval:   getDurationEnv("REFRESH_TOKEN_CLEANUP_INTERVAL", time.Hour),
+			BatchSize:  getIntEnv("REFRESH_TOKEN_CLEANUP_BATCH_SIZE", 1000),
+			BatchPause: getDurationEnv("REFRESH_TOKEN_CLEANUP_BATCH_PAUSE", 100*time.Millisecond),
+		},
+		Lockout: LockoutConfig{
+			MaxFailedAttempts: getIntEnv("LOGIN_LOCKOUT_MAX_ATTEMPTS", 5),
+			BaseCooldown:      getDurationEnv("LOGIN_LOCKOUT_BASE_COOLDOWN", time.Minute),
+			MaxCooldown:       getDurationEnv("LOGIN_LOCKOUT_MAX_COOLDOWN", time.Hour),
+			QuietPeriod:       getDurationEnv("LOGIN_LOCKOUT_QUIET_PERIOD", 24*time.Hour),
+		},
+		LoginAlert: LoginAlertConfig{
+			NewDeviceMatch: getEnv("LOGIN_NEW_DEVICE_MATCH", "lenient"),
+		},
+		Audit: SecurityAuditConfig{
+			Enabled:       getBoolEnv("SECURITY_AUDIT_ENABLED", true),
+			BufferSize:    getIntEnv("SECURITY_AUDIT_BUFFER_SIZE", 1000),
+			RetryInterval: getDurationEnv("SECURITY_AUDIT_RETRY_INTERVAL", 30*time.Second),
+		},
+		MagicLink: MagicLinkConfig{
+			Expiry:      getDurationEnv("MAGIC_LINK_EXPIRY", 15*time.Minute),
+			MaxRequests: getIntEnv("MAGIC_LINK_MAX_REQUESTS", 3),
+			Window:      getDurationEnv("MAGIC_LINK_WINDOW", time.Hour),
+			URL:         getEnv("MAGIC_LINK_URL", ""),
+		},
+		PasswordReset: PasswordResetConfig{
+			Expiry: getDurationEnv("PASSWORD_RESET_EXPIRY", time.Hour),
+			URL:    getEnv("PASSWORD_RESET_URL", ""),
+		},
+		ID: IDConfig{
+			UUIDVersion:   getIntEnv("ID_UUID_VERSION", 7),
+			StrictVersion: getBoolEnv("ID_STRICT_VERSION", false),
+		},
+		Admin: AdminConfig{
+			Email:    getEnv("ADMIN_EMAIL", ""),
+			Password: getEnv("ADMIN_PASSWORD", ""),
+			Name:     getEnv("ADMIN_NAME", "Administrator"),
+
+			IPAllowlist: getSliceEnv("ADMIN_IP_ALLOWLIST", nil),
+			IPDenylist:  getSliceEnv("ADMIN_IP_DENYLIST", nil),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:  getBoolEnv("RATE_LIMIT_ENABLED", true),
+			Requests: getIntEnv("RATE_LIMIT_REQUESTS", 10),
+			Window:   getDurationEnv("RATE_LIMIT_WINDOW", time.Minute),
+			Paths:    getSliceEnv("RATE_LIMIT_PATHS", []string{"/api/v1/auth/signup", "/api/v1/auth/login", "/api/v1/auth/refresh", "/api/v1/auth/magic-link", "/api/v1/auth/password-reset"}),
+
+			TrustedKeys: getSliceEnv("RATE_LIMIT_TRUSTED_KEYS", nil),
+		},
+		Compression: CompressionConfig{
+			Level:            getIntEnv("COMPRESSION_LEVEL", -1),
+			MinLength:        getIntEnv("COMPRESSION_MIN_LENGTH", 1024),
+			SkipContentTypes: getSliceEnv("COMPRESSION_SKIP_CONTENT_TYPES", []string{"image/", "video/", "audio/", "font/woff2", "application/zip", "application/gzip"}),
 		},
 	}
 
@@ -102,16 +511,149 @@ func LoadConfig() (*Config, error) {
 
 // Validate 設定の妥当性を検証
 func (c *Config) Validate() error {
+	switch c.Database.Driver {
+	case DatabaseDriverMySQL:
+	case DatabaseDriverMemory:
+		// 再起動でアカウントやトークンが失われるため、本番環境では使用できない
+		if c.Env == "production" {
+			return fmt.Errorf("DB_DRIVER=memory is not allowed in production environment")
+		}
+	default:
+		return fmt.Errorf("DB_DRIVER must be one of mysql, memory")
+	}
+
 	if c.Database.Password == "" && c.Env == "production" {
 		return fmt.Errorf("DB_PASSWORD is required in production environment")
 	}
 
-	// JWT秘密鍵の長さをチェック（最小32文字）
-	if len(c.JWT.AccessTokenSecret) < 32 {
-		return fmt.Errorf("JWT_ACCESS_TOKEN_SECRET must be at least 32 characters long")
+	if c.Server.ShutdownTimeout <= 0 {
+		return fmt.Errorf("SERVER_SHUTDOWN_TIMEOUT must be positive")
+	}
+	if c.Server.InflightPerConnection < 1 {
+		return fmt.Errorf("SERVER_INFLIGHT_PER_CONNECTION must be positive")
+	}
+
+	if c.Database.QueryTimeout < 0 {
+		return fmt.Errorf("DB_QUERY_TIMEOUT must not be negative")
+	}
+
+	if c.ID.UUIDVersion != 4 && c.ID.UUIDVersion != 7 {
+		return fmt.Errorf("ID_UUID_VERSION must be 4 or 7")
+	}
+
+	// 猶予期間は再試行を想定した短い時間に限定する
+	if c.JWT.RefreshTokenReuseGrace < 0 || c.JWT.RefreshTokenReuseGrace > time.Minute {
+		return fmt.Errorf("JWT_REFRESH_TOKEN_REUSE_GRACE must be between 0 and 1m")
+	}
+	if c.JWT.RefreshRateLimit < 0 {
+		return fmt.Errorf("JWT_REFRESH_RATE_LIMIT must not be negative")
+	}
+	if c.JWT.RefreshRateLimit > 0 && c.JWT.RefreshRateWindow <= 0 {
+		return fmt.Errorf("JWT_REFRESH_RATE_WINDOW must be positive")
+	}
+
+	// accounts.nameはVARCHAR(255)のため、それを超える最大文字数は設定できない
+	if c.Name.MinLength < 1 || c.Name.MaxLength < c.Name.MinLength || c.Name.MaxLength > 255 {
+		return fmt.Errorf("ACCOUNT_NAME_MIN_LENGTH and ACCOUNT_NAME_MAX_LENGTH must satisfy 1 <= min <= max <= 255")
+	}
+
+	// projects.descriptionはTEXTのため、4バイト文字のみでも収まる文字数までに制限する
+	if c.Project.DescriptionMaxLength < 1 || c.Project.DescriptionMaxLength > 16000 {
+		return fmt.Errorf("PROJECT_DESCRIPTION_MAX_LENGTH must satisfy 1 <= max <= 16000")
+	}
+
+	if c.Password.HistorySize < 0 {
+		return fmt.Errorf("PASSWORD_HISTORY_SIZE must not be negative")
+	}
+
+	if c.Deletion.GracePeriod <= 0 || c.Deletion.PurgeInterval <= 0 {
+		return fmt.Errorf("ACCOUNT_DELETION_GRACE_PERIOD and ACCOUNT_PURGE_INTERVAL must be positive")
+	}
+
+	if c.Cleanup.Interval <= 0 || c.Cleanup.BatchSize <= 0 {
+		return fmt.Errorf("REFRESH_TOKEN_CLEANUP_INTERVAL and REFRESH_TOKEN_CLEANUP_BATCH_SIZE must be positive")
+	}
+	if c.Cleanup.BatchPause < 0 {
+		return fmt.Errorf("REFRESH_TOKEN_CLEANUP_BATCH_PAUSE must not be negative")
+	}
+
+	if c.Lockout.MaxFailedAttempts < 0 {
+		return fmt.Errorf("LOGIN_LOCKOUT_MAX_ATTEMPTS must not be negative")
+	}
+	if c.Lockout.MaxFailedAttempts > 0 {
+		if c.Lockout.BaseCooldown <= 0 || c.Lockout.MaxCooldown < c.Lockout.BaseCooldown {
+			return fmt.Errorf("LOGIN_LOCKOUT_BASE_COOLDOWN and LOGIN_LOCKOUT_MAX_COOLDOWN must satisfy 0 < base <= max")
+		}
+		if c.Lockout.QuietPeriod <= 0 {
+			return fmt.Errorf("LOGIN_LOCKOUT_QUIET_PERIOD must be positive")
+		}
+	}
+
+	if c.Audit.Enabled && (c.Audit.BufferSize <= 0 || c.Audit.RetryInterval <= 0) {
+		return fmt.Errorf("SECURITY_AUDIT_BUFFER_SIZE and SECURITY_AUDIT_RETRY_INTERVAL must be positive when the security audit log is enabled")
+	}
+
+	switch c.Privacy.ListEmailMasking {
+	case "off", "unprivileged", "all":
+	default:
+		return fmt.Errorf("ACCOUNT_LIST_EMAIL_MASKING must be one of off, unprivileged, all: %q", c.Privacy.ListEmailMasking)
+	}
+
+	if c.AccountList.DefaultLimit < 1 || c.AccountList.MaxLimit < c.AccountList.DefaultLimit {
+		return fmt.Errorf("ACCOUNT_LIST_DEFAULT_LIMIT must be positive and ACCOUNT_LIST_MAX_LIMIT must not be less than it")
 	}
-	if len(c.JWT.RefreshTokenSecret) < 32 {
-		return fmt.Errorf("JWT_REFRESH_TOKEN_SECRET must be at least 32 characters long")
+
+	switch c.LoginAlert.NewDeviceMatch {
+	case "off", "lenient", "strict":
+	default:
+		return fmt.Errorf("LOGIN_NEW_DEVICE_MATCH must be one of off, lenient, strict: %q", c.LoginAlert.NewDeviceMatch)
+	}
+
+	if c.Signup.InviteExpiry <= 0 {
+		return fmt.Errorf("INVITE_EXPIRY must be positive")
+	}
+
+	// 管理者ロールはサインアップで割り当てない（招待または管理者によるアカウント作成のみ）
+	if err := c.Signup.RolePolicy().Validate(); err != nil {
+		return fmt.Errorf("SIGNUP_DEFAULT_ROLE and SIGNUP_SELF_ASSIGNABLE_ROLES must be defined roles other than admin: %w", err)
+	}
+
+	if c.MagicLink.Expiry <= 0 || c.MagicLink.MaxRequests <= 0 || c.MagicLink.Window <= 0 {
+		return fmt.Errorf("MAGIC_LINK_EXPIRY, MAGIC_LINK_MAX_REQUESTS and MAGIC_LINK_WINDOW must be positive")
+	}
+
+	if c.PasswordReset.Expiry <= 0 {
+		return fmt.Errorf("PASSWORD_RESET_EXPIRY must be positive")
+	}
+
+	if c.RateLimit.Enabled && (c.RateLimit.Requests <= 0 || c.RateLimit.Window <= 0) {
+		return fmt.Errorf("RATE_LIMIT_REQUESTS and RATE_LIMIT_WINDOW must be positive when rate limiting is enabled")
+	}
+
+	// 0は圧縮なしではなく既定値として扱われるため、明示的な指定は-1または1〜9に限定する
+	if c.Compression.Level != -1 && (c.Compression.Level < 1 || c.Compression.Level > 9) {
+		return fmt.Errorf("COMPRESSION_LEVEL must be -1 or between 1 and 9")
+	}
+	if c.Compression.MinLength < 0 {
+		return fmt.Errorf("COMPRESSION_MIN_LENGTH must not be negative")
+	}
+
+	// 管理者を作成する場合はパスワードが必須
+	if c.Admin.Email != "" && len(c.Admin.Password) < 8 {
+		return fmt.Errorf("ADMIN_PASSWORD must be at least 8 characters long when ADMIN_EMAIL is set")
+	}
+
+	// JWT秘密鍵の長さをチェック（署名アルゴリズムのハッシュ長以上）
+	minSecretLength, ok := minSecretLengths[c.JWT.SigningAlgorithm]
+	if !ok {
+		return fmt.Errorf("JWT_SIGNING_ALGORITHM must be HS256, HS384 or HS512")
+	}
+	if len(c.JWT.AccessTokenSecret) < minSecretLength {
+		return fmt.Errorf("JWT_ACCESS_TOKEN_SECRET must be at least %d bytes long for %s (got %d)", minSecretLength, c.JWT.SigningAlgorithm, len(c.JWT.AccessTokenSecret))
+	}
+	// 検証のみの構成ではリフレッシュトークンに署名・検証しないため、シークレットを配布しなくてよい
+	if !c.JWT.VerifyOnly && len(c.JWT.RefreshTokenSecret) < minSecretLength {
+		return fmt.Errorf("JWT_REFRESH_TOKEN_SECRET must be at least %d bytes long for %s (got %d)", minSecretLength, c.JWT.SigningAlgorithm, len(c.JWT.RefreshTokenSecret))
 	}
 
 	// Issuerが空でないことを確認
@@ -119,14 +661,130 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("JWT_ISSUER cannot be empty")
 	}
 
+	// 既定のIssuerのままだと、シークレットが漏洩した場合に他の環境で発行したトークンが本番環境でも通ってしまう
+	if c.IsProduction() && c.JWT.Issuer == DefaultJWTIssuer {
+		return fmt.Errorf("JWT_ISSUER must be set to an environment-specific value in production (e.g. %s-production)", DefaultJWTIssuer)
+	}
+
+	// ディスカバリードキュメントの各エンドポイントのURLの基点になるため、絶対URLに限定する
+	if c.JWT.IssuerURL != "" {
+		issuerURL, err := url.Parse(c.JWT.IssuerURL)
+		if err != nil || (issuerURL.Scheme != "https" && issuerURL.Scheme != "http") || issuerURL.Host == "" || issuerURL.RawQuery != "" || issuerURL.Fragment != "" {
+			return fmt.Errorf("JWT_ISSUER_URL must be an absolute http(s) URL without query or fragment")
+		}
+	}
+
 	// Audienceが少なくとも1つの値を持つことを確認
 	if len(c.JWT.Audience) == 0 {
 		return fmt.Errorf("JWT_AUDIENCE must have at least one value")
 	}
 
+	if c.JWT.AudienceMatchMode != "exact" && c.JWT.AudienceMatchMode != "any" {
+		return fmt.Errorf("JWT_AUDIENCE_MATCH_MODE must be exact or any")
+	}
+
+	// アクセストークンのtypは常に検証するため空は許可しない
+	if strings.TrimSpace(c.JWT.AccessTokenType) == "" {
+		return fmt.Errorf("JWT_ACCESS_TOKEN_TYPE cannot be empty")
+	}
+
+	// スライディング方式では絶対有効期限が1回分の有効期限以上である必要がある
+	if c.JWT.RefreshTokenSliding && c.JWT.RefreshTokenMaxLifetime < c.JWT.RefreshTokenExpiry {
+		return fmt.Errorf("JWT_REFRESH_TOKEN_MAX_LIFETIME must be greater than or equal to JWT_REFRESH_TOKEN_EXPIRY")
+	}
+
+	if err := c.validateKeyRotation(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// validateKeyRotation 署名鍵のローテーションの設定を検証
+func (c *Config) validateKeyRotation() error {
+	if c.JWT.KeyRotationInterval < 0 {
+		return fmt.Errorf("JWT_KEY_ROTATION_INTERVAL must not be negative")
+	}
+	if c.JWT.KeyRotationInterval == 0 {
+		return nil
+	}
+	// 鍵を作成・保存するのはトークンを発行する構成のみ
+	if c.JWT.VerifyOnly {
+		return fmt.Errorf("JWT_KEY_ROTATION_INTERVAL cannot be used with JWT_VERIFY_ONLY")
+	}
+	if c.JWT.KeyRotationCheckInterval <= 0 || c.JWT.KeyRotationCheckInterval >= c.JWT.KeyRotationInterval {
+		return fmt.Errorf("JWT_KEY_ROTATION_CHECK_INTERVAL must be positive and shorter than JWT_KEY_ROTATION_INTERVAL")
+	}
+	// 引き継ぐ前に発行したアクセストークンが失効する前に検証できなくなるのを防ぐ
+	if c.JWT.KeyRotationOverlap != 0 && c.JWT.KeyRotationOverlap < c.JWT.AccessTokenExpiry {
+		return fmt.Errorf("JWT_KEY_ROTATION_OVERLAP must be greater than or equal to JWT_ACCESS_TOKEN_EXPIRY")
+	}
+	return nil
+}
+
+// Domain ユースケースに渡すID生成と検証の設定を返す
+func (c IDConfig) Domain() domain.IDConfig {
+	return domain.IDConfig{Version: domain.IDVersion(c.UUIDVersion), StrictVersion: c.StrictVersion}
+}
+
+// Domain ユースケースに渡すアカウント名の検証ルールを返す
+func (c AccountNameConfig) Domain() domain.NameConfig {
+	names := domain.NameConfig{
+		MinLength:    c.MinLength,
+		MaxLength:    c.MaxLength,
+		RejectMarkup: c.RejectMarkup,
+		BlockedWords: c.BlockedWords,
+	}
+	for _, class := range c.AllowedClasses {
+		names.AllowedClasses = append(names.AllowedClasses, domain.NameCharClass(strings.TrimSpace(class)))
+	}
+	return names
+}
+
+// RolePolicy サインアップで割り当てるロールの方針を返す
+func (c SignupConfig) RolePolicy() domain.SignupRolePolicy {
+	policy := domain.SignupRolePolicy{DefaultRole: domain.Role(strings.TrimSpace(c.DefaultRole))}
+	for _, role := range c.SelfAssignableRoles {
+		policy.SelfAssignable = append(policy.SelfAssignable, domain.Role(strings.TrimSpace(role)))
+	}
+	return policy
+}
+
+// KeyRotationOverlap 署名に使用しなくなった鍵を検証に使用し続ける期間を返す（未設定の場合はアクセストークンの有効期間）
+func (c *Config) KeyRotationOverlap() time.Duration {
+	if c.JWT.KeyRotationOverlap == 0 {
+		return c.JWT.AccessTokenExpiry
+	}
+	return c.JWT.KeyRotationOverlap
+}
+
+// Warnings 起動は続行できるが見直しが必要な設定の警告を返す
+func (c *Config) Warnings() []string {
+	var warnings []string
+	if c.JWT.Issuer == DefaultJWTIssuer {
+		warnings = append(warnings, fmt.Sprintf(
+			"JWT_ISSUER is the shared default %q; set an environment-specific value (e.g. %s-%s) so tokens from other environments are rejected",
+			DefaultJWTIssuer, DefaultJWTIssuer, c.Env))
+	}
+	return warnings
+}
+
+// InflightLimit 同時に処理するリクエストの上限を返す（0の場合は制限しない）
+// 上限を省略した場合はDB_MAX_OPEN_CONNSのSERVER_INFLIGHT_PER_CONNECTION倍とし、
+// コネクション数に上限が無い場合は制限しない
+func (c *Config) InflightLimit() int {
+	switch {
+	case c.Server.MaxInflightRequests < 0:
+		return 0
+	case c.Server.MaxInflightRequests > 0:
+		return c.Server.MaxInflightRequests
+	case c.Database.MaxOpenConns > 0:
+		return c.Database.MaxOpenConns * c.Server.InflightPerConnection
+	default:
+		return 0
+	}
+}
+
 // IsDevelopment 開発環境かどうかを返す
 func (c *Config) IsDevelopment() bool {
 	return c.Env == "development"
@@ -155,6 +813,16 @@ func getIntEnv(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getBoolEnv 環境変数を真偽値として取得
+func getBoolEnv(key string, defaultValue bool) bool {
+	if value, exists := os.LookupEnv(key); exists {
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			return boolVal
+		}
+	}
+	return defaultValue
+}
+
 // getDurationEnv 環境変数を時間として取得
 func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	if value, exists := os.LookupEnv(key); exists {
@@ -1,6 +1,11 @@
 package di
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+
 	"github.com/aida0710/jwt-auth/internal/api"
 	"github.com/aida0710/jwt-auth/internal/auth"
 	"github.com/aida0710/jwt-auth/internal/config"
@@ -8,7 +13,10 @@ import (
 	"github.com/aida0710/jwt-auth/internal/handler"
 	"github.com/aida0710/jwt-auth/internal/infrastructure/database"
 	"github.com/aida0710/jwt-auth/internal/logger"
+	"github.com/aida0710/jwt-auth/internal/notification"
 	"github.com/aida0710/jwt-auth/internal/repository"
+	"github.com/aida0710/jwt-auth/internal/repository/memory"
+	"github.com/aida0710/jwt-auth/internal/selfcheck"
 	"github.com/aida0710/jwt-auth/internal/usecase"
 	"github.com/jmoiron/sqlx"
 )
@@ -18,39 +26,121 @@ type Container struct {
 	config            *config.Config
 	db                *sqlx.DB
 	logger            logger.Logger
+	logOutput         io.Closer
 	txManager         database.TransactionManager
 	repos             repository.Repositories
 	handler           api.ServerInterface
+	accountUsecase    usecase.AccountUsecase
+	authUsecase       *usecase.AuthUsecase
 	jwtManager        *auth.JWTManager
 	securityAuditRepo domain.SecurityAuditLogRepository
+	auditBuffer       *repository.SecurityAuditBuffer
+	refreshTokenRepo  domain.RefreshTokenRepository
+	signingKeys       *auth.KeyRing
+	signingKeyUsecase *usecase.SigningKeyUsecase
 }
 
 // NewContainer 新しいDIコンテナを作成
-func NewContainer(cfg *config.Config) (*Container, error) {
-	// データベース接続の初期化
-	dbConfig := &database.Config{
-		Host:     cfg.Database.Host,
-		Port:     cfg.Database.Port,
-		User:     cfg.Database.User,
-		Password: cfg.Database.Password,
-		Database: cfg.Database.Database,
+func NewContainer(cfg *config.Config) (_ *Container, err error) {
+	// 初期化の途中で失敗した場合は、それまでに開いたデータベース接続とログの出力先を閉じる
+	var (
+		db        *sqlx.DB
+		logOutput io.Closer
+	)
+	defer func() {
+		if err == nil {
+			return
+		}
+		_ = closeDB(db)
+		if logOutput != nil {
+			_ = logOutput.Close()
+		}
+	}()
+
+	// ID生成方式の設定
+	ids := cfg.ID.Domain()
+	if err := ids.Validate(); err != nil {
+		return nil, err
+	}
+
+	// アカウント名の検証ルールの設定
+	names := cfg.Name.Domain()
+	if err := names.Validate(); err != nil {
+		return nil, err
 	}
 
-	db, err := database.NewMySQLConnection(dbConfig)
+	// パスワードのペッパーの設定
+	peppers, err := auth.ParsePeppers(cfg.Password.Peppers)
 	if err != nil {
 		return nil, err
 	}
+	passwords, err := auth.NewPasswordHasher(peppers)
+	if err != nil {
+		return nil, err
+	}
+
+	// データベース接続の初期化（DB_DRIVER=memoryの場合は接続しない）
+	if cfg.Database.Driver != config.DatabaseDriverMemory {
+		dbConfig := &database.Config{
+			Host:     cfg.Database.Host,
+			Port:     cfg.Database.Port,
+			User:     cfg.Database.User,
+			Password: cfg.Database.Password,
+			Database: cfg.Database.Database,
+		}
+
+		db, err = database.NewMySQLConnection(dbConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		// コネクションプールの設定
+		db.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+		db.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+		db.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
+	}
 
-	// コネクションプールの設定
-	db.SetMaxOpenConns(cfg.Database.MaxOpenConns)
-	db.SetMaxIdleConns(cfg.Database.MaxIdleConns)
-	db.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
+	// セルフチェックの対象（*sqlx.DBのnilをそのまま渡すとnilと判定されないため分けて持つ）
+	var checkDB selfcheck.Database
+	var poolStats func() sql.DBStats
+	if db != nil {
+		checkDB = db
+		poolStats = db.Stats
+	}
 
 	// ロガーの初期化
-	log := logger.NewLogger(cfg.Logger.Level, cfg.Logger.Format)
+	output, err := logger.OpenOutput(logger.OutputConfig{
+		Destination: cfg.Logger.Output,
+		MaxSize:     int64(cfg.Logger.MaxSizeMB) * 1024 * 1024,
+		MaxAge:      cfg.Logger.MaxAge,
+		MaxBackups:  cfg.Logger.MaxBackups,
+	})
+	if err != nil {
+		return nil, err
+	}
+	logOutput = output
+	log := logger.NewLoggerWithOutput(cfg.Logger.Level, cfg.Logger.Format, output,
+		logger.WithRedactKeys(cfg.Logger.RedactKeys...),
+		logger.WithPrivacyMode(cfg.Logger.PrivacyMode),
+	)
+
+	// 起動時のセルフチェック（設定の誤りをリクエストの受け付け前に検出）
+	// 結果は1行の構造化ログに出力し、本番環境では失敗したチェックがあれば起動を中止する
+	report := selfcheck.Run(context.Background(), checkDB, cfg)
+	if report.OK() {
+		log.Info(context.Background(), "Startup self-check passed", logger.F("checks", report.Summary()))
+	} else {
+		log.Warn(context.Background(), "Startup self-check failed", logger.F("checks", report.Summary()))
+		if cfg.IsProduction() {
+			return nil, report.Error()
+		}
+	}
 
-	// トランザクションマネージャーの初期化
-	txManager := database.NewTransactionManager(db)
+	// ローテーションする署名鍵（無効の場合はnilのままJWT_ACCESS_TOKEN_SECRETで署名する）
+	var signingKeys *auth.KeyRing
+	if cfg.JWT.KeyRotationInterval > 0 {
+		signingKeys = auth.NewKeyRing()
+	}
 
 	// JWTマネージャーの初期化
 	jwtManager := auth.NewJWTManager(auth.JWTConfig{
@@ -60,41 +150,211 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 		RefreshTokenExpiry: cfg.JWT.RefreshTokenExpiry,
 		Issuer:             cfg.JWT.Issuer,
 		Audience:           cfg.JWT.Audience,
+		AudienceMatchMode:  auth.AudienceMatchMode(cfg.JWT.AudienceMatchMode),
+		AcceptedAudiences:  cfg.JWT.AcceptedAudiences,
+		AccessTokenType:    cfg.JWT.AccessTokenType,
+		RefreshTokenType:   cfg.JWT.RefreshTokenType,
+		SigningAlgorithm:   cfg.JWT.SigningAlgorithm,
+
+		RefreshTokenIssuer:   cfg.JWT.RefreshTokenIssuer,
+		RefreshTokenAudience: cfg.JWT.RefreshTokenAudience,
+		AcceptedIssuers:      cfg.JWT.AcceptedIssuers,
+		VerifyOnly:           cfg.JWT.VerifyOnly,
+		SigningKeys:          signingKeys,
 	})
 
-	// リポジトリの初期化
-	repos := repository.NewRepositories(db)
+	// トランザクションマネージャーとリポジトリの初期化
+	var (
+		txManager           database.TransactionManager
+		repos               repository.Repositories
+		refreshTokenRepo    domain.RefreshTokenRepository
+		passwordHistoryRepo domain.PasswordHistoryRepository
+		securityAuditRepo   domain.SecurityAuditLogRepository
+		loginAttemptRepo    domain.LoginAttemptRepository
+		magicLinkRepo       domain.MagicLinkRepository
+		passwordResetRepo   domain.PasswordResetRepository
+		inviteRepo          domain.InviteRepository
+		signingKeyRepo      domain.SigningKeyRepository
+	)
+	if db == nil {
+		// インメモリの実装が無いログイン失敗の記録、マジックリンク、パスワード再設定、招待はnilのまま（機能を無効にする）
+		store := memory.NewStore()
+		txManager = memory.TransactionManager{}
+		repos = store
+		refreshTokenRepo = store.RefreshToken()
+		passwordHistoryRepo = store.PasswordHistory()
+		securityAuditRepo = store.SecurityAuditLog()
+		signingKeyRepo = store.SigningKey()
+	} else {
+		txManager = database.NewTransactionManager(db)
+
+		// 遅いクエリでリクエストが滞留しないよう、リポジトリのクエリごとにタイムアウトを設定
+		repoDB := database.NewDB(db, cfg.Database.QueryTimeout)
+		repos = repository.NewRepositories(repoDB)
+		refreshTokenRepo = repository.NewRefreshTokenRepository(repoDB)
+		passwordHistoryRepo = repository.NewPasswordHistoryRepository(repoDB)
+		securityAuditRepo = repository.NewSecurityAuditLogRepository(repoDB)
+		loginAttemptRepo = repository.NewLoginAttemptRepository(repoDB)
+		magicLinkRepo = repository.NewMagicLinkRepository(repoDB)
+		passwordResetRepo = repository.NewPasswordResetRepository(repoDB)
+		inviteRepo = repository.NewInviteRepository(repoDB)
+		signingKeyRepo = repository.NewSigningKeyRepository(repoDB)
+	}
+
+	// 署名鍵の読み込み（保存された鍵が無い場合は作成する）
+	// 復号できない鍵（JWT_ACCESS_TOKEN_SECRETを変更した場合など）は読み込まずに起動を続ける
+	var signingKeyUsecase *usecase.SigningKeyUsecase
+	if signingKeys != nil {
+		signingKeyUsecase = usecase.NewSigningKeyUsecase(signingKeyRepo, txManager, signingKeys, usecase.SigningKeyConfig{
+			Algorithm:        cfg.JWT.SigningAlgorithm,
+			RotationInterval: cfg.JWT.KeyRotationInterval,
+			Overlap:          cfg.KeyRotationOverlap(),
+			PropagationDelay: cfg.JWT.KeyRotationCheckInterval,
+			EncryptionSecret: cfg.JWT.AccessTokenSecret,
+		})
+		if _, err := signingKeyUsecase.Rotate(context.Background(), domain.Now()); errors.Is(err, auth.ErrSigningKeyDecryption) {
+			log.Error(context.Background(), "Failed to load signing keys", err)
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	// 監査ログを無効にした場合は保存しないリポジトリに差し替え、
+	// 有効な場合は保存に失敗した監査ログを保持して再試行する
+	var auditBuffer *repository.SecurityAuditBuffer
+	if cfg.Audit.Enabled {
+		auditBuffer = repository.NewSecurityAuditBuffer(securityAuditRepo, cfg.Audit.BufferSize, log)
+		securityAuditRepo = auditBuffer
+	} else {
+		securityAuditRepo = domain.NopSecurityAuditLogRepository{}
+	}
 
-	// リフレッシュトークンリポジトリの初期化
-	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
+	// 通知の初期化（メール送信基盤を用意するまではログ出力）
+	notifier := notification.NewLogNotifier(log)
 
-	// セキュリティ監査ログリポジトリの初期化
-	securityAuditRepo := repository.NewSecurityAuditLogRepository(db)
+	// メールドメイン検査の初期化
+	var blocklist []string
+	if cfg.Signup.EmailDomainBlocklistFile != "" {
+		blocklist, err = auth.LoadDomainBlocklist(cfg.Signup.EmailDomainBlocklistFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	emailDomainChecker := auth.NewEmailDomainChecker(auth.EmailDomainConfig{
+		Blocklist: blocklist,
+		CheckMX:   cfg.Signup.EmailDomainCheckMX,
+	})
+
+	// サインアップで割り当てるロールの方針
+	signupRoles := cfg.Signup.RolePolicy()
+	if err := signupRoles.Validate(); err != nil {
+		return nil, err
+	}
 
 	// ユースケースの初期化
 	authUsecase := usecase.NewAuthUsecase(
 		repos.Account(),
 		refreshTokenRepo,
 		securityAuditRepo,
+		loginAttemptRepo,
+		magicLinkRepo,
+		inviteRepo,
+		txManager,
+		notifier,
 		jwtManager,
+		usecase.AuthConfig{
+			RefreshTokenExpiry:      cfg.JWT.RefreshTokenExpiry,
+			SlidingRefresh:          cfg.JWT.RefreshTokenSliding,
+			RefreshTokenMaxLifetime: cfg.JWT.RefreshTokenMaxLifetime,
+			RefreshTokenReuseGrace:  cfg.JWT.RefreshTokenReuseGrace,
+			RefreshRateLimit:        cfg.JWT.RefreshRateLimit,
+			RefreshRateWindow:       cfg.JWT.RefreshRateWindow,
+			DisableSignup:           !cfg.Signup.Enabled,
+			EmailDomainChecker:      emailDomainChecker,
+			Lockout: domain.LockoutPolicy{
+				MaxFailedAttempts: cfg.Lockout.MaxFailedAttempts,
+				BaseCooldown:      cfg.Lockout.BaseCooldown,
+				MaxCooldown:       cfg.Lockout.MaxCooldown,
+				QuietPeriod:       cfg.Lockout.QuietPeriod,
+			},
+			MagicLinkExpiry:      cfg.MagicLink.Expiry,
+			MagicLinkMaxRequests: cfg.MagicLink.MaxRequests,
+			MagicLinkWindow:      cfg.MagicLink.Window,
+			MagicLinkURL:         cfg.MagicLink.URL,
+			InviteExpiry:         cfg.Signup.InviteExpiry,
+			NewDeviceMatch:       domain.DeviceMatchMode(cfg.LoginAlert.NewDeviceMatch),
+			SignupRoles:          signupRoles,
+			UsernameLogin:        cfg.Signup.UsernameLogin,
+			IDs:                  ids,
+			Names:                names,
+			Passwords:            passwords,
+		},
 	)
 	accountUsecase := usecase.NewAccountUsecase(
 		repos.Account(),
 		repos.Project(),
+		refreshTokenRepo,
+		passwordHistoryRepo,
+		passwordResetRepo,
+		securityAuditRepo,
 		txManager,
+		notifier,
+		usecase.AccountConfig{
+			PasswordHistorySize: cfg.Password.HistorySize,
+			DeletionGracePeriod: cfg.Deletion.GracePeriod,
+			ListDefaultLimit:    cfg.AccountList.DefaultLimit,
+			ListMaxLimit:        cfg.AccountList.MaxLimit,
+			PasswordResetExpiry: cfg.PasswordReset.Expiry,
+			PasswordResetURL:    cfg.PasswordReset.URL,
+			IDs:                 ids,
+			Names:               names,
+			Passwords:           passwords,
+		},
 	)
 	projectUsecase := usecase.NewProjectUsecase(
 		repos.Project(),
 		repos.Account(),
 		txManager,
+		usecase.ProjectConfig{
+			UniqueNames:          cfg.Project.UniqueNames,
+			IDs:                  ids,
+			DescriptionMaxLength: cfg.Project.DescriptionMaxLength,
+		},
 	)
 
+	// 管理者アカウントの作成（既に存在する場合はスキップ）
+	if cfg.Admin.Email != "" {
+		_, err = accountUsecase.Create(context.Background(), usecase.CreateInput{
+			Email:    cfg.Admin.Email,
+			Password: cfg.Admin.Password,
+			Name:     cfg.Admin.Name,
+			Role:     domain.RoleAdmin,
+		})
+		if err != nil && !errors.Is(err, domain.ErrDuplicateEmail) {
+			return nil, err
+		}
+	}
+
 	// ハンドラーの初期化
 	authHandler := handler.NewAuthHandler(authUsecase)
 	h := handler.NewServer(
 		accountUsecase,
 		projectUsecase,
 		authHandler,
+		handler.HealthConfig{
+			Readiness: func(ctx context.Context) selfcheck.Report {
+				report := selfcheck.Run(ctx, checkDB, cfg)
+				if auditBuffer != nil {
+					report.Results = append(report.Results, selfcheck.CheckAuditBuffer(auditBuffer))
+				}
+				return report
+			},
+			PoolStats:       poolStats,
+			ExposePoolStats: cfg.Database.ExposePoolStats,
+		},
+		handler.PrivacyConfig{
+			ListEmailMasking: domain.EmailMaskMode(cfg.Privacy.ListEmailMasking),
+		},
 		log,
 	)
 
@@ -102,17 +362,32 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 		config:            cfg,
 		db:                db,
 		logger:            log,
+		logOutput:         logOutput,
 		txManager:         txManager,
 		repos:             repos,
 		handler:           h,
+		accountUsecase:    accountUsecase,
 		jwtManager:        jwtManager,
 		securityAuditRepo: securityAuditRepo,
+		auditBuffer:       auditBuffer,
+		refreshTokenRepo:  refreshTokenRepo,
+		signingKeys:       signingKeys,
+		signingKeyUsecase: signingKeyUsecase,
+		authUsecase:       authUsecase,
 	}, nil
 }
 
 // Close コンテナのリソースをクリーンアップ
 func (c *Container) Close() error {
-	return c.DB().Close()
+	return errors.Join(closeDB(c.db), c.logOutput.Close())
+}
+
+// closeDB データベース接続を閉じる（DB_DRIVER=memoryで接続していない場合は何もしない）
+func closeDB(db *sqlx.DB) error {
+	if db == nil {
+		return nil
+	}
+	return db.Close()
 }
 
 // GetLogger ロガーを返す
@@ -135,7 +410,17 @@ func (c *Container) GetHandler() api.ServerInterface {
 	return c.handler
 }
 
-// DB データベース接続を返す
+// GetAccountUsecase アカウントユースケースを返す（定期的な完全削除に使用）
+func (c *Container) GetAccountUsecase() usecase.AccountUsecase {
+	return c.accountUsecase
+}
+
+// GetAuthUsecase 認証ユースケースを返す（tokenサブコマンドに使用）
+func (c *Container) GetAuthUsecase() *usecase.AuthUsecase {
+	return c.authUsecase
+}
+
+// DB データベース接続を返す（DB_DRIVER=memoryの場合はnil）
 func (c *Container) DB() *sqlx.DB {
 	return c.db
 }
@@ -149,3 +434,23 @@ func (c *Container) GetJWTManager() *auth.JWTManager {
 func (c *Container) GetSecurityAuditRepo() domain.SecurityAuditLogRepository {
 	return c.securityAuditRepo
 }
+
+// GetSecurityAuditBuffer 保存に失敗した監査ログのバッファを返す（監査ログが無効の場合はnil）
+func (c *Container) GetSecurityAuditBuffer() *repository.SecurityAuditBuffer {
+	return c.auditBuffer
+}
+
+// GetRefreshTokenRepo リフレッシュトークンリポジトリを返す（有効期限切れのトークンの定期削除に使用）
+func (c *Container) GetRefreshTokenRepo() domain.RefreshTokenRepository {
+	return c.refreshTokenRepo
+}
+
+// GetSigningKeys ローテーションする署名鍵を返す（ローテーションが無効の場合はnil）
+func (c *Container) GetSigningKeys() *auth.KeyRing {
+	return c.signingKeys
+}
+
+// GetSigningKeyUsecase 署名鍵ユースケースを返す（定期的なローテーションに使用、無効の場合はnil）
+func (c *Container) GetSigningKeyUsecase() *usecase.SigningKeyUsecase {
+	return c.signingKeyUsecase
+}
@@ -1,51 +1,358 @@
 package domain
 
 import (
+	"context"
+	"crypto/subtle"
+	"net/url"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
+	_ "time/tzdata" // tzdataの無い環境でもIANAタイムゾーンを検証できるよう埋め込む
 
 	"github.com/google/uuid"
 )
 
+// Role アカウントの権限
+type Role string
+
+const (
+	// RoleUser 一般ユーザー
+	RoleUser Role = "user"
+	// RoleAdmin 管理者
+	RoleAdmin Role = "admin"
+)
+
+// IsValid 定義済みのロールかどうかを返す
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleUser, RoleAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+// AccountStatus アカウントの状態
+type AccountStatus string
+
+const (
+	// AccountStatusActive 有効
+	AccountStatusActive AccountStatus = "active"
+	// AccountStatusSuspended 管理者により停止中（ログインとトークンのリフレッシュを拒否する）
+	AccountStatusSuspended AccountStatus = "suspended"
+	// AccountStatusPendingDeletion 削除の猶予期間中（ログインを拒否し、猶予期間が過ぎると完全に削除する）
+	AccountStatusPendingDeletion AccountStatus = "pending_deletion"
+)
+
+// IsValid 定義済みの状態かどうかを返す
+func (s AccountStatus) IsValid() bool {
+	switch s {
+	case AccountStatusActive, AccountStatusSuspended, AccountStatusPendingDeletion:
+		return true
+	default:
+		return false
+	}
+}
+
+// Permission ロールに付与される操作権限
+type Permission string
+
+const (
+	PermissionAccountRead    Permission = "account:read"
+	PermissionAccountWrite   Permission = "account:write"
+	PermissionProjectRead    Permission = "project:read"
+	PermissionProjectWrite   Permission = "project:write"
+	PermissionAccountCreate  Permission = "admin:account:create"
+	PermissionProjectListAll Permission = "admin:project:list"
+	// PermissionAccountEmailRead 一覧でメールアドレスを伏せずに参照する（EmailMaskUnprivilegedの場合）
+	PermissionAccountEmailRead Permission = "admin:account:email"
+)
+
+// rolePermissions ロールごとの権限（管理者は一般ユーザーの権限をすべて含む）
+var rolePermissions = map[Role][]Permission{
+	RoleUser: {
+		PermissionAccountRead, PermissionAccountWrite,
+		PermissionProjectRead, PermissionProjectWrite,
+	},
+	RoleAdmin: {
+		PermissionAccountRead, PermissionAccountWrite,
+		PermissionProjectRead, PermissionProjectWrite,
+		PermissionAccountCreate, PermissionProjectListAll,
+		PermissionAccountEmailRead,
+	},
+}
+
+// Permissions ロールに付与された権限の一覧を返す（未定義のロールは空）
+func (r Role) Permissions() []Permission {
+	permissions := rolePermissions[r]
+	copied := make([]Permission, len(permissions))
+	copy(copied, permissions)
+	return copied
+}
+
+// HasPermission ロールに指定の権限が付与されているかどうかを返す
+func (r Role) HasPermission(permission Permission) bool {
+	return slices.Contains(rolePermissions[r], permission)
+}
+
 // Account アカウントエンティティ
 type Account struct {
-	ID           uuid.UUID `db:"id" json:"id"`
-	Email        string    `db:"email" json:"email"`
-	Name         string    `db:"name" json:"name"`
-	PasswordHash string    `db:"password_hash" json:"-"` // JSONレスポンスには含めない
-	CreatedAt    time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt    time.Time `db:"updated_at" json:"updated_at"`
+	ID           uuid.UUID     `db:"id" json:"id"`
+	TenantID     string        `db:"tenant_id" json:"tenant_id"`
+	Email        string        `db:"email" json:"email"`
+	Name         string        `db:"name" json:"name"`
+	Role         Role          `db:"role" json:"role"`
+	Status       AccountStatus `db:"status" json:"status"`
+	PasswordHash string        `db:"password_hash" json:"-"` // JSONレスポンスには含めない
+	CreatedAt    time.Time     `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time     `db:"updated_at" json:"updated_at"`
+	// Version 楽観的排他制御のバージョン（更新のたびにリポジトリが1増やす）
+	Version int64 `db:"version" json:"version"`
+
+	// Username ログインに使用できるユーザー名（任意、小文字に正規化して保存し大文字小文字を区別せずに一意）
+	Username *string `db:"username" json:"username,omitempty"`
+
+	// プロフィール（すべて任意項目）
+	DisplayName *string `db:"display_name" json:"display_name,omitempty"`
+	AvatarURL   *string `db:"avatar_url" json:"avatar_url,omitempty"`
+	Locale      *string `db:"locale" json:"locale,omitempty"`
+	Timezone    *string `db:"timezone" json:"timezone,omitempty"` // IANAタイムゾーン名（例: Asia/Tokyo）
+
+	// メールアドレス変更の確認待ち状態（確認されるまでEmailは変更しない）
+	PendingEmail               *string    `db:"pending_email" json:"pending_email,omitempty"`
+	EmailVerificationTokenHash *string    `db:"email_verification_token_hash" json:"-"`
+	EmailVerificationExpiresAt *time.Time `db:"email_verification_expires_at" json:"-"`
+
+	// DeletionScheduledAt 完全に削除する日時（削除の猶予期間中のみ設定）
+	DeletionScheduledAt *time.Time `db:"deletion_scheduled_at" json:"deletion_scheduled_at,omitempty"`
+
+	// 最後にログイン（トークンのリフレッシュを含む）した日時と接続元
+	// ログインのたびに変わるため、Updateでは保存せずAccountRepository.RecordLoginでのみ更新する（バージョンも変えない）
+	LastLoginAt *time.Time `db:"last_login_at" json:"last_login_at,omitempty"`
+	LastLoginIP *string    `db:"last_login_ip" json:"last_login_ip,omitempty"`
+
+	// AllowedAudiences ログイン・リフレッシュで要求できるAudience（管理者のみ変更でき、空の場合は既定のAudienceのみ）
+	AllowedAudiences []string `db:"allowed_audiences" json:"allowed_audiences,omitempty"`
+}
+
+// ShouldRecordLogin nowのログインで最終ログインを更新する必要があるかを返す
+// 同じ接続元からinterval以内に記録済みの場合は、頻繁なリフレッシュのたびに書き込まないよう更新を省略する
+func (a *Account) ShouldRecordLogin(now time.Time, ipAddress string, interval time.Duration) bool {
+	if a.LastLoginAt == nil || now.Sub(*a.LastLoginAt) >= interval {
+		return true
+	}
+	lastIP := ""
+	if a.LastLoginIP != nil {
+		lastIP = *a.LastLoginIP
+	}
+	return lastIP != ipAddress
+}
+
+// AccountFilter アカウント検索の条件
+// nilの項目は絞り込みに使用しない
+type AccountFilter struct {
+	Role   *Role
+	Limit  int
+	Offset int
+	Cursor *PageCursor // 指定した場合はOffsetの代わりにカーソルの前後を取得
+}
+
+// メールアドレスの前方一致検索で受け付ける文字数
+// 短すぎる検索語はほぼ全件に一致してインデックスの効果が無くなるため拒否する
+const (
+	MinEmailSearchLength = 3
+	MaxEmailSearchLength = 255
+)
+
+// AccountProjectCount アカウントと所有するプロジェクト数
+type AccountProjectCount struct {
+	Account      *Account
+	ProjectCount int
 }
 
 // NewAccount 新しいAccountを作成
 func NewAccount(email, name, passwordHash string) *Account {
 	return &Account{
-		ID:           uuid.New(),
+		ID:           NewID(),
+		TenantID:     DefaultTenantID,
 		Email:        email,
 		Name:         name,
+		Role:         RoleUser,
+		Status:       AccountStatusActive,
 		PasswordHash: passwordHash,
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
+		CreatedAt:    Now(),
+		UpdatedAt:    Now(),
+		Version:      1,
 	}
 }
 
 // Validate アカウントエンティティを検証
 func (a *Account) Validate() error {
-	if a.Email == "" {
+	if err := validateEmail(a.Email); err != nil {
+		return err
+	}
+	if a.PendingEmail != nil {
+		if err := validateEmail(*a.PendingEmail); err != nil {
+			return err
+		}
+	}
+	if err := ValidateName(a.Name); err != nil {
+		return err
+	}
+	if a.Username != nil {
+		if err := ValidateUsername(*a.Username); err != nil {
+			return err
+		}
+	}
+	if !a.Role.IsValid() {
+		return ErrInvalidRole
+	}
+	if !a.Status.IsValid() {
+		return ErrInvalidAccountStatus
+	}
+	if (a.Status == AccountStatusPendingDeletion) != (a.DeletionScheduledAt != nil) {
+		return ErrInvalidAccountStatus
+	}
+	if err := validateAudiences(a.AllowedAudiences); err != nil {
+		return err
+	}
+	return a.validateProfile()
+}
+
+// validateEmail メールアドレスの形式を検証
+func validateEmail(email string) error {
+	if email == "" {
 		return ErrInvalidEmail
 	}
 	// 簡単なチェックのため、今後修正する必要あり
-	if !strings.Contains(a.Email, "@") || !strings.Contains(a.Email, ".") {
+	if !strings.Contains(email, "@") || !strings.Contains(email, ".") {
 		return ErrInvalidEmail
 	}
-	if len(a.Email) > MaxEmailLength {
+	if len(email) > MaxEmailLength {
 		return ErrInvalidEmail
 	}
-	if a.Name == "" {
-		return ErrInvalidName
+	return nil
+}
+
+// localePattern BCP 47形式のロケール（例: ja, en-US, zh-Hant-TW）
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// validateProfile プロフィール項目を検証（未設定の項目は検証しない）
+func (a *Account) validateProfile() error {
+	if a.DisplayName != nil && (*a.DisplayName == "" || len(*a.DisplayName) > MaxNameLength) {
+		return ErrInvalidDisplayName
+	}
+	if a.AvatarURL != nil {
+		if len(*a.AvatarURL) > MaxAvatarURLLength {
+			return ErrInvalidAvatarURL
+		}
+		u, err := url.ParseRequestURI(*a.AvatarURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return ErrInvalidAvatarURL
+		}
+	}
+	if a.Locale != nil && !localePattern.MatchString(*a.Locale) {
+		return ErrInvalidLocale
+	}
+	if a.Timezone != nil {
+		// "Local"はサーバーのタイムゾーンを指すため受け付けない
+		if *a.Timezone == "" || *a.Timezone == "Local" {
+			return ErrInvalidTimezone
+		}
+		if _, err := time.LoadLocation(*a.Timezone); err != nil {
+			return ErrInvalidTimezone
+		}
+	}
+	return nil
+}
+
+// RequestEmailChange メールアドレスの変更を確認待ち状態にする
+// 確認トークンはハッシュ化した値のみを保持する
+func (a *Account) RequestEmailChange(newEmail, tokenHash string, expiresAt time.Time) {
+	a.PendingEmail = &newEmail
+	a.EmailVerificationTokenHash = &tokenHash
+	a.EmailVerificationExpiresAt = &expiresAt
+}
+
+// ConfirmEmailChange 確認トークンを検証し、確認待ちのメールアドレスを確定する
+func (a *Account) ConfirmEmailChange(tokenHash string, now time.Time) error {
+	if a.PendingEmail == nil || a.EmailVerificationTokenHash == nil || a.EmailVerificationExpiresAt == nil {
+		return ErrInvalidVerificationToken
+	}
+	if subtle.ConstantTimeCompare([]byte(*a.EmailVerificationTokenHash), []byte(tokenHash)) != 1 {
+		return ErrInvalidVerificationToken
+	}
+	if !now.Before(*a.EmailVerificationExpiresAt) {
+		return ErrInvalidVerificationToken
+	}
+
+	a.Email = *a.PendingEmail
+	a.ClearPendingEmail()
+	return nil
+}
+
+// ClearPendingEmail 確認待ちのメールアドレス変更を破棄する
+func (a *Account) ClearPendingEmail() {
+	a.PendingEmail = nil
+	a.EmailVerificationTokenHash = nil
+	a.EmailVerificationExpiresAt = nil
+}
+
+// BelongsTo アカウントがコンテキストのテナントに属しているか返す
+func (a *Account) BelongsTo(ctx context.Context) bool {
+	return InTenant(ctx, a.TenantID)
+}
+
+// IsAdmin 管理者かどうかを返す
+func (a *Account) IsAdmin() bool {
+	return a.Role == RoleAdmin
+}
+
+// IsSuspended 停止中かどうかを返す
+func (a *Account) IsSuspended() bool {
+	return a.Status == AccountStatusSuspended
+}
+
+// IsPendingDeletion 削除の猶予期間中かどうかを返す
+func (a *Account) IsPendingDeletion() bool {
+	return a.Status == AccountStatusPendingDeletion
+}
+
+// CheckCanSignIn ログインやトークンのリフレッシュを許可できる状態か確認する
+// 停止中はErrAccountSuspended、削除の猶予期間中はErrAccountPendingDeletionを返す
+func (a *Account) CheckCanSignIn() error {
+	switch a.Status {
+	case AccountStatusSuspended:
+		return ErrAccountSuspended
+	case AccountStatusPendingDeletion:
+		return ErrAccountPendingDeletion
+	default:
+		return nil
+	}
+}
+
+// ScheduleDeletion 削除を予約し、指定した日時まで削除の猶予期間中にする
+func (a *Account) ScheduleDeletion(at time.Time) error {
+	if a.IsPendingDeletion() {
+		return ErrAccountPendingDeletion
 	}
-	if len(a.Name) > MaxNameLength {
-		return ErrInvalidName
+	a.Status = AccountStatusPendingDeletion
+	a.DeletionScheduledAt = &at
+	return nil
+}
+
+// CancelDeletion 削除の予約を取り消し、有効な状態に戻す
+func (a *Account) CancelDeletion() error {
+	if !a.IsPendingDeletion() {
+		return ErrAccountNotPendingDeletion
 	}
+	a.Status = AccountStatusActive
+	a.DeletionScheduledAt = nil
 	return nil
 }
+
+// IsDeletionDue 猶予期間が過ぎ、完全に削除できるかどうかを返す
+func (a *Account) IsDeletionDue(now time.Time) bool {
+	return a.IsPendingDeletion() && a.DeletionScheduledAt != nil && !now.Before(*a.DeletionScheduledAt)
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// アカウントに許可するAudienceの件数と1件あたりの文字数の上限
+const (
+	MaxAllowedAudiences = 20
+	MaxAudienceLength   = 255
+)
+
+// NormalizeAudiences アカウントに許可するAudienceの一覧を検証し、前後の空白を除いて重複を取り除く
+// 空の一覧はnil（既定のAudienceのみ許可）を返す
+func NormalizeAudiences(audiences []string) ([]string, error) {
+	var normalized []string
+	for _, audience := range audiences {
+		audience = strings.TrimSpace(audience)
+		if err := validateAudience(audience); err != nil {
+			return nil, err
+		}
+		if !slices.Contains(normalized, audience) {
+			normalized = append(normalized, audience)
+		}
+	}
+	if len(normalized) > MaxAllowedAudiences {
+		return nil, fmt.Errorf("%w: at most %d audiences are allowed", ErrInvalidAudience, MaxAllowedAudiences)
+	}
+	slices.Sort(normalized)
+	return normalized, nil
+}
+
+// validateAudience Audience1件の形式を検証
+// 保存時に空白区切りで連結するため、空白や制御文字を含む値は受け付けない
+func validateAudience(audience string) error {
+	if audience == "" {
+		return fmt.Errorf("%w: must not be empty", ErrInvalidAudience)
+	}
+	if len(audience) > MaxAudienceLength {
+		return fmt.Errorf("%w: must be at most %d characters", ErrInvalidAudience, MaxAudienceLength)
+	}
+	if strings.ContainsFunc(audience, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) {
+		return fmt.Errorf("%w: must not contain whitespace or control characters", ErrInvalidAudience)
+	}
+	return nil
+}
+
+// validateAudiences 保存するAudienceの一覧を検証
+func validateAudiences(audiences []string) error {
+	if len(audiences) > MaxAllowedAudiences {
+		return fmt.Errorf("%w: at most %d audiences are allowed", ErrInvalidAudience, MaxAllowedAudiences)
+	}
+	for _, audience := range audiences {
+		if err := validateAudience(audience); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckAudience 要求されたAudienceのアクセストークンを発行できるか確認（空の場合は既定のAudienceのため常に許可）
+func (a *Account) CheckAudience(audience string) error {
+	if audience == "" || slices.Contains(a.AllowedAudiences, audience) {
+		return nil
+	}
+	return ErrAudienceNotAllowed
+}
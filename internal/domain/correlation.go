@@ -0,0 +1,38 @@
+package domain
+
+import "context"
+
+// Correlation 監査ログやログの行をリクエストのトレースと結び付けるためのID
+type Correlation struct {
+	RequestID string // X-Request-IDの値
+	TraceID   string // W3C Trace Context（traceparent）のtrace-id、無い場合は空
+	SpanID    string // traceparentのparent-id（呼び出し元のスパン）、無い場合は空
+}
+
+// correlationContextKey コンテキストに相関IDを保持するためのキー
+type correlationContextKey struct{}
+
+// WithCorrelation リクエストの相関IDをコンテキストに設定
+func WithCorrelation(ctx context.Context, correlation Correlation) context.Context {
+	return context.WithValue(ctx, correlationContextKey{}, correlation)
+}
+
+// CorrelationFromContext コンテキストに設定された相関IDを返す（未設定の場合はfalse）
+// バックグラウンドの処理（定期的な完全削除など）では設定されない
+func CorrelationFromContext(ctx context.Context) (Correlation, bool) {
+	correlation, ok := ctx.Value(correlationContextKey{}).(Correlation)
+	return correlation, ok && (correlation.RequestID != "" || correlation.TraceID != "")
+}
+
+// AddTo 空でないIDをメタデータに追加する（request_id / trace_id / span_id）
+func (c Correlation) AddTo(metadata SecurityAuditMetadata) {
+	if c.RequestID != "" {
+		metadata["request_id"] = c.RequestID
+	}
+	if c.TraceID != "" {
+		metadata["trace_id"] = c.TraceID
+	}
+	if c.SpanID != "" {
+		metadata["span_id"] = c.SpanID
+	}
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"encoding/base64"
+
+	"github.com/google/uuid"
+)
+
+// PageCursor キーセット方式のページングの位置
+// IDはUUID v7で作成順に並ぶため、IDの大小で前後を判定する（深いOFFSETのような読み飛ばしが発生しない）
+type PageCursor struct {
+	ID     uuid.UUID
+	Before bool // trueの場合はIDより新しい項目（前のページ）を取得し、falseの場合は古い項目を取得
+}
+
+// EncodeCursor IDをクライアントに返す不透明なカーソル文字列に変換
+func EncodeCursor(id uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString(id[:])
+}
+
+// DecodeCursor EncodeCursorで作成したカーソル文字列をIDに戻す
+func DecodeCursor(cursor string) (uuid.UUID, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(b) != len(uuid.UUID{}) {
+		return uuid.Nil, ErrInvalidPagination
+	}
+	return uuid.FromBytes(b)
+}
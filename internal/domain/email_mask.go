@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// EmailMaskMode 一覧のレスポンスでメールアドレスを伏せる対象
+type EmailMaskMode string
+
+const (
+	// EmailMaskOff 伏せない
+	EmailMaskOff EmailMaskMode = "off"
+	// EmailMaskUnprivileged PermissionAccountEmailReadを持たないロールにのみ伏せる
+	EmailMaskUnprivileged EmailMaskMode = "unprivileged"
+	// EmailMaskAll すべてのロールに伏せる（完全なメールアドレスはアカウントの個別の取得でのみ返す）
+	EmailMaskAll EmailMaskMode = "all"
+)
+
+// IsValid 定義済みのモードかどうかを返す
+func (m EmailMaskMode) IsValid() bool {
+	switch m {
+	case EmailMaskOff, EmailMaskUnprivileged, EmailMaskAll:
+		return true
+	default:
+		return false
+	}
+}
+
+// Masks 指定ロールの呼び出し元に対して一覧のメールアドレスを伏せるかどうかを返す
+func (m EmailMaskMode) Masks(role Role) bool {
+	switch m {
+	case EmailMaskAll:
+		return true
+	case EmailMaskUnprivileged:
+		return !role.HasPermission(PermissionAccountEmailRead)
+	default:
+		return false
+	}
+}
+
+// MaskEmail ローカル部の先頭1文字以外を伏せたメールアドレスを返す（例: john@example.com → j***@example.com）
+// ドメインは残すため、組織単位の把握には使えるが個人は特定しにくい
+func MaskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return "***"
+	}
+	first, _ := utf8.DecodeRuneInString(email)
+	return string(first) + "***" + email[at:]
+}
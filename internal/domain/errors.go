@@ -5,26 +5,72 @@ import (
 	"fmt"
 )
 
+// 個別の「見つからない」エラーはErrNotFoundをラップしているため、
+// errors.Is(err, ErrNotFound) で種類を問わず判定できます
 var (
-	ErrAccountNotFound    = errors.New("account not found")
+	ErrAccountNotFound    = fmt.Errorf("account %w", ErrNotFound)
 	ErrInvalidEmail       = errors.New("invalid email address")
 	ErrInvalidName        = errors.New("invalid name")
 	ErrDuplicateEmail     = errors.New("email already exists")
 	ErrEmailAlreadyExists = errors.New("email already exists")
+	ErrInvalidDisplayName = errors.New("invalid display name")
+	ErrInvalidAvatarURL   = errors.New("invalid avatar url")
+	ErrInvalidLocale      = errors.New("invalid locale")
+	ErrInvalidTimezone    = errors.New("invalid timezone")
+	ErrInvalidUsername    = errors.New("invalid username")
+	ErrDuplicateUsername  = errors.New("username already exists")
 
-	ErrProjectNotFound      = errors.New("project not found")
+	ErrDisallowedEmailDomain = errors.New("email domain is not allowed")
+	ErrSignupDisabled        = errors.New("signup is disabled")
+	ErrInvalidInvite         = errors.New("invalid, used or expired invite")
+	ErrInvalidRole           = errors.New("invalid role")
+	ErrInvalidAccountStatus  = errors.New("invalid account status")
+	ErrInvalidAudience       = errors.New("invalid audience")
+	ErrAudienceNotAllowed    = errors.New("requested audience is not allowed for this account")
+
+	ErrAccountPendingDeletion    = errors.New("account is pending deletion")
+	ErrAccountNotPendingDeletion = errors.New("account is not pending deletion")
+	ErrVersionConflict           = errors.New("resource has been modified by another request")
+
+	ErrInvalidVerificationToken = errors.New("invalid or expired verification token")
+	// ErrInvalidPasswordResetToken 状態を推測されないよう、存在しない・期限切れ・使用済みのトークンを区別しない
+	ErrInvalidPasswordResetToken = errors.New("invalid or expired password reset token")
+
+	ErrProjectNotFound      = fmt.Errorf("project %w", ErrNotFound)
 	ErrInvalidAccountID     = errors.New("invalid account id")
 	ErrInvalidStatus        = errors.New("invalid project status")
+	ErrInvalidDescription   = errors.New("invalid project description")
 	ErrProjectLimitExceeded = errors.New("project limit exceeded (max: 10)")
+	ErrDuplicateProjectName = errors.New("project name already exists")
 
-	ErrInvalidID = errors.New("invalid id format")
-	ErrNotFound  = errors.New("not found")
+	ErrInvalidID         = errors.New("invalid id format")
+	ErrInvalidPagination = errors.New("invalid pagination parameters")
+	ErrInvalidSearch     = errors.New("invalid search query")
+	ErrNotFound          = errors.New("not found")
 
 	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrAccountLocked      = errors.New("account is temporarily locked")
+	ErrAccountSuspended   = errors.New("account is suspended")
+	ErrPasswordReused     = errors.New("password was used recently")
+	ErrIncorrectPassword  = errors.New("current password is incorrect")
 	ErrInvalidToken       = errors.New("invalid or expired token")
 	ErrTokenExpired       = errors.New("token has expired")
 	ErrTokenCompromised   = errors.New("token may be compromised - all tokens have been revoked for security")
+	ErrRefreshRateLimited = errors.New("too many token refreshes")
+	ErrDuplicateToken     = errors.New("refresh token already exists")
+	ErrSessionNotFound    = fmt.Errorf("session %w", ErrNotFound)
+	ErrInvalidDeviceName  = errors.New("invalid device name")
+	ErrInvalidRevocation  = errors.New("invalid session revocation criteria")
 	ErrUnauthorized       = errors.New("unauthorized")
+	ErrForbidden          = errors.New("forbidden")
+
+	ErrSigningKeyRotationScheduled = errors.New("signing key rotation is already scheduled")
+
+	// ErrServiceUnavailable データベースとの接続が失われたなど、時間をおいて再試行すれば成功し得る一時的な障害
+	ErrServiceUnavailable = errors.New("service temporarily unavailable")
+
+	// ErrQueryTimeout データベースのクエリが設定されたタイムアウト内に完了しなかった
+	ErrQueryTimeout = errors.New("database query timed out")
 )
 
 // ValidationError バリデーションエラーを表す構造体
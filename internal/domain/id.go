@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// IDVersion 新規エンティティのIDに使用するUUIDのバージョン
+type IDVersion int
+
+const (
+	IDVersion4 IDVersion = 4 // ランダム
+	IDVersion7 IDVersion = 7 // 時刻順にソート可能
+)
+
+// IDConfig ID生成と検証の設定
+// ユースケースの設定として渡し、アカウントやセッションなどユースケースで作成するエンティティのIDに使用する
+// 監査ログと署名鍵のIDは常にv7で生成する
+type IDConfig struct {
+	// Version 新規IDのバージョン（0の場合はv7）
+	Version IDVersion
+	// StrictVersion 有効にするとVersion以外のIDを検証で拒否する
+	// 無効の場合は移行期間としてv4とv7の両方を受け付ける
+	StrictVersion bool
+}
+
+// DefaultIDConfig 既定のID設定
+var DefaultIDConfig = IDConfig{Version: IDVersion7}
+
+// IsValid サポートしているバージョンか確認
+func (v IDVersion) IsValid() bool {
+	return v == IDVersion4 || v == IDVersion7
+}
+
+// Validate 設定を検証する
+func (c IDConfig) Validate() error {
+	if !c.version().IsValid() {
+		return fmt.Errorf("unsupported uuid version: %d", c.Version)
+	}
+	return nil
+}
+
+// NewID 設定されたバージョンで新しいIDを生成
+func (c IDConfig) NewID() uuid.UUID {
+	if c.version() == IDVersion4 {
+		return uuid.New()
+	}
+	return uuid.Must(uuid.NewV7())
+}
+
+// ValidateID IDのバージョンを検証
+// StrictVersionが無効の場合はv4とv7の両方を受け付ける
+func (c IDConfig) ValidateID(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return ErrInvalidID
+	}
+
+	version := IDVersion(id.Version())
+	if c.StrictVersion {
+		if version != c.version() {
+			return ErrInvalidID
+		}
+		return nil
+	}
+	if !version.IsValid() {
+		return ErrInvalidID
+	}
+	return nil
+}
+
+// ParseAccountID 文字列をアカウントIDとして解析・検証
+func (c IDConfig) ParseAccountID(s string) (uuid.UUID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, ErrInvalidAccountID
+	}
+	if err := c.ValidateID(id); err != nil {
+		return uuid.Nil, ErrInvalidAccountID
+	}
+	return id, nil
+}
+
+// version 新規IDのバージョンを返す（未設定の場合はv7）
+func (c IDConfig) version() IDVersion {
+	if c.Version == 0 {
+		return IDVersion7
+	}
+	return c.Version
+}
+
+// NewID 既定の設定（v7）で新しいIDを生成
+// エンティティのコンストラクタが使用し、ユースケースは設定に従って生成したIDで上書きする
+func NewID() uuid.UUID {
+	return DefaultIDConfig.NewID()
+}
+
+// ValidateAccountID アカウントIDを既定の設定（v4とv7の両方を受け付ける）で検証
+func ValidateAccountID(id uuid.UUID) error {
+	if err := DefaultIDConfig.ValidateID(id); err != nil {
+		return ErrInvalidAccountID
+	}
+	return nil
+}
+
+// ParseAccountID 文字列をアカウントIDとして既定の設定で解析・検証
+func ParseAccountID(s string) (uuid.UUID, error) {
+	return DefaultIDConfig.ParseAccountID(s)
+}
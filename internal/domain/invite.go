@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Invite 招待制のサインアップで使用する招待
+// トークンはハッシュのみを保存し、1回使用すると消費済みになる
+// サインアップしたアカウントには招待で指定したロールとテナントを割り当てる
+type Invite struct {
+	ID         uuid.UUID  `db:"id"`
+	TokenHash  string     `db:"token_hash"`
+	Role       Role       `db:"role"`
+	TenantID   string     `db:"tenant_id"`
+	CreatedBy  uuid.UUID  `db:"created_by"`
+	ExpiresAt  time.Time  `db:"expires_at"`
+	ConsumedAt *time.Time `db:"consumed_at"`
+	ConsumedBy *uuid.UUID `db:"consumed_by"` // 招待を使用してサインアップしたアカウント
+	CreatedAt  time.Time  `db:"created_at"`
+}
+
+// NewInvite 新しいInviteを作成
+func NewInvite(createdBy uuid.UUID, tokenHash string, role Role, tenantID string, expiresAt time.Time) *Invite {
+	return &Invite{
+		ID:        NewID(),
+		TokenHash: tokenHash,
+		Role:      role,
+		TenantID:  tenantID,
+		CreatedBy: createdBy,
+		ExpiresAt: expiresAt,
+		CreatedAt: Now(),
+	}
+}
+
+// IsUsable 指定時刻に未使用かつ有効期限内か確認
+func (i *Invite) IsUsable(now time.Time) bool {
+	return i.ConsumedAt == nil && now.Before(i.ExpiresAt)
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountLockedError ロック中のログインを拒否した際のエラー
+// errors.Is(err, ErrAccountLocked) で判定でき、解除までの時間を保持する
+type AccountLockedError struct {
+	LockedUntil time.Time
+	RetryAfter  time.Duration
+}
+
+// Error errorインターフェースを実装
+func (e *AccountLockedError) Error() string {
+	return fmt.Sprintf("%s: retry after %s", ErrAccountLocked, e.RetryAfter.Round(time.Second))
+}
+
+// RetryAfterSeconds ロック解除までの秒数を切り上げて返す（最低1秒）
+func (e *AccountLockedError) RetryAfterSeconds() int {
+	return max(int(math.Ceil(e.RetryAfter.Seconds())), 1)
+}
+
+// Unwrap ErrAccountLockedを返す
+func (e *AccountLockedError) Unwrap() error {
+	return ErrAccountLocked
+}
+
+// LockoutPolicy ログイン失敗によるロックアウトの設定
+type LockoutPolicy struct {
+	// MaxFailedAttempts ロックするまでに許容する連続失敗回数（0でロックアウトを無効化）
+	MaxFailedAttempts int
+	// BaseCooldown 最初のロックの期間（以降はロックのたびに倍増する）
+	BaseCooldown time.Duration
+	// MaxCooldown ロック期間の上限
+	MaxCooldown time.Duration
+	// QuietPeriod 最後の失敗からこの期間が経過すると失敗回数と倍増の段階をリセットする
+	QuietPeriod time.Duration
+}
+
+// Enabled ロックアウトが有効か返す
+func (p LockoutPolicy) Enabled() bool {
+	return p.MaxFailedAttempts > 0
+}
+
+// Cooldown lockoutCount回目のロックの期間を返す
+// BaseCooldown * 2^(lockoutCount-1) をMaxCooldownで打ち切る
+func (p LockoutPolicy) Cooldown(lockoutCount int) time.Duration {
+	cooldown := p.BaseCooldown
+	for i := 1; i < lockoutCount && cooldown < p.MaxCooldown; i++ {
+		cooldown *= 2
+	}
+	return min(cooldown, p.MaxCooldown)
+}
+
+// LoginAttempt アカウントごとのログイン失敗の記録
+type LoginAttempt struct {
+	AccountID    uuid.UUID  `db:"account_id"`
+	FailedCount  int        `db:"failed_count"`  // 直近のロック以降の連続失敗回数
+	LockoutCount int        `db:"lockout_count"` // QuietPeriod内に繰り返されたロックの回数
+	LockedUntil  *time.Time `db:"locked_until"`
+	LastFailedAt time.Time  `db:"last_failed_at"`
+}
+
+// NewLoginAttempt 失敗の記録が無いアカウントのLoginAttemptを作成
+func NewLoginAttempt(accountID uuid.UUID) *LoginAttempt {
+	return &LoginAttempt{AccountID: accountID}
+}
+
+// IsLocked 指定時刻にロック中か確認
+func (a *LoginAttempt) IsLocked(now time.Time) bool {
+	return a.LockedUntil != nil && now.Before(*a.LockedUntil)
+}
+
+// RecordFailure ログインの失敗を記録
+// 失敗回数がMaxFailedAttemptsに達した場合はロックし、ロックの期間を返す（ロックしない場合は0）
+func (a *LoginAttempt) RecordFailure(now time.Time, policy LockoutPolicy) time.Duration {
+	// 静穏期間を過ぎた場合は新しい攻撃とみなして段階をリセット
+	if !a.LastFailedAt.IsZero() && now.Sub(a.LastFailedAt) >= policy.QuietPeriod {
+		a.FailedCount = 0
+		a.LockoutCount = 0
+	}
+	a.LastFailedAt = now
+	a.FailedCount++
+
+	if a.FailedCount < policy.MaxFailedAttempts {
+		return 0
+	}
+
+	a.FailedCount = 0
+	a.LockoutCount++
+	cooldown := policy.Cooldown(a.LockoutCount)
+	lockedUntil := now.Add(cooldown)
+	a.LockedUntil = &lockedUntil
+	return cooldown
+}
+
+// RecordSuccess ログインの成功を記録し、連続失敗回数をリセット
+// 倍増の段階はQuietPeriodが経過するまで維持する
+func (a *LoginAttempt) RecordSuccess() {
+	a.FailedCount = 0
+	a.LockedUntil = nil
+}
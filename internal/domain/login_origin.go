@@ -0,0 +1,98 @@
+package domain
+
+import "net/netip"
+
+// DeviceMatchMode 新しい端末・場所からのログインかどうかを判定する厳しさ
+type DeviceMatchMode string
+
+const (
+	// DeviceMatchOff 判定しない
+	DeviceMatchOff DeviceMatchMode = "off"
+	// DeviceMatchLenient 過去のセッションと端末またはサブネットのどちらかが一致すれば既知とする
+	DeviceMatchLenient DeviceMatchMode = "lenient"
+	// DeviceMatchStrict 端末とサブネットの両方が一致するセッションがある場合のみ既知とする
+	DeviceMatchStrict DeviceMatchMode = "strict"
+)
+
+// Enabled 新しい端末・場所からのログインを判定するか返す
+func (m DeviceMatchMode) Enabled() bool {
+	return m == DeviceMatchLenient || m == DeviceMatchStrict
+}
+
+// サブネットとして比較するプレフィックス長
+// 同じ回線でも再接続でアドレスが変わるため、アドレス単位ではなくサブネット単位で比較する
+const (
+	loginSubnetBitsIPv4 = 24
+	loginSubnetBitsIPv6 = 48
+)
+
+// LoginOrigin ログイン元の端末とネットワーク
+type LoginOrigin struct {
+	// Device User-Agentから推定した端末（ブラウザの更新で別の端末と扱わないようバージョンは含めない）
+	Device UserAgentInfo
+	// Subnet IPアドレスを含むサブネット（IPv4は/24、IPv6は/48、不明な場合は空文字）
+	Subnet string
+}
+
+// NewLoginOrigin User-AgentとIPアドレスからログイン元を求める
+func NewLoginOrigin(userAgent, ipAddress string) LoginOrigin {
+	device := ParseUserAgent(userAgent)
+	device.BrowserVersion = ""
+	return LoginOrigin{
+		Device: device,
+		Subnet: loginSubnet(ipAddress),
+	}
+}
+
+// loginSubnet IPアドレスを含むサブネットを返す（解析できない場合は空文字）
+func loginSubnet(ipAddress string) string {
+	addr, err := netip.ParseAddr(ipAddress)
+	if err != nil {
+		return ""
+	}
+	addr = addr.Unmap()
+	bits := loginSubnetBitsIPv6
+	if addr.Is4() {
+		bits = loginSubnetBitsIPv4
+	}
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return ""
+	}
+	return prefix.String()
+}
+
+// IsNew 過去のセッションに同じ端末・サブネットからのものが無いか判定
+// User-AgentもIPアドレスも記録されていないセッション（サインアップ直後など）は比較に使わず、
+// 比較できるセッションが無い場合（初回のログイン）や判定しない設定（空文字を含む）の場合はfalseを返す
+func (m DeviceMatchMode) IsNew(origin LoginOrigin, history []*RefreshToken) bool {
+	if !m.Enabled() {
+		return false
+	}
+
+	compared := 0
+	for _, token := range history {
+		var userAgent, ipAddress string
+		if token.UserAgent != nil {
+			userAgent = *token.UserAgent
+		}
+		if token.IPAddress != nil {
+			ipAddress = *token.IPAddress
+		}
+		if userAgent == "" && ipAddress == "" {
+			continue
+		}
+		compared++
+
+		known := NewLoginOrigin(userAgent, ipAddress)
+		sameDevice := known.Device == origin.Device
+		sameSubnet := known.Subnet != "" && known.Subnet == origin.Subnet
+		if sameDevice && sameSubnet {
+			return false
+		}
+		if m != DeviceMatchStrict && (sameDevice || sameSubnet) {
+			return false
+		}
+	}
+	return compared > 0
+}
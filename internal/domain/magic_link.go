@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MagicLink メールで送信するパスワード不要のログイン用リンク
+// トークンはハッシュのみを保存し、1回使用すると消費済みになる
+type MagicLink struct {
+	ID         uuid.UUID  `db:"id"`
+	AccountID  uuid.UUID  `db:"account_id"`
+	TokenHash  string     `db:"token_hash"`
+	ExpiresAt  time.Time  `db:"expires_at"`
+	ConsumedAt *time.Time `db:"consumed_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+}
+
+// NewMagicLink 新しいMagicLinkを作成
+func NewMagicLink(accountID uuid.UUID, tokenHash string, expiresAt time.Time) *MagicLink {
+	return &MagicLink{
+		ID:        NewID(),
+		AccountID: accountID,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+		CreatedAt: Now(),
+	}
+}
+
+// IsUsable 指定時刻に未使用かつ有効期限内か確認
+func (l *MagicLink) IsUsable(now time.Time) bool {
+	return l.ConsumedAt == nil && now.Before(l.ExpiresAt)
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// NameCharClass アカウント名に使用できる文字の種類（Unicodeの一般カテゴリ）
+// 制御文字やゼロ幅文字などの書式文字はどの種類にも含まれないため、常に拒否される
+type NameCharClass string
+
+const (
+	NameCharLetter      NameCharClass = "letter"      // 文字（漢字・かなを含む）
+	NameCharMark        NameCharClass = "mark"        // 結合文字（濁点・アクセントなど）
+	NameCharNumber      NameCharClass = "number"      // 数字
+	NameCharPunctuation NameCharClass = "punctuation" // 句読点・ハイフンなど
+	NameCharSymbol      NameCharClass = "symbol"      // 記号・絵文字
+	NameCharSpace       NameCharClass = "space"       // スペース（改行やタブは含まない）
+)
+
+// nameCharClassTables 文字の種類ごとのUnicodeの範囲
+var nameCharClassTables = map[NameCharClass]*unicode.RangeTable{
+	NameCharLetter:      unicode.L,
+	NameCharMark:        unicode.M,
+	NameCharNumber:      unicode.N,
+	NameCharPunctuation: unicode.P,
+	NameCharSymbol:      unicode.S,
+	NameCharSpace:       unicode.Zs,
+}
+
+// IsValid 定義済みの文字の種類か確認
+func (c NameCharClass) IsValid() bool {
+	_, ok := nameCharClassTables[c]
+	return ok
+}
+
+// NameConfig アカウント名の検証ルール
+type NameConfig struct {
+	// MinLength 最小文字数（1以上）
+	MinLength int
+	// MaxLength 最大文字数（MaxNameLength以下）
+	MaxLength int
+	// AllowedClasses 使用できる文字の種類
+	AllowedClasses []NameCharClass
+	// RejectMarkup 有効にするとHTMLとして解釈されうる文字（< > & " '）を拒否する
+	RejectMarkup bool
+	// BlockedWords 含めることを禁止する語（大文字小文字を区別しない）
+	BlockedWords []string
+}
+
+// DefaultNameConfig 既定のアカウント名の検証ルール
+var DefaultNameConfig = NameConfig{
+	MinLength: 1,
+	MaxLength: MaxNameLength,
+	AllowedClasses: []NameCharClass{
+		NameCharLetter, NameCharMark, NameCharNumber,
+		NameCharPunctuation, NameCharSymbol, NameCharSpace,
+	},
+}
+
+// Validate 検証ルールの設定値を検証
+func (c NameConfig) Validate() error {
+	if c.MinLength < 1 || c.MaxLength < c.MinLength || c.MaxLength > MaxNameLength {
+		return fmt.Errorf("name length must satisfy 1 <= min <= max <= %d", MaxNameLength)
+	}
+	if len(c.AllowedClasses) == 0 {
+		return fmt.Errorf("at least one name character class is required")
+	}
+	for _, class := range c.AllowedClasses {
+		if !class.IsValid() {
+			return fmt.Errorf("unsupported name character class: %q", class)
+		}
+	}
+	return nil
+}
+
+// NormalizeName 前後の空白を取り除く
+func NormalizeName(name string) string {
+	return strings.TrimSpace(name)
+}
+
+// ValidateName アカウント名を既定の検証ルールで検証
+// エンティティの検証が使用し、ユースケースは設定された検証ルールで追加で検証する
+func ValidateName(name string) error {
+	return DefaultNameConfig.ValidateName(name)
+}
+
+// ValidateName アカウント名をこの検証ルールで検証
+// 違反した場合は理由を含めてErrInvalidNameをラップしたエラーを返す
+func (c NameConfig) ValidateName(name string) error {
+	if !utf8.ValidString(name) {
+		return fmt.Errorf("%w: must be valid UTF-8", ErrInvalidName)
+	}
+	length := utf8.RuneCountInString(name)
+	if length < c.MinLength {
+		return fmt.Errorf("%w: must be at least %d characters", ErrInvalidName, c.MinLength)
+	}
+	if length > c.MaxLength {
+		return fmt.Errorf("%w: must be at most %d characters", ErrInvalidName, c.MaxLength)
+	}
+
+	tables := make([]*unicode.RangeTable, 0, len(c.AllowedClasses))
+	for _, class := range c.AllowedClasses {
+		tables = append(tables, nameCharClassTables[class])
+	}
+	for _, r := range name {
+		if unicode.IsControl(r) || unicode.Is(unicode.Cf, r) {
+			return fmt.Errorf("%w: must not contain control or invisible characters", ErrInvalidName)
+		}
+		if !unicode.IsOneOf(tables, r) {
+			return fmt.Errorf("%w: contains a disallowed character %q", ErrInvalidName, r)
+		}
+	}
+
+	if c.RejectMarkup && strings.ContainsAny(name, `<>&"'`) {
+		return fmt.Errorf("%w: must not contain markup characters", ErrInvalidName)
+	}
+
+	lower := strings.ToLower(name)
+	for _, word := range c.BlockedWords {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" && strings.Contains(lower, word) {
+			return fmt.Errorf("%w: contains a blocked word", ErrInvalidName)
+		}
+	}
+
+	return nil
+}
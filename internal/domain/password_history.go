@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordHistory 過去に使用したパスワードのハッシュ
+// パスワード変更時に再利用を防ぐため、変更前のハッシュを保存する
+type PasswordHistory struct {
+	ID           uuid.UUID `db:"id"`
+	AccountID    uuid.UUID `db:"account_id"`
+	PasswordHash string    `db:"password_hash"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// NewPasswordHistory 新しいパスワード履歴を作成
+func NewPasswordHistory(accountID uuid.UUID, passwordHash string) *PasswordHistory {
+	return &PasswordHistory{
+		ID:           NewID(),
+		AccountID:    accountID,
+		PasswordHash: passwordHash,
+		CreatedAt:    Now(),
+	}
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordReset メールで送信するパスワード再設定用のトークン
+// トークンはハッシュのみを保存し、1回使用するか新しいトークンを発行すると消費済みになる
+type PasswordReset struct {
+	ID         uuid.UUID  `db:"id"`
+	AccountID  uuid.UUID  `db:"account_id"`
+	TokenHash  string     `db:"token_hash"`
+	ExpiresAt  time.Time  `db:"expires_at"`
+	ConsumedAt *time.Time `db:"consumed_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+}
+
+// NewPasswordReset 新しいPasswordResetを作成
+func NewPasswordReset(accountID uuid.UUID, tokenHash string, expiresAt time.Time) *PasswordReset {
+	return &PasswordReset{
+		ID:        NewID(),
+		AccountID: accountID,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+		CreatedAt: Now(),
+	}
+}
+
+// IsUsable 指定時刻に未使用かつ有効期限内か確認
+func (r *PasswordReset) IsUsable(now time.Time) bool {
+	return r.ConsumedAt == nil && now.Before(r.ExpiresAt)
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,27 +19,52 @@ const (
 // Project プロジェクトエンティティ
 type Project struct {
 	ID          uuid.UUID     `db:"id" json:"id"`
+	TenantID    string        `db:"tenant_id" json:"tenant_id"` // 所有するアカウントと同じテナント
 	AccountID   uuid.UUID     `db:"account_id" json:"account_id"`
 	Name        string        `db:"name" json:"name"`
 	Description string        `db:"description" json:"description"`
 	Status      ProjectStatus `db:"status" json:"status"`
+	CreatedBy   *uuid.UUID    `db:"created_by" json:"created_by,omitempty"` // 作成したアカウント
+	UpdatedBy   *uuid.UUID    `db:"updated_by" json:"updated_by,omitempty"` // 最後に更新したアカウント
 	CreatedAt   time.Time     `db:"created_at" json:"created_at"`
 	UpdatedAt   time.Time     `db:"updated_at" json:"updated_at"`
 }
 
+// ProjectFilter プロジェクト検索の条件
+// nilの項目は絞り込みに使用しない
+type ProjectFilter struct {
+	AccountID *uuid.UUID
+	Status    *ProjectStatus
+	Limit     int
+	Offset    int
+	Cursor    *PageCursor // 指定した場合はOffsetの代わりにカーソルの前後を取得
+}
+
 // NewProject 新しいProjectを作成
 func NewProject(accountID uuid.UUID, name, description string) *Project {
 	return &Project{
-		ID:          uuid.New(),
+		ID:          NewID(),
+		TenantID:    DefaultTenantID,
 		AccountID:   accountID,
 		Name:        name,
 		Description: description,
 		Status:      ProjectStatusActive,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		CreatedAt:   Now(),
+		UpdatedAt:   Now(),
 	}
 }
 
+// RecordCreatedBy 作成者を記録（最終更新者も同じアカウントになる）
+func (p *Project) RecordCreatedBy(actorID uuid.UUID) {
+	p.CreatedBy = &actorID
+	p.UpdatedBy = &actorID
+}
+
+// RecordUpdatedBy 最終更新者を記録
+func (p *Project) RecordUpdatedBy(actorID uuid.UUID) {
+	p.UpdatedBy = &actorID
+}
+
 // Validate プロジェクトエンティティを検証
 func (p *Project) Validate() error {
 	if p.AccountID == uuid.Nil {
@@ -50,6 +76,15 @@ func (p *Project) Validate() error {
 	if len(p.Name) > MaxNameLength {
 		return ErrInvalidName
 	}
+	// 設定された最大文字数はユースケースで検証し、ここでは保存できる上限のみ確認する
+	description, err := NormalizeProjectDescription(p.Description, MaxProjectDescriptionLength)
+	if err != nil {
+		return err
+	}
+	p.Description = description
+	if p.TenantID == "" {
+		p.TenantID = DefaultTenantID
+	}
 	if p.Status == "" {
 		p.Status = ProjectStatusActive
 	}
@@ -59,9 +94,19 @@ func (p *Project) Validate() error {
 	return nil
 }
 
+// BelongsTo プロジェクトがコンテキストのテナントに属しているか返す
+func (p *Project) BelongsTo(ctx context.Context) bool {
+	return InTenant(ctx, p.TenantID)
+}
+
 // IsValidStatus ステータスが有効か確認
 func (p *Project) IsValidStatus() bool {
-	switch p.Status {
+	return p.Status.IsValid()
+}
+
+// IsValid 定義済みのステータスか確認
+func (s ProjectStatus) IsValid() bool {
+	switch s {
 	case ProjectStatusActive, ProjectStatusInactive, ProjectStatusArchived:
 		return true
 	default:
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxProjectDescriptionLength 設定できるプロジェクト説明の最大文字数の上限
+// 4バイト文字のみでもTEXTカラム（65,535バイト）に収まる文字数
+const MaxProjectDescriptionLength = 16000
+
+// DefaultProjectDescriptionMaxLength 既定のプロジェクト説明の最大文字数
+const DefaultProjectDescriptionMaxLength = 2000
+
+// NormalizeProjectDescription 説明から制御文字を取り除き、指定した最大文字数で検証
+// 複数行の説明を書けるよう、改行とタブは残す（CRLFはLFになる）
+// 違反した場合は理由を含めてErrInvalidDescriptionをラップしたエラーを返す
+func NormalizeProjectDescription(description string, maxLength int) (string, error) {
+	if !utf8.ValidString(description) {
+		return "", fmt.Errorf("%w: must be valid UTF-8", ErrInvalidDescription)
+	}
+	description = strings.Map(func(r rune) rune {
+		if r != '\n' && r != '\t' && unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, description)
+	if utf8.RuneCountInString(description) > maxLength {
+		return "", fmt.Errorf("%w: must be at most %d characters", ErrInvalidDescription, maxLength)
+	}
+	return description, nil
+}
@@ -1,52 +1,135 @@
 package domain
 
 import (
+	"fmt"
+	"math"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
 
 // RefreshToken リフレッシュトークンのドメインモデル
 type RefreshToken struct {
-	ID        uuid.UUID  `db:"id"`
-	AccountID uuid.UUID  `db:"account_id"`
-	TokenHash string     `db:"token_hash"`
-	ExpiresAt time.Time  `db:"expires_at"`
-	CreatedAt time.Time  `db:"created_at"`
-	UsedAt    *time.Time `db:"used_at"`
-	RevokedAt *time.Time `db:"revoked_at"`
-	UserAgent *string    `db:"user_agent"`
-	IPAddress *string    `db:"ip_address"`
+	ID                uuid.UUID  `db:"id"`
+	AccountID         uuid.UUID  `db:"account_id"`
+	FamilyID          uuid.UUID  `db:"family_id"` // ローテーションで引き継がれるトークンファミリーのID
+	TokenHash         string     `db:"token_hash"`
+	ExpiresAt         time.Time  `db:"expires_at"`
+	AbsoluteExpiresAt time.Time  `db:"absolute_expires_at"` // ファミリー全体の絶対有効期限
+	CreatedAt         time.Time  `db:"created_at"`
+	UsedAt            *time.Time `db:"used_at"`
+	SuccessorID       *uuid.UUID `db:"successor_id"` // ローテーションで発行した次のトークンのID
+	RevokedAt         *time.Time `db:"revoked_at"`
+	UserAgent         *string    `db:"user_agent"`
+	IPAddress         *string    `db:"ip_address"`
+	DeviceName        *string    `db:"device_name"` // セッション一覧で表示する端末名
+}
+
+// UserAgentInfo 作成時のUser-Agentから推定したブラウザ・OS・端末の種類を返す
+func (rt *RefreshToken) UserAgentInfo() UserAgentInfo {
+	if rt.UserAgent == nil {
+		return UserAgentInfo{}
+	}
+	return ParseUserAgent(*rt.UserAgent)
+}
+
+// MaxDeviceNameLength 端末名の最大文字数
+const MaxDeviceNameLength = 100
+
+// NormalizeDeviceName ユーザーが指定した端末名の前後の空白を取り除いて検証
+func NormalizeDeviceName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if utf8.RuneCountInString(name) > MaxDeviceNameLength {
+		return "", fmt.Errorf("%w: must be at most %d characters", ErrInvalidDeviceName, MaxDeviceNameLength)
+	}
+	for _, r := range name {
+		if unicode.IsControl(r) || unicode.Is(unicode.Cf, r) {
+			return "", fmt.Errorf("%w: must not contain control or invisible characters", ErrInvalidDeviceName)
+		}
+	}
+	return name, nil
 }
 
 // NewRefreshToken 新しいRefreshTokenを作成
+// 新しいファミリーの先頭として作成されるため、FamilyIDはトークンID、絶対有効期限はexpiresAtになります
 func NewRefreshToken(accountID uuid.UUID, tokenHash string, expiresAt time.Time, userAgent, ipAddress *string) *RefreshToken {
+	id := NewID()
 	return &RefreshToken{
-		ID:        uuid.New(),
-		AccountID: accountID,
-		TokenHash: tokenHash,
-		ExpiresAt: expiresAt,
-		CreatedAt: time.Now(),
-		UserAgent: userAgent,
-		IPAddress: ipAddress,
+		ID:                id,
+		AccountID:         accountID,
+		FamilyID:          id,
+		TokenHash:         tokenHash,
+		ExpiresAt:         expiresAt,
+		AbsoluteExpiresAt: expiresAt,
+		CreatedAt:         Now(),
+		UserAgent:         userAgent,
+		IPAddress:         ipAddress,
 	}
 }
 
 // IsValid トークンが有効かどうかを確認します
 func (rt *RefreshToken) IsValid() bool {
-	now := time.Now()
+	now := Now()
 	// 有効期限切れ、使用済み、無効化済みでないことを確認
 	return rt.ExpiresAt.After(now) && rt.UsedAt == nil && rt.RevokedAt == nil
 }
 
+// IsAbsoluteExpired ファミリーの絶対有効期限を過ぎているかを確認します
+func (rt *RefreshToken) IsAbsoluteExpired(now time.Time) bool {
+	return !rt.AbsoluteExpiresAt.After(now)
+}
+
 // MarkAsUsed トークンを使用済みとしてマークします
 func (rt *RefreshToken) MarkAsUsed() {
-	now := time.Now()
+	now := Now()
 	rt.UsedAt = &now
 }
 
+// IsRetryWithinGrace 使用済みトークンの再提示がローテーション直後の猶予期間内かを確認します
+// 通信エラーによるクライアントの再試行を再利用攻撃と区別するために使用します
+func (rt *RefreshToken) IsRetryWithinGrace(now time.Time, grace time.Duration) bool {
+	if grace <= 0 || rt.UsedAt == nil || rt.SuccessorID == nil || rt.RevokedAt != nil {
+		return false
+	}
+	return !now.After(rt.UsedAt.Add(grace))
+}
+
 // Revoke トークンを無効化します
 func (rt *RefreshToken) Revoke() {
-	now := time.Now()
+	now := Now()
 	rt.RevokedAt = &now
 }
+
+// SlidingExpiresAt スライディング方式で次のトークンの有効期限を計算します
+// アイドル期間分だけ延長しますが、絶対有効期限を超えることはありません
+func SlidingExpiresAt(now time.Time, idleTimeout time.Duration, absoluteExpiresAt time.Time) time.Time {
+	expiresAt := now.Add(idleTimeout)
+	if expiresAt.After(absoluteExpiresAt) {
+		return absoluteExpiresAt
+	}
+	return expiresAt
+}
+
+// RefreshRateLimitedError 短時間のリフレッシュが上限を超えたため拒否した際のエラー
+// errors.Is(err, ErrRefreshRateLimited) で判定でき、再試行できるまでの時間を保持する
+type RefreshRateLimitedError struct {
+	RetryAfter time.Duration
+}
+
+// Error errorインターフェースを実装
+func (e *RefreshRateLimitedError) Error() string {
+	return fmt.Sprintf("%s: retry after %s", ErrRefreshRateLimited, e.RetryAfter.Round(time.Second))
+}
+
+// RetryAfterSeconds 再試行できるまでの秒数を切り上げて返す（最低1秒）
+func (e *RefreshRateLimitedError) RetryAfterSeconds() int {
+	return max(int(math.Ceil(e.RetryAfter.Seconds())), 1)
+}
+
+// Unwrap ErrRefreshRateLimitedを返す
+func (e *RefreshRateLimitedError) Unwrap() error {
+	return ErrRefreshRateLimited
+}
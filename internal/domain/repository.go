@@ -2,39 +2,99 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 // AccountRepository アカウントリポジトリのインターフェースを定義
+// 対象が存在しない場合は (nil, nil) ではなく ErrAccountNotFound を返す
+// コンテキストにテナントが設定されている場合は、そのテナントのアカウントのみを対象とする
 type AccountRepository interface {
 	Create(ctx context.Context, account *Account) error
 	GetByID(ctx context.Context, id uuid.UUID) (*Account, error)
 	GetByEmail(ctx context.Context, email string) (*Account, error)
-	List(ctx context.Context) ([]*Account, error)
+	GetByUsername(ctx context.Context, username string) (*Account, error)                            // 正規化したユーザー名で取得
+	List(ctx context.Context, limit, offset int) ([]*Account, error)                                 // 作成日時の新しい順
+	ListWithProjectCounts(ctx context.Context, filter AccountFilter) ([]*AccountProjectCount, error) // プロジェクト数を集計して取得
+	Count(ctx context.Context, filter AccountFilter) (int, error)
+	SearchByEmailPrefix(ctx context.Context, prefix string, limit int) ([]*Account, error) // メールアドレスの前方一致（メールアドレス順）
+	ListDeletionDue(ctx context.Context, now time.Time, limit int) ([]*Account, error)     // 猶予期間が過ぎた削除予定のアカウント（削除予定日時の古い順）
 	Update(ctx context.Context, account *Account) error
+	RecordLogin(ctx context.Context, id uuid.UUID, at time.Time, ipAddress *string) error // 最終ログインの日時と接続元を記録（バージョンと更新日時は変えない）
+	UpdatePasswordHash(ctx context.Context, id uuid.UUID, passwordHash string) error      // 同じパスワードのハッシュを置き換える（バージョンと更新日時は変えない）
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
 // ProjectRepository プロジェクトリポジトリのインターフェースを定義
+// 対象が存在しない場合は (nil, nil) ではなく ErrProjectNotFound を返す
+// コンテキストにテナントが設定されている場合は、そのテナントのプロジェクトのみを対象とする
 type ProjectRepository interface {
 	Create(ctx context.Context, project *Project) error
 	GetByID(ctx context.Context, id uuid.UUID) (*Project, error)
 	GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*Project, error)
 	List(ctx context.Context) ([]*Project, error)
+	Search(ctx context.Context, filter ProjectFilter) ([]*Project, error)
+	Count(ctx context.Context, filter ProjectFilter) (int, error)
 	Update(ctx context.Context, project *Project) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	DeleteByAccountID(ctx context.Context, accountID uuid.UUID) error
 }
 
 // RefreshTokenRepository リフレッシュトークンリポジトリのインターフェースを定義
+// 対象が存在しない場合は (nil, nil) ではなく ErrNotFound を返す
 type RefreshTokenRepository interface {
 	Create(ctx context.Context, token *RefreshToken) error
+	GetByID(ctx context.Context, id uuid.UUID) (*RefreshToken, error)
 	GetByTokenHash(ctx context.Context, tokenHash string) (*RefreshToken, error)
-	MarkAsUsed(ctx context.Context, id uuid.UUID) error
+	MarkAsUsed(ctx context.Context, id uuid.UUID) (bool, error)                 // 未使用の場合のみ使用済みにする（既に使用済みならfalse）
+	MarkAsRotated(ctx context.Context, id, successorID uuid.UUID) (bool, error) // 未使用の場合のみ使用済みにして次のトークンを記録（既に使用済みならfalse）
 	Revoke(ctx context.Context, id uuid.UUID) error
-	RevokeByAccountID(ctx context.Context, accountID uuid.UUID) error
-	DeleteExpired(ctx context.Context) error
+	RevokeByAccountID(ctx context.Context, accountID uuid.UUID) (int64, error)
+	RevokeByIP(ctx context.Context, ipAddress string) (int64, error)                                    // 作成元のIPアドレスが一致する未失効のトークンを無効化（コンテキストのテナントのアカウントのみ）
+	RevokeByUserAgent(ctx context.Context, userAgent string) (int64, error)                             // 作成元のUser-Agentが完全一致する未失効のトークンを無効化（コンテキストのテナントのアカウントのみ）
+	CountRotatedSince(ctx context.Context, accountID uuid.UUID, since time.Time) (int, error)           // since以降にリフレッシュで使用済みになった件数
+	ListRecentByAccountID(ctx context.Context, accountID uuid.UUID, limit int) ([]*RefreshToken, error) // 失効・期限切れを含めて作成日時の新しい順
+	DeleteExpired(ctx context.Context, batchSize int, pause time.Duration) (int64, error)               // batchSize件ずつ削除し、バッチの間はpauseだけ待つ（削除した件数を返す）
+}
+
+// PasswordHistoryRepository パスワード履歴リポジトリのインターフェースを定義
+type PasswordHistoryRepository interface {
+	Create(ctx context.Context, history *PasswordHistory) error
+	ListRecent(ctx context.Context, accountID uuid.UUID, limit int) ([]*PasswordHistory, error) // 新しい順
+	Prune(ctx context.Context, accountID uuid.UUID, keep int) error                             // 新しい順にkeep件だけ残す
+}
+
+// LoginAttemptRepository ログイン失敗の記録リポジトリのインターフェースを定義
+type LoginAttemptRepository interface {
+	GetByAccountID(ctx context.Context, accountID uuid.UUID) (*LoginAttempt, error) // 記録が無い場合はErrNotFound
+	Save(ctx context.Context, attempt *LoginAttempt) error                          // 作成または更新
+}
+
+// MagicLinkRepository マジックリンクリポジトリのインターフェースを定義
+// 対象が存在しない場合は (nil, nil) ではなく ErrNotFound を返す
+type MagicLinkRepository interface {
+	Create(ctx context.Context, link *MagicLink) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*MagicLink, error)
+	MarkAsConsumed(ctx context.Context, id uuid.UUID) (bool, error)                           // 未使用の場合のみ消費済みにする（既に消費済みならfalse）
+	CountCreatedSince(ctx context.Context, accountID uuid.UUID, since time.Time) (int, error) // since以降に作成した件数
+}
+
+// PasswordResetRepository パスワード再設定トークンリポジトリのインターフェースを定義
+// 対象が存在しない場合は (nil, nil) ではなく ErrNotFound を返す
+type PasswordResetRepository interface {
+	Create(ctx context.Context, reset *PasswordReset) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*PasswordReset, error)
+	MarkAsConsumed(ctx context.Context, id uuid.UUID) (bool, error)       // 未使用かつ有効期限内の場合のみ消費済みにする（それ以外はfalse）
+	InvalidateByAccountID(ctx context.Context, accountID uuid.UUID) error // アカウントの未使用のトークンをすべて消費済みにする
+}
+
+// InviteRepository 招待リポジトリのインターフェースを定義
+// 対象が存在しない場合は (nil, nil) ではなく ErrNotFound を返す
+type InviteRepository interface {
+	Create(ctx context.Context, invite *Invite) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*Invite, error)
+	MarkAsConsumed(ctx context.Context, id, accountID uuid.UUID) (bool, error) // 未使用かつ有効期限内の場合のみ消費済みにする（それ以外はfalse）
 }
 
 // SecurityAuditLogRepository セキュリティ監査ログリポジトリのインターフェースを定義
@@ -44,3 +104,12 @@ type SecurityAuditLogRepository interface {
 	GetByEventType(ctx context.Context, eventType SecurityEventType, limit, offset int) ([]*SecurityAuditLog, error)
 	CountByAccountID(ctx context.Context, accountID uuid.UUID) (int, error)
 }
+
+// SigningKeyRepository アクセストークンの署名鍵リポジトリのインターフェースを定義
+// テナントに関係なく、すべてのインスタンスで同じ鍵を共有する
+type SigningKeyRepository interface {
+	Create(ctx context.Context, key *SigningKey) error
+	List(ctx context.Context) ([]*SigningKey, error)                                              // 有効になる日時の新しい順
+	MarkRotating(ctx context.Context, id uuid.UUID, rotatesAt, retiresAt time.Time) (bool, error) // ローテーションが未予定の場合のみ記録する（既に予定済みならfalse）
+	DeleteRetired(ctx context.Context, now time.Time) (int64, error)                              // 検証に使用しなくなった鍵を削除（削除した件数を返す）
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"encoding/json"
 	"time"
 
@@ -13,16 +14,50 @@ type SecurityEventType string
 const (
 	// EventTokenReuseDetected 使用済みトークンの再利用検出
 	EventTokenReuseDetected SecurityEventType = "TOKEN_REUSE_DETECTED"
+	// EventTokenRetryAccepted 猶予期間内の使用済みトークンの再提示（再試行として許可）
+	EventTokenRetryAccepted SecurityEventType = "TOKEN_RETRY_ACCEPTED"
+	// EventRefreshRateLimited 短時間のリフレッシュが上限を超えたため拒否（トークンの大量発行の疑い）
+	EventRefreshRateLimited SecurityEventType = "REFRESH_RATE_LIMITED"
 	// EventAllTokensRevoked すべてのトークンを無効化
 	EventAllTokensRevoked SecurityEventType = "ALL_TOKENS_REVOKED"
+	// EventSessionRevoked 個別のセッションを無効化
+	EventSessionRevoked SecurityEventType = "SESSION_REVOKED"
 	// EventSuspiciousLogin 疑わしいログイン試行
 	EventSuspiciousLogin SecurityEventType = "SUSPICIOUS_LOGIN"
+	// EventNewDeviceLogin 過去のセッションに無い端末・場所からのログイン
+	EventNewDeviceLogin SecurityEventType = "NEW_DEVICE_LOGIN"
 	// EventPasswordChanged パスワード変更
 	EventPasswordChanged SecurityEventType = "PASSWORD_CHANGED"
 	// EventAccountLocked アカウントロック
 	EventAccountLocked SecurityEventType = "ACCOUNT_LOCKED"
 	// EventMultipleFailedLogins 複数回のログイン失敗
 	EventMultipleFailedLogins SecurityEventType = "MULTIPLE_FAILED_LOGINS"
+	// EventMagicLinkRateLimited メールアドレスごとの上限を超えたためマジックリンクを送信しなかった
+	EventMagicLinkRateLimited SecurityEventType = "MAGIC_LINK_RATE_LIMITED"
+	// EventAccountDeletionRequested アカウントの削除を予約（猶予期間の開始）
+	EventAccountDeletionRequested SecurityEventType = "ACCOUNT_DELETION_REQUESTED"
+	// EventAccountDeletionCancelled 猶予期間中の削除の予約を取り消し
+	EventAccountDeletionCancelled SecurityEventType = "ACCOUNT_DELETION_CANCELLED"
+	// EventAccountPurged 猶予期間が過ぎたアカウントを完全に削除
+	EventAccountPurged SecurityEventType = "ACCOUNT_PURGED"
+	// EventAdminAction 管理者による他のアカウントへの操作（種類はメタデータのactionに記録）
+	EventAdminAction SecurityEventType = "ADMIN_ACTION"
+	// EventDebugTokenIssued ログインを経ずにデバッグ用のアクセストークンを発行（tokenサブコマンド）
+	EventDebugTokenIssued SecurityEventType = "DEBUG_TOKEN_ISSUED"
+)
+
+// AdminAction 監査ログに記録する管理者の操作の種類
+type AdminAction string
+
+const (
+	AdminActionSuspendAccount    AdminAction = "suspend_account"
+	AdminActionReactivateAccount AdminAction = "reactivate_account"
+	AdminActionDeleteAccount     AdminAction = "delete_account"
+	AdminActionRestoreAccount    AdminAction = "restore_account"
+	AdminActionRevokeSession     AdminAction = "revoke_session"
+	AdminActionBulkRevokeSession AdminAction = "bulk_revoke_sessions"
+	AdminActionExportAccount     AdminAction = "export_account"
+	AdminActionUpdateAudiences   AdminAction = "update_audiences"
 )
 
 // SecurityAuditLog セキュリティ監査ログのドメインモデル
@@ -58,13 +93,37 @@ func NewSecurityAuditLog(
 	}
 
 	return &SecurityAuditLog{
-		ID:               uuid.New(),
+		ID:               NewID(),
 		AccountID:        accountID,
 		EventType:        eventType,
 		EventDescription: description,
 		IPAddress:        ipAddress,
 		UserAgent:        userAgent,
 		Metadata:         metadataJSON,
-		CreatedAt:        time.Now(),
+		CreatedAt:        Now(),
 	}, nil
 }
+
+// NopSecurityAuditLogRepository 監査ログを保存しないSecurityAuditLogRepositoryの実装
+// 監査ログを無効にした場合やリポジトリを指定しなかった場合に使用し、呼び出し側でnilを判定しなくてよいようにする
+type NopSecurityAuditLogRepository struct{}
+
+// Create 何もしない
+func (NopSecurityAuditLogRepository) Create(context.Context, *SecurityAuditLog) error {
+	return nil
+}
+
+// GetByAccountID 常に空を返す
+func (NopSecurityAuditLogRepository) GetByAccountID(context.Context, uuid.UUID, int, int) ([]*SecurityAuditLog, error) {
+	return []*SecurityAuditLog{}, nil
+}
+
+// GetByEventType 常に空を返す
+func (NopSecurityAuditLogRepository) GetByEventType(context.Context, SecurityEventType, int, int) ([]*SecurityAuditLog, error) {
+	return []*SecurityAuditLog{}, nil
+}
+
+// CountByAccountID 常に0を返す
+func (NopSecurityAuditLogRepository) CountByAccountID(context.Context, uuid.UUID) (int, error) {
+	return 0, nil
+}
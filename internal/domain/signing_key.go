@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SigningKeyStatus アクセストークンの署名鍵の状態
+type SigningKeyStatus string
+
+const (
+	// SigningKeyStatusPending 有効になるのを待っている（すべてのインスタンスが読み込むまでは検証にのみ使用する）
+	SigningKeyStatusPending SigningKeyStatus = "pending"
+	// SigningKeyStatusActive 新しいトークンの署名に使用する
+	SigningKeyStatusActive SigningKeyStatus = "active"
+	// SigningKeyStatusRetiring 署名には使用せず、発行済みのトークンが失効するまで検証にのみ使用する
+	SigningKeyStatusRetiring SigningKeyStatus = "retiring"
+	// SigningKeyStatusRetired 検証にも使用しない（削除の対象）
+	SigningKeyStatusRetired SigningKeyStatus = "retired"
+)
+
+// SigningKey アクセストークンに署名するHMACの鍵
+// IDはJWTのkidヘッダーに設定し、鍵自体はサーバーの秘密の値で暗号化して保存する
+// 状態は日時から決まるため、複数のインスタンスが同じ鍵の一覧から同じ状態を判定できる
+type SigningKey struct {
+	ID              uuid.UUID
+	Algorithm       string // HS256/HS384/HS512
+	EncryptedSecret []byte
+	CreatedAt       time.Time
+	ActivatesAt     time.Time  // 署名に使用し始める日時
+	RotatesAt       *time.Time // 次の鍵に署名を引き継ぐ日時（ローテーションを予定するまではnil）
+	RetiresAt       *time.Time // 検証に使用しなくなる日時（ローテーションを予定するまではnil）
+}
+
+// Status nowの時点での鍵の状態を返す
+func (k *SigningKey) Status(now time.Time) SigningKeyStatus {
+	switch {
+	case k.RetiresAt != nil && !now.Before(*k.RetiresAt):
+		return SigningKeyStatusRetired
+	case k.RotatesAt != nil && !now.Before(*k.RotatesAt):
+		return SigningKeyStatusRetiring
+	case now.Before(k.ActivatesAt):
+		return SigningKeyStatusPending
+	default:
+		return SigningKeyStatusActive
+	}
+}
+
+// Verifies nowの時点でトークンの検証に使用できるか返す（引退した鍵以外）
+func (k *SigningKey) Verifies(now time.Time) bool {
+	return k.Status(now) != SigningKeyStatusRetired
+}
+
+// CurrentSigningKey nowの時点で署名に使用する鍵を返す（無い場合はnil）
+// 複数のインスタンスが同時に最初の鍵を作成した場合など有効な鍵が複数あるときは、最も新しく有効になった鍵を使用する
+func CurrentSigningKey(keys []*SigningKey, now time.Time) *SigningKey {
+	var current *SigningKey
+	for _, k := range keys {
+		if k.Status(now) != SigningKeyStatusActive {
+			continue
+		}
+		if current == nil || k.ActivatesAt.After(current.ActivatesAt) ||
+			(k.ActivatesAt.Equal(current.ActivatesAt) && k.ID.String() > current.ID.String()) {
+			current = k
+		}
+	}
+	return current
+}
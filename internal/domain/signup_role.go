@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+)
+
+// SignupRolePolicy サインアップで作成するアカウントのロールの方針
+// クライアントが指定したロールはSelfAssignableに含まれる場合のみ採用し、それ以外はDefaultRoleを割り当てる
+// 招待によるサインアップは招待で指定したロールを使用するため、この方針の対象外
+type SignupRolePolicy struct {
+	// DefaultRole ロールの指定が無い、または指定を採用しない場合に割り当てるロール（空の場合はRoleUser）
+	DefaultRole Role
+	// SelfAssignable クライアントが自分で選べるロール（空の場合は指定を常に無視する）
+	SelfAssignable []Role
+}
+
+// Validate 方針を検証する
+// 権限の昇格を防ぐため、管理者ロールは既定のロールにも自分で選べるロールにも指定できない
+func (p SignupRolePolicy) Validate() error {
+	for _, role := range append([]Role{p.defaultRole()}, p.SelfAssignable...) {
+		if !role.IsValid() {
+			return fmt.Errorf("%w: %q", ErrInvalidRole, role)
+		}
+		if role == RoleAdmin {
+			return fmt.Errorf("%w: %q cannot be assigned at signup", ErrInvalidRole, role)
+		}
+	}
+	return nil
+}
+
+// Resolve クライアントが指定したロール（空の場合は指定無し）からアカウントに割り当てるロールを返す
+// 自分で選べないロールの指定はエラーにせず無視し、既定のロールを割り当てる
+func (p SignupRolePolicy) Resolve(requested Role) Role {
+	if requested != "" && requested != RoleAdmin && slices.Contains(p.SelfAssignable, requested) {
+		return requested
+	}
+	return p.defaultRole()
+}
+
+// defaultRole 既定のロールを返す
+func (p SignupRolePolicy) defaultRole() Role {
+	if p.DefaultRole == "" {
+		return RoleUser
+	}
+	return p.DefaultRole
+}
@@ -0,0 +1,44 @@
+package domain
+
+import "context"
+
+// DefaultTenantID テナント導入前のデータと、テナントを指定せずに作成したアカウントが属するテナント
+const DefaultTenantID = "default"
+
+// tenantContextKey コンテキストにテナントIDを保持するためのキー
+type tenantContextKey struct{}
+
+// WithTenantID 操作対象のテナントをコンテキストに設定
+// リポジトリとユースケースは設定されたテナントのデータのみを扱う
+func WithTenantID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+// WithoutTenant テナントの絞り込みを解除したコンテキストを返す
+// メールアドレスやユーザー名などすべてのテナントで一意な値の重複確認に使用する
+func WithoutTenant(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, "")
+}
+
+// TenantIDFromContext コンテキストに設定されたテナントIDを返す（未設定の場合はfalse）
+// ログインやトークンのリフレッシュなど、認証前の処理ではテナントは設定されない
+func TenantIDFromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(tenantContextKey{}).(string)
+	return tenantID, ok && tenantID != ""
+}
+
+// ResolveTenantID 新しく作成するデータのテナントIDを返す
+// コンテキストにテナントが設定されていない場合はDefaultTenantID
+func ResolveTenantID(ctx context.Context) string {
+	if tenantID, ok := TenantIDFromContext(ctx); ok {
+		return tenantID
+	}
+	return DefaultTenantID
+}
+
+// InTenant データのテナントがコンテキストのテナントと一致するか返す
+// テナントが設定されていないコンテキストでは常にtrue
+func InTenant(ctx context.Context, tenantID string) bool {
+	current, ok := TenantIDFromContext(ctx)
+	return !ok || current == tenantID
+}
@@ -0,0 +1,9 @@
+package domain
+
+import "time"
+
+// Now 現在時刻をUTCで返す
+// タイムスタンプはサーバーのタイムゾーンに関わらずUTCで生成・保存し、APIでもUTC（RFC 3339の"Z"表記）で返す
+func Now() time.Time {
+	return time.Now().UTC()
+}
@@ -0,0 +1,138 @@
+package domain
+
+import "strings"
+
+// DeviceType User-Agentから推定した端末の種類
+type DeviceType string
+
+const (
+	DeviceDesktop DeviceType = "desktop"
+	DeviceMobile  DeviceType = "mobile"
+	DeviceTablet  DeviceType = "tablet"
+	DeviceBot     DeviceType = "bot"
+)
+
+// UserAgentInfo User-Agentから推定したブラウザ・OS・端末の種類
+// 推定できない項目は空文字
+// 解析ルールの改善が過去のセッションにも反映されるよう、保存せずに生のUser-Agentから都度求める
+type UserAgentInfo struct {
+	Browser        string
+	BrowserVersion string // メジャーバージョン
+	OS             string
+	Device         DeviceType
+}
+
+// userAgentRule User-Agentに含まれる識別子と名前の対応
+type userAgentRule struct {
+	token string
+	name  string
+}
+
+// browserRules ブラウザの判定ルール（先頭から順に判定する）
+// Chromium系はUser-AgentにChromeやSafariを含むため、派生ブラウザを先に並べる
+// tokenの直後の数字をバージョンとして扱う（SafariはVersion/の値を使用する）
+var browserRules = []userAgentRule{
+	{"Edg/", "Edge"},
+	{"EdgiOS/", "Edge"},
+	{"OPR/", "Opera"},
+	{"SamsungBrowser/", "Samsung Internet"},
+	{"Firefox/", "Firefox"},
+	{"FxiOS/", "Firefox"},
+	{"CriOS/", "Chrome"},
+	{"Chrome/", "Chrome"},
+	{"Version/", "Safari"},
+	{"Safari/", "Safari"},
+	{"curl/", "curl"},
+}
+
+// botTokens クローラーなどの自動化されたクライアントを示す語（小文字で比較する）
+var botTokens = []string{"bot", "crawler", "spider", "slurp"}
+
+// osRules OSの判定ルール（先頭から順に判定する）
+// iOSはlike Mac OS X、AndroidはLinuxを含むため先に並べる
+var osRules = []userAgentRule{
+	{"iPhone", "iOS"},
+	{"iPad", "iPadOS"},
+	{"Android", "Android"},
+	{"CrOS", "ChromeOS"},
+	{"Windows", "Windows"},
+	{"Mac OS X", "macOS"},
+	{"Macintosh", "macOS"},
+	{"Linux", "Linux"},
+}
+
+// ParseUserAgent User-Agentからブラウザ・OS・端末の種類を推定
+func ParseUserAgent(userAgent string) UserAgentInfo {
+	var info UserAgentInfo
+	if rule, ok := matchUserAgentRule(userAgent, browserRules); ok {
+		info.Browser = rule.name
+		info.BrowserVersion = majorVersionAfter(userAgent, rule.token)
+	}
+	if rule, ok := matchUserAgentRule(userAgent, osRules); ok {
+		info.OS = rule.name
+	}
+	info.Device = detectDeviceType(userAgent, info.OS)
+	return info
+}
+
+// matchUserAgentRule 最初に一致したルールを返す
+func matchUserAgentRule(userAgent string, rules []userAgentRule) (userAgentRule, bool) {
+	for _, rule := range rules {
+		if strings.Contains(userAgent, rule.token) {
+			return rule, true
+		}
+	}
+	return userAgentRule{}, false
+}
+
+// majorVersionAfter tokenの直後に続くバージョンのメジャー部分を返す
+func majorVersionAfter(userAgent, token string) string {
+	i := strings.Index(userAgent, token)
+	if i < 0 {
+		return ""
+	}
+	rest := userAgent[i+len(token):]
+	end := 0
+	for end < len(rest) && rest[end] >= '0' && rest[end] <= '9' {
+		end++
+	}
+	return rest[:end]
+}
+
+// detectDeviceType 端末の種類を推定（判定できない場合は空文字）
+func detectDeviceType(userAgent, os string) DeviceType {
+	lower := strings.ToLower(userAgent)
+	for _, token := range botTokens {
+		if strings.Contains(lower, token) {
+			return DeviceBot
+		}
+	}
+
+	switch os {
+	case "iPadOS":
+		return DeviceTablet
+	case "iOS":
+		return DeviceMobile
+	case "Android":
+		// Android端末のうちスマートフォンはMobileを含む
+		if strings.Contains(userAgent, "Mobile") {
+			return DeviceMobile
+		}
+		return DeviceTablet
+	case "Windows", "macOS", "ChromeOS", "Linux":
+		return DeviceDesktop
+	}
+	return ""
+}
+
+// Summary 「Chrome on macOS」のような表示用の要約を返す（推定できない場合は空文字）
+func (i UserAgentInfo) Summary() string {
+	switch {
+	case i.Browser != "" && i.OS != "":
+		return i.Browser + " on " + i.OS
+	case i.Browser != "":
+		return i.Browser
+	default:
+		return i.OS
+	}
+}
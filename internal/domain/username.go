@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ユーザー名の文字数の範囲
+const (
+	MinUsernameLength = 3
+	MaxUsernameLength = 30
+)
+
+// usernamePattern 正規化後のユーザー名に使用できる文字（英小文字・数字・_ . -）
+// @を含まないため、ログインの識別子に@があればメールアドレス、無ければユーザー名として区別できる
+var usernamePattern = regexp.MustCompile(`^[a-z0-9_.-]+$`)
+
+// NormalizeUsername 前後の空白を取り除き小文字にする（大文字小文字を区別せずに一意とするため）
+func NormalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+// ValidateUsername 正規化したユーザー名を検証
+// 違反した場合は理由を含めてErrInvalidUsernameをラップしたエラーを返す
+func ValidateUsername(username string) error {
+	if len(username) < MinUsernameLength || len(username) > MaxUsernameLength {
+		return fmt.Errorf("%w: must be %d to %d characters", ErrInvalidUsername, MinUsernameLength, MaxUsernameLength)
+	}
+	if !usernamePattern.MatchString(username) {
+		return fmt.Errorf("%w: may only contain letters, numbers, '_', '.' and '-'", ErrInvalidUsername)
+	}
+	return nil
+}
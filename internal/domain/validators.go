@@ -4,4 +4,5 @@ const (
 	MaxProjectsPerAccount = 10
 	MaxNameLength         = 255
 	MaxEmailLength        = 255
+	MaxAvatarURLLength    = 2048
 )
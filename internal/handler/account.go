@@ -1,12 +1,17 @@
 package handler
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/aida0710/jwt-auth/internal/api"
 	"github.com/aida0710/jwt-auth/internal/domain"
 	"github.com/aida0710/jwt-auth/internal/logger"
+	"github.com/aida0710/jwt-auth/internal/middleware"
 	"github.com/aida0710/jwt-auth/internal/usecase"
 	"github.com/labstack/echo/v4"
 	openapiTypes "github.com/oapi-codegen/runtime/types"
@@ -15,22 +20,67 @@ import (
 // NewAPIAccountFromEntity エンティティからAPIレスポンスに変換
 func NewAPIAccountFromEntity(account *domain.Account) api.Account {
 	return api.Account{
-		Id:        account.ID,
-		Email:     openapiTypes.Email(account.Email),
-		Name:      account.Name,
-		CreatedAt: account.CreatedAt,
-		UpdatedAt: account.UpdatedAt,
+		Id:          account.ID,
+		Email:       openapiTypes.Email(account.Email),
+		Username:    account.Username,
+		Name:        account.Name,
+		TenantId:    account.TenantID,
+		Role:        api.AccountRole(account.Role),
+		Status:      api.AccountStatus(account.Status),
+		Permissions: permissionNames(account.Role),
+		CreatedAt:   utcTime(account.CreatedAt),
+		UpdatedAt:   utcTime(account.UpdatedAt),
+		Version:     account.Version,
+
+		PendingEmail: pendingEmail(account),
+		DisplayName:  optionalStringPtr(account.DisplayName),
+		AvatarUrl:    optionalStringPtr(account.AvatarURL),
+		Locale:       optionalStringPtr(account.Locale),
+		Timezone:     optionalStringPtr(account.Timezone),
+
+		DeletionScheduledAt: utcTimePtr(account.DeletionScheduledAt),
+		LastLoginAt:         utcTimePtr(account.LastLoginAt),
+		LastLoginIp:         account.LastLoginIP,
+		AllowedAudiences:    allowedAudiences(account),
+	}
+}
+
+// PrivacyConfig レスポンスに含める個人情報の設定
+type PrivacyConfig struct {
+	// ListEmailMasking 一覧でメールアドレスを伏せる対象（空文字の場合は伏せない）
+	ListEmailMasking domain.EmailMaskMode
+}
+
+// newAPIAccountListItem 一覧のレスポンス用にエンティティを変換
+// 設定と呼び出し元のロールに応じてメールアドレスを伏せてユーザー名と最終ログインのIPアドレスを除き、一括の参照で個人情報が露出しないようにする
+func (s *Server) newAPIAccountListItem(ctx echo.Context, account *domain.Account) api.Account {
+	apiAccount := NewAPIAccountFromEntity(account)
+	role, _ := ctx.Get(string(middleware.RoleKey)).(string)
+	if !s.privacy.ListEmailMasking.Masks(domain.Role(role)) {
+		return apiAccount
+	}
+
+	apiAccount.Email = openapiTypes.Email(domain.MaskEmail(account.Email))
+	apiAccount.Username = nil
+	apiAccount.LastLoginIp = nil
+	if apiAccount.PendingEmail != nil {
+		masked := openapiTypes.Email(domain.MaskEmail(string(*apiAccount.PendingEmail)))
+		apiAccount.PendingEmail = &masked
 	}
+	return apiAccount
 }
 
 // ListAccounts アカウント一覧を取得
-func (s *Server) ListAccounts(ctx echo.Context) error {
+func (s *Server) ListAccounts(ctx echo.Context, params api.ListAccountsParams) error {
 	reqCtx := ctx.Request().Context()
 
 	s.logger.Info(reqCtx, "Getting accounts list")
 
-	// すべてのアカウントを取得
-	accounts, err := s.accountUsecase.List(reqCtx)
+	// 件数の上限を超える指定は切り詰め、実際に適用した件数をヘッダーで返す
+	accounts, limit, err := s.accountUsecase.List(reqCtx, usecase.ListPageInput{
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	})
 	if err != nil {
 		s.logger.Error(reqCtx, "Failed to get accounts", err)
 		return handleAccountError(ctx, err)
@@ -39,12 +89,217 @@ func (s *Server) ListAccounts(ctx echo.Context) error {
 	// エンティティからAPIレスポンスに変換
 	apiAccounts := make([]api.Account, len(accounts))
 	for i, account := range accounts {
-		apiAccounts[i] = NewAPIAccountFromEntity(account)
+		apiAccounts[i] = s.newAPIAccountListItem(ctx, account)
+	}
+
+	ctx.Response().Header().Set(headerPageLimit, strconv.Itoa(limit))
+	return ctx.JSON(http.StatusOK, apiAccounts)
+}
+
+// ListAccountProjectCounts アカウントを所有するプロジェクト数とともに取得（管理者用）
+func (s *Server) ListAccountProjectCounts(ctx echo.Context, params api.ListAccountProjectCountsParams) error {
+	reqCtx := ctx.Request().Context()
+
+	s.logger.Info(reqCtx, "Listing accounts with project counts",
+		logger.F("role", params.Role),
+	)
+
+	input := usecase.ListAccountsInput{
+		Limit:  params.Limit,
+		Offset: params.Offset,
+		After:  params.After,
+		Before: params.Before,
+	}
+	if params.Role != nil {
+		role := string(*params.Role)
+		input.Role = &role
+	}
+
+	counts, page, err := s.accountUsecase.ListWithProjectCounts(reqCtx, input)
+	if err != nil {
+		s.logger.Error(reqCtx, "Failed to list accounts with project counts", err)
+		return handleAccountError(ctx, err)
+	}
+
+	items := make([]api.AccountProjectCount, len(counts))
+	for i, count := range counts {
+		items[i] = api.AccountProjectCount{
+			Account:      s.newAPIAccountListItem(ctx, count.Account),
+			ProjectCount: count.ProjectCount,
+		}
+	}
+
+	limit, offset := usecase.DefaultPageSize, 0
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+
+	return ctx.JSON(http.StatusOK, api.AccountProjectCountList{
+		Items:      items,
+		Total:      page.Total,
+		Limit:      limit,
+		Offset:     offset,
+		NextCursor: optionalString(page.NextCursor),
+		PrevCursor: optionalString(page.PrevCursor),
+	})
+}
+
+// SearchAccounts メールアドレスの前方一致でアカウントを検索（管理者用）
+func (s *Server) SearchAccounts(ctx echo.Context, params api.SearchAccountsParams) error {
+	reqCtx := ctx.Request().Context()
+
+	accounts, err := s.accountUsecase.SearchByEmail(reqCtx, usecase.SearchAccountsInput{
+		Email: params.Email,
+		Limit: params.Limit,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidSearch) {
+			return middleware.RespondError(ctx, http.StatusBadRequest, api.Error{
+				Error: fmt.Sprintf("email must be between %d and %d characters", domain.MinEmailSearchLength, domain.MaxEmailSearchLength),
+				Code:  api.ErrorCodeInvalidRequest,
+			})
+		}
+		s.logger.Error(reqCtx, "Failed to search accounts", err)
+		return handleAccountError(ctx, err)
+	}
+
+	apiAccounts := make([]api.Account, len(accounts))
+	for i, account := range accounts {
+		apiAccounts[i] = s.newAPIAccountListItem(ctx, account)
 	}
 
 	return ctx.JSON(http.StatusOK, apiAccounts)
 }
 
+// UpdateAccountStatus アカウントを停止・再開（管理者用）
+// 停止するとログインとトークンのリフレッシュを拒否し、既存のセッションはすべて無効化される
+func (s *Server) UpdateAccountStatus(ctx echo.Context, accountId api.AccountID) error {
+	reqCtx := ctx.Request().Context()
+
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return middleware.RespondError(ctx, http.StatusUnauthorized, api.Error{
+			Error: "Unauthorized",
+			Code:  api.ErrorCodeUnauthorized,
+		})
+	}
+
+	var req api.UpdateAccountStatusRequest
+	if err := ctx.Bind(&req); err != nil {
+		return middleware.RespondError(ctx, http.StatusBadRequest, api.Error{
+			Error: bindErrorMessage(err),
+			Code:  api.ErrorCodeInvalidRequest,
+		})
+	}
+	if req.Status == "" {
+		return middleware.RespondError(ctx, http.StatusBadRequest, api.Error{
+			Error: "status is required",
+			Code:  api.ErrorCodeInvalidRequest,
+		})
+	}
+
+	account, err := s.accountUsecase.UpdateStatus(reqCtx, accountId, domain.AccountStatus(req.Status), actor)
+	if err != nil {
+		s.logger.Warn(reqCtx, "Failed to update account status",
+			logger.F("account_id", accountId),
+			logger.F("error", err.Error()),
+		)
+		return handleAccountError(ctx, err)
+	}
+
+	s.logger.Info(reqCtx, "Account status updated",
+		logger.F("account_id", accountId),
+		logger.F("status", string(account.Status)),
+	)
+
+	return ctx.JSON(http.StatusOK, NewAPIAccountFromEntity(account))
+}
+
+// UpdateAccountAudiences ログイン・リフレッシュで要求できるAudienceを置き換える（管理者用）
+func (s *Server) UpdateAccountAudiences(ctx echo.Context, accountId api.AccountID) error {
+	reqCtx := ctx.Request().Context()
+
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return middleware.RespondError(ctx, http.StatusUnauthorized, api.Error{
+			Error: "Unauthorized",
+			Code:  api.ErrorCodeUnauthorized,
+		})
+	}
+
+	var req api.UpdateAccountAudiencesRequest
+	if err := ctx.Bind(&req); err != nil {
+		return middleware.RespondError(ctx, http.StatusBadRequest, api.Error{
+			Error: bindErrorMessage(err),
+			Code:  api.ErrorCodeInvalidRequest,
+		})
+	}
+
+	account, err := s.accountUsecase.UpdateAllowedAudiences(reqCtx, accountId, req.Audiences, actor)
+	if err != nil {
+		s.logger.Warn(reqCtx, "Failed to update allowed audiences",
+			logger.F("account_id", accountId),
+			logger.F("error", err.Error()),
+		)
+		return handleAccountError(ctx, err)
+	}
+
+	s.logger.Info(reqCtx, "Allowed audiences updated",
+		logger.F("account_id", accountId),
+		logger.F("audiences", account.AllowedAudiences),
+	)
+
+	return ctx.JSON(http.StatusOK, NewAPIAccountFromEntity(account))
+}
+
+// CreateAccount トークンを発行せずにアカウントを作成（管理者による発行用）
+func (s *Server) CreateAccount(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	var req api.CreateAccountRequest
+	if err := ctx.Bind(&req); err != nil {
+		s.logger.Warn(reqCtx, "Invalid request body", logger.F("error", err.Error()))
+		return middleware.RespondError(ctx, http.StatusBadRequest, api.Error{
+			Error: bindErrorMessage(err),
+			Code:  api.ErrorCodeInvalidRequest,
+		})
+	}
+
+	// サインアップと同じパスワードの長さ制限
+	if len(req.Password) < 8 || len(req.Password) > 60 {
+		return middleware.RespondError(ctx, http.StatusBadRequest, api.Error{
+			Error: "password must be between 8 and 60 characters",
+			Code:  api.ErrorCodeInvalidRequest,
+		})
+	}
+
+	input := usecase.CreateInput{
+		Email:    string(req.Email),
+		Name:     req.Name,
+		Password: req.Password,
+	}
+	if req.Role != nil {
+		input.Role = domain.Role(*req.Role)
+	}
+
+	account, err := s.accountUsecase.Create(reqCtx, input)
+	if err != nil {
+		s.logger.Error(reqCtx, "Failed to create account", err)
+		return handleAccountError(ctx, err)
+	}
+
+	s.logger.Info(reqCtx, "Account created by admin",
+		logger.F("account_id", account.ID),
+		logger.F("role", account.Role),
+	)
+
+	setLocation(ctx, accountLocation(account.ID))
+	return jsonWithETagValue(ctx, http.StatusCreated, accountETag(account), NewAPIAccountFromEntity(account))
+}
+
 // GetAccount IDでアカウントを取得
 func (s *Server) GetAccount(ctx echo.Context, accountId api.AccountID) error {
 	reqCtx := ctx.Request().Context()
@@ -62,25 +317,46 @@ func (s *Server) GetAccount(ctx echo.Context, accountId api.AccountID) error {
 	}
 
 	apiAccount := NewAPIAccountFromEntity(account)
-	return ctx.JSON(http.StatusOK, apiAccount)
+	return conditionalJSONWithETag(ctx, accountETag(account), apiAccount)
 }
 
 // UpdateAccount アカウントを更新
 func (s *Server) UpdateAccount(ctx echo.Context, accountId api.AccountID) error {
+	return s.updateAccount(ctx, accountId)
+}
+
+// PatchAccount アカウントを部分更新（指定した項目のみ変更）
+// application/merge-patch+jsonの場合は、プロフィール項目に明示的なnullを指定すると未設定に戻す
+func (s *Server) PatchAccount(ctx echo.Context, accountId api.AccountID) error {
+	if !isMergePatch(ctx) {
+		return s.updateAccount(ctx, accountId)
+	}
+
+	input, err := bindAccountMergePatch(ctx)
+	if err != nil {
+		s.logger.Warn(ctx.Request().Context(), "Invalid merge patch", logger.F("error", err.Error()))
+		return middleware.RespondError(ctx, http.StatusBadRequest, api.Error{
+			Error: err.Error(),
+			Code:  api.ErrorCodeInvalidRequest,
+		})
+	}
+	return s.applyAccountUpdate(ctx, accountId, input)
+}
+
+// updateAccount PUT/PATCH共通のアカウント更新処理
+// 省略された項目は変更しない
+func (s *Server) updateAccount(ctx echo.Context, accountId api.AccountID) error {
 	reqCtx := ctx.Request().Context()
 
 	var req api.UpdateAccountRequest
 	if err := ctx.Bind(&req); err != nil {
 		s.logger.Warn(reqCtx, "Invalid request body", logger.F("error", err.Error()))
-		return ctx.JSON(http.StatusBadRequest, api.Error{
-			Error: "Invalid request body",
+		return middleware.RespondError(ctx, http.StatusBadRequest, api.Error{
+			Error: bindErrorMessage(err),
+			Code:  api.ErrorCodeInvalidRequest,
 		})
 	}
 
-	s.logger.Info(reqCtx, "Updating account",
-		logger.F("account_id", accountId),
-	)
-
 	input := usecase.UpdateInput{}
 	if req.Email != nil {
 		email := string(*req.Email)
@@ -89,6 +365,23 @@ func (s *Server) UpdateAccount(ctx echo.Context, accountId api.AccountID) error
 	if req.Name != nil {
 		input.Name = req.Name
 	}
+	input.DisplayName = req.DisplayName
+	input.AvatarURL = req.AvatarUrl
+	input.Locale = req.Locale
+	input.Timezone = req.Timezone
+
+	return s.applyAccountUpdate(ctx, accountId, input)
+}
+
+// applyAccountUpdate 読み込んだ入力でアカウントを更新し、更新後のアカウントを返す
+// If-Matchを指定した場合は、そのETagのバージョンから変更されていないときのみ更新する
+func (s *Server) applyAccountUpdate(ctx echo.Context, accountId api.AccountID, input usecase.UpdateInput) error {
+	reqCtx := ctx.Request().Context()
+	input.ExpectedVersions = ifMatchVersions(ctx.Request().Header.Get(headerIfMatch))
+
+	s.logger.Info(reqCtx, "Updating account",
+		logger.F("account_id", accountId),
+	)
 
 	account, err := s.accountUsecase.Update(reqCtx, accountId, input)
 	if err != nil {
@@ -103,18 +396,180 @@ func (s *Server) UpdateAccount(ctx echo.Context, accountId api.AccountID) error
 	)
 
 	apiAccount := NewAPIAccountFromEntity(account)
-	return ctx.JSON(http.StatusOK, apiAccount)
+	return jsonWithETagValue(ctx, http.StatusOK, accountETag(account), apiAccount)
+}
+
+// ConfirmEmailChange 確認トークンでメールアドレスの変更を確定
+func (s *Server) ConfirmEmailChange(ctx echo.Context, accountId api.AccountID) error {
+	reqCtx := ctx.Request().Context()
+
+	var req api.ConfirmEmailChangeRequest
+	if err := ctx.Bind(&req); err != nil {
+		return middleware.RespondError(ctx, http.StatusBadRequest, api.Error{
+			Error: bindErrorMessage(err),
+			Code:  api.ErrorCodeInvalidRequest,
+		})
+	}
+	if req.Token == "" {
+		return middleware.RespondError(ctx, http.StatusBadRequest, api.Error{
+			Error: "token is required",
+			Code:  api.ErrorCodeInvalidRequest,
+		})
+	}
+
+	account, err := s.accountUsecase.ConfirmEmailChange(reqCtx, accountId, req.Token)
+	if err != nil {
+		s.logger.Warn(reqCtx, "Failed to confirm email change",
+			logger.F("account_id", accountId),
+			logger.F("error", err.Error()),
+		)
+		return handleAccountError(ctx, err)
+	}
+
+	s.logger.Info(reqCtx, "Email change confirmed",
+		logger.F("account_id", accountId),
+	)
+
+	return jsonWithETagValue(ctx, http.StatusOK, accountETag(account), NewAPIAccountFromEntity(account))
+}
+
+// ChangePassword 現在のパスワードを確認してパスワードを変更
+func (s *Server) ChangePassword(ctx echo.Context, accountId api.AccountID) error {
+	reqCtx := ctx.Request().Context()
+
+	var req api.ChangePasswordRequest
+	if err := ctx.Bind(&req); err != nil {
+		return middleware.RespondError(ctx, http.StatusBadRequest, api.Error{
+			Error: bindErrorMessage(err),
+			Code:  api.ErrorCodeInvalidRequest,
+		})
+	}
+	if req.CurrentPassword == "" {
+		return middleware.RespondError(ctx, http.StatusBadRequest, api.Error{
+			Error: "current_password and new_password are required",
+			Code:  api.ErrorCodeInvalidRequest,
+		})
+	}
+
+	// サインアップと同じパスワードの長さ制限
+	if len(req.NewPassword) < 8 || len(req.NewPassword) > 60 {
+		return middleware.RespondError(ctx, http.StatusBadRequest, api.Error{
+			Error: "password must be between 8 and 60 characters",
+			Code:  api.ErrorCodeInvalidRequest,
+		})
+	}
+
+	err := s.accountUsecase.ChangePassword(reqCtx, accountId, usecase.ChangePasswordInput{
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+	})
+	if err != nil {
+		s.logger.Warn(reqCtx, "Failed to change password",
+			logger.F("account_id", accountId),
+			logger.F("error", err.Error()),
+		)
+		return handleAccountError(ctx, err)
+	}
+
+	s.logger.Info(reqCtx, "Password changed",
+		logger.F("account_id", accountId),
+	)
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// RequestPasswordReset メールアドレス宛てにパスワード再設定用のトークンを送信
+// アカウントの有無を推測されないよう、登録されていないメールアドレスでも200を返す
+func (s *Server) RequestPasswordReset(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	var req api.PasswordResetRequest
+	if err := ctx.Bind(&req); err != nil {
+		return middleware.RespondError(ctx, http.StatusBadRequest, api.Error{
+			Error: bindErrorMessage(err),
+			Code:  api.ErrorCodeInvalidRequest,
+		})
+	}
+	if req.Email == "" {
+		return middleware.RespondError(ctx, http.StatusBadRequest, api.Error{
+			Error: "email is required",
+			Code:  api.ErrorCodeInvalidRequest,
+		})
+	}
+
+	err := s.accountUsecase.RequestPasswordReset(reqCtx, usecase.PasswordResetInput{
+		Email:     string(req.Email),
+		UserAgent: ctx.Request().UserAgent(),
+		IPAddress: ctx.RealIP(),
+	})
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, api.ErrorCodeInternalError, "failed to send password reset").SetInternal(err)
+	}
+
+	return ctx.NoContent(http.StatusOK)
+}
+
+// ResetPassword パスワード再設定用のトークンを消費してパスワードを変更
+func (s *Server) ResetPassword(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	var req api.ResetPasswordRequest
+	if err := ctx.Bind(&req); err != nil {
+		return middleware.RespondError(ctx, http.StatusBadRequest, api.Error{
+			Error: bindErrorMessage(err),
+			Code:  api.ErrorCodeInvalidRequest,
+		})
+	}
+	if req.Token == "" {
+		return middleware.RespondError(ctx, http.StatusBadRequest, api.Error{
+			Error: "token and new_password are required",
+			Code:  api.ErrorCodeInvalidRequest,
+		})
+	}
+
+	// サインアップと同じパスワードの長さ制限
+	if len(req.NewPassword) < 8 || len(req.NewPassword) > 60 {
+		return middleware.RespondError(ctx, http.StatusBadRequest, api.Error{
+			Error: "password must be between 8 and 60 characters",
+			Code:  api.ErrorCodeInvalidRequest,
+		})
+	}
+
+	err := s.accountUsecase.ResetPassword(reqCtx, usecase.ResetPasswordInput{
+		Token:       req.Token,
+		NewPassword: req.NewPassword,
+		UserAgent:   ctx.Request().UserAgent(),
+		IPAddress:   ctx.RealIP(),
+	})
+	if err != nil {
+		s.logger.Warn(reqCtx, "Failed to reset password",
+			logger.F("error", err.Error()),
+		)
+		return handleAccountError(ctx, err)
+	}
+
+	s.logger.Info(reqCtx, "Password reset")
+
+	return ctx.NoContent(http.StatusNoContent)
 }
 
-// DeleteAccount アカウントを削除
+// DeleteAccount アカウントの削除を予約（猶予期間が過ぎると完全に削除）
 func (s *Server) DeleteAccount(ctx echo.Context, accountId api.AccountID) error {
 	reqCtx := ctx.Request().Context()
 
-	s.logger.Info(reqCtx, "Deleting account",
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return middleware.RespondError(ctx, http.StatusUnauthorized, api.Error{
+			Error: "Unauthorized",
+			Code:  api.ErrorCodeUnauthorized,
+		})
+	}
+
+	s.logger.Info(reqCtx, "Scheduling account deletion",
 		logger.F("account_id", accountId),
 	)
 
-	err := s.accountUsecase.Delete(reqCtx, accountId)
+	err = s.accountUsecase.Delete(reqCtx, accountId, actor)
 	if err != nil {
 		s.logger.Error(reqCtx, "Failed to delete account", err,
 			logger.F("account_id", accountId),
@@ -122,35 +577,257 @@ func (s *Server) DeleteAccount(ctx echo.Context, accountId api.AccountID) error
 		return handleAccountError(ctx, err)
 	}
 
-	s.logger.Info(reqCtx, "Account deleted successfully",
+	s.logger.Info(reqCtx, "Account deletion scheduled",
 		logger.F("account_id", accountId),
 	)
 
 	return ctx.NoContent(http.StatusNoContent)
 }
 
+// RestoreAccount 猶予期間中のアカウントの削除を取り消す（本人または管理者のみ）
+func (s *Server) RestoreAccount(ctx echo.Context, accountId api.AccountID) error {
+	reqCtx := ctx.Request().Context()
+
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return middleware.RespondError(ctx, http.StatusUnauthorized, api.Error{
+			Error: "Unauthorized",
+			Code:  api.ErrorCodeUnauthorized,
+		})
+	}
+
+	account, err := s.accountUsecase.Restore(reqCtx, accountId, actor)
+	if err != nil {
+		s.logger.Warn(reqCtx, "Failed to restore account",
+			logger.F("account_id", accountId),
+			logger.F("error", err.Error()),
+		)
+		return handleAccountError(ctx, err)
+	}
+
+	s.logger.Info(reqCtx, "Account deletion cancelled",
+		logger.F("account_id", accountId),
+	)
+
+	return jsonWithETagValue(ctx, http.StatusOK, accountETag(account), NewAPIAccountFromEntity(account))
+}
+
+// ExportAccount アカウントのプロフィール、プロジェクト、監査ログを1つのJSONとして書き出す（本人または管理者のみ）
+// 件数の多いアカウントでも全件をメモリに載せないよう、ページごとにレスポンスへ書き出す
+func (s *Server) ExportAccount(ctx echo.Context, accountId api.AccountID) error {
+	reqCtx := ctx.Request().Context()
+
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return middleware.RespondError(ctx, http.StatusUnauthorized, api.Error{
+			Error: "Unauthorized",
+			Code:  api.ErrorCodeUnauthorized,
+		})
+	}
+
+	stream := &accountExportStream{ctx: ctx}
+	err = s.accountUsecase.Export(reqCtx, accountId, actor, stream)
+	if err == nil {
+		err = stream.Close()
+	}
+	if err != nil {
+		s.logger.Error(reqCtx, "Failed to export account", err,
+			logger.F("account_id", accountId),
+		)
+		// 書き出しを開始した後はステータスを変更できないため、途中で終わったJSONのまま返す
+		if stream.started {
+			return nil
+		}
+		return handleAccountError(ctx, err)
+	}
+
+	s.logger.Info(reqCtx, "Account exported",
+		logger.F("account_id", accountId),
+	)
+	return nil
+}
+
+// accountExportSections エクスポートのJSONに書き出す配列（書き出す順）
+var accountExportSections = []string{"projects", "security_events"}
+
+// accountExportStream usecase.AccountExportWriterの実装
+// api.AccountExportと同じ形のJSONをレスポンスに逐次書き出す
+// WriteAccountが呼ばれるまでレスポンスを開始しないため、それ以前のエラーは通常のエラーレスポンスにできる
+type accountExportStream struct {
+	ctx     echo.Context
+	started bool
+	opened  int  // 書き出しを開始した配列の数
+	empty   bool // 最後に開始した配列に要素をまだ書き出していないか
+}
+
+func (s *accountExportStream) WriteAccount(account *domain.Account) error {
+	exportedAt, err := json.Marshal(time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(NewAPIAccountFromEntity(account))
+	if err != nil {
+		return err
+	}
+
+	res := s.ctx.Response()
+	res.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="account-%s.json"`, account.ID))
+	res.WriteHeader(http.StatusOK)
+	s.started = true
+	return s.write(`{"exported_at":%s,"account":%s`, exportedAt, body)
+}
+
+func (s *accountExportStream) WriteProjects(projects []*domain.Project) error {
+	for _, project := range projects {
+		if err := s.writeItem(0, NewAPIProjectFromEntity(project)); err != nil {
+			return err
+		}
+	}
+	return s.flush()
+}
+
+func (s *accountExportStream) WriteSecurityEvents(logs []*domain.SecurityAuditLog) error {
+	for _, log := range logs {
+		if err := s.writeItem(1, newAPISecurityEvent(log)); err != nil {
+			return err
+		}
+	}
+	return s.flush()
+}
+
+// Close 要素の無い配列を空配列として書き出し、JSONを閉じる
+func (s *accountExportStream) Close() error {
+	if err := s.openSection(len(accountExportSections) - 1); err != nil {
+		return err
+	}
+	if err := s.write("]}\n"); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+// writeItem section番目の配列に要素を1件書き出す
+func (s *accountExportStream) writeItem(section int, item interface{}) error {
+	body, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	if err := s.openSection(section); err != nil {
+		return err
+	}
+	separator := ","
+	if s.empty {
+		separator = ""
+		s.empty = false
+	}
+	return s.write("%s%s", separator, body)
+}
+
+// openSection section番目までの配列を順に開始する（前の配列は閉じる）
+func (s *accountExportStream) openSection(section int) error {
+	for s.opened <= section {
+		prefix := ","
+		if s.opened > 0 {
+			prefix = "],"
+		}
+		if err := s.write(`%s%q:[`, prefix, accountExportSections[s.opened]); err != nil {
+			return err
+		}
+		s.opened++
+		s.empty = true
+	}
+	return nil
+}
+
+func (s *accountExportStream) write(format string, args ...interface{}) error {
+	_, err := fmt.Fprintf(s.ctx.Response(), format, args...)
+	return err
+}
+
+// flush 書き出したページをクライアントに送信する（Flushに対応しないWriterでは何もしない）
+func (s *accountExportStream) flush() error {
+	if err := http.NewResponseController(s.ctx.Response()).Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// newAPISecurityEvent 監査ログをAPIレスポンスに変換
+func newAPISecurityEvent(log *domain.SecurityAuditLog) api.SecurityEvent {
+	event := api.SecurityEvent{
+		Id:          log.ID,
+		EventType:   string(log.EventType),
+		Description: log.EventDescription,
+		IpAddress:   log.IPAddress,
+		UserAgent:   log.UserAgent,
+		CreatedAt:   utcTime(log.CreatedAt),
+	}
+	var metadata map[string]interface{}
+	if len(log.Metadata) > 0 && json.Unmarshal(log.Metadata, &metadata) == nil && len(metadata) > 0 {
+		event.Metadata = &metadata
+	}
+	return event
+}
+
 // handleAccountError アカウント関連のエラーをHTTPレスポンスに変換
 func handleAccountError(ctx echo.Context, err error) error {
 	// エラーマッピングから適切なステータスコードを探す
 	if errors.Is(err, domain.ErrAccountNotFound) {
-		return ctx.JSON(http.StatusNotFound, api.Error{
-			Error: err.Error(),
-		})
+		return middleware.RespondError(ctx, http.StatusNotFound, newAPIError(err))
 	}
-	if errors.Is(err, domain.ErrDuplicateEmail) {
-		return ctx.JSON(http.StatusConflict, api.Error{
-			Error: err.Error(),
-		})
+	if errors.Is(err, domain.ErrIncorrectPassword) {
+		return middleware.RespondError(ctx, http.StatusUnauthorized, newAPIError(err))
+	}
+	if errors.Is(err, domain.ErrForbidden) {
+		return middleware.RespondError(ctx, http.StatusForbidden, newAPIError(err))
+	}
+	if errors.Is(err, domain.ErrDuplicateEmail) || errors.Is(err, domain.ErrAccountPendingDeletion) ||
+		errors.Is(err, domain.ErrAccountNotPendingDeletion) || errors.Is(err, domain.ErrVersionConflict) {
+		return middleware.RespondError(ctx, http.StatusConflict, newAPIError(err))
 	}
 	if errors.Is(err, domain.ErrInvalidEmail) || errors.Is(err, domain.ErrInvalidName) ||
-		errors.Is(err, domain.ErrInvalidID) || errors.Is(err, domain.ErrInvalidAccountID) {
-		return ctx.JSON(http.StatusBadRequest, api.Error{
-			Error: err.Error(),
-		})
+		errors.Is(err, domain.ErrInvalidID) || errors.Is(err, domain.ErrInvalidAccountID) ||
+		errors.Is(err, domain.ErrInvalidDisplayName) || errors.Is(err, domain.ErrInvalidAvatarURL) ||
+		errors.Is(err, domain.ErrInvalidLocale) || errors.Is(err, domain.ErrInvalidTimezone) ||
+		errors.Is(err, domain.ErrInvalidVerificationToken) || errors.Is(err, domain.ErrInvalidRole) ||
+		errors.Is(err, domain.ErrPasswordReused) || errors.Is(err, domain.ErrInvalidPagination) ||
+		errors.Is(err, domain.ErrInvalidAccountStatus) || errors.Is(err, domain.ErrInvalidAudience) ||
+		errors.Is(err, domain.ErrInvalidPasswordResetToken) {
+		return middleware.RespondError(ctx, http.StatusBadRequest, newAPIError(err))
 	}
 
 	// デフォルトのエラーレスポンス
-	return ctx.JSON(http.StatusInternalServerError, api.Error{
-		Error: "Internal server error",
-	})
+	return newInternalError(err)
+}
+
+// pendingEmail 確認待ちのメールアドレスをAPIの型に変換
+func pendingEmail(account *domain.Account) *openapiTypes.Email {
+	if account.PendingEmail == nil || *account.PendingEmail == "" {
+		return nil
+	}
+	email := openapiTypes.Email(*account.PendingEmail)
+	return &email
+}
+
+// allowedAudiences アカウントに許可されたAudienceをAPIの型に変換（既定のAudienceのみの場合はnil）
+func allowedAudiences(account *domain.Account) *[]string {
+	if len(account.AllowedAudiences) == 0 {
+		return nil
+	}
+	audiences := append([]string(nil), account.AllowedAudiences...)
+	return &audiences
+}
+
+// permissionNames ロールの権限をAPIの文字列配列に変換
+func permissionNames(role domain.Role) *[]string {
+	permissions := role.Permissions()
+	if len(permissions) == 0 {
+		return nil
+	}
+	names := make([]string, len(permissions))
+	for i, p := range permissions {
+		names[i] = string(p)
+	}
+	return &names
 }
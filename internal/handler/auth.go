@@ -4,12 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/aida0710/jwt-auth/internal/api"
 	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/middleware"
 	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
-	openapiTypes "github.com/oapi-codegen/runtime/types"
 )
 
 // AuthHandler 認証関連のハンドラー
@@ -27,84 +29,131 @@ func NewAuthHandler(authUsecase *usecase.AuthUsecase) *AuthHandler {
 // SignUp 新規アカウント登録
 func (h *AuthHandler) SignUp(c echo.Context) error {
 	var req api.SignUpRequest
-	if err := c.Bind(&req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	if err := bindRequestBody(c, &req); err != nil {
+		return err
 	}
 
 	if req.Email == "" || req.Password == "" || req.Name == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "email, password and name are required")
+		return newHTTPError(http.StatusBadRequest, api.ErrorCodeInvalidRequest, "email, password and name are required")
 	}
 
 	if len(req.Password) < 8 {
-		return echo.NewHTTPError(http.StatusBadRequest, "password must be at least 8 characters")
+		return newHTTPError(http.StatusBadRequest, api.ErrorCodeInvalidRequest, "password must be at least 8 characters")
 	}
 
 	if len(req.Password) > 60 {
 		// bcryptは最大72バイト (ASCII文字なら72文字) まで
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("password must be less than 60 characters"))
+		return newHTTPError(http.StatusBadRequest, api.ErrorCodeInvalidRequest, fmt.Sprintf("password must be less than 60 characters"))
 	}
 
-	tokens, err := h.authUsecase.SignUp(c.Request().Context(), usecase.SignUpInput{
+	input := usecase.SignUpInput{
 		Email:    string(req.Email),
 		Password: req.Password,
 		Name:     req.Name,
-	})
+	}
+	if req.InviteToken != nil {
+		input.InviteToken = *req.InviteToken
+	}
+	if req.Role != nil {
+		input.Role = domain.Role(*req.Role)
+	}
+	if req.Username != nil {
+		input.Username = *req.Username
+	}
+
+	tokens, err := h.authUsecase.SignUp(c.Request().Context(), input)
 
 	if err != nil {
 		switch {
+		case errors.Is(err, domain.ErrSignupDisabled):
+			return newHTTPError(http.StatusForbidden, ErrorCode(err), "signup is disabled")
+		case errors.Is(err, domain.ErrInvalidInvite):
+			return newHTTPError(http.StatusBadRequest, ErrorCode(err), "invite is invalid, already used or expired")
 		case errors.Is(err, domain.ErrEmailAlreadyExists), errors.Is(err, domain.ErrDuplicateEmail):
-			return echo.NewHTTPError(http.StatusConflict, "email already exists")
+			return newHTTPError(http.StatusConflict, ErrorCode(err), "email already exists")
+		case errors.Is(err, domain.ErrDuplicateUsername):
+			return newHTTPError(http.StatusConflict, ErrorCode(err), "username already exists")
 		case errors.Is(err, domain.ErrInvalidEmail):
-			return echo.NewHTTPError(http.StatusBadRequest, "invalid email address")
-		case errors.Is(err, domain.ErrInvalidName):
-			return echo.NewHTTPError(http.StatusBadRequest, "invalid name")
+			return newHTTPError(http.StatusBadRequest, ErrorCode(err), "invalid email address")
+		case errors.Is(err, domain.ErrDisallowedEmailDomain):
+			return newHTTPError(http.StatusBadRequest, ErrorCode(err), "email domain is not allowed")
+		case errors.Is(err, domain.ErrInvalidName), errors.Is(err, domain.ErrInvalidUsername):
+			// 違反したルールが分かるよう、検証エラーのメッセージをそのまま返す
+			return newHTTPError(http.StatusBadRequest, ErrorCode(err), ErrorMessage(err))
 		default:
-			return echo.NewHTTPError(http.StatusInternalServerError, "failed to create account")
+			return newHTTPError(http.StatusInternalServerError, api.ErrorCodeInternalError, "failed to create account").SetInternal(err)
 		}
 	}
 
+	setLocation(c, accountLocation(tokens.Account.ID))
 	return c.JSON(http.StatusCreated, api.AuthResponse{
 		AccessToken:  tokens.AccessToken,
 		RefreshToken: tokens.RefreshToken,
 		TokenType:    "Bearer",
 		ExpiresIn:    tokens.ExpiresIn,
-		Account: api.Account{
-			Id:        tokens.Account.ID,
-			Email:     openapiTypes.Email(tokens.Account.Email),
-			Name:      tokens.Account.Name,
-			CreatedAt: tokens.Account.CreatedAt,
-			UpdatedAt: tokens.Account.UpdatedAt,
-		},
+		Account:      NewAPIAccountFromEntity(tokens.Account),
 	})
 }
 
-// Login メールとパスワードでログイン
+// Login メールアドレスまたはユーザー名とパスワードでログイン
+// identifierを優先し、指定が無い場合は互換性のためemailを使用する
 func (h *AuthHandler) Login(c echo.Context) error {
 	var req api.LoginRequest
-	if err := c.Bind(&req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	if err := bindRequestBody(c, &req); err != nil {
+		return err
 	}
 
-	if req.Email == "" || req.Password == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "email and password are required")
+	var identifier string
+	switch {
+	case req.Identifier != nil:
+		identifier = *req.Identifier
+	case req.Email != nil:
+		identifier = string(*req.Email)
+	}
+	if identifier == "" || req.Password == "" {
+		return newHTTPError(http.StatusBadRequest, api.ErrorCodeInvalidRequest, "identifier (or email) and password are required")
 	}
 
 	userAgent := c.Request().UserAgent()
 	ipAddress := c.RealIP()
 
+	var deviceName, audience string
+	if req.DeviceName != nil {
+		deviceName = *req.DeviceName
+	}
+	if req.Audience != nil {
+		audience = *req.Audience
+	}
+
 	tokens, err := h.authUsecase.Login(c.Request().Context(), usecase.LoginInput{
-		Email:     string(req.Email),
-		Password:  req.Password,
-		UserAgent: userAgent,
-		IPAddress: ipAddress,
+		Identifier: identifier,
+		Password:   req.Password,
+		UserAgent:  userAgent,
+		IPAddress:  ipAddress,
+		DeviceName: deviceName,
+		Audience:   audience,
 	})
 
 	if err != nil {
 		switch {
+		case errors.Is(err, domain.ErrInvalidDeviceName):
+			return newHTTPError(http.StatusBadRequest, ErrorCode(err), ErrorMessage(err))
 		case errors.Is(err, domain.ErrInvalidCredentials):
-			return echo.NewHTTPError(http.StatusUnauthorized, "invalid email or password")
+			return newHTTPError(http.StatusUnauthorized, ErrorCode(err), "invalid email or password")
+		case errors.Is(err, domain.ErrAccountLocked):
+			var locked *domain.AccountLockedError
+			if errors.As(err, &locked) {
+				c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(locked.RetryAfterSeconds()))
+			}
+			return newHTTPError(http.StatusLocked, ErrorCode(err), "account is temporarily locked due to repeated failed logins")
+		case errors.Is(err, domain.ErrAccountSuspended):
+			return newHTTPError(http.StatusForbidden, ErrorCode(err), "account is suspended")
+		case errors.Is(err, domain.ErrAccountPendingDeletion):
+			return newHTTPError(http.StatusForbidden, ErrorCode(err), "account is pending deletion")
+		case errors.Is(err, domain.ErrAudienceNotAllowed):
+			return newHTTPError(http.StatusForbidden, ErrorCode(err), ErrorMessage(err))
 		default:
-			return echo.NewHTTPError(http.StatusInternalServerError, "failed to login")
+			return newHTTPError(http.StatusInternalServerError, api.ErrorCodeInternalError, "failed to login").SetInternal(err)
 		}
 	}
 
@@ -113,13 +162,7 @@ func (h *AuthHandler) Login(c echo.Context) error {
 		RefreshToken: tokens.RefreshToken,
 		TokenType:    "Bearer",
 		ExpiresIn:    tokens.ExpiresIn,
-		Account: api.Account{
-			Id:        tokens.Account.ID,
-			Email:     openapiTypes.Email(tokens.Account.Email),
-			Name:      tokens.Account.Name,
-			CreatedAt: tokens.Account.CreatedAt,
-			UpdatedAt: tokens.Account.UpdatedAt,
-		},
+		Account:      NewAPIAccountFromEntity(tokens.Account),
 	})
 }
 
@@ -127,32 +170,56 @@ func (h *AuthHandler) Login(c echo.Context) error {
 func (h *AuthHandler) RefreshToken(c echo.Context) error {
 	var req api.RefreshTokenRequest
 	if err := c.Bind(&req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+		return invalidRequestBodyError(err)
 	}
 
 	if req.RefreshToken == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "refresh_token is required")
+		return newHTTPError(http.StatusBadRequest, api.ErrorCodeInvalidRequest, "refresh_token is required")
 	}
 
 	userAgent := c.Request().UserAgent()
 	ipAddress := c.RealIP()
 
-	tokens, err := h.authUsecase.RefreshToken(
-		c.Request().Context(),
-		req.RefreshToken,
-		userAgent,
-		ipAddress,
-	)
+	input := usecase.RefreshInput{
+		RefreshToken: req.RefreshToken,
+		UserAgent:    userAgent,
+		IPAddress:    ipAddress,
+	}
+	if req.DeviceName != nil {
+		input.DeviceName = *req.DeviceName
+	}
+	if req.Audience != nil {
+		input.Audience = *req.Audience
+	}
+
+	tokens, err := h.authUsecase.Refresh(c.Request().Context(), input)
 
 	if err != nil {
 		switch {
+		case errors.Is(err, domain.ErrInvalidDeviceName):
+			return newHTTPError(http.StatusBadRequest, ErrorCode(err), ErrorMessage(err))
 		case errors.Is(err, domain.ErrTokenCompromised):
 			// セキュリティ侵害の可能性がある場合は、明確にユーザーに通知
-			return echo.NewHTTPError(http.StatusUnauthorized, "Security alert: This refresh token has already been used. For your security, all tokens have been revoked. Please login again.")
+			return newHTTPError(http.StatusUnauthorized, ErrorCode(err), "Security alert: This refresh token has already been used. For your security, all tokens have been revoked. Please login again.")
+		case errors.Is(err, domain.ErrRefreshRateLimited):
+			body := api.Error{Error: "too many token refreshes, please retry later", Code: ErrorCode(err)}
+			var limited *domain.RefreshRateLimitedError
+			if errors.As(err, &limited) {
+				seconds := limited.RetryAfterSeconds()
+				c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(seconds))
+				body.RetryAfterSeconds = &seconds
+			}
+			return echo.NewHTTPError(http.StatusTooManyRequests, body)
 		case errors.Is(err, domain.ErrInvalidToken), errors.Is(err, domain.ErrTokenExpired):
-			return echo.NewHTTPError(http.StatusUnauthorized, "invalid or expired refresh token")
+			return newHTTPError(http.StatusUnauthorized, ErrorCode(err), "invalid or expired refresh token")
+		case errors.Is(err, domain.ErrAccountSuspended):
+			return newHTTPError(http.StatusForbidden, ErrorCode(err), "account is suspended")
+		case errors.Is(err, domain.ErrAccountPendingDeletion):
+			return newHTTPError(http.StatusForbidden, ErrorCode(err), "account is pending deletion")
+		case errors.Is(err, domain.ErrAudienceNotAllowed):
+			return newHTTPError(http.StatusForbidden, ErrorCode(err), ErrorMessage(err))
 		default:
-			return echo.NewHTTPError(http.StatusInternalServerError, "failed to refresh token")
+			return newHTTPError(http.StatusInternalServerError, api.ErrorCodeInternalError, "failed to refresh token").SetInternal(err)
 		}
 	}
 
@@ -161,31 +228,355 @@ func (h *AuthHandler) RefreshToken(c echo.Context) error {
 		RefreshToken: tokens.RefreshToken,
 		TokenType:    "Bearer",
 		ExpiresIn:    tokens.ExpiresIn,
-		Account: api.Account{
-			Id:        tokens.Account.ID,
-			Email:     openapiTypes.Email(tokens.Account.Email),
-			Name:      tokens.Account.Name,
-			CreatedAt: tokens.Account.CreatedAt,
-			UpdatedAt: tokens.Account.UpdatedAt,
-		},
+		Account:      NewAPIAccountFromEntity(tokens.Account),
 	})
 }
 
 // Logout リフレッシュトークンを無効化
+// ボディにリフレッシュトークンがあればそのトークンのみを無効化し、
+// 省略された場合はアクセストークンのアカウントのすべてのトークンを無効化する
 func (h *AuthHandler) Logout(c echo.Context) error {
 	var req api.LogoutRequest
 	if err := c.Bind(&req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+		return invalidRequestBodyError(err)
 	}
 
-	if req.RefreshToken == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "refresh_token is required")
+	if req.RefreshToken != "" {
+		if err := h.authUsecase.Logout(c.Request().Context(), req.RefreshToken); err != nil {
+			return newHTTPError(http.StatusInternalServerError, api.ErrorCodeInternalError, "failed to logout").SetInternal(err)
+		}
+		// 204 No Content を返す
+		return c.NoContent(http.StatusNoContent)
 	}
 
-	if err := h.authUsecase.Logout(c.Request().Context(), req.RefreshToken); err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to logout")
+	// リフレッシュトークンを持たないクライアントはアクセストークンでログアウト
+	accountID, err := accountIDFromContext(c)
+	if err != nil {
+		return newHTTPError(http.StatusUnauthorized, api.ErrorCodeUnauthorized, "missing or invalid access token")
+	}
+
+	if _, err := h.authUsecase.LogoutAll(c.Request().Context(), accountID, c.Request().UserAgent(), c.RealIP()); err != nil {
+		return newHTTPError(http.StatusInternalServerError, api.ErrorCodeInternalError, "failed to logout").SetInternal(err)
 	}
 
 	// 204 No Content を返す
 	return c.NoContent(http.StatusNoContent)
 }
+
+// LogoutAll 認証中のアカウントのすべてのセッションを無効化
+func (h *AuthHandler) LogoutAll(c echo.Context) error {
+	accountID, err := accountIDFromContext(c)
+	if err != nil {
+		return newHTTPError(http.StatusUnauthorized, api.ErrorCodeUnauthorized, "missing or invalid access token")
+	}
+
+	revoked, err := h.authUsecase.LogoutAll(c.Request().Context(), accountID, c.Request().UserAgent(), c.RealIP())
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, api.ErrorCodeInternalError, "failed to logout").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, api.LogoutAllResponse{
+		RevokedSessions: revoked,
+	})
+}
+
+// RequestMagicLink メールアドレス宛てにログイン用リンクを送信
+// アカウントの有無を推測されないよう、登録されていないメールアドレスでも200を返す
+func (h *AuthHandler) RequestMagicLink(c echo.Context) error {
+	var req api.MagicLinkRequest
+	if err := bindRequestBody(c, &req); err != nil {
+		return err
+	}
+
+	if req.Email == "" {
+		return newHTTPError(http.StatusBadRequest, api.ErrorCodeInvalidRequest, "email is required")
+	}
+
+	err := h.authUsecase.RequestMagicLink(c.Request().Context(), usecase.MagicLinkInput{
+		Email:     string(req.Email),
+		UserAgent: c.Request().UserAgent(),
+		IPAddress: c.RealIP(),
+	})
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, api.ErrorCodeInternalError, "failed to send magic link").SetInternal(err)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// VerifyMagicLink ログイン用リンクのトークンを消費してログイン
+func (h *AuthHandler) VerifyMagicLink(c echo.Context) error {
+	var req api.VerifyMagicLinkRequest
+	if err := bindRequestBody(c, &req); err != nil {
+		return err
+	}
+
+	if req.Token == "" {
+		return newHTTPError(http.StatusBadRequest, api.ErrorCodeInvalidRequest, "token is required")
+	}
+
+	var deviceName string
+	if req.DeviceName != nil {
+		deviceName = *req.DeviceName
+	}
+
+	tokens, err := h.authUsecase.VerifyMagicLink(c.Request().Context(), usecase.VerifyMagicLinkInput{
+		Token:      req.Token,
+		UserAgent:  c.Request().UserAgent(),
+		IPAddress:  c.RealIP(),
+		DeviceName: deviceName,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidDeviceName):
+			return newHTTPError(http.StatusBadRequest, ErrorCode(err), ErrorMessage(err))
+		case errors.Is(err, domain.ErrInvalidToken):
+			return newHTTPError(http.StatusUnauthorized, ErrorCode(err), "invalid or expired magic link")
+		case errors.Is(err, domain.ErrAccountSuspended):
+			return newHTTPError(http.StatusForbidden, ErrorCode(err), "account is suspended")
+		case errors.Is(err, domain.ErrAccountPendingDeletion):
+			return newHTTPError(http.StatusForbidden, ErrorCode(err), "account is pending deletion")
+		default:
+			return newHTTPError(http.StatusInternalServerError, api.ErrorCodeInternalError, "failed to login").SetInternal(err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, api.AuthResponse{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    tokens.ExpiresIn,
+		Account:      NewAPIAccountFromEntity(tokens.Account),
+	})
+}
+
+// GetCurrentSession X-Refresh-Tokenヘッダーのリフレッシュトークンで指定したセッションの情報を取得
+// トークンやハッシュは返さず、作成日時や接続元などの機密でない情報のみを返す
+func (h *AuthHandler) GetCurrentSession(c echo.Context, params api.GetCurrentSessionParams) error {
+	accountID, err := accountIDFromContext(c)
+	if err != nil {
+		return newHTTPError(http.StatusUnauthorized, api.ErrorCodeUnauthorized, "missing or invalid access token")
+	}
+	if params.XRefreshToken == "" {
+		return newHTTPError(http.StatusBadRequest, api.ErrorCodeInvalidRequest, "refresh token is required")
+	}
+
+	session, err := h.authUsecase.CurrentSession(c.Request().Context(), accountID, params.XRefreshToken)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return newHTTPError(http.StatusNotFound, ErrorCode(err), "session not found")
+		}
+		return newHTTPError(http.StatusInternalServerError, api.ErrorCodeInternalError, "failed to get session").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, api.SessionInfo{
+		Id:                session.ID,
+		CreatedAt:         utcTime(session.CreatedAt),
+		ExpiresAt:         utcTime(session.ExpiresAt),
+		AbsoluteExpiresAt: utcTime(session.AbsoluteExpiresAt),
+		LastUsedAt:        utcTimePtr(session.UsedAt),
+		UserAgent:         session.UserAgent,
+		IpAddress:         session.IPAddress,
+		DeviceName:        session.DeviceName,
+		UserAgentInfo:     toAPIUserAgentInfo(session),
+	})
+}
+
+// toAPIUserAgentInfo セッションのUser-Agentの解析結果をAPIの型に変換（User-Agentが無い場合はnil）
+func toAPIUserAgentInfo(session *domain.RefreshToken) *api.UserAgentInfo {
+	if session.UserAgent == nil || *session.UserAgent == "" {
+		return nil
+	}
+	ua := session.UserAgentInfo()
+	info := &api.UserAgentInfo{}
+	if ua.Browser != "" {
+		info.Browser = &ua.Browser
+	}
+	if ua.BrowserVersion != "" {
+		info.BrowserVersion = &ua.BrowserVersion
+	}
+	if ua.OS != "" {
+		info.Os = &ua.OS
+	}
+	if ua.Device != "" {
+		device := api.UserAgentInfoDevice(ua.Device)
+		info.Device = &device
+	}
+	return info
+}
+
+// RevokeSession リフレッシュトークンまたはそのハッシュで指定したセッションを無効化
+// 管理者または対象セッションを所有するアカウントのみ実行できる
+func (h *AuthHandler) RevokeSession(c echo.Context) error {
+	accountID, err := accountIDFromContext(c)
+	if err != nil {
+		return newHTTPError(http.StatusUnauthorized, api.ErrorCodeUnauthorized, "missing or invalid access token")
+	}
+
+	var req api.RevokeSessionRequest
+	if err := c.Bind(&req); err != nil {
+		return invalidRequestBodyError(err)
+	}
+
+	refreshToken, tokenHash, sessionID := "", "", uuid.Nil
+	given := 0
+	if req.RefreshToken != nil && *req.RefreshToken != "" {
+		refreshToken = *req.RefreshToken
+		given++
+	}
+	if req.TokenHash != nil && *req.TokenHash != "" {
+		tokenHash = *req.TokenHash
+		given++
+	}
+	if req.SessionId != nil && *req.SessionId != uuid.Nil {
+		sessionID = *req.SessionId
+		given++
+	}
+	if given != 1 {
+		return newHTTPError(http.StatusBadRequest, api.ErrorCodeInvalidRequest, "exactly one of session_id, refresh_token or token_hash is required")
+	}
+
+	role, _ := c.Get(string(middleware.RoleKey)).(string)
+	err = h.authUsecase.RevokeSession(c.Request().Context(), usecase.RevokeSessionInput{
+		SessionID:    sessionID,
+		RefreshToken: refreshToken,
+		TokenHash:    tokenHash,
+		ActorID:      accountID,
+		ActorRole:    domain.Role(role),
+		UserAgent:    c.Request().UserAgent(),
+		IPAddress:    c.RealIP(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrSessionNotFound):
+			return newHTTPError(http.StatusNotFound, ErrorCode(err), "session not found")
+		case errors.Is(err, domain.ErrForbidden):
+			return newHTTPError(http.StatusForbidden, ErrorCode(err), "not allowed to revoke this session")
+		default:
+			return newHTTPError(http.StatusInternalServerError, api.ErrorCodeInternalError, "failed to revoke session").SetInternal(err)
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetCurrentAccount 認証済みアカウントの情報（ロールと権限を含む）を取得
+func (h *AuthHandler) GetCurrentAccount(c echo.Context) error {
+	accountID, err := accountIDFromContext(c)
+	if err != nil {
+		return newHTTPError(http.StatusUnauthorized, api.ErrorCodeUnauthorized, "missing or invalid access token")
+	}
+
+	account, err := h.authUsecase.CurrentAccount(c.Request().Context(), accountID)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidToken) {
+			return newHTTPError(http.StatusUnauthorized, ErrorCode(err), "account no longer exists")
+		}
+		return newHTTPError(http.StatusInternalServerError, api.ErrorCodeInternalError, "failed to get account").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, NewAPIAccountFromEntity(account))
+}
+
+// CreateInvite 招待制のサインアップで使用する招待を作成（管理者のみ）
+// トークンはこのレスポンスでのみ返し、保存するのはハッシュのみ
+func (h *AuthHandler) CreateInvite(c echo.Context) error {
+	var req api.CreateInviteRequest
+	if err := c.Bind(&req); err != nil {
+		return invalidRequestBodyError(err)
+	}
+
+	actor, err := actorFromContext(c)
+	if err != nil {
+		return newHTTPError(http.StatusUnauthorized, api.ErrorCodeUnauthorized, "missing or invalid access token")
+	}
+
+	input := usecase.CreateInviteInput{Actor: actor}
+	if req.Role != nil {
+		input.Role = domain.Role(*req.Role)
+	}
+	if req.TenantId != nil {
+		input.TenantID = *req.TenantId
+	}
+
+	created, err := h.authUsecase.CreateInvite(c.Request().Context(), input)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidRole):
+			return newHTTPError(http.StatusBadRequest, ErrorCode(err), "role must be user or admin")
+		case errors.Is(err, domain.ErrForbidden):
+			return newHTTPError(http.StatusForbidden, ErrorCode(err), "cannot create an invite for another tenant")
+		default:
+			return newHTTPError(http.StatusInternalServerError, api.ErrorCodeInternalError, "failed to create invite").SetInternal(err)
+		}
+	}
+
+	return c.JSON(http.StatusCreated, api.Invite{
+		Id:        created.Invite.ID,
+		Token:     created.Token,
+		Role:      api.InviteRole(created.Invite.Role),
+		TenantId:  created.Invite.TenantID,
+		ExpiresAt: utcTime(created.Invite.ExpiresAt),
+		CreatedAt: utcTime(created.Invite.CreatedAt),
+	})
+}
+
+// RevokeSessionsByOrigin 送信元のIPアドレスまたはUser-Agentが一致するセッションを一括で無効化（管理者のみ）
+func (h *AuthHandler) RevokeSessionsByOrigin(c echo.Context) error {
+	var req api.RevokeSessionsByOriginRequest
+	if err := c.Bind(&req); err != nil {
+		return invalidRequestBodyError(err)
+	}
+
+	actor, err := actorFromContext(c)
+	if err != nil {
+		return newHTTPError(http.StatusUnauthorized, api.ErrorCodeUnauthorized, "missing or invalid access token")
+	}
+
+	input := usecase.RevokeSessionsByOriginInput{Actor: actor}
+	if req.IpAddress != nil {
+		input.IPAddress = *req.IpAddress
+	}
+	if req.UserAgent != nil {
+		input.UserAgent = *req.UserAgent
+	}
+
+	revoked, err := h.authUsecase.RevokeSessionsByOrigin(c.Request().Context(), input)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidRevocation):
+			// 不足している条件が分かるよう、検証エラーのメッセージをそのまま返す
+			return newHTTPError(http.StatusBadRequest, ErrorCode(err), ErrorMessage(err))
+		case errors.Is(err, domain.ErrForbidden):
+			return newHTTPError(http.StatusForbidden, ErrorCode(err), "administrator role required")
+		default:
+			return newHTTPError(http.StatusInternalServerError, api.ErrorCodeInternalError, "failed to revoke sessions").SetInternal(err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, api.RevokeSessionsByOriginResponse{RevokedCount: revoked})
+}
+
+// accountIDFromContext 認証ミドルウェアが設定したアカウントIDを取得
+func accountIDFromContext(c echo.Context) (uuid.UUID, error) {
+	value, ok := c.Get(string(middleware.AccountIDKey)).(string)
+	if !ok || value == "" {
+		return uuid.Nil, domain.ErrInvalidToken
+	}
+	return domain.ParseAccountID(value)
+}
+
+// actorFromContext 認証済みのアカウントとリクエストの送信元を監査ログ用の操作者として取得
+func actorFromContext(c echo.Context) (usecase.Actor, error) {
+	accountID, err := accountIDFromContext(c)
+	if err != nil {
+		return usecase.Actor{}, err
+	}
+	role, _ := c.Get(string(middleware.RoleKey)).(string)
+	return usecase.Actor{
+		ID:        accountID,
+		Role:      domain.Role(role),
+		UserAgent: c.Request().UserAgent(),
+		IPAddress: c.RealIP(),
+	}, nil
+}
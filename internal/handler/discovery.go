@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/labstack/echo/v4"
+)
+
+// ディスカバリーのパス（OpenAPIのベースURLの外に公開する）
+const (
+	DiscoveryPath = "/.well-known/openid-configuration"
+	JWKSPath      = "/.well-known/jwks.json"
+)
+
+// discoveryCacheControl ディスカバリードキュメントは設定を変えて再起動するまで変わらないためキャッシュを許可する
+const discoveryCacheControl = "public, max-age=3600"
+
+// DiscoveryConfig ディスカバリードキュメントに記載する設定
+type DiscoveryConfig struct {
+	// Issuer 発行するトークンのissクレーム（JWT_ISSUER）
+	Issuer string
+	// IssuerURL 外部から到達できるこのサーバーのURL（JWT_ISSUER_URL、各エンドポイントのURLの基点）
+	IssuerURL string
+	// SigningAlgorithm 署名アルゴリズム（JWT_SIGNING_ALGORITHM）
+	SigningAlgorithm string
+	// SigningKeys ローテーションする署名鍵（JWT_KEY_ROTATION_INTERVAL、無効の場合はnil）
+	SigningKeys *auth.KeyRing
+}
+
+// DiscoveryDocument OpenID Connect Discoveryの形式の設定情報
+type DiscoveryDocument struct {
+	Issuer                            string   `json:"issuer"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	RefreshEndpoint                   string   `json:"refresh_endpoint"`
+	RevocationEndpoint                string   `json:"revocation_endpoint"`
+	UserinfoEndpoint                  string   `json:"userinfo_endpoint"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
+
+// JWKS JSON Web Key Setの形式の公開鍵の一覧
+type JWKS struct {
+	Keys []map[string]interface{} `json:"keys"`
+}
+
+// DiscoveryHandler OpenID Connect Discovery形式の設定情報を返すハンドラー
+type DiscoveryHandler struct {
+	document    DiscoveryDocument
+	signingKeys *auth.KeyRing
+}
+
+// NewDiscoveryHandler 設定からディスカバリードキュメントを組み立ててハンドラーを作成
+func NewDiscoveryHandler(config DiscoveryConfig) *DiscoveryHandler {
+	baseURL := strings.TrimSuffix(config.IssuerURL, "/")
+
+	return &DiscoveryHandler{
+		document: DiscoveryDocument{
+			Issuer:                            config.Issuer,
+			JWKSURI:                           baseURL + JWKSPath,
+			TokenEndpoint:                     baseURL + "/api/v1/auth/login",
+			RefreshEndpoint:                   baseURL + "/api/v1/auth/refresh",
+			RevocationEndpoint:                baseURL + "/api/v1/auth/logout",
+			UserinfoEndpoint:                  baseURL + "/api/v1/auth/me",
+			GrantTypesSupported:               []string{"password", "refresh_token"},
+			ResponseTypesSupported:            []string{"token"},
+			SubjectTypesSupported:             []string{"public"},
+			IDTokenSigningAlgValuesSupported:  []string{config.SigningAlgorithm},
+			TokenEndpointAuthMethodsSupported: []string{"none"},
+			ClaimsSupported:                   []string{"sub", "iss", "aud", "exp", "iat", "nbf", "jti", "account_id", "email", "role", "tenant_id"},
+		},
+		signingKeys: config.SigningKeys,
+	}
+}
+
+// GetConfiguration ディスカバリードキュメントを返す（認証不要）
+func (h *DiscoveryHandler) GetConfiguration(c echo.Context) error {
+	c.Response().Header().Set("Cache-Control", discoveryCacheControl)
+	return c.JSON(http.StatusOK, h.document)
+}
+
+// GetJWKS 公開鍵の一覧を返す（認証不要）
+// 署名はHMACのみのため公開できる鍵は無く、署名鍵をローテーションしない場合は常に空の一覧を返す
+// 検証する側はjwtverifyパッケージなどでJWT_ACCESS_TOKEN_SECRETを共有して検証する
+// ローテーションする場合は、検証に使用している鍵のkidとアルゴリズムのみを返す（共通鍵のため鍵そのもの（k）は含めない）
+func (h *DiscoveryHandler) GetJWKS(c echo.Context) error {
+	keys := []map[string]interface{}{}
+	if h.signingKeys == nil {
+		c.Response().Header().Set("Cache-Control", discoveryCacheControl)
+		return c.JSON(http.StatusOK, JWKS{Keys: keys})
+	}
+
+	for _, key := range h.signingKeys.Keys(time.Now()) {
+		keys = append(keys, map[string]interface{}{
+			"kty": "oct",
+			"kid": key.ID.String(),
+			"alg": key.Algorithm,
+			"use": "sig",
+		})
+	}
+	// 鍵はローテーションで変わるためキャッシュさせない
+	c.Response().Header().Set("Cache-Control", "no-cache")
+	return c.JSON(http.StatusOK, JWKS{Keys: keys})
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+// errorCodes ドメインのエラーとAPIのエラーコードの対応
+// ErrAccountNotFoundなどはErrNotFoundをラップしているため、個別のエラーを先に並べる
+var errorCodes = []struct {
+	err  error
+	code api.ErrorCode
+}{
+	{domain.ErrAccountNotFound, api.ErrorCodeAccountNotFound},
+	{domain.ErrProjectNotFound, api.ErrorCodeProjectNotFound},
+	{domain.ErrSessionNotFound, api.ErrorCodeSessionNotFound},
+	{domain.ErrNotFound, api.ErrorCodeNotFound},
+
+	{domain.ErrInvalidCredentials, api.ErrorCodeInvalidCredentials},
+	{domain.ErrAccountLocked, api.ErrorCodeAccountLocked},
+	{domain.ErrAccountSuspended, api.ErrorCodeAccountSuspended},
+	{domain.ErrAccountPendingDeletion, api.ErrorCodeAccountPendingDeletion},
+	{domain.ErrAccountNotPendingDeletion, api.ErrorCodeAccountNotPendingDeletion},
+	{domain.ErrVersionConflict, api.ErrorCodeVersionConflict},
+	{domain.ErrIncorrectPassword, api.ErrorCodeIncorrectPassword},
+	{domain.ErrPasswordReused, api.ErrorCodePasswordReused},
+	{domain.ErrTokenCompromised, api.ErrorCodeTokenCompromised},
+	{domain.ErrRefreshRateLimited, api.ErrorCodeRateLimited},
+	{domain.ErrTokenExpired, api.ErrorCodeTokenExpired},
+	{domain.ErrInvalidToken, api.ErrorCodeInvalidToken},
+	{domain.ErrUnauthorized, api.ErrorCodeUnauthorized},
+	{domain.ErrForbidden, api.ErrorCodeForbidden},
+
+	{domain.ErrEmailAlreadyExists, api.ErrorCodeEmailExists},
+	{domain.ErrDuplicateEmail, api.ErrorCodeEmailExists},
+	{domain.ErrInvalidEmail, api.ErrorCodeInvalidEmail},
+	{domain.ErrDisallowedEmailDomain, api.ErrorCodeEmailDomainNotAllowed},
+	{domain.ErrSignupDisabled, api.ErrorCodeSignupDisabled},
+	{domain.ErrInvalidInvite, api.ErrorCodeInvalidInvite},
+	{domain.ErrInvalidVerificationToken, api.ErrorCodeInvalidVerificationToken},
+	{domain.ErrInvalidPasswordResetToken, api.ErrorCodeInvalidPasswordResetToken},
+	{domain.ErrInvalidName, api.ErrorCodeInvalidName},
+	{domain.ErrInvalidUsername, api.ErrorCodeInvalidUsername},
+	{domain.ErrDuplicateUsername, api.ErrorCodeUsernameExists},
+	{domain.ErrInvalidDisplayName, api.ErrorCodeInvalidProfile},
+	{domain.ErrInvalidAvatarURL, api.ErrorCodeInvalidProfile},
+	{domain.ErrInvalidLocale, api.ErrorCodeInvalidProfile},
+	{domain.ErrInvalidTimezone, api.ErrorCodeInvalidProfile},
+	{domain.ErrInvalidRole, api.ErrorCodeInvalidRole},
+	{domain.ErrInvalidAudience, api.ErrorCodeInvalidRequest},
+	{domain.ErrAudienceNotAllowed, api.ErrorCodeAudienceNotAllowed},
+
+	{domain.ErrInvalidStatus, api.ErrorCodeInvalidStatus},
+	{domain.ErrInvalidDescription, api.ErrorCodeInvalidRequest},
+	{domain.ErrInvalidAccountStatus, api.ErrorCodeInvalidStatus},
+	{domain.ErrProjectLimitExceeded, api.ErrorCodeProjectLimitExceeded},
+	{domain.ErrDuplicateProjectName, api.ErrorCodeProjectNameExists},
+
+	{domain.ErrInvalidID, api.ErrorCodeInvalidId},
+	{domain.ErrInvalidAccountID, api.ErrorCodeInvalidId},
+	{domain.ErrInvalidPagination, api.ErrorCodeInvalidPagination},
+	{domain.ErrInvalidSearch, api.ErrorCodeInvalidRequest},
+	{domain.ErrInvalidDeviceName, api.ErrorCodeInvalidRequest},
+	{domain.ErrInvalidRevocation, api.ErrorCodeInvalidRequest},
+
+	{domain.ErrServiceUnavailable, api.ErrorCodeServiceUnavailable},
+	{domain.ErrQueryTimeout, api.ErrorCodeQueryTimeout},
+}
+
+// ErrorCode ドメインのエラーに対応するAPIのエラーコードを返す
+// 対応の無いエラー（DBエラーなど）は内部エラーとして扱う
+func ErrorCode(err error) api.ErrorCode {
+	for _, m := range errorCodes {
+		if errors.Is(err, m.err) {
+			return m.code
+		}
+	}
+	return api.ErrorCodeInternalError
+}
+
+// ErrorMessage ドメインのエラーからクライアントに返して安全なメッセージを返す
+// ユースケースやリポジトリがラップした外側のメッセージ（IDや内部の処理名を含む）は返さず、
+// ドメインのエラーから始まるメッセージ（"invalid name: must be at most 100 characters"など）までを返す
+func ErrorMessage(err error) string {
+	for _, m := range errorCodes {
+		if !errors.Is(err, m.err) {
+			continue
+		}
+		for e := err; e != nil; e = errors.Unwrap(e) {
+			if strings.HasPrefix(e.Error(), m.err.Error()) {
+				return e.Error()
+			}
+		}
+		return m.err.Error()
+	}
+	return http.StatusText(http.StatusInternalServerError)
+}
+
+// newAPIError ドメインのエラーからエラーレスポンスを作成
+func newAPIError(err error) api.Error {
+	return api.Error{
+		Error: ErrorMessage(err),
+		Code:  ErrorCode(err),
+	}
+}
+
+// newInternalError 内部エラーのHTTPエラーを作成
+// クライアントには汎用のメッセージとリクエストIDのみを返し、errはHTTPErrorHandlerがリクエストIDと共にログに出力する
+func newInternalError(err error) *echo.HTTPError {
+	return echo.NewHTTPError(http.StatusInternalServerError, api.Error{
+		Code: api.ErrorCodeInternalError,
+	}).SetInternal(err)
+}
+
+// newHTTPError エラーコード付きのHTTPエラーを作成
+// HTTPErrorHandlerはapi.Errorのメッセージをそのままmiddleware.RespondErrorでレスポンスボディとして返す
+func newHTTPError(status int, code api.ErrorCode, message string) *echo.HTTPError {
+	return echo.NewHTTPError(status, api.Error{
+		Error: message,
+		Code:  code,
+	})
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+// HTTPヘッダー名（Echoに定数が無いもの）
+const (
+	headerETag        = "ETag"
+	headerIfNoneMatch = "If-None-Match"
+	headerIfMatch     = "If-Match"
+	// headerPageLimit 一覧で実際に適用した件数の上限（指定が上限を超えて切り詰めた場合に分かるようにする）
+	headerPageLimit = "X-Page-Limit"
+)
+
+// computeETag レスポンス本文のハッシュから強いETagを生成
+// 表現が変われば必ず値が変わるため、updated_atの精度に依存しない
+func computeETag(body interface{}) (string, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+// accountETag アカウントのバージョンから強いETagを生成
+// If-Matchで送り返された値から更新の前提とするバージョンを取り出せるよう、表現のハッシュではなくバージョンを使う
+// 最終ログインはバージョンを変えずに更新されるため、記録がある場合は"バージョン-最終ログイン日時"にして
+// If-None-Matchで古い最終ログインを返さないようにする（If-Matchではバージョンのみを比較する）
+func accountETag(account *domain.Account) string {
+	version := strconv.FormatInt(account.Version, 10)
+	if account.LastLoginAt == nil {
+		return `"` + version + `"`
+	}
+	return `"` + version + "-" + strconv.FormatInt(account.LastLoginAt.UnixNano(), 10) + `"`
+}
+
+// ifMatchVersions If-Matchの値からアカウントの更新で期待するバージョンの一覧を返す
+// ヘッダーが無い場合と"*"（存在すれば更新）の場合はnil（無条件）を返す
+// 弱いETagや他のリソースのETagはどのバージョンにも一致しないため除外し、空の一覧は必ず競合になる
+func ifMatchVersions(ifMatch string) []int64 {
+	if strings.TrimSpace(ifMatch) == "" {
+		return nil
+	}
+	versions := make([]int64, 0)
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return nil
+		}
+		unquoted, ok := strings.CutPrefix(candidate, `"`)
+		if !ok {
+			continue
+		}
+		unquoted, ok = strings.CutSuffix(unquoted, `"`)
+		if !ok {
+			continue
+		}
+		unquoted, _, _ = strings.Cut(unquoted, "-")
+		if version, err := strconv.ParseInt(unquoted, 10, 64); err == nil {
+			versions = append(versions, version)
+		}
+	}
+	return versions
+}
+
+// jsonWithETag ETagヘッダーを付与してJSONレスポンスを返す
+func jsonWithETag(ctx echo.Context, status int, body interface{}) error {
+	etag, err := computeETag(body)
+	if err != nil {
+		return err
+	}
+	return jsonWithETagValue(ctx, status, etag, body)
+}
+
+// jsonWithETagValue 指定したETagヘッダーを付与してJSONレスポンスを返す
+func jsonWithETagValue(ctx echo.Context, status int, etag string, body interface{}) error {
+	ctx.Response().Header().Set(headerETag, etag)
+	return ctx.JSON(status, body)
+}
+
+// conditionalJSON If-None-Matchが現在のETagと一致する場合は304、それ以外はETag付きの200を返す
+func conditionalJSON(ctx echo.Context, body interface{}) error {
+	etag, err := computeETag(body)
+	if err != nil {
+		return err
+	}
+	return conditionalJSONWithETag(ctx, etag, body)
+}
+
+// conditionalJSONWithETag 指定したETagでconditionalJSONと同じ応答を返す
+func conditionalJSONWithETag(ctx echo.Context, etag string, body interface{}) error {
+	ctx.Response().Header().Set(headerETag, etag)
+
+	if etagMatches(ctx.Request().Header.Get(headerIfNoneMatch), etag) {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+	return ctx.JSON(http.StatusOK, body)
+}
+
+// etagMatches If-None-Matchの値にETagが含まれるか判定（弱い比較）
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -13,20 +13,27 @@ type Server struct {
 	accountUsecase usecase.AccountUsecase
 	projectUsecase usecase.ProjectUsecase
 	authHandler    *AuthHandler
+	health         HealthConfig
+	privacy        PrivacyConfig
 	logger         logger.Logger
 }
 
 // NewServer 新しいサーバーインスタンスを作成
+// health.Readinessがnilの場合、レディネスチェックは常に準備完了を返す
 func NewServer(
 	accountUsecase usecase.AccountUsecase,
 	projectUsecase usecase.ProjectUsecase,
 	authHandler *AuthHandler,
+	health HealthConfig,
+	privacy PrivacyConfig,
 	logger logger.Logger,
 ) api.ServerInterface {
 	return &Server{
 		accountUsecase: accountUsecase,
 		projectUsecase: projectUsecase,
 		authHandler:    authHandler,
+		health:         health,
+		privacy:        privacy,
 		logger:         logger,
 	}
 }
@@ -50,3 +57,43 @@ func (s *Server) RefreshToken(ctx echo.Context) error {
 func (s *Server) Logout(ctx echo.Context) error {
 	return s.authHandler.Logout(ctx)
 }
+
+// LogoutAll 全セッションログアウトエンドポイント
+func (s *Server) LogoutAll(ctx echo.Context) error {
+	return s.authHandler.LogoutAll(ctx)
+}
+
+// RequestMagicLink ログイン用リンク送信エンドポイント
+func (s *Server) RequestMagicLink(ctx echo.Context) error {
+	return s.authHandler.RequestMagicLink(ctx)
+}
+
+// VerifyMagicLink ログイン用リンクによるログインエンドポイント
+func (s *Server) VerifyMagicLink(ctx echo.Context) error {
+	return s.authHandler.VerifyMagicLink(ctx)
+}
+
+// GetCurrentSession 現在のセッション情報取得エンドポイント
+func (s *Server) GetCurrentSession(ctx echo.Context, params api.GetCurrentSessionParams) error {
+	return s.authHandler.GetCurrentSession(ctx, params)
+}
+
+// RevokeSession セッション無効化エンドポイント
+func (s *Server) RevokeSession(ctx echo.Context) error {
+	return s.authHandler.RevokeSession(ctx)
+}
+
+// GetCurrentAccount 認証済みアカウント取得エンドポイント
+func (s *Server) GetCurrentAccount(ctx echo.Context) error {
+	return s.authHandler.GetCurrentAccount(ctx)
+}
+
+// CreateInvite 招待作成エンドポイント
+func (s *Server) CreateInvite(ctx echo.Context) error {
+	return s.authHandler.CreateInvite(ctx)
+}
+
+// RevokeSessionsByOrigin セッション一括無効化エンドポイント
+func (s *Server) RevokeSessionsByOrigin(ctx echo.Context) error {
+	return s.authHandler.RevokeSessionsByOrigin(ctx)
+}
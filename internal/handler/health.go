@@ -1,11 +1,33 @@
 package handler
 
 import (
+	"context"
+	"database/sql"
 	"net/http"
 
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/buildinfo"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/logger"
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/aida0710/jwt-auth/internal/selfcheck"
 	"github.com/labstack/echo/v4"
 )
 
+// ReadinessCheck レディネスチェックで実行するセルフチェック
+type ReadinessCheck func(ctx context.Context) selfcheck.Report
+
+// HealthConfig ヘルスチェック・レディネスチェックの設定
+type HealthConfig struct {
+	// Readiness 実行するセルフチェック（nilの場合は常に準備完了）
+	Readiness ReadinessCheck
+	// PoolStats データベースのコネクションプールの統計を返す関数（nilの場合は統計を返さない）
+	PoolStats func() sql.DBStats
+	// ExposePoolStats プールの統計を管理者以外にも返すかどうか（内部ネットワークからのみ到達できる環境向け）
+	ExposePoolStats bool
+}
+
 // GetHealth ヘルスチェックエンドポイント
 func (s *Server) GetHealth(ctx echo.Context) error {
 	s.logger.Debug(ctx.Request().Context(), "Health check requested")
@@ -14,3 +36,75 @@ func (s *Server) GetHealth(ctx echo.Context) error {
 		"status": "ok",
 	})
 }
+
+// GetInfo ビルド情報とパスワードハッシュの設定を返す（監査用、秘密情報は含めない）
+func (s *Server) GetInfo(ctx echo.Context) error {
+	info := buildinfo.Get()
+
+	return ctx.JSON(http.StatusOK, api.BuildInfo{
+		Version:   info.Version,
+		Commit:    info.Commit,
+		GoVersion: info.GoVersion,
+		PasswordHashing: api.PasswordHashingInfo{
+			Algorithm: auth.PasswordHashAlgorithm,
+			Cost:      auth.PasswordHashCost,
+		},
+	})
+}
+
+// GetReadiness セルフチェックを実行し、いずれかのチェックが失敗した場合は503を返す
+// チェックが設定されていない場合は常に準備完了とする
+// コネクションプールの統計はインフラの情報のため、管理者または内部向けに有効化した場合のみ含める
+func (s *Server) GetReadiness(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	var report selfcheck.Report
+	if s.health.Readiness != nil {
+		report = s.health.Readiness(reqCtx)
+	}
+
+	checks := make([]api.SelfCheckResult, len(report.Results))
+	for i, result := range report.Results {
+		checks[i] = api.SelfCheckResult{
+			Name:    result.Name,
+			Ok:      result.OK,
+			Message: optionalString(result.Message),
+		}
+	}
+
+	status := http.StatusOK
+	if !report.OK() {
+		status = http.StatusServiceUnavailable
+		s.logger.Warn(reqCtx, "Readiness check failed", logger.F("checks", report.Summary()))
+	}
+
+	return ctx.JSON(status, api.ReadinessReport{
+		Ready:  report.OK(),
+		Checks: checks,
+		Pool:   s.poolStats(ctx),
+	})
+}
+
+// poolStats リクエストに返してよい場合のみコネクションプールの統計を返す
+func (s *Server) poolStats(ctx echo.Context) *api.DatabasePoolStats {
+	if s.health.PoolStats == nil {
+		return nil
+	}
+	role, _ := ctx.Get(string(middleware.RoleKey)).(string)
+	if !s.health.ExposePoolStats && domain.Role(role) != domain.RoleAdmin {
+		return nil
+	}
+
+	stats := s.health.PoolStats()
+	return &api.DatabasePoolStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDurationMs:     stats.WaitDuration.Milliseconds(),
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+	}
+}
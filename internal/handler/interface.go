@@ -5,31 +5,64 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// コンパイル時に生成されたServerInterfaceとの整合性を検証
+// OpenAPI定義に操作を追加した場合は、以下のインターフェースにも追加しないとビルドが失敗する
+var (
+	_ api.ServerInterface = Handler(nil)
+	_ Handler             = (*Server)(nil)
+)
+
 // Handler すべてのハンドラーを集約するインターフェース
+// api.ServerInterfaceと同じメソッドをリソースごとに分割して定義する
 type Handler interface {
 	AccountHandler
 	ProjectHandler
+	AuthenticationHandler
 	HealthHandler
 }
 
 // AccountHandler アカウント関連のハンドラーインターフェース
 type AccountHandler interface {
 	// ListAccounts アカウント一覧取得
-	ListAccounts(ctx echo.Context, params []api.Account) error
-	// CreateAccount アカウント作成
+	ListAccounts(ctx echo.Context, params api.ListAccountsParams) error
+	// CreateAccount アカウント作成（管理者のみ）
 	CreateAccount(ctx echo.Context) error
+	// ListAccountProjectCounts プロジェクト数付きのアカウント一覧取得（管理者のみ）
+	ListAccountProjectCounts(ctx echo.Context, params api.ListAccountProjectCountsParams) error
+	// SearchAccounts メールアドレスの前方一致によるアカウント検索（管理者のみ）
+	SearchAccounts(ctx echo.Context, params api.SearchAccountsParams) error
+	// UpdateAccountStatus アカウントの停止・再開（管理者のみ）
+	UpdateAccountStatus(ctx echo.Context, accountId api.AccountID) error
+	// UpdateAccountAudiences アカウントが要求できるAudienceの変更（管理者のみ）
+	UpdateAccountAudiences(ctx echo.Context, accountId api.AccountID) error
 	// GetAccount アカウント取得
 	GetAccount(ctx echo.Context, accountId api.AccountID) error
 	// UpdateAccount アカウント更新
 	UpdateAccount(ctx echo.Context, accountId api.AccountID) error
-	// DeleteAccount アカウント削除
+	// PatchAccount アカウント部分更新
+	PatchAccount(ctx echo.Context, accountId api.AccountID) error
+	// ConfirmEmailChange メールアドレス変更の確定
+	ConfirmEmailChange(ctx echo.Context, accountId api.AccountID) error
+	// ExportAccount アカウントのデータのエクスポート
+	ExportAccount(ctx echo.Context, accountId api.AccountID) error
+	// ChangePassword パスワード変更
+	ChangePassword(ctx echo.Context, accountId api.AccountID) error
+	// RequestPasswordReset パスワード再設定用のトークンの送信
+	RequestPasswordReset(ctx echo.Context) error
+	// ResetPassword パスワード再設定用のトークンによるパスワード変更
+	ResetPassword(ctx echo.Context) error
+	// DeleteAccount アカウント削除（猶予期間の後に完全に削除）
 	DeleteAccount(ctx echo.Context, accountId api.AccountID) error
+	// RestoreAccount 猶予期間中のアカウントの削除の取り消し
+	RestoreAccount(ctx echo.Context, accountId api.AccountID) error
 }
 
 // ProjectHandler プロジェクト関連のハンドラーインターフェース
 type ProjectHandler interface {
 	// ListProjects プロジェクト一覧取得
-	ListProjects(ctx echo.Context, accountId api.AccountID, params []api.Project) error
+	ListProjects(ctx echo.Context, accountId api.AccountID, params api.ListProjectsParams) error
+	// ListAllProjects 全アカウントのプロジェクト一覧取得（管理者のみ）
+	ListAllProjects(ctx echo.Context, params api.ListAllProjectsParams) error
 	// CreateProject プロジェクト作成
 	CreateProject(ctx echo.Context, accountId api.AccountID) error
 	// GetProject プロジェクト取得
@@ -40,8 +73,40 @@ type ProjectHandler interface {
 	DeleteProject(ctx echo.Context, accountId api.AccountID, projectId api.ProjectID) error
 }
 
+// AuthenticationHandler 認証関連のハンドラーインターフェース
+type AuthenticationHandler interface {
+	// SignUp サインアップ
+	SignUp(ctx echo.Context) error
+	// Login ログイン
+	Login(ctx echo.Context) error
+	// RefreshToken トークンリフレッシュ
+	RefreshToken(ctx echo.Context) error
+	// Logout ログアウト
+	Logout(ctx echo.Context) error
+	// LogoutAll 全セッションのログアウト
+	LogoutAll(ctx echo.Context) error
+	// RequestMagicLink ログイン用リンクの送信
+	RequestMagicLink(ctx echo.Context) error
+	// VerifyMagicLink ログイン用リンクによるログイン
+	VerifyMagicLink(ctx echo.Context) error
+	// GetCurrentSession 現在のセッション情報の取得
+	GetCurrentSession(ctx echo.Context, params api.GetCurrentSessionParams) error
+	// RevokeSession 個別のセッションの無効化
+	RevokeSession(ctx echo.Context) error
+	// GetCurrentAccount 認証済みアカウントの取得
+	GetCurrentAccount(ctx echo.Context) error
+	// CreateInvite 招待の作成（管理者のみ）
+	CreateInvite(ctx echo.Context) error
+	// RevokeSessionsByOrigin 送信元によるセッションの一括無効化（管理者のみ）
+	RevokeSessionsByOrigin(ctx echo.Context) error
+}
+
 // HealthHandler ヘルスチェック関連のハンドラーインターフェース
 type HealthHandler interface {
 	// GetHealth ヘルスチェック
 	GetHealth(ctx echo.Context) error
+	// GetInfo ビルド情報の取得
+	GetInfo(ctx echo.Context) error
+	// GetReadiness セルフチェックによるレディネスチェック
+	GetReadiness(ctx echo.Context) error
 }
@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// BasePath APIのルートを登録するベースパス
+// 作成したリソースのLocationヘッダーもこのパスを基準に組み立てる
+const BasePath = "/api/v1"
+
+// accountLocation アカウントリソースのパス
+func accountLocation(accountID uuid.UUID) string {
+	return BasePath + "/accounts/" + accountID.String()
+}
+
+// projectLocation プロジェクトリソースのパス
+func projectLocation(accountID, projectID uuid.UUID) string {
+	return accountLocation(accountID) + "/projects/" + projectID.String()
+}
+
+// setLocation 作成したリソースのパスをLocationヘッダーに設定
+func setLocation(ctx echo.Context, location string) {
+	ctx.Response().Header().Set(echo.HeaderLocation, location)
+}
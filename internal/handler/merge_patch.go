@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime"
+
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// mimeMergePatchJSON JSON Merge Patch（RFC 7396）のContent-Type
+const mimeMergePatchJSON = "application/merge-patch+json"
+
+// isMergePatch リクエストのContent-TypeがJSON Merge Patchか返す
+func isMergePatch(c echo.Context) bool {
+	mediaType, _, err := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	return err == nil && mediaType == mimeMergePatchJSON
+}
+
+// patchField JSON Merge Patchの1項目（省略・null・値の3状態を区別する）
+type patchField[T any] struct {
+	Set   bool // キーが存在する（nullを含む）
+	Null  bool // 明示的にnullが指定された
+	Value T
+}
+
+// UnmarshalJSON キーが存在する場合のみ呼ばれるため、呼ばれた時点でSetにする
+func (f *patchField[T]) UnmarshalJSON(data []byte) error {
+	f.Set = true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		f.Null = true
+		return nil
+	}
+	return json.Unmarshal(data, &f.Value)
+}
+
+// accountMergePatch アカウントのJSON Merge Patch
+// プロフィール項目はnullで未設定に戻し、メールアドレスと名前はnullを指定できない
+type accountMergePatch struct {
+	Email       patchField[string] `json:"email"`
+	Name        patchField[string] `json:"name"`
+	DisplayName patchField[string] `json:"display_name"`
+	AvatarURL   patchField[string] `json:"avatar_url"`
+	Locale      patchField[string] `json:"locale"`
+	Timezone    patchField[string] `json:"timezone"`
+}
+
+// bindAccountMergePatch リクエストボディをJSON Merge Patchとして読み込み、更新の入力に変換
+func bindAccountMergePatch(c echo.Context) (usecase.UpdateInput, error) {
+	// Merge Patchはオブジェクト以外（配列やnull）だとリソース全体の置き換えになるため受け付けない
+	var raw json.RawMessage
+	if err := json.NewDecoder(c.Request().Body).Decode(&raw); err != nil {
+		return usecase.UpdateInput{}, errors.New(bindErrorMessage(err))
+	}
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) == 0 || trimmed[0] != '{' {
+		return usecase.UpdateInput{}, errors.New("merge patch must be a JSON object")
+	}
+
+	var patch accountMergePatch
+	if err := json.Unmarshal(raw, &patch); err != nil {
+		return usecase.UpdateInput{}, errors.New(bindErrorMessage(err))
+	}
+	if patch.Email.Null {
+		return usecase.UpdateInput{}, errors.New("email cannot be null")
+	}
+	if patch.Name.Null {
+		return usecase.UpdateInput{}, errors.New("name cannot be null")
+	}
+
+	return usecase.UpdateInput{
+		Email:       patch.Email.value(),
+		Name:        patch.Name.value(),
+		DisplayName: patch.DisplayName.clearable(),
+		AvatarURL:   patch.AvatarURL.clearable(),
+		Locale:      patch.Locale.clearable(),
+		Timezone:    patch.Timezone.clearable(),
+	}, nil
+}
+
+// value 指定された値を返す（省略された場合はnilで変更しない）
+func (f patchField[T]) value() *T {
+	if !f.Set {
+		return nil
+	}
+	return &f.Value
+}
+
+// clearable 省略はnil、nullはゼロ値（空文字で未設定に戻す）、それ以外は指定された値を返す
+func (f patchField[T]) clearable() *T {
+	if !f.Set {
+		return nil
+	}
+	if f.Null {
+		var zero T
+		return &zero
+	}
+	return &f.Value
+}
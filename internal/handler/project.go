@@ -7,6 +7,7 @@ import (
 	"github.com/aida0710/jwt-auth/internal/api"
 	"github.com/aida0710/jwt-auth/internal/domain"
 	"github.com/aida0710/jwt-auth/internal/logger"
+	"github.com/aida0710/jwt-auth/internal/middleware"
 	"github.com/aida0710/jwt-auth/internal/usecase"
 	"github.com/labstack/echo/v4"
 )
@@ -14,16 +15,15 @@ import (
 // NewAPIProjectFromEntity エンティティからAPIレスポンスに変換
 func NewAPIProjectFromEntity(project *domain.Project) api.Project {
 	apiProject := api.Project{
-		Id:        project.ID,
-		AccountId: project.AccountID,
-		Name:      project.Name,
-		CreatedAt: project.CreatedAt,
-		UpdatedAt: project.UpdatedAt,
-	}
-
-	// Descriptionの変換
-	if project.Description != "" {
-		apiProject.Description = &project.Description
+		Id:          project.ID,
+		TenantId:    project.TenantID,
+		AccountId:   project.AccountID,
+		Name:        project.Name,
+		Description: optionalString(project.Description),
+		CreatedBy:   project.CreatedBy,
+		UpdatedBy:   project.UpdatedBy,
+		CreatedAt:   utcTime(project.CreatedAt),
+		UpdatedAt:   utcTime(project.UpdatedAt),
 	}
 
 	// Statusの変換
@@ -42,14 +42,19 @@ func NewAPIProjectFromEntity(project *domain.Project) api.Project {
 }
 
 // ListProjects アカウントのプロジェクト一覧を取得
-func (s *Server) ListProjects(ctx echo.Context, accountId api.AccountID) error {
+func (s *Server) ListProjects(ctx echo.Context, accountId api.AccountID, params api.ListProjectsParams) error {
 	reqCtx := ctx.Request().Context()
 
 	s.logger.Info(reqCtx, "Getting projects for account",
 		logger.F("account_id", accountId),
 	)
 
-	projects, err := s.projectUsecase.ListByAccountID(reqCtx, accountId)
+	projects, page, err := s.projectUsecase.ListByAccountID(reqCtx, accountId, usecase.ListProjectsInput{
+		Limit:  params.Limit,
+		Offset: params.Offset,
+		After:  params.After,
+		Before: params.Before,
+	})
 	if err != nil {
 		s.logger.Error(reqCtx, "Failed to get projects", err,
 			logger.F("account_id", accountId),
@@ -63,18 +68,93 @@ func (s *Server) ListProjects(ctx echo.Context, accountId api.AccountID) error {
 		apiProjects[i] = NewAPIProjectFromEntity(project)
 	}
 
-	return ctx.JSON(http.StatusOK, apiProjects)
+	limit, offset := usecase.DefaultPageSize, 0
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+
+	return ctx.JSON(http.StatusOK, api.ProjectList{
+		Items:      apiProjects,
+		Total:      page.Total,
+		Limit:      limit,
+		Offset:     offset,
+		NextCursor: optionalString(page.NextCursor),
+		PrevCursor: optionalString(page.PrevCursor),
+	})
+}
+
+// ListAllProjects 全アカウントのプロジェクト一覧を取得（管理者用）
+func (s *Server) ListAllProjects(ctx echo.Context, params api.ListAllProjectsParams) error {
+	reqCtx := ctx.Request().Context()
+
+	s.logger.Info(reqCtx, "Listing projects across accounts",
+		logger.F("account_id", params.AccountId),
+		logger.F("status", params.Status),
+	)
+
+	input := usecase.ListAllProjectsInput{
+		Limit:     params.Limit,
+		Offset:    params.Offset,
+		After:     params.After,
+		Before:    params.Before,
+		AccountID: params.AccountId,
+	}
+	if params.Status != nil {
+		status := string(*params.Status)
+		input.Status = &status
+	}
+
+	projects, page, err := s.projectUsecase.ListAll(reqCtx, input)
+	if err != nil {
+		s.logger.Error(reqCtx, "Failed to list projects", err)
+		return handleProjectError(ctx, err)
+	}
+
+	// エンティティからAPIレスポンスに変換
+	apiProjects := make([]api.Project, len(projects))
+	for i, project := range projects {
+		apiProjects[i] = NewAPIProjectFromEntity(project)
+	}
+
+	limit, offset := usecase.DefaultPageSize, 0
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+
+	return ctx.JSON(http.StatusOK, api.ProjectList{
+		Items:      apiProjects,
+		Total:      page.Total,
+		Limit:      limit,
+		Offset:     offset,
+		NextCursor: optionalString(page.NextCursor),
+		PrevCursor: optionalString(page.PrevCursor),
+	})
 }
 
 // CreateProject 新しいプロジェクトを作成
 func (s *Server) CreateProject(ctx echo.Context, accountId api.AccountID) error {
 	reqCtx := ctx.Request().Context()
 
+	actorID, err := accountIDFromContext(ctx)
+	if err != nil {
+		return middleware.RespondError(ctx, http.StatusUnauthorized, api.Error{
+			Error: "Unauthorized",
+			Code:  api.ErrorCodeUnauthorized,
+		})
+	}
+
 	var req api.CreateProjectRequest
 	if err := ctx.Bind(&req); err != nil {
 		s.logger.Warn(reqCtx, "Invalid request body", logger.F("error", err.Error()))
-		return ctx.JSON(http.StatusBadRequest, api.Error{
-			Error: "Invalid request body",
+		return middleware.RespondError(ctx, http.StatusBadRequest, api.Error{
+			Error: bindErrorMessage(err),
+			Code:  api.ErrorCodeInvalidRequest,
 		})
 	}
 
@@ -98,7 +178,7 @@ func (s *Server) CreateProject(ctx echo.Context, accountId api.AccountID) error
 		input.Status = &status
 	}
 
-	project, err := s.projectUsecase.Create(reqCtx, accountId, input)
+	project, err := s.projectUsecase.Create(reqCtx, accountId, actorID, input)
 	if err != nil {
 		s.logger.Error(reqCtx, "Failed to create project", err,
 			logger.F("account_id", accountId),
@@ -112,7 +192,8 @@ func (s *Server) CreateProject(ctx echo.Context, accountId api.AccountID) error
 	)
 
 	apiProject := NewAPIProjectFromEntity(project)
-	return ctx.JSON(http.StatusCreated, apiProject)
+	setLocation(ctx, projectLocation(project.AccountID, project.ID))
+	return jsonWithETag(ctx, http.StatusCreated, apiProject)
 }
 
 // GetProject IDでプロジェクトを取得
@@ -134,18 +215,27 @@ func (s *Server) GetProject(ctx echo.Context, accountId api.AccountID, projectId
 	}
 
 	apiProject := NewAPIProjectFromEntity(project)
-	return ctx.JSON(http.StatusOK, apiProject)
+	return conditionalJSON(ctx, apiProject)
 }
 
 // UpdateProject プロジェクトを更新
 func (s *Server) UpdateProject(ctx echo.Context, accountId api.AccountID, projectId api.ProjectID) error {
 	reqCtx := ctx.Request().Context()
 
+	actorID, err := accountIDFromContext(ctx)
+	if err != nil {
+		return middleware.RespondError(ctx, http.StatusUnauthorized, api.Error{
+			Error: "Unauthorized",
+			Code:  api.ErrorCodeUnauthorized,
+		})
+	}
+
 	var req api.UpdateProjectRequest
 	if err := ctx.Bind(&req); err != nil {
 		s.logger.Warn(reqCtx, "Invalid request body", logger.F("error", err.Error()))
-		return ctx.JSON(http.StatusBadRequest, api.Error{
-			Error: "Invalid request body",
+		return middleware.RespondError(ctx, http.StatusBadRequest, api.Error{
+			Error: bindErrorMessage(err),
+			Code:  api.ErrorCodeInvalidRequest,
 		})
 	}
 
@@ -164,7 +254,7 @@ func (s *Server) UpdateProject(ctx echo.Context, accountId api.AccountID, projec
 		input.Status = &status
 	}
 
-	project, err := s.projectUsecase.Update(reqCtx, accountId, projectId, input)
+	project, err := s.projectUsecase.Update(reqCtx, accountId, projectId, actorID, input)
 	if err != nil {
 		s.logger.Error(reqCtx, "Failed to update project", err,
 			logger.F("account_id", accountId),
@@ -179,7 +269,7 @@ func (s *Server) UpdateProject(ctx echo.Context, accountId api.AccountID, projec
 	)
 
 	apiProject := NewAPIProjectFromEntity(project)
-	return ctx.JSON(http.StatusOK, apiProject)
+	return jsonWithETag(ctx, http.StatusOK, apiProject)
 }
 
 // DeleteProject プロジェクトを削除
@@ -212,24 +302,17 @@ func (s *Server) DeleteProject(ctx echo.Context, accountId api.AccountID, projec
 func handleProjectError(ctx echo.Context, err error) error {
 	// エラーマッピングから適切なステータスコードを探す
 	if errors.Is(err, domain.ErrProjectNotFound) || errors.Is(err, domain.ErrAccountNotFound) {
-		return ctx.JSON(http.StatusNotFound, api.Error{
-			Error: err.Error(),
-		})
+		return middleware.RespondError(ctx, http.StatusNotFound, newAPIError(err))
 	}
-	if errors.Is(err, domain.ErrProjectLimitExceeded) {
-		return ctx.JSON(http.StatusConflict, api.Error{
-			Error: err.Error(),
-		})
+	if errors.Is(err, domain.ErrProjectLimitExceeded) || errors.Is(err, domain.ErrDuplicateProjectName) {
+		return middleware.RespondError(ctx, http.StatusConflict, newAPIError(err))
 	}
 	if errors.Is(err, domain.ErrInvalidAccountID) || errors.Is(err, domain.ErrInvalidStatus) ||
-		errors.Is(err, domain.ErrInvalidID) || errors.Is(err, domain.ErrInvalidName) {
-		return ctx.JSON(http.StatusBadRequest, api.Error{
-			Error: err.Error(),
-		})
+		errors.Is(err, domain.ErrInvalidID) || errors.Is(err, domain.ErrInvalidName) ||
+		errors.Is(err, domain.ErrInvalidDescription) || errors.Is(err, domain.ErrInvalidPagination) {
+		return middleware.RespondError(ctx, http.StatusBadRequest, newAPIError(err))
 	}
 
 	// デフォルトのエラーレスポンス
-	return ctx.JSON(http.StatusInternalServerError, api.Error{
-		Error: "Internal server error",
-	})
+	return newInternalError(err)
 }
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/labstack/echo/v4"
+)
+
+// unsupportedMediaTypeMessage 受け付けないContent-Typeに対するエラーメッセージ
+const unsupportedMediaTypeMessage = "Content-Type must be application/json or application/x-www-form-urlencoded"
+
+// bindRequestBody Content-Typeを検証してリクエストボディを読み込む
+// JSONとフォーム（application/x-www-form-urlencoded）を受け付け、それ以外は415を返す
+// フォームの各項目はJSONのフィールド名で対応付けるため、生成された型をそのまま使える（文字列項目のみ）
+func bindRequestBody(c echo.Context, dst interface{}) error {
+	mediaType, _, err := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	if err != nil {
+		return newHTTPError(http.StatusUnsupportedMediaType, api.ErrorCodeUnsupportedMediaType, unsupportedMediaTypeMessage)
+	}
+
+	switch mediaType {
+	case echo.MIMEApplicationJSON:
+		if err := json.NewDecoder(c.Request().Body).Decode(dst); err != nil {
+			return invalidRequestBodyError(err)
+		}
+		return nil
+	case echo.MIMEApplicationForm:
+		form, err := c.FormParams()
+		if err != nil {
+			return newHTTPError(http.StatusBadRequest, api.ErrorCodeInvalidRequest, "invalid request body")
+		}
+		values := make(map[string]string, len(form))
+		for key := range form {
+			values[key] = form.Get(key)
+		}
+		encoded, err := json.Marshal(values)
+		if err != nil {
+			return newHTTPError(http.StatusBadRequest, api.ErrorCodeInvalidRequest, "invalid request body")
+		}
+		if err := json.Unmarshal(encoded, dst); err != nil {
+			return newHTTPError(http.StatusBadRequest, api.ErrorCodeInvalidRequest, "invalid request body")
+		}
+		return nil
+	default:
+		return newHTTPError(http.StatusUnsupportedMediaType, api.ErrorCodeUnsupportedMediaType, unsupportedMediaTypeMessage)
+	}
+}
+
+// invalidRequestBodyError リクエストボディを読み込めなかった理由を含む400エラーを作成
+func invalidRequestBodyError(err error) *echo.HTTPError {
+	return newHTTPError(http.StatusBadRequest, api.ErrorCodeInvalidRequest, bindErrorMessage(err))
+}
+
+// bindErrorMessage リクエストボディの読み込みエラーを、APIの利用者が原因を特定できるメッセージに変換
+// JSONの構文エラーは位置を、型の不一致はフィールド名と期待する型を含める（c.Bindのエラーも内部のエラーから判定する）
+func bindErrorMessage(err error) string {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return fmt.Sprintf("malformed JSON at offset %d: %s", syntaxErr.Offset, syntaxErr.Error())
+	case errors.As(err, &typeErr):
+		if typeErr.Field == "" {
+			return fmt.Sprintf("request body must be a JSON %s, got %s", jsonTypeName(typeErr.Type), typeErr.Value)
+		}
+		return fmt.Sprintf("invalid value for field %q at offset %d: expected %s, got %s",
+			typeErr.Field, typeErr.Offset, jsonTypeName(typeErr.Type), typeErr.Value)
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return "malformed JSON: unexpected end of input"
+	case errors.Is(err, io.EOF):
+		return "request body is empty"
+	default:
+		return "invalid request body"
+	}
+}
+
+// textUnmarshalerType encoding.TextUnmarshalerの型（UUIDなど文字列で表す型の判定用）
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// jsonTypeName Goの型に対応するJSONの型名を返す
+func jsonTypeName(t reflect.Type) string {
+	if t == nil {
+		return "value"
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return "string"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return t.String()
+	}
+}
@@ -0,0 +1,45 @@
+package handler
+
+import "time"
+
+// レスポンスの任意項目の方針
+//
+// 値が無い任意項目は null を返さず、JSONから省略する。
+// 生成されたDTOの任意項目はポインタ＋omitemptyのため、未設定（nil）はキーごと省略される。
+// 空文字もnilに揃えることで、データの保存形式（NULLか空文字か）に関わらず同じ形のオブジェクトを返す。
+// クライアントは「キーが無い＝値が無い」として扱えばよい。
+//
+// タイムスタンプの方針
+//
+// 日時はサーバーのタイムゾーンに関わらずUTCに変換して返す。
+// JSONではRFC 3339形式になり、UTCのため常に"Z"で終わる（例: 2024-01-02T03:04:05Z）。
+
+// optionalString 空文字の場合はnilを返す（レスポンスから省略される）
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// optionalStringPtr nilまたは空文字の場合はnilを返す（レスポンスから省略される）
+func optionalStringPtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	return optionalString(*s)
+}
+
+// utcTime 日時をUTCに変換する
+func utcTime(t time.Time) time.Time {
+	return t.UTC()
+}
+
+// utcTimePtr 日時をUTCに変換する（nilの場合はnilを返す）
+func utcTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/go-sql-driver/mysql"
+)
+
+// IsConnectionError エラーがデータベースとの接続の問題（切断、接続の拒否、ネットワークエラー）によるものか判定
+// クエリの誤りや制約違反と異なり、時間をおいて再試行すれば成功し得る
+// コンテキストの期限切れ・キャンセルとクエリのタイムアウトは、net.Errorを実装していても接続の問題として扱わない
+func IsConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) || errors.Is(err, ErrQueryTimeout) {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, mysql.ErrInvalidConn) || errors.Is(err, sql.ErrConnDone) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// wrapConnectionError 接続の問題によるエラーをdomain.ErrServiceUnavailableでラップ
+// ハンドラーはドライバーのエラーの詳細を返さず、503として再試行を促せる
+func wrapConnectionError(err error) error {
+	if !IsConnectionError(err) || errors.Is(err, domain.ErrServiceUnavailable) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", domain.ErrServiceUnavailable, err)
+}
+
+// connectionErrorExecutor 接続の問題によるエラーをdomain.ErrServiceUnavailableに変換するExecutor
+type connectionErrorExecutor struct {
+	exec Executor
+}
+
+// ExecContext クエリを実行
+func (e *connectionErrorExecutor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	result, err := e.exec.ExecContext(ctx, query, args...)
+	return result, wrapConnectionError(err)
+}
+
+// GetContext 1行を取得
+func (e *connectionErrorExecutor) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return wrapConnectionError(e.exec.GetContext(ctx, dest, query, args...))
+}
+
+// SelectContext 複数行を取得
+func (e *connectionErrorExecutor) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return wrapConnectionError(e.exec.SelectContext(ctx, dest, query, args...))
+}
+
+// NamedExecContext 名前付きクエリを実行
+func (e *connectionErrorExecutor) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	result, err := e.exec.NamedExecContext(ctx, query, arg)
+	return result, wrapConnectionError(err)
+}
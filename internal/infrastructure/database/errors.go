@@ -0,0 +1,90 @@
+package database
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const (
+	// mysqlErrDuplicateEntry MySQLの一意制約違反のエラー番号
+	mysqlErrDuplicateEntry = 1062
+	// sqlStateUniqueViolation PostgreSQLの一意制約違反のSQLSTATE
+	sqlStateUniqueViolation = "23505"
+	// sqlStateClassDataException PostgreSQLのデータ例外のSQLSTATEクラス
+	sqlStateClassDataException = "22"
+	// sqlStateClassIntegrityViolation PostgreSQLの整合性制約違反のSQLSTATEクラス
+	sqlStateClassIntegrityViolation = "23"
+)
+
+// mysqlPermanentErrors 再試行しても成功しないMySQLのエラー番号（外部キー・NOT NULL制約違反とデータの誤り）
+var mysqlPermanentErrors = map[uint16]bool{
+	1048: true, // ER_BAD_NULL_ERROR
+	1216: true, // ER_NO_REFERENCED_ROW
+	1217: true, // ER_ROW_IS_REFERENCED
+	1264: true, // ER_WARN_DATA_OUT_OF_RANGE
+	1292: true, // ER_TRUNCATED_WRONG_VALUE
+	1366: true, // ER_TRUNCATED_WRONG_VALUE_FOR_FIELD
+	1406: true, // ER_DATA_TOO_LONG
+	1451: true, // ER_ROW_IS_REFERENCED_2
+	1452: true, // ER_NO_REFERENCED_ROW_2
+	3140: true, // ER_INVALID_JSON_TEXT
+}
+
+// sqlStater SQLSTATEを返すドライバーエラー（pgx, lib/pq など）
+type sqlStater interface {
+	SQLState() string
+}
+
+// IsUniqueViolation エラーが一意制約違反かどうかを判定
+// 事前の存在チェックだけでは並行リクエストで重複を防げないため、
+// 一意インデックスを最終的なガードとしてこのエラーをドメインエラーに変換する
+func IsUniqueViolation(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlErrDuplicateEntry
+	}
+
+	var stater sqlStater
+	if errors.As(err, &stater) {
+		return stater.SQLState() == sqlStateUniqueViolation
+	}
+
+	return false
+}
+
+// IsUniqueViolationOn エラーが指定したインデックス（制約）の一意制約違反かどうかを判定
+// 複数の一意インデックスを持つテーブルで、どの値が重複したかを区別するために使用する
+// （MySQLはエラーメッセージに "for key 'accounts.uq_accounts_email'" のようにインデックス名を含む）
+func IsUniqueViolationOn(err error, index string) bool {
+	return IsUniqueViolation(err) && strings.Contains(err.Error(), index)
+}
+
+// IsPermanentError エラーが制約違反やデータの誤りなど、同じ値で再試行しても成功しないものかどうかを判定
+// 一意制約違反は書き込み済みの可能性があるため呼び出し元で個別に扱い、ここでは含めない
+func IsPermanentError(err error) bool {
+	if err == nil || IsUniqueViolation(err) {
+		return false
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlPermanentErrors[mysqlErr.Number]
+	}
+
+	var stater sqlStater
+	if errors.As(err, &stater) {
+		class := stater.SQLState()
+		if len(class) >= 2 {
+			class = class[:2]
+		}
+		return class == sqlStateClassDataException || class == sqlStateClassIntegrityViolation
+	}
+
+	return false
+}
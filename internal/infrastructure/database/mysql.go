@@ -22,12 +22,15 @@ func NewMySQLConnection(cfg *Config) (*sqlx.DB, error) {
 	// デフォルト値
 	charset := "utf8mb4"
 	parseTime := true
-	loc := "Local"
+	// タイムスタンプはUTCで保存する（サーバーのタイムゾーンで値が変わらないよう、
+	// ドライバーの変換とMySQLのセッションのタイムゾーンの両方をUTCに揃える）
+	loc := "UTC"
+	timeZone := "%27%2B00%3A00%27" // '+00:00'
 	maxOpen := 25
 	maxIdle := 25
 	lifetime := 5 * time.Minute
 
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=%s&parseTime=%t&loc=%s",
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=%s&parseTime=%t&loc=%s&time_zone=%s",
 		cfg.User,
 		cfg.Password,
 		cfg.Host,
@@ -36,6 +39,7 @@ func NewMySQLConnection(cfg *Config) (*sqlx.DB, error) {
 		charset,
 		parseTime,
 		loc,
+		timeZone,
 	)
 
 	db, err := sqlx.Connect("mysql", dsn)
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// ErrQueryTimeout クエリがタイムアウトした場合のエラー
+var ErrQueryTimeout = domain.ErrQueryTimeout
+
+// DB クエリのタイムアウト設定を保持するデータベース接続
+// リポジトリはこの値を経由してExecutorを取得する
+type DB struct {
+	*sqlx.DB
+	queryTimeout time.Duration
+}
+
+// NewDB 1クエリあたりのタイムアウトを指定してDBを作成（0で無効）
+func NewDB(db *sqlx.DB, queryTimeout time.Duration) *DB {
+	return &DB{
+		DB:           db,
+		queryTimeout: queryTimeout,
+	}
+}
+
+// QueryTimeout 1クエリあたりのタイムアウトを返す
+func (db *DB) QueryTimeout() time.Duration {
+	return db.queryTimeout
+}
+
+// timeoutExecutor クエリごとにタイムアウトを設定するExecutor
+// 遅いクエリで接続が占有され、コネクションプールが枯渇することを防ぐ
+type timeoutExecutor struct {
+	exec    Executor
+	timeout time.Duration
+}
+
+// ExecContext タイムアウト付きでクエリを実行
+func (e *timeoutExecutor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+	result, err := e.exec.ExecContext(queryCtx, query, args...)
+	return result, e.wrapError(ctx, queryCtx, err)
+}
+
+// GetContext タイムアウト付きで1行を取得
+func (e *timeoutExecutor) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	queryCtx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+	return e.wrapError(ctx, queryCtx, e.exec.GetContext(queryCtx, dest, query, args...))
+}
+
+// SelectContext タイムアウト付きで複数行を取得
+func (e *timeoutExecutor) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	queryCtx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+	return e.wrapError(ctx, queryCtx, e.exec.SelectContext(queryCtx, dest, query, args...))
+}
+
+// NamedExecContext タイムアウト付きで名前付きクエリを実行
+func (e *timeoutExecutor) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+	result, err := e.exec.NamedExecContext(queryCtx, query, arg)
+	return result, e.wrapError(ctx, queryCtx, err)
+}
+
+// wrapError クエリのタイムアウトによるエラーをErrQueryTimeoutでラップ
+// 呼び出し元のコンテキストが先に終了した場合（リクエストのキャンセルなど）はそのまま返す
+func (e *timeoutExecutor) wrapError(ctx, queryCtx context.Context, err error) error {
+	if err == nil || ctx.Err() != nil || !errors.Is(queryCtx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	return fmt.Errorf("%w after %s: %w", ErrQueryTimeout, e.timeout, err)
+}
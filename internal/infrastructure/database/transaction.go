@@ -56,7 +56,7 @@ func (tm *txManager) RunInTransaction(ctx context.Context, fn func(ctx context.C
 	// 新しいトランザクションを開始
 	tx, err := tm.db.BeginTxx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w", wrapConnectionError(err))
 	}
 
 	// トランザクションをコンテキストに保存
@@ -80,7 +80,7 @@ func (tm *txManager) RunInTransaction(ctx context.Context, fn func(ctx context.C
 
 	// コミット
 	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return fmt.Errorf("failed to commit transaction: %w", wrapConnectionError(err))
 	}
 
 	return nil
@@ -99,11 +99,17 @@ func GetTx(ctx context.Context) (*sqlx.Tx, bool) {
 
 // GetExecutor コンテキストから適切なExecutorを取得
 // トランザクションがあればそれを、なければDBを返す
-func GetExecutor(ctx context.Context, db *sqlx.DB) Executor {
+// クエリのタイムアウトが設定されている場合は、クエリごとにタイムアウトを適用する
+// 接続の問題によるエラーはdomain.ErrServiceUnavailableでラップして返す
+func GetExecutor(ctx context.Context, db *DB) Executor {
+	var exec Executor = db.DB
 	if tx, ok := GetTx(ctx); ok {
-		return tx
+		exec = tx
 	}
-	return db
+	if timeout := db.QueryTimeout(); timeout > 0 {
+		exec = &timeoutExecutor{exec: exec, timeout: timeout}
+	}
+	return &connectionErrorExecutor{exec: exec}
 }
 
 // TxOptions トランザクションのオプション
@@ -9,7 +9,10 @@ import (
 	"os"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
 )
 
 // Logger ロギングのインターフェース
@@ -80,22 +83,61 @@ func ParseLevel(s string) Level {
 	}
 }
 
+// stackKey スタックトレースを出力するフィールドのキー
+const stackKey = "stack"
+
+// stackRequest WithStackで指定されたスタックトレース取得の目印
+type stackRequest struct{}
+
+// WithStack Error/Fatalの呼び出し元のスタックトレースをログに含めるフィールド
+func WithStack() Field {
+	return Field{Key: stackKey, Value: stackRequest{}}
+}
+
+// Option ロガーの生成オプション
+type Option func(*logger)
+
+// WithCallerSkip 呼び出し元として報告するスタックの深さを追加でスキップする
+// ロガーをラップするヘルパー関数から使う場合に、ヘルパーの段数を指定する
+func WithCallerSkip(skip int) Option {
+	return func(l *logger) {
+		l.callerSkip = skip
+	}
+}
+
 // logger Loggerインターフェースの実装
 type logger struct {
-	level  Level
-	format string
-	output io.Writer
-	fields []Field
+	level      Level
+	format     string
+	output     io.Writer
+	writeMu    *sync.Mutex // With で派生したロガー間で共有する書き込みロック
+	callerSkip int
+	fields     []Field
+
+	// 機密情報のマスク設定
+	redactKeys  map[string]struct{}
+	privacyMode bool
 }
 
-// NewLogger 新しいロガーを作成
-func NewLogger(level, format string) Logger {
-	return &logger{
-		level:  ParseLevel(level),
-		format: format,
-		output: os.Stdout,
-		fields: []Field{},
+// NewLogger 標準出力に書き込む新しいロガーを作成
+func NewLogger(level, format string, opts ...Option) Logger {
+	return NewLoggerWithOutput(level, format, os.Stdout, opts...)
+}
+
+// NewLoggerWithOutput 出力先を指定して新しいロガーを作成
+func NewLoggerWithOutput(level, format string, output io.Writer, opts ...Option) Logger {
+	l := &logger{
+		level:      ParseLevel(level),
+		format:     format,
+		output:     output,
+		writeMu:    &sync.Mutex{},
+		fields:     []Field{},
+		redactKeys: newKeySet(DefaultRedactKeys),
 	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
 }
 
 // With フィールドを追加した新しいロガーを返す
@@ -105,10 +147,14 @@ func (l *logger) With(fields ...Field) Logger {
 	copy(newFields[len(l.fields):], fields)
 
 	return &logger{
-		level:  l.level,
-		format: l.format,
-		output: l.output,
-		fields: newFields,
+		level:       l.level,
+		format:      l.format,
+		output:      l.output,
+		writeMu:     l.writeMu,
+		callerSkip:  l.callerSkip,
+		fields:      newFields,
+		redactKeys:  l.redactKeys,
+		privacyMode: l.privacyMode,
 	}
 }
 
@@ -148,8 +194,8 @@ func (l *logger) Fatal(ctx context.Context, msg string, err error, fields ...Fie
 
 // log ログを出力する内部メソッド
 func (l *logger) log(ctx context.Context, level Level, msg string, err error, fields ...Field) {
-	// 呼び出し元の情報を取得
-	_, file, line, _ := runtime.Caller(2)
+	// 呼び出し元の情報を取得（log → Debug/Info等 → 呼び出し元）
+	_, file, line, _ := runtime.Caller(callerDepth + l.callerSkip)
 
 	// ファイル名を短縮
 	parts := strings.Split(file, "/")
@@ -162,8 +208,19 @@ func (l *logger) log(ctx context.Context, level Level, msg string, err error, fi
 
 	// すべてのフィールドを結合
 	allFields := make([]Field, 0, len(l.fields)+len(fields)+6)
-	allFields = append(allFields, l.fields...)
-	allFields = append(allFields, fields...)
+	for _, field := range l.fields {
+		allFields = append(allFields, l.redact(field))
+	}
+	for _, field := range fields {
+		// WithStackはError/Fatalのみで有効
+		if _, ok := field.Value.(stackRequest); ok {
+			if level >= ErrorLevel {
+				allFields = append(allFields, F(stackKey, captureStack(callerDepth+l.callerSkip)))
+			}
+			continue
+		}
+		allFields = append(allFields, l.redact(field))
+	}
 	allFields = append(allFields,
 		F("timestamp", timestamp),
 		F("level", level.String()),
@@ -176,6 +233,10 @@ func (l *logger) log(ctx context.Context, level Level, msg string, err error, fi
 	if requestID := getRequestID(ctx); requestID != "" {
 		allFields = append(allFields, F("request_id", requestID))
 	}
+	// トレースIDがあれば追加（監査ログのtrace_idと同じ値）
+	if correlation, ok := domain.CorrelationFromContext(ctx); ok && correlation.TraceID != "" {
+		allFields = append(allFields, F("trace_id", correlation.TraceID))
+	}
 
 	// エラーがあれば追加
 	if err != nil {
@@ -183,15 +244,82 @@ func (l *logger) log(ctx context.Context, level Level, msg string, err error, fi
 	}
 
 	// フォーマットに応じて出力
+	var entry string
 	if l.format == "json" {
-		l.outputJSON(allFields)
+		entry = l.formatJSON(allFields)
 	} else {
-		l.outputText(allFields)
+		entry = l.formatText(allFields)
+	}
+	if entry == "" {
+		return
+	}
+
+	// Fatalは直後に終了するため必ず書き込みを完了させる
+	if level == FatalLevel {
+		l.writeEntry(entry)
+		return
 	}
+	l.write(ctx, entry)
 }
 
-// outputJSON JSON形式でログを出力
-func (l *logger) outputJSON(fields []Field) {
+// callerDepth log から見た呼び出し元までのスタックの深さ
+const callerDepth = 2
+
+// captureStack 指定した深さ以降のスタックトレースを文字列で返す
+func captureStack(skip int) string {
+	pcs := make([]uintptr, 32)
+	// runtime.Callers自身とcaptureStackの分を加算
+	n := runtime.Callers(skip+2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var sb strings.Builder
+	for {
+		frame, more := frames.Next()
+		sb.WriteString(fmt.Sprintf("%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line))
+		if !more {
+			break
+		}
+	}
+	return sb.String()
+}
+
+// write ログを出力先に書き込む
+// コンテキストにデッドラインがある場合は、書き込みがブロックしてもデッドラインで呼び出し元に戻る
+func (l *logger) write(ctx context.Context, entry string) {
+	if ctx == nil {
+		l.writeEntry(entry)
+		return
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		l.writeEntry(entry)
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		l.writeEntry(entry)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		// 書き込みはバックグラウンドで継続される
+	}
+}
+
+// writeEntry ロックを取得して1行書き込む
+func (l *logger) writeEntry(entry string) {
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+
+	if _, err := fmt.Fprintln(l.output, entry); err != nil {
+		log.Printf("Failed to write log entry: %v", err)
+	}
+}
+
+// formatJSON JSON形式のログ行を作成
+func (l *logger) formatJSON(fields []Field) string {
 	logEntry := make(map[string]interface{})
 	for _, field := range fields {
 		logEntry[field.Key] = field.Value
@@ -200,18 +328,14 @@ func (l *logger) outputJSON(fields []Field) {
 	data, err := json.Marshal(logEntry)
 	if err != nil {
 		log.Printf("Failed to marshal log entry: %v", err)
-		return
+		return ""
 	}
 
-	_, err = fmt.Fprintln(l.output, string(data))
-	if err != nil {
-		log.Printf("Failed to write log entry: %v", err)
-		return
-	}
+	return string(data)
 }
 
-// outputText テキスト形式でログを出力
-func (l *logger) outputText(fields []Field) {
+// formatText テキスト形式のログ行を作成
+func (l *logger) formatText(fields []Field) string {
 	var sb strings.Builder
 
 	// 基本情報を先に出力
@@ -242,11 +366,7 @@ func (l *logger) outputText(fields []Field) {
 		sb.WriteString(strings.Join(extras, " "))
 	}
 
-	_, err := fmt.Fprintln(l.output, sb.String())
-	if err != nil {
-		log.Printf("Failed to write log entry: %v", err)
-		return
-	}
+	return sb.String()
 }
 
 // getRequestID コンテキストからリクエストIDを取得
@@ -264,5 +384,10 @@ func getRequestID(ctx context.Context) string {
 		return reqID
 	}
 
+	// Correlationミドルウェアが設定した相関ID
+	if correlation, ok := domain.CorrelationFromContext(ctx); ok {
+		return correlation.RequestID
+	}
+
 	return ""
 }
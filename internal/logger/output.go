@@ -0,0 +1,252 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// OutputStdout 標準出力へ出力
+	OutputStdout = "stdout"
+	// OutputStderr 標準エラー出力へ出力
+	OutputStderr = "stderr"
+
+	// backupTimeFormat ローテーション済みファイル名に付与するタイムスタンプの形式
+	backupTimeFormat = "20060102T150405.000"
+)
+
+// OutputConfig ログ出力先の設定
+type OutputConfig struct {
+	// Destination stdout、stderr、またはファイルパス
+	Destination string
+	// MaxSize ファイル出力時のローテーションサイズ（バイト、0の場合はローテーションしない）
+	MaxSize int64
+	// MaxAge ローテーション済みファイルの保持期間（0の場合は期間で削除しない）
+	MaxAge time.Duration
+	// MaxBackups ローテーション済みファイルの保持数（0の場合は数で削除しない）
+	MaxBackups int
+}
+
+// OpenOutput 設定に応じたログ出力先を開く
+func OpenOutput(config OutputConfig) (io.WriteCloser, error) {
+	switch strings.ToLower(config.Destination) {
+	case "", OutputStdout:
+		return nopCloser{os.Stdout}, nil
+	case OutputStderr:
+		return nopCloser{os.Stderr}, nil
+	default:
+		return NewRotatingFile(config)
+	}
+}
+
+// nopCloser 標準出力などクローズしない出力先のラッパー
+type nopCloser struct {
+	io.Writer
+}
+
+// Close 何もしない
+func (nopCloser) Close() error {
+	return nil
+}
+
+// RotatingFile サイズと保持期間でローテーションするファイル出力
+type RotatingFile struct {
+	mu     sync.Mutex
+	config OutputConfig
+	file   *os.File
+	size   int64
+	now    func() time.Time
+}
+
+// NewRotatingFile 新しいローテーションファイル出力を作成
+func NewRotatingFile(config OutputConfig) (*RotatingFile, error) {
+	if config.Destination == "" {
+		return nil, fmt.Errorf("log file path is required")
+	}
+
+	r := &RotatingFile{
+		config: config,
+		now:    time.Now,
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Write ログを書き込み、上限サイズを超える場合は事前にローテーションする
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if r.config.MaxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.config.MaxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Rotate 現在のファイルを退避して新しいファイルに切り替える
+func (r *RotatingFile) Rotate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rotate()
+}
+
+// Close ファイルを閉じる
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// open ログファイルを追記モードで開く
+func (r *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(r.config.Destination), 0o755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(r.config.Destination, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+// rotate ロック取得済みの状態でローテーションを行う
+func (r *RotatingFile) rotate() error {
+	if r.file != nil {
+		if err := r.file.Close(); err != nil {
+			return fmt.Errorf("failed to close log file: %w", err)
+		}
+		r.file = nil
+	}
+
+	// 同一ミリ秒内に複数回ローテーションした場合も上書きしないよう時刻をずらす
+	backupTime := r.now()
+	for {
+		if _, err := os.Stat(r.backupName(backupTime)); os.IsNotExist(err) {
+			break
+		}
+		backupTime = backupTime.Add(time.Millisecond)
+	}
+
+	if err := os.Rename(r.config.Destination, r.backupName(backupTime)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+
+	if err := r.open(); err != nil {
+		return err
+	}
+
+	r.removeOldBackups()
+	return nil
+}
+
+// backupName ローテーション済みファイルの名前を返す（例: app-20250101T000000.000.log）
+func (r *RotatingFile) backupName(t time.Time) string {
+	dir := filepath.Dir(r.config.Destination)
+	ext := filepath.Ext(r.config.Destination)
+	prefix := strings.TrimSuffix(filepath.Base(r.config.Destination), ext)
+	return filepath.Join(dir, fmt.Sprintf("%s-%s%s", prefix, t.Format(backupTimeFormat), ext))
+}
+
+// removeOldBackups 保持数・保持期間を超えたローテーション済みファイルを削除
+func (r *RotatingFile) removeOldBackups() {
+	if r.config.MaxBackups <= 0 && r.config.MaxAge <= 0 {
+		return
+	}
+
+	backups, err := r.backups()
+	if err != nil {
+		return
+	}
+
+	cutoff := r.now().Add(-r.config.MaxAge)
+	for i, backup := range backups {
+		expired := r.config.MaxAge > 0 && backup.timestamp.Before(cutoff)
+		overflow := r.config.MaxBackups > 0 && i >= r.config.MaxBackups
+		if expired || overflow {
+			_ = os.Remove(backup.path)
+		}
+	}
+}
+
+// backupFile ローテーション済みファイルの情報
+type backupFile struct {
+	path      string
+	timestamp time.Time
+}
+
+// Backups ローテーション済みファイルのパスを新しい順に返す
+func (r *RotatingFile) Backups() ([]string, error) {
+	backups, err := r.backups()
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, len(backups))
+	for i, backup := range backups {
+		paths[i] = backup.path
+	}
+	return paths, nil
+}
+
+// backups ローテーション済みファイルを新しい順に返す
+func (r *RotatingFile) backups() ([]backupFile, error) {
+	dir := filepath.Dir(r.config.Destination)
+	ext := filepath.Ext(r.config.Destination)
+	prefix := strings.TrimSuffix(filepath.Base(r.config.Destination), ext) + "-"
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []backupFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		ts, err := time.ParseInLocation(backupTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext), time.Local)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backupFile{path: filepath.Join(dir, name), timestamp: ts})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].timestamp.After(backups[j].timestamp)
+	})
+	return backups, nil
+}
@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// redactedValue マスクされた値の表示
+const redactedValue = "***"
+
+// DefaultRedactKeys デフォルトでマスクするフィールドのキー
+var DefaultRedactKeys = []string{
+	"password",
+	"token",
+	"access_token",
+	"refresh_token",
+	"authorization",
+}
+
+// emailKeys プライバシーモードでハッシュ化するフィールドのキー
+var emailKeys = map[string]struct{}{
+	"email": {},
+}
+
+// WithRedactKeys マスクするフィールドのキーを設定する（大文字小文字は区別しない）
+func WithRedactKeys(keys ...string) Option {
+	return func(l *logger) {
+		l.redactKeys = newKeySet(keys)
+	}
+}
+
+// WithPrivacyMode 有効にするとメールアドレスをハッシュ化して出力する
+func WithPrivacyMode(enabled bool) Option {
+	return func(l *logger) {
+		l.privacyMode = enabled
+	}
+}
+
+// newKeySet キーの集合を作成
+func newKeySet(keys []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key != "" {
+			set[key] = struct{}{}
+		}
+	}
+	return set
+}
+
+// redact 機密情報を含むフィールドの値をマスクする
+func (l *logger) redact(field Field) Field {
+	key := strings.ToLower(field.Key)
+	if _, ok := l.redactKeys[key]; ok {
+		return F(field.Key, redactedValue)
+	}
+	if _, ok := emailKeys[key]; ok && l.privacyMode {
+		return F(field.Key, hashValue(fmt.Sprint(field.Value)))
+	}
+	return field
+}
+
+// hashValue 値を突き合わせ可能な形でハッシュ化する
+func hashValue(value string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(value)))
+	return "sha256:" + hex.EncodeToString(sum[:8])
+}
@@ -15,6 +15,12 @@ import (
 type AuthConfig struct {
 	JWTManager  *auth.JWTManager
 	PublicPaths []string
+
+	// OptionalPaths 認証を必須としないパス（有効なアクセストークンがある場合のみ認証済みとして扱う）
+	OptionalPaths []string
+
+	// IDs トークンのアカウントIDの検証（ゼロ値の場合はv4とv7の両方を受け付ける）
+	IDs domain.IDConfig
 }
 
 // contextKey コンテキストキーの型です
@@ -25,6 +31,10 @@ const (
 	AccountIDKey contextKey = "account_id"
 	// EmailKey コンテキストからメールアドレスを取得するためのキー
 	EmailKey contextKey = "email"
+	// RoleKey コンテキストからロールを取得するためのキー
+	RoleKey contextKey = "role"
+	// TenantIDKey コンテキストからテナントIDを取得するためのキー
+	TenantIDKey contextKey = "tenant_id"
 )
 
 // NewAuthMiddleware 認証ミドルウェアを作成
@@ -39,6 +49,20 @@ func NewAuthMiddleware(config AuthConfig) echo.MiddlewareFunc {
 				}
 			}
 
+			// 認証が任意のパスは、トークンが無いまたは無効な場合も未認証のまま処理を続ける
+			for _, optionalPath := range config.OptionalPaths {
+				if isPublicPath(path, optionalPath) {
+					if tokenString, ok := bearerToken(c.Request().Header.Get("Authorization")); ok {
+						if claims, err := config.JWTManager.ValidateAccessToken(tokenString); err == nil {
+							if _, err := config.IDs.ParseAccountID(claims.AccountID); err == nil {
+								setClaims(c, claims)
+							}
+						}
+					}
+					return next(c)
+				}
+			}
+
 			// Authorizationヘッダーからトークンを取得
 			authHeader := c.Request().Header.Get("Authorization")
 			if authHeader == "" {
@@ -46,15 +70,11 @@ func NewAuthMiddleware(config AuthConfig) echo.MiddlewareFunc {
 			}
 
 			// Bearer トークンの形式をチェック
-			tokenParts := strings.Split(authHeader, " ")
-			if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
+			tokenString, ok := bearerToken(authHeader)
+			if !ok {
 				return echo.NewHTTPError(http.StatusUnauthorized, "invalid authorization header format")
 			}
 
-			// fmt.Println(tokenParts[1])
-
-			tokenString := tokenParts[1]
-
 			// トークンを検証
 			claims, err := config.JWTManager.ValidateAccessToken(tokenString)
 			if err != nil {
@@ -74,15 +94,50 @@ func NewAuthMiddleware(config AuthConfig) echo.MiddlewareFunc {
 				return echo.NewHTTPError(http.StatusUnauthorized, errorMsg)
 			}
 
-			// アカウントIDとメールを共通で使えるようにコンテキストへ設定
-			c.Set(string(AccountIDKey), claims.AccountID)
-			c.Set(string(EmailKey), claims.Email)
+			// 厳格モードでは設定したバージョン以外のアカウントIDを持つトークンを拒否する
+			if _, err := config.IDs.ParseAccountID(claims.AccountID); err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "invalid token: invalid account id")
+			}
+
+			setClaims(c, claims)
 
 			return next(c)
 		}
 	}
 }
 
+// bearerToken Authorizationヘッダーの値からBearerトークンを取り出す
+func bearerToken(authHeader string) (string, bool) {
+	tokenParts := strings.Split(authHeader, " ")
+	if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
+		return "", false
+	}
+	return tokenParts[1], true
+}
+
+// setClaims 検証済みのクレームをコンテキストに設定
+func setClaims(c echo.Context, claims *auth.Claims) {
+	// アカウントIDとメールを共通で使えるようにコンテキストへ設定
+	c.Set(string(AccountIDKey), claims.AccountID)
+	c.Set(string(EmailKey), claims.Email)
+	c.Set(string(RoleKey), claims.Role)
+
+	// テナント導入前に発行されたトークンはdefaultテナントとして扱う
+	tenantID := claims.TenantID
+	if tenantID == "" {
+		tenantID = domain.DefaultTenantID
+	}
+	SetTenantID(c, tenantID)
+}
+
+// SetTenantID テナントIDをEchoのコンテキストとリクエストのコンテキストに設定
+// ユースケースとリポジトリはリクエストのコンテキストのテナントでデータを絞り込む
+func SetTenantID(c echo.Context, tenantID string) {
+	c.Set(string(TenantIDKey), tenantID)
+	req := c.Request()
+	c.SetRequest(req.WithContext(domain.WithTenantID(req.Context(), tenantID)))
+}
+
 // isPublicPath パスが公開パスかどうかをチェック
 func isPublicPath(path, publicPath string) bool {
 	if path == publicPath {
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// CompressionConfig レスポンス圧縮ミドルウェアの設定
+type CompressionConfig struct {
+	// Level gzipの圧縮レベル（-1で既定、1〜9）
+	Level int
+	// MinLength 圧縮するレスポンスボディの最小バイト数（これより小さいレスポンスは圧縮しない）
+	MinLength int
+	// SkipContentTypes 圧縮しないContent-Type（"image/"のように/で終わる場合は前方一致）
+	SkipContentTypes []string
+}
+
+// NewCompressionMiddleware gzipでレスポンスを圧縮するミドルウェアを作成
+// 小さなレスポンスや圧縮済みの形式（画像など）を圧縮してもサイズはほとんど減らずCPUを消費するだけのため、
+// MinLength未満のボディ、SkipContentTypesに一致するレスポンス、Content-Encoding設定済みのレスポンスはそのまま返す
+func NewCompressionMiddleware(config CompressionConfig) echo.MiddlewareFunc {
+	gzip := middleware.GzipWithConfig(middleware.GzipConfig{
+		Level:     config.Level,
+		MinLength: config.MinLength,
+	})
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			raw := c.Response().Writer
+			return gzip(func(c echo.Context) error {
+				// Accept-Encodingにgzipを含む場合のみ圧縮用のWriterに差し替えられる
+				if res := c.Response(); res.Writer != raw {
+					res.Writer = &selectiveCompressWriter{
+						ResponseWriter: res.Writer,
+						raw:            raw,
+						skip:           config.SkipContentTypes,
+					}
+				}
+				return next(c)
+			})(c)
+		}
+	}
+}
+
+// selectiveCompressWriter ヘッダーを書き込む時点のContent-Typeで圧縮するかどうかを決める
+// 圧縮しない場合は圧縮用のWriterを経由せずに元のWriterへ書き込む
+type selectiveCompressWriter struct {
+	http.ResponseWriter                     // 圧縮用のWriter
+	raw                 http.ResponseWriter // 圧縮しない場合の書き込み先
+	skip                []string
+	target              http.ResponseWriter
+}
+
+func (w *selectiveCompressWriter) writer() http.ResponseWriter {
+	if w.target == nil {
+		w.target = w.ResponseWriter
+		if w.Header().Get(echo.HeaderContentEncoding) != "" || skipCompression(w.Header().Get(echo.HeaderContentType), w.skip) {
+			w.target = w.raw
+		}
+	}
+	return w.target
+}
+
+func (w *selectiveCompressWriter) WriteHeader(code int) {
+	w.writer().WriteHeader(code)
+}
+
+func (w *selectiveCompressWriter) Write(b []byte) (int, error) {
+	return w.writer().Write(b)
+}
+
+func (w *selectiveCompressWriter) Flush() {
+	_ = http.NewResponseController(w.writer()).Flush()
+}
+
+func (w *selectiveCompressWriter) Unwrap() http.ResponseWriter {
+	return w.writer()
+}
+
+// skipCompression Content-Typeが圧縮の対象外かどうかを返す
+func skipCompression(contentType string, skip []string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, s := range skip {
+		s = strings.ToLower(s)
+		if mediaType == s || (strings.HasSuffix(s, "/") && strings.HasPrefix(mediaType, s)) {
+			return true
+		}
+	}
+	return false
+}
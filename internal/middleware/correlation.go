@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderTraceparent W3C Trace Contextでトレースを伝搬するヘッダー
+const HeaderTraceparent = "traceparent"
+
+// Correlation リクエストIDとトレースIDをリクエストのコンテキストに設定するミドルウェア
+// 監査ログのメタデータとログの行に同じIDを記録し、リクエストのトレースから辿れるようにする
+// RequestIDミドルウェアの後に登録すること（レスポンスのX-Request-IDを使用する）
+func Correlation(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		correlation := domain.Correlation{
+			RequestID: c.Response().Header().Get(echo.HeaderXRequestID),
+		}
+		if correlation.RequestID == "" {
+			correlation.RequestID = c.Request().Header.Get(echo.HeaderXRequestID)
+		}
+		correlation.TraceID, correlation.SpanID = parseTraceparent(c.Request().Header.Get(HeaderTraceparent))
+
+		req := c.Request()
+		c.SetRequest(req.WithContext(domain.WithCorrelation(req.Context(), correlation)))
+		return next(c)
+	}
+}
+
+// parseTraceparent traceparentヘッダー（version-traceid-parentid-flags）からtrace-idとparent-idを取り出す
+// 形式が不正な場合や、すべて0の無効なIDの場合は空を返す
+func parseTraceparent(value string) (traceID, spanID string) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return "", ""
+	}
+	traceID, spanID = strings.ToLower(parts[1]), strings.ToLower(parts[2])
+	if !isHexID(traceID, 32) || !isHexID(spanID, 16) {
+		return "", ""
+	}
+	return traceID, spanID
+}
+
+// isHexID 指定した長さの16進数で、すべて0ではないか返す
+func isHexID(id string, length int) bool {
+	if len(id) != length {
+		return false
+	}
+	if _, err := hex.DecodeString(id); err != nil {
+		return false
+	}
+	return strings.Trim(id, "0") != ""
+}
@@ -4,63 +4,113 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"runtime"
+	"strconv"
 
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/logger"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/labstack/gommon/log"
 )
 
+// serviceUnavailableRetryAfterSeconds データベースとの接続が失われたなど一時的な障害で失敗したリクエストに返す再試行までの秒数
+const serviceUnavailableRetryAfterSeconds = 5
+
 // ErrorHandler アプリケーション全体のエラーハンドラー
+// エラーは構造化ログ（logger.Logger）に出力し、リクエストID・トレースIDはリクエストのコンテキストから付与される
 type ErrorHandler struct {
+	log       logger.Logger
 	stackSize int
 }
 
 // NewErrorHandler ErrorHandlerの新しいインスタンスを作成
-func NewErrorHandler() *ErrorHandler {
+func NewErrorHandler(log logger.Logger) *ErrorHandler {
 	return &ErrorHandler{
+		log:       log,
 		stackSize: 4096,
 	}
 }
 
+// RespondError エラーレスポンスを書き込む
+// ハンドラー、ミドルウェア、Echoのエラーハンドラーのすべてがこの関数を使い、クライアントには常に同じ形式（api.Error）を返す
+// エラーコードまたはメッセージが空の場合はステータスコードから決める
+func RespondError(c echo.Context, status int, body api.Error) error {
+	if body.Code == "" {
+		body.Code = statusErrorCode(status)
+	}
+	if body.Error == "" {
+		body.Error = http.StatusText(status)
+	}
+	return c.JSON(status, body)
+}
+
 // HTTPErrorHandler EchoのHTTPエラーハンドラー
 func (eh *ErrorHandler) HTTPErrorHandler(err error, c echo.Context) {
 	code := http.StatusInternalServerError
-	message := "Internal Server Error"
+	var body api.Error
+
+	// ログに出力するエラー（HTTPエラーに元のエラーが設定されている場合はそちら）
+	cause := err
 
 	var he *echo.HTTPError
 	if errors.As(err, &he) {
 		code = he.Code
-		message = fmt.Sprintf("%v", he.Message)
+		if he.Internal != nil {
+			cause = he.Internal
+		}
+		// ハンドラーがエラーコードを指定した場合はそのまま返し、それ以外はステータスコードから決める
+		// 5xxのメッセージは内部の詳細を含み得るため、ハンドラーが指定したもの以外は返さない
+		if apiErr, ok := he.Message.(api.Error); ok {
+			body = apiErr
+		} else if code < 500 {
+			body = api.Error{
+				Error: fmt.Sprintf("%v", he.Message),
+				Code:  statusErrorCode(code),
+			}
+		}
+	}
+
+	// クエリのタイムアウトは接続の障害と区別できるよう、専用のコードを付けて504として返す
+	// データベースとの接続が失われたなど一時的な障害は、再試行できることを示すため503として返す
+	if code >= 500 && errors.Is(cause, domain.ErrQueryTimeout) {
+		code = http.StatusGatewayTimeout
+		body = api.Error{
+			Error: "database query timed out",
+			Code:  api.ErrorCodeQueryTimeout,
+		}
+	} else if code >= 500 && errors.Is(cause, domain.ErrServiceUnavailable) {
+		seconds := serviceUnavailableRetryAfterSeconds
+		code = http.StatusServiceUnavailable
+		c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(seconds))
+		body = api.Error{
+			Error:             "service temporarily unavailable, please retry later",
+			Code:              api.ErrorCodeServiceUnavailable,
+			RetryAfterSeconds: &seconds,
+		}
+	}
+
+	// 5xxはエラーの詳細をログにのみ出力し、クライアントにはログと照合するためのリクエストIDを返す
+	if code >= 500 {
+		body.RequestId = requestID(c)
 	}
 
-	// ログレベルに応じた処理
+	// ログレベルに応じた処理（5xxはスタックトレースをフィールドに含める）
+	ctx := c.Request().Context()
+	fields := eh.requestFields(c, code)
 	switch {
 	case code == http.StatusNotFound || code == http.StatusMethodNotAllowed:
-		c.Logger().Info("APIError: %v", err)
+		eh.log.Info(ctx, "API error", append(fields, logger.F("error", cause.Error()))...)
 	case code >= 400 && code < 500:
-		c.Logger().Warn("APIError: %v", err)
-	case code >= 500:
-		c.Logger().Error("APIError: %v", err)
-
-		trace := make([]byte, eh.stackSize)
-		n := runtime.Stack(trace, false)
-		stackStr := string(trace[:n])
-
-		c.Logger().Error("===== Start stack trace =====")
-		c.Logger().Error(stackStr)
-		c.Logger().Error("===== End stack trace =====")
+		eh.log.Warn(ctx, "API error", append(fields, logger.F("error", cause.Error()))...)
 	default:
-		c.Logger().Error("APIError: %v", err)
+		eh.log.Error(ctx, "API error", cause, append(fields, logger.WithStack())...)
 	}
 
 	// レスポンスがまだ送信されていない場合のみエラーレスポンスを送信
 	if !c.Response().Committed {
-		if err := c.JSON(code, map[string]interface{}{
-			"error": message,
-			"code":  code,
-		}); err != nil {
-			c.Logger().Error("Failed to send error response: %v", err)
+		if err := RespondError(c, code, body); err != nil {
+			eh.log.Error(ctx, "Failed to send error response", err, fields...)
 		}
 	}
 }
@@ -76,14 +126,8 @@ func (eh *ErrorHandler) LoggingMiddleware(next echo.HandlerFunc) echo.HandlerFun
 				return err
 			}
 
-			trace := make([]byte, eh.stackSize)
-			n := runtime.Stack(trace, false)
-			stackStr := string(trace[:n])
-
-			c.Logger().Error("RequestError: %v", err)
-			c.Logger().Error("===== Start stack trace =====")
-			c.Logger().Error(stackStr)
-			c.Logger().Error("===== End stack trace =====")
+			eh.log.Error(c.Request().Context(), "Request error", err,
+				append(eh.requestFields(c, http.StatusInternalServerError), logger.WithStack())...)
 
 			return err
 		}
@@ -104,15 +148,51 @@ func (eh *ErrorHandler) RecoverConfig() middleware.RecoverConfig {
 
 // recoverLogError panicからのリカバリー時のログ出力
 func (eh *ErrorHandler) recoverLogError(c echo.Context, err error, stack []byte) error {
-	stackStr := string(stack)
-	errMsg := fmt.Sprintf("RequestProcessingError: %v", err)
+	// panicが発生した箇所のスタックトレースを出力する
+	eh.log.Error(c.Request().Context(), "Recovered from panic", err,
+		append(eh.requestFields(c, http.StatusInternalServerError), logger.F("stack", string(stack)))...)
+
+	return RespondError(c, http.StatusInternalServerError, api.Error{RequestId: requestID(c)})
+}
+
+// requestFields エラーログに含めるリクエストの情報を返す
+func (eh *ErrorHandler) requestFields(c echo.Context, status int) []logger.Field {
+	return []logger.Field{
+		logger.F("status", status),
+		logger.F("method", c.Request().Method),
+		logger.F("path", c.Request().URL.Path),
+	}
+}
 
-	c.Logger().Error(errMsg)
-	c.Logger().Error("===== Start stack trace =====")
-	c.Logger().Error(stackStr)
-	c.Logger().Error("===== End stack trace =====")
+// requestID レスポンスに付けるリクエストIDを返す（RequestIDミドルウェアの前で発生したエラーではnil）
+func requestID(c echo.Context) *string {
+	id := c.Response().Header().Get(echo.HeaderXRequestID)
+	if id == "" {
+		return nil
+	}
+	return &id
+}
 
-	return c.JSON(http.StatusInternalServerError, map[string]string{
-		"error": "Internal server error",
-	})
+// statusErrorCode エラーコードの指定が無いHTTPエラー（ミドルウェアやルーティングのエラー）のコードを返す
+func statusErrorCode(status int) api.ErrorCode {
+	switch status {
+	case http.StatusUnauthorized:
+		return api.ErrorCodeUnauthorized
+	case http.StatusForbidden:
+		return api.ErrorCodeForbidden
+	case http.StatusNotFound:
+		return api.ErrorCodeNotFound
+	case http.StatusMethodNotAllowed:
+		return api.ErrorCodeMethodNotAllowed
+	case http.StatusUnsupportedMediaType:
+		return api.ErrorCodeUnsupportedMediaType
+	case http.StatusTooManyRequests:
+		return api.ErrorCodeRateLimited
+	case http.StatusServiceUnavailable:
+		return api.ErrorCodeServiceUnavailable
+	}
+	if status >= 400 && status < 500 {
+		return api.ErrorCodeInvalidRequest
+	}
+	return api.ErrorCodeInternalError
 }
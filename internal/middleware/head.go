@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Head HEADリクエストをGETのルートで処理し、本文を破棄してヘッダーのみを返すミドルウェア
+// ルーティングより前に実行する必要があるため、e.Preで登録すること
+// 405レスポンスのAllowヘッダーには、GETを許可するパスでHEADも追加する
+func Head(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		res := c.Response()
+		if req.Method != http.MethodHead {
+			err := next(c)
+			addHeadToAllow(res.Header())
+			return err
+		}
+
+		// Echoはe.Preの後に元のリクエストのメソッドでルーティングするため、コピーではなくメソッドを書き換える
+		req.Method = http.MethodGet
+		res.Writer = &headResponseWriter{ResponseWriter: res.Writer}
+		err := next(c)
+		req.Method = http.MethodHead
+		addHeadToAllow(res.Header())
+		// エラーレスポンスはこの後にエラーハンドラーが書き込むため、本文を破棄するWriterは戻さない
+		return err
+	}
+}
+
+// addHeadToAllow AllowヘッダーにGETが含まれていてHEADが含まれていない場合、GETの後にHEADを追加
+func addHeadToAllow(header http.Header) {
+	allow := header.Get(echo.HeaderAllow)
+	if allow == "" {
+		return
+	}
+	methods := strings.Split(allow, ", ")
+	for _, method := range methods {
+		if method == http.MethodHead {
+			return
+		}
+	}
+	for i, method := range methods {
+		if method == http.MethodGet {
+			methods = append(methods[:i+1], append([]string{http.MethodHead}, methods[i+1:]...)...)
+			header.Set(echo.HeaderAllow, strings.Join(methods, ", "))
+			return
+		}
+	}
+}
+
+// headResponseWriter ステータスコードとヘッダーはそのまま送信し、本文を破棄するResponseWriter
+type headResponseWriter struct {
+	http.ResponseWriter
+}
+
+// Write 本文を書き込まずに書き込んだものとして扱う
+func (w *headResponseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+// Unwrap http.ResponseControllerから元のResponseWriterを参照できるようにする
+func (w *headResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+// inflightRetryAfterSeconds 同時実行数の上限を超えたリクエストに返す再試行までの秒数
+const inflightRetryAfterSeconds = 1
+
+// InflightLimitConfig 同時に処理するリクエスト数の制限の設定
+type InflightLimitConfig struct {
+	// Max 同時に処理するリクエストの上限
+	Max int
+	// SkipPaths 制限の対象外とするパス（完全一致、ヘルスチェックなど）
+	SkipPaths []string
+}
+
+// NewInflightLimitMiddleware 同時に処理するリクエスト数を制限するミドルウェアを作成
+// 上限に達している場合は待たせずに503とRetry-Afterを返し、処理待ちのリクエストが溜まって
+// データベースのコネクションを使い切り、すべてのリクエストがタイムアウトすることを防ぐ
+func NewInflightLimitMiddleware(config InflightLimitConfig) echo.MiddlewareFunc {
+	slots := make(chan struct{}, config.Max)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if slices.Contains(config.SkipPaths, c.Request().URL.Path) {
+				return next(c)
+			}
+
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+				return next(c)
+			default:
+				log.Warnf("[InflightLimited] Method: %s | Path: %s | IP: %s | Limit: %d\n",
+					c.Request().Method, c.Request().URL.Path, c.RealIP(), config.Max)
+				seconds := inflightRetryAfterSeconds
+				c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(seconds))
+				return RespondError(c, http.StatusServiceUnavailable, api.Error{
+					Error:             "server is busy",
+					Code:              api.ErrorCodeServiceUnavailable,
+					RetryAfterSeconds: &seconds,
+				})
+			}
+		}
+	}
+}
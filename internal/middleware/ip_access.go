@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+// IPAccessConfig IPアドレスによるアクセス制御の設定を保持します
+// CIDR表記（例: "10.0.0.0/8", "2001:db8::/32"）または単一のIPアドレスで指定する
+type IPAccessConfig struct {
+	// PathPrefixes 制限を適用するパス（前方一致、例: "/api/v1/admin"）
+	PathPrefixes []string
+	// Allowlist 許可するアドレス（空の場合はDenylist以外をすべて許可）
+	Allowlist []string
+	// Denylist 拒否するアドレス（Allowlistより優先）
+	Denylist []string
+}
+
+// NewIPAccessMiddleware IPアドレスによるアクセス制御ミドルウェアを作成
+// クライアントのアドレスはc.RealIP()で判定するため、EchoのIPExtractorにNewIPExtractorを設定しておく
+// 設定の誤りで意図せず公開されることを防ぐため、不正なCIDRは起動時にエラーとする
+func NewIPAccessMiddleware(config IPAccessConfig) (echo.MiddlewareFunc, error) {
+	allowlist, err := ParseCIDRs(config.Allowlist)
+	if err != nil {
+		return nil, fmt.Errorf("invalid allowlist: %w", err)
+	}
+	denylist, err := ParseCIDRs(config.Denylist)
+	if err != nil {
+		return nil, fmt.Errorf("invalid denylist: %w", err)
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !hasPathPrefix(c.Request().URL.Path, config.PathPrefixes) {
+				return next(c)
+			}
+
+			ip := c.RealIP()
+			if !isIPAllowed(ip, allowlist, denylist) {
+				log.Warnf("[IPDenied] Method: %s | Path: %s | IP: %s\n",
+					c.Request().Method, c.Request().URL.Path, ip)
+				return echo.NewHTTPError(http.StatusForbidden, "access denied from this address")
+			}
+
+			return next(c)
+		}
+	}, nil
+}
+
+// ParseCIDRs CIDR表記またはIPアドレスの一覧を解析
+// 単一のアドレスはそのアドレスのみを含む範囲（/32, /128）として扱う
+func ParseCIDRs(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		if strings.Contains(value, "/") {
+			prefix, err := netip.ParsePrefix(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIDR %q: %w", value, err)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP address %q: %w", value, err)
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// isIPAllowed 拒否リストに含まれず、許可リストが空または許可リストに含まれる場合にtrueを返す
+// 解析できないアドレスは拒否する
+func isIPAllowed(ip string, allowlist, denylist []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	if containsAddr(denylist, addr) {
+		return false
+	}
+	return len(allowlist) == 0 || containsAddr(allowlist, addr)
+}
+
+// containsAddr いずれかの範囲にアドレスが含まれるか判定
+func containsAddr(prefixes []netip.Prefix, addr netip.Addr) bool {
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPathPrefix パスがいずれかのプレフィックス配下か判定（"/admin" は "/admin" と "/admin/..." に一致）
+func hasPathPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		prefix = strings.TrimSuffix(prefix, "/")
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
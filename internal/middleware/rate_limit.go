@@ -0,0 +1,232 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+const (
+	// HeaderRateLimitLimit 期間内に許可されるリクエスト数を示すヘッダー
+	HeaderRateLimitLimit = "X-RateLimit-Limit"
+	// HeaderRateLimitRemaining 期間内の残りリクエスト数を示すヘッダー
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	// HeaderAPIKey サービスアカウントがAPIキーを送信するヘッダー
+	HeaderAPIKey = "X-API-Key"
+)
+
+// minRateLimitKeyLength 信頼済みのAPIキーの最小文字数（推測して上限を引き上げられないようにする）
+const minRateLimitKeyLength = 32
+
+// RateLimitKey レート制限で接続元アドレスの代わりに識別する信頼済みのAPIキー（サーバー間連携用）
+type RateLimitKey struct {
+	// Name ログと制限の単位に使用する名前（キーそのものは記録しない）
+	Name string
+	// Key X-API-Keyヘッダーで送信されるキー
+	Key string
+	// Requests Window内に許可するリクエスト数
+	Requests int
+}
+
+// RateLimitConfig レート制限ミドルウェアの設定
+type RateLimitConfig struct {
+	// PathPrefixes 制限を適用するパス（前方一致、例: "/api/v1/auth/login"）
+	PathPrefixes []string
+	// Requests Window内に許可するリクエスト数
+	Requests int
+	// Window リクエスト数を数える期間
+	Window time.Duration
+	// Keys 信頼済みのAPIキー（キーごとの上限で、APIキーごとに制限する）
+	Keys []RateLimitKey
+	// JWTManager 有効なアクセストークンを持つリクエストをアカウントごとに制限する（nilの場合は接続元アドレスのみ）
+	JWTManager *auth.JWTManager
+	// PublicPaths 認証不要のパス（完全一致）。アクセストークンを送信しても接続元アドレスで数える
+	// ログインやサインアップで使い捨てのアカウントのトークンを送り、アドレスごとの上限を回避されないようにする
+	PublicPaths []string
+	// Now 現在時刻を返す関数（テスト用、nilの場合はtime.Now）
+	Now func() time.Time
+}
+
+// rateLimitWindow 接続元ごとの期間とリクエスト数
+type rateLimitWindow struct {
+	count   int
+	resetAt time.Time
+}
+
+// rateLimiter 固定ウィンドウ方式でリクエスト数を数える
+type rateLimiter struct {
+	mu        sync.Mutex
+	window    time.Duration
+	now       func() time.Time
+	windows   map[string]*rateLimitWindow
+	nextSweep time.Time
+}
+
+// NewRateLimitMiddleware 認証済みの主体または接続元アドレスごとのレート制限ミドルウェアを作成
+// 信頼済みのAPIキー、有効なアクセストークンのアカウント、接続元アドレスの順に制限の単位を決めるため、
+// 同じアドレスを共有する複数のテナントやサービスが互いの上限を使い切らない
+// アカウントごとの制限は認証が必要なパスのみに適用し、認証不要のパスは常に接続元アドレスで数える
+// 制限対象のレスポンスには常にX-RateLimit-Limit/X-RateLimit-Remainingを付与し、
+// 超過時は429とRetry-Afterヘッダー、エラーコードrate_limitedとretry_after_secondsを含むエラーレスポンスを返す
+func NewRateLimitMiddleware(config RateLimitConfig) echo.MiddlewareFunc {
+	now := config.Now
+	if now == nil {
+		now = time.Now
+	}
+	limiter := &rateLimiter{
+		window:  config.Window,
+		now:     now,
+		windows: make(map[string]*rateLimitWindow),
+	}
+
+	// キーはハッシュ化した値で照合する（ヘッダーの値と比較する際に長さや内容による時間差が出ない）
+	keys := make(map[string]RateLimitKey, len(config.Keys))
+	for _, key := range config.Keys {
+		keys[auth.HashToken(key.Key)] = key
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !hasPathPrefix(c.Request().URL.Path, config.PathPrefixes) {
+				return next(c)
+			}
+
+			jwtManager := config.JWTManager
+			if slices.Contains(config.PublicPaths, c.Request().URL.Path) {
+				jwtManager = nil
+			}
+			principal, limit := rateLimitPrincipal(c, keys, jwtManager, config.Requests)
+			remaining, retryAfter, allowed := limiter.allow(principal, limit)
+
+			header := c.Response().Header()
+			header.Set(HeaderRateLimitLimit, strconv.Itoa(limit))
+			header.Set(HeaderRateLimitRemaining, strconv.Itoa(remaining))
+
+			if !allowed {
+				seconds := retryAfterSeconds(retryAfter)
+				header.Set(echo.HeaderRetryAfter, strconv.Itoa(seconds))
+				log.Warnf("[RateLimited] Method: %s | Path: %s | IP: %s | Principal: %s\n",
+					c.Request().Method, c.Request().URL.Path, c.RealIP(), principal)
+				return RespondError(c, http.StatusTooManyRequests, api.Error{
+					Error:             "rate limit exceeded",
+					Code:              api.ErrorCodeRateLimited,
+					RetryAfterSeconds: &seconds,
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// rateLimitPrincipal リクエスト数を数える単位と上限を返す
+// 登録されていないAPIキーや無効なアクセストークンは認証されていないものとして接続元アドレスで数える
+// jwtManagerがnilの場合はアクセストークンを確認しない
+func rateLimitPrincipal(c echo.Context, keys map[string]RateLimitKey, jwtManager *auth.JWTManager, requests int) (string, int) {
+	if apiKey := c.Request().Header.Get(HeaderAPIKey); apiKey != "" {
+		if key, ok := keys[auth.HashToken(apiKey)]; ok {
+			return "api_key:" + key.Name, key.Requests
+		}
+	}
+
+	if jwtManager != nil {
+		if token, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer "); ok {
+			if claims, err := jwtManager.ValidateAccessToken(token); err == nil {
+				return "account:" + claims.AccountID, requests
+			}
+		}
+	}
+
+	return "ip:" + c.RealIP(), requests
+}
+
+// ParseRateLimitKeys "名前:キー:リクエスト数" 形式の設定を信頼済みのAPIキーに変換
+// 形式の誤りや重複は起動時に検出できるようエラーを返す
+func ParseRateLimitKeys(entries []string) ([]RateLimitKey, error) {
+	keys := make([]RateLimitKey, 0, len(entries))
+	names := make(map[string]bool, len(entries))
+	values := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		// キーに":"が含まれていても分割できるよう、名前と上限を両端から取り出す
+		name, rest, ok := strings.Cut(entry, ":")
+		separator := strings.LastIndex(rest, ":")
+		if !ok || name == "" || separator <= 0 {
+			return nil, fmt.Errorf("invalid rate limit key %q: expected name:key:requests", name)
+		}
+		key, limit := rest[:separator], rest[separator+1:]
+		if len(key) < minRateLimitKeyLength {
+			return nil, fmt.Errorf("invalid rate limit key %q: key must be at least %d characters long", name, minRateLimitKeyLength)
+		}
+		requests, err := strconv.Atoi(limit)
+		if err != nil || requests <= 0 {
+			return nil, fmt.Errorf("invalid rate limit key %q: requests must be a positive integer", name)
+		}
+		if names[name] || values[key] {
+			return nil, fmt.Errorf("duplicate rate limit key %q", name)
+		}
+		names[name] = true
+		values[key] = true
+
+		keys = append(keys, RateLimitKey{Name: name, Key: key, Requests: requests})
+	}
+	return keys, nil
+}
+
+// allow リクエストを1件数え、残り回数・再試行までの時間・許可するかを返す
+// limitは制限の単位ごとの上限（APIキーごとに異なる）
+func (l *rateLimiter) allow(key string, limit int) (int, time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	w, ok := l.windows[key]
+	if !ok || !now.Before(w.resetAt) {
+		w = &rateLimitWindow{resetAt: now.Add(l.window)}
+		l.windows[key] = w
+	}
+
+	if w.count >= limit {
+		return 0, w.resetAt.Sub(now), false
+	}
+	w.count++
+	return limit - w.count, 0, true
+}
+
+// sweep 期限切れの記録を削除してメモリの増加を防ぐ（1ウィンドウに1回まで）
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Before(l.nextSweep) {
+		return
+	}
+	for key, w := range l.windows {
+		if !now.Before(w.resetAt) {
+			delete(l.windows, key)
+		}
+	}
+	l.nextSweep = now.Add(l.window)
+}
+
+// retryAfterSeconds 再試行までの時間を秒に切り上げる（最低1秒）
+func retryAfterSeconds(d time.Duration) int {
+	seconds := int(math.Ceil(d.Seconds()))
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/labstack/echo/v4"
+)
+
+// NewIPExtractor 信頼するプロキシを経由した場合のみX-Forwarded-Forを参照するIP抽出関数を作成
+// X-Forwarded-Forは右から辿り、信頼するプロキシ以外の最初のアドレスをクライアントとする
+// 信頼するプロキシが空の場合は転送ヘッダーを一切参照せず、接続元アドレスを返す
+func NewIPExtractor(trustedProxies []string) (echo.IPExtractor, error) {
+	prefixes, err := ParseCIDRs(trustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+	if len(prefixes) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, prefix := range prefixes {
+		options = append(options, echo.TrustIPRange(&net.IPNet{
+			IP:   net.IP(prefix.Addr().AsSlice()),
+			Mask: net.CIDRMask(prefix.Bits(), prefix.Addr().BitLen()),
+		}))
+	}
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+// RoleConfig ロールによるアクセス制御の設定を保持します
+type RoleConfig struct {
+	// Rules "METHOD パス" ごとに必要なロール（例: "POST /api/v1/accounts"）
+	// パスはEchoのルート定義（:account_id などを含む形式）で指定する
+	Rules map[string]domain.Role
+}
+
+// NewRoleMiddleware ロールによるアクセス制御ミドルウェアを作成
+// 認証ミドルウェアがコンテキストに設定したロールを参照するため、認証ミドルウェアの後に適用する
+func NewRoleMiddleware(config RoleConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			required, ok := config.Rules[c.Request().Method+" "+c.Path()]
+			if !ok {
+				return next(c)
+			}
+
+			role, _ := c.Get(string(RoleKey)).(string)
+			if domain.Role(role) != required {
+				log.Warnf("[Forbidden] Method: %s | Path: %s | Role: %q | Required: %s | IP: %s\n",
+					c.Request().Method, c.Path(), role, required, c.RealIP())
+				return echo.NewHTTPError(http.StatusForbidden, "insufficient permissions")
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -4,15 +4,19 @@ import (
 	"os"
 	"time"
 
+	"github.com/aida0710/jwt-auth/internal/logger"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/labstack/gommon/log"
 )
 
 // Setup すべてのミドルウェアを設定
-func Setup(e *echo.Echo) {
+func Setup(e *echo.Echo, appLogger logger.Logger, compression CompressionConfig) {
 	// エラーハンドラーの初期化
-	errorHandler := NewErrorHandler()
+	errorHandler := NewErrorHandler(appLogger)
+
+	// HEADリクエストをGETのルートで処理（ルーティングより前に実行）
+	e.Pre(Head)
 
 	// ロガーの設定
 	e.Logger.SetLevel(log.DEBUG)
@@ -27,6 +31,9 @@ func Setup(e *echo.Echo) {
 	e.Use(middleware.RecoverWithConfig(errorHandler.RecoverConfig()))
 	e.Use(middleware.RequestID())
 
+	// 監査ログとログの行に記録する相関ID（リクエストID・トレースID）
+	e.Use(Correlation)
+
 	// エラーログ出力ミドルウェア
 	e.Use(errorHandler.LoggingMiddleware)
 
@@ -36,8 +43,8 @@ func Setup(e *echo.Echo) {
 	// タイムアウト設定
 	e.Use(middleware.TimeoutWithConfig(getTimeoutConfig()))
 
-	// GZIP圧縮
-	e.Use(middleware.Gzip())
+	// GZIP圧縮（小さなレスポンスと圧縮済みの形式は対象外）
+	e.Use(NewCompressionMiddleware(compression))
 
 	// セキュリティヘッダー
 	e.Use(middleware.SecureWithConfig(getSecureConfig()))
@@ -47,8 +54,12 @@ func Setup(e *echo.Echo) {
 }
 
 // getCORSConfig CORS設定を返す
+// 条件付きGETのためにETagヘッダーを、作成したリソースの参照のためにLocationヘッダーを、再試行の判断のためにレート制限のヘッダーを、
+// 一覧で適用された件数の確認のためにX-Page-Limitヘッダーを、405で許可されたメソッドの確認のためにAllowヘッダーをブラウザから参照できるようにする
 func getCORSConfig() middleware.CORSConfig {
-	return middleware.DefaultCORSConfig
+	config := middleware.DefaultCORSConfig
+	config.ExposeHeaders = []string{"ETag", echo.HeaderLocation, echo.HeaderRetryAfter, HeaderRateLimitLimit, HeaderRateLimitRemaining, "X-Page-Limit", echo.HeaderAllow}
+	return config
 }
 
 // getTimeoutConfig タイムアウト設定を返す
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/labstack/echo/v4"
+)
+
+// VerifyOnlyConfig トークンを発行しない（検証のみの）構成で拒否するエンドポイントの設定
+type VerifyOnlyConfig struct {
+	// Paths 拒否するパス（完全一致、トークンを発行するエンドポイントとリフレッシュトークンを受け取るエンドポイント）
+	Paths []string
+}
+
+// NewVerifyOnlyMiddleware 検証のみの構成でトークンを扱う認証エンドポイントを503で拒否するミドルウェアを作成
+// ハンドラーまで到達させず、発行に失敗する前にアカウントの作成などの副作用が起きることを防ぐ
+func NewVerifyOnlyMiddleware(config VerifyOnlyConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !slices.Contains(config.Paths, c.Request().URL.Path) {
+				return next(c)
+			}
+			return RespondError(c, http.StatusServiceUnavailable, api.Error{
+				Error: "token issuance is disabled on this server",
+				Code:  api.ErrorCodeServiceUnavailable,
+			})
+		}
+	}
+}
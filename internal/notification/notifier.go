@@ -0,0 +1,40 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/aida0710/jwt-auth/internal/logger"
+)
+
+// Message 送信する通知の内容
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier ユーザーへの通知（メールなど）を送信するインターフェース
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// logNotifier 通知をログに出力するだけのNotifier（開発環境用）
+type logNotifier struct {
+	logger logger.Logger
+}
+
+// NewLogNotifier 通知をログに出力するNotifierを作成
+// メール送信基盤を用意するまでの開発用で、本文にトークンを含むため本番では使用しない
+func NewLogNotifier(log logger.Logger) Notifier {
+	return &logNotifier{logger: log}
+}
+
+// Send 通知内容をログに出力
+func (n *logNotifier) Send(ctx context.Context, msg Message) error {
+	n.logger.Info(ctx, "Notification sent",
+		logger.F("to", msg.To),
+		logger.F("subject", msg.Subject),
+		logger.F("body", msg.Body),
+	)
+	return nil
+}
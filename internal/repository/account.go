@@ -4,22 +4,48 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/aida0710/jwt-auth/internal/domain"
 	"github.com/aida0710/jwt-auth/internal/infrastructure/database"
 	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
 )
 
 // accountDB データベース用のアカウント構造体（UUIDをstringで保存）
 type accountDB struct {
 	ID           string    `db:"id"`
+	TenantID     string    `db:"tenant_id"`
 	Email        string    `db:"email"`
+	Username     *string   `db:"username"`
 	Name         string    `db:"name"`
+	Role         string    `db:"role"`
+	Status       string    `db:"status"`
 	PasswordHash string    `db:"password_hash"`
 	CreatedAt    time.Time `db:"created_at"`
 	UpdatedAt    time.Time `db:"updated_at"`
+	Version      int64     `db:"version"`
+	DisplayName  *string   `db:"display_name"`
+	AvatarURL    *string   `db:"avatar_url"`
+	Locale       *string   `db:"locale"`
+	Timezone     *string   `db:"timezone"`
+
+	PendingEmail               *string    `db:"pending_email"`
+	EmailVerificationTokenHash *string    `db:"email_verification_token_hash"`
+	EmailVerificationExpiresAt *time.Time `db:"email_verification_expires_at"`
+
+	DeletionScheduledAt *time.Time `db:"deletion_scheduled_at"`
+
+	LastLoginAt *time.Time `db:"last_login_at"`
+	LastLoginIP *string    `db:"last_login_ip"`
+
+	AllowedAudiences *string `db:"allowed_audiences"` // 空白区切り
+}
+
+// accountProjectCountDB プロジェクト数を集計したアカウントの行
+type accountProjectCountDB struct {
+	accountDB
+	ProjectCount int `db:"project_count"`
 }
 
 // toDomain DB構造体からドメインモデルへ変換
@@ -31,11 +57,31 @@ func (a *accountDB) toDomain() (*domain.Account, error) {
 
 	return &domain.Account{
 		ID:           id,
+		TenantID:     a.TenantID,
 		Email:        a.Email,
+		Username:     a.Username,
 		Name:         a.Name,
+		Role:         domain.Role(a.Role),
+		Status:       domain.AccountStatus(a.Status),
 		PasswordHash: a.PasswordHash,
 		CreatedAt:    a.CreatedAt,
 		UpdatedAt:    a.UpdatedAt,
+		Version:      a.Version,
+		DisplayName:  a.DisplayName,
+		AvatarURL:    a.AvatarURL,
+		Locale:       a.Locale,
+		Timezone:     a.Timezone,
+
+		PendingEmail:               a.PendingEmail,
+		EmailVerificationTokenHash: a.EmailVerificationTokenHash,
+		EmailVerificationExpiresAt: a.EmailVerificationExpiresAt,
+
+		DeletionScheduledAt: a.DeletionScheduledAt,
+
+		LastLoginAt: a.LastLoginAt,
+		LastLoginIP: a.LastLoginIP,
+
+		AllowedAudiences: splitAudiences(a.AllowedAudiences),
 	}, nil
 }
 
@@ -43,21 +89,68 @@ func (a *accountDB) toDomain() (*domain.Account, error) {
 func fromDomainAccount(account *domain.Account) *accountDB {
 	return &accountDB{
 		ID:           account.ID.String(),
+		TenantID:     account.TenantID,
 		Email:        account.Email,
+		Username:     account.Username,
 		Name:         account.Name,
+		Role:         string(account.Role),
+		Status:       string(account.Status),
 		PasswordHash: account.PasswordHash,
 		CreatedAt:    account.CreatedAt,
 		UpdatedAt:    account.UpdatedAt,
+		Version:      account.Version,
+		DisplayName:  account.DisplayName,
+		AvatarURL:    account.AvatarURL,
+		Locale:       account.Locale,
+		Timezone:     account.Timezone,
+
+		PendingEmail:               account.PendingEmail,
+		EmailVerificationTokenHash: account.EmailVerificationTokenHash,
+		EmailVerificationExpiresAt: account.EmailVerificationExpiresAt,
+
+		DeletionScheduledAt: account.DeletionScheduledAt,
+
+		LastLoginAt: account.LastLoginAt,
+		LastLoginIP: account.LastLoginIP,
+
+		AllowedAudiences: joinAudiences(account.AllowedAudiences),
+	}
+}
+
+// joinAudiences Audienceの一覧を空白区切りの文字列に変換（空の場合はNULL）
+func joinAudiences(audiences []string) *string {
+	if len(audiences) == 0 {
+		return nil
+	}
+	joined := strings.Join(audiences, " ")
+	return &joined
+}
+
+// splitAudiences 空白区切りの文字列をAudienceの一覧に変換
+func splitAudiences(value *string) []string {
+	if value == nil {
+		return nil
 	}
+	audiences := strings.Fields(*value)
+	if len(audiences) == 0 {
+		return nil
+	}
+	return audiences
 }
 
+// accountColumns accountDBに読み込むカラムの一覧
+const accountColumns = `id, tenant_id, email, username, name, role, status, password_hash, created_at, updated_at, version,
+			display_name, avatar_url, locale, timezone,
+			pending_email, email_verification_token_hash, email_verification_expires_at,
+			deletion_scheduled_at, last_login_at, last_login_ip, allowed_audiences`
+
 // accountRepository repository.AccountRepositoryの実装
 type accountRepository struct {
-	db *sqlx.DB
+	db *database.DB
 }
 
 // NewAccountRepository アカウントリポジトリを作成
-func NewAccountRepository(db *sqlx.DB) domain.AccountRepository {
+func NewAccountRepository(db *database.DB) domain.AccountRepository {
 	return &accountRepository{
 		db: db,
 	}
@@ -66,19 +159,46 @@ func NewAccountRepository(db *sqlx.DB) domain.AccountRepository {
 // Create 新しいアカウントを作成
 func (r *accountRepository) Create(ctx context.Context, account *domain.Account) error {
 	query := `
-		INSERT INTO accounts (id, email, name, password_hash, created_at, updated_at)
-		VALUES (:id, :email, :name, :password_hash, :created_at, :updated_at)
+		INSERT INTO accounts (
+			id, tenant_id, email, username, name, role, status, password_hash, created_at, updated_at, version,
+			display_name, avatar_url, locale, timezone,
+			pending_email, email_verification_token_hash, email_verification_expires_at,
+			deletion_scheduled_at, allowed_audiences
+		)
+		VALUES (
+			:id, :tenant_id, :email, :username, :name, :role, :status, :password_hash, :created_at, :updated_at, :version,
+			:display_name, :avatar_url, :locale, :timezone,
+			:pending_email, :email_verification_token_hash, :email_verification_expires_at,
+			:deletion_scheduled_at, :allowed_audiences
+		)
 	`
 
-	now := time.Now()
+	if account.TenantID == "" {
+		account.TenantID = domain.ResolveTenantID(ctx)
+	}
+	if !account.BelongsTo(ctx) {
+		return domain.ErrForbidden
+	}
+
+	now := domain.Now()
 	account.CreatedAt = now
 	account.UpdatedAt = now
+	if account.Version == 0 {
+		account.Version = 1
+	}
 
 	dbAccount := fromDomainAccount(account)
 
 	exec := database.GetExecutor(ctx, r.db)
 	_, err := exec.NamedExecContext(ctx, query, dbAccount)
 	if err != nil {
+		// ユーザー名・メールアドレスの一意制約違反を重複エラーに変換
+		if database.IsUniqueViolationOn(err, "uq_accounts_username") {
+			return domain.ErrDuplicateUsername
+		}
+		if database.IsUniqueViolation(err) {
+			return domain.ErrDuplicateEmail
+		}
 		return err
 	}
 
@@ -88,17 +208,18 @@ func (r *accountRepository) Create(ctx context.Context, account *domain.Account)
 // GetByID IDでアカウントを取得
 func (r *accountRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Account, error) {
 	var dbAccount accountDB
+	tenant, tenantArgs := tenantCondition(ctx, "")
 	query := `
-		SELECT id, email, name, password_hash, created_at, updated_at
+		SELECT ` + accountColumns + `
 		FROM accounts
-		WHERE id = ?
+		WHERE id = ?` + tenant + `
 	`
 
 	exec := database.GetExecutor(ctx, r.db)
-	err := exec.GetContext(ctx, &dbAccount, query, id.String())
+	err := exec.GetContext(ctx, &dbAccount, query, append([]interface{}{id.String()}, tenantArgs...)...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, domain.ErrNotFound
+			return nil, domain.ErrAccountNotFound
 		}
 		return nil, err
 	}
@@ -107,19 +228,46 @@ func (r *accountRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 }
 
 // GetByEmail メールアドレスでアカウントを取得
+// 一意インデックス導入前に作成された重複が残っていても失敗しないよう、最も古いアカウントを返す
 func (r *accountRepository) GetByEmail(ctx context.Context, email string) (*domain.Account, error) {
 	var dbAccount accountDB
+	tenant, tenantArgs := tenantCondition(ctx, "")
+	query := `
+		SELECT ` + accountColumns + `
+		FROM accounts
+		WHERE email = ?` + tenant + `
+		ORDER BY created_at ASC, id ASC
+		LIMIT 1
+	`
+
+	exec := database.GetExecutor(ctx, r.db)
+	err := exec.GetContext(ctx, &dbAccount, query, append([]interface{}{email}, tenantArgs...)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrAccountNotFound
+		}
+		return nil, err
+	}
+
+	return dbAccount.toDomain()
+}
+
+// GetByUsername ユーザー名でアカウントを取得
+// 呼び出し側でNormalizeUsernameにより小文字にしたユーザー名を指定する
+func (r *accountRepository) GetByUsername(ctx context.Context, username string) (*domain.Account, error) {
+	var dbAccount accountDB
+	tenant, tenantArgs := tenantCondition(ctx, "")
 	query := `
-		SELECT id, email, name, password_hash, created_at, updated_at
+		SELECT ` + accountColumns + `
 		FROM accounts
-		WHERE email = ?
+		WHERE username = ?` + tenant + `
 	`
 
 	exec := database.GetExecutor(ctx, r.db)
-	err := exec.GetContext(ctx, &dbAccount, query, email)
+	err := exec.GetContext(ctx, &dbAccount, query, append([]interface{}{username}, tenantArgs...)...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, domain.ErrNotFound
+			return nil, domain.ErrAccountNotFound
 		}
 		return nil, err
 	}
@@ -127,17 +275,21 @@ func (r *accountRepository) GetByEmail(ctx context.Context, email string) (*doma
 	return dbAccount.toDomain()
 }
 
-// List アカウント一覧を取得
-func (r *accountRepository) List(ctx context.Context) ([]*domain.Account, error) {
+// List アカウント一覧を作成日時の新しい順に取得
+func (r *accountRepository) List(ctx context.Context, limit, offset int) ([]*domain.Account, error) {
 	dbAccounts := make([]accountDB, 0)
+	where, args := accountFilterClause(ctx, domain.AccountFilter{}, "")
 	query := `
-		SELECT id, email, name, password_hash, created_at, updated_at
+		SELECT ` + accountColumns + `
 		FROM accounts
-		ORDER BY created_at DESC
+	` + where + `
+		` + pageOrder(nil, "") + `
+		LIMIT ? OFFSET ?
 	`
+	args = append(args, limit, offset)
 
 	exec := database.GetExecutor(ctx, r.db)
-	err := exec.SelectContext(ctx, &dbAccounts, query)
+	err := exec.SelectContext(ctx, &dbAccounts, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -154,20 +306,189 @@ func (r *accountRepository) List(ctx context.Context) ([]*domain.Account, error)
 	return accounts, nil
 }
 
+// ListWithProjectCounts 条件に一致するアカウントを所有するプロジェクト数とともに取得
+// アカウントごとに件数を問い合わせないよう、1回の集計クエリで取得する
+func (r *accountRepository) ListWithProjectCounts(ctx context.Context, filter domain.AccountFilter) ([]*domain.AccountProjectCount, error) {
+	rows := make([]accountProjectCountDB, 0)
+	where, args := accountFilterClause(ctx, filter, "a.")
+	query := `
+		SELECT a.id, a.tenant_id, a.email, a.username, a.name, a.role, a.status, a.password_hash, a.created_at, a.updated_at, a.version,
+			a.display_name, a.avatar_url, a.locale, a.timezone,
+			a.pending_email, a.email_verification_token_hash, a.email_verification_expires_at,
+			a.deletion_scheduled_at, a.last_login_at, a.last_login_ip, a.allowed_audiences,
+			COUNT(p.id) AS project_count
+		FROM accounts a
+		LEFT JOIN projects p ON p.account_id = a.id
+	` + where + `
+		GROUP BY a.id
+		` + pageOrder(filter.Cursor, "a.") + `
+		LIMIT ? OFFSET ?
+	`
+	args = append(args, filter.Limit, filter.Offset)
+
+	exec := database.GetExecutor(ctx, r.db)
+	if err := exec.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+	reversePage(filter.Cursor, rows)
+
+	counts := make([]*domain.AccountProjectCount, 0, len(rows))
+	for i := range rows {
+		account, err := rows[i].accountDB.toDomain()
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, &domain.AccountProjectCount{
+			Account:      account,
+			ProjectCount: rows[i].ProjectCount,
+		})
+	}
+
+	return counts, nil
+}
+
+// Count 条件に一致するアカウントの総数を取得（カーソルの位置に関係なく数える）
+func (r *accountRepository) Count(ctx context.Context, filter domain.AccountFilter) (int, error) {
+	var count int
+	filter.Cursor = nil
+	where, args := accountFilterClause(ctx, filter, "")
+	query := `SELECT COUNT(*) FROM accounts ` + where
+
+	exec := database.GetExecutor(ctx, r.db)
+	if err := exec.GetContext(ctx, &count, query, args...); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// SearchByEmailPrefix メールアドレスが前方一致するアカウントをメールアドレス順に取得
+// LIKE 'prefix%' はメールアドレスのインデックスで範囲検索できる
+func (r *accountRepository) SearchByEmailPrefix(ctx context.Context, prefix string, limit int) ([]*domain.Account, error) {
+	dbAccounts := make([]accountDB, 0)
+	tenant, tenantArgs := tenantCondition(ctx, "")
+	query := `
+		SELECT ` + accountColumns + `
+		FROM accounts
+		WHERE email LIKE ?` + tenant + `
+		ORDER BY email, id
+		LIMIT ?
+	`
+	args := append([]interface{}{escapeLike(prefix) + "%"}, tenantArgs...)
+	args = append(args, limit)
+
+	exec := database.GetExecutor(ctx, r.db)
+	if err := exec.SelectContext(ctx, &dbAccounts, query, args...); err != nil {
+		return nil, err
+	}
+
+	accounts := make([]*domain.Account, 0, len(dbAccounts))
+	for _, dbAcc := range dbAccounts {
+		acc, err := dbAcc.toDomain()
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, acc)
+	}
+
+	return accounts, nil
+}
+
+// ListDeletionDue 猶予期間が過ぎた削除予定のアカウントを削除予定日時の古い順に取得
+// 定期的な完全削除で使用するため、コンテキストにテナントが無い場合はすべてのテナントを対象とする
+func (r *accountRepository) ListDeletionDue(ctx context.Context, now time.Time, limit int) ([]*domain.Account, error) {
+	dbAccounts := make([]accountDB, 0)
+	tenant, tenantArgs := tenantCondition(ctx, "")
+	query := `
+		SELECT ` + accountColumns + `
+		FROM accounts
+		WHERE status = ? AND deletion_scheduled_at <= ?` + tenant + `
+		ORDER BY deletion_scheduled_at, id
+		LIMIT ?
+	`
+	args := append([]interface{}{string(domain.AccountStatusPendingDeletion), now}, tenantArgs...)
+	args = append(args, limit)
+
+	exec := database.GetExecutor(ctx, r.db)
+	if err := exec.SelectContext(ctx, &dbAccounts, query, args...); err != nil {
+		return nil, err
+	}
+
+	accounts := make([]*domain.Account, 0, len(dbAccounts))
+	for _, dbAcc := range dbAccounts {
+		acc, err := dbAcc.toDomain()
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, acc)
+	}
+
+	return accounts, nil
+}
+
+// likeEscaper LIKEのワイルドカードとエスケープ文字を文字どおりに扱うための置換
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike 検索語をLIKEのパターンとして安全な形にエスケープ
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// accountFilterClause 検索条件とコンテキストのテナントからWHERE句とパラメータを組み立てる
+// aliasはJOIN時のテーブル別名（例: "a."）
+func accountFilterClause(ctx context.Context, filter domain.AccountFilter, alias string) (string, []interface{}) {
+	conditions := make([]string, 0, 3)
+	args := make([]interface{}, 0, 4)
+	if tenantID, ok := domain.TenantIDFromContext(ctx); ok {
+		conditions = append(conditions, alias+"tenant_id = ?")
+		args = append(args, tenantID)
+	}
+	if filter.Role != nil {
+		conditions = append(conditions, alias+"role = ?")
+		args = append(args, string(*filter.Role))
+	}
+	if filter.Cursor != nil {
+		condition, arg := cursorCondition(filter.Cursor, alias)
+		conditions = append(conditions, condition)
+		args = append(args, arg)
+	}
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
 // Update アカウントを更新
+// 読み込んだ時点からバージョンが変わっている（他のリクエストが更新した）場合はErrVersionConflictを返す
 func (r *accountRepository) Update(ctx context.Context, account *domain.Account) error {
 	query := `
 		UPDATE accounts
-		SET email = :email, name = :name, password_hash = :password_hash, updated_at = :updated_at
-		WHERE id = :id
+		SET email = :email, name = :name, role = :role, status = :status, password_hash = :password_hash, updated_at = :updated_at,
+			version = version + 1,
+			display_name = :display_name, avatar_url = :avatar_url, locale = :locale, timezone = :timezone,
+			pending_email = :pending_email,
+			email_verification_token_hash = :email_verification_token_hash,
+			email_verification_expires_at = :email_verification_expires_at,
+			deletion_scheduled_at = :deletion_scheduled_at,
+			allowed_audiences = :allowed_audiences
+		WHERE id = :id AND tenant_id = :tenant_id AND version = :version
 	`
 
-	account.UpdatedAt = time.Now()
+	// 他のテナントのアカウントは存在しないものとして扱う
+	if !account.BelongsTo(ctx) {
+		return domain.ErrAccountNotFound
+	}
+
+	account.UpdatedAt = domain.Now()
 	dbAccount := fromDomainAccount(account)
 
 	exec := database.GetExecutor(ctx, r.db)
 	result, err := exec.NamedExecContext(ctx, query, dbAccount)
 	if err != nil {
+		// メールアドレスの一意制約違反を重複エラーに変換
+		if database.IsUniqueViolation(err) {
+			return domain.ErrDuplicateEmail
+		}
 		return err
 	}
 
@@ -177,18 +498,52 @@ func (r *accountRepository) Update(ctx context.Context, account *domain.Account)
 	}
 
 	if rows == 0 {
+		// 存在するのに更新されなかった場合はバージョンの不一致
+		var count int
+		if err := exec.GetContext(ctx, &count, `SELECT COUNT(*) FROM accounts WHERE id = ? AND tenant_id = ?`, dbAccount.ID, dbAccount.TenantID); err != nil {
+			return err
+		}
+		if count > 0 {
+			return domain.ErrVersionConflict
+		}
 		return domain.ErrAccountNotFound
 	}
 
+	account.Version++
 	return nil
 }
 
+// RecordLogin 最終ログインの日時と接続元を記録
+// ログインのたびに呼ばれるため、主キーで1行だけを更新し、バージョンと更新日時は変えない（If-Matchの競合にしない）
+func (r *accountRepository) RecordLogin(ctx context.Context, id uuid.UUID, at time.Time, ipAddress *string) error {
+	tenant, tenantArgs := tenantCondition(ctx, "")
+	// updated_at = updated_at でON UPDATE CURRENT_TIMESTAMPによる更新を防ぐ
+	query := `UPDATE accounts SET last_login_at = ?, last_login_ip = ?, updated_at = updated_at WHERE id = ?` + tenant
+
+	exec := database.GetExecutor(ctx, r.db)
+	_, err := exec.ExecContext(ctx, query, append([]interface{}{at, ipAddress, id.String()}, tenantArgs...)...)
+	return err
+}
+
+// UpdatePasswordHash 同じパスワードのハッシュを置き換える（ペッパーのローテーションなど）
+// パスワード自体は変わらないため、RecordLoginと同じくバージョンと更新日時は変えない（If-Matchの競合にしない）
+func (r *accountRepository) UpdatePasswordHash(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	tenant, tenantArgs := tenantCondition(ctx, "")
+	// updated_at = updated_at でON UPDATE CURRENT_TIMESTAMPによる更新を防ぐ
+	query := `UPDATE accounts SET password_hash = ?, updated_at = updated_at WHERE id = ?` + tenant
+
+	exec := database.GetExecutor(ctx, r.db)
+	_, err := exec.ExecContext(ctx, query, append([]interface{}{passwordHash, id.String()}, tenantArgs...)...)
+	return err
+}
+
 // Delete アカウントを削除
 func (r *accountRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM accounts WHERE id = ?`
+	tenant, tenantArgs := tenantCondition(ctx, "")
+	query := `DELETE FROM accounts WHERE id = ?` + tenant
 
 	exec := database.GetExecutor(ctx, r.db)
-	result, err := exec.ExecContext(ctx, query, id.String())
+	result, err := exec.ExecContext(ctx, query, append([]interface{}{id.String()}, tenantArgs...)...)
 	if err != nil {
 		return err
 	}
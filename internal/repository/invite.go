@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/infrastructure/database"
+	"github.com/google/uuid"
+)
+
+// inviteDB データベース用の招待構造体
+type inviteDB struct {
+	ID         string     `db:"id"`
+	TokenHash  string     `db:"token_hash"`
+	Role       string     `db:"role"`
+	TenantID   string     `db:"tenant_id"`
+	CreatedBy  string     `db:"created_by"`
+	ExpiresAt  time.Time  `db:"expires_at"`
+	ConsumedAt *time.Time `db:"consumed_at"`
+	ConsumedBy *string    `db:"consumed_by"`
+	CreatedAt  time.Time  `db:"created_at"`
+}
+
+// toDomain DB構造体からドメインモデルへ変換
+func (i *inviteDB) toDomain() (*domain.Invite, error) {
+	id, err := uuid.Parse(i.ID)
+	if err != nil {
+		return nil, err
+	}
+	createdBy, err := uuid.Parse(i.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+	var consumedBy *uuid.UUID
+	if i.ConsumedBy != nil {
+		accountID, err := uuid.Parse(*i.ConsumedBy)
+		if err != nil {
+			return nil, err
+		}
+		consumedBy = &accountID
+	}
+	return &domain.Invite{
+		ID:         id,
+		TokenHash:  i.TokenHash,
+		Role:       domain.Role(i.Role),
+		TenantID:   i.TenantID,
+		CreatedBy:  createdBy,
+		ExpiresAt:  i.ExpiresAt,
+		ConsumedAt: i.ConsumedAt,
+		ConsumedBy: consumedBy,
+		CreatedAt:  i.CreatedAt,
+	}, nil
+}
+
+// InviteRepository 招待リポジトリの実装
+type InviteRepository struct {
+	db *database.DB
+}
+
+// NewInviteRepository 新しい招待リポジトリを作成
+func NewInviteRepository(db *database.DB) domain.InviteRepository {
+	return &InviteRepository{db: db}
+}
+
+// Create 招待を作成
+func (r *InviteRepository) Create(ctx context.Context, invite *domain.Invite) error {
+	query := `
+		INSERT INTO invites (id, token_hash, role, tenant_id, created_by, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	exec := database.GetExecutor(ctx, r.db)
+	_, err := exec.ExecContext(ctx, query,
+		invite.ID.String(),
+		invite.TokenHash,
+		string(invite.Role),
+		invite.TenantID,
+		invite.CreatedBy.String(),
+		invite.ExpiresAt,
+		invite.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create invite: %w", err)
+	}
+
+	return nil
+}
+
+// GetByTokenHash トークンハッシュから招待を取得
+func (r *InviteRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.Invite, error) {
+	var row inviteDB
+
+	query := `
+		SELECT id, token_hash, role, tenant_id, created_by, expires_at, consumed_at, consumed_by, created_at
+		FROM invites
+		WHERE token_hash = ?
+	`
+
+	exec := database.GetExecutor(ctx, r.db)
+	if err := exec.GetContext(ctx, &row, query, tokenHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get invite: %w", err)
+	}
+
+	return row.toDomain()
+}
+
+// MarkAsConsumed 未使用かつ有効期限内の招待を、サインアップしたアカウントによる消費済みとしてマーク
+// 同時に別のリクエストが消費していた場合や期限切れの場合はfalseを返す
+func (r *InviteRepository) MarkAsConsumed(ctx context.Context, id, accountID uuid.UUID) (bool, error) {
+	query := `
+		UPDATE invites
+		SET consumed_at = ?, consumed_by = ?
+		WHERE id = ? AND consumed_at IS NULL AND expires_at > ?
+	`
+
+	now := domain.Now()
+	exec := database.GetExecutor(ctx, r.db)
+	result, err := exec.ExecContext(ctx, query, now, accountID.String(), id.String(), now)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark invite as consumed: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rows > 0, nil
+}
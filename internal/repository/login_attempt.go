@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/infrastructure/database"
+	"github.com/google/uuid"
+)
+
+// loginAttemptDB データベース用のログイン失敗の記録構造体
+type loginAttemptDB struct {
+	AccountID    string     `db:"account_id"`
+	FailedCount  int        `db:"failed_count"`
+	LockoutCount int        `db:"lockout_count"`
+	LockedUntil  *time.Time `db:"locked_until"`
+	LastFailedAt time.Time  `db:"last_failed_at"`
+}
+
+// toDomain DB構造体からドメインモデルへ変換
+func (a *loginAttemptDB) toDomain() (*domain.LoginAttempt, error) {
+	accountID, err := uuid.Parse(a.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.LoginAttempt{
+		AccountID:    accountID,
+		FailedCount:  a.FailedCount,
+		LockoutCount: a.LockoutCount,
+		LockedUntil:  a.LockedUntil,
+		LastFailedAt: a.LastFailedAt,
+	}, nil
+}
+
+// LoginAttemptRepository ログイン失敗の記録リポジトリの実装
+type LoginAttemptRepository struct {
+	db *database.DB
+}
+
+// NewLoginAttemptRepository 新しいログイン失敗の記録リポジトリを作成
+func NewLoginAttemptRepository(db *database.DB) domain.LoginAttemptRepository {
+	return &LoginAttemptRepository{db: db}
+}
+
+// GetByAccountID アカウントのログイン失敗の記録を取得
+func (r *LoginAttemptRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) (*domain.LoginAttempt, error) {
+	var row loginAttemptDB
+
+	query := `
+		SELECT account_id, failed_count, lockout_count, locked_until, last_failed_at
+		FROM login_attempts
+		WHERE account_id = ?
+	`
+
+	exec := database.GetExecutor(ctx, r.db)
+	if err := exec.GetContext(ctx, &row, query, accountID.String()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get login attempt: %w", err)
+	}
+
+	return row.toDomain()
+}
+
+// Save ログイン失敗の記録を作成または更新
+func (r *LoginAttemptRepository) Save(ctx context.Context, attempt *domain.LoginAttempt) error {
+	query := `
+		INSERT INTO login_attempts (account_id, failed_count, lockout_count, locked_until, last_failed_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			failed_count = VALUES(failed_count),
+			lockout_count = VALUES(lockout_count),
+			locked_until = VALUES(locked_until),
+			last_failed_at = VALUES(last_failed_at)
+	`
+
+	exec := database.GetExecutor(ctx, r.db)
+	_, err := exec.ExecContext(ctx, query,
+		attempt.AccountID.String(),
+		attempt.FailedCount,
+		attempt.LockoutCount,
+		attempt.LockedUntil,
+		attempt.LastFailedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save login attempt: %w", err)
+	}
+
+	return nil
+}
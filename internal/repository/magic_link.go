@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/infrastructure/database"
+	"github.com/google/uuid"
+)
+
+// magicLinkDB データベース用のマジックリンク構造体
+type magicLinkDB struct {
+	ID         string     `db:"id"`
+	AccountID  string     `db:"account_id"`
+	TokenHash  string     `db:"token_hash"`
+	ExpiresAt  time.Time  `db:"expires_at"`
+	ConsumedAt *time.Time `db:"consumed_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+}
+
+// toDomain DB構造体からドメインモデルへ変換
+func (l *magicLinkDB) toDomain() (*domain.MagicLink, error) {
+	id, err := uuid.Parse(l.ID)
+	if err != nil {
+		return nil, err
+	}
+	accountID, err := uuid.Parse(l.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.MagicLink{
+		ID:         id,
+		AccountID:  accountID,
+		TokenHash:  l.TokenHash,
+		ExpiresAt:  l.ExpiresAt,
+		ConsumedAt: l.ConsumedAt,
+		CreatedAt:  l.CreatedAt,
+	}, nil
+}
+
+// MagicLinkRepository マジックリンクリポジトリの実装
+type MagicLinkRepository struct {
+	db *database.DB
+}
+
+// NewMagicLinkRepository 新しいマジックリンクリポジトリを作成
+func NewMagicLinkRepository(db *database.DB) domain.MagicLinkRepository {
+	return &MagicLinkRepository{db: db}
+}
+
+// Create マジックリンクを作成
+func (r *MagicLinkRepository) Create(ctx context.Context, link *domain.MagicLink) error {
+	query := `
+		INSERT INTO magic_link_tokens (id, account_id, token_hash, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	exec := database.GetExecutor(ctx, r.db)
+	_, err := exec.ExecContext(ctx, query,
+		link.ID.String(),
+		link.AccountID.String(),
+		link.TokenHash,
+		link.ExpiresAt,
+		link.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create magic link: %w", err)
+	}
+
+	return nil
+}
+
+// GetByTokenHash トークンハッシュからマジックリンクを取得
+func (r *MagicLinkRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.MagicLink, error) {
+	var row magicLinkDB
+
+	query := `
+		SELECT id, account_id, token_hash, expires_at, consumed_at, created_at
+		FROM magic_link_tokens
+		WHERE token_hash = ?
+	`
+
+	exec := database.GetExecutor(ctx, r.db)
+	if err := exec.GetContext(ctx, &row, query, tokenHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get magic link: %w", err)
+	}
+
+	return row.toDomain()
+}
+
+// MarkAsConsumed 未使用かつ有効期限内のマジックリンクを消費済みとしてマーク
+// 同時に別のリクエストが消費していた場合や期限切れの場合はfalseを返す
+func (r *MagicLinkRepository) MarkAsConsumed(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `
+		UPDATE magic_link_tokens
+		SET consumed_at = ?
+		WHERE id = ? AND consumed_at IS NULL AND expires_at > ?
+	`
+
+	now := domain.Now()
+	exec := database.GetExecutor(ctx, r.db)
+	result, err := exec.ExecContext(ctx, query, now, id.String(), now)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark magic link as consumed: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// CountCreatedSince アカウントに対してsince以降に作成したマジックリンクの件数を取得
+func (r *MagicLinkRepository) CountCreatedSince(ctx context.Context, accountID uuid.UUID, since time.Time) (int, error) {
+	var count int
+
+	query := `
+		SELECT COUNT(*)
+		FROM magic_link_tokens
+		WHERE account_id = ? AND created_at >= ?
+	`
+
+	exec := database.GetExecutor(ctx, r.db)
+	if err := exec.GetContext(ctx, &count, query, accountID.String(), since); err != nil {
+		return 0, fmt.Errorf("failed to count magic links: %w", err)
+	}
+
+	return count, nil
+}
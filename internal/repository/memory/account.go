@@ -0,0 +1,267 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/google/uuid"
+)
+
+// accountRepository domain.AccountRepositoryのインメモリ実装
+// メールアドレスはデータベースの照合順序（utf8mb4_unicode_ci）と同じく大文字小文字を区別せずに一意とする
+type accountRepository struct {
+	store *Store
+}
+
+// Create 新しいアカウントを作成
+func (r *accountRepository) Create(ctx context.Context, account *domain.Account) error {
+	if account.TenantID == "" {
+		account.TenantID = domain.ResolveTenantID(ctx)
+	}
+	if !account.BelongsTo(ctx) {
+		return domain.ErrForbidden
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if _, ok := r.store.accounts[account.ID]; ok || r.emailTakenLocked(account.Email, account.ID) {
+		return domain.ErrDuplicateEmail
+	}
+	if account.Username != nil && r.usernameTakenLocked(*account.Username) {
+		return domain.ErrDuplicateUsername
+	}
+
+	now := domain.Now()
+	account.CreatedAt = now
+	account.UpdatedAt = now
+	if account.Version == 0 {
+		account.Version = 1
+	}
+	copied := *account
+	r.store.accounts[account.ID] = &copied
+	return nil
+}
+
+// emailTakenLocked 他のアカウントが同じメールアドレスを使用しているか返す（すべてのテナントが対象）
+func (r *accountRepository) emailTakenLocked(email string, exceptID uuid.UUID) bool {
+	for _, a := range r.store.accounts {
+		if a.ID != exceptID && strings.EqualFold(a.Email, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// usernameTakenLocked 同じユーザー名のアカウントが存在するか返す（すべてのテナントが対象）
+func (r *accountRepository) usernameTakenLocked(username string) bool {
+	for _, a := range r.store.accounts {
+		if a.Username != nil && strings.EqualFold(*a.Username, username) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetByID IDでアカウントを取得
+func (r *accountRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Account, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	a, ok := r.store.accounts[id]
+	if !ok || !a.BelongsTo(ctx) {
+		return nil, domain.ErrAccountNotFound
+	}
+	copied := *a
+	return &copied, nil
+}
+
+// GetByEmail メールアドレスでアカウントを取得（大文字小文字を区別しない）
+func (r *accountRepository) GetByEmail(ctx context.Context, email string) (*domain.Account, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	for _, a := range r.store.accounts {
+		if strings.EqualFold(a.Email, email) && a.BelongsTo(ctx) {
+			copied := *a
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrAccountNotFound
+}
+
+// GetByUsername ユーザー名でアカウントを取得（大文字小文字を区別しない）
+func (r *accountRepository) GetByUsername(ctx context.Context, username string) (*domain.Account, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	for _, a := range r.store.accounts {
+		if a.Username != nil && strings.EqualFold(*a.Username, username) && a.BelongsTo(ctx) {
+			copied := *a
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrAccountNotFound
+}
+
+// List アカウント一覧を作成日時の新しい順に取得
+func (r *accountRepository) List(ctx context.Context, limit, offset int) ([]*domain.Account, error) {
+	return paginate(r.match(ctx, domain.AccountFilter{}), nil, limit, offset,
+		func(a *domain.Account) uuid.UUID { return a.ID }), nil
+}
+
+// ListWithProjectCounts 条件に一致するアカウントを所有するプロジェクト数とともに取得
+func (r *accountRepository) ListWithProjectCounts(ctx context.Context, filter domain.AccountFilter) ([]*domain.AccountProjectCount, error) {
+	accounts := paginate(r.match(ctx, filter), filter.Cursor, filter.Limit, filter.Offset,
+		func(a *domain.Account) uuid.UUID { return a.ID })
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	counts := make([]*domain.AccountProjectCount, 0, len(accounts))
+	for _, account := range accounts {
+		count := 0
+		for _, p := range r.store.projects {
+			if p.AccountID == account.ID {
+				count++
+			}
+		}
+		counts = append(counts, &domain.AccountProjectCount{Account: account, ProjectCount: count})
+	}
+	return counts, nil
+}
+
+// Count 条件に一致するアカウントの総数を取得（カーソルの位置に関係なく数える）
+func (r *accountRepository) Count(ctx context.Context, filter domain.AccountFilter) (int, error) {
+	return len(r.match(ctx, filter)), nil
+}
+
+// SearchByEmailPrefix メールアドレスが前方一致するアカウントをメールアドレス順に取得
+func (r *accountRepository) SearchByEmailPrefix(ctx context.Context, prefix string, limit int) ([]*domain.Account, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	prefix = strings.ToLower(prefix)
+	matched := make([]*domain.Account, 0)
+	for _, a := range r.store.accounts {
+		if !a.BelongsTo(ctx) || !strings.HasPrefix(strings.ToLower(a.Email), prefix) {
+			continue
+		}
+		copied := *a
+		matched = append(matched, &copied)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if ei, ej := strings.ToLower(matched[i].Email), strings.ToLower(matched[j].Email); ei != ej {
+			return ei < ej
+		}
+		return matched[i].ID.String() < matched[j].ID.String()
+	})
+	return truncate(matched, limit), nil
+}
+
+// ListDeletionDue 猶予期間が過ぎた削除予定のアカウントを削除予定日時の古い順に取得
+func (r *accountRepository) ListDeletionDue(ctx context.Context, now time.Time, limit int) ([]*domain.Account, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	matched := make([]*domain.Account, 0)
+	for _, a := range r.store.accounts {
+		if !a.BelongsTo(ctx) || !a.IsDeletionDue(now) {
+			continue
+		}
+		copied := *a
+		matched = append(matched, &copied)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].DeletionScheduledAt.Equal(*matched[j].DeletionScheduledAt) {
+			return matched[i].DeletionScheduledAt.Before(*matched[j].DeletionScheduledAt)
+		}
+		return matched[i].ID.String() < matched[j].ID.String()
+	})
+	return truncate(matched, limit), nil
+}
+
+// match 条件に一致するアカウントを作成日時の新しい順に返す
+func (r *accountRepository) match(ctx context.Context, filter domain.AccountFilter) []*domain.Account {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	matched := make([]*domain.Account, 0, len(r.store.accounts))
+	for _, a := range r.store.accounts {
+		if !a.BelongsTo(ctx) {
+			continue
+		}
+		if filter.Role != nil && a.Role != *filter.Role {
+			continue
+		}
+		copied := *a
+		matched = append(matched, &copied)
+	}
+	sortNewestFirst(matched, func(a *domain.Account) (time.Time, uuid.UUID) { return a.CreatedAt, a.ID })
+	return matched
+}
+
+// Update アカウントを更新（ID・テナント・作成日時は変更しない）
+// 読み込んだ時点からバージョンが変わっている場合はErrVersionConflictを返す
+func (r *accountRepository) Update(ctx context.Context, account *domain.Account) error {
+	// 他のテナントのアカウントは存在しないものとして扱う
+	if !account.BelongsTo(ctx) {
+		return domain.ErrAccountNotFound
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	stored, ok := r.store.accounts[account.ID]
+	if !ok || stored.TenantID != account.TenantID {
+		return domain.ErrAccountNotFound
+	}
+	if stored.Version != account.Version {
+		return domain.ErrVersionConflict
+	}
+	if r.emailTakenLocked(account.Email, account.ID) {
+		return domain.ErrDuplicateEmail
+	}
+
+	account.UpdatedAt = domain.Now()
+	account.Version++
+	copied := *account
+	copied.CreatedAt = stored.CreatedAt
+	// ユーザー名は作成時にのみ設定する
+	copied.Username = stored.Username
+	// 最終ログインはRecordLoginでのみ更新する（読み込んだ後のログインを古い値で上書きしない）
+	copied.LastLoginAt = stored.LastLoginAt
+	copied.LastLoginIP = stored.LastLoginIP
+	r.store.accounts[account.ID] = &copied
+	return nil
+}
+
+// RecordLogin 最終ログインの日時と接続元を記録（バージョンと更新日時は変えない）
+func (r *accountRepository) RecordLogin(ctx context.Context, id uuid.UUID, at time.Time, ipAddress *string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	stored, ok := r.store.accounts[id]
+	if !ok || !stored.BelongsTo(ctx) {
+		return domain.ErrAccountNotFound
+	}
+	stored.LastLoginAt = &at
+	stored.LastLoginIP = ipAddress
+	return nil
+}
+
+// UpdatePasswordHash 同じパスワードのハッシュを置き換える（バージョンと更新日時は変えない）
+func (r *accountRepository) UpdatePasswordHash(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	stored, ok := r.store.accounts[id]
+	if !ok || !stored.BelongsTo(ctx) {
+		return domain.ErrAccountNotFound
+	}
+	stored.PasswordHash = passwordHash
+	return nil
+}
+
+// Delete アカウントを削除（プロジェクト、リフレッシュトークン、監査ログ、パスワード履歴も削除する）
+func (r *accountRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if a, ok := r.store.accounts[id]; !ok || !a.BelongsTo(ctx) {
+		return domain.ErrAccountNotFound
+	}
+	r.store.deleteAccountLocked(id)
+	return nil
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"bytes"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/google/uuid"
+)
+
+// sortNewestFirst 作成日時の新しい順（同じ日時の場合はIDの大きい順）に並べ替える
+func sortNewestFirst[T any](items []T, keyOf func(T) (time.Time, uuid.UUID)) {
+	sort.Slice(items, func(i, j int) bool {
+		ti, idi := keyOf(items[i])
+		tj, idj := keyOf(items[j])
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return bytes.Compare(idi[:], idj[:]) > 0
+	})
+}
+
+// paginate 作成日時の新しい順に並んだ項目からMySQLの実装と同じ規則で1ページ分を取り出す
+// カーソルを指定した場合はIDのみで並べてカーソルの前後を取得し、前のページは新しい順に戻す
+// LIMIT ? OFFSET ? と同じく、limitが0以下の場合は空を返す
+func paginate[T any](items []T, cursor *domain.PageCursor, limit, offset int, idOf func(T) uuid.UUID) []T {
+	if cursor != nil {
+		matched := make([]T, 0, len(items))
+		for _, item := range items {
+			id := idOf(item)
+			c := bytes.Compare(id[:], cursor.ID[:])
+			if (cursor.Before && c > 0) || (!cursor.Before && c < 0) {
+				matched = append(matched, item)
+			}
+		}
+		sort.Slice(matched, func(i, j int) bool {
+			a, b := idOf(matched[i]), idOf(matched[j])
+			if cursor.Before {
+				return bytes.Compare(a[:], b[:]) < 0
+			}
+			return bytes.Compare(a[:], b[:]) > 0
+		})
+		items = matched
+	}
+
+	if limit <= 0 || offset >= len(items) {
+		return []T{}
+	}
+	page := truncate(items[offset:], limit)
+	if cursor != nil && cursor.Before {
+		slices.Reverse(page)
+	}
+	return page
+}
+
+// truncate 先頭からlimit件までに切り詰める
+func truncate[T any](items []T, limit int) []T {
+	if limit < 0 {
+		return items[:0]
+	}
+	if limit < len(items) {
+		return items[:limit]
+	}
+	return items
+}
@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/google/uuid"
+)
+
+// passwordHistoryRepository domain.PasswordHistoryRepositoryのインメモリ実装
+type passwordHistoryRepository struct {
+	store *Store
+}
+
+// Create パスワード履歴を追加
+func (r *passwordHistoryRepository) Create(_ context.Context, history *domain.PasswordHistory) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	copied := *history
+	r.store.histories = append(r.store.histories, &copied)
+	return nil
+}
+
+// ListRecent アカウントの直近のパスワード履歴を新しい順に取得
+func (r *passwordHistoryRepository) ListRecent(_ context.Context, accountID uuid.UUID, limit int) ([]*domain.PasswordHistory, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return truncate(r.recentLocked(accountID), limit), nil
+}
+
+// Prune 新しい順にkeep件を残して古いパスワード履歴を削除
+func (r *passwordHistoryRepository) Prune(_ context.Context, accountID uuid.UUID, keep int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	kept := make(map[uuid.UUID]bool)
+	for _, h := range truncate(r.recentLocked(accountID), keep) {
+		kept[h.ID] = true
+	}
+	r.store.histories = deleteWhere(r.store.histories, func(h *domain.PasswordHistory) bool {
+		return h.AccountID == accountID && !kept[h.ID]
+	})
+	return nil
+}
+
+// recentLocked アカウントのパスワード履歴を新しい順に返す（呼び出し側でロックを取得していること）
+func (r *passwordHistoryRepository) recentLocked(accountID uuid.UUID) []*domain.PasswordHistory {
+	histories := make([]*domain.PasswordHistory, 0)
+	for _, h := range r.store.histories {
+		if h.AccountID == accountID {
+			copied := *h
+			histories = append(histories, &copied)
+		}
+	}
+	sortNewestFirst(histories, func(h *domain.PasswordHistory) (time.Time, uuid.UUID) { return h.CreatedAt, h.ID })
+	return histories
+}
@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/google/uuid"
+)
+
+// projectRepository domain.ProjectRepositoryのインメモリ実装
+type projectRepository struct {
+	store *Store
+}
+
+// Create 新しいプロジェクトを作成
+func (r *projectRepository) Create(ctx context.Context, project *domain.Project) error {
+	if project.TenantID == "" {
+		project.TenantID = domain.ResolveTenantID(ctx)
+	}
+	if !project.BelongsTo(ctx) {
+		return domain.ErrForbidden
+	}
+
+	now := domain.Now()
+	project.CreatedAt = now
+	project.UpdatedAt = now
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	copied := *project
+	r.store.projects[project.ID] = &copied
+	return nil
+}
+
+// GetByID IDでプロジェクトを取得
+func (r *projectRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Project, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	p, ok := r.store.projects[id]
+	if !ok || !p.BelongsTo(ctx) {
+		return nil, domain.ErrProjectNotFound
+	}
+	copied := *p
+	return &copied, nil
+}
+
+// GetByAccountID アカウントのプロジェクトを作成日時の新しい順に取得
+func (r *projectRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.Project, error) {
+	return r.match(ctx, domain.ProjectFilter{AccountID: &accountID}), nil
+}
+
+// List プロジェクト一覧を作成日時の新しい順に取得
+func (r *projectRepository) List(ctx context.Context) ([]*domain.Project, error) {
+	return r.match(ctx, domain.ProjectFilter{}), nil
+}
+
+// Search 条件に一致するプロジェクトをページ単位で取得
+func (r *projectRepository) Search(ctx context.Context, filter domain.ProjectFilter) ([]*domain.Project, error) {
+	return paginate(r.match(ctx, filter), filter.Cursor, filter.Limit, filter.Offset,
+		func(p *domain.Project) uuid.UUID { return p.ID }), nil
+}
+
+// Count 条件に一致するプロジェクトの総数を取得（カーソルの位置に関係なく数える）
+func (r *projectRepository) Count(ctx context.Context, filter domain.ProjectFilter) (int, error) {
+	return len(r.match(ctx, filter)), nil
+}
+
+// match 条件に一致するプロジェクトを作成日時の新しい順に返す
+func (r *projectRepository) match(ctx context.Context, filter domain.ProjectFilter) []*domain.Project {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	matched := make([]*domain.Project, 0, len(r.store.projects))
+	for _, p := range r.store.projects {
+		if !p.BelongsTo(ctx) {
+			continue
+		}
+		if filter.AccountID != nil && p.AccountID != *filter.AccountID {
+			continue
+		}
+		if filter.Status != nil && p.Status != *filter.Status {
+			continue
+		}
+		copied := *p
+		matched = append(matched, &copied)
+	}
+	sortNewestFirst(matched, func(p *domain.Project) (time.Time, uuid.UUID) { return p.CreatedAt, p.ID })
+	return matched
+}
+
+// Update プロジェクトの名前・説明・状態・最終更新者を更新
+func (r *projectRepository) Update(ctx context.Context, project *domain.Project) error {
+	// 他のテナントのプロジェクトは存在しないものとして扱う
+	if !project.BelongsTo(ctx) {
+		return domain.ErrProjectNotFound
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	stored, ok := r.store.projects[project.ID]
+	if !ok || stored.TenantID != project.TenantID {
+		return domain.ErrProjectNotFound
+	}
+
+	project.UpdatedAt = domain.Now()
+	stored.Name = project.Name
+	stored.Description = project.Description
+	stored.Status = project.Status
+	stored.UpdatedBy = project.UpdatedBy
+	stored.UpdatedAt = project.UpdatedAt
+	return nil
+}
+
+// Delete プロジェクトを削除
+func (r *projectRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if p, ok := r.store.projects[id]; !ok || !p.BelongsTo(ctx) {
+		return domain.ErrProjectNotFound
+	}
+	delete(r.store.projects, id)
+	return nil
+}
+
+// DeleteByAccountID アカウントIDですべてのプロジェクトを削除
+func (r *projectRepository) DeleteByAccountID(ctx context.Context, accountID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for id, p := range r.store.projects {
+		if p.AccountID == accountID && p.BelongsTo(ctx) {
+			delete(r.store.projects, id)
+		}
+	}
+	return nil
+}
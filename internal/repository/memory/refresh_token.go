@@ -0,0 +1,204 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/google/uuid"
+)
+
+// refreshTokenRepository domain.RefreshTokenRepositoryのインメモリ実装
+type refreshTokenRepository struct {
+	store *Store
+}
+
+// Create 新しいリフレッシュトークンを作成（トークンハッシュが重複する場合はErrDuplicateToken）
+func (r *refreshTokenRepository) Create(_ context.Context, token *domain.RefreshToken) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, t := range r.store.refreshTokens {
+		if t.TokenHash == token.TokenHash {
+			return domain.ErrDuplicateToken
+		}
+	}
+	if _, ok := r.store.refreshTokens[token.ID]; ok {
+		return fmt.Errorf("failed to create refresh token: duplicate id %s", token.ID)
+	}
+
+	copied := *token
+	// 保存時点では未使用・未ローテーション（INSERTで設定しないカラム）
+	copied.UsedAt = nil
+	copied.SuccessorID = nil
+	copied.RevokedAt = nil
+	r.store.refreshTokens[token.ID] = &copied
+	return nil
+}
+
+// GetByID IDからリフレッシュトークンを取得
+func (r *refreshTokenRepository) GetByID(_ context.Context, id uuid.UUID) (*domain.RefreshToken, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	t, ok := r.store.refreshTokens[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	copied := *t
+	return &copied, nil
+}
+
+// GetByTokenHash トークンハッシュからリフレッシュトークンを取得
+func (r *refreshTokenRepository) GetByTokenHash(_ context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	for _, t := range r.store.refreshTokens {
+		if t.TokenHash == tokenHash {
+			copied := *t
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+// MarkAsUsed 未使用のトークンを使用済みとしてマーク（既に使用済みならfalse）
+func (r *refreshTokenRepository) MarkAsUsed(_ context.Context, id uuid.UUID) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	t, ok := r.store.refreshTokens[id]
+	if !ok {
+		return false, domain.ErrNotFound
+	}
+	if t.UsedAt != nil {
+		return false, nil
+	}
+	now := domain.Now()
+	t.UsedAt = &now
+	return true, nil
+}
+
+// MarkAsRotated 未使用のトークンを使用済みとしてマークし、次のトークンを記録（既に使用済みならfalse）
+func (r *refreshTokenRepository) MarkAsRotated(_ context.Context, id, successorID uuid.UUID) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	t, ok := r.store.refreshTokens[id]
+	if !ok {
+		return false, domain.ErrNotFound
+	}
+	if t.UsedAt != nil {
+		return false, nil
+	}
+	now := domain.Now()
+	t.UsedAt = &now
+	t.SuccessorID = &successorID
+	return true, nil
+}
+
+// Revoke トークンを無効化
+func (r *refreshTokenRepository) Revoke(_ context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	t, ok := r.store.refreshTokens[id]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	now := domain.Now()
+	t.RevokedAt = &now
+	return nil
+}
+
+// RevokeByAccountID アカウントIDに紐づく未失効のトークンを無効化し、無効化した件数を返す
+func (r *refreshTokenRepository) RevokeByAccountID(_ context.Context, accountID uuid.UUID) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	var revoked int64
+	now := domain.Now()
+	for _, t := range r.store.refreshTokens {
+		if t.AccountID == accountID && t.RevokedAt == nil {
+			t.RevokedAt = &now
+			revoked++
+		}
+	}
+	return revoked, nil
+}
+
+// RevokeByIP 作成元のIPアドレスが一致する未失効のトークンを無効化し、無効化した件数を返す
+func (r *refreshTokenRepository) RevokeByIP(ctx context.Context, ipAddress string) (int64, error) {
+	return r.revokeMatching(ctx, func(t *domain.RefreshToken) bool {
+		return t.IPAddress != nil && *t.IPAddress == ipAddress
+	}), nil
+}
+
+// RevokeByUserAgent 作成元のUser-Agentが完全一致する未失効のトークンを無効化し、無効化した件数を返す
+func (r *refreshTokenRepository) RevokeByUserAgent(ctx context.Context, userAgent string) (int64, error) {
+	return r.revokeMatching(ctx, func(t *domain.RefreshToken) bool {
+		return t.UserAgent != nil && *t.UserAgent == userAgent
+	}), nil
+}
+
+// revokeMatching 条件に一致するコンテキストのテナントの未失効のトークンを無効化
+func (r *refreshTokenRepository) revokeMatching(ctx context.Context, match func(*domain.RefreshToken) bool) int64 {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	var revoked int64
+	now := domain.Now()
+	for _, t := range r.store.refreshTokens {
+		if t.RevokedAt != nil || !match(t) {
+			continue
+		}
+		if account, ok := r.store.accounts[t.AccountID]; !ok || !domain.InTenant(ctx, account.TenantID) {
+			continue
+		}
+		t.RevokedAt = &now
+		revoked++
+	}
+	return revoked
+}
+
+// CountRotatedSince since以降にリフレッシュで使用済みになったアカウントのトークン数を取得
+func (r *refreshTokenRepository) CountRotatedSince(_ context.Context, accountID uuid.UUID, since time.Time) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	count := 0
+	for _, t := range r.store.refreshTokens {
+		if t.AccountID == accountID && t.UsedAt != nil && !t.UsedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// ListRecentByAccountID アカウントのリフレッシュトークンを失効・期限切れを含めて新しい順に最大limit件取得
+func (r *refreshTokenRepository) ListRecentByAccountID(_ context.Context, accountID uuid.UUID, limit int) ([]*domain.RefreshToken, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	tokens := make([]*domain.RefreshToken, 0)
+	for _, t := range r.store.refreshTokens {
+		if t.AccountID == accountID {
+			copied := *t
+			tokens = append(tokens, &copied)
+		}
+	}
+	sortNewestFirst(tokens, func(t *domain.RefreshToken) (time.Time, uuid.UUID) { return t.CreatedAt, t.ID })
+	return truncate(tokens, limit), nil
+}
+
+// DeleteExpired 有効期限切れのトークンを削除し、削除した件数を返す
+// メモリ上の削除はロックを長く保持しないため、バッチに分けずに一度に削除する
+func (r *refreshTokenRepository) DeleteExpired(_ context.Context, batchSize int, _ time.Duration) (int64, error) {
+	if batchSize <= 0 {
+		return 0, fmt.Errorf("batch size must be positive: %d", batchSize)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	now := domain.Now()
+	var deleted int64
+	for id, t := range r.store.refreshTokens {
+		if t.ExpiresAt.Before(now) {
+			delete(r.store.refreshTokens, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/google/uuid"
+)
+
+// securityAuditLogRepository domain.SecurityAuditLogRepositoryのインメモリ実装
+type securityAuditLogRepository struct {
+	store *Store
+}
+
+// Create セキュリティ監査ログを作成
+func (r *securityAuditLogRepository) Create(_ context.Context, log *domain.SecurityAuditLog) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	copied := *log
+	copied.Metadata = slices.Clone(log.Metadata)
+	r.store.auditLogs = append(r.store.auditLogs, &copied)
+	return nil
+}
+
+// GetByAccountID アカウントIDからセキュリティ監査ログを新しい順に取得
+func (r *securityAuditLogRepository) GetByAccountID(_ context.Context, accountID uuid.UUID, limit, offset int) ([]*domain.SecurityAuditLog, error) {
+	logs := r.match(func(l *domain.SecurityAuditLog) bool { return l.AccountID == accountID })
+	return paginate(logs, nil, limit, offset, func(l *domain.SecurityAuditLog) uuid.UUID { return l.ID }), nil
+}
+
+// GetByEventType イベントタイプからセキュリティ監査ログを新しい順に取得
+func (r *securityAuditLogRepository) GetByEventType(_ context.Context, eventType domain.SecurityEventType, limit, offset int) ([]*domain.SecurityAuditLog, error) {
+	logs := r.match(func(l *domain.SecurityAuditLog) bool { return l.EventType == eventType })
+	return paginate(logs, nil, limit, offset, func(l *domain.SecurityAuditLog) uuid.UUID { return l.ID }), nil
+}
+
+// CountByAccountID アカウントIDごとのログ数を取得
+func (r *securityAuditLogRepository) CountByAccountID(_ context.Context, accountID uuid.UUID) (int, error) {
+	return len(r.match(func(l *domain.SecurityAuditLog) bool { return l.AccountID == accountID })), nil
+}
+
+// match 条件に一致するログを新しい順に返す
+func (r *securityAuditLogRepository) match(keep func(*domain.SecurityAuditLog) bool) []*domain.SecurityAuditLog {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	matched := make([]*domain.SecurityAuditLog, 0)
+	for _, l := range r.store.auditLogs {
+		if keep(l) {
+			copied := *l
+			matched = append(matched, &copied)
+		}
+	}
+	sortNewestFirst(matched, func(l *domain.SecurityAuditLog) (time.Time, uuid.UUID) { return l.CreatedAt, l.ID })
+	return matched
+}
@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/google/uuid"
+)
+
+// signingKeyRepository domain.SigningKeyRepositoryのインメモリ実装
+type signingKeyRepository struct {
+	store *Store
+}
+
+// Create 署名鍵を追加
+func (r *signingKeyRepository) Create(_ context.Context, key *domain.SigningKey) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	copied := *key
+	r.store.signingKeys = append(r.store.signingKeys, &copied)
+	return nil
+}
+
+// List 署名鍵を有効になる日時の新しい順に取得
+func (r *signingKeyRepository) List(_ context.Context) ([]*domain.SigningKey, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	keys := make([]*domain.SigningKey, 0, len(r.store.signingKeys))
+	for _, k := range r.store.signingKeys {
+		copied := *k
+		keys = append(keys, &copied)
+	}
+	sort.SliceStable(keys, func(i, j int) bool { return keys[i].ActivatesAt.After(keys[j].ActivatesAt) })
+	return keys, nil
+}
+
+// MarkRotating ローテーションが未予定の署名鍵に次の鍵へ引き継ぐ日時と引退する日時を記録
+func (r *signingKeyRepository) MarkRotating(_ context.Context, id uuid.UUID, rotatesAt, retiresAt time.Time) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, k := range r.store.signingKeys {
+		if k.ID == id && k.RotatesAt == nil {
+			k.RotatesAt = &rotatesAt
+			k.RetiresAt = &retiresAt
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// DeleteRetired 検証に使用しなくなった署名鍵を削除
+func (r *signingKeyRepository) DeleteRetired(_ context.Context, now time.Time) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	before := len(r.store.signingKeys)
+	r.store.signingKeys = deleteWhere(r.store.signingKeys, func(k *domain.SigningKey) bool { return !k.Verifies(now) })
+	return int64(before - len(r.store.signingKeys)), nil
+}
@@ -0,0 +1,123 @@
+// Package memory MySQLを使わずにメモリ上でデータを保持するリポジトリの実装
+// テストやローカル開発・デモ向け（DB_DRIVER=memory）で、プロセスを終了するとデータは失われる
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/google/uuid"
+)
+
+// Store すべてのリポジトリが共有するインメモリのデータ
+// データベースと同じく、アカウントの削除時は関連するデータを連鎖して削除する
+type Store struct {
+	mu            sync.RWMutex
+	accounts      map[uuid.UUID]*domain.Account
+	projects      map[uuid.UUID]*domain.Project
+	refreshTokens map[uuid.UUID]*domain.RefreshToken
+	auditLogs     []*domain.SecurityAuditLog
+	histories     []*domain.PasswordHistory
+	signingKeys   []*domain.SigningKey
+
+	account         *accountRepository
+	project         *projectRepository
+	refreshToken    *refreshTokenRepository
+	securityAudit   *securityAuditLogRepository
+	passwordHistory *passwordHistoryRepository
+	signingKey      *signingKeyRepository
+}
+
+// NewStore 空のストアを作成
+func NewStore() *Store {
+	s := &Store{
+		accounts:      make(map[uuid.UUID]*domain.Account),
+		projects:      make(map[uuid.UUID]*domain.Project),
+		refreshTokens: make(map[uuid.UUID]*domain.RefreshToken),
+	}
+	s.account = &accountRepository{store: s}
+	s.project = &projectRepository{store: s}
+	s.refreshToken = &refreshTokenRepository{store: s}
+	s.securityAudit = &securityAuditLogRepository{store: s}
+	s.passwordHistory = &passwordHistoryRepository{store: s}
+	s.signingKey = &signingKeyRepository{store: s}
+	return s
+}
+
+// Account アカウントリポジトリを返す
+func (s *Store) Account() domain.AccountRepository {
+	return s.account
+}
+
+// Project プロジェクトリポジトリを返す
+func (s *Store) Project() domain.ProjectRepository {
+	return s.project
+}
+
+// RefreshToken リフレッシュトークンリポジトリを返す
+func (s *Store) RefreshToken() domain.RefreshTokenRepository {
+	return s.refreshToken
+}
+
+// SecurityAuditLog セキュリティ監査ログリポジトリを返す
+func (s *Store) SecurityAuditLog() domain.SecurityAuditLogRepository {
+	return s.securityAudit
+}
+
+// PasswordHistory パスワード履歴リポジトリを返す
+func (s *Store) PasswordHistory() domain.PasswordHistoryRepository {
+	return s.passwordHistory
+}
+
+// SigningKey 署名鍵リポジトリを返す
+func (s *Store) SigningKey() domain.SigningKeyRepository {
+	return s.signingKey
+}
+
+// deleteAccountLocked アカウントと関連するデータを削除（ON DELETE CASCADE / SET NULLに相当）
+// 呼び出し側でロックを取得していること
+func (s *Store) deleteAccountLocked(id uuid.UUID) {
+	delete(s.accounts, id)
+
+	for projectID, p := range s.projects {
+		switch {
+		case p.AccountID == id:
+			delete(s.projects, projectID)
+		default:
+			if p.CreatedBy != nil && *p.CreatedBy == id {
+				p.CreatedBy = nil
+			}
+			if p.UpdatedBy != nil && *p.UpdatedBy == id {
+				p.UpdatedBy = nil
+			}
+		}
+	}
+	for tokenID, t := range s.refreshTokens {
+		if t.AccountID == id {
+			delete(s.refreshTokens, tokenID)
+		}
+	}
+	s.auditLogs = deleteWhere(s.auditLogs, func(l *domain.SecurityAuditLog) bool { return l.AccountID == id })
+	s.histories = deleteWhere(s.histories, func(h *domain.PasswordHistory) bool { return h.AccountID == id })
+}
+
+// deleteWhere 条件に一致する要素を取り除いたスライスを返す
+func deleteWhere[T any](items []T, match func(T) bool) []T {
+	kept := items[:0]
+	for _, item := range items {
+		if !match(item) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// TransactionManager トランザクションを張らずに処理をそのまま実行する
+// インメモリのリポジトリは操作ごとにロックを取得するため、途中で失敗しても変更は巻き戻らない
+type TransactionManager struct{}
+
+// RunInTransaction 処理をそのまま実行
+func (TransactionManager) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"slices"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+)
+
+// cursorCondition カーソルの前後の項目に絞り込む条件とパラメータを返す
+// IDの文字列表現は固定長の16進数のため、文字列の大小がUUIDの大小と一致する
+// aliasはJOIN時のテーブル別名（例: "a."）
+func cursorCondition(cursor *domain.PageCursor, alias string) (string, interface{}) {
+	if cursor.Before {
+		return alias + "id > ?", cursor.ID
+	}
+	return alias + "id < ?", cursor.ID
+}
+
+// pageOrder ページングの並び順を返す
+// カーソルを指定しない場合は作成日時の新しい順、指定した場合はIDのみで並べてインデックスで範囲検索する
+// 前のページはカーソルに近い順に取得し、reversePageで新しい順に戻す
+func pageOrder(cursor *domain.PageCursor, alias string) string {
+	switch {
+	case cursor == nil:
+		return "ORDER BY " + alias + "created_at DESC, " + alias + "id DESC"
+	case cursor.Before:
+		return "ORDER BY " + alias + "id ASC"
+	default:
+		return "ORDER BY " + alias + "id DESC"
+	}
+}
+
+// reversePage 前のページをカーソルに近い順で取得した結果を新しい順に並べ替える
+func reversePage[T any](cursor *domain.PageCursor, items []T) {
+	if cursor != nil && cursor.Before {
+		slices.Reverse(items)
+	}
+}
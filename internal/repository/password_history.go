@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/infrastructure/database"
+	"github.com/google/uuid"
+)
+
+// passwordHistoryDB データベース用のパスワード履歴構造体
+type passwordHistoryDB struct {
+	ID           string    `db:"id"`
+	AccountID    string    `db:"account_id"`
+	PasswordHash string    `db:"password_hash"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// toDomain DB構造体からドメインモデルへ変換
+func (h *passwordHistoryDB) toDomain() (*domain.PasswordHistory, error) {
+	id, err := uuid.Parse(h.ID)
+	if err != nil {
+		return nil, err
+	}
+	accountID, err := uuid.Parse(h.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PasswordHistory{
+		ID:           id,
+		AccountID:    accountID,
+		PasswordHash: h.PasswordHash,
+		CreatedAt:    h.CreatedAt,
+	}, nil
+}
+
+// PasswordHistoryRepository パスワード履歴リポジトリの実装
+type PasswordHistoryRepository struct {
+	db *database.DB
+}
+
+// NewPasswordHistoryRepository 新しいパスワード履歴リポジトリを作成
+func NewPasswordHistoryRepository(db *database.DB) domain.PasswordHistoryRepository {
+	return &PasswordHistoryRepository{db: db}
+}
+
+// Create パスワード履歴を追加
+func (r *PasswordHistoryRepository) Create(ctx context.Context, history *domain.PasswordHistory) error {
+	query := `
+		INSERT INTO password_history (id, account_id, password_hash, created_at)
+		VALUES (?, ?, ?, ?)
+	`
+
+	exec := database.GetExecutor(ctx, r.db)
+	_, err := exec.ExecContext(ctx, query,
+		history.ID.String(),
+		history.AccountID.String(),
+		history.PasswordHash,
+		history.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create password history: %w", err)
+	}
+
+	return nil
+}
+
+// ListRecent アカウントの直近のパスワード履歴を新しい順に取得
+func (r *PasswordHistoryRepository) ListRecent(ctx context.Context, accountID uuid.UUID, limit int) ([]*domain.PasswordHistory, error) {
+	var rows []passwordHistoryDB
+
+	query := `
+		SELECT id, account_id, password_hash, created_at
+		FROM password_history
+		WHERE account_id = ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?
+	`
+
+	exec := database.GetExecutor(ctx, r.db)
+	if err := exec.SelectContext(ctx, &rows, query, accountID.String(), limit); err != nil {
+		return nil, fmt.Errorf("failed to list password history: %w", err)
+	}
+
+	histories := make([]*domain.PasswordHistory, 0, len(rows))
+	for i := range rows {
+		history, err := rows[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		histories = append(histories, history)
+	}
+
+	return histories, nil
+}
+
+// Prune 新しい順にkeep件を残して古いパスワード履歴を削除
+func (r *PasswordHistoryRepository) Prune(ctx context.Context, accountID uuid.UUID, keep int) error {
+	// MySQLはサブクエリ内のLIMITを直接使えないため、派生テーブルで残す行を求める
+	query := `
+		DELETE FROM password_history
+		WHERE account_id = ?
+		  AND id NOT IN (
+			SELECT id FROM (
+				SELECT id FROM password_history
+				WHERE account_id = ?
+				ORDER BY created_at DESC, id DESC
+				LIMIT ?
+			) AS recent
+		  )
+	`
+
+	exec := database.GetExecutor(ctx, r.db)
+	if _, err := exec.ExecContext(ctx, query, accountID.String(), accountID.String(), keep); err != nil {
+		return fmt.Errorf("failed to prune password history: %w", err)
+	}
+
+	return nil
+}
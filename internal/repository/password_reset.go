@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/infrastructure/database"
+	"github.com/google/uuid"
+)
+
+// passwordResetDB データベース用のパスワード再設定トークン構造体
+type passwordResetDB struct {
+	ID         string     `db:"id"`
+	AccountID  string     `db:"account_id"`
+	TokenHash  string     `db:"token_hash"`
+	ExpiresAt  time.Time  `db:"expires_at"`
+	ConsumedAt *time.Time `db:"consumed_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+}
+
+// toDomain DB構造体からドメインモデルへ変換
+func (r *passwordResetDB) toDomain() (*domain.PasswordReset, error) {
+	id, err := uuid.Parse(r.ID)
+	if err != nil {
+		return nil, err
+	}
+	accountID, err := uuid.Parse(r.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PasswordReset{
+		ID:         id,
+		AccountID:  accountID,
+		TokenHash:  r.TokenHash,
+		ExpiresAt:  r.ExpiresAt,
+		ConsumedAt: r.ConsumedAt,
+		CreatedAt:  r.CreatedAt,
+	}, nil
+}
+
+// PasswordResetRepository パスワード再設定トークンリポジトリの実装
+type PasswordResetRepository struct {
+	db *database.DB
+}
+
+// NewPasswordResetRepository 新しいパスワード再設定トークンリポジトリを作成
+func NewPasswordResetRepository(db *database.DB) domain.PasswordResetRepository {
+	return &PasswordResetRepository{db: db}
+}
+
+// Create パスワード再設定トークンを作成
+func (r *PasswordResetRepository) Create(ctx context.Context, reset *domain.PasswordReset) error {
+	query := `
+		INSERT INTO password_reset_tokens (id, account_id, token_hash, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	exec := database.GetExecutor(ctx, r.db)
+	_, err := exec.ExecContext(ctx, query,
+		reset.ID.String(),
+		reset.AccountID.String(),
+		reset.TokenHash,
+		reset.ExpiresAt,
+		reset.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create password reset: %w", err)
+	}
+
+	return nil
+}
+
+// GetByTokenHash トークンハッシュからパスワード再設定トークンを取得
+func (r *PasswordResetRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.PasswordReset, error) {
+	var row passwordResetDB
+
+	query := `
+		SELECT id, account_id, token_hash, expires_at, consumed_at, created_at
+		FROM password_reset_tokens
+		WHERE token_hash = ?
+	`
+
+	exec := database.GetExecutor(ctx, r.db)
+	if err := exec.GetContext(ctx, &row, query, tokenHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get password reset: %w", err)
+	}
+
+	return row.toDomain()
+}
+
+// MarkAsConsumed 未使用かつ有効期限内のパスワード再設定トークンを消費済みとしてマーク
+// 同時に別のリクエストが消費していた場合や期限切れの場合はfalseを返す
+func (r *PasswordResetRepository) MarkAsConsumed(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `
+		UPDATE password_reset_tokens
+		SET consumed_at = ?
+		WHERE id = ? AND consumed_at IS NULL AND expires_at > ?
+	`
+
+	now := domain.Now()
+	exec := database.GetExecutor(ctx, r.db)
+	result, err := exec.ExecContext(ctx, query, now, id.String(), now)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark password reset as consumed: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// InvalidateByAccountID アカウントの未使用のパスワード再設定トークンをすべて消費済みにする
+// 新しいトークンを発行する前に呼び出し、以前に送信したトークンを使えなくする
+func (r *PasswordResetRepository) InvalidateByAccountID(ctx context.Context, accountID uuid.UUID) error {
+	query := `
+		UPDATE password_reset_tokens
+		SET consumed_at = ?
+		WHERE account_id = ? AND consumed_at IS NULL
+	`
+
+	exec := database.GetExecutor(ctx, r.db)
+	if _, err := exec.ExecContext(ctx, query, domain.Now(), accountID.String()); err != nil {
+		return fmt.Errorf("failed to invalidate password resets: %w", err)
+	}
+
+	return nil
+}
@@ -4,21 +4,23 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"time"
+	"strings"
 
 	"github.com/aida0710/jwt-auth/internal/domain"
 	"github.com/aida0710/jwt-auth/internal/infrastructure/database"
 	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
 )
 
+// projectColumns domain.Projectに読み込むカラムの一覧
+const projectColumns = `id, tenant_id, account_id, name, description, status, created_by, updated_by, created_at, updated_at`
+
 // projectRepository repository.ProjectRepositoryの実装
 type projectRepository struct {
-	db *sqlx.DB
+	db *database.DB
 }
 
 // NewProjectRepository プロジェクトリポジトリを作成
-func NewProjectRepository(db *sqlx.DB) domain.ProjectRepository {
+func NewProjectRepository(db *database.DB) domain.ProjectRepository {
 	return &projectRepository{
 		db: db,
 	}
@@ -27,17 +29,28 @@ func NewProjectRepository(db *sqlx.DB) domain.ProjectRepository {
 // Create 新しいプロジェクトを作成
 func (r *projectRepository) Create(ctx context.Context, project *domain.Project) error {
 	query := `
-		INSERT INTO projects (id, account_id, name, description, status, created_at, updated_at)
-		VALUES (:id, :account_id, :name, :description, :status, :created_at, :updated_at)
+		INSERT INTO projects (id, tenant_id, account_id, name, description, status, created_by, updated_by, created_at, updated_at)
+		VALUES (:id, :tenant_id, :account_id, :name, :description, :status, :created_by, :updated_by, :created_at, :updated_at)
 	`
 
-	now := time.Now()
+	if project.TenantID == "" {
+		project.TenantID = domain.ResolveTenantID(ctx)
+	}
+	if !project.BelongsTo(ctx) {
+		return domain.ErrForbidden
+	}
+
+	now := domain.Now()
 	project.CreatedAt = now
 	project.UpdatedAt = now
 
 	exec := database.GetExecutor(ctx, r.db)
 	_, err := exec.NamedExecContext(ctx, query, project)
 	if err != nil {
+		// アカウント内のプロジェクト名の一意制約違反を重複エラーに変換（PROJECT_UNIQUE_NAMESのマイグレーション適用時）
+		if database.IsUniqueViolation(err) {
+			return domain.ErrDuplicateProjectName
+		}
 		return err
 	}
 
@@ -47,17 +60,18 @@ func (r *projectRepository) Create(ctx context.Context, project *domain.Project)
 // GetByID IDでプロジェクトを取得
 func (r *projectRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Project, error) {
 	var project domain.Project
+	tenant, tenantArgs := tenantCondition(ctx, "")
 	query := `
-		SELECT id, account_id, name, description, status, created_at, updated_at
+		SELECT ` + projectColumns + `
 		FROM projects
-		WHERE id = ?
+		WHERE id = ?` + tenant + `
 	`
 
 	exec := database.GetExecutor(ctx, r.db)
-	err := exec.GetContext(ctx, &project, query, id)
+	err := exec.GetContext(ctx, &project, query, append([]interface{}{id}, tenantArgs...)...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, nil
+			return nil, domain.ErrProjectNotFound
 		}
 		return nil, err
 	}
@@ -68,15 +82,16 @@ func (r *projectRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 // GetByAccountID アカウントIDでプロジェクトを取得
 func (r *projectRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.Project, error) {
 	projects := make([]*domain.Project, 0)
+	where, args := projectFilterClause(ctx, domain.ProjectFilter{AccountID: &accountID})
 	query := `
-		SELECT id, account_id, name, description, status, created_at, updated_at
+		SELECT ` + projectColumns + `
 		FROM projects
-		WHERE account_id = ?
+	` + where + `
 		ORDER BY created_at DESC
 	`
 
 	exec := database.GetExecutor(ctx, r.db)
-	err := exec.SelectContext(ctx, &projects, query, accountID)
+	err := exec.SelectContext(ctx, &projects, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -87,34 +102,110 @@ func (r *projectRepository) GetByAccountID(ctx context.Context, accountID uuid.U
 // List すべてのプロジェクトを取得
 func (r *projectRepository) List(ctx context.Context) ([]*domain.Project, error) {
 	projects := make([]*domain.Project, 0)
+	where, args := projectFilterClause(ctx, domain.ProjectFilter{})
 	query := `
-		SELECT id, account_id, name, description, status, created_at, updated_at
+		SELECT ` + projectColumns + `
 		FROM projects
+	` + where + `
 		ORDER BY created_at DESC
 	`
 
 	exec := database.GetExecutor(ctx, r.db)
-	err := exec.SelectContext(ctx, &projects, query)
+	err := exec.SelectContext(ctx, &projects, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// Search 条件に一致するプロジェクトをページ単位で取得
+func (r *projectRepository) Search(ctx context.Context, filter domain.ProjectFilter) ([]*domain.Project, error) {
+	projects := make([]*domain.Project, 0)
+	where, args := projectFilterClause(ctx, filter)
+	query := `
+		SELECT ` + projectColumns + `
+		FROM projects
+	` + where + `
+		` + pageOrder(filter.Cursor, "") + `
+		LIMIT ? OFFSET ?
+	`
+	args = append(args, filter.Limit, filter.Offset)
+
+	exec := database.GetExecutor(ctx, r.db)
+	err := exec.SelectContext(ctx, &projects, query, args...)
 	if err != nil {
 		return nil, err
 	}
+	reversePage(filter.Cursor, projects)
 
 	return projects, nil
 }
 
+// Count 条件に一致するプロジェクトの総数を取得（カーソルの位置に関係なく数える）
+func (r *projectRepository) Count(ctx context.Context, filter domain.ProjectFilter) (int, error) {
+	var count int
+	filter.Cursor = nil
+	where, args := projectFilterClause(ctx, filter)
+	query := `SELECT COUNT(*) FROM projects ` + where
+
+	exec := database.GetExecutor(ctx, r.db)
+	err := exec.GetContext(ctx, &count, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// projectFilterClause 検索条件とコンテキストのテナントからWHERE句とパラメータを組み立てる
+func projectFilterClause(ctx context.Context, filter domain.ProjectFilter) (string, []interface{}) {
+	conditions := make([]string, 0, 4)
+	args := make([]interface{}, 0, 5)
+	if tenantID, ok := domain.TenantIDFromContext(ctx); ok {
+		conditions = append(conditions, "tenant_id = ?")
+		args = append(args, tenantID)
+	}
+	if filter.AccountID != nil {
+		conditions = append(conditions, "account_id = ?")
+		args = append(args, *filter.AccountID)
+	}
+	if filter.Status != nil {
+		conditions = append(conditions, "status = ?")
+		args = append(args, *filter.Status)
+	}
+	if filter.Cursor != nil {
+		condition, arg := cursorCondition(filter.Cursor, "")
+		conditions = append(conditions, condition)
+		args = append(args, arg)
+	}
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
 // Update プロジェクトを更新
 func (r *projectRepository) Update(ctx context.Context, project *domain.Project) error {
 	query := `
 		UPDATE projects
-		SET name = :name, description = :description, status = :status, updated_at = :updated_at
-		WHERE id = :id
+		SET name = :name, description = :description, status = :status, updated_by = :updated_by, updated_at = :updated_at
+		WHERE id = :id AND tenant_id = :tenant_id
 	`
 
-	project.UpdatedAt = time.Now()
+	// 他のテナントのプロジェクトは存在しないものとして扱う
+	if !project.BelongsTo(ctx) {
+		return domain.ErrProjectNotFound
+	}
+
+	project.UpdatedAt = domain.Now()
 
 	exec := database.GetExecutor(ctx, r.db)
 	result, err := exec.NamedExecContext(ctx, query, project)
 	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return domain.ErrDuplicateProjectName
+		}
 		return err
 	}
 
@@ -132,10 +223,11 @@ func (r *projectRepository) Update(ctx context.Context, project *domain.Project)
 
 // Delete プロジェクトを削除
 func (r *projectRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM projects WHERE id = ?`
+	tenant, tenantArgs := tenantCondition(ctx, "")
+	query := `DELETE FROM projects WHERE id = ?` + tenant
 
 	exec := database.GetExecutor(ctx, r.db)
-	result, err := exec.ExecContext(ctx, query, id)
+	result, err := exec.ExecContext(ctx, query, append([]interface{}{id}, tenantArgs...)...)
 	if err != nil {
 		return err
 	}
@@ -154,10 +246,11 @@ func (r *projectRepository) Delete(ctx context.Context, id uuid.UUID) error {
 
 // DeleteByAccountID アカウントIDですべてのプロジェクトを削除
 func (r *projectRepository) DeleteByAccountID(ctx context.Context, accountID uuid.UUID) error {
-	query := `DELETE FROM projects WHERE account_id = ?`
+	tenant, tenantArgs := tenantCondition(ctx, "")
+	query := `DELETE FROM projects WHERE account_id = ?` + tenant
 
 	exec := database.GetExecutor(ctx, r.db)
-	_, err := exec.ExecContext(ctx, query, accountID)
+	_, err := exec.ExecContext(ctx, query, append([]interface{}{accountID}, tenantArgs...)...)
 	if err != nil {
 		return err
 	}
@@ -8,21 +8,25 @@ import (
 	"time"
 
 	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/infrastructure/database"
 	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
 )
 
 // refreshTokenDB データベース用のリフレッシュトークン構造体
 type refreshTokenDB struct {
-	ID        string     `db:"id"`
-	AccountID string     `db:"account_id"`
-	TokenHash string     `db:"token_hash"`
-	ExpiresAt time.Time  `db:"expires_at"`
-	CreatedAt time.Time  `db:"created_at"`
-	UsedAt    *time.Time `db:"used_at"`
-	RevokedAt *time.Time `db:"revoked_at"`
-	UserAgent *string    `db:"user_agent"`
-	IPAddress *string    `db:"ip_address"`
+	ID                string     `db:"id"`
+	AccountID         string     `db:"account_id"`
+	FamilyID          *string    `db:"family_id"` // 移行前のレコードはNULL
+	TokenHash         string     `db:"token_hash"`
+	ExpiresAt         time.Time  `db:"expires_at"`
+	AbsoluteExpiresAt *time.Time `db:"absolute_expires_at"` // 移行前のレコードはNULL
+	CreatedAt         time.Time  `db:"created_at"`
+	UsedAt            *time.Time `db:"used_at"`
+	SuccessorID       *string    `db:"successor_id"`
+	RevokedAt         *time.Time `db:"revoked_at"`
+	UserAgent         *string    `db:"user_agent"`
+	IPAddress         *string    `db:"ip_address"`
+	DeviceName        *string    `db:"device_name"`
 }
 
 // toDomain DB構造体からドメインモデルへ変換
@@ -36,41 +40,71 @@ func (r *refreshTokenDB) toDomain() (*domain.RefreshToken, error) {
 		return nil, err
 	}
 
+	// 移行前のレコードは単独のファミリーとして扱う
+	familyID := id
+	if r.FamilyID != nil {
+		familyID, err = uuid.Parse(*r.FamilyID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	absoluteExpiresAt := r.ExpiresAt
+	if r.AbsoluteExpiresAt != nil {
+		absoluteExpiresAt = *r.AbsoluteExpiresAt
+	}
+	var successorID *uuid.UUID
+	if r.SuccessorID != nil {
+		parsed, err := uuid.Parse(*r.SuccessorID)
+		if err != nil {
+			return nil, err
+		}
+		successorID = &parsed
+	}
+
 	return &domain.RefreshToken{
-		ID:        id,
-		AccountID: accountID,
-		TokenHash: r.TokenHash,
-		ExpiresAt: r.ExpiresAt,
-		CreatedAt: r.CreatedAt,
-		UsedAt:    r.UsedAt,
-		RevokedAt: r.RevokedAt,
-		UserAgent: r.UserAgent,
-		IPAddress: r.IPAddress,
+		ID:                id,
+		AccountID:         accountID,
+		FamilyID:          familyID,
+		TokenHash:         r.TokenHash,
+		ExpiresAt:         r.ExpiresAt,
+		AbsoluteExpiresAt: absoluteExpiresAt,
+		CreatedAt:         r.CreatedAt,
+		UsedAt:            r.UsedAt,
+		SuccessorID:       successorID,
+		RevokedAt:         r.RevokedAt,
+		UserAgent:         r.UserAgent,
+		IPAddress:         r.IPAddress,
+		DeviceName:        r.DeviceName,
 	}, nil
 }
 
 // fromDomain ドメインモデルからDB構造体へ変換
 func fromDomainRefreshToken(token *domain.RefreshToken) *refreshTokenDB {
+	familyID := token.FamilyID.String()
+	absoluteExpiresAt := token.AbsoluteExpiresAt
 	return &refreshTokenDB{
-		ID:        token.ID.String(),
-		AccountID: token.AccountID.String(),
-		TokenHash: token.TokenHash,
-		ExpiresAt: token.ExpiresAt,
-		CreatedAt: token.CreatedAt,
-		UsedAt:    token.UsedAt,
-		RevokedAt: token.RevokedAt,
-		UserAgent: token.UserAgent,
-		IPAddress: token.IPAddress,
+		ID:                token.ID.String(),
+		AccountID:         token.AccountID.String(),
+		FamilyID:          &familyID,
+		TokenHash:         token.TokenHash,
+		ExpiresAt:         token.ExpiresAt,
+		AbsoluteExpiresAt: &absoluteExpiresAt,
+		CreatedAt:         token.CreatedAt,
+		UsedAt:            token.UsedAt,
+		RevokedAt:         token.RevokedAt,
+		UserAgent:         token.UserAgent,
+		IPAddress:         token.IPAddress,
+		DeviceName:        token.DeviceName,
 	}
 }
 
 // RefreshTokenRepository リフレッシュトークンリポジトリの実装
 type RefreshTokenRepository struct {
-	db *sqlx.DB
+	db *database.DB
 }
 
 // NewRefreshTokenRepository 新しいリフレッシュトークンリポジトリを作成
-func NewRefreshTokenRepository(db *sqlx.DB) domain.RefreshTokenRepository {
+func NewRefreshTokenRepository(db *database.DB) domain.RefreshTokenRepository {
 	return &RefreshTokenRepository{db: db}
 }
 
@@ -78,41 +112,73 @@ func NewRefreshTokenRepository(db *sqlx.DB) domain.RefreshTokenRepository {
 func (r *RefreshTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
 	query := `
 		INSERT INTO refresh_tokens (
-			id, account_id, token_hash, expires_at, 
-			created_at, user_agent, ip_address
-		) VALUES (?, ?, ?, ?, ?, ?, ?)
+			id, account_id, family_id, token_hash, expires_at, 
+			absolute_expires_at, created_at, user_agent, ip_address, device_name
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	dbToken := fromDomainRefreshToken(token)
-	_, err := r.db.ExecContext(ctx, query,
+	exec := database.GetExecutor(ctx, r.db)
+	_, err := exec.ExecContext(ctx, query,
 		dbToken.ID,
 		dbToken.AccountID,
+		dbToken.FamilyID,
 		dbToken.TokenHash,
 		dbToken.ExpiresAt,
+		dbToken.AbsoluteExpiresAt,
 		dbToken.CreatedAt,
 		dbToken.UserAgent,
 		dbToken.IPAddress,
+		dbToken.DeviceName,
 	)
 
 	if err != nil {
+		// token_hashの一意制約違反は参照の整合性を壊さないよう明示的なエラーにする
+		if database.IsUniqueViolation(err) {
+			return domain.ErrDuplicateToken
+		}
 		return fmt.Errorf("failed to create refresh token: %w", err)
 	}
 
 	return nil
 }
 
+// GetByID IDからリフレッシュトークンを取得
+func (r *RefreshTokenRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.RefreshToken, error) {
+	var dbToken refreshTokenDB
+	query := `
+		SELECT 
+			id, account_id, family_id, token_hash, expires_at, absolute_expires_at,
+			created_at, used_at, successor_id, revoked_at, user_agent, ip_address, device_name
+		FROM refresh_tokens 
+		WHERE id = ?
+	`
+
+	exec := database.GetExecutor(ctx, r.db)
+	err := exec.GetContext(ctx, &dbToken, query, id.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	return dbToken.toDomain()
+}
+
 // GetByTokenHash トークンハッシュからリフレッシュトークンを取得
 func (r *RefreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
 	var dbToken refreshTokenDB
 	query := `
 		SELECT 
-			id, account_id, token_hash, expires_at, created_at,
-			used_at, revoked_at, user_agent, ip_address
+			id, account_id, family_id, token_hash, expires_at, absolute_expires_at,
+			created_at, used_at, successor_id, revoked_at, user_agent, ip_address, device_name
 		FROM refresh_tokens 
 		WHERE token_hash = ?
 	`
 
-	err := r.db.GetContext(ctx, &dbToken, query, tokenHash)
+	exec := database.GetExecutor(ctx, r.db)
+	err := exec.GetContext(ctx, &dbToken, query, tokenHash)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrNotFound
@@ -123,26 +189,79 @@ func (r *RefreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash s
 	return dbToken.toDomain()
 }
 
-// MarkAsUsed トークンを使用済みとしてマーク
-func (r *RefreshTokenRepository) MarkAsUsed(ctx context.Context, id uuid.UUID) error {
+// MarkAsUsed 未使用のトークンを使用済みとしてマーク
+// 同時に別のリクエストが使用済みにしていた場合はfalseを返す
+func (r *RefreshTokenRepository) MarkAsUsed(ctx context.Context, id uuid.UUID) (bool, error) {
 	query := `
 		UPDATE refresh_tokens 
 		SET used_at = ? 
-		WHERE id = ?
+		WHERE id = ? AND used_at IS NULL
 	`
 
-	result, err := r.db.ExecContext(ctx, query, time.Now(), id.String())
+	exec := database.GetExecutor(ctx, r.db)
+	result, err := exec.ExecContext(ctx, query, domain.Now(), id.String())
 	if err != nil {
-		return fmt.Errorf("failed to mark token as used: %w", err)
+		return false, fmt.Errorf("failed to mark token as used: %w", err)
 	}
 
 	rows, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to get affected rows: %w", err)
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
 	}
 
 	if rows == 0 {
-		return domain.ErrNotFound
+		// 更新されなかった原因が使用済みか存在しないかを区別する
+		if err := r.ensureExists(ctx, id); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// MarkAsRotated 未使用のトークンを使用済みとしてマークし、ローテーションで発行した次のトークンを記録
+// 同時に別のリクエストが使用済みにしていた場合はfalseを返す
+func (r *RefreshTokenRepository) MarkAsRotated(ctx context.Context, id, successorID uuid.UUID) (bool, error) {
+	query := `
+		UPDATE refresh_tokens 
+		SET used_at = ?, successor_id = ? 
+		WHERE id = ? AND used_at IS NULL
+	`
+
+	exec := database.GetExecutor(ctx, r.db)
+	result, err := exec.ExecContext(ctx, query, domain.Now(), successorID.String(), id.String())
+	if err != nil {
+		return false, fmt.Errorf("failed to mark token as rotated: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		// 更新されなかった原因が使用済みか存在しないかを区別する
+		if err := r.ensureExists(ctx, id); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// ensureExists トークンが存在するか確認（存在しない場合はErrNotFound）
+func (r *RefreshTokenRepository) ensureExists(ctx context.Context, id uuid.UUID) error {
+	var exists int
+	query := `SELECT 1 FROM refresh_tokens WHERE id = ?`
+
+	exec := database.GetExecutor(ctx, r.db)
+	if err := exec.GetContext(ctx, &exists, query, id.String()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to get refresh token: %w", err)
 	}
 
 	return nil
@@ -156,7 +275,8 @@ func (r *RefreshTokenRepository) Revoke(ctx context.Context, id uuid.UUID) error
 		WHERE id = ?
 	`
 
-	result, err := r.db.ExecContext(ctx, query, time.Now(), id.String())
+	exec := database.GetExecutor(ctx, r.db)
+	result, err := exec.ExecContext(ctx, query, domain.Now(), id.String())
 	if err != nil {
 		return fmt.Errorf("failed to revoke token: %w", err)
 	}
@@ -173,33 +293,158 @@ func (r *RefreshTokenRepository) Revoke(ctx context.Context, id uuid.UUID) error
 	return nil
 }
 
-// RevokeByAccountID アカウントIDに紐づくすべてのトークンを無効化
-func (r *RefreshTokenRepository) RevokeByAccountID(ctx context.Context, accountID uuid.UUID) error {
+// RevokeByAccountID アカウントIDに紐づくすべてのトークンを無効化し、無効化した件数を返す
+func (r *RefreshTokenRepository) RevokeByAccountID(ctx context.Context, accountID uuid.UUID) (int64, error) {
 	query := `
 		UPDATE refresh_tokens 
 		SET revoked_at = ? 
 		WHERE account_id = ? AND revoked_at IS NULL
 	`
 
-	_, err := r.db.ExecContext(ctx, query, time.Now(), accountID.String())
+	exec := database.GetExecutor(ctx, r.db)
+	result, err := exec.ExecContext(ctx, query, domain.Now(), accountID.String())
 	if err != nil {
-		return fmt.Errorf("failed to revoke tokens by account ID: %w", err)
+		return 0, fmt.Errorf("failed to revoke tokens by account ID: %w", err)
 	}
 
-	return nil
+	revoked, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get revoked token count: %w", err)
+	}
+
+	return revoked, nil
+}
+
+// RevokeByIP 作成元のIPアドレスが一致する未失効のトークンを無効化し、無効化した件数を返す
+func (r *RefreshTokenRepository) RevokeByIP(ctx context.Context, ipAddress string) (int64, error) {
+	revoked, err := r.revokeByColumn(ctx, "ip_address", ipAddress)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke tokens by IP address: %w", err)
+	}
+	return revoked, nil
 }
 
-// DeleteExpired 有効期限切れのトークンを削除
-func (r *RefreshTokenRepository) DeleteExpired(ctx context.Context) error {
+// RevokeByUserAgent 作成元のUser-Agentが完全一致する未失効のトークンを無効化し、無効化した件数を返す
+func (r *RefreshTokenRepository) RevokeByUserAgent(ctx context.Context, userAgent string) (int64, error) {
+	revoked, err := r.revokeByColumn(ctx, "user_agent", userAgent)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke tokens by user agent: %w", err)
+	}
+	return revoked, nil
+}
+
+// revokeByColumn 指定したカラムの値が一致する未失効のトークンを無効化
+// columnはインデックスのある固定のカラム名のみを渡す（利用者の入力を渡さない）
+// コンテキストにテナントが設定されている場合は、そのテナントのアカウントのトークンのみを対象にする
+func (r *RefreshTokenRepository) revokeByColumn(ctx context.Context, column, value string) (int64, error) {
 	query := `
-		DELETE FROM refresh_tokens 
-		WHERE expires_at < ?
+		UPDATE refresh_tokens
+		SET revoked_at = ?
+		WHERE ` + column + ` = ? AND revoked_at IS NULL
 	`
+	args := []interface{}{domain.Now(), value}
+	if tenantID, ok := domain.TenantIDFromContext(ctx); ok {
+		query += ` AND account_id IN (SELECT id FROM accounts WHERE tenant_id = ?)`
+		args = append(args, tenantID)
+	}
 
-	_, err := r.db.ExecContext(ctx, query, time.Now())
+	exec := database.GetExecutor(ctx, r.db)
+	result, err := exec.ExecContext(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("failed to delete expired tokens: %w", err)
+		return 0, err
 	}
 
-	return nil
+	revoked, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get revoked token count: %w", err)
+	}
+
+	return revoked, nil
+}
+
+// CountRotatedSince since以降にリフレッシュで使用済みになったアカウントのトークン数を取得
+func (r *RefreshTokenRepository) CountRotatedSince(ctx context.Context, accountID uuid.UUID, since time.Time) (int, error) {
+	var count int
+	query := `
+		SELECT COUNT(*) 
+		FROM refresh_tokens 
+		WHERE account_id = ? AND used_at >= ?
+	`
+
+	exec := database.GetExecutor(ctx, r.db)
+	if err := exec.GetContext(ctx, &count, query, accountID.String(), since); err != nil {
+		return 0, fmt.Errorf("failed to count rotated refresh tokens: %w", err)
+	}
+
+	return count, nil
+}
+
+// ListRecentByAccountID アカウントのリフレッシュトークンを失効・期限切れを含めて新しい順に最大limit件取得
+func (r *RefreshTokenRepository) ListRecentByAccountID(ctx context.Context, accountID uuid.UUID, limit int) ([]*domain.RefreshToken, error) {
+	var rows []refreshTokenDB
+	query := `
+		SELECT 
+			id, account_id, family_id, token_hash, expires_at, absolute_expires_at,
+			created_at, used_at, successor_id, revoked_at, user_agent, ip_address, device_name
+		FROM refresh_tokens 
+		WHERE account_id = ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?
+	`
+
+	exec := database.GetExecutor(ctx, r.db)
+	if err := exec.SelectContext(ctx, &rows, query, accountID.String(), limit); err != nil {
+		return nil, fmt.Errorf("failed to list refresh tokens: %w", err)
+	}
+
+	tokens := make([]*domain.RefreshToken, 0, len(rows))
+	for i := range rows {
+		token, err := rows[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+// DeleteExpired 有効期限切れのトークンをbatchSize件ずつ削除し、削除した件数を返す
+// 1回の大きなDELETEでテーブルのロックやレプリケーション遅延が起きないよう、バッチの間はpauseだけ待つ
+// コンテキストがキャンセルされた場合は、それまでに削除した件数とエラーを返す
+func (r *RefreshTokenRepository) DeleteExpired(ctx context.Context, batchSize int, pause time.Duration) (int64, error) {
+	if batchSize <= 0 {
+		return 0, fmt.Errorf("batch size must be positive: %d", batchSize)
+	}
+
+	query := `
+		DELETE FROM refresh_tokens 
+		WHERE expires_at < ?
+		LIMIT ?
+	`
+
+	// 削除中に期限切れになったトークンは次回の削除に回す
+	now := domain.Now()
+	exec := database.GetExecutor(ctx, r.db)
+	var deleted int64
+	for {
+		result, err := exec.ExecContext(ctx, query, now, batchSize)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to delete expired tokens: %w", err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return deleted, fmt.Errorf("failed to get deleted token count: %w", err)
+		}
+		deleted += affected
+		if affected < int64(batchSize) {
+			return deleted, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return deleted, ctx.Err()
+		case <-time.After(pause):
+		}
+	}
 }
@@ -2,7 +2,7 @@ package repository
 
 import (
 	"github.com/aida0710/jwt-auth/internal/domain"
-	"github.com/jmoiron/sqlx"
+	"github.com/aida0710/jwt-auth/internal/infrastructure/database"
 )
 
 // Repositories すべてのリポジトリを集約するインターフェース
@@ -18,7 +18,7 @@ type repositories struct {
 }
 
 // NewRepositories リポジトリ集約を生成
-func NewRepositories(db *sqlx.DB) Repositories {
+func NewRepositories(db *database.DB) Repositories {
 	return &repositories{
 		account: NewAccountRepository(db),
 		project: NewProjectRepository(db),
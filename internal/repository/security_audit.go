@@ -7,17 +7,17 @@ import (
 	"fmt"
 
 	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/infrastructure/database"
 	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
 )
 
 // SecurityAuditLogRepository セキュリティ監査ログリポジトリの実装
 type SecurityAuditLogRepository struct {
-	db *sqlx.DB
+	db *database.DB
 }
 
 // NewSecurityAuditLogRepository 新しいセキュリティ監査ログリポジトリを作成
-func NewSecurityAuditLogRepository(db *sqlx.DB) domain.SecurityAuditLogRepository {
+func NewSecurityAuditLogRepository(db *database.DB) domain.SecurityAuditLogRepository {
 	return &SecurityAuditLogRepository{db: db}
 }
 
@@ -30,7 +30,8 @@ func (r *SecurityAuditLogRepository) Create(ctx context.Context, log *domain.Sec
 		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	exec := database.GetExecutor(ctx, r.db)
+	_, err := exec.ExecContext(ctx, query,
 		log.ID,
 		log.AccountID,
 		log.EventType,
@@ -62,7 +63,8 @@ func (r *SecurityAuditLogRepository) GetByAccountID(ctx context.Context, account
 		LIMIT ? OFFSET ?
 	`
 
-	err := r.db.SelectContext(ctx, &logs, query, accountID, limit, offset)
+	exec := database.GetExecutor(ctx, r.db)
+	err := exec.SelectContext(ctx, &logs, query, accountID, limit, offset)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return []*domain.SecurityAuditLog{}, nil
@@ -87,7 +89,8 @@ func (r *SecurityAuditLogRepository) GetByEventType(ctx context.Context, eventTy
 		LIMIT ? OFFSET ?
 	`
 
-	err := r.db.SelectContext(ctx, &logs, query, eventType, limit, offset)
+	exec := database.GetExecutor(ctx, r.db)
+	err := exec.SelectContext(ctx, &logs, query, eventType, limit, offset)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return []*domain.SecurityAuditLog{}, nil
@@ -103,7 +106,8 @@ func (r *SecurityAuditLogRepository) CountByAccountID(ctx context.Context, accou
 	var count int
 	query := `SELECT COUNT(*) FROM security_audit_logs WHERE account_id = ?`
 
-	err := r.db.GetContext(ctx, &count, query, accountID)
+	exec := database.GetExecutor(ctx, r.db)
+	err := exec.GetContext(ctx, &count, query, accountID)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count security audit logs: %w", err)
 	}
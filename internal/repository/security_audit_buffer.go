@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/infrastructure/database"
+	"github.com/aida0710/jwt-auth/internal/logger"
+)
+
+// SecurityAuditBuffer 書き込みに失敗した監査ログを保持して再試行するセキュリティ監査ログリポジトリ
+// 保存先の障害で監査ログが黙って失われないよう、失敗したイベントをメモリのリングバッファに保持し、
+// Flushで古い順に書き込み直す。バッファが一杯の場合は最も古いイベントを破棄して件数を数える
+// 外部キー違反などの再試行しても成功しないイベントは、後続のイベントを塞がないようログに出力して破棄する
+// 読み取りは保存先のリポジトリにそのまま委譲する
+type SecurityAuditBuffer struct {
+	domain.SecurityAuditLogRepository
+
+	log      logger.Logger
+	capacity int
+
+	mu      sync.Mutex
+	pending []*domain.SecurityAuditLog
+	dropped uint64
+}
+
+// NewSecurityAuditBuffer 保存先のリポジトリに失敗時のバッファを付けたリポジトリを作成
+// capacityは保持するイベントの最大件数（1未満の場合は1）
+func NewSecurityAuditBuffer(repo domain.SecurityAuditLogRepository, capacity int, log logger.Logger) *SecurityAuditBuffer {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &SecurityAuditBuffer{
+		SecurityAuditLogRepository: repo,
+		log:                        log,
+		capacity:                   capacity,
+	}
+}
+
+// Create 監査ログを保存し、失敗した場合はバッファに保持して後で再試行する
+// バッファに保持したイベントは失われていないため、呼び出し元にはエラーを返さない
+// 再試行しても成功しないエラーの場合は保持せず、そのまま返す
+func (b *SecurityAuditBuffer) Create(ctx context.Context, auditLog *domain.SecurityAuditLog) error {
+	err := b.SecurityAuditLogRepository.Create(ctx, auditLog)
+	if err == nil || database.IsPermanentError(err) {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.pending) == 0 {
+		b.log.Error(ctx, "Security audit pipeline degraded: buffering events for retry", err,
+			logger.F("event_type", auditLog.EventType),
+			logger.F("account_id", auditLog.AccountID),
+		)
+	}
+	if len(b.pending) >= b.capacity {
+		oldest := b.pending[0]
+		b.pending = b.pending[1:]
+		b.dropped++
+		b.log.Error(ctx, "Security audit event dropped: fallback buffer is full", err,
+			logger.F("event_id", oldest.ID),
+			logger.F("event_type", oldest.EventType),
+			logger.F("account_id", oldest.AccountID),
+			logger.F("dropped_total", b.dropped),
+		)
+	}
+	b.pending = append(b.pending, auditLog)
+	return nil
+}
+
+// Flush バッファに保持したイベントを古い順に書き込み、書き込んだ件数を返す
+// 書き込みに失敗した時点で中断し、残りのイベントは次回に再試行する
+// 再試行しても成功しないイベント（削除済みアカウントへの外部キー違反など）はログに出力して破棄し、後続のイベントの書き込みを続ける
+func (b *SecurityAuditBuffer) Flush(ctx context.Context) (int, error) {
+	flushed := 0
+	for {
+		b.mu.Lock()
+		if len(b.pending) == 0 {
+			b.mu.Unlock()
+			break
+		}
+		next := b.pending[0]
+		b.mu.Unlock()
+
+		// 前回の書き込みがタイムアウト後に反映されていた場合は一意制約違反になるため、書き込み済みとして扱う
+		err := b.SecurityAuditLogRepository.Create(ctx, next)
+		switch {
+		case err == nil || database.IsUniqueViolation(err):
+			flushed++
+		case database.IsPermanentError(err):
+			b.logUnpersisted(ctx, "Security audit event discarded: write failed permanently", err, next)
+		default:
+			return flushed, err
+		}
+
+		b.mu.Lock()
+		// 書き込み中にバッファが一杯になって破棄された場合は先頭が入れ替わっている
+		if len(b.pending) > 0 && b.pending[0] == next {
+			b.pending = b.pending[1:]
+		}
+		recovered := len(b.pending) == 0
+		b.mu.Unlock()
+
+		if recovered {
+			b.log.Info(ctx, "Security audit pipeline recovered", logger.F("flushed", flushed))
+		}
+	}
+	return flushed, nil
+}
+
+// LogPending 書き込めなかったイベントをアプリケーションのログに出力してバッファを空にする
+// シャットダウン時に呼び出し、プロセスの終了で監査ログが失われないようにする
+func (b *SecurityAuditBuffer) LogPending(ctx context.Context) int {
+	b.mu.Lock()
+	pending := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	for _, auditLog := range pending {
+		b.logUnpersisted(ctx, "Security audit event not persisted", nil, auditLog)
+	}
+	return len(pending)
+}
+
+// logUnpersisted 保存できなかったイベントの内容をアプリケーションのログに出力
+func (b *SecurityAuditBuffer) logUnpersisted(ctx context.Context, msg string, err error, auditLog *domain.SecurityAuditLog) {
+	b.log.Error(ctx, msg, err,
+		logger.F("event_id", auditLog.ID),
+		logger.F("event_type", auditLog.EventType),
+		logger.F("account_id", auditLog.AccountID),
+		logger.F("description", auditLog.EventDescription),
+		logger.F("metadata", string(auditLog.Metadata)),
+		logger.F("created_at", auditLog.CreatedAt),
+	)
+}
+
+// Pending 再試行を待っているイベントの件数を返す
+func (b *SecurityAuditBuffer) Pending() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.pending)
+}
+
+// Dropped バッファが一杯で破棄したイベントの累計件数を返す
+func (b *SecurityAuditBuffer) Dropped() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.dropped
+}
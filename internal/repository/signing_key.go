@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/infrastructure/database"
+	"github.com/google/uuid"
+)
+
+// signingKeyDB データベース用の署名鍵構造体
+type signingKeyDB struct {
+	ID              string     `db:"id"`
+	Algorithm       string     `db:"algorithm"`
+	EncryptedSecret []byte     `db:"encrypted_secret"`
+	CreatedAt       time.Time  `db:"created_at"`
+	ActivatesAt     time.Time  `db:"activates_at"`
+	RotatesAt       *time.Time `db:"rotates_at"`
+	RetiresAt       *time.Time `db:"retires_at"`
+}
+
+// toDomain DB構造体からドメインモデルへ変換
+func (k *signingKeyDB) toDomain() (*domain.SigningKey, error) {
+	id, err := uuid.Parse(k.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.SigningKey{
+		ID:              id,
+		Algorithm:       k.Algorithm,
+		EncryptedSecret: k.EncryptedSecret,
+		CreatedAt:       k.CreatedAt,
+		ActivatesAt:     k.ActivatesAt,
+		RotatesAt:       k.RotatesAt,
+		RetiresAt:       k.RetiresAt,
+	}, nil
+}
+
+// SigningKeyRepository 署名鍵リポジトリの実装
+type SigningKeyRepository struct {
+	db *database.DB
+}
+
+// NewSigningKeyRepository 新しい署名鍵リポジトリを作成
+func NewSigningKeyRepository(db *database.DB) domain.SigningKeyRepository {
+	return &SigningKeyRepository{db: db}
+}
+
+// Create 署名鍵を作成
+func (r *SigningKeyRepository) Create(ctx context.Context, key *domain.SigningKey) error {
+	query := `
+		INSERT INTO signing_keys (id, algorithm, encrypted_secret, created_at, activates_at, rotates_at, retires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	exec := database.GetExecutor(ctx, r.db)
+	_, err := exec.ExecContext(ctx, query,
+		key.ID.String(),
+		key.Algorithm,
+		key.EncryptedSecret,
+		key.CreatedAt,
+		key.ActivatesAt,
+		key.RotatesAt,
+		key.RetiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create signing key: %w", err)
+	}
+
+	return nil
+}
+
+// List 署名鍵を有効になる日時の新しい順に取得
+func (r *SigningKeyRepository) List(ctx context.Context) ([]*domain.SigningKey, error) {
+	rows := make([]signingKeyDB, 0)
+
+	query := `
+		SELECT id, algorithm, encrypted_secret, created_at, activates_at, rotates_at, retires_at
+		FROM signing_keys
+		ORDER BY activates_at DESC
+	`
+
+	exec := database.GetExecutor(ctx, r.db)
+	if err := exec.SelectContext(ctx, &rows, query); err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+
+	keys := make([]*domain.SigningKey, 0, len(rows))
+	for i := range rows {
+		key, err := rows[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// MarkRotating ローテーションが未予定の署名鍵に次の鍵へ引き継ぐ日時と引退する日時を記録
+// 他のインスタンスが先にローテーションを予定していた場合はfalseを返す
+func (r *SigningKeyRepository) MarkRotating(ctx context.Context, id uuid.UUID, rotatesAt, retiresAt time.Time) (bool, error) {
+	query := `
+		UPDATE signing_keys
+		SET rotates_at = ?, retires_at = ?
+		WHERE id = ? AND rotates_at IS NULL
+	`
+
+	exec := database.GetExecutor(ctx, r.db)
+	result, err := exec.ExecContext(ctx, query, rotatesAt, retiresAt, id.String())
+	if err != nil {
+		return false, fmt.Errorf("failed to mark signing key as rotating: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// DeleteRetired 検証に使用しなくなった署名鍵を削除
+func (r *SigningKeyRepository) DeleteRetired(ctx context.Context, now time.Time) (int64, error) {
+	query := `DELETE FROM signing_keys WHERE retires_at <= ?`
+
+	exec := database.GetExecutor(ctx, r.db)
+	result, err := exec.ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete retired signing keys: %w", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+)
+
+// tenantCondition コンテキストのテナントで絞り込むAND条件とパラメータを返す
+// テナントが設定されていない場合は空の条件を返す
+// aliasはJOIN時のテーブル別名（例: "a."）
+func tenantCondition(ctx context.Context, alias string) (string, []interface{}) {
+	tenantID, ok := domain.TenantIDFromContext(ctx)
+	if !ok {
+		return "", nil
+	}
+	return " AND " + alias + "tenant_id = ?", []interface{}{tenantID}
+}
@@ -0,0 +1,188 @@
+package selfcheck
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/config"
+)
+
+// チェック名
+const (
+	CheckDatabase = "database"
+	CheckTables   = "tables"
+	CheckJWT      = "jwt"
+	CheckSecrets  = "secrets"
+	CheckAudit    = "audit"
+)
+
+// checkTimeout データベースのチェックに掛ける最大時間（応答しないデータベースで起動やプローブが止まらないようにする）
+const checkTimeout = 5 * time.Second
+
+// RequiredTables アプリケーションが使用するテーブル（ddl/schema.sqlとddl/auth_schema.sqlで作成）
+var RequiredTables = []string{
+	"accounts",
+	"projects",
+	"refresh_tokens",
+	"security_audit_logs",
+	"password_history",
+	"login_attempts",
+	"magic_link_tokens",
+	"password_reset_tokens",
+	"invites",
+}
+
+// Database セルフチェックで使用するデータベースの操作（*sqlx.DBが実装）
+type Database interface {
+	PingContext(ctx context.Context) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
+// AuditBuffer 保存に失敗したセキュリティ監査ログのバッファ（*repository.SecurityAuditBufferが実装）
+type AuditBuffer interface {
+	Pending() int
+	Dropped() uint64
+}
+
+// Result 1つのチェックの結果
+type Result struct {
+	Name    string
+	OK      bool
+	Message string // 失敗した理由（成功時は空）
+}
+
+// Report セルフチェック全体の結果
+type Report struct {
+	Results []Result
+}
+
+// OK すべてのチェックが成功したかどうかを返す
+func (r Report) OK() bool {
+	return len(r.Failed()) == 0
+}
+
+// Failed 失敗したチェックを返す
+func (r Report) Failed() []Result {
+	var failed []Result
+	for _, result := range r.Results {
+		if !result.OK {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Summary チェック名ごとの結果（成功時は"ok"、失敗時は理由）を返す（構造化ログ用）
+func (r Report) Summary() map[string]string {
+	summary := make(map[string]string, len(r.Results))
+	for _, result := range r.Results {
+		if result.OK {
+			summary[result.Name] = "ok"
+		} else {
+			summary[result.Name] = result.Message
+		}
+	}
+	return summary
+}
+
+// Error 失敗したチェックをまとめたエラーを返す（すべて成功した場合はnil）
+func (r Report) Error() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	messages := make([]string, len(failed))
+	for i, result := range failed {
+		messages[i] = result.Name + ": " + result.Message
+	}
+	return fmt.Errorf("startup self-check failed: %s", strings.Join(messages, "; "))
+}
+
+// Run データベースへの接続、必要なテーブルの存在、JWTの設定を検査する
+// 起動時とレディネスチェックの両方から呼び出す
+// dbがnilの場合（DB_DRIVER=memory）はデータベースのチェックを行わない
+func Run(ctx context.Context, db Database, cfg *config.Config) Report {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	var results []Result
+	if db != nil {
+		database := checkDatabase(ctx, db)
+		tables := Result{Name: CheckTables, Message: "skipped: database is unreachable"}
+		if database.OK {
+			tables = checkTables(ctx, db)
+		}
+		results = append(results, database, tables)
+	}
+
+	return Report{Results: append(results,
+		checkJWT(cfg.JWT),
+		checkSecrets(cfg.JWT),
+	)}
+}
+
+// checkDatabase データベースに接続できることを確認
+// 結果はレディネスチェックで公開するため、接続先を含みうるドライバーのエラーは理由に含めない
+func checkDatabase(ctx context.Context, db Database) Result {
+	if err := db.PingContext(ctx); err != nil {
+		return Result{Name: CheckDatabase, Message: "database is unreachable"}
+	}
+	return Result{Name: CheckDatabase, OK: true}
+}
+
+// checkTables RequiredTablesがすべて存在することを確認
+func checkTables(ctx context.Context, db Database) Result {
+	var existing []string
+	err := db.SelectContext(ctx, &existing, `
+		SELECT TABLE_NAME FROM information_schema.TABLES
+		WHERE TABLE_SCHEMA = DATABASE()
+	`)
+	if err != nil {
+		return Result{Name: CheckTables, Message: "failed to list tables"}
+	}
+
+	var missing []string
+	for _, table := range RequiredTables {
+		if !slices.Contains(existing, table) {
+			missing = append(missing, table)
+		}
+	}
+	if len(missing) > 0 {
+		return Result{Name: CheckTables, Message: "missing tables: " + strings.Join(missing, ", ")}
+	}
+	return Result{Name: CheckTables, OK: true}
+}
+
+// checkJWT 有効期限の組み合わせなど、Config.Validateでは検査しないJWTの設定を確認
+func checkJWT(cfg config.JWTConfig) Result {
+	switch {
+	case cfg.AccessTokenExpiry <= 0 || cfg.RefreshTokenExpiry <= 0:
+		return Result{Name: CheckJWT, Message: "JWT_ACCESS_TOKEN_EXPIRY and JWT_REFRESH_TOKEN_EXPIRY must be positive"}
+	case cfg.RefreshTokenExpiry <= cfg.AccessTokenExpiry:
+		return Result{Name: CheckJWT, Message: "JWT_REFRESH_TOKEN_EXPIRY must be longer than JWT_ACCESS_TOKEN_EXPIRY"}
+	}
+	return Result{Name: CheckJWT, OK: true}
+}
+
+// checkSecrets アクセストークンとリフレッシュトークンで異なるシークレットを使用していることを確認
+// 同じ場合は一方のシークレットの漏洩で両方のトークンを偽造できてしまう
+// 検証のみの構成ではリフレッシュトークンのシークレットを使用しないため確認しない
+func checkSecrets(cfg config.JWTConfig) Result {
+	if !cfg.VerifyOnly && cfg.AccessTokenSecret == cfg.RefreshTokenSecret {
+		return Result{Name: CheckSecrets, Message: "JWT_ACCESS_TOKEN_SECRET and JWT_REFRESH_TOKEN_SECRET must be different"}
+	}
+	return Result{Name: CheckSecrets, OK: true}
+}
+
+// CheckAuditBuffer 保存を待っている監査ログが無いことを確認
+// 保存先の障害で監査ログを保存できていない間は失敗として扱い、監視で検知できるようにする
+func CheckAuditBuffer(buffer AuditBuffer) Result {
+	pending, dropped := buffer.Pending(), buffer.Dropped()
+	if pending > 0 {
+		return Result{Name: CheckAudit, Message: fmt.Sprintf("security audit log is degraded: %d events pending, %d dropped", pending, dropped)}
+	}
+	return Result{Name: CheckAudit, OK: true}
+}
@@ -2,66 +2,210 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/aida0710/jwt-auth/internal/auth"
 	"github.com/aida0710/jwt-auth/internal/domain"
 	"github.com/aida0710/jwt-auth/internal/infrastructure/database"
+	"github.com/aida0710/jwt-auth/internal/notification"
 	"github.com/google/uuid"
 )
 
-// CreateInput アカウント作成用の入力（SignUp専用）
+// CreateInput アカウント作成用の入力（管理者による発行・初期管理者の作成用）
 type CreateInput struct {
-	Email    string `json:"email" validate:"required,email"`
-	Name     string `json:"name" validate:"required"`
-	Password string `json:"password" validate:"required,min=8"`
+	Email    string      `json:"email" validate:"required,email"`
+	Name     string      `json:"name" validate:"required"`
+	Password string      `json:"password" validate:"required,min=8"`
+	Role     domain.Role `json:"role,omitempty"` // 省略時は一般ユーザー
 }
 
 // UpdateInput アカウント更新用の入力
+// nilの項目は変更しない。プロフィール項目に空文字を指定した場合は未設定に戻す
 type UpdateInput struct {
-	Email *string `json:"email,omitempty" validate:"omitempty,email"`
-	Name  *string `json:"name,omitempty"`
+	Email       *string `json:"email,omitempty" validate:"omitempty,email"`
+	Name        *string `json:"name,omitempty"`
+	DisplayName *string `json:"display_name,omitempty"`
+	AvatarURL   *string `json:"avatar_url,omitempty" validate:"omitempty,url"`
+	Locale      *string `json:"locale,omitempty"`
+	Timezone    *string `json:"timezone,omitempty"`
+
+	// ExpectedVersions nil以外の場合、アカウントのバージョンがいずれかに一致するときのみ更新する（不一致はErrVersionConflict）
+	ExpectedVersions []int64 `json:"-"`
+}
+
+// ListAccountsInput アカウント一覧取得（管理者用）の入力
+// nilの項目は既定値を使用、または絞り込みに使用しない
+type ListAccountsInput struct {
+	Limit  *int
+	Offset *int
+	After  *string // Offsetの代わりに指定するカーソル
+	Before *string
+	Role   *string
+}
+
+// ListPageInput アカウント一覧取得の入力
+// nilの項目は既定値を使用する
+type ListPageInput struct {
+	Limit  *int
+	Offset *int
+}
+
+// SearchAccountsInput メールアドレスによるアカウント検索（管理者用）の入力
+type SearchAccountsInput struct {
+	Email string // 前方一致で検索するメールアドレス
+	Limit *int   // nilの場合は既定値
+}
+
+// ChangePasswordInput パスワード変更用の入力
+type ChangePasswordInput struct {
+	CurrentPassword string
+	NewPassword     string
+}
+
+// PasswordResetInput パスワード再設定用のトークンの送信要求の入力
+type PasswordResetInput struct {
+	Email     string
+	UserAgent string
+	IPAddress string
+}
+
+// ResetPasswordInput パスワード再設定用のトークンによるパスワード変更の入力
+type ResetPasswordInput struct {
+	Token       string
+	NewPassword string
+	UserAgent   string
+	IPAddress   string
+}
+
+// AccountConfig アカウントユースケースの設定
+type AccountConfig struct {
+	// PasswordHistorySize 再利用を禁止する過去のパスワード数（現在のパスワードは常に禁止、0で履歴を保存しない）
+	PasswordHistorySize int
+	// DeletionGracePeriod 削除を予約してから完全に削除するまでの猶予期間（期間中は復元できる）
+	DeletionGracePeriod time.Duration
+	// ListDefaultLimit アカウント一覧で件数の指定が無い場合の件数（0の場合はDefaultPageSize）
+	ListDefaultLimit int
+	// ListMaxLimit アカウント一覧で1回に返す件数の上限（0の場合はMaxPageSize、超える指定は上限に切り詰める）
+	ListMaxLimit int
+	// PasswordResetExpiry パスワード再設定用のトークンの有効期限（0の場合は1時間）
+	PasswordResetExpiry time.Duration
+	// PasswordResetURL メールに記載するリンクのURL（tokenクエリを付与する、空の場合はトークンのみを記載）
+	PasswordResetURL string
+	// IDs 作成するアカウントなどのIDの生成（ゼロ値の場合はv7）
+	IDs domain.IDConfig
+	// Names 作成・更新するアカウント名の検証ルール（ゼロ値の場合はDefaultNameConfig）
+	Names domain.NameConfig
+	// Passwords パスワードのハッシュ化と検証（ゼロ値の場合はペッパーを適用しない）
+	Passwords auth.PasswordHasher
+}
+
+// emailVerificationTTL メールアドレス変更の確認トークンの有効期限
+const emailVerificationTTL = 24 * time.Hour
+
+// errPasswordResetUnavailable パスワード再設定のリポジトリ、通知またはトランザクションマネージャーが設定されていない場合のエラー
+var errPasswordResetUnavailable = errors.New("password reset is not configured")
+
+// purgeBatchSize 完全削除で1回に読み込む削除予定のアカウント数
+const purgeBatchSize = 100
+
+// exportPageSize エクスポートでプロジェクトと監査ログを読み込む1回あたりの件数
+const exportPageSize = 100
+
+// AccountExportWriter アカウントのエクスポートを書き出す
+// WriteAccountを最初に1回呼び出し、続けてプロジェクト、監査ログの順にページごとに呼び出す
+// 全件をメモリに載せずにレスポンスへ書き出せるよう、ページを渡した後は保持しない
+type AccountExportWriter interface {
+	WriteAccount(account *domain.Account) error
+	WriteProjects(projects []*domain.Project) error
+	WriteSecurityEvents(logs []*domain.SecurityAuditLog) error
 }
 
 // accountUsecase AccountUsecaseインターフェースの実装
 type accountUsecase struct {
-	accountRepo domain.AccountRepository
-	projectRepo domain.ProjectRepository
-	txManager   database.TransactionManager
+	accountRepo      domain.AccountRepository
+	projectRepo      domain.ProjectRepository
+	refreshTokenRepo domain.RefreshTokenRepository
+	passwordHistory  domain.PasswordHistoryRepository
+	passwordResets   domain.PasswordResetRepository
+	securityAudit    domain.SecurityAuditLogRepository
+	txManager        database.TransactionManager
+	notifier         notification.Notifier
+	config           AccountConfig
 }
 
 // NewAccountUsecase 新しいアカウントユースケースを作成
 func NewAccountUsecase(
 	accountRepo domain.AccountRepository,
 	projectRepo domain.ProjectRepository,
+	refreshTokenRepo domain.RefreshTokenRepository,
+	passwordHistory domain.PasswordHistoryRepository,
+	passwordResets domain.PasswordResetRepository,
+	securityAudit domain.SecurityAuditLogRepository,
 	txManager database.TransactionManager,
+	notifier notification.Notifier,
+	config AccountConfig,
 ) AccountUsecase {
+	// 監査ログのリポジトリを省略した場合は記録しない
+	if securityAudit == nil {
+		securityAudit = domain.NopSecurityAuditLogRepository{}
+	}
+	if config.PasswordResetExpiry == 0 {
+		config.PasswordResetExpiry = time.Hour
+	}
+	if len(config.Names.AllowedClasses) == 0 {
+		config.Names = domain.DefaultNameConfig
+	}
+
 	return &accountUsecase{
-		accountRepo: accountRepo,
-		projectRepo: projectRepo,
-		txManager:   txManager,
+		accountRepo:      accountRepo,
+		projectRepo:      projectRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		passwordHistory:  passwordHistory,
+		passwordResets:   passwordResets,
+		securityAudit:    securityAudit,
+		txManager:        txManager,
+		notifier:         notifier,
+		config:           config,
 	}
 }
 
 // Create 新しいアカウントを作成
 func (u *accountUsecase) Create(ctx context.Context, input CreateInput) (*domain.Account, error) {
-	existing, _ := u.accountRepo.GetByEmail(ctx, input.Email)
+	// メールアドレスはすべてのテナントで一意（認証前のログインなどはテナントを指定せずに検索する）
+	existing, err := u.accountRepo.GetByEmail(domain.WithoutTenant(ctx), input.Email)
+	if err != nil && !errors.Is(err, domain.ErrAccountNotFound) {
+		return nil, err
+	}
 	if existing != nil {
 		return nil, domain.ErrDuplicateEmail
 	}
 
 	// パスワードをハッシュ化
-	passwordHash, err := auth.HashPassword(input.Password)
+	passwordHash, err := u.config.Passwords.Hash(input.Password)
 	if err != nil {
 		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 
 	// Domain層のファクトリメソッドを使用
-	account := domain.NewAccount(input.Email, input.Name, passwordHash)
+	account := domain.NewAccount(input.Email, domain.NormalizeName(input.Name), passwordHash)
+	account.ID = u.config.IDs.NewID()
+	account.TenantID = domain.ResolveTenantID(ctx) // 作成した管理者と同じテナントに所属させる
+	if input.Role != "" {
+		account.Role = input.Role
+	}
 
 	if err := account.Validate(); err != nil {
 		return nil, err
 	}
+	if err := u.config.Names.ValidateName(account.Name); err != nil {
+		return nil, err
+	}
 
 	if err := u.accountRepo.Create(ctx, account); err != nil {
 		return nil, err
@@ -72,13 +216,10 @@ func (u *accountUsecase) Create(ctx context.Context, input CreateInput) (*domain
 
 // GetByID IDでアカウントを取得
 func (u *accountUsecase) GetByID(ctx context.Context, id uuid.UUID) (*domain.Account, error) {
-	account, err := u.accountRepo.GetByID(ctx, id)
+	account, err := getTenantAccount(ctx, u.accountRepo, id)
 	if err != nil {
 		return nil, err
 	}
-	if account == nil {
-		return nil, domain.ErrAccountNotFound
-	}
 
 	return account, nil
 }
@@ -93,45 +234,144 @@ func (u *accountUsecase) GetByEmail(ctx context.Context, email string) (*domain.
 	if err != nil {
 		return nil, err
 	}
-	if account == nil {
+	if !account.BelongsTo(ctx) {
 		return nil, domain.ErrAccountNotFound
 	}
 
 	return account, nil
 }
 
-// List アカウント一覧を取得
-func (u *accountUsecase) List(ctx context.Context) ([]*domain.Account, error) {
-	accounts, err := u.accountRepo.List(ctx)
+// List アカウント一覧を作成日時の新しい順に取得し、実際に適用した件数の上限とともに返す
+// テーブル全体を読み込まないよう、上限を超える件数の指定はエラーにせず上限に切り詰める
+func (u *accountUsecase) List(ctx context.Context, input ListPageInput) ([]*domain.Account, int, error) {
+	limit, maxLimit := u.config.ListDefaultLimit, u.config.ListMaxLimit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	if maxLimit <= 0 {
+		maxLimit = MaxPageSize
+	}
+	if input.Limit != nil {
+		if *input.Limit < 1 {
+			return nil, 0, domain.ErrInvalidPagination
+		}
+		limit = *input.Limit
+	}
+	limit = min(limit, maxLimit)
+
+	offset := 0
+	if input.Offset != nil {
+		if *input.Offset < 0 {
+			return nil, 0, domain.ErrInvalidPagination
+		}
+		offset = *input.Offset
+	}
+
+	accounts, err := u.accountRepo.List(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return accounts, limit, nil
+}
+
+// ListWithProjectCounts アカウントを所有するプロジェクト数とともに条件で絞り込んで取得
+func (u *accountUsecase) ListWithProjectCounts(ctx context.Context, input ListAccountsInput) ([]*domain.AccountProjectCount, PageInfo, error) {
+	limit, offset, err := resolvePagination(input.Limit, input.Offset)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	cursor, err := resolveCursor(input.After, input.Before, offset)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	filter := domain.AccountFilter{
+		Limit:  limit,
+		Offset: offset,
+		Cursor: cursor,
+	}
+
+	if input.Role != nil {
+		role := domain.Role(*input.Role)
+		if !role.IsValid() {
+			return nil, PageInfo{}, domain.ErrInvalidRole
+		}
+		filter.Role = &role
+	}
+
+	total, err := u.accountRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	// 次のページの有無を判定するため、1件多く取得する
+	filter.Limit++
+	counts, err := u.accountRepo.ListWithProjectCounts(ctx, filter)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	counts, page := pageOf(counts, limit, offset, cursor, func(c *domain.AccountProjectCount) uuid.UUID { return c.Account.ID })
+	page.Total = total
+	return counts, page, nil
+}
+
+// SearchByEmail メールアドレスが前方一致するアカウントを検索
+// 空や短すぎる検索語はほぼ全件の走査になるため拒否する
+func (u *accountUsecase) SearchByEmail(ctx context.Context, input SearchAccountsInput) ([]*domain.Account, error) {
+	prefix := strings.TrimSpace(input.Email)
+	if n := utf8.RuneCountInString(prefix); n < domain.MinEmailSearchLength || n > domain.MaxEmailSearchLength {
+		return nil, domain.ErrInvalidSearch
+	}
+
+	limit, _, err := resolvePagination(input.Limit, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	return accounts, nil
+	return u.accountRepo.SearchByEmailPrefix(ctx, prefix, limit)
 }
 
 // Update アカウントを更新
+// メールアドレスの変更は即時反映せず、新しいアドレスに確認トークンを送って確認待ちにする
 func (u *accountUsecase) Update(ctx context.Context, id uuid.UUID, input UpdateInput) (*domain.Account, error) {
-	account, err := u.accountRepo.GetByID(ctx, id)
+	account, err := getTenantAccount(ctx, u.accountRepo, id)
 	if err != nil {
 		return nil, err
 	}
-	if account == nil {
-		return nil, domain.ErrAccountNotFound
+	// 読み込んでから保存するまでの間の更新はリポジトリの条件付き更新で検出する
+	if input.ExpectedVersions != nil && !slices.Contains(input.ExpectedVersions, account.Version) {
+		return nil, domain.ErrVersionConflict
 	}
 
+	var verificationToken string
 	if input.Email != nil && *input.Email != account.Email {
-		existing, _ := u.accountRepo.GetByEmail(ctx, *input.Email)
-		if existing != nil {
-			return nil, domain.ErrDuplicateEmail
+		if err := u.ensureEmailAvailable(ctx, *input.Email); err != nil {
+			return nil, err
 		}
-		account.Email = *input.Email
+
+		verificationToken, err = auth.GenerateSecureToken()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate verification token: %w", err)
+		}
+		account.RequestEmailChange(*input.Email, auth.HashToken(verificationToken), domain.Now().Add(emailVerificationTTL))
+	} else if input.Email != nil && account.PendingEmail != nil {
+		// 現在のアドレスを指定した場合は確認待ちの変更を取り消す
+		account.ClearPendingEmail()
 	}
 
 	if input.Name != nil {
-		account.Name = *input.Name
+		account.Name = domain.NormalizeName(*input.Name)
+		if err := u.config.Names.ValidateName(account.Name); err != nil {
+			return nil, err
+		}
 	}
 
+	applyProfileField(&account.DisplayName, input.DisplayName)
+	applyProfileField(&account.AvatarURL, input.AvatarURL)
+	applyProfileField(&account.Locale, input.Locale)
+	applyProfileField(&account.Timezone, input.Timezone)
+
 	if err := account.Validate(); err != nil {
 		return nil, err
 	}
@@ -140,18 +380,443 @@ func (u *accountUsecase) Update(ctx context.Context, id uuid.UUID, input UpdateI
 		return nil, err
 	}
 
+	if verificationToken != "" {
+		if err := u.notifier.Send(ctx, notification.Message{
+			To:      *account.PendingEmail,
+			Subject: "Confirm your new email address",
+			Body:    fmt.Sprintf("Use this token to confirm your new email address: %s", verificationToken),
+		}); err != nil {
+			return nil, fmt.Errorf("failed to send verification email: %w", err)
+		}
+	}
+
 	return account, nil
 }
 
-// Delete アカウントとそのプロジェクトを削除
-func (u *accountUsecase) Delete(ctx context.Context, id uuid.UUID) error {
-	return u.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+// ConfirmEmailChange 確認トークンを検証してメールアドレスの変更を確定する
+// 変更後は乗っ取られたセッションを残さないよう、すべてのリフレッシュトークンを無効化する
+func (u *accountUsecase) ConfirmEmailChange(ctx context.Context, id uuid.UUID, token string) (*domain.Account, error) {
+	account, err := getTenantAccount(ctx, u.accountRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 確認待ちの間に同じアドレスが他のアカウントで使われていないか再確認
+	if account.PendingEmail != nil {
+		if err := u.ensureEmailAvailable(ctx, *account.PendingEmail); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := account.ConfirmEmailChange(auth.HashToken(token), domain.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := u.accountRepo.Update(ctx, account); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.refreshTokenRepo.RevokeByAccountID(ctx, account.ID); err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return account, nil
+}
+
+// ChangePassword 現在のパスワードを確認してパスワードを変更する
+// 現在および直近PasswordHistorySize件のパスワードへの変更は拒否し、変更後はすべてのセッションを無効化する
+func (u *accountUsecase) ChangePassword(ctx context.Context, id uuid.UUID, input ChangePasswordInput) error {
+	account, err := getTenantAccount(ctx, u.accountRepo, id)
+	if err != nil {
+		return err
+	}
+
+	if err := u.config.Passwords.Verify(input.CurrentPassword, account.PasswordHash); err != nil {
+		return domain.ErrIncorrectPassword
+	}
+
+	if err := u.ensurePasswordNotReused(ctx, account, input.NewPassword); err != nil {
+		return err
+	}
+
+	passwordHash, err := u.config.Passwords.Hash(input.NewPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	previousHash := account.PasswordHash
+	account.PasswordHash = passwordHash
+
+	err = u.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		return u.savePassword(ctx, account, previousHash)
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := u.refreshTokenRepo.RevokeByAccountID(ctx, account.ID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return nil
+}
+
+// RequestPasswordReset メールアドレス宛てにパスワード再設定用のトークンを送信する
+// 以前に送信した未使用のトークンは無効にし、最後に送信したトークンのみ使用できるようにする
+// アカウントの有無を推測されないよう、存在しないメールアドレスでもエラーを返さない
+func (u *accountUsecase) RequestPasswordReset(ctx context.Context, input PasswordResetInput) error {
+	if u.passwordResets == nil || u.notifier == nil || u.txManager == nil {
+		return errPasswordResetUnavailable
+	}
+
+	account, err := u.accountRepo.GetByEmail(ctx, input.Email)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+
+	token, err := auth.GenerateSecureToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate password reset token: %w", err)
+	}
+	reset := domain.NewPasswordReset(account.ID, auth.HashToken(token), domain.Now().Add(u.config.PasswordResetExpiry))
+	reset.ID = u.config.IDs.NewID()
+
+	err = u.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := u.passwordResets.InvalidateByAccountID(ctx, account.ID); err != nil {
+			return err
+		}
+		return u.passwordResets.Create(ctx, reset)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := u.notifier.Send(ctx, notification.Message{
+		To:      account.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Use this link to reset your password. It expires in %s and can only be used once: %s",
+			u.config.PasswordResetExpiry, linkWithToken(u.config.PasswordResetURL, token)),
+	}); err != nil {
+		return fmt.Errorf("failed to send password reset email: %w", err)
+	}
+
+	return nil
+}
+
+// ResetPassword パスワード再設定用のトークンを消費してパスワードを変更する
+// 存在しない・期限切れ・使用済み・新しいトークンで無効になったトークンはすべてErrInvalidPasswordResetTokenを返す
+// 同じトークンでの同時リクエストは1件だけが成功し、変更後はすべてのセッションを無効化する
+func (u *accountUsecase) ResetPassword(ctx context.Context, input ResetPasswordInput) error {
+	if u.passwordResets == nil || u.txManager == nil {
+		return errPasswordResetUnavailable
+	}
+
+	reset, err := u.passwordResets.GetByTokenHash(ctx, auth.HashToken(input.Token))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrInvalidPasswordResetToken
+		}
+		return fmt.Errorf("failed to get password reset: %w", err)
+	}
+	if !reset.IsUsable(domain.Now()) {
+		return domain.ErrInvalidPasswordResetToken
+	}
+
+	account, err := u.accountRepo.GetByID(ctx, reset.AccountID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrInvalidPasswordResetToken
+		}
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+
+	if err := u.ensurePasswordNotReused(ctx, account, input.NewPassword); err != nil {
+		return err
+	}
+
+	passwordHash, err := u.config.Passwords.Hash(input.NewPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	previousHash := account.PasswordHash
+	account.PasswordHash = passwordHash
+
+	// 確認と消費の間に別のリクエストが消費していないよう、未使用の場合のみ消費済みにしてから同じトランザクションで変更する
+	err = u.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		consumed, err := u.passwordResets.MarkAsConsumed(ctx, reset.ID)
+		if err != nil {
+			return err
+		}
+		if !consumed {
+			return domain.ErrInvalidPasswordResetToken
+		}
+		return u.savePassword(ctx, account, previousHash)
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := u.refreshTokenRepo.RevokeByAccountID(ctx, account.ID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	recordSecurityEvent(ctx, u.securityAudit, account.ID,
+		domain.EventPasswordChanged,
+		"Password was reset with an emailed token. All sessions have been revoked.",
+		input.UserAgent, input.IPAddress,
+		domain.SecurityAuditMetadata{
+			"method": "reset",
+		})
+
+	return nil
+}
+
+// savePassword 変更したパスワードを保存し、変更前のパスワードを履歴に追加する（トランザクション内で呼び出す）
+func (u *accountUsecase) savePassword(ctx context.Context, account *domain.Account, previousHash string) error {
+	if err := u.accountRepo.Update(ctx, account); err != nil {
+		return err
+	}
+
+	if u.config.PasswordHistorySize <= 0 {
+		return nil
+	}
+	// 変更前のパスワードを履歴に追加し、設定件数を超えた古い履歴を削除
+	history := domain.NewPasswordHistory(account.ID, previousHash)
+	history.ID = u.config.IDs.NewID()
+	if err := u.passwordHistory.Create(ctx, history); err != nil {
+		return err
+	}
+	return u.passwordHistory.Prune(ctx, account.ID, u.config.PasswordHistorySize)
+}
+
+// UpdateStatus アカウントの状態を変更する（管理者用）
+// 停止したアカウントが既存のセッションを使い続けないよう、すべてのリフレッシュトークンを無効化する
+func (u *accountUsecase) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.AccountStatus, actor Actor) (*domain.Account, error) {
+	account, err := getTenantAccount(ctx, u.accountRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := account.Status
+	account.Status = status
+	if err := account.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := u.accountRepo.Update(ctx, account); err != nil {
+		return nil, err
+	}
+
+	if account.IsSuspended() {
+		if _, err := u.refreshTokenRepo.RevokeByAccountID(ctx, account.ID); err != nil {
+			return nil, fmt.Errorf("failed to revoke sessions: %w", err)
+		}
+	}
+
+	action := domain.AdminActionReactivateAccount
+	if account.IsSuspended() {
+		action = domain.AdminActionSuspendAccount
+	}
+	recordAdminAction(ctx, u.securityAudit, actor, account.ID, account.ID, action, domain.SecurityAuditMetadata{
+		"previous_status": string(previous),
+		"status":          string(account.Status),
+	})
+
+	return account, nil
+}
+
+// UpdateAllowedAudiences ログイン・リフレッシュで要求できるAudienceを置き換える（管理者用）
+// 空の一覧を指定すると既定のAudienceのトークンのみ発行する。発行済みのアクセストークンは失効するまで有効
+func (u *accountUsecase) UpdateAllowedAudiences(ctx context.Context, id uuid.UUID, audiences []string, actor Actor) (*domain.Account, error) {
+	normalized, err := domain.NormalizeAudiences(audiences)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := getTenantAccount(ctx, u.accountRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := account.AllowedAudiences
+	account.AllowedAudiences = normalized
+	if err := u.accountRepo.Update(ctx, account); err != nil {
+		return nil, err
+	}
+
+	recordAdminAction(ctx, u.securityAudit, actor, account.ID, account.ID, domain.AdminActionUpdateAudiences, domain.SecurityAuditMetadata{
+		"previous_audiences": previous,
+		"audiences":          normalized,
+	})
+
+	return account, nil
+}
+
+// ensurePasswordNotReused 新しいパスワードが現在または直近のパスワードと一致しないか確認
+func (u *accountUsecase) ensurePasswordNotReused(ctx context.Context, account *domain.Account, password string) error {
+	if u.config.Passwords.Verify(password, account.PasswordHash) == nil {
+		return domain.ErrPasswordReused
+	}
+
+	if u.config.PasswordHistorySize <= 0 {
+		return nil
+	}
+
+	histories, err := u.passwordHistory.ListRecent(ctx, account.ID, u.config.PasswordHistorySize)
+	if err != nil {
+		return fmt.Errorf("failed to get password history: %w", err)
+	}
+	for _, history := range histories {
+		if u.config.Passwords.Verify(password, history.PasswordHash) == nil {
+			return domain.ErrPasswordReused
+		}
+	}
+
+	return nil
+}
+
+// ensureEmailAvailable メールアドレスが他のアカウントで使われていないか確認
+func (u *accountUsecase) ensureEmailAvailable(ctx context.Context, email string) error {
+	existing, err := u.accountRepo.GetByEmail(ctx, email)
+	if err != nil && !errors.Is(err, domain.ErrAccountNotFound) {
+		return err
+	}
+	if existing != nil {
+		return domain.ErrDuplicateEmail
+	}
+	return nil
+}
+
+// Delete アカウントの削除を予約し、猶予期間中の状態にする
+// 猶予期間中はログインを拒否し、すべてのセッションを無効化する（期間が過ぎるとPurgeDeletedAccountsで完全に削除）
+// 管理者が他のアカウントを削除した場合は監査ログに記録する
+func (u *accountUsecase) Delete(ctx context.Context, id uuid.UUID, actor Actor) error {
+	account, err := getTenantAccount(ctx, u.accountRepo, id)
+	if err != nil {
+		return err
+	}
+
+	if err := account.ScheduleDeletion(domain.Now().Add(u.config.DeletionGracePeriod)); err != nil {
+		return err
+	}
+	if err := u.accountRepo.Update(ctx, account); err != nil {
+		return err
+	}
+
+	if _, err := u.refreshTokenRepo.RevokeByAccountID(ctx, account.ID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	scheduledAt := account.DeletionScheduledAt.UTC().Format(time.RFC3339)
+	recordSecurityEvent(ctx, u.securityAudit, account.ID,
+		domain.EventAccountDeletionRequested,
+		fmt.Sprintf("Account deletion requested. The account will be purged at %s.", scheduledAt),
+		actor.UserAgent, actor.IPAddress,
+		domain.SecurityAuditMetadata{
+			"requested_by":          actor.ID.String(),
+			"deletion_scheduled_at": scheduledAt,
+		})
+
+	// 対象アカウントの監査ログは完全削除時に消えるため、管理者自身のログとして残す
+	if actor.IsAdminActingOn(id) {
+		recordAdminAction(ctx, u.securityAudit, actor, actor.ID, id, domain.AdminActionDeleteAccount, domain.SecurityAuditMetadata{
+			"target_email":          account.Email,
+			"deletion_scheduled_at": scheduledAt,
+		})
+	}
+
+	return nil
+}
+
+// Restore 猶予期間中のアカウントの削除を取り消し、有効な状態に戻す
+// 本人と管理者のみ実行できる（無効化したセッションは戻らないため、本人は再度ログインする）
+func (u *accountUsecase) Restore(ctx context.Context, id uuid.UUID, actor Actor) (*domain.Account, error) {
+	if actor.ID != id && actor.Role != domain.RoleAdmin {
+		return nil, domain.ErrForbidden
+	}
+
+	account, err := getTenantAccount(ctx, u.accountRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := account.CancelDeletion(); err != nil {
+		return nil, err
+	}
+	if err := u.accountRepo.Update(ctx, account); err != nil {
+		return nil, err
+	}
+
+	recordSecurityEvent(ctx, u.securityAudit, account.ID,
+		domain.EventAccountDeletionCancelled,
+		"Account deletion cancelled.",
+		actor.UserAgent, actor.IPAddress,
+		domain.SecurityAuditMetadata{
+			"restored_by": actor.ID.String(),
+		})
+
+	if actor.IsAdminActingOn(id) {
+		recordAdminAction(ctx, u.securityAudit, actor, account.ID, account.ID, domain.AdminActionRestoreAccount, nil)
+	}
+
+	return account, nil
+}
+
+// PurgeDeletedAccounts 猶予期間が過ぎたアカウントとそのプロジェクトを完全に削除し、削除した件数を返す
+// 定期実行を想定しており、コンテキストにテナントが無い場合はすべてのテナントを対象とする
+func (u *accountUsecase) PurgeDeletedAccounts(ctx context.Context, now time.Time) (int, error) {
+	purged := 0
+	for {
+		accounts, err := u.accountRepo.ListDeletionDue(ctx, now, purgeBatchSize)
+		if err != nil {
+			return purged, fmt.Errorf("failed to list accounts due for deletion: %w", err)
+		}
+
+		for _, account := range accounts {
+			deleted, err := u.purge(ctx, account.ID, now)
+			if err != nil {
+				return purged, fmt.Errorf("failed to purge account %s: %w", account.ID, err)
+			}
+			if !deleted {
+				continue
+			}
+			purged++
+
+			// 対象アカウントの監査ログは一緒に削除されるため、保存せずにアラートとしてのみ出力する
+			recordSecurityEvent(ctx, domain.NopSecurityAuditLogRepository{}, account.ID,
+				domain.EventAccountPurged,
+				"Account purged after the deletion grace period.",
+				"", "",
+				domain.SecurityAuditMetadata{
+					"tenant_id":             account.TenantID,
+					"deletion_scheduled_at": account.DeletionScheduledAt.UTC().Format(time.RFC3339),
+				})
+		}
+
+		if len(accounts) < purgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+// purge 猶予期間が過ぎたアカウントとそのプロジェクトを削除する
+// 一覧の取得後に復元された場合は削除せずにfalseを返す
+func (u *accountUsecase) purge(ctx context.Context, id uuid.UUID, now time.Time) (bool, error) {
+	deleted := false
+	err := u.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
 		account, err := u.accountRepo.GetByID(ctx, id)
 		if err != nil {
+			if errors.Is(err, domain.ErrAccountNotFound) {
+				return nil
+			}
 			return err
 		}
-		if account == nil {
-			return domain.ErrAccountNotFound
+		if !account.IsDeletionDue(now) {
+			return nil
 		}
 
 		// このアカウントに関連するすべてのプロジェクトを削除
@@ -164,6 +829,117 @@ func (u *accountUsecase) Delete(ctx context.Context, id uuid.UUID) error {
 			return err
 		}
 
+		deleted = true
 		return nil
 	})
+	return deleted, err
+}
+
+// applyProfileField プロフィール項目を部分更新する
+// valueがnilの場合は変更せず、空文字の場合は未設定に戻す
+func applyProfileField(field **string, value *string) {
+	if value == nil {
+		return
+	}
+	if *value == "" {
+		*field = nil
+		return
+	}
+	v := *value
+	*field = &v
+}
+
+// Export アカウントのプロフィール、プロジェクト、監査ログを書き出す（データポータビリティ・開示請求用）
+// 本人と管理者のみ実行でき、権限やアカウントの存在はWriteAccountの呼び出し前に確認する
+// パスワードのハッシュなどの秘密情報は書き出さず、監査ログのメタデータからも取り除く
+func (u *accountUsecase) Export(ctx context.Context, id uuid.UUID, actor Actor, w AccountExportWriter) error {
+	if actor.ID != id && actor.Role != domain.RoleAdmin {
+		return domain.ErrForbidden
+	}
+
+	account, err := getTenantAccount(ctx, u.accountRepo, id)
+	if err != nil {
+		return err
+	}
+	if err := w.WriteAccount(account); err != nil {
+		return err
+	}
+
+	// 作成日時の新しい順にカーソルで読み込む（読み込み中に作成されたプロジェクトで重複・欠落しない）
+	filter := domain.ProjectFilter{AccountID: &id, Limit: exportPageSize}
+	for {
+		projects, err := u.projectRepo.Search(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to export projects: %w", err)
+		}
+		if len(projects) > 0 {
+			if err := w.WriteProjects(projects); err != nil {
+				return err
+			}
+		}
+		if len(projects) < exportPageSize {
+			break
+		}
+		filter.Cursor = &domain.PageCursor{ID: projects[len(projects)-1].ID}
+	}
+
+	for offset := 0; ; offset += exportPageSize {
+		logs, err := u.securityAudit.GetByAccountID(ctx, id, exportPageSize, offset)
+		if err != nil {
+			return fmt.Errorf("failed to export security events: %w", err)
+		}
+		if len(logs) > 0 {
+			for i, log := range logs {
+				logs[i] = redactAuditLog(log)
+			}
+			if err := w.WriteSecurityEvents(logs); err != nil {
+				return err
+			}
+		}
+		if len(logs) < exportPageSize {
+			break
+		}
+	}
+
+	if actor.IsAdminActingOn(id) {
+		recordAdminAction(ctx, u.securityAudit, actor, id, id, domain.AdminActionExportAccount, nil)
+	}
+	return nil
+}
+
+// redactAuditLog 監査ログのメタデータから秘密情報のキーを取り除いたコピーを返す
+// メタデータを解析できない場合は内容を書き出さない
+func redactAuditLog(log *domain.SecurityAuditLog) *domain.SecurityAuditLog {
+	redacted := *log
+	if len(log.Metadata) == 0 {
+		return &redacted
+	}
+
+	var metadata domain.SecurityAuditMetadata
+	if err := json.Unmarshal(log.Metadata, &metadata); err != nil {
+		redacted.Metadata = nil
+		return &redacted
+	}
+	for key := range metadata {
+		if isSecretMetadataKey(key) {
+			delete(metadata, key)
+		}
+	}
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		redacted.Metadata = nil
+		return &redacted
+	}
+	redacted.Metadata = encoded
+	return &redacted
+}
+
+// isSecretMetadataKey ハッシュ、シークレット、パスワード、トークンそのものを表すメタデータのキーか判定
+// token_idなどの識別子は秘密情報ではないため残す
+func isSecretMetadataKey(key string) bool {
+	key = strings.ToLower(key)
+	return strings.Contains(key, "hash") ||
+		strings.Contains(key, "secret") ||
+		strings.Contains(key, "password") ||
+		key == "token" || strings.HasSuffix(key, "_token")
 }
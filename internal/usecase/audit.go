@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/google/uuid"
+	"github.com/labstack/gommon/log"
+)
+
+// Actor 操作したアカウントとリクエストの送信元（監査ログの記録に使用）
+type Actor struct {
+	ID        uuid.UUID
+	Role      domain.Role
+	UserAgent string
+	IPAddress string
+}
+
+// IsAdminActingOn 管理者が自分以外のアカウントを操作しているか返す
+func (a Actor) IsAdminActingOn(accountID uuid.UUID) bool {
+	return a.Role == domain.RoleAdmin && a.ID != accountID
+}
+
+// recordSecurityEvent セキュリティ監査ログを保存し、アラートとして出力する
+// 監査ログの保存に失敗しても元の操作は失敗させない
+// コンテキストに相関ID（リクエストID・トレースID）があればメタデータに追加する
+func recordSecurityEvent(
+	ctx context.Context,
+	repo domain.SecurityAuditLogRepository,
+	accountID uuid.UUID,
+	eventType domain.SecurityEventType,
+	description string,
+	userAgent, ipAddress string,
+	metadata domain.SecurityAuditMetadata,
+) {
+	if correlation, ok := domain.CorrelationFromContext(ctx); ok {
+		if metadata == nil {
+			metadata = domain.SecurityAuditMetadata{}
+		}
+		correlation.AddTo(metadata)
+	}
+
+	// セキュリティ監査ログを作成
+	var userAgentPtr, ipAddressPtr *string
+	if userAgent != "" {
+		userAgentPtr = &userAgent
+	}
+	if ipAddress != "" {
+		ipAddressPtr = &ipAddress
+	}
+
+	auditLog, err := domain.NewSecurityAuditLog(
+		accountID,
+		eventType,
+		description,
+		ipAddressPtr,
+		userAgentPtr,
+		metadata,
+	)
+	if err != nil {
+		fmt.Printf("[ERROR] Failed to create security audit log: %v\n", err)
+		return
+	}
+
+	if err := repo.Create(ctx, auditLog); err != nil {
+		fmt.Printf("[ERROR] Failed to save security audit log: %v\n", err)
+	}
+
+	log.Warnf("[SECURITY ALERT] AccountID: %s, Event: %s, Description: %s, IP: %s\n", accountID.String(), eventType, description, ipAddress)
+}
+
+// recordAdminAction 管理者の操作をADMIN_ACTIONとして記録する
+// ownerIDは監査ログを紐付けるアカウント（通常は操作対象、削除時は対象と一緒にログが消えないよう管理者自身）
+func recordAdminAction(
+	ctx context.Context,
+	repo domain.SecurityAuditLogRepository,
+	actor Actor,
+	ownerID, targetID uuid.UUID,
+	action domain.AdminAction,
+	metadata domain.SecurityAuditMetadata,
+) {
+	if metadata == nil {
+		metadata = domain.SecurityAuditMetadata{}
+	}
+	metadata["action"] = string(action)
+	metadata["admin_id"] = actor.ID.String()
+	metadata["target_account_id"] = targetID.String()
+
+	recordSecurityEvent(ctx, repo, ownerID,
+		domain.EventAdminAction,
+		fmt.Sprintf("Administrator %s performed %s on account %s.", actor.ID, action, targetID),
+		actor.UserAgent, actor.IPAddress,
+		metadata)
+}
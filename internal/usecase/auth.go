@@ -4,21 +4,86 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/aida0710/jwt-auth/internal/auth"
 	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/infrastructure/database"
+	"github.com/aida0710/jwt-auth/internal/notification"
 	"github.com/google/uuid"
-	"github.com/labstack/gommon/log"
 )
 
+// maxRefreshTokenAttempts リフレッシュトークンのハッシュ重複時の最大生成回数
+const maxRefreshTokenAttempts = 3
+
+// newDeviceHistoryLimit 新しい端末・場所からのログインの判定で比較する過去のセッション数
+const newDeviceHistoryLimit = 100
+
+// lastLoginRecordInterval 同じ接続元からのログインで最終ログインを更新する最短の間隔
+// アクセストークンの期限ごとのリフレッシュで毎回書き込まないよう、間隔内の更新は省略する
+const lastLoginRecordInterval = time.Minute
+
+// AuthConfig 認証ユースケースの設定
+type AuthConfig struct {
+	// RefreshTokenExpiry リフレッシュトークン1回分の有効期限
+	RefreshTokenExpiry time.Duration
+	// SlidingRefresh 有効にするとリフレッシュのたびに有効期限を延長する
+	SlidingRefresh bool
+	// RefreshTokenMaxLifetime スライディング方式でのファミリーの絶対有効期限
+	RefreshTokenMaxLifetime time.Duration
+	// RefreshTokenReuseGrace ローテーション直後に使用済みトークンの再提示を再試行として許可する期間
+	// 期間内は発行済みの次のトークンを返し、期間外は再利用攻撃として全トークンを無効化する（0で無効）
+	RefreshTokenReuseGrace time.Duration
+	// RefreshRateLimit RefreshRateWindow内に1つのアカウントで許可するリフレッシュの上限（0で無効）
+	// 再利用検知を補完し、盗まれたトークンによる大量発行を期間が過ぎるまで拒否する
+	RefreshRateLimit int
+	// RefreshRateWindow リフレッシュの回数を数える期間
+	RefreshRateWindow time.Duration
+	// DisableSignup 有効にするとサインアップを拒否する（招待制、管理者によるアカウント作成には影響しない）
+	DisableSignup bool
+	// EmailDomainChecker サインアップ時のメールアドレスのドメイン検査（nilの場合は検査しない）
+	EmailDomainChecker *auth.EmailDomainChecker
+	// Lockout ログイン失敗によるロックアウトの設定（ログイン失敗の記録リポジトリが無い場合は無効）
+	Lockout domain.LockoutPolicy
+	// MagicLinkExpiry マジックリンクの有効期限
+	MagicLinkExpiry time.Duration
+	// MagicLinkMaxRequests MagicLinkWindow内に1つのメールアドレスへ送信するマジックリンクの上限
+	MagicLinkMaxRequests int
+	// MagicLinkWindow マジックリンクの送信数を数える期間
+	MagicLinkWindow time.Duration
+	// MagicLinkURL メールに記載するリンクのURL（tokenクエリを付与する、空の場合はトークンのみを記載）
+	MagicLinkURL string
+	// InviteExpiry 招待の有効期限
+	InviteExpiry time.Duration
+	// NewDeviceMatch 新しい端末・場所からのログインを判定する厳しさ（空文字またはoffで判定しない）
+	NewDeviceMatch domain.DeviceMatchMode
+	// SignupRoles サインアップで割り当てるロールの方針（ゼロ値の場合は指定を無視して常に一般ユーザー）
+	SignupRoles domain.SignupRolePolicy
+	// UsernameLogin 有効にするとサインアップでユーザー名を設定でき、メールアドレスの代わりにユーザー名でログインできる
+	UsernameLogin bool
+	// IDs 作成するアカウント・セッションなどのIDの生成とトークンのアカウントIDの検証（ゼロ値の場合はv7、v4も受け付ける）
+	IDs domain.IDConfig
+	// Names サインアップで設定するアカウント名の検証ルール（ゼロ値の場合はDefaultNameConfig）
+	Names domain.NameConfig
+	// Passwords パスワードのハッシュ化と検証（AccountConfigと同じペッパーを設定する、ゼロ値の場合はペッパーを適用しない）
+	Passwords auth.PasswordHasher
+}
+
 // AuthUsecase 認証関連のユースケース
 type AuthUsecase struct {
 	accountRepo       domain.AccountRepository
 	refreshTokenRepo  domain.RefreshTokenRepository
 	securityAuditRepo domain.SecurityAuditLogRepository
+	loginAttemptRepo  domain.LoginAttemptRepository
+	magicLinkRepo     domain.MagicLinkRepository
+	inviteRepo        domain.InviteRepository
+	txManager         database.TransactionManager
+	notifier          notification.Notifier
 	jwtManager        *auth.JWTManager
+	config            AuthConfig
 }
 
 // NewAuthUsecase 新しい認証ユースケースを作成
@@ -26,13 +91,52 @@ func NewAuthUsecase(
 	accountRepo domain.AccountRepository,
 	refreshTokenRepo domain.RefreshTokenRepository,
 	securityAuditRepo domain.SecurityAuditLogRepository,
+	loginAttemptRepo domain.LoginAttemptRepository,
+	magicLinkRepo domain.MagicLinkRepository,
+	inviteRepo domain.InviteRepository,
+	txManager database.TransactionManager,
+	notifier notification.Notifier,
 	jwtManager *auth.JWTManager,
+	config AuthConfig,
 ) *AuthUsecase {
+	// デフォルト値を設定
+	if config.RefreshTokenExpiry == 0 {
+		config.RefreshTokenExpiry = 30 * 24 * time.Hour
+	}
+	if config.RefreshTokenMaxLifetime < config.RefreshTokenExpiry {
+		config.RefreshTokenMaxLifetime = config.RefreshTokenExpiry
+	}
+	if config.MagicLinkExpiry == 0 {
+		config.MagicLinkExpiry = 15 * time.Minute
+	}
+	if config.MagicLinkMaxRequests == 0 {
+		config.MagicLinkMaxRequests = 3
+	}
+	if config.MagicLinkWindow == 0 {
+		config.MagicLinkWindow = time.Hour
+	}
+	if config.InviteExpiry == 0 {
+		config.InviteExpiry = 7 * 24 * time.Hour
+	}
+	if len(config.Names.AllowedClasses) == 0 {
+		config.Names = domain.DefaultNameConfig
+	}
+	// 監査ログのリポジトリを省略した場合は記録しない
+	if securityAuditRepo == nil {
+		securityAuditRepo = domain.NopSecurityAuditLogRepository{}
+	}
+
 	return &AuthUsecase{
 		accountRepo:       accountRepo,
 		refreshTokenRepo:  refreshTokenRepo,
 		securityAuditRepo: securityAuditRepo,
+		loginAttemptRepo:  loginAttemptRepo,
+		magicLinkRepo:     magicLinkRepo,
+		inviteRepo:        inviteRepo,
+		txManager:         txManager,
+		notifier:          notifier,
 		jwtManager:        jwtManager,
+		config:            config,
 	}
 }
 
@@ -41,14 +145,38 @@ type SignUpInput struct {
 	Email    string
 	Password string
 	Name     string
+	// Username ログインに使用するユーザー名（任意、AuthConfig.UsernameLoginが有効な場合のみ指定できる）
+	Username string
+	// InviteToken 招待のトークン（サインアップが無効な場合は必須）
+	InviteToken string
+	// Role クライアントが指定したロール（AuthConfig.SignupRolesで自分で選べるロールのみ採用する）
+	Role domain.Role
 }
 
 // LoginInput ログインの入力
 type LoginInput struct {
-	Email     string
-	Password  string
-	UserAgent string
-	IPAddress string
+	Email string
+	// Identifier メールアドレスまたはユーザー名（空の場合はEmailを使用、@を含む場合はメールアドレスとして扱う）
+	Identifier string
+	Password   string
+	UserAgent  string
+	IPAddress  string
+	// DeviceName セッションに付ける端末名（空の場合はUser-Agentの要約）
+	DeviceName string
+	// Audience アクセストークンの対象とするAudience（空の場合は既定のAudience、アカウントに許可されたもののみ指定できる）
+	Audience string
+}
+
+// RefreshInput トークンのリフレッシュの入力
+type RefreshInput struct {
+	RefreshToken string
+	UserAgent    string
+	IPAddress    string
+	// DeviceName 新しい端末名（空の場合は元のセッションの端末名を引き継ぐ）
+	DeviceName string
+	// Audience アクセストークンの対象とするAudience（空の場合は既定のAudience、アカウントに許可されたもののみ指定できる）
+	// セッションには保存しないため、特定のサービス向けのトークンが必要な場合はリフレッシュのたびに指定する
+	Audience string
 }
 
 // AuthTokens 認証トークンのペア
@@ -57,10 +185,81 @@ type AuthTokens struct {
 	RefreshToken string
 	ExpiresIn    int
 	Account      *domain.Account
+
+	refreshTokenID uuid.UUID // 保存したリフレッシュトークンのID
+}
+
+// MagicLinkInput マジックリンクの送信要求の入力
+type MagicLinkInput struct {
+	Email     string
+	UserAgent string
+	IPAddress string
+}
+
+// DebugTokenInput デバッグ用のアクセストークンの発行の入力（AccountIDとEmailのどちらか一方を指定）
+type DebugTokenInput struct {
+	AccountID uuid.UUID
+	Email     string
+}
+
+// VerifyMagicLinkInput マジックリンクによるログインの入力
+type VerifyMagicLinkInput struct {
+	Token     string
+	UserAgent string
+	IPAddress string
+	// DeviceName セッションに付ける端末名（空の場合はUser-Agentの要約）
+	DeviceName string
+}
+
+// errSuccessorUnavailable 猶予期間内でも次のトークンを再送できない場合のエラー（再利用として扱う）
+var errSuccessorUnavailable = errors.New("successor refresh token is unavailable")
+
+// errMagicLinkUnavailable マジックリンクのリポジトリまたは通知が設定されていない場合のエラー
+var errMagicLinkUnavailable = errors.New("magic link login is not configured")
+
+// errInviteUnavailable 招待のリポジトリまたはトランザクションマネージャーが設定されていない場合のエラー
+var errInviteUnavailable = errors.New("invites are not configured")
+
+// CreateInviteInput 招待の作成の入力
+type CreateInviteInput struct {
+	// Role サインアップしたアカウントに割り当てるロール（空の場合はRoleUser）
+	Role domain.Role
+	// TenantID サインアップしたアカウントが属するテナント（空の場合は管理者のテナント）
+	TenantID string
+	Actor    Actor
+}
+
+// CreatedInvite 作成した招待と、サインアップ時に提示するトークン（トークンは作成時にのみ返す）
+type CreatedInvite struct {
+	Invite *domain.Invite
+	Token  string
 }
 
 // SignUp 新規アカウントを作成
+// 招待のトークンを指定した場合は招待を消費し、招待で指定したロールとテナントでアカウントを作成する
+// 招待が無い場合のロールはAuthConfig.SignupRolesで決め、自分で選べないロールの指定は無視する
+// サインアップが無効な場合は招待のトークンが必須
 func (u *AuthUsecase) SignUp(ctx context.Context, input SignUpInput) (*AuthTokens, error) {
+	if input.InviteToken == "" && u.config.DisableSignup {
+		return nil, domain.ErrSignupDisabled
+	}
+
+	// パスワードのハッシュ化より前に、使用できない招待を拒否する
+	var invite *domain.Invite
+	if input.InviteToken != "" {
+		var err error
+		if invite, err = u.usableInvite(ctx, input.InviteToken); err != nil {
+			return nil, err
+		}
+	}
+
+	// 使い捨てメールなど許可していないドメインを拒否
+	if u.config.EmailDomainChecker != nil {
+		if err := u.config.EmailDomainChecker.Check(ctx, input.Email); err != nil {
+			return nil, err
+		}
+	}
+
 	existing, err := u.accountRepo.GetByEmail(ctx, input.Email)
 	if err != nil && !errors.Is(err, domain.ErrNotFound) {
 		return nil, fmt.Errorf("failed to check existing account: %w", err)
@@ -69,33 +268,169 @@ func (u *AuthUsecase) SignUp(ctx context.Context, input SignUpInput) (*AuthToken
 		return nil, domain.ErrEmailAlreadyExists
 	}
 
-	passwordHash, err := auth.HashPassword(input.Password)
+	username, err := u.signupUsername(ctx, input.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	passwordHash, err := u.config.Passwords.Hash(input.Password)
 	// fmt.Printf("passwordHash: %s\n", passwordHash)
 	if err != nil {
 		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 
-	// NewAccountを使用してUUID v4で作成
-	account := domain.NewAccount(input.Email, input.Name, passwordHash)
+	// NewAccountで作成（IDは設定されたバージョンのUUIDで生成される）
+	account := domain.NewAccount(input.Email, domain.NormalizeName(input.Name), passwordHash)
+	account.ID = u.config.IDs.NewID()
+	account.TenantID = domain.ResolveTenantID(ctx)
+	account.Role = u.config.SignupRoles.Resolve(input.Role)
+	account.Username = username
+	if invite != nil {
+		account.Role = invite.Role
+		account.TenantID = invite.TenantID
+	}
 
 	// アカウントを検証
 	if err := account.Validate(); err != nil {
 		return nil, err
 	}
+	if err := u.config.Names.ValidateName(account.Name); err != nil {
+		return nil, err
+	}
 
 	// データベースに保存
-	if err := u.accountRepo.Create(ctx, account); err != nil {
+	if invite != nil {
+		err = u.createInvitedAccount(ctx, account, invite)
+	} else {
+		err = u.accountRepo.Create(ctx, account)
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInvite) {
+			return nil, err
+		}
+		// 同じメールアドレスで同時にサインアップした場合、両方が事前の存在チェックを通過し、後から保存した側が一意制約に違反する
+		// 事前チェックで見つかった場合と同じエラーにして、競合に負けたリクエストにも409を返す
+		if errors.Is(err, domain.ErrDuplicateEmail) {
+			return nil, domain.ErrEmailAlreadyExists
+		}
 		return nil, fmt.Errorf("failed to create account: %w", err)
 	}
 
 	// トークンを生成
-	return u.generateTokens(ctx, account, "", "")
+	return u.generateTokens(ctx, account, "", "", "", "", nil)
 }
 
-// Login メールとパスワードでログイン
+// signupUsername サインアップで指定されたユーザー名を正規化して検証する（未指定の場合はnil）
+// 使用済みのユーザー名はErrDuplicateUsernameとし、同時に作成された場合は一意インデックスで拒否する
+func (u *AuthUsecase) signupUsername(ctx context.Context, username string) (*string, error) {
+	username = domain.NormalizeUsername(username)
+	if username == "" {
+		return nil, nil
+	}
+	if !u.config.UsernameLogin {
+		return nil, fmt.Errorf("%w: usernames are not enabled", domain.ErrInvalidUsername)
+	}
+	if err := domain.ValidateUsername(username); err != nil {
+		return nil, err
+	}
+
+	existing, err := u.accountRepo.GetByUsername(ctx, username)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, fmt.Errorf("failed to check existing username: %w", err)
+	}
+	if existing != nil {
+		return nil, domain.ErrDuplicateUsername
+	}
+	return &username, nil
+}
+
+// usableInvite トークンに対応する未使用かつ有効期限内の招待を返す
+func (u *AuthUsecase) usableInvite(ctx context.Context, token string) (*domain.Invite, error) {
+	if u.inviteRepo == nil || u.txManager == nil {
+		return nil, errInviteUnavailable
+	}
+
+	invite, err := u.inviteRepo.GetByTokenHash(ctx, auth.HashToken(token))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidInvite
+		}
+		return nil, fmt.Errorf("failed to get invite: %w", err)
+	}
+	if !invite.IsUsable(domain.Now()) {
+		return nil, domain.ErrInvalidInvite
+	}
+	return invite, nil
+}
+
+// createInvitedAccount アカウントの作成と招待の消費を1つのトランザクションで行う
+// 同時に別のサインアップが招待を消費していた場合はアカウントを作成せずにErrInvalidInviteを返し、
+// アカウントの作成に失敗した場合（メールアドレスの重複など）は招待を消費しない
+func (u *AuthUsecase) createInvitedAccount(ctx context.Context, account *domain.Account, invite *domain.Invite) error {
+	return u.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := u.accountRepo.Create(ctx, account); err != nil {
+			return err
+		}
+		consumed, err := u.inviteRepo.MarkAsConsumed(ctx, invite.ID, account.ID)
+		if err != nil {
+			return fmt.Errorf("failed to consume invite: %w", err)
+		}
+		if !consumed {
+			return domain.ErrInvalidInvite
+		}
+		return nil
+	})
+}
+
+// CreateInvite 招待制のサインアップで使用する招待を作成（管理者のみ）
+// テナントに属する管理者は、自分のテナント以外への招待を作成できない
+func (u *AuthUsecase) CreateInvite(ctx context.Context, input CreateInviteInput) (*CreatedInvite, error) {
+	if u.inviteRepo == nil {
+		return nil, errInviteUnavailable
+	}
+
+	role := input.Role
+	if role == "" {
+		role = domain.RoleUser
+	}
+	if !role.IsValid() {
+		return nil, domain.ErrInvalidRole
+	}
+
+	tenantID := input.TenantID
+	if tenantID == "" {
+		tenantID = domain.ResolveTenantID(ctx)
+	}
+	if !domain.InTenant(ctx, tenantID) {
+		return nil, domain.ErrForbidden
+	}
+
+	token, err := auth.GenerateSecureToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invite token: %w", err)
+	}
+	invite := domain.NewInvite(input.Actor.ID, auth.HashToken(token), role, tenantID, domain.Now().Add(u.config.InviteExpiry))
+	invite.ID = u.config.IDs.NewID()
+	if err := u.inviteRepo.Create(ctx, invite); err != nil {
+		return nil, err
+	}
+
+	return &CreatedInvite{Invite: invite, Token: token}, nil
+}
+
+// Login メールアドレス（AuthConfig.UsernameLoginが有効な場合はユーザー名も可）とパスワードでログイン
 func (u *AuthUsecase) Login(ctx context.Context, input LoginInput) (*AuthTokens, error) {
+	deviceName, err := domain.NormalizeDeviceName(input.DeviceName)
+	if err != nil {
+		return nil, err
+	}
+
 	// アカウントを取得
-	account, err := u.accountRepo.GetByEmail(ctx, input.Email)
+	identifier := input.Identifier
+	if identifier == "" {
+		identifier = input.Email
+	}
+	account, err := u.accountByIdentifier(ctx, identifier)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			return nil, domain.ErrInvalidCredentials
@@ -103,16 +438,172 @@ func (u *AuthUsecase) Login(ctx context.Context, input LoginInput) (*AuthTokens,
 		return nil, fmt.Errorf("failed to get account: %w", err)
 	}
 
-	if err := auth.VerifyPassword(input.Password, account.PasswordHash); err != nil {
+	// ロック中はパスワードを検証せずに拒否（ロック中の総当たりを無意味にする）
+	now := domain.Now()
+	var attempt *domain.LoginAttempt
+	if u.lockoutEnabled() {
+		attempt, err = u.loginAttemptRepo.GetByAccountID(ctx, account.ID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get login attempt: %w", err)
+		}
+		if attempt == nil {
+			attempt = domain.NewLoginAttempt(account.ID)
+		}
+		if attempt.IsLocked(now) {
+			return nil, &domain.AccountLockedError{
+				LockedUntil: *attempt.LockedUntil,
+				RetryAfter:  attempt.LockedUntil.Sub(now),
+			}
+		}
+	}
+
+	if err := u.config.Passwords.Verify(input.Password, account.PasswordHash); err != nil {
+		if attempt != nil {
+			return nil, u.recordLoginFailure(ctx, attempt, now, input.UserAgent, input.IPAddress)
+		}
 		return nil, domain.ErrInvalidCredentials
 	}
 
+	// 成功した場合は連続失敗回数をリセット
+	if attempt != nil && (attempt.FailedCount > 0 || attempt.LockedUntil != nil) {
+		attempt.RecordSuccess()
+		if err := u.loginAttemptRepo.Save(ctx, attempt); err != nil {
+			return nil, fmt.Errorf("failed to reset login attempt: %w", err)
+		}
+	}
+
+	// 停止中・削除の猶予期間中のアカウントは正しいパスワードでもログインさせない
+	if err := account.CheckCanSignIn(); err != nil {
+		return nil, err
+	}
+	if err := account.CheckAudience(input.Audience); err != nil {
+		return nil, err
+	}
+
+	u.rehashPassword(ctx, account, input.Password)
+	u.checkNewDevice(ctx, account, input.UserAgent, input.IPAddress)
+
 	// トークンを生成
-	return u.generateTokens(ctx, account, input.UserAgent, input.IPAddress)
+	tokens, err := u.generateTokens(ctx, account, input.UserAgent, input.IPAddress, deviceName, input.Audience, nil)
+	if err != nil {
+		return nil, err
+	}
+	u.recordLogin(ctx, tokens.Account, input.IPAddress)
+	return tokens, nil
+}
+
+// accountByIdentifier ログインの識別子に対応するアカウントを取得
+// @を含む場合はメールアドレス、含まない場合はユーザー名として検索する（ユーザー名には@を使用できないため区別できる）
+// ユーザー名でのログインが無効な場合は、存在しないメールアドレスと同じくErrAccountNotFoundを返す
+func (u *AuthUsecase) accountByIdentifier(ctx context.Context, identifier string) (*domain.Account, error) {
+	if strings.Contains(identifier, "@") {
+		return u.accountRepo.GetByEmail(ctx, identifier)
+	}
+	if !u.config.UsernameLogin {
+		return nil, domain.ErrAccountNotFound
+	}
+	return u.accountRepo.GetByUsername(ctx, domain.NormalizeUsername(identifier))
+}
+
+// rehashPassword ハッシュが現在のペッパーで作成されていない場合、現在のペッパーでハッシュし直して保存する
+// 平文のパスワードが分かるのはログインの成功時のみのため、ペッパーのローテーションはここで進める
+// パスワード自体は変わらないため、バージョンと更新日時は変えずにハッシュのみを置き換える
+// 保存に失敗してもログインは失敗させない（次回のログインで再試行する）
+func (u *AuthUsecase) rehashPassword(ctx context.Context, account *domain.Account, password string) {
+	if !u.config.Passwords.NeedsRehash(account.PasswordHash) {
+		return
+	}
+
+	passwordHash, err := u.config.Passwords.Hash(password)
+	if err != nil {
+		fmt.Printf("[ERROR] Failed to rehash password: %v\n", err)
+		return
+	}
+	if err := u.accountRepo.UpdatePasswordHash(ctx, account.ID, passwordHash); err != nil {
+		fmt.Printf("[ERROR] Failed to save rehashed password: %v\n", err)
+		return
+	}
+	account.PasswordHash = passwordHash
+}
+
+// recordLogin 最終ログインの日時と接続元を記録し、レスポンスのアカウントにも反映する
+// 同じ接続元からlastLoginRecordInterval以内に記録済みの場合は書き込まない
+// 保存に失敗してもログインは失敗させない（次回のログインで再試行する）
+func (u *AuthUsecase) recordLogin(ctx context.Context, account *domain.Account, ipAddress string) {
+	now := domain.Now()
+	if !account.ShouldRecordLogin(now, ipAddress, lastLoginRecordInterval) {
+		return
+	}
+
+	var ipAddressPtr *string
+	if ipAddress != "" {
+		ipAddressPtr = &ipAddress
+	}
+	if err := u.accountRepo.RecordLogin(ctx, account.ID, now, ipAddressPtr); err != nil {
+		fmt.Printf("[ERROR] Failed to record last login: %v\n", err)
+		return
+	}
+	account.LastLoginAt = &now
+	account.LastLoginIP = ipAddressPtr
 }
 
-// RefreshToken リフレッシュトークンを使用して新しいトークンを生成
-func (u *AuthUsecase) RefreshToken(ctx context.Context, refreshToken string, userAgent, ipAddress string) (*AuthTokens, error) {
+// lockoutEnabled ログイン失敗によるロックアウトが有効か返す
+func (u *AuthUsecase) lockoutEnabled() bool {
+	return u.loginAttemptRepo != nil && u.config.Lockout.Enabled()
+}
+
+// recordLoginFailure ログインの失敗を記録し、ログインに返すエラーを返す
+// 失敗回数が上限に達した場合はロックしてACCOUNT_LOCKEDを記録する
+func (u *AuthUsecase) recordLoginFailure(ctx context.Context, attempt *domain.LoginAttempt, now time.Time, userAgent, ipAddress string) error {
+	cooldown := attempt.RecordFailure(now, u.config.Lockout)
+	if err := u.loginAttemptRepo.Save(ctx, attempt); err != nil {
+		return fmt.Errorf("failed to record login attempt: %w", err)
+	}
+	if cooldown == 0 {
+		return domain.ErrInvalidCredentials
+	}
+
+	u.logSecurityEvent(
+		ctx,
+		attempt.AccountID,
+		domain.EventAccountLocked,
+		"Account locked after repeated failed login attempts",
+		userAgent,
+		ipAddress,
+		domain.SecurityAuditMetadata{
+			"cooldown_seconds":    int(cooldown.Seconds()),
+			"locked_until":        attempt.LockedUntil.UTC().Format(time.RFC3339),
+			"lockout_count":       attempt.LockoutCount,
+			"max_failed_attempts": u.config.Lockout.MaxFailedAttempts,
+		},
+	)
+
+	return &domain.AccountLockedError{
+		LockedUntil: *attempt.LockedUntil,
+		RetryAfter:  cooldown,
+	}
+}
+
+// RefreshToken リフレッシュトークンを使用して既定のAudienceの新しいトークンを生成
+// deviceNameを省略した場合は元のセッションの端末名を引き継ぐ
+func (u *AuthUsecase) RefreshToken(ctx context.Context, refreshToken string, userAgent, ipAddress, deviceName string) (*AuthTokens, error) {
+	return u.Refresh(ctx, RefreshInput{
+		RefreshToken: refreshToken,
+		UserAgent:    userAgent,
+		IPAddress:    ipAddress,
+		DeviceName:   deviceName,
+	})
+}
+
+// Refresh リフレッシュトークンを使用して新しいトークンを生成
+// Audienceを指定した場合は、アカウントに許可されていればそのAudienceのみを対象とするアクセストークンを発行する
+func (u *AuthUsecase) Refresh(ctx context.Context, input RefreshInput) (*AuthTokens, error) {
+	refreshToken, userAgent, ipAddress := input.RefreshToken, input.UserAgent, input.IPAddress
+	deviceName, err := domain.NormalizeDeviceName(input.DeviceName)
+	if err != nil {
+		return nil, err
+	}
+
 	// リフレッシュトークンを検証
 	claims, err := u.jwtManager.ValidateRefreshToken(refreshToken)
 	if err != nil {
@@ -123,7 +614,7 @@ func (u *AuthUsecase) RefreshToken(ctx context.Context, refreshToken string, use
 			u.logSecurityEvent(ctx, uuid.Nil,
 				domain.EventSuspiciousLogin,
 				fmt.Sprintf("Invalid refresh token attempt: %v", err),
-				userAgent, ipAddress)
+				userAgent, ipAddress, nil)
 		}
 		return nil, domain.ErrInvalidToken
 	}
@@ -140,8 +631,28 @@ func (u *AuthUsecase) RefreshToken(ctx context.Context, refreshToken string, use
 
 	// 使用済みトークンの再利用を検出（セキュリティ侵害の可能性）
 	if storedToken.UsedAt != nil {
+		// ローテーション直後の再試行（通信エラーなど）は、発行済みの次のトークンを再送する
+		if storedToken.IsRetryWithinGrace(domain.Now(), u.config.RefreshTokenReuseGrace) {
+			tokens, err := u.reissueSuccessor(ctx, storedToken, input.Audience)
+			if err == nil {
+				u.logSecurityEvent(ctx, storedToken.AccountID,
+					domain.EventTokenRetryAccepted,
+					"Used refresh token was presented again within the grace period. The already-issued token was returned.",
+					userAgent, ipAddress,
+					domain.SecurityAuditMetadata{
+						"token_id":      storedToken.ID.String(),
+						"successor_id":  storedToken.SuccessorID.String(),
+						"token_used_at": storedToken.UsedAt,
+					})
+				return tokens, nil
+			}
+			if !errors.Is(err, errSuccessorUnavailable) {
+				return nil, err
+			}
+		}
+
 		// セキュリティ侵害の可能性があるため、このアカウントのすべてのリフレッシュトークンを無効化
-		if err := u.refreshTokenRepo.RevokeByAccountID(ctx, storedToken.AccountID); err != nil {
+		if _, err := u.refreshTokenRepo.RevokeByAccountID(ctx, storedToken.AccountID); err != nil {
 			// エラーでも続行（セキュリティを優先）
 			fmt.Printf("Failed to revoke tokens for account %s: %v\n", storedToken.AccountID, err)
 		}
@@ -150,7 +661,16 @@ func (u *AuthUsecase) RefreshToken(ctx context.Context, refreshToken string, use
 		u.logSecurityEvent(ctx, storedToken.AccountID,
 			domain.EventTokenReuseDetected,
 			"Attempted reuse of used refresh token detected. All tokens have been revoked for security.",
-			userAgent, ipAddress)
+			userAgent, ipAddress,
+			domain.SecurityAuditMetadata{
+				"token_id":          storedToken.ID.String(),
+				"family_id":         storedToken.FamilyID.String(),
+				"issued_ip_address": storedToken.IPAddress,
+				"issued_user_agent": storedToken.UserAgent,
+				"replay_ip_address": ipAddress,
+				"replay_user_agent": userAgent,
+				"token_used_at":     storedToken.UsedAt,
+			})
 
 		return nil, domain.ErrTokenCompromised
 	}
@@ -160,8 +680,17 @@ func (u *AuthUsecase) RefreshToken(ctx context.Context, refreshToken string, use
 		return nil, domain.ErrInvalidToken
 	}
 
+	// ファミリーの絶対有効期限を確認（スライディング方式の上限）
+	if storedToken.IsAbsoluteExpired(domain.Now()) {
+		return nil, domain.ErrTokenExpired
+	}
+
+	if err := u.checkRefreshRate(ctx, storedToken, userAgent, ipAddress); err != nil {
+		return nil, err
+	}
+
 	// claims.AccountIDをUUIDに変換
-	accountID, err := uuid.Parse(claims.AccountID)
+	accountID, err := u.config.IDs.ParseAccountID(claims.AccountID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid account ID in token: %w", err)
 	}
@@ -175,13 +704,142 @@ func (u *AuthUsecase) RefreshToken(ctx context.Context, refreshToken string, use
 		return nil, fmt.Errorf("failed to get account: %w", err)
 	}
 
-	// 古いトークンを使用済みにマーク
-	if err := u.refreshTokenRepo.MarkAsUsed(ctx, storedToken.ID); err != nil {
+	if err := account.CheckCanSignIn(); err != nil {
+		return nil, err
+	}
+
+	// 新しいトークンを生成（ファミリーを引き継ぐ）
+	tokens, err := u.generateTokens(ctx, account, userAgent, ipAddress, deviceName, input.Audience, storedToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// 古いトークンを使用済みにマークし、再試行時に再送できるよう次のトークンを記録
+	// 未使用の場合のみ更新するため、同じトークンでの同時リフレッシュは1つだけが成功する
+	rotated, err := u.refreshTokenRepo.MarkAsRotated(ctx, storedToken.ID, tokens.refreshTokenID)
+	if err != nil {
 		return nil, fmt.Errorf("failed to mark token as used: %w", err)
 	}
+	if !rotated {
+		return u.resolveConcurrentRefresh(ctx, storedToken.ID, tokens.refreshTokenID, userAgent, ipAddress, input.Audience)
+	}
 
-	// 新しいトークンを生成
-	return u.generateTokens(ctx, account, userAgent, ipAddress)
+	u.recordLogin(ctx, tokens.Account, ipAddress)
+	return tokens, nil
+}
+
+// checkRefreshRate アカウントの直近のリフレッシュ回数が上限に達していればREFRESH_RATE_LIMITEDを記録して拒否する
+// 拒否したトークンは使用済みにしないため、期間が過ぎれば同じトークンでリフレッシュできる
+func (u *AuthUsecase) checkRefreshRate(ctx context.Context, storedToken *domain.RefreshToken, userAgent, ipAddress string) error {
+	if u.config.RefreshRateLimit <= 0 {
+		return nil
+	}
+
+	refreshed, err := u.refreshTokenRepo.CountRotatedSince(ctx, storedToken.AccountID, domain.Now().Add(-u.config.RefreshRateWindow))
+	if err != nil {
+		return fmt.Errorf("failed to count refreshes: %w", err)
+	}
+	if refreshed < u.config.RefreshRateLimit {
+		return nil
+	}
+
+	u.logSecurityEvent(ctx, storedToken.AccountID,
+		domain.EventRefreshRateLimited,
+		"Token refresh was rejected because the per-account limit was reached",
+		userAgent, ipAddress,
+		domain.SecurityAuditMetadata{
+			"token_id":       storedToken.ID.String(),
+			"family_id":      storedToken.FamilyID.String(),
+			"refreshes":      refreshed,
+			"max_refreshes":  u.config.RefreshRateLimit,
+			"window_seconds": int(u.config.RefreshRateWindow.Seconds()),
+		})
+
+	return &domain.RefreshRateLimitedError{RetryAfter: u.config.RefreshRateWindow}
+}
+
+// resolveConcurrentRefresh 同時リフレッシュで先を越された場合に、先に発行された次のトークンを返す
+// 両方のリクエストが使用済みの確認を通過した後の競合のため、再利用攻撃ではなく再試行として扱う
+func (u *AuthUsecase) resolveConcurrentRefresh(ctx context.Context, tokenID, discardedID uuid.UUID, userAgent, ipAddress, audience string) (*AuthTokens, error) {
+	// 競合に負けた側で発行したトークンは使われないため無効化する
+	if err := u.refreshTokenRepo.Revoke(ctx, discardedID); err != nil {
+		return nil, fmt.Errorf("failed to revoke discarded refresh token: %w", err)
+	}
+
+	storedToken, err := u.refreshTokenRepo.GetByID(ctx, tokenID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	if storedToken.SuccessorID == nil || storedToken.RevokedAt != nil {
+		return nil, domain.ErrInvalidToken
+	}
+
+	tokens, err := u.reissueSuccessor(ctx, storedToken, audience)
+	if err != nil {
+		if errors.Is(err, errSuccessorUnavailable) {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	u.logSecurityEvent(ctx, storedToken.AccountID,
+		domain.EventTokenRetryAccepted,
+		"Refresh token was presented concurrently. The token issued by the first request was returned.",
+		userAgent, ipAddress,
+		domain.SecurityAuditMetadata{
+			"token_id":      storedToken.ID.String(),
+			"successor_id":  storedToken.SuccessorID.String(),
+			"token_used_at": storedToken.UsedAt,
+			"concurrent":    true,
+		})
+	return tokens, nil
+}
+
+// reissueSuccessor ローテーションで発行済みの次のリフレッシュトークンを再送する
+// アクセストークンは再試行で要求されたaudienceで発行し直す
+// 次のトークンが既に使用・無効化されている場合はerrSuccessorUnavailableを返す
+func (u *AuthUsecase) reissueSuccessor(ctx context.Context, used *domain.RefreshToken, audience string) (*AuthTokens, error) {
+	successor, err := u.refreshTokenRepo.GetByID(ctx, *used.SuccessorID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, errSuccessorUnavailable
+		}
+		return nil, fmt.Errorf("failed to get successor refresh token: %w", err)
+	}
+	if !successor.IsValid() {
+		return nil, errSuccessorUnavailable
+	}
+
+	// 発行時と同じクレームで署名し直すと同じトークン文字列になる
+	refreshToken, err := u.jwtManager.SignRefreshToken(successor.ID, successor.AccountID, successor.CreatedAt, successor.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign refresh token: %w", err)
+	}
+	if auth.HashToken(refreshToken) != successor.TokenHash {
+		return nil, errSuccessorUnavailable
+	}
+
+	account, err := u.accountRepo.GetByID(ctx, successor.AccountID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	if err := account.CheckCanSignIn(); err != nil {
+		return nil, err
+	}
+
+	accessToken, err := u.generateAccessToken(account, audience)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAuthTokens(account, accessToken, refreshToken, successor.ID), nil
 }
 
 // Logout リフレッシュトークンを無効化
@@ -207,68 +865,448 @@ func (u *AuthUsecase) Logout(ctx context.Context, refreshToken string) error {
 	return nil
 }
 
-// LogoutAll アカウントのすべてのリフレッシュトークンを無効化
-func (u *AuthUsecase) LogoutAll(ctx context.Context, accountID uuid.UUID) error {
-	if err := u.refreshTokenRepo.RevokeByAccountID(ctx, accountID); err != nil {
-		return fmt.Errorf("failed to revoke all tokens: %w", err)
+// RevokeSessionInput セッション無効化の入力
+// RefreshTokenとTokenHashのどちらか一方を指定する
+type RevokeSessionInput struct {
+	SessionID    uuid.UUID // 指定した場合はRefreshToken・TokenHashより優先する
+	RefreshToken string
+	TokenHash    string
+	ActorID      uuid.UUID   // 操作したアカウント
+	ActorRole    domain.Role // 操作したアカウントのロール
+	UserAgent    string
+	IPAddress    string
+}
+
+// RevokeSession セッションID、リフレッシュトークンまたはそのハッシュで指定したセッションを無効化
+// 管理者はすべてのセッションを、それ以外はアカウント自身のセッションのみを無効化できる
+func (u *AuthUsecase) RevokeSession(ctx context.Context, input RevokeSessionInput) error {
+	var storedToken *domain.RefreshToken
+	var err error
+	if input.SessionID != uuid.Nil {
+		storedToken, err = u.refreshTokenRepo.GetByID(ctx, input.SessionID)
+	} else {
+		tokenHash := strings.ToLower(strings.TrimSpace(input.TokenHash))
+		if input.RefreshToken != "" {
+			tokenHash = auth.HashToken(input.RefreshToken)
+		}
+		storedToken, err = u.refreshTokenRepo.GetByTokenHash(ctx, tokenHash)
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrSessionNotFound
+		}
+		return fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	// 他のテナントのアカウントのセッションは存在しないものとして扱う
+	if _, err := getTenantAccount(ctx, u.accountRepo, storedToken.AccountID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrSessionNotFound
+		}
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+
+	if input.ActorRole != domain.RoleAdmin && storedToken.AccountID != input.ActorID {
+		return domain.ErrForbidden
 	}
+
+	if err := u.refreshTokenRepo.Revoke(ctx, storedToken.ID); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+
+	// 調査時に誰がどのセッションを無効化したか追えるよう、セッションの所有者の監査ログに記録
+	u.logSecurityEvent(ctx, storedToken.AccountID,
+		domain.EventSessionRevoked,
+		fmt.Sprintf("Session %s was revoked by %s (%s).", storedToken.ID, input.ActorID, input.ActorRole),
+		input.UserAgent, input.IPAddress,
+		domain.SecurityAuditMetadata{
+			"token_id":        storedToken.ID.String(),
+			"family_id":       storedToken.FamilyID.String(),
+			"revoked_by":      input.ActorID.String(),
+			"revoked_by_role": string(input.ActorRole),
+		})
+
+	actor := Actor{ID: input.ActorID, Role: input.ActorRole, UserAgent: input.UserAgent, IPAddress: input.IPAddress}
+	if actor.IsAdminActingOn(storedToken.AccountID) {
+		recordAdminAction(ctx, u.securityAuditRepo, actor, storedToken.AccountID, storedToken.AccountID, domain.AdminActionRevokeSession, domain.SecurityAuditMetadata{
+			"token_id": storedToken.ID.String(),
+		})
+	}
+
 	return nil
 }
 
+// RevokeSessionsByOriginInput 送信元によるセッションの一括無効化の入力
+// IPAddressとUserAgentのどちらか一方を指定する
+type RevokeSessionsByOriginInput struct {
+	IPAddress string
+	UserAgent string // 完全一致で比較する
+	Actor     Actor
+}
+
+// RevokeSessionsByOrigin 送信元のIPアドレスまたはUser-Agentが一致するセッションをアカウントを問わず無効化（管理者のみ）
+// インシデント対応で使用し、条件と無効化した件数を管理者自身の監査ログに記録する
+func (u *AuthUsecase) RevokeSessionsByOrigin(ctx context.Context, input RevokeSessionsByOriginInput) (int64, error) {
+	if input.Actor.Role != domain.RoleAdmin {
+		return 0, domain.ErrForbidden
+	}
+
+	ipAddress := strings.TrimSpace(input.IPAddress)
+	userAgent := strings.TrimSpace(input.UserAgent)
+	if (ipAddress == "") == (userAgent == "") {
+		return 0, fmt.Errorf("%w: exactly one of ip_address or user_agent is required", domain.ErrInvalidRevocation)
+	}
+
+	var (
+		revoked  int64
+		err      error
+		criteria domain.SecurityAuditMetadata
+	)
+	if ipAddress != "" {
+		ip := net.ParseIP(ipAddress)
+		if ip == nil {
+			return 0, fmt.Errorf("%w: ip_address is not a valid IP address", domain.ErrInvalidRevocation)
+		}
+		// 保存時と同じ正規化した表記で比較する
+		ipAddress = ip.String()
+		criteria = domain.SecurityAuditMetadata{"ip_address": ipAddress}
+		revoked, err = u.refreshTokenRepo.RevokeByIP(ctx, ipAddress)
+	} else {
+		criteria = domain.SecurityAuditMetadata{"user_agent": userAgent}
+		revoked, err = u.refreshTokenRepo.RevokeByUserAgent(ctx, userAgent)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	// 対象は複数のアカウントにまたがるため、操作した管理者の監査ログに記録
+	metadata := domain.SecurityAuditMetadata{
+		"action":        string(domain.AdminActionBulkRevokeSession),
+		"admin_id":      input.Actor.ID.String(),
+		"criteria":      criteria,
+		"revoked_count": revoked,
+	}
+	u.logSecurityEvent(ctx, input.Actor.ID,
+		domain.EventAdminAction,
+		fmt.Sprintf("Administrator %s revoked %d sessions matching %v.", input.Actor.ID, revoked, criteria),
+		input.Actor.UserAgent, input.Actor.IPAddress,
+		metadata)
+
+	return revoked, nil
+}
+
+// CurrentSession リフレッシュトークンで指定したアカウント自身の有効なセッションを取得
+// 他のアカウントのセッションや、期限切れ・使用済み・無効化済みのセッションは存在しないものとして扱う
+func (u *AuthUsecase) CurrentSession(ctx context.Context, accountID uuid.UUID, refreshToken string) (*domain.RefreshToken, error) {
+	storedToken, err := u.refreshTokenRepo.GetByTokenHash(ctx, auth.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	if storedToken.AccountID != accountID || !storedToken.IsValid() {
+		return nil, domain.ErrSessionNotFound
+	}
+
+	sessionCopy := *storedToken
+	sessionCopy.TokenHash = ""
+	return &sessionCopy, nil
+}
+
+// CurrentAccount 認証済みアカウントの情報を取得
+func (u *AuthUsecase) CurrentAccount(ctx context.Context, accountID uuid.UUID) (*domain.Account, error) {
+	account, err := u.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	accountCopy := *account
+	accountCopy.PasswordHash = ""
+	return &accountCopy, nil
+}
+
+// IssueDebugAccessToken ログインを経ずにアカウントのアクセストークンを発行する（連携のデバッグ用）
+// パスワードやロックアウトの検証を行わないため、本番環境で呼び出さないこと（呼び出し側で制限する）
+// 停止中・削除の猶予期間中のアカウントには発行せず、発行したことはセキュリティ監査ログに記録する
+func (u *AuthUsecase) IssueDebugAccessToken(ctx context.Context, input DebugTokenInput) (string, error) {
+	var account *domain.Account
+	var err error
+	if input.AccountID != uuid.Nil {
+		account, err = u.accountRepo.GetByID(ctx, input.AccountID)
+	} else {
+		account, err = u.accountRepo.GetByEmail(ctx, input.Email)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get account: %w", err)
+	}
+	if err := account.CheckCanSignIn(); err != nil {
+		return "", err
+	}
+
+	accessToken, err := u.jwtManager.GenerateTenantAccessToken(account.TenantID, account.ID, account.Email, string(account.Role), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	u.logSecurityEvent(ctx, account.ID, domain.EventDebugTokenIssued,
+		"Debug access token issued without authentication", "", "", nil)
+
+	return accessToken, nil
+}
+
+// LogoutAll アカウントのすべてのリフレッシュトークンを無効化し、無効化したセッション数を返す
+func (u *AuthUsecase) LogoutAll(ctx context.Context, accountID uuid.UUID, userAgent, ipAddress string) (int, error) {
+	revoked, err := u.refreshTokenRepo.RevokeByAccountID(ctx, accountID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke all tokens: %w", err)
+	}
+
+	// 強制ログアウトはセキュリティイベントとして記録
+	u.logSecurityEvent(ctx, accountID,
+		domain.EventAllTokensRevoked,
+		fmt.Sprintf("All sessions were revoked by the account owner (%d sessions).", revoked),
+		userAgent, ipAddress,
+		domain.SecurityAuditMetadata{
+			"revoked_sessions": revoked,
+		})
+
+	return int(revoked), nil
+}
+
+// RequestMagicLink メールアドレス宛てに1回だけ使用できるログイン用リンクを送信
+// アカウントの有無を推測されないよう、存在しないメールアドレスや送信数の上限を超えた場合もエラーを返さない
+func (u *AuthUsecase) RequestMagicLink(ctx context.Context, input MagicLinkInput) error {
+	if u.magicLinkRepo == nil || u.notifier == nil {
+		return errMagicLinkUnavailable
+	}
+
+	account, err := u.accountRepo.GetByEmail(ctx, input.Email)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+
+	// メールアドレスごとに送信数を制限し、受信箱へのスパムやトークンの総当たりを防ぐ
+	now := domain.Now()
+	sent, err := u.magicLinkRepo.CountCreatedSince(ctx, account.ID, now.Add(-u.config.MagicLinkWindow))
+	if err != nil {
+		return fmt.Errorf("failed to count magic links: %w", err)
+	}
+	if sent >= u.config.MagicLinkMaxRequests {
+		u.logSecurityEvent(ctx, account.ID,
+			domain.EventMagicLinkRateLimited,
+			"Magic link was not sent because the per-email limit was reached",
+			input.UserAgent, input.IPAddress,
+			domain.SecurityAuditMetadata{
+				"max_requests":   u.config.MagicLinkMaxRequests,
+				"window_seconds": int(u.config.MagicLinkWindow.Seconds()),
+			})
+		return nil
+	}
+
+	token, err := auth.GenerateSecureToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate magic link token: %w", err)
+	}
+	link := domain.NewMagicLink(account.ID, auth.HashToken(token), now.Add(u.config.MagicLinkExpiry))
+	link.ID = u.config.IDs.NewID()
+	if err := u.magicLinkRepo.Create(ctx, link); err != nil {
+		return err
+	}
+
+	if err := u.notifier.Send(ctx, notification.Message{
+		To:      account.Email,
+		Subject: "Your sign-in link",
+		Body: fmt.Sprintf("Use this link to sign in. It expires in %s and can only be used once: %s",
+			u.config.MagicLinkExpiry, u.magicLinkFor(token)),
+	}); err != nil {
+		return fmt.Errorf("failed to send magic link email: %w", err)
+	}
+
+	return nil
+}
+
+// magicLinkFor メールに記載するリンクを返す（URLが未設定の場合はトークンのみ）
+func (u *AuthUsecase) magicLinkFor(token string) string {
+	return linkWithToken(u.config.MagicLinkURL, token)
+}
+
+// linkWithToken メールに記載するリンクとして、URLにtokenクエリを付与して返す（URLが未設定の場合はトークンのみ）
+func linkWithToken(baseURL, token string) string {
+	if baseURL == "" {
+		return token
+	}
+	separator := "?"
+	if strings.Contains(baseURL, "?") {
+		separator = "&"
+	}
+	return baseURL + separator + "token=" + url.QueryEscape(token)
+}
+
+// VerifyMagicLink マジックリンクのトークンを消費してトークンを発行
+// 同じトークンでの同時リクエストは1件だけが消費に成功し、それ以外はErrInvalidTokenを返す
+func (u *AuthUsecase) VerifyMagicLink(ctx context.Context, input VerifyMagicLinkInput) (*AuthTokens, error) {
+	if u.magicLinkRepo == nil {
+		return nil, errMagicLinkUnavailable
+	}
+
+	deviceName, err := domain.NormalizeDeviceName(input.DeviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := u.magicLinkRepo.GetByTokenHash(ctx, auth.HashToken(input.Token))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to get magic link: %w", err)
+	}
+	if !link.IsUsable(domain.Now()) {
+		return nil, domain.ErrInvalidToken
+	}
+
+	// 確認と消費の間に別のリクエストが消費していないよう、未使用の場合のみ消費済みにする
+	consumed, err := u.magicLinkRepo.MarkAsConsumed(ctx, link.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume magic link: %w", err)
+	}
+	if !consumed {
+		return nil, domain.ErrInvalidToken
+	}
+
+	account, err := u.accountRepo.GetByID(ctx, link.AccountID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	if err := account.CheckCanSignIn(); err != nil {
+		return nil, err
+	}
+
+	u.checkNewDevice(ctx, account, input.UserAgent, input.IPAddress)
+
+	tokens, err := u.generateTokens(ctx, account, input.UserAgent, input.IPAddress, deviceName, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	u.recordLogin(ctx, tokens.Account, input.IPAddress)
+	return tokens, nil
+}
+
+// checkNewDevice 過去のセッションに無い端末・場所からのログインであればNEW_DEVICE_LOGINを記録して本人に通知する
+// 今回のセッションを保存する前に呼び出す（失敗してもログインは妨げない）
+func (u *AuthUsecase) checkNewDevice(ctx context.Context, account *domain.Account, userAgent, ipAddress string) {
+	mode := u.config.NewDeviceMatch
+	if !mode.Enabled() {
+		return
+	}
+
+	history, err := u.refreshTokenRepo.ListRecentByAccountID(ctx, account.ID, newDeviceHistoryLimit)
+	if err != nil {
+		fmt.Printf("[ERROR] Failed to list sessions for new device check: %v\n", err)
+		return
+	}
+	origin := domain.NewLoginOrigin(userAgent, ipAddress)
+	if !mode.IsNew(origin, history) {
+		return
+	}
+
+	u.logSecurityEvent(ctx, account.ID,
+		domain.EventNewDeviceLogin,
+		"Login from a device or location not seen in previous sessions",
+		userAgent, ipAddress,
+		domain.SecurityAuditMetadata{
+			"browser":           origin.Device.Browser,
+			"os":                origin.Device.OS,
+			"device_type":       string(origin.Device.Device),
+			"subnet":            origin.Subnet,
+			"match_mode":        string(mode),
+			"compared_sessions": len(history),
+		})
+
+	if u.notifier == nil {
+		return
+	}
+	device := origin.Device.Summary()
+	if device == "" {
+		device = "unknown device"
+	}
+	location := origin.Subnet
+	if location == "" {
+		location = "unknown network"
+	}
+	if err := u.notifier.Send(ctx, notification.Message{
+		To:      account.Email,
+		Subject: "New sign-in to your account",
+		Body: fmt.Sprintf("Your account was signed in from a new device or location (%s, %s) at %s. "+
+			"If this wasn't you, change your password and sign out of all sessions.",
+			device, location, domain.Now().Format(time.RFC3339)),
+	}); err != nil {
+		fmt.Printf("[ERROR] Failed to send new device notification: %v\n", err)
+	}
+}
+
 // logSecurityEvent セキュリティイベントをログに記録
+// metadataには調査用の構造化情報を渡す（不要な場合はnil）
 func (u *AuthUsecase) logSecurityEvent(
 	ctx context.Context,
 	accountID uuid.UUID,
 	eventType domain.SecurityEventType,
 	description string,
 	userAgent, ipAddress string,
+	metadata domain.SecurityAuditMetadata,
 ) {
-	// セキュリティ監査ログを作成
-	var userAgentPtr, ipAddressPtr *string
-	if userAgent != "" {
-		userAgentPtr = &userAgent
-	}
-	if ipAddress != "" {
-		ipAddressPtr = &ipAddress
-	}
+	recordSecurityEvent(ctx, u.securityAuditRepo, accountID, eventType, description, userAgent, ipAddress, metadata)
+}
 
-	auditLog, err := domain.NewSecurityAuditLog(
-		accountID,
-		eventType,
-		description,
-		ipAddressPtr,
-		userAgentPtr,
-		nil, // 追加メタデータがあればここに設定
-	)
-	if err != nil {
-		fmt.Printf("[ERROR] Failed to create security audit log: %v\n", err)
-		return
+// generateAccessToken アクセストークンを生成
+// audienceを指定した場合は、アカウントに許可されていればそのAudienceのみを対象とするトークンを発行する
+func (u *AuthUsecase) generateAccessToken(account *domain.Account, audience string) (string, error) {
+	if err := account.CheckAudience(audience); err != nil {
+		return "", err
 	}
-
-	if u.securityAuditRepo != nil {
-		if err := u.securityAuditRepo.Create(ctx, auditLog); err != nil {
-			fmt.Printf("[ERROR] Failed to save security audit log: %v\n", err)
-		}
+	accessToken, err := u.jwtManager.GenerateTenantAccessTokenForAudience(account.TenantID, audience, account.ID, account.Email, string(account.Role), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate access token: %w", err)
 	}
-
-	log.Warnf("[SECURITY ALERT] AccountID: %s, Event: %s, Description: %s, IP: %s\n", accountID.String(), eventType, description, ipAddress)
+	return accessToken, nil
 }
 
 // generateTokens アクセストークンとリフレッシュトークンを生成
-func (u *AuthUsecase) generateTokens(ctx context.Context, account *domain.Account, userAgent, ipAddress string) (*AuthTokens, error) {
-	// アクセストークンを生成
-	accessToken, err := u.jwtManager.GenerateAccessToken(account.ID, account.Email)
+// parentが指定された場合はローテーションとして扱い、トークンファミリーと絶対有効期限を引き継ぐ
+func (u *AuthUsecase) generateTokens(ctx context.Context, account *domain.Account, userAgent, ipAddress, deviceName, audience string, parent *domain.RefreshToken) (*AuthTokens, error) {
+	// アクセストークンを生成（リフレッシュトークンを保存する前に、要求されたAudienceを確認する）
+	accessToken, err := u.generateAccessToken(account, audience)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
+		return nil, err
 	}
 
-	// リフレッシュトークンを生成
-	refreshToken, tokenID, err := u.jwtManager.GenerateRefreshToken(account.ID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	// リフレッシュトークンの有効期限を計算
+	// JWTの日時は秒単位のため、再送時に同じトークンを再現できるよう保存値も秒単位に揃える
+	now := domain.Now().Truncate(time.Second)
+	expiresAt := now.Add(u.config.RefreshTokenExpiry)
+	absoluteExpiresAt := expiresAt
+	if u.config.SlidingRefresh {
+		absoluteExpiresAt = now.Add(u.config.RefreshTokenMaxLifetime)
+		if parent != nil {
+			absoluteExpiresAt = parent.AbsoluteExpiresAt
+		}
+		expiresAt = domain.SlidingExpiresAt(now, u.config.RefreshTokenExpiry, absoluteExpiresAt)
 	}
+	expiresAt = expiresAt.Truncate(time.Second)
 
-	// リフレッシュトークンをデータベースに保存
+	// リフレッシュトークンを保存用のメタデータ
 	var userAgentPtr, ipAddressPtr *string
 	if userAgent != "" {
 		userAgentPtr = &userAgent
@@ -277,27 +1315,72 @@ func (u *AuthUsecase) generateTokens(ctx context.Context, account *domain.Accoun
 		ipAddressPtr = &ipAddress
 	}
 
-	storedToken := domain.NewRefreshToken(
-		account.ID,
-		auth.HashToken(refreshToken),
-		time.Now().Add(30*24*time.Hour), // 30日
-		userAgentPtr,
-		ipAddressPtr,
-	)
-	storedToken.ID = tokenID // JWTから生成されたtokenIDを使用
+	// リフレッシュトークンを生成してデータベースに保存
+	// ハッシュの重複は実質起こり得ないが、発生した場合はトークンを再生成する
+	var refreshToken string
+	var tokenID uuid.UUID
+	for attempt := 1; ; attempt++ {
+		tokenID = u.config.IDs.NewID()
+		refreshToken, err = u.jwtManager.SignRefreshToken(tokenID, account.ID, now, expiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+		}
 
-	if err := u.refreshTokenRepo.Create(ctx, storedToken); err != nil {
-		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+		storedToken := domain.NewRefreshToken(
+			account.ID,
+			auth.HashToken(refreshToken),
+			expiresAt,
+			userAgentPtr,
+			ipAddressPtr,
+		)
+		storedToken.ID = tokenID // JWTから生成されたtokenIDを使用
+		storedToken.FamilyID = tokenID
+		storedToken.CreatedAt = now
+		storedToken.AbsoluteExpiresAt = absoluteExpiresAt
+		storedToken.DeviceName = resolveDeviceName(deviceName, userAgent, parent)
+		if parent != nil {
+			storedToken.FamilyID = parent.FamilyID
+		}
+
+		err = u.refreshTokenRepo.Create(ctx, storedToken)
+		if errors.Is(err, domain.ErrDuplicateToken) && attempt < maxRefreshTokenAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to store refresh token: %w", err)
+		}
+		break
 	}
 
+	return newAuthTokens(account, accessToken, refreshToken, tokenID), nil
+}
+
+// resolveDeviceName セッションに保存する端末名を決める
+// 指定が無い場合はローテーション元の端末名を引き継ぎ、それも無ければUser-Agentの要約を使用する
+func resolveDeviceName(deviceName, userAgent string, parent *domain.RefreshToken) *string {
+	if deviceName == "" && parent != nil && parent.DeviceName != nil {
+		deviceName = *parent.DeviceName
+	}
+	if deviceName == "" {
+		deviceName = domain.ParseUserAgent(userAgent).Summary()
+	}
+	if deviceName == "" {
+		return nil
+	}
+	return &deviceName
+}
+
+// newAuthTokens レスポンス用のトークンのペアを作成
+func newAuthTokens(account *domain.Account, accessToken, refreshToken string, refreshTokenID uuid.UUID) *AuthTokens {
 	// パスワードハッシュを除外したアカウント情報を返す
 	accountCopy := *account
 	accountCopy.PasswordHash = ""
 
 	return &AuthTokens{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresIn:    3600, // 1時間（秒）
-		Account:      &accountCopy,
-	}, nil
+		AccessToken:    accessToken,
+		RefreshToken:   refreshToken,
+		ExpiresIn:      3600, // 1時間（秒）
+		Account:        &accountCopy,
+		refreshTokenID: refreshTokenID,
+	}
 }
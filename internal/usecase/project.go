@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"strings"
 
 	"github.com/aida0710/jwt-auth/internal/domain"
 	"github.com/aida0710/jwt-auth/internal/infrastructure/database"
@@ -22,11 +23,55 @@ type UpdateProjectInput struct {
 	Status      *string `json:"status,omitempty"`
 }
 
+// ページングの既定値と上限
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// PageInfo 一覧の総数と前後のページのカーソル
+type PageInfo struct {
+	Total      int
+	NextCursor string // 次（古い側）のページのカーソル、無い場合は空文字
+	PrevCursor string // 前（新しい側）のページのカーソル、無い場合は空文字
+}
+
+// ListProjectsInput アカウントのプロジェクト一覧取得用の入力
+// nilの項目は既定値を使用する。After/BeforeはOffsetの代わりに指定するカーソル
+type ListProjectsInput struct {
+	Limit  *int
+	Offset *int
+	After  *string
+	Before *string
+}
+
+// ListAllProjectsInput 全プロジェクト一覧取得用の入力
+// nilの項目は既定値を使用、または絞り込みに使用しない
+type ListAllProjectsInput struct {
+	Limit     *int
+	Offset    *int
+	After     *string
+	Before    *string
+	AccountID *uuid.UUID
+	Status    *string
+}
+
+// ProjectConfig プロジェクトユースケースの設定
+type ProjectConfig struct {
+	// UniqueNames 有効にするとアカウント内で同じ名前のプロジェクトの作成・更新をErrDuplicateProjectNameで拒否する
+	UniqueNames bool
+	// IDs 作成するプロジェクトのIDの生成（ゼロ値の場合はv7）
+	IDs domain.IDConfig
+	// DescriptionMaxLength 説明の最大文字数（制御文字を取り除いた後の文字数、0の場合はDefaultProjectDescriptionMaxLength）
+	DescriptionMaxLength int
+}
+
 // projectUsecase ProjectUsecaseインターフェースの実装
 type projectUsecase struct {
 	projectRepo domain.ProjectRepository
 	accountRepo domain.AccountRepository
 	txManager   database.TransactionManager
+	config      ProjectConfig
 }
 
 // NewProjectUsecase 新しいプロジェクトユースケースを作成
@@ -34,24 +79,28 @@ func NewProjectUsecase(
 	projectRepo domain.ProjectRepository,
 	accountRepo domain.AccountRepository,
 	txManager database.TransactionManager,
+	config ProjectConfig,
 ) ProjectUsecase {
+	if config.DescriptionMaxLength == 0 {
+		config.DescriptionMaxLength = domain.DefaultProjectDescriptionMaxLength
+	}
+
 	return &projectUsecase{
 		projectRepo: projectRepo,
 		accountRepo: accountRepo,
 		txManager:   txManager,
+		config:      config,
 	}
 }
 
 // Create 新しいプロジェクトを作成
-func (u *projectUsecase) Create(ctx context.Context, accountID uuid.UUID, input CreateProjectInput) (*domain.Project, error) {
+// actorIDは作成者・最終更新者として記録する
+func (u *projectUsecase) Create(ctx context.Context, accountID, actorID uuid.UUID, input CreateProjectInput) (*domain.Project, error) {
 	// アカウントが存在するか確認
-	account, err := u.accountRepo.GetByID(ctx, accountID)
+	account, err := getTenantAccount(ctx, u.accountRepo, accountID)
 	if err != nil {
 		return nil, err
 	}
-	if account == nil {
-		return nil, domain.ErrAccountNotFound
-	}
 
 	// プロジェクト数の制限をチェック
 	projects, err := u.projectRepo.GetByAccountID(ctx, accountID)
@@ -61,9 +110,20 @@ func (u *projectUsecase) Create(ctx context.Context, accountID uuid.UUID, input
 	if len(projects) >= domain.MaxProjectsPerAccount {
 		return nil, domain.ErrProjectLimitExceeded
 	}
+	if err := u.checkUniqueName(projects, uuid.Nil, input.Name); err != nil {
+		return nil, err
+	}
+
+	description, err := domain.NormalizeProjectDescription(input.Description, u.config.DescriptionMaxLength)
+	if err != nil {
+		return nil, err
+	}
 
 	// Domain層のファクトリメソッドを使用
-	project := domain.NewProject(accountID, input.Name, input.Description)
+	project := domain.NewProject(accountID, input.Name, description)
+	project.ID = u.config.IDs.NewID()
+	project.TenantID = account.TenantID
+	project.RecordCreatedBy(actorID)
 
 	// ステータスの処理を文字列として統一
 	if input.Status != nil {
@@ -90,21 +150,15 @@ func (u *projectUsecase) Create(ctx context.Context, accountID uuid.UUID, input
 // GetByID IDでプロジェクトを取得
 func (u *projectUsecase) GetByID(ctx context.Context, accountID, projectID uuid.UUID) (*domain.Project, error) {
 	// Verify account exists
-	account, err := u.accountRepo.GetByID(ctx, accountID)
+	_, err := getTenantAccount(ctx, u.accountRepo, accountID)
 	if err != nil {
 		return nil, err
 	}
-	if account == nil {
-		return nil, domain.ErrAccountNotFound
-	}
 
-	project, err := u.projectRepo.GetByID(ctx, projectID)
+	project, err := getTenantProject(ctx, u.projectRepo, projectID)
 	if err != nil {
 		return nil, err
 	}
-	if project == nil {
-		return nil, domain.ErrProjectNotFound
-	}
 
 	// プロジェクトがアカウントに属しているか確認
 	if project.AccountID != accountID {
@@ -115,45 +169,176 @@ func (u *projectUsecase) GetByID(ctx context.Context, accountID, projectID uuid.
 }
 
 // ListByAccountID アカウントIDでプロジェクト一覧を取得
-func (u *projectUsecase) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.Project, error) {
-	account, err := u.accountRepo.GetByID(ctx, accountID)
+// ページャーを組み立てられるよう、ページングに関係なく総数も返す
+func (u *projectUsecase) ListByAccountID(ctx context.Context, accountID uuid.UUID, input ListProjectsInput) ([]*domain.Project, PageInfo, error) {
+	limit, offset, err := resolvePagination(input.Limit, input.Offset)
 	if err != nil {
-		return nil, err
+		return nil, PageInfo{}, err
 	}
-	if account == nil {
-		return nil, domain.ErrAccountNotFound
+	cursor, err := resolveCursor(input.After, input.Before, offset)
+	if err != nil {
+		return nil, PageInfo{}, err
 	}
 
-	projects, err := u.projectRepo.GetByAccountID(ctx, accountID)
+	if _, err := getTenantAccount(ctx, u.accountRepo, accountID); err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	return u.search(ctx, domain.ProjectFilter{
+		AccountID: &accountID,
+		Limit:     limit,
+		Offset:    offset,
+		Cursor:    cursor,
+	})
+}
+
+// ListAll 全アカウントのプロジェクトを条件で絞り込んで取得
+func (u *projectUsecase) ListAll(ctx context.Context, input ListAllProjectsInput) ([]*domain.Project, PageInfo, error) {
+	limit, offset, err := resolvePagination(input.Limit, input.Offset)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	cursor, err := resolveCursor(input.After, input.Before, offset)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	filter := domain.ProjectFilter{
+		AccountID: input.AccountID,
+		Limit:     limit,
+		Offset:    offset,
+		Cursor:    cursor,
+	}
+
+	if input.Status != nil {
+		status := domain.ProjectStatus(*input.Status)
+		if !status.IsValid() {
+			return nil, PageInfo{}, domain.ErrInvalidStatus
+		}
+		filter.Status = &status
+	}
+
+	return u.search(ctx, filter)
+}
+
+// search 総数と1ページ分のプロジェクトを取得
+// 次のページの有無を判定するため、1件多く取得する
+func (u *projectUsecase) search(ctx context.Context, filter domain.ProjectFilter) ([]*domain.Project, PageInfo, error) {
+	total, err := u.projectRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	limit := filter.Limit
+	filter.Limit++
+	projects, err := u.projectRepo.Search(ctx, filter)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	projects, page := pageOf(projects, limit, filter.Offset, filter.Cursor, func(p *domain.Project) uuid.UUID { return p.ID })
+	page.Total = total
+	return projects, page, nil
+}
+
+// resolvePagination ページングの入力を検証し、省略時は既定値を返す
+func resolvePagination(limit, offset *int) (int, int, error) {
+	resolvedLimit, resolvedOffset := DefaultPageSize, 0
+	if limit != nil {
+		if *limit < 1 || *limit > MaxPageSize {
+			return 0, 0, domain.ErrInvalidPagination
+		}
+		resolvedLimit = *limit
+	}
+	if offset != nil {
+		if *offset < 0 {
+			return 0, 0, domain.ErrInvalidPagination
+		}
+		resolvedOffset = *offset
+	}
+	return resolvedLimit, resolvedOffset, nil
+}
+
+// resolveCursor カーソルの入力を検証する
+// afterとbeforeの同時指定、およびoffsetとの併用は拒否する
+func resolveCursor(after, before *string, offset int) (*domain.PageCursor, error) {
+	if after == nil && before == nil {
+		return nil, nil
+	}
+	if (after != nil && before != nil) || offset != 0 {
+		return nil, domain.ErrInvalidPagination
+	}
+
+	cursor := &domain.PageCursor{}
+	raw := after
+	if before != nil {
+		raw = before
+		cursor.Before = true
+	}
+	id, err := domain.DecodeCursor(*raw)
 	if err != nil {
 		return nil, err
 	}
+	cursor.ID = id
+	return cursor, nil
+}
+
+// pageOf 1件多く取得した結果からページ分の項目と前後のカーソルを求める
+// 結果は新しい順に並んでいるため、前のページを取得した場合は先頭の1件が余分になる
+func pageOf[T any](items []T, limit, offset int, cursor *domain.PageCursor, idOf func(T) uuid.UUID) ([]T, PageInfo) {
+	var page PageInfo
+	more := len(items) > limit
+	if more {
+		if cursor != nil && cursor.Before {
+			items = items[len(items)-limit:]
+		} else {
+			items = items[:limit]
+		}
+	}
+	if len(items) == 0 {
+		return items, page
+	}
 
-	return projects, nil
+	first := domain.EncodeCursor(idOf(items[0]))
+	last := domain.EncodeCursor(idOf(items[len(items)-1]))
+	switch {
+	case cursor == nil:
+		if more {
+			page.NextCursor = last
+		}
+		if offset > 0 {
+			page.PrevCursor = first
+		}
+	case cursor.Before:
+		page.NextCursor = last
+		if more {
+			page.PrevCursor = first
+		}
+	default:
+		page.PrevCursor = first
+		if more {
+			page.NextCursor = last
+		}
+	}
+	return items, page
 }
 
 // Update プロジェクトを更新
-func (u *projectUsecase) Update(ctx context.Context, accountID, projectID uuid.UUID, input UpdateProjectInput) (*domain.Project, error) {
+// actorIDは最終更新者として記録する
+func (u *projectUsecase) Update(ctx context.Context, accountID, projectID, actorID uuid.UUID, input UpdateProjectInput) (*domain.Project, error) {
 	var updatedProject *domain.Project
 
 	// トランザクション内で実行
 	err := u.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
 		// Verify account exists
-		account, err := u.accountRepo.GetByID(ctx, accountID)
+		_, err := getTenantAccount(ctx, u.accountRepo, accountID)
 		if err != nil {
 			return err
 		}
-		if account == nil {
-			return domain.ErrAccountNotFound
-		}
 
-		project, err := u.projectRepo.GetByID(ctx, projectID)
+		project, err := getTenantProject(ctx, u.projectRepo, projectID)
 		if err != nil {
 			return err
 		}
-		if project == nil {
-			return domain.ErrProjectNotFound
-		}
 
 		// Verify the project belongs to the account
 		if project.AccountID != accountID {
@@ -161,11 +346,24 @@ func (u *projectUsecase) Update(ctx context.Context, accountID, projectID uuid.U
 		}
 
 		if input.Name != nil {
+			if u.config.UniqueNames && *input.Name != project.Name {
+				projects, err := u.projectRepo.GetByAccountID(ctx, accountID)
+				if err != nil {
+					return err
+				}
+				if err := u.checkUniqueName(projects, project.ID, *input.Name); err != nil {
+					return err
+				}
+			}
 			project.Name = *input.Name
 		}
 
 		if input.Description != nil {
-			project.Description = *input.Description
+			description, err := domain.NormalizeProjectDescription(*input.Description, u.config.DescriptionMaxLength)
+			if err != nil {
+				return err
+			}
+			project.Description = description
 		}
 
 		if input.Status != nil {
@@ -179,6 +377,7 @@ func (u *projectUsecase) Update(ctx context.Context, accountID, projectID uuid.U
 			return err
 		}
 
+		project.RecordUpdatedBy(actorID)
 		if err := u.projectRepo.Update(ctx, project); err != nil {
 			return err
 		}
@@ -194,24 +393,33 @@ func (u *projectUsecase) Update(ctx context.Context, accountID, projectID uuid.U
 	return updatedProject, nil
 }
 
+// checkUniqueName UniqueNamesが有効な場合、excludeID以外のプロジェクトに同じ名前があればErrDuplicateProjectNameを返す
+// DBの一意インデックス（照合順序で大文字・小文字を区別しない）に合わせて大文字・小文字を区別せずに比較する
+// 並行したリクエストでの重複は一意インデックスで防ぐ（リポジトリが同じエラーに変換する）
+func (u *projectUsecase) checkUniqueName(projects []*domain.Project, excludeID uuid.UUID, name string) error {
+	if !u.config.UniqueNames {
+		return nil
+	}
+	for _, p := range projects {
+		if p.ID != excludeID && strings.EqualFold(p.Name, name) {
+			return domain.ErrDuplicateProjectName
+		}
+	}
+	return nil
+}
+
 // Delete プロジェクトを削除
 func (u *projectUsecase) Delete(ctx context.Context, accountID, projectID uuid.UUID) error {
 	// Verify account exists
-	account, err := u.accountRepo.GetByID(ctx, accountID)
+	_, err := getTenantAccount(ctx, u.accountRepo, accountID)
 	if err != nil {
 		return err
 	}
-	if account == nil {
-		return domain.ErrAccountNotFound
-	}
 
-	project, err := u.projectRepo.GetByID(ctx, projectID)
+	project, err := getTenantProject(ctx, u.projectRepo, projectID)
 	if err != nil {
 		return err
 	}
-	if project == nil {
-		return domain.ErrProjectNotFound
-	}
 
 	// Verify the project belongs to the account
 	if project.AccountID != accountID {
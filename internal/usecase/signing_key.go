@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/infrastructure/database"
+)
+
+// SigningKeyConfig アクセストークンの署名鍵のローテーションの設定
+type SigningKeyConfig struct {
+	// Algorithm 新しい鍵の署名アルゴリズム（HS256/HS384/HS512）
+	Algorithm string
+	// RotationInterval 鍵が有効になってから次の鍵に引き継ぐまでの期間
+	RotationInterval time.Duration
+	// Overlap 署名に使用しなくなってから検証に使用しなくなるまでの期間
+	// 引き継ぐ前に発行したトークンが失効するまで検証できるよう、最も長いトークンの有効期間以上にする
+	Overlap time.Duration
+	// PropagationDelay 新しい鍵を作成してから署名に使用し始めるまでの期間
+	// すべてのインスタンスが鍵を読み込み直す間隔以上にし、他のインスタンスが未知の鍵のトークンを拒否しないようにする
+	PropagationDelay time.Duration
+	// EncryptionSecret 保存する鍵の暗号化に使用する秘密の値
+	EncryptionSecret string
+}
+
+// SigningKeyUsecase アクセストークンの署名鍵のローテーション
+// 鍵はリポジトリに保存してすべてのインスタンスで共有し、各インスタンスは定期的にRotateを呼び出して鍵リングを更新する
+type SigningKeyUsecase struct {
+	signingKeyRepo domain.SigningKeyRepository
+	txManager      database.TransactionManager
+	keyRing        *auth.KeyRing
+	config         SigningKeyConfig
+}
+
+// NewSigningKeyUsecase 新しい署名鍵ユースケースを作成
+func NewSigningKeyUsecase(
+	signingKeyRepo domain.SigningKeyRepository,
+	txManager database.TransactionManager,
+	keyRing *auth.KeyRing,
+	config SigningKeyConfig,
+) *SigningKeyUsecase {
+	return &SigningKeyUsecase{
+		signingKeyRepo: signingKeyRepo,
+		txManager:      txManager,
+		keyRing:        keyRing,
+		config:         config,
+	}
+}
+
+// Rotate nowの時点で必要な鍵の作成とローテーションを行い、保存されている鍵を鍵リングに読み込み直す
+//   - 署名に使用できる鍵が無い場合（初回起動時や長期間停止していた場合）は、すぐに有効になる鍵を作成する
+//   - 署名に使用している鍵がRotationIntervalを過ぎた場合は、PropagationDelay後に有効になる次の鍵を作成し、
+//     現在の鍵は次の鍵が有効になってからOverlapの間だけ検証に使用する
+//   - 検証に使用しなくなった鍵は削除する
+//
+// 新しい鍵を作成した場合はtrueを返す
+func (u *SigningKeyUsecase) Rotate(ctx context.Context, now time.Time) (bool, error) {
+	keys, err := u.signingKeyRepo.List(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var created bool
+	err = u.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		created, err = u.rotate(ctx, keys, now)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := u.signingKeyRepo.DeleteRetired(ctx, now); err != nil {
+		return created, err
+	}
+	return created, u.reload(ctx, now)
+}
+
+// rotate 鍵の一覧から必要な鍵の作成とローテーションを行う
+func (u *SigningKeyUsecase) rotate(ctx context.Context, keys []*domain.SigningKey, now time.Time) (bool, error) {
+	current := domain.CurrentSigningKey(keys, now)
+
+	// 同時に作成されるなどして有効な鍵が複数ある場合は、使用しない鍵を引退させる
+	for _, k := range keys {
+		if k != current && k.RotatesAt == nil && k.Status(now) == domain.SigningKeyStatusActive {
+			if _, err := u.signingKeyRepo.MarkRotating(ctx, k.ID, now, now.Add(u.config.Overlap)); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	if current == nil {
+		_, err := u.create(ctx, now)
+		return err == nil, err
+	}
+	if current.RotatesAt != nil || now.Before(current.ActivatesAt.Add(u.config.RotationInterval)) {
+		return false, nil
+	}
+
+	_, err := u.scheduleNext(ctx, current, now)
+	// 他のインスタンスが先にローテーションを予定した場合は、その次の鍵を読み込む
+	if errors.Is(err, domain.ErrSigningKeyRotationScheduled) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// RotateNow RotationIntervalを待たずに次の鍵へのローテーションを予定し、作成した鍵を返す（rotate-secretサブコマンド）
+// 次の鍵はPropagationDelay後に署名に使用し始め、現在の鍵はそれからOverlapの間だけ検証に使用するため、
+// 発行済みのトークンは失効するまで検証でき、稼働中のインスタンスを再起動する必要は無い
+// 既にローテーションを予定している場合はErrSigningKeyRotationScheduledを返す
+func (u *SigningKeyUsecase) RotateNow(ctx context.Context, now time.Time) (*domain.SigningKey, error) {
+	keys, err := u.signingKeyRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var next *domain.SigningKey
+	err = u.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		next, err = u.scheduleNext(ctx, domain.CurrentSigningKey(keys, now), now)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return next, u.reload(ctx, now)
+}
+
+// scheduleNext PropagationDelay後に有効になる次の鍵を作成し、現在の鍵（無い場合はnil）に引き継ぐ日時を記録する
+func (u *SigningKeyUsecase) scheduleNext(ctx context.Context, current *domain.SigningKey, now time.Time) (*domain.SigningKey, error) {
+	activatesAt := now.Add(u.config.PropagationDelay)
+	if current != nil {
+		marked, err := u.signingKeyRepo.MarkRotating(ctx, current.ID, activatesAt, activatesAt.Add(u.config.Overlap))
+		if err != nil {
+			return nil, err
+		}
+		if !marked {
+			return nil, domain.ErrSigningKeyRotationScheduled
+		}
+	}
+	return u.create(ctx, activatesAt)
+}
+
+// create activatesAtから有効になる鍵を作成して保存する
+func (u *SigningKeyUsecase) create(ctx context.Context, activatesAt time.Time) (*domain.SigningKey, error) {
+	key, err := auth.GenerateSigningKey(u.config.Algorithm, activatesAt, u.config.EncryptionSecret)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.signingKeyRepo.Create(ctx, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// reload 検証に使用できる鍵を復号して鍵リングを置き換える
+func (u *SigningKeyUsecase) reload(ctx context.Context, now time.Time) error {
+	keys, err := u.signingKeyRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]auth.KeyRingEntry, 0, len(keys))
+	var errs []error
+	for _, key := range keys {
+		if !key.Verifies(now) {
+			continue
+		}
+		secret, err := auth.DecryptSigningKey(key, u.config.EncryptionSecret)
+		if err != nil {
+			// 復号できない鍵は読み込まずに、他の鍵で署名と検証を続ける
+			errs = append(errs, err)
+			continue
+		}
+		entries = append(entries, auth.KeyRingEntry{Key: key, Secret: secret})
+	}
+	u.keyRing.Replace(entries)
+	return errors.Join(errs...)
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/google/uuid"
+)
+
+// getTenantAccount IDでアカウントを取得し、他のテナントのアカウントは存在しないものとして扱う
+// リポジトリでの絞り込みに加えてユースケースでも確認し、実装の漏れによるテナント間の参照を防ぐ
+func getTenantAccount(ctx context.Context, repo domain.AccountRepository, id uuid.UUID) (*domain.Account, error) {
+	account, err := repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !account.BelongsTo(ctx) {
+		return nil, domain.ErrAccountNotFound
+	}
+	return account, nil
+}
+
+// getTenantProject IDでプロジェクトを取得し、他のテナントのプロジェクトは存在しないものとして扱う
+func getTenantProject(ctx context.Context, repo domain.ProjectRepository, id uuid.UUID) (*domain.Project, error) {
+	project, err := repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !project.BelongsTo(ctx) {
+		return nil, domain.ErrProjectNotFound
+	}
+	return project, nil
+}
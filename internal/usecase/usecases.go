@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/aida0710/jwt-auth/internal/domain"
 	"github.com/google/uuid"
@@ -15,19 +16,31 @@ type Usecases struct {
 
 // AccountUsecase アカウントユースケースのインターフェースを定義
 type AccountUsecase interface {
-	Create(ctx context.Context, input CreateInput) (*domain.Account, error) // SignUpから内部的に使用
+	Create(ctx context.Context, input CreateInput) (*domain.Account, error) // トークンを発行せずにアカウントを作成
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Account, error)
 	GetByEmail(ctx context.Context, email string) (*domain.Account, error)
-	List(ctx context.Context) ([]*domain.Account, error)
+	List(ctx context.Context, input ListPageInput) ([]*domain.Account, int, error)                                       // 一覧と実際に適用した件数の上限を取得（上限を超える指定は切り詰める）
+	ListWithProjectCounts(ctx context.Context, input ListAccountsInput) ([]*domain.AccountProjectCount, PageInfo, error) // プロジェクト数付きの一覧と総数・カーソルを取得（管理者用）
+	SearchByEmail(ctx context.Context, input SearchAccountsInput) ([]*domain.Account, error)                             // メールアドレスの前方一致で検索（管理者用）
 	Update(ctx context.Context, id uuid.UUID, input UpdateInput) (*domain.Account, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	ConfirmEmailChange(ctx context.Context, id uuid.UUID, token string) (*domain.Account, error)
+	ChangePassword(ctx context.Context, id uuid.UUID, input ChangePasswordInput) error                                  // 直近のパスワードの再利用は拒否
+	RequestPasswordReset(ctx context.Context, input PasswordResetInput) error                                           // パスワード再設定用のトークンを送信（以前のトークンは無効化、未登録のアドレスでも成功）
+	ResetPassword(ctx context.Context, input ResetPasswordInput) error                                                  // トークンを消費してパスワードを変更（1回のみ使用可能）
+	UpdateStatus(ctx context.Context, id uuid.UUID, status domain.AccountStatus, actor Actor) (*domain.Account, error)  // 状態を変更（管理者用、停止時はすべてのセッションを無効化）
+	UpdateAllowedAudiences(ctx context.Context, id uuid.UUID, audiences []string, actor Actor) (*domain.Account, error) // ログイン・リフレッシュで要求できるAudienceを置き換える（管理者用）
+	Delete(ctx context.Context, id uuid.UUID, actor Actor) error                                                        // 削除を予約して猶予期間中にする（管理者による削除は監査ログに記録）
+	Restore(ctx context.Context, id uuid.UUID, actor Actor) (*domain.Account, error)                                    // 猶予期間中の削除を取り消す（本人または管理者のみ）
+	PurgeDeletedAccounts(ctx context.Context, now time.Time) (int, error)                                               // 猶予期間が過ぎたアカウントを完全に削除（定期実行用）
+	Export(ctx context.Context, id uuid.UUID, actor Actor, w AccountExportWriter) error                                 // 本人または管理者のみ、プロジェクトと監査ログはページごとに書き出す
 }
 
 // ProjectUsecase プロジェクトユースケースのインターフェースを定義
 type ProjectUsecase interface {
-	Create(ctx context.Context, accountID uuid.UUID, input CreateProjectInput) (*domain.Project, error)
+	Create(ctx context.Context, accountID, actorID uuid.UUID, input CreateProjectInput) (*domain.Project, error) // actorIDは操作したアカウント
 	GetByID(ctx context.Context, accountID, projectID uuid.UUID) (*domain.Project, error)
-	ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.Project, error)
-	Update(ctx context.Context, accountID, projectID uuid.UUID, input UpdateProjectInput) (*domain.Project, error)
+	ListByAccountID(ctx context.Context, accountID uuid.UUID, input ListProjectsInput) ([]*domain.Project, PageInfo, error) // アカウントのプロジェクトと総数・カーソルを取得
+	ListAll(ctx context.Context, input ListAllProjectsInput) ([]*domain.Project, PageInfo, error)                           // 全アカウントのプロジェクトと総数・カーソルを取得（管理者用）
+	Update(ctx context.Context, accountID, projectID, actorID uuid.UUID, input UpdateProjectInput) (*domain.Project, error)
 	Delete(ctx context.Context, accountID, projectID uuid.UUID) error
 }
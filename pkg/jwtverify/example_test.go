@@ -0,0 +1,57 @@
+package jwtverify_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aida0710/jwt-auth/pkg/jwtverify"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ExampleVerifier_ValidateAccessToken 認証サーバーに依存せず、シークレット・Issuer・Audienceのみでアクセストークンを検証する例
+func ExampleVerifier_ValidateAccessToken() {
+	const secret = "shared-access-token-secret-0123456789"
+
+	verifier, err := jwtverify.New(jwtverify.Config{
+		Secret:   secret,
+		Issuer:   "jwt-auth",
+		Audience: []string{"jwt-auth-api"},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// 認証サーバーが発行するアクセストークンと同じ形式のトークン
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"account_id": "0190b6a0-7c1e-7a00-8000-000000000001",
+		"email":      "alice@example.com",
+		"role":       "user",
+		"iss":        "jwt-auth",
+		"aud":        []string{"jwt-auth-api"},
+		"exp":        time.Now().Add(time.Minute).Unix(),
+	}).SignedString([]byte(secret))
+	if err != nil {
+		panic(err)
+	}
+
+	claims, err := verifier.ValidateAccessToken(token)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(claims.AccountID, claims.Email, claims.Role)
+
+	// 別のシークレットで署名されたトークンは拒否する
+	forged, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"account_id": "0190b6a0-7c1e-7a00-8000-000000000001",
+		"email":      "alice@example.com",
+		"iss":        "jwt-auth",
+		"aud":        []string{"jwt-auth-api"},
+		"exp":        time.Now().Add(time.Minute).Unix(),
+	}).SignedString([]byte("another-secret-0123456789abcdefghij"))
+	_, err = verifier.ValidateAccessToken(forged)
+	fmt.Println(err)
+
+	// Output:
+	// 0190b6a0-7c1e-7a00-8000-000000000001 alice@example.com user
+	// token signature verification failed
+}
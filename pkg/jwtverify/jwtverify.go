@@ -0,0 +1,91 @@
+// Package jwtverify jwt-authが発行したアクセストークンを、他のサービスで検証するためのパッケージ
+//
+// Echoやデータベース、ユースケースに依存せず、署名の検証に必要な情報（シークレット、Issuer、Audience）のみで
+// 認証サーバーと同じ検証（アルゴリズム、typヘッダー、有効期限、Issuer、Audience、必須クレーム）を行う
+// 署名はHMAC（HS256/HS384/HS512）のみ対応しているため、認証サーバーと同じJWT_ACCESS_TOKEN_SECRETを使用する
+// トークンの発行は行わないため、リフレッシュトークンのシークレットは不要
+package jwtverify
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aida0710/jwt-auth/internal/auth"
+)
+
+// Claims 検証済みのアクセストークンのクレーム
+type Claims = auth.Claims
+
+// AudienceMatchMode Audienceの検証方法
+type AudienceMatchMode = auth.AudienceMatchMode
+
+const (
+	// AudienceMatchExact トークンのAudienceが設定と完全に一致する場合のみ許可（既定）
+	AudienceMatchExact = auth.AudienceMatchExact
+	// AudienceMatchAny トークンのAudienceのいずれかが設定に含まれていれば許可
+	AudienceMatchAny = auth.AudienceMatchAny
+)
+
+// Config アクセストークンの検証に必要な情報
+// 認証サーバーの同名の環境変数（JWT_*）と同じ値を設定する
+type Config struct {
+	// Secret アクセストークンの署名に使用するシークレット（JWT_ACCESS_TOKEN_SECRET）
+	Secret string
+	// Issuer 発行者（JWT_ISSUER）
+	Issuer string
+	// Audience 対象者（JWT_AUDIENCE、空の場合は検証しない）
+	Audience []string
+	// AudienceMatchMode Audienceの検証方法（JWT_AUDIENCE_MATCH_MODE、空の場合はexact）
+	AudienceMatchMode AudienceMatchMode
+	// AcceptedAudiences Audienceに加えて受け入れるAudience（JWT_ACCEPTED_AUDIENCES）
+	AcceptedAudiences []string
+	// AcceptedIssuers Issuerに加えて受け入れるIssuer（JWT_ACCEPTED_ISSUERS）
+	AcceptedIssuers []string
+	// SigningAlgorithm 署名アルゴリズム（JWT_SIGNING_ALGORITHM、空の場合はHS256）
+	SigningAlgorithm string
+	// TokenType アクセストークンのtypヘッダー（JWT_ACCESS_TOKEN_TYPE、空の場合はJWT）
+	TokenType string
+}
+
+// Verifier アクセストークンの検証器
+// 複数のgoroutineから同時に使用できる
+type Verifier struct {
+	manager *auth.JWTManager
+}
+
+// New 検証に必要な情報から検証器を作成
+// シークレットまたはIssuerが空の場合、未対応の署名アルゴリズムの場合はエラーを返す
+func New(config Config) (*Verifier, error) {
+	if config.Secret == "" {
+		return nil, errors.New("jwtverify: secret is required")
+	}
+	if config.Issuer == "" {
+		return nil, errors.New("jwtverify: issuer is required")
+	}
+	if config.SigningAlgorithm == "" {
+		config.SigningAlgorithm = auth.DefaultSigningAlgorithm
+	}
+	if !auth.IsSupportedSigningAlgorithm(config.SigningAlgorithm) {
+		return nil, fmt.Errorf("jwtverify: unsupported signing algorithm: %s", config.SigningAlgorithm)
+	}
+
+	return &Verifier{
+		manager: auth.NewJWTManager(auth.JWTConfig{
+			AccessTokenSecret: config.Secret,
+			Issuer:            config.Issuer,
+			Audience:          config.Audience,
+			AudienceMatchMode: config.AudienceMatchMode,
+			AcceptedAudiences: config.AcceptedAudiences,
+			AccessTokenType:   config.TokenType,
+			SigningAlgorithm:  config.SigningAlgorithm,
+			AcceptedIssuers:   config.AcceptedIssuers,
+			VerifyOnly:        true,
+		}),
+	}, nil
+}
+
+// ValidateAccessToken アクセストークンを検証し、クレームを返す
+// 署名、有効期限、Issuer、Audienceのいずれかが不正な場合はエラーを返す
+func (v *Verifier) ValidateAccessToken(token string) (*Claims, error) {
+	return v.manager.ValidateAccessToken(token)
+}
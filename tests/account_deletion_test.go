@@ -0,0 +1,284 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/handler"
+	"github.com/aida0710/jwt-auth/internal/logger"
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// accountDeletionGracePeriod テストで使用する削除の猶予期間
+const accountDeletionGracePeriod = 30 * 24 * time.Hour
+
+// accountDeletionFixture 削除のテストで共有するユースケースとリポジトリ
+type accountDeletionFixture struct {
+	authUsecase    *usecase.AuthUsecase
+	accountUsecase usecase.AccountUsecase
+	accountRepo    *fakeAccountRepository
+	projectRepo    *fakeProjectRepository
+	auditRepo      *fakeSecurityAuditLogRepository
+}
+
+// newAccountDeletionFixture アカウントと認証のユースケースがリポジトリを共有するテスト用の構成を作成
+func newAccountDeletionFixture(t *testing.T) *accountDeletionFixture {
+	t.Helper()
+
+	accountRepo := newFakeAccountRepository()
+	projectRepo := newFakeProjectRepository()
+	accountRepo.projects = projectRepo
+	refreshTokenRepo := newFakeRefreshTokenRepository()
+	auditRepo := &fakeSecurityAuditLogRepository{}
+	jwtManager := auth.NewJWTManager(auth.JWTConfig{
+		AccessTokenSecret:  "test-access-secret-0123456789abcdef",
+		RefreshTokenSecret: "test-refresh-secret-0123456789abcdef",
+		AccessTokenExpiry:  time.Minute,
+		RefreshTokenExpiry: time.Hour,
+		Issuer:             "jwt-auth-test",
+		Audience:           []string{"jwt-auth-test"},
+	})
+
+	return &accountDeletionFixture{
+		authUsecase: usecase.NewAuthUsecase(accountRepo, refreshTokenRepo, auditRepo, nil, nil, nil, fakeTxManager{}, nil, jwtManager,
+			usecase.AuthConfig{RefreshTokenExpiry: time.Hour}),
+		accountUsecase: usecase.NewAccountUsecase(accountRepo, projectRepo, refreshTokenRepo, nil, nil, auditRepo, fakeTxManager{}, nil,
+			usecase.AccountConfig{DeletionGracePeriod: accountDeletionGracePeriod}),
+		accountRepo: accountRepo,
+		projectRepo: projectRepo,
+		auditRepo:   auditRepo,
+	}
+}
+
+// signUp テスト用のアカウントを作成
+func (f *accountDeletionFixture) signUp(t *testing.T, email string) *usecase.AuthTokens {
+	t.Helper()
+	tokens, err := f.authUsecase.SignUp(context.Background(), usecase.SignUpInput{
+		Email:    email,
+		Password: "SecurePassword123!",
+		Name:     "Deletion User",
+	})
+	if err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+	return tokens
+}
+
+// login テスト用のアカウントでログイン
+func (f *accountDeletionFixture) login(email string) error {
+	_, err := f.authUsecase.Login(context.Background(), usecase.LoginInput{Email: email, Password: "SecurePassword123!"})
+	return err
+}
+
+// countEvents 指定した種類の監査ログの件数を返す
+func (f *accountDeletionFixture) countEvents(eventType domain.SecurityEventType) int {
+	logs, _ := f.auditRepo.GetByEventType(context.Background(), eventType, -1, 0)
+	return len(logs)
+}
+
+// TestAccountDeletion_GracePeriod 削除の予約から復元までの状態の遷移をテスト
+func TestAccountDeletion_GracePeriod(t *testing.T) {
+	ctx := context.Background()
+	f := newAccountDeletionFixture(t)
+	signedUp := f.signUp(t, "grace@example.com")
+	id := signedUp.Account.ID
+	self := usecase.Actor{ID: id, Role: domain.RoleUser}
+
+	before := time.Now()
+	if err := f.accountUsecase.Delete(ctx, id, self); err != nil {
+		t.Fatalf("❌ 削除の予約に失敗: %v", err)
+	}
+
+	account, _ := f.accountRepo.GetByID(ctx, id)
+	if account.Status != domain.AccountStatusPendingDeletion || account.DeletionScheduledAt == nil {
+		t.Fatalf("❌ 削除の猶予期間中になっていません: %+v", account)
+	}
+	if account.DeletionScheduledAt.Before(before.Add(accountDeletionGracePeriod)) {
+		t.Errorf("❌ 削除予定日時が猶予期間より前です: %s", account.DeletionScheduledAt)
+	}
+	if f.countEvents(domain.EventAccountDeletionRequested) != 1 {
+		t.Error("❌ ACCOUNT_DELETION_REQUESTEDが記録されていません")
+	}
+
+	t.Run("猶予期間中はログインとリフレッシュを拒否する", func(t *testing.T) {
+		if err := f.login("grace@example.com"); !errors.Is(err, domain.ErrAccountPendingDeletion) {
+			t.Errorf("❌ ログイン 期待値: ErrAccountPendingDeletion, 実際: %v", err)
+		}
+		if _, err := f.authUsecase.RefreshToken(ctx, signedUp.RefreshToken, "", "", ""); err == nil {
+			t.Error("❌ 削除を予約する前のセッションが有効なままです")
+		}
+	})
+
+	t.Run("猶予期間中の再度の削除は拒否する", func(t *testing.T) {
+		if err := f.accountUsecase.Delete(ctx, id, self); !errors.Is(err, domain.ErrAccountPendingDeletion) {
+			t.Errorf("❌ 期待値: ErrAccountPendingDeletion, 実際: %v", err)
+		}
+	})
+
+	t.Run("他のアカウントは復元できない", func(t *testing.T) {
+		other := usecase.Actor{ID: domain.NewID(), Role: domain.RoleUser}
+		if _, err := f.accountUsecase.Restore(ctx, id, other); !errors.Is(err, domain.ErrForbidden) {
+			t.Errorf("❌ 期待値: ErrForbidden, 実際: %v", err)
+		}
+	})
+
+	t.Run("復元するとログインできる", func(t *testing.T) {
+		restored, err := f.accountUsecase.Restore(ctx, id, self)
+		if err != nil {
+			t.Fatalf("❌ 復元に失敗: %v", err)
+		}
+		if restored.Status != domain.AccountStatusActive || restored.DeletionScheduledAt != nil {
+			t.Errorf("❌ 有効な状態に戻っていません: %+v", restored)
+		}
+		if err := f.login("grace@example.com"); err != nil {
+			t.Errorf("❌ 復元後のログインに失敗: %v", err)
+		}
+		if f.countEvents(domain.EventAccountDeletionCancelled) != 1 {
+			t.Error("❌ ACCOUNT_DELETION_CANCELLEDが記録されていません")
+		}
+		if _, err := f.accountUsecase.Restore(ctx, id, self); !errors.Is(err, domain.ErrAccountNotPendingDeletion) {
+			t.Errorf("❌ 再度の復元 期待値: ErrAccountNotPendingDeletion, 実際: %v", err)
+		}
+	})
+
+	t.Run("状態の変更で削除の猶予期間には移行できない", func(t *testing.T) {
+		admin := usecase.Actor{ID: domain.NewID(), Role: domain.RoleAdmin}
+		if _, err := f.accountUsecase.UpdateStatus(ctx, id, domain.AccountStatusPendingDeletion, admin); !errors.Is(err, domain.ErrInvalidAccountStatus) {
+			t.Errorf("❌ 期待値: ErrInvalidAccountStatus, 実際: %v", err)
+		}
+	})
+}
+
+// TestAccountDeletion_Purge 猶予期間が過ぎたアカウントのみが完全に削除されることをテスト
+func TestAccountDeletion_Purge(t *testing.T) {
+	ctx := context.Background()
+	f := newAccountDeletionFixture(t)
+
+	due := f.signUp(t, "due@example.com").Account
+	restored := f.signUp(t, "restored@example.com").Account
+	active := f.signUp(t, "active@example.com").Account
+	for _, account := range []*domain.Account{due, restored, active} {
+		if err := f.projectRepo.Create(ctx, domain.NewProject(account.ID, "Project", "")); err != nil {
+			t.Fatalf("❌ プロジェクトの作成に失敗: %v", err)
+		}
+	}
+	for _, account := range []*domain.Account{due, restored} {
+		if err := f.accountUsecase.Delete(ctx, account.ID, usecase.Actor{ID: account.ID, Role: domain.RoleUser}); err != nil {
+			t.Fatalf("❌ 削除の予約に失敗: %v", err)
+		}
+	}
+	if _, err := f.accountUsecase.Restore(ctx, restored.ID, usecase.Actor{ID: restored.ID, Role: domain.RoleUser}); err != nil {
+		t.Fatalf("❌ 復元に失敗: %v", err)
+	}
+
+	t.Run("猶予期間中は削除しない", func(t *testing.T) {
+		purged, err := f.accountUsecase.PurgeDeletedAccounts(ctx, time.Now().Add(accountDeletionGracePeriod-time.Hour))
+		if err != nil || purged != 0 {
+			t.Fatalf("❌ 削除件数 期待値: 0, 実際: %d (%v)", purged, err)
+		}
+		if _, err := f.accountRepo.GetByID(ctx, due.ID); err != nil {
+			t.Errorf("❌ 猶予期間中のアカウントが削除されました: %v", err)
+		}
+	})
+
+	t.Run("猶予期間が過ぎたアカウントとプロジェクトを削除する", func(t *testing.T) {
+		purged, err := f.accountUsecase.PurgeDeletedAccounts(ctx, time.Now().Add(accountDeletionGracePeriod+time.Hour))
+		if err != nil || purged != 1 {
+			t.Fatalf("❌ 削除件数 期待値: 1, 実際: %d (%v)", purged, err)
+		}
+		if _, err := f.accountRepo.GetByID(ctx, due.ID); !errors.Is(err, domain.ErrAccountNotFound) {
+			t.Errorf("❌ 猶予期間が過ぎたアカウントが残っています: %v", err)
+		}
+		if projects, _ := f.projectRepo.GetByAccountID(ctx, due.ID); len(projects) != 0 {
+			t.Errorf("❌ 削除したアカウントのプロジェクトが残っています: %d件", len(projects))
+		}
+		for _, kept := range []*domain.Account{restored, active} {
+			if _, err := f.accountRepo.GetByID(ctx, kept.ID); err != nil {
+				t.Errorf("❌ %s が削除されました: %v", kept.Email, err)
+			}
+			if projects, _ := f.projectRepo.GetByAccountID(ctx, kept.ID); len(projects) != 1 {
+				t.Errorf("❌ %s のプロジェクトが削除されました", kept.Email)
+			}
+		}
+	})
+
+	t.Run("削除済みのアカウントは再度削除しない", func(t *testing.T) {
+		purged, err := f.accountUsecase.PurgeDeletedAccounts(ctx, time.Now().Add(accountDeletionGracePeriod+time.Hour))
+		if err != nil || purged != 0 {
+			t.Errorf("❌ 削除件数 期待値: 0, 実際: %d (%v)", purged, err)
+		}
+	})
+}
+
+// TestAccountDeletion_HTTP 削除の予約と復元のエンドポイントをテスト
+func TestAccountDeletion_HTTP(t *testing.T) {
+	f := newAccountDeletionFixture(t)
+	server := handler.NewServer(f.accountUsecase, nil, handler.NewAuthHandler(f.authUsecase), handler.HealthConfig{}, handler.PrivacyConfig{}, logger.NewLoggerWithOutput("error", "json", io.Discard))
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(string(middleware.RoleKey), c.Request().Header.Get("X-Test-Role"))
+			c.Set(string(middleware.AccountIDKey), c.Request().Header.Get("X-Test-Account"))
+			return next(c)
+		}
+	})
+	api.RegisterHandlersWithBaseURL(e, server, "/api/v1")
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	account := f.signUp(t, "http-delete@example.com").Account
+	path := "/api/v1/accounts/" + account.ID.String()
+
+	resp, body := sendAsAccount(t, srv, http.MethodDelete, path, account.ID, nil)
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("❌ 削除 期待値: 204, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+
+	resp, body = sendAsAccount(t, srv, http.MethodGet, path, account.ID, nil)
+	var pending api.Account
+	if err := json.Unmarshal(body, &pending); err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("❌ アカウントの取得に失敗: %d, body: %s", resp.StatusCode, body)
+	}
+	if pending.Status != api.AccountStatusPendingDeletion || pending.DeletionScheduledAt == nil {
+		t.Errorf("❌ 削除の猶予期間中になっていません: %+v", pending)
+	}
+
+	resp, body = sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/login", nil, map[string]string{
+		"email":    "http-delete@example.com",
+		"password": "SecurePassword123!",
+	})
+	var apiErr api.Error
+	if err := json.Unmarshal(body, &apiErr); err != nil || resp.StatusCode != http.StatusForbidden || apiErr.Code != api.ErrorCodeAccountPendingDeletion {
+		t.Errorf("❌ ログイン 期待値: 403 account_pending_deletion, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+
+	resp, body = sendAsAccount(t, srv, http.MethodDelete, path, account.ID, nil)
+	if resp.StatusCode != http.StatusConflict {
+		t.Errorf("❌ 再度の削除 期待値: 409, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+
+	resp, body = sendAsAccount(t, srv, http.MethodPost, path+"/restore", account.ID, nil)
+	var restored api.Account
+	if err := json.Unmarshal(body, &restored); err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("❌ 復元 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+	if restored.Status != api.AccountStatusActive || restored.DeletionScheduledAt != nil {
+		t.Errorf("❌ 有効な状態に戻っていません: %+v", restored)
+	}
+
+	resp, body = sendAsAccount(t, srv, http.MethodPost, path+"/restore", account.ID, nil)
+	if err := json.Unmarshal(body, &apiErr); err != nil || resp.StatusCode != http.StatusConflict || apiErr.Code != api.ErrorCodeAccountNotPendingDeletion {
+		t.Errorf("❌ 再度の復元 期待値: 409 account_not_pending_deletion, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,182 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/handler"
+	"github.com/aida0710/jwt-auth/internal/logger"
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// newAccountExportTestServer エクスポートに必要なリポジトリを設定したテスト用サーバーを作成
+// X-Test-Role / X-Test-Account ヘッダーの値を認証済みのロール・アカウントIDとして扱う
+func newAccountExportTestServer(t *testing.T) (*httptest.Server, *fakeAccountRepository, *fakeProjectRepository, *fakeSecurityAuditLogRepository) {
+	t.Helper()
+
+	accountRepo := newFakeAccountRepository()
+	projectRepo := newFakeProjectRepository()
+	auditRepo := &fakeSecurityAuditLogRepository{}
+	accountUsecase := usecase.NewAccountUsecase(accountRepo, projectRepo, nil, nil, nil, auditRepo, fakeTxManager{}, nil, usecase.AccountConfig{})
+	server := handler.NewServer(accountUsecase, nil, nil, handler.HealthConfig{}, handler.PrivacyConfig{}, logger.NewLoggerWithOutput("error", "json", io.Discard))
+
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(string(middleware.RoleKey), c.Request().Header.Get("X-Test-Role"))
+			c.Set(string(middleware.AccountIDKey), c.Request().Header.Get("X-Test-Account"))
+			return next(c)
+		}
+	})
+	api.RegisterHandlersWithBaseURL(e, server, "/api/v1")
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+	return srv, accountRepo, projectRepo, auditRepo
+}
+
+// TestAccountExport_Bundle エクスポートにプロフィール、全プロジェクト、秘密情報を除いた監査ログが含まれることをテスト
+func TestAccountExport_Bundle(t *testing.T) {
+	ctx := context.Background()
+	srv, accountRepo, projectRepo, auditRepo := newAccountExportTestServer(t)
+
+	const passwordHash = "$2a$10$export-test-password-hash"
+	account := domain.NewAccount("export@example.com", "Export User", passwordHash)
+	if err := accountRepo.Create(ctx, account); err != nil {
+		t.Fatalf("❌ アカウントの作成に失敗: %v", err)
+	}
+	other := domain.NewAccount("other@example.com", "Other User", passwordHash)
+	if err := accountRepo.Create(ctx, other); err != nil {
+		t.Fatalf("❌ アカウントの作成に失敗: %v", err)
+	}
+
+	// 1ページ（100件）を超えるプロジェクト
+	const projectCount = 130
+	for i := 0; i < projectCount; i++ {
+		if err := projectRepo.Create(ctx, domain.NewProject(account.ID, fmt.Sprintf("Project %03d", i), "")); err != nil {
+			t.Fatalf("❌ プロジェクトの作成に失敗: %v", err)
+		}
+	}
+	if err := projectRepo.Create(ctx, domain.NewProject(other.ID, "Other Project", "")); err != nil {
+		t.Fatalf("❌ プロジェクトの作成に失敗: %v", err)
+	}
+
+	log, err := domain.NewSecurityAuditLog(account.ID, domain.EventSessionRevoked, "Session revoked.", nil, nil, domain.SecurityAuditMetadata{
+		"token_id":   "0190b6a0-7c1e-7a00-8000-000000000001",
+		"token_hash": "deadbeef",
+	})
+	if err != nil {
+		t.Fatalf("❌ 監査ログの作成に失敗: %v", err)
+	}
+	if err := auditRepo.Create(ctx, log); err != nil {
+		t.Fatalf("❌ 監査ログの保存に失敗: %v", err)
+	}
+
+	exportAs := func(t *testing.T, actorID, role string, target string) (*http.Response, []byte) {
+		t.Helper()
+		return sendTestRequest(t, srv, http.MethodGet, "/api/v1/accounts/"+target+"/export",
+			map[string]string{"X-Test-Account": actorID, "X-Test-Role": role}, nil)
+	}
+
+	t.Run("本人はすべてのセクションを含むエクスポートを取得できる", func(t *testing.T) {
+		resp, body := exportAs(t, account.ID.String(), string(domain.RoleUser), account.ID.String())
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		if disposition := resp.Header.Get("Content-Disposition"); !strings.Contains(disposition, "account-"+account.ID.String()+".json") {
+			t.Errorf("❌ Content-Dispositionが不正です: %s", disposition)
+		}
+
+		var sections map[string]json.RawMessage
+		if err := json.Unmarshal(body, &sections); err != nil {
+			t.Fatalf("❌ エクスポートのデコードに失敗: %v, body: %s", err, body)
+		}
+		for _, section := range []string{"exported_at", "account", "projects", "security_events"} {
+			if _, ok := sections[section]; !ok {
+				t.Errorf("❌ %s セクションがありません", section)
+			}
+		}
+
+		var export api.AccountExport
+		if err := json.Unmarshal(body, &export); err != nil {
+			t.Fatalf("❌ エクスポートのデコードに失敗: %v", err)
+		}
+		if export.Account.Id != account.ID || string(export.Account.Email) != account.Email {
+			t.Errorf("❌ アカウントが不正です: %+v", export.Account)
+		}
+		if len(export.Projects) != projectCount {
+			t.Errorf("❌ プロジェクト数 期待値: %d, 実際: %d", projectCount, len(export.Projects))
+		}
+		seen := make(map[string]bool, len(export.Projects))
+		for _, project := range export.Projects {
+			if project.AccountId != account.ID || seen[project.Id.String()] {
+				t.Fatalf("❌ 他のアカウントまたは重複したプロジェクトが含まれています: %+v", project)
+			}
+			seen[project.Id.String()] = true
+		}
+
+		if len(export.SecurityEvents) != 1 {
+			t.Fatalf("❌ 監査ログ数 期待値: 1, 実際: %d", len(export.SecurityEvents))
+		}
+		event := export.SecurityEvents[0]
+		if event.EventType != string(domain.EventSessionRevoked) || event.Metadata == nil {
+			t.Fatalf("❌ 監査ログが不正です: %+v", event)
+		}
+		if _, ok := (*event.Metadata)["token_id"]; !ok {
+			t.Errorf("❌ 識別子のメタデータが削除されています: %v", *event.Metadata)
+		}
+		if _, ok := (*event.Metadata)["token_hash"]; ok {
+			t.Errorf("❌ トークンのハッシュが含まれています: %v", *event.Metadata)
+		}
+
+		for _, secret := range []string{passwordHash, "deadbeef", "password"} {
+			if strings.Contains(string(body), secret) {
+				t.Errorf("❌ エクスポートに秘密情報 %q が含まれています", secret)
+			}
+		}
+	})
+
+	t.Run("プロジェクトや監査ログが無くても空配列を返す", func(t *testing.T) {
+		resp, body := exportAs(t, other.ID.String(), string(domain.RoleUser), other.ID.String())
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		if !strings.Contains(string(body), `"security_events":[]`) {
+			t.Errorf("❌ 空の監査ログが空配列になっていません: %s", body)
+		}
+	})
+
+	t.Run("他のアカウントのエクスポートは拒否する", func(t *testing.T) {
+		resp, body := exportAs(t, other.ID.String(), string(domain.RoleUser), account.ID.String())
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("❌ ステータスコード 期待値: 403, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+	})
+
+	t.Run("管理者は他のアカウントをエクスポートでき、監査ログに記録される", func(t *testing.T) {
+		admin := domain.NewID()
+		resp, body := exportAs(t, admin.String(), string(domain.RoleAdmin), other.ID.String())
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		logs, _ := auditRepo.GetByEventType(ctx, domain.EventAdminAction, 10, 0)
+		if len(logs) != 1 || logs[0].AccountID != other.ID || !strings.Contains(string(logs[0].Metadata), string(domain.AdminActionExportAccount)) {
+			t.Errorf("❌ ADMIN_ACTIONが記録されていません: %+v", logs)
+		}
+
+		resp, body = exportAs(t, admin.String(), string(domain.RoleAdmin), domain.NewID().String())
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("❌ 存在しないアカウント 期待値: 404, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+)
+
+// TestListAccounts_PageLimit アカウント一覧の既定の件数と、上限を超える指定の切り詰めをテスト
+func TestListAccounts_PageLimit(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("設定した既定の件数と上限を適用する", func(t *testing.T) {
+		accountRepo := newFakeAccountRepository()
+		for i := 0; i < 5; i++ {
+			if err := accountRepo.Create(ctx, domain.NewAccount(fmt.Sprintf("limit-%d@example.com", i), "Limit", "hash")); err != nil {
+				t.Fatalf("❌ アカウント作成に失敗: %v", err)
+			}
+		}
+		accountUsecase := usecase.NewAccountUsecase(accountRepo, newFakeProjectRepository(), nil, nil, nil, nil, fakeTxManager{}, nil, usecase.AccountConfig{
+			ListDefaultLimit: 2,
+			ListMaxLimit:     3,
+		})
+		intPtr := func(v int) *int { return &v }
+
+		cases := []struct {
+			name          string
+			input         usecase.ListPageInput
+			expectedLimit int
+			expectedCount int
+		}{
+			{"指定なしは既定の件数", usecase.ListPageInput{}, 2, 2},
+			{"上限以内の指定はそのまま", usecase.ListPageInput{Limit: intPtr(3)}, 3, 3},
+			{"上限を超える指定は切り詰める", usecase.ListPageInput{Limit: intPtr(1000000)}, 3, 3},
+			{"オフセット", usecase.ListPageInput{Limit: intPtr(3), Offset: intPtr(4)}, 3, 1},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				accounts, limit, err := accountUsecase.List(ctx, tc.input)
+				if err != nil {
+					t.Fatalf("❌ 一覧の取得に失敗: %v", err)
+				}
+				if limit != tc.expectedLimit || len(accounts) != tc.expectedCount {
+					t.Errorf("❌ 件数の上限・件数 期待値: %d, %d, 実際: %d, %d", tc.expectedLimit, tc.expectedCount, limit, len(accounts))
+				}
+			})
+		}
+
+		for name, input := range map[string]usecase.ListPageInput{
+			"0件":      {Limit: intPtr(0)},
+			"負のオフセット": {Offset: intPtr(-1)},
+		} {
+			if _, _, err := accountUsecase.List(ctx, input); !errors.Is(err, domain.ErrInvalidPagination) {
+				t.Errorf("❌ %s 期待値: ErrInvalidPagination, 実際: %v", name, err)
+			}
+		}
+	})
+
+	t.Run("適用した件数をX-Page-Limitヘッダーで返す", func(t *testing.T) {
+		srv, accountRepo, _ := newAdminTestServer(t)
+		for i := 0; i < 3; i++ {
+			if err := accountRepo.Create(ctx, domain.NewAccount(fmt.Sprintf("header-%d@example.com", i), "Header", "hash")); err != nil {
+				t.Fatalf("❌ アカウント作成に失敗: %v", err)
+			}
+		}
+
+		for path, expected := range map[string]string{
+			"/api/v1/accounts":               "20",
+			"/api/v1/accounts?limit=2":       "2",
+			"/api/v1/accounts?limit=1000000": "100",
+		} {
+			resp, body := sendAsRole(t, srv, http.MethodGet, path, string(domain.RoleUser), nil)
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("❌ %s ステータスコード 期待値: 200, 実際: %d, body: %s", path, resp.StatusCode, body)
+			}
+			if limit := resp.Header.Get("X-Page-Limit"); limit != expected {
+				t.Errorf("❌ %s X-Page-Limit 期待値: %s, 実際: %s", path, expected, limit)
+			}
+			var accounts []api.Account
+			if err := json.Unmarshal(body, &accounts); err != nil {
+				t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+			}
+			if path == "/api/v1/accounts?limit=2" && len(accounts) != 2 {
+				t.Errorf("❌ %s 件数 期待値: 2, 実際: %d", path, len(accounts))
+			}
+		}
+
+		resp, body := sendAsRole(t, srv, http.MethodGet, "/api/v1/accounts?limit=0", string(domain.RoleUser), nil)
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("❌ limit=0 ステータスコード 期待値: 400, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+	})
+}
@@ -0,0 +1,221 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+)
+
+// TestValidateName_Default 既定の検証ルールをテスト
+func TestValidateName_Default(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{"英字", "John Doe", true},
+		{"日本語", "山田 太郎", true},
+		{"結合文字", "Zoë", true},
+		{"記号", "O'Brien-Smith (Dev)", true},
+		{"最大文字数", strings.Repeat("a", domain.MaxNameLength), true},
+		{"マルチバイトの最大文字数", strings.Repeat("あ", domain.MaxNameLength), true},
+		{"空文字", "", false},
+		{"最大文字数超過", strings.Repeat("a", domain.MaxNameLength+1), false},
+		{"NUL文字", "Bad\x00Name", false},
+		{"タブ", "Tab\tName", false},
+		{"改行", "Line\nBreak", false},
+		{"ゼロ幅スペース", "Zero\u200bWidth", false},
+		{"右から左への上書き", "admin\u202etxt.exe", false},
+		{"不正なUTF-8", "Bad\xffName", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := domain.ValidateName(tc.input)
+			if tc.valid && err != nil {
+				t.Errorf("❌ 許可されるべき名前が拒否されました: %v", err)
+			}
+			if !tc.valid && !errors.Is(err, domain.ErrInvalidName) {
+				t.Errorf("❌ 期待値: ErrInvalidName, 実際: %v", err)
+			}
+		})
+	}
+}
+
+// TestValidateName_Configured 設定した検証ルールをテスト
+func TestValidateName_Configured(t *testing.T) {
+	names := domain.NameConfig{
+		MinLength:      2,
+		MaxLength:      10,
+		AllowedClasses: []domain.NameCharClass{domain.NameCharLetter, domain.NameCharSpace, domain.NameCharPunctuation, domain.NameCharSymbol},
+		RejectMarkup:   true,
+		BlockedWords:   []string{" BadWord "},
+	}
+	if err := names.Validate(); err != nil {
+		t.Fatalf("❌ 検証ルールが拒否されました: %v", err)
+	}
+
+	cases := []struct {
+		name    string
+		input   string
+		message string // 空の場合は許可される
+	}{
+		{"許可", "Jane Doe", ""},
+		{"最小文字数未満", "J", "at least 2 characters"},
+		{"最大文字数超過", "Jane Doe Smith", "at most 10 characters"},
+		{"許可していない文字の種類", "R2D2", "disallowed character"},
+		{"マークアップ", "<b>Jane", "markup"},
+		{"禁止語", "my badword", "blocked word"},
+		{"禁止語（大文字）", "MY BADWORD", "blocked word"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := names.ValidateName(tc.input)
+			if tc.message == "" {
+				if err != nil {
+					t.Errorf("❌ 許可されるべき名前が拒否されました: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, domain.ErrInvalidName) || !strings.Contains(err.Error(), tc.message) {
+				t.Errorf("❌ 期待値: %q を含むErrInvalidName, 実際: %v", tc.message, err)
+			}
+		})
+	}
+
+	t.Run("ユースケースに設定した検証ルールを適用する", func(t *testing.T) {
+		ctx := context.Background()
+		authUsecase, _, _ := newTestAuthUsecaseWithConfig(t, usecase.AuthConfig{RefreshTokenExpiry: time.Hour, Names: names})
+		_, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+			Email:    "configured-name@example.com",
+			Password: "SecurePassword123!",
+			Name:     "R2D2",
+		})
+		if !errors.Is(err, domain.ErrInvalidName) {
+			t.Errorf("❌ サインアップ 期待値: ErrInvalidName, 実際: %v", err)
+		}
+
+		accountRepo := newFakeAccountRepository()
+		account := domain.NewAccount("configured-update@example.com", "R2D2", "hash")
+		if err := accountRepo.Create(ctx, account); err != nil {
+			t.Fatalf("❌ アカウントの作成に失敗: %v", err)
+		}
+		accountUsecase := usecase.NewAccountUsecase(accountRepo, nil, newFakeRefreshTokenRepository(), nil, nil, nil, fakeTxManager{}, nil, usecase.AccountConfig{Names: names})
+		name := "my badword"
+		if _, err := accountUsecase.Update(ctx, account.ID, usecase.UpdateInput{Name: &name}); !errors.Is(err, domain.ErrInvalidName) {
+			t.Errorf("❌ 更新 期待値: ErrInvalidName, 実際: %v", err)
+		}
+
+		// 名前を変更しない更新は既存の名前を新しい検証ルールで拒否しない
+		status := domain.AccountStatusSuspended
+		if _, err := accountUsecase.UpdateStatus(ctx, account.ID, status, usecase.Actor{}); err != nil {
+			t.Errorf("❌ 名前を変更しない更新が拒否されました: %v", err)
+		}
+	})
+
+	t.Run("不正な設定は拒否する", func(t *testing.T) {
+		invalid := []domain.NameConfig{
+			{MinLength: 0, MaxLength: 10, AllowedClasses: []domain.NameCharClass{domain.NameCharLetter}},
+			{MinLength: 5, MaxLength: 4, AllowedClasses: []domain.NameCharClass{domain.NameCharLetter}},
+			{MinLength: 1, MaxLength: domain.MaxNameLength + 1, AllowedClasses: []domain.NameCharClass{domain.NameCharLetter}},
+			{MinLength: 1, MaxLength: 10},
+			{MinLength: 1, MaxLength: 10, AllowedClasses: []domain.NameCharClass{"control"}},
+		}
+		for _, config := range invalid {
+			if err := config.Validate(); err == nil {
+				t.Errorf("❌ 不正な設定が受け付けられました: %+v", config)
+			}
+		}
+	})
+}
+
+// TestAccountName_Requests サインアップと更新で名前が正規化・検証されることをテスト
+func TestAccountName_Requests(t *testing.T) {
+	t.Run("サインアップ", func(t *testing.T) {
+		srv := newAuthTestServer(t)
+
+		resp, body := sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/signup", nil, map[string]string{
+			"email":    "trimmed@example.com",
+			"password": "SecurePassword123!",
+			"name":     "  Trimmed Name 　",
+		})
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("❌ ステータスコード 期待値: 201, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var authResp api.AuthResponse
+		if err := json.Unmarshal(body, &authResp); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if authResp.Account.Name != "Trimmed Name" {
+			t.Errorf("❌ 前後の空白が除去されていません: %q", authResp.Account.Name)
+		}
+
+		resp, body = sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/signup", nil, map[string]string{
+			"email":    "control@example.com",
+			"password": "SecurePassword123!",
+			"name":     "Control\u0007Name",
+		})
+		assertInvalidName(t, resp, body, "control or invisible characters")
+
+		resp, body = sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/signup", nil, map[string]string{
+			"email":    "blank@example.com",
+			"password": "SecurePassword123!",
+			"name":     "   ",
+		})
+		assertInvalidName(t, resp, body, "at least 1 characters")
+	})
+
+	t.Run("更新", func(t *testing.T) {
+		srv, accountRepo, _ := newAdminTestServer(t)
+		account := domain.NewAccount("update-name@example.com", "Before", "hash")
+		if err := accountRepo.Create(t.Context(), account); err != nil {
+			t.Fatalf("❌ アカウントの作成に失敗: %v", err)
+		}
+		path := "/api/v1/accounts/" + account.ID.String()
+
+		resp, body := sendAsAccount(t, srv, http.MethodPatch, path, account.ID, map[string]string{
+			"name": strings.Repeat("a", domain.MaxNameLength+1),
+		})
+		assertInvalidName(t, resp, body, "at most 255 characters")
+
+		resp, body = sendAsAccount(t, srv, http.MethodPatch, path, account.ID, map[string]string{
+			"name": "Zero\u200bWidth",
+		})
+		assertInvalidName(t, resp, body, "control or invisible characters")
+
+		resp, body = sendAsAccount(t, srv, http.MethodPatch, path, account.ID, map[string]string{
+			"name": " After ",
+		})
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		stored, _ := accountRepo.GetByID(t.Context(), account.ID)
+		if stored.Name != "After" {
+			t.Errorf("❌ 前後の空白が除去されていません: %q", stored.Name)
+		}
+	})
+}
+
+// assertInvalidName 理由を含むinvalid_nameエラーが返されたことを確認
+func assertInvalidName(t *testing.T, resp *http.Response, body []byte, reason string) {
+	t.Helper()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("❌ ステータスコード 期待値: 400, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+	var apiErr api.Error
+	if err := json.Unmarshal(body, &apiErr); err != nil {
+		t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+	}
+	if apiErr.Code != api.ErrorCodeInvalidName || !strings.Contains(apiErr.Error, reason) {
+		t.Errorf("❌ 期待値: %q を含むinvalid_name, 実際: %s", reason, body)
+	}
+}
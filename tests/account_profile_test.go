@@ -0,0 +1,183 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+)
+
+// TestAccountProfile_PartialUpdate プロフィール項目の部分更新をテスト
+func TestAccountProfile_PartialUpdate(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeAccountRepository()
+	accountUsecase := usecase.NewAccountUsecase(repo, nil, nil, nil, nil, nil, nil, nil, usecase.AccountConfig{})
+
+	account := domain.NewAccount("profile@example.com", "Profile User", "hash")
+	if err := repo.Create(ctx, account); err != nil {
+		t.Fatalf("❌ アカウント作成に失敗: %v", err)
+	}
+
+	str := func(s string) *string { return &s }
+
+	t.Run("指定した項目のみ更新される", func(t *testing.T) {
+		updated, err := accountUsecase.Update(ctx, account.ID, usecase.UpdateInput{
+			DisplayName: str("Pro"),
+			Timezone:    str("Asia/Tokyo"),
+		})
+		if err != nil {
+			t.Fatalf("❌ 更新に失敗: %v", err)
+		}
+		if updated.DisplayName == nil || *updated.DisplayName != "Pro" {
+			t.Errorf("❌ display_nameが更新されていません: %v", updated.DisplayName)
+		}
+		if updated.Name != "Profile User" || updated.Email != "profile@example.com" {
+			t.Errorf("❌ 未指定の項目が変更されています: %s %s", updated.Name, updated.Email)
+		}
+
+		// 2回目の更新では前回の値が維持される
+		updated, err = accountUsecase.Update(ctx, account.ID, usecase.UpdateInput{
+			Locale: str("ja-JP"),
+		})
+		if err != nil {
+			t.Fatalf("❌ 更新に失敗: %v", err)
+		}
+		if updated.Timezone == nil || *updated.Timezone != "Asia/Tokyo" {
+			t.Errorf("❌ timezoneが維持されていません: %v", updated.Timezone)
+		}
+		if updated.Locale == nil || *updated.Locale != "ja-JP" {
+			t.Errorf("❌ localeが更新されていません: %v", updated.Locale)
+		}
+	})
+
+	t.Run("空文字で未設定に戻る", func(t *testing.T) {
+		updated, err := accountUsecase.Update(ctx, account.ID, usecase.UpdateInput{
+			DisplayName: str(""),
+		})
+		if err != nil {
+			t.Fatalf("❌ 更新に失敗: %v", err)
+		}
+		if updated.DisplayName != nil {
+			t.Errorf("❌ display_nameが未設定に戻っていません: %v", *updated.DisplayName)
+		}
+	})
+
+	t.Run("不正な値は拒否される", func(t *testing.T) {
+		cases := []struct {
+			name  string
+			input usecase.UpdateInput
+			want  error
+		}{
+			{"存在しないタイムゾーン", usecase.UpdateInput{Timezone: str("Mars/Olympus")}, domain.ErrInvalidTimezone},
+			{"Localタイムゾーン", usecase.UpdateInput{Timezone: str("Local")}, domain.ErrInvalidTimezone},
+			{"httpでないURL", usecase.UpdateInput{AvatarURL: str("javascript:alert(1)")}, domain.ErrInvalidAvatarURL},
+			{"相対URL", usecase.UpdateInput{AvatarURL: str("/avatar.png")}, domain.ErrInvalidAvatarURL},
+			{"不正なロケール", usecase.UpdateInput{Locale: str("日本語")}, domain.ErrInvalidLocale},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := accountUsecase.Update(ctx, account.ID, tc.input)
+				if !errors.Is(err, tc.want) {
+					t.Errorf("❌ 期待値: %v, 実際: %v", tc.want, err)
+				}
+			})
+		}
+
+		// 拒否された更新は保存されない
+		stored, _ := repo.GetByID(ctx, account.ID)
+		if stored.Timezone == nil || *stored.Timezone != "Asia/Tokyo" {
+			t.Errorf("❌ 拒否された更新が保存されています: %v", stored.Timezone)
+		}
+	})
+}
+
+// TestAccountProfile_MergePatch JSON Merge Patchで省略・値の指定・nullを区別することをテスト
+func TestAccountProfile_MergePatch(t *testing.T) {
+	ctx := context.Background()
+	srv, accountRepo, _, _ := newAccountExportTestServer(t)
+
+	account := domain.NewAccount("merge@example.com", "Merge User", "hash")
+	displayName, timezone := "Merger", "Asia/Tokyo"
+	account.DisplayName = &displayName
+	account.Timezone = &timezone
+	if err := accountRepo.Create(ctx, account); err != nil {
+		t.Fatalf("❌ アカウント作成に失敗: %v", err)
+	}
+
+	patch := func(t *testing.T, contentType, body string) (int, api.Account, []byte) {
+		t.Helper()
+		resp, respBody := sendTestRequest(t, srv, http.MethodPatch, "/api/v1/accounts/"+account.ID.String(),
+			map[string]string{
+				"Content-Type":   contentType,
+				"X-Test-Account": account.ID.String(),
+				"X-Test-Role":    string(domain.RoleUser),
+			}, json.RawMessage(body))
+		var updated api.Account
+		if resp.StatusCode == http.StatusOK {
+			if err := json.Unmarshal(respBody, &updated); err != nil {
+				t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+			}
+		}
+		return resp.StatusCode, updated, respBody
+	}
+
+	t.Run("省略した項目は変更しない", func(t *testing.T) {
+		status, updated, body := patch(t, "application/merge-patch+json", `{"locale":"ja-JP"}`)
+		if status != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", status, body)
+		}
+		if updated.DisplayName == nil || *updated.DisplayName != "Merger" {
+			t.Errorf("❌ display_nameが変更されました: %v", updated.DisplayName)
+		}
+		if updated.Locale == nil || *updated.Locale != "ja-JP" {
+			t.Errorf("❌ localeが設定されていません: %v", updated.Locale)
+		}
+	})
+
+	t.Run("値を指定した項目は更新する", func(t *testing.T) {
+		status, updated, body := patch(t, "application/merge-patch+json", `{"display_name":"Patched"}`)
+		if status != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", status, body)
+		}
+		if updated.DisplayName == nil || *updated.DisplayName != "Patched" {
+			t.Errorf("❌ display_nameが更新されていません: %v", updated.DisplayName)
+		}
+	})
+
+	t.Run("明示的なnullで未設定に戻す", func(t *testing.T) {
+		status, updated, body := patch(t, "application/merge-patch+json", `{"display_name":null}`)
+		if status != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", status, body)
+		}
+		if updated.DisplayName != nil {
+			t.Errorf("❌ display_nameが未設定に戻っていません: %v", *updated.DisplayName)
+		}
+		if updated.Timezone == nil || *updated.Timezone != "Asia/Tokyo" {
+			t.Errorf("❌ 省略したtimezoneが変更されました: %v", updated.Timezone)
+		}
+	})
+
+	t.Run("application/jsonではnullを省略として扱う", func(t *testing.T) {
+		status, updated, body := patch(t, "application/json", `{"timezone":null}`)
+		if status != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", status, body)
+		}
+		if updated.Timezone == nil || *updated.Timezone != "Asia/Tokyo" {
+			t.Errorf("❌ timezoneが変更されました: %v", updated.Timezone)
+		}
+	})
+
+	t.Run("未設定に戻せない項目とオブジェクト以外は拒否する", func(t *testing.T) {
+		for _, body := range []string{`{"email":null}`, `{"name":null}`, `null`, `["display_name"]`} {
+			if status, _, respBody := patch(t, "application/merge-patch+json", body); status != http.StatusBadRequest {
+				t.Errorf("❌ %s: ステータスコード 期待値: 400, 実際: %d, body: %s", body, status, respBody)
+			}
+		}
+	})
+}
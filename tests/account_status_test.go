@@ -0,0 +1,200 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/handler"
+	"github.com/aida0710/jwt-auth/internal/logger"
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// newAccountStatusTestServer アカウントと認証のユースケースがリポジトリを共有するテスト用サーバーを作成
+// X-Test-Role / X-Test-Account ヘッダーの値を認証済みのロール・アカウントIDとして扱う
+func newAccountStatusTestServer(t *testing.T) (*httptest.Server, *usecase.AuthUsecase, *fakeAccountRepository, *fakeSecurityAuditLogRepository) {
+	t.Helper()
+
+	accountRepo := newFakeAccountRepository()
+	refreshTokenRepo := newFakeRefreshTokenRepository()
+	auditRepo := &fakeSecurityAuditLogRepository{}
+	jwtManager := auth.NewJWTManager(auth.JWTConfig{
+		AccessTokenSecret:  "test-access-secret-0123456789abcdef",
+		RefreshTokenSecret: "test-refresh-secret-0123456789abcdef",
+		AccessTokenExpiry:  time.Minute,
+		RefreshTokenExpiry: time.Hour,
+		Issuer:             "jwt-auth-test",
+		Audience:           []string{"jwt-auth-test"},
+	})
+
+	authUsecase := usecase.NewAuthUsecase(accountRepo, refreshTokenRepo, auditRepo, nil, nil, nil, nil, nil, jwtManager,
+		usecase.AuthConfig{RefreshTokenExpiry: time.Hour})
+	accountUsecase := usecase.NewAccountUsecase(accountRepo, nil, refreshTokenRepo, nil, nil, auditRepo, fakeTxManager{}, nil, usecase.AccountConfig{})
+	server := handler.NewServer(accountUsecase, nil, handler.NewAuthHandler(authUsecase), handler.HealthConfig{}, handler.PrivacyConfig{}, logger.NewLoggerWithOutput("error", "json", io.Discard))
+
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(string(middleware.RoleKey), c.Request().Header.Get("X-Test-Role"))
+			c.Set(string(middleware.AccountIDKey), c.Request().Header.Get("X-Test-Account"))
+			return next(c)
+		}
+	})
+	e.Use(middleware.NewRoleMiddleware(middleware.RoleConfig{
+		Rules: map[string]domain.Role{
+			"PUT /api/v1/admin/accounts/:account_id/status": domain.RoleAdmin,
+		},
+	}))
+	api.RegisterHandlersWithBaseURL(e, server, "/api/v1")
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+	return srv, authUsecase, accountRepo, auditRepo
+}
+
+// sendAsAdmin 指定アカウントを管理者としてテスト用サーバーにリクエストを送信
+func sendAsAdmin(t *testing.T, srv *httptest.Server, method, path string, adminID uuid.UUID, body interface{}) (*http.Response, []byte) {
+	t.Helper()
+	return sendTestRequest(t, srv, method, path, map[string]string{
+		"X-Test-Role":    string(domain.RoleAdmin),
+		"X-Test-Account": adminID.String(),
+	}, body)
+}
+
+// TestAccountStatus_Suspend 管理者による停止でログインとリフレッシュが拒否されることをテスト
+func TestAccountStatus_Suspend(t *testing.T) {
+	ctx := context.Background()
+	srv, authUsecase, _, _ := newAccountStatusTestServer(t)
+	adminID := uuid.New()
+
+	signedUp, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+		Email:    "suspend@example.com",
+		Password: "SecurePassword123!",
+		Name:     "Suspended User",
+	})
+	if err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+	if signedUp.Account.Status != domain.AccountStatusActive {
+		t.Errorf("❌ 作成直後の状態 期待値: active, 実際: %s", signedUp.Account.Status)
+	}
+
+	path := "/api/v1/admin/accounts/" + signedUp.Account.ID.String() + "/status"
+	login := usecase.LoginInput{Email: "suspend@example.com", Password: "SecurePassword123!"}
+
+	t.Run("一般ユーザーは変更できない", func(t *testing.T) {
+		resp, body := sendAsAccount(t, srv, http.MethodPut, path, signedUp.Account.ID, api.UpdateAccountStatusRequest{
+			Status: api.UpdateAccountStatusRequestStatusSuspended,
+		})
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("❌ ステータスコード 期待値: 403, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+	})
+
+	t.Run("不正な状態は400", func(t *testing.T) {
+		resp, body := sendAsAdmin(t, srv, http.MethodPut, path, adminID, map[string]string{"status": "deleted"})
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("❌ ステータスコード 期待値: 400, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+	})
+
+	t.Run("停止するとログインとリフレッシュを拒否する", func(t *testing.T) {
+		resp, body := sendAsAdmin(t, srv, http.MethodPut, path, adminID, api.UpdateAccountStatusRequest{
+			Status: api.UpdateAccountStatusRequestStatusSuspended,
+		})
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var account api.Account
+		if err := json.Unmarshal(body, &account); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if account.Status != api.AccountStatusSuspended {
+			t.Errorf("❌ status 期待値: suspended, 実際: %s", account.Status)
+		}
+
+		if _, err := authUsecase.Login(ctx, login); !errors.Is(err, domain.ErrAccountSuspended) {
+			t.Errorf("❌ ログイン 期待値: ErrAccountSuspended, 実際: %v", err)
+		}
+
+		// 停止前に発行したリフレッシュトークンは無効化されている
+		if _, err := authUsecase.RefreshToken(ctx, signedUp.RefreshToken, "", "", ""); !errors.Is(err, domain.ErrInvalidToken) {
+			t.Errorf("❌ リフレッシュ 期待値: ErrInvalidToken, 実際: %v", err)
+		}
+
+		identifier := "suspend@example.com"
+		resp, body = sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/login", nil, api.LoginRequest{
+			Identifier: &identifier,
+			Password:   "SecurePassword123!",
+		})
+		if resp.StatusCode != http.StatusForbidden {
+			t.Fatalf("❌ ログインのステータスコード 期待値: 403, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var apiErr api.Error
+		if err := json.Unmarshal(body, &apiErr); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if apiErr.Code != api.ErrorCodeAccountSuspended {
+			t.Errorf("❌ code 期待値: account_suspended, 実際: %s", apiErr.Code)
+		}
+	})
+
+	t.Run("再開するとログインできる", func(t *testing.T) {
+		resp, body := sendAsAdmin(t, srv, http.MethodPut, path, adminID, api.UpdateAccountStatusRequest{
+			Status: api.UpdateAccountStatusRequestStatusActive,
+		})
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		if _, err := authUsecase.Login(ctx, login); err != nil {
+			t.Errorf("❌ 再開後のログインに失敗: %v", err)
+		}
+	})
+}
+
+// TestAccountStatus_RefreshRejectsSuspended 有効なリフレッシュトークンでも停止中のアカウントは拒否することをテスト
+func TestAccountStatus_RefreshRejectsSuspended(t *testing.T) {
+	ctx := context.Background()
+	srv, authUsecase, accountRepo, _ := newAccountStatusTestServer(t)
+
+	signedUp, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+		Email:    "suspend-refresh@example.com",
+		Password: "SecurePassword123!",
+		Name:     "Suspended Refresh",
+	})
+	if err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+
+	// トークンの無効化を経ずに停止された場合（無効化の失敗など）もリフレッシュを拒否する
+	account, err := accountRepo.GetByID(ctx, signedUp.Account.ID)
+	if err != nil {
+		t.Fatalf("❌ アカウントの取得に失敗: %v", err)
+	}
+	account.Status = domain.AccountStatusSuspended
+	if err := accountRepo.Update(ctx, account); err != nil {
+		t.Fatalf("❌ アカウントの更新に失敗: %v", err)
+	}
+
+	if _, err := authUsecase.RefreshToken(ctx, signedUp.RefreshToken, "", "", ""); !errors.Is(err, domain.ErrAccountSuspended) {
+		t.Errorf("❌ リフレッシュ 期待値: ErrAccountSuspended, 実際: %v", err)
+	}
+
+	resp, body := sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/refresh", nil, api.RefreshTokenRequest{
+		RefreshToken: signedUp.RefreshToken,
+	})
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("❌ ステータスコード 期待値: 403, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,98 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+)
+
+// TestUpdateAccount_IfMatch If-Matchによるアカウントの楽観的排他制御をテスト
+// 2人の管理者が同じアカウントを読み込んで編集し、後から保存した側の古い更新を409で拒否する
+func TestUpdateAccount_IfMatch(t *testing.T) {
+	ctx := context.Background()
+	srv, accountRepo, _ := newAdminTestServer(t)
+
+	account := domain.NewAccount("if-match@example.com", "Original", "hash")
+	if err := accountRepo.Create(ctx, account); err != nil {
+		t.Fatalf("❌ アカウント作成に失敗: %v", err)
+	}
+	path := "/api/v1/accounts/" + account.ID.String()
+
+	// update If-Matchを付けてアカウントを更新する
+	update := func(t *testing.T, method, ifMatch, name string) (*http.Response, []byte) {
+		t.Helper()
+		headers := map[string]string{"X-Test-Role": string(domain.RoleAdmin)}
+		if ifMatch != "" {
+			headers["If-Match"] = ifMatch
+		}
+		return sendTestRequest(t, srv, method, path, headers, map[string]string{"name": name})
+	}
+	// currentName 保存されているアカウントの名前を返す
+	currentName := func(t *testing.T) string {
+		t.Helper()
+		stored, err := accountRepo.GetByID(ctx, account.ID)
+		if err != nil {
+			t.Fatalf("❌ アカウント取得に失敗: %v", err)
+		}
+		return stored.Name
+	}
+
+	resp, _ := sendAsRole(t, srv, http.MethodGet, path, string(domain.RoleAdmin), nil)
+	staleETag := resp.Header.Get("ETag")
+	if resp.StatusCode != http.StatusOK || staleETag == "" {
+		t.Fatalf("❌ 取得 期待値: 200とETag, 実際: %d %q", resp.StatusCode, staleETag)
+	}
+
+	t.Run("最新のETagを指定した更新は成功しETagが変わる", func(t *testing.T) {
+		resp, body := update(t, http.MethodPatch, staleETag, "First Admin")
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		if etag := resp.Header.Get("ETag"); etag == "" || etag == staleETag {
+			t.Errorf("❌ 更新後のETagが変わっていません: %q", etag)
+		}
+		var updated api.Account
+		if err := json.Unmarshal(body, &updated); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if updated.Version != account.Version+1 {
+			t.Errorf("❌ version 期待値: %d, 実際: %d", account.Version+1, updated.Version)
+		}
+	})
+
+	t.Run("古いETagを指定した更新は409で拒否し上書きしない", func(t *testing.T) {
+		for _, method := range []string{http.MethodPatch, http.MethodPut} {
+			resp, body := update(t, method, staleETag, "Second Admin")
+			if resp.StatusCode != http.StatusConflict {
+				t.Fatalf("❌ %s ステータスコード 期待値: 409, 実際: %d, body: %s", method, resp.StatusCode, body)
+			}
+			var apiErr api.Error
+			if err := json.Unmarshal(body, &apiErr); err != nil || apiErr.Code != api.ErrorCodeVersionConflict {
+				t.Errorf("❌ %s エラーコード 期待値: version_conflict, 実際: %s (%v)", method, apiErr.Code, err)
+			}
+		}
+		if name := currentName(t); name != "First Admin" {
+			t.Errorf("❌ 古い更新で上書きされました: %s", name)
+		}
+	})
+
+	t.Run("一致しないETagの形式は競合として扱う", func(t *testing.T) {
+		for _, ifMatch := range []string{`W/"2"`, `"not-a-version"`, "2"} {
+			if resp, body := update(t, http.MethodPatch, ifMatch, "Invalid"); resp.StatusCode != http.StatusConflict {
+				t.Errorf("❌ If-Match: %s ステータスコード 期待値: 409, 実際: %d, body: %s", ifMatch, resp.StatusCode, body)
+			}
+		}
+	})
+
+	t.Run("If-Matchが無い場合と*の場合は無条件に更新する", func(t *testing.T) {
+		for _, ifMatch := range []string{"", "*"} {
+			if resp, body := update(t, http.MethodPatch, ifMatch, "Unconditional"); resp.StatusCode != http.StatusOK {
+				t.Errorf("❌ If-Match: %q ステータスコード 期待値: 200, 実際: %d, body: %s", ifMatch, resp.StatusCode, body)
+			}
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+)
+
+// TestAdminSearchAccounts 管理者によるメールアドレスの前方一致検索をテスト
+func TestAdminSearchAccounts(t *testing.T) {
+	ctx := context.Background()
+	srv, accountRepo, _ := newAdminTestServer(t)
+
+	for _, email := range []string{"alice.smith@example.com", "alice@example.org", "Alicia@example.com", "bob@example.com"} {
+		if err := accountRepo.Create(ctx, domain.NewAccount(email, "Search", "hash")); err != nil {
+			t.Fatalf("❌ アカウント作成に失敗: %v", err)
+		}
+	}
+
+	search := func(t *testing.T, query string) []string {
+		t.Helper()
+		resp, body := sendAsRole(t, srv, http.MethodGet, "/api/v1/admin/accounts/search"+query, "admin", nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var accounts []api.Account
+		if err := json.Unmarshal(body, &accounts); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		emails := make([]string, len(accounts))
+		for i, a := range accounts {
+			emails[i] = string(a.Email)
+		}
+		return emails
+	}
+
+	t.Run("前方一致するアカウントをメールアドレス順に返す", func(t *testing.T) {
+		got := search(t, "?email=alice")
+		want := []string{"alice.smith@example.com", "alice@example.org"}
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("❌ 期待値: %v, 実際: %v", want, got)
+		}
+	})
+
+	t.Run("大文字小文字を区別しない", func(t *testing.T) {
+		if got := search(t, "?email=ALI"); len(got) != 3 {
+			t.Errorf("❌ 件数 期待値: 3, 実際: %v", got)
+		}
+	})
+
+	t.Run("limitで件数を制限する", func(t *testing.T) {
+		if got := search(t, "?email=ali&limit=1"); len(got) != 1 || got[0] != "alice.smith@example.com" {
+			t.Errorf("❌ 期待値: [alice.smith@example.com], 実際: %v", got)
+		}
+	})
+
+	t.Run("一致しない場合は空配列", func(t *testing.T) {
+		if got := search(t, "?email=carol"); len(got) != 0 {
+			t.Errorf("❌ 期待値: [], 実際: %v", got)
+		}
+	})
+
+	t.Run("空や短すぎる検索語は400", func(t *testing.T) {
+		long := strings.Repeat("a", domain.MaxEmailSearchLength+1)
+		for _, query := range []string{"", "?email=", "?email=" + url.QueryEscape("  "), "?email=al", "?email=" + long, "?email=alice&limit=0"} {
+			resp, body := sendAsRole(t, srv, http.MethodGet, "/api/v1/admin/accounts/search"+query, "admin", nil)
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("❌ %.30s: ステータスコード 期待値: 400, 実際: %d, body: %s", query, resp.StatusCode, body)
+			}
+		}
+	})
+
+	t.Run("一般ユーザーは403", func(t *testing.T) {
+		resp, body := sendAsRole(t, srv, http.MethodGet, "/api/v1/admin/accounts/search?email=alice", "user", nil)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("❌ ステータスコード 期待値: 403, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+	})
+}
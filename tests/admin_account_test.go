@@ -0,0 +1,226 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/handler"
+	"github.com/aida0710/jwt-auth/internal/logger"
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// newAdminTestServer ロールミドルウェアとAPIハンドラーを組み合わせたテスト用サーバーを作成
+// X-Test-Role / X-Test-Account / X-Test-Tenant ヘッダーの値を認証済みのロール・アカウントID・テナントとして扱う
+func newAdminTestServer(t *testing.T) (*httptest.Server, *fakeAccountRepository, *fakeProjectRepository) {
+	t.Helper()
+	return newAdminTestServerWithProjectConfig(t, usecase.ProjectConfig{})
+}
+
+// newAdminTestServerWithProjectConfig プロジェクトユースケースの設定を指定してテスト用サーバーを作成
+func newAdminTestServerWithProjectConfig(t *testing.T, projectConfig usecase.ProjectConfig) (*httptest.Server, *fakeAccountRepository, *fakeProjectRepository) {
+	t.Helper()
+	return newAdminTestServerWithConfig(t, projectConfig, handler.PrivacyConfig{})
+}
+
+// newAdminTestServerWithConfig プロジェクトユースケースとレスポンスの個人情報の設定を指定してテスト用サーバーを作成
+func newAdminTestServerWithConfig(t *testing.T, projectConfig usecase.ProjectConfig, privacy handler.PrivacyConfig) (*httptest.Server, *fakeAccountRepository, *fakeProjectRepository) {
+	t.Helper()
+
+	accountRepo := newFakeAccountRepository()
+	projectRepo := newFakeProjectRepository()
+	accountRepo.projects = projectRepo
+	accountUsecase := usecase.NewAccountUsecase(accountRepo, projectRepo, nil, nil, nil, nil, fakeTxManager{}, nil, usecase.AccountConfig{})
+	projectUsecase := usecase.NewProjectUsecase(projectRepo, accountRepo, fakeTxManager{}, projectConfig)
+	server := handler.NewServer(accountUsecase, projectUsecase, nil, handler.HealthConfig{}, privacy, logger.NewLoggerWithOutput("error", "json", io.Discard))
+
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(string(middleware.RoleKey), c.Request().Header.Get("X-Test-Role"))
+			c.Set(string(middleware.AccountIDKey), c.Request().Header.Get("X-Test-Account"))
+			if tenantID := c.Request().Header.Get("X-Test-Tenant"); tenantID != "" {
+				middleware.SetTenantID(c, tenantID)
+			}
+			return next(c)
+		}
+	})
+	e.Use(middleware.NewRoleMiddleware(middleware.RoleConfig{
+		Rules: map[string]domain.Role{
+			"POST /api/v1/accounts":                            domain.RoleAdmin,
+			"GET /api/v1/admin/accounts":                       domain.RoleAdmin,
+			"GET /api/v1/admin/accounts/search":                domain.RoleAdmin,
+			"PUT /api/v1/admin/accounts/:account_id/status":    domain.RoleAdmin,
+			"PUT /api/v1/admin/accounts/:account_id/audiences": domain.RoleAdmin,
+			"GET /api/v1/admin/projects":                       domain.RoleAdmin,
+		},
+	}))
+	api.RegisterHandlersWithBaseURL(e, server, "/api/v1")
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+	return srv, accountRepo, projectRepo
+}
+
+// sendAsRole 指定ロールでテスト用サーバーにリクエストを送信
+func sendAsRole(t *testing.T, srv *httptest.Server, method, path, role string, body interface{}) (*http.Response, []byte) {
+	t.Helper()
+	return sendTestRequest(t, srv, method, path, map[string]string{"X-Test-Role": role}, body)
+}
+
+// sendAsAccount 指定アカウント（一般ユーザー）としてテスト用サーバーにリクエストを送信
+func sendAsAccount(t *testing.T, srv *httptest.Server, method, path string, accountID uuid.UUID, body interface{}) (*http.Response, []byte) {
+	t.Helper()
+	return sendTestRequest(t, srv, method, path, map[string]string{
+		"X-Test-Role":    string(domain.RoleUser),
+		"X-Test-Account": accountID.String(),
+	}, body)
+}
+
+// sendTestRequest ヘッダーを指定してテスト用サーバーにリクエストを送信
+func sendTestRequest(t *testing.T, srv *httptest.Server, method, path string, headers map[string]string, body interface{}) (*http.Response, []byte) {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("❌ リクエストのエンコードに失敗: %v", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequest(method, srv.URL+path, reader)
+	if err != nil {
+		t.Fatalf("❌ リクエスト作成に失敗: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("❌ リクエスト送信に失敗: %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(resp.Body)
+	return resp, respBody
+}
+
+// postAccount 指定ロールでアカウント作成APIを呼び出す
+func postAccount(t *testing.T, srv *httptest.Server, role string, body interface{}) (*http.Response, []byte) {
+	t.Helper()
+	return sendAsRole(t, srv, http.MethodPost, "/api/v1/accounts", role, body)
+}
+
+// TestAdminCreateAccount 管理者によるアカウント作成をテスト
+func TestAdminCreateAccount(t *testing.T) {
+	srv, repo, _ := newAdminTestServer(t)
+
+	t.Run("管理者はアカウントを作成できる", func(t *testing.T) {
+		resp, body := postAccount(t, srv, "admin", map[string]string{
+			"email":    "provisioned@example.com",
+			"password": "password123",
+			"name":     "Provisioned User",
+		})
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("❌ ステータスコード 期待値: 201, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+
+		var account api.Account
+		if err := json.Unmarshal(body, &account); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if account.Email != "provisioned@example.com" {
+			t.Errorf("❌ メールアドレス 期待値: provisioned@example.com, 実際: %s", account.Email)
+		}
+		if account.Role != api.AccountRoleUser {
+			t.Errorf("❌ ロール未指定時は一般ユーザーになるべきです: %s", account.Role)
+		}
+
+		// トークンは発行されない
+		var raw map[string]interface{}
+		_ = json.Unmarshal(body, &raw)
+		if _, ok := raw["access_token"]; ok {
+			t.Error("❌ アカウント作成でトークンが発行されています")
+		}
+	})
+
+	t.Run("ロールを指定して管理者を作成できる", func(t *testing.T) {
+		resp, body := postAccount(t, srv, "admin", map[string]string{
+			"email":    "second-admin@example.com",
+			"password": "password123",
+			"name":     "Second Admin",
+			"role":     "admin",
+		})
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("❌ ステータスコード 期待値: 201, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+
+		account, err := repo.GetByEmail(context.Background(), "second-admin@example.com")
+		if err != nil || !account.IsAdmin() {
+			t.Error("❌ 管理者ロールで保存されていません")
+		}
+	})
+
+	t.Run("重複したメールアドレスは409", func(t *testing.T) {
+		resp, body := postAccount(t, srv, "admin", map[string]string{
+			"email":    "provisioned@example.com",
+			"password": "password123",
+			"name":     "Duplicate",
+		})
+		if resp.StatusCode != http.StatusConflict {
+			t.Errorf("❌ ステータスコード 期待値: 409, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+	})
+
+	t.Run("不正な入力は400", func(t *testing.T) {
+		cases := map[string]map[string]string{
+			"短いパスワード":    {"email": "short@example.com", "password": "short", "name": "Short"},
+			"不正なメールアドレス": {"email": "not-an-email", "password": "password123", "name": "Invalid"},
+			"不正なロール":     {"email": "role@example.com", "password": "password123", "name": "Role", "role": "owner"},
+		}
+		for name, body := range cases {
+			t.Run(name, func(t *testing.T) {
+				resp, respBody := postAccount(t, srv, "admin", body)
+				if resp.StatusCode != http.StatusBadRequest {
+					t.Errorf("❌ ステータスコード 期待値: 400, 実際: %d, body: %s", resp.StatusCode, respBody)
+				}
+			})
+		}
+	})
+
+	t.Run("一般ユーザーは403", func(t *testing.T) {
+		resp, body := postAccount(t, srv, "user", map[string]string{
+			"email":    "forbidden@example.com",
+			"password": "password123",
+			"name":     "Forbidden",
+		})
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("❌ ステータスコード 期待値: 403, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		if _, err := repo.GetByEmail(context.Background(), "forbidden@example.com"); err == nil {
+			t.Error("❌ 権限のないリクエストでアカウントが作成されています")
+		}
+	})
+
+	t.Run("ルールの無いエンドポイントはロールを問わない", func(t *testing.T) {
+		resp, err := http.Get(srv.URL + "/api/v1/accounts")
+		if err != nil {
+			t.Fatalf("❌ リクエスト送信に失敗: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("❌ ステータスコード 期待値: 200, 実際: %d", resp.StatusCode)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/google/uuid"
+)
+
+// assertAdminActionLog ADMIN_ACTIONの監査ログが1件だけ記録され、操作者と対象が含まれることを確認
+func assertAdminActionLog(t *testing.T, auditRepo *fakeSecurityAuditLogRepository, owner, adminID, targetID uuid.UUID, action domain.AdminAction) {
+	t.Helper()
+
+	logs, _ := auditRepo.GetByEventType(context.Background(), domain.EventAdminAction, -1, 0)
+	if len(logs) != 1 {
+		t.Fatalf("❌ ADMIN_ACTIONの件数 期待値: 1, 実際: %d", len(logs))
+	}
+	if logs[0].AccountID != owner {
+		t.Errorf("❌ 監査ログのアカウント 期待値: %s, 実際: %s", owner, logs[0].AccountID)
+	}
+
+	var metadata map[string]interface{}
+	if err := json.Unmarshal(logs[0].Metadata, &metadata); err != nil {
+		t.Fatalf("❌ メタデータのパースに失敗: %v", err)
+	}
+	if metadata["action"] != string(action) {
+		t.Errorf("❌ action 期待値: %s, 実際: %v", action, metadata["action"])
+	}
+	if metadata["admin_id"] != adminID.String() {
+		t.Errorf("❌ admin_id 期待値: %s, 実際: %v", adminID, metadata["admin_id"])
+	}
+	if metadata["target_account_id"] != targetID.String() {
+		t.Errorf("❌ target_account_id 期待値: %s, 実際: %v", targetID, metadata["target_account_id"])
+	}
+}
+
+// TestAdminAction_Suspend 管理者によるアカウントの停止が監査ログに記録されることをテスト
+func TestAdminAction_Suspend(t *testing.T) {
+	ctx := context.Background()
+	srv, authUsecase, _, auditRepo := newAccountStatusTestServer(t)
+	adminID := uuid.New()
+
+	signedUp, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+		Email:    "audited@example.com",
+		Password: "SecurePassword123!",
+		Name:     "Audited User",
+	})
+	if err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+
+	resp, body := sendAsAdmin(t, srv, http.MethodPut, "/api/v1/admin/accounts/"+signedUp.Account.ID.String()+"/status", adminID,
+		api.UpdateAccountStatusRequest{Status: api.UpdateAccountStatusRequestStatusSuspended})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+
+	assertAdminActionLog(t, auditRepo, signedUp.Account.ID, adminID, signedUp.Account.ID, domain.AdminActionSuspendAccount)
+}
+
+// TestAdminAction_DeleteAccount 管理者による削除は管理者自身の監査ログに記録され、本人による削除は記録されないことをテスト
+func TestAdminAction_DeleteAccount(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (usecase.AccountUsecase, *fakeSecurityAuditLogRepository, *domain.Account) {
+		t.Helper()
+		accountRepo := newFakeAccountRepository()
+		auditRepo := &fakeSecurityAuditLogRepository{}
+		accountUsecase := usecase.NewAccountUsecase(accountRepo, newFakeProjectRepository(), newFakeRefreshTokenRepository(), nil, nil, auditRepo, fakeTxManager{}, nil, usecase.AccountConfig{})
+
+		account, err := accountUsecase.Create(ctx, usecase.CreateInput{
+			Email:    "deleted@example.com",
+			Name:     "Deleted User",
+			Password: "SecurePassword123!",
+		})
+		if err != nil {
+			t.Fatalf("❌ アカウント作成に失敗: %v", err)
+		}
+		return accountUsecase, auditRepo, account
+	}
+
+	t.Run("管理者による削除", func(t *testing.T) {
+		accountUsecase, auditRepo, account := setup(t)
+		adminID := uuid.New()
+
+		if err := accountUsecase.Delete(ctx, account.ID, usecase.Actor{ID: adminID, Role: domain.RoleAdmin}); err != nil {
+			t.Fatalf("❌ 削除に失敗: %v", err)
+		}
+		assertAdminActionLog(t, auditRepo, adminID, adminID, account.ID, domain.AdminActionDeleteAccount)
+	})
+
+	t.Run("本人による削除", func(t *testing.T) {
+		accountUsecase, auditRepo, account := setup(t)
+
+		if err := accountUsecase.Delete(ctx, account.ID, usecase.Actor{ID: account.ID, Role: domain.RoleUser}); err != nil {
+			t.Fatalf("❌ 削除に失敗: %v", err)
+		}
+		if logs, _ := auditRepo.GetByEventType(ctx, domain.EventAdminAction, -1, 0); len(logs) != 0 {
+			t.Errorf("❌ ADMIN_ACTIONの件数 期待値: 0, 実際: %d", len(logs))
+		}
+	})
+}
@@ -0,0 +1,214 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+)
+
+// TestAdminListAllProjects 管理者による全プロジェクト一覧の取得をテスト
+func TestAdminListAllProjects(t *testing.T) {
+	ctx := context.Background()
+	srv, accountRepo, projectRepo := newAdminTestServer(t)
+
+	owner := domain.NewAccount("owner@example.com", "Owner", "hash")
+	other := domain.NewAccount("other@example.com", "Other", "hash")
+	for _, a := range []*domain.Account{owner, other} {
+		if err := accountRepo.Create(ctx, a); err != nil {
+			t.Fatalf("❌ アカウント作成に失敗: %v", err)
+		}
+	}
+
+	// ownerに3件（うち1件はアーカイブ済み）、otherに2件
+	seed := []struct {
+		account *domain.Account
+		status  domain.ProjectStatus
+	}{
+		{owner, domain.ProjectStatusActive},
+		{owner, domain.ProjectStatusActive},
+		{owner, domain.ProjectStatusArchived},
+		{other, domain.ProjectStatusActive},
+		{other, domain.ProjectStatusInactive},
+	}
+	for i, s := range seed {
+		project := domain.NewProject(s.account.ID, fmt.Sprintf("Project %d", i), "")
+		project.Status = s.status
+		if err := projectRepo.Create(ctx, project); err != nil {
+			t.Fatalf("❌ プロジェクト作成に失敗: %v", err)
+		}
+	}
+
+	list := func(t *testing.T, query string) api.ProjectList {
+		t.Helper()
+		resp, body := sendAsRole(t, srv, http.MethodGet, "/api/v1/admin/projects"+query, "admin", nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var result api.ProjectList
+		if err := json.Unmarshal(body, &result); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		return result
+	}
+
+	t.Run("全アカウントのプロジェクトを取得できる", func(t *testing.T) {
+		result := list(t, "")
+		if result.Total != 5 || len(result.Items) != 5 {
+			t.Errorf("❌ 件数 期待値: 5, 実際: total=%d items=%d", result.Total, len(result.Items))
+		}
+		if result.Limit != 20 || result.Offset != 0 {
+			t.Errorf("❌ 既定のページング 期待値: limit=20 offset=0, 実際: limit=%d offset=%d", result.Limit, result.Offset)
+		}
+	})
+
+	t.Run("ページングされる", func(t *testing.T) {
+		result := list(t, "?limit=2&offset=4")
+		if result.Total != 5 {
+			t.Errorf("❌ 総数はページングの影響を受けません: %d", result.Total)
+		}
+		if len(result.Items) != 1 {
+			t.Errorf("❌ 最終ページの件数 期待値: 1, 実際: %d", len(result.Items))
+		}
+	})
+
+	t.Run("アカウントで絞り込める", func(t *testing.T) {
+		result := list(t, "?account_id="+other.ID.String())
+		if result.Total != 2 {
+			t.Errorf("❌ 件数 期待値: 2, 実際: %d", result.Total)
+		}
+		for _, p := range result.Items {
+			if p.AccountId != other.ID {
+				t.Errorf("❌ 別アカウントのプロジェクトが含まれています: %s", p.AccountId)
+			}
+		}
+	})
+
+	t.Run("ステータスとアカウントを組み合わせて絞り込める", func(t *testing.T) {
+		result := list(t, "?status=active&account_id="+owner.ID.String())
+		if result.Total != 2 {
+			t.Errorf("❌ 件数 期待値: 2, 実際: %d", result.Total)
+		}
+		for _, p := range result.Items {
+			if p.Status != api.ProjectStatusActive {
+				t.Errorf("❌ 異なるステータスが含まれています: %s", p.Status)
+			}
+		}
+	})
+
+	t.Run("不正な条件は400", func(t *testing.T) {
+		for _, query := range []string{
+			"?limit=0",
+			"?limit=101",
+			"?offset=-1",
+			"?status=deleted",
+			"?account_id=not-a-uuid",
+		} {
+			resp, body := sendAsRole(t, srv, http.MethodGet, "/api/v1/admin/projects"+query, "admin", nil)
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("❌ %s ステータスコード 期待値: 400, 実際: %d, body: %s", query, resp.StatusCode, body)
+			}
+		}
+	})
+
+	t.Run("一般ユーザーは403", func(t *testing.T) {
+		resp, body := sendAsRole(t, srv, http.MethodGet, "/api/v1/admin/projects", "user", nil)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("❌ ステータスコード 期待値: 403, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+	})
+}
+
+// TestAdminListAccountProjectCounts 管理者によるプロジェクト数付きアカウント一覧の取得をテスト
+func TestAdminListAccountProjectCounts(t *testing.T) {
+	ctx := context.Background()
+	srv, accountRepo, projectRepo := newAdminTestServer(t)
+
+	// 作成日時の新しい順: admin(0件), busy(3件), idle(0件), single(1件)
+	base := time.Now().Add(-time.Hour)
+	seed := []struct {
+		email    string
+		role     domain.Role
+		projects int
+	}{
+		{"single@example.com", domain.RoleUser, 1},
+		{"idle@example.com", domain.RoleUser, 0},
+		{"busy@example.com", domain.RoleUser, 3},
+		{"counts-admin@example.com", domain.RoleAdmin, 0},
+	}
+	expected := make(map[string]int, len(seed))
+	for i, s := range seed {
+		account := domain.NewAccount(s.email, s.email, "hash")
+		account.Role = s.role
+		account.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+		if err := accountRepo.Create(ctx, account); err != nil {
+			t.Fatalf("❌ アカウント作成に失敗: %v", err)
+		}
+		for j := 0; j < s.projects; j++ {
+			if err := projectRepo.Create(ctx, domain.NewProject(account.ID, fmt.Sprintf("%s %d", s.email, j), "")); err != nil {
+				t.Fatalf("❌ プロジェクト作成に失敗: %v", err)
+			}
+		}
+		expected[s.email] = s.projects
+	}
+
+	list := func(t *testing.T, query string) api.AccountProjectCountList {
+		t.Helper()
+		resp, body := sendAsRole(t, srv, http.MethodGet, "/api/v1/admin/accounts"+query, "admin", nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var result api.AccountProjectCountList
+		if err := json.Unmarshal(body, &result); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		return result
+	}
+
+	t.Run("プロジェクト数がシードデータと一致する", func(t *testing.T) {
+		result := list(t, "")
+		if result.Total != len(seed) || len(result.Items) != len(seed) {
+			t.Fatalf("❌ 件数 期待値: %d, 実際: total=%d, items=%d", len(seed), result.Total, len(result.Items))
+		}
+		for _, item := range result.Items {
+			email := string(item.Account.Email)
+			if item.ProjectCount != expected[email] {
+				t.Errorf("❌ %s のプロジェクト数 期待値: %d, 実際: %d", email, expected[email], item.ProjectCount)
+			}
+		}
+	})
+
+	t.Run("ページングとロールで絞り込める", func(t *testing.T) {
+		result := list(t, "?role=user&limit=2&offset=1")
+		if result.Total != 3 || result.Limit != 2 || result.Offset != 1 {
+			t.Errorf("❌ total=%d limit=%d offset=%d", result.Total, result.Limit, result.Offset)
+		}
+		if len(result.Items) != 2 || result.Items[0].Account.Email != "idle@example.com" || result.Items[1].Account.Email != "single@example.com" {
+			t.Errorf("❌ 作成日時の新しい順に2件目から取得されていません: %+v", result.Items)
+		}
+		if result.Items[0].ProjectCount != 0 {
+			t.Errorf("❌ プロジェクトの無いアカウントは0件であるべきです: %d", result.Items[0].ProjectCount)
+		}
+	})
+
+	t.Run("不正なパラメータは400", func(t *testing.T) {
+		for _, query := range []string{"?limit=0", "?limit=101", "?offset=-1", "?role=owner"} {
+			resp, body := sendAsRole(t, srv, http.MethodGet, "/api/v1/admin/accounts"+query, "admin", nil)
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("❌ %s: ステータスコード 期待値: 400, 実際: %d, body: %s", query, resp.StatusCode, body)
+			}
+		}
+	})
+
+	t.Run("一般ユーザーは403", func(t *testing.T) {
+		resp, body := sendAsRole(t, srv, http.MethodGet, "/api/v1/admin/accounts", "user", nil)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("❌ ステータスコード 期待値: 403, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+	})
+}
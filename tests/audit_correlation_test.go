@@ -0,0 +1,96 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/handler"
+	"github.com/aida0710/jwt-auth/internal/logger"
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+)
+
+// TestAuditCorrelation_Metadata リクエストIDとトレースIDが監査ログのメタデータに記録されることをテスト
+func TestAuditCorrelation_Metadata(t *testing.T) {
+	ctx := context.Background()
+	authUsecase, _, auditRepo := newTestAuthUsecaseWithConfig(t, usecase.AuthConfig{})
+
+	server := handler.NewServer(nil, nil, handler.NewAuthHandler(authUsecase), handler.HealthConfig{}, handler.PrivacyConfig{}, logger.NewLoggerWithOutput("error", "json", io.Discard))
+	e := echo.New()
+	e.Use(echomiddleware.RequestID())
+	e.Use(middleware.Correlation)
+	api.RegisterHandlersWithBaseURL(e, server, "/api/v1")
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	// 再利用を検出させ、そのリクエストの相関IDが記録されたメタデータを返す
+	replay := func(t *testing.T, email string, headers map[string]string) map[string]interface{} {
+		t.Helper()
+		tokens, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+			Email:    email,
+			Password: "SecurePassword123!",
+			Name:     "Correlation User",
+		})
+		if err != nil {
+			t.Fatalf("❌ サインアップに失敗: %v", err)
+		}
+
+		body := api.RefreshTokenRequest{RefreshToken: tokens.RefreshToken}
+		if resp, respBody := sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/refresh", nil, body); resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ リフレッシュに失敗: %d, body: %s", resp.StatusCode, respBody)
+		}
+		resp, respBody := sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/refresh", headers, body)
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("❌ ステータスコード 期待値: 401, 実際: %d, body: %s", resp.StatusCode, respBody)
+		}
+
+		logs, _ := auditRepo.GetByEventType(ctx, domain.EventTokenReuseDetected, 1, 0)
+		if len(logs) != 1 || logs[0].AccountID != tokens.Account.ID {
+			t.Fatalf("❌ 再利用の監査ログが記録されていません: %+v", logs)
+		}
+		var metadata map[string]interface{}
+		if err := json.Unmarshal(logs[0].Metadata, &metadata); err != nil {
+			t.Fatalf("❌ メタデータのパースに失敗: %v", err)
+		}
+		return metadata
+	}
+
+	t.Run("traceparentのトレースIDとリクエストIDを記録する", func(t *testing.T) {
+		metadata := replay(t, "traced@example.com", map[string]string{
+			echo.HeaderXRequestID:        "req-replay-0001",
+			middleware.HeaderTraceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		})
+
+		expected := map[string]string{
+			"request_id": "req-replay-0001",
+			"trace_id":   "4bf92f3577b34da6a3ce929d0e0e4736",
+			"span_id":    "00f067aa0ba902b7",
+		}
+		for key, value := range expected {
+			if metadata[key] != value {
+				t.Errorf("❌ %s 期待値: %s, 実際: %v", key, value, metadata[key])
+			}
+		}
+	})
+
+	t.Run("不正なtraceparentは無視し、生成したリクエストIDを記録する", func(t *testing.T) {
+		metadata := replay(t, "untraced@example.com", map[string]string{
+			middleware.HeaderTraceparent: "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		})
+
+		if requestID, _ := metadata["request_id"].(string); requestID == "" {
+			t.Errorf("❌ request_idが記録されていません: %v", metadata)
+		}
+		if _, ok := metadata["trace_id"]; ok {
+			t.Errorf("❌ 無効なtrace_idが記録されました: %v", metadata["trace_id"])
+		}
+	})
+}
@@ -0,0 +1,182 @@
+package tests_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/handler"
+	"github.com/aida0710/jwt-auth/internal/logger"
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// newAuthTestServer 認証ユースケースを組み込んだテスト用サーバーを作成
+func newAuthTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	authUsecase, _, _ := newTestAuthUsecase(t)
+	return newAuthTestServerWithUsecase(t, authUsecase)
+}
+
+// newAuthTestServerWithUsecase 指定した認証ユースケースでテスト用サーバーを作成
+// X-Test-Account / X-Test-Role ヘッダーの値を認証済みのアカウントID・ロールとして扱う
+func newAuthTestServerWithUsecase(t *testing.T, authUsecase *usecase.AuthUsecase) *httptest.Server {
+	t.Helper()
+
+	server := handler.NewServer(nil, nil, handler.NewAuthHandler(authUsecase), handler.HealthConfig{}, handler.PrivacyConfig{}, logger.NewLoggerWithOutput("error", "json", io.Discard))
+
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if accountID := c.Request().Header.Get("X-Test-Account"); accountID != "" {
+				c.Set(string(middleware.AccountIDKey), accountID)
+			}
+			if role := c.Request().Header.Get("X-Test-Role"); role != "" {
+				c.Set(string(middleware.RoleKey), role)
+			}
+			return next(c)
+		}
+	})
+	api.RegisterHandlersWithBaseURL(e, server, "/api/v1")
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// TestAuthResponse_RoleAndPermissions 認証レスポンスにロールと権限が含まれることをテスト
+func TestAuthResponse_RoleAndPermissions(t *testing.T) {
+	srv := newAuthTestServer(t)
+	credentials := map[string]string{
+		"email":    "role@example.com",
+		"password": "SecurePassword123!",
+		"name":     "Role User",
+	}
+
+	decodeAuth := func(t *testing.T, resp *http.Response, body []byte, expectedStatus int) api.AuthResponse {
+		t.Helper()
+		if resp.StatusCode != expectedStatus {
+			t.Fatalf("❌ ステータスコード 期待値: %d, 実際: %d, body: %s", expectedStatus, resp.StatusCode, body)
+		}
+		var authResp api.AuthResponse
+		if err := json.Unmarshal(body, &authResp); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		return authResp
+	}
+
+	var accountID string
+
+	t.Run("サインアップのレスポンスにロールと権限が含まれる", func(t *testing.T) {
+		resp, body := sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/signup", nil, credentials)
+		authResp := decodeAuth(t, resp, body, http.StatusCreated)
+		accountID = authResp.Account.Id.String()
+
+		if authResp.Account.Role != api.AccountRoleUser {
+			t.Errorf("❌ ロール 期待値: user, 実際: %s", authResp.Account.Role)
+		}
+		assertPermissions(t, authResp.Account, domain.RoleUser)
+	})
+
+	t.Run("ログインのレスポンスにロールと権限が含まれる", func(t *testing.T) {
+		resp, body := sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/login", nil, map[string]string{
+			"email":    credentials["email"],
+			"password": credentials["password"],
+		})
+		authResp := decodeAuth(t, resp, body, http.StatusOK)
+
+		if authResp.Account.Role != api.AccountRoleUser {
+			t.Errorf("❌ ロール 期待値: user, 実際: %s", authResp.Account.Role)
+		}
+		assertPermissions(t, authResp.Account, domain.RoleUser)
+	})
+
+	t.Run("/auth/meで認証済みアカウントを取得できる", func(t *testing.T) {
+		resp, body := sendTestRequest(t, srv, http.MethodGet, "/api/v1/auth/me", map[string]string{"X-Test-Account": accountID}, nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var account api.Account
+		if err := json.Unmarshal(body, &account); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if account.Id.String() != accountID {
+			t.Errorf("❌ アカウントID 期待値: %s, 実際: %s", accountID, account.Id)
+		}
+		assertPermissions(t, account, domain.RoleUser)
+	})
+
+	t.Run("/auth/meは未認証なら401", func(t *testing.T) {
+		resp, body := sendTestRequest(t, srv, http.MethodGet, "/api/v1/auth/me", nil, nil)
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("❌ ステータスコード 期待値: 401, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+	})
+
+	t.Run("/auth/meは削除済みアカウントなら401", func(t *testing.T) {
+		resp, body := sendTestRequest(t, srv, http.MethodGet, "/api/v1/auth/me", map[string]string{"X-Test-Account": domain.NewID().String()}, nil)
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("❌ ステータスコード 期待値: 401, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+	})
+}
+
+// TestRolePermissions ロールごとの権限をテスト
+func TestRolePermissions(t *testing.T) {
+	admin := domain.RoleAdmin.Permissions()
+	user := domain.RoleUser.Permissions()
+
+	if len(admin) <= len(user) {
+		t.Errorf("❌ 管理者の権限が一般ユーザー以下です: admin=%v, user=%v", admin, user)
+	}
+	for _, p := range user {
+		if !containsPermission(admin, p) {
+			t.Errorf("❌ 管理者に一般ユーザーの権限 %s がありません", p)
+		}
+	}
+	if containsPermission(user, domain.PermissionAccountCreate) {
+		t.Error("❌ 一般ユーザーに管理者権限が付与されています")
+	}
+	if len(domain.Role("owner").Permissions()) != 0 {
+		t.Error("❌ 未定義のロールに権限が付与されています")
+	}
+
+	// 返却されたスライスを変更しても定義に影響しない
+	user[0] = "tampered"
+	if domain.RoleUser.Permissions()[0] == "tampered" {
+		t.Error("❌ 権限の定義が呼び出し側から変更できます")
+	}
+}
+
+// assertPermissions APIレスポンスの権限がロールの定義と一致するか検証
+func assertPermissions(t *testing.T, account api.Account, role domain.Role) {
+	t.Helper()
+	if account.Permissions == nil {
+		t.Fatal("❌ 権限がレスポンスに含まれていません")
+	}
+	expected := role.Permissions()
+	if len(*account.Permissions) != len(expected) {
+		t.Fatalf("❌ 権限数 期待値: %d, 実際: %d", len(expected), len(*account.Permissions))
+	}
+	for i, p := range expected {
+		if (*account.Permissions)[i] != string(p) {
+			t.Errorf("❌ 権限 期待値: %s, 実際: %s", p, (*account.Permissions)[i])
+		}
+	}
+}
+
+// containsPermission 権限の一覧に指定の権限が含まれるか判定
+func containsPermission(permissions []domain.Permission, target domain.Permission) bool {
+	for _, p := range permissions {
+		if p == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package tests_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/buildinfo"
+	"github.com/aida0710/jwt-auth/internal/handler"
+	"github.com/aida0710/jwt-auth/internal/logger"
+	"github.com/labstack/echo/v4"
+)
+
+// TestGetInfo ビルド情報エンドポイントのレスポンスをテスト
+func TestGetInfo(t *testing.T) {
+	// ldflagsでの埋め込みを再現
+	originalVersion, originalCommit := buildinfo.Version, buildinfo.Commit
+	buildinfo.Version, buildinfo.Commit = "1.2.3", "abc1234"
+	t.Cleanup(func() { buildinfo.Version, buildinfo.Commit = originalVersion, originalCommit })
+
+	e := echo.New()
+	api.RegisterHandlersWithBaseURL(e, handler.NewServer(nil, nil, nil, handler.HealthConfig{}, handler.PrivacyConfig{}, logger.NewLoggerWithOutput("error", "json", io.Discard)), "/api/v1")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/info", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d", rec.Code)
+	}
+
+	var info api.BuildInfo
+	if err := json.Unmarshal(rec.Body.Bytes(), &info); err != nil {
+		t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+	}
+	if info.Version != "1.2.3" || info.Commit != "abc1234" {
+		t.Errorf("❌ バージョン情報が埋め込んだ値と異なります: %+v", info)
+	}
+	if info.GoVersion != runtime.Version() {
+		t.Errorf("❌ Goバージョン 期待値: %s, 実際: %s", runtime.Version(), info.GoVersion)
+	}
+	if info.PasswordHashing.Algorithm != auth.PasswordHashAlgorithm || info.PasswordHashing.Cost != auth.PasswordHashCost {
+		t.Errorf("❌ パスワードハッシュの設定が異なります: %+v", info.PasswordHashing)
+	}
+
+	// 定義された項目以外（秘密情報など）を含まない
+	var raw map[string]interface{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &raw)
+	for key := range raw {
+		switch key {
+		case "version", "commit", "go_version", "password_hashing":
+		default:
+			t.Errorf("❌ 想定外の項目が含まれています: %s", key)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package tests_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// TestCompression_Threshold 小さなレスポンスと圧縮済みの形式は圧縮せず、大きなレスポンスのみ圧縮することをテスト
+func TestCompression_Threshold(t *testing.T) {
+	large := strings.Repeat("a", 4096)
+
+	e := echo.New()
+	e.Use(middleware.NewCompressionMiddleware(middleware.CompressionConfig{
+		Level:            -1,
+		MinLength:        1024,
+		SkipContentTypes: []string{"image/", "application/zip"},
+	}))
+	e.GET("/small", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+	})
+	e.GET("/large", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{"data": large})
+	})
+	e.GET("/image", func(c echo.Context) error {
+		return c.Blob(http.StatusOK, "image/png", []byte(large))
+	})
+
+	get := func(t *testing.T, path, acceptEncoding string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if acceptEncoding != "" {
+			req.Header.Set(echo.HeaderAcceptEncoding, acceptEncoding)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("❌ %s: ステータスコード 期待値: 200, 実際: %d", path, rec.Code)
+		}
+		return rec
+	}
+
+	t.Run("小さなレスポンスは圧縮しない", func(t *testing.T) {
+		rec := get(t, "/small", "gzip")
+		if enc := rec.Header().Get(echo.HeaderContentEncoding); enc != "" {
+			t.Errorf("❌ Content-Encoding 期待値: なし, 実際: %s", enc)
+		}
+		if !strings.Contains(rec.Body.String(), `"status":"ok"`) {
+			t.Errorf("❌ ボディがそのまま返されていません: %s", rec.Body.String())
+		}
+	})
+
+	t.Run("大きなレスポンスは圧縮する", func(t *testing.T) {
+		rec := get(t, "/large", "gzip")
+		if enc := rec.Header().Get(echo.HeaderContentEncoding); enc != "gzip" {
+			t.Fatalf("❌ Content-Encoding 期待値: gzip, 実際: %q", enc)
+		}
+		reader, err := gzip.NewReader(rec.Body)
+		if err != nil {
+			t.Fatalf("❌ gzipの展開に失敗: %v", err)
+		}
+		body, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("❌ gzipの展開に失敗: %v", err)
+		}
+		if !bytes.Contains(body, []byte(large)) {
+			t.Error("❌ 展開したボディが元のレスポンスと一致しません")
+		}
+	})
+
+	t.Run("圧縮済みの形式は圧縮しない", func(t *testing.T) {
+		rec := get(t, "/image", "gzip")
+		if enc := rec.Header().Get(echo.HeaderContentEncoding); enc != "" {
+			t.Errorf("❌ Content-Encoding 期待値: なし, 実際: %s", enc)
+		}
+		if rec.Body.String() != large {
+			t.Error("❌ ボディがそのまま返されていません")
+		}
+	})
+
+	t.Run("gzipを受け付けないクライアントには圧縮しない", func(t *testing.T) {
+		rec := get(t, "/large", "")
+		if enc := rec.Header().Get(echo.HeaderContentEncoding); enc != "" {
+			t.Errorf("❌ Content-Encoding 期待値: なし, 実際: %s", enc)
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+)
+
+// TestGetCurrentSession 現在のセッション情報の取得をテスト
+func TestGetCurrentSession(t *testing.T) {
+	ctx := context.Background()
+	authUsecase, _, _ := newTestAuthUsecase(t)
+	srv := newAuthTestServerWithUsecase(t, authUsecase)
+
+	if _, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+		Email:    "current-session@example.com",
+		Password: "SecurePassword123!",
+		Name:     "Current Session",
+	}); err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+	session, err := authUsecase.Login(ctx, usecase.LoginInput{
+		Email:     "current-session@example.com",
+		Password:  "SecurePassword123!",
+		UserAgent: "session-test/1.0",
+		IPAddress: "203.0.113.10",
+	})
+	if err != nil {
+		t.Fatalf("❌ ログインに失敗: %v", err)
+	}
+	accountID := session.Account.ID.String()
+
+	getSession := func(t *testing.T, accountID, refreshToken string) (*http.Response, []byte) {
+		t.Helper()
+		headers := map[string]string{"X-Test-Account": accountID}
+		if refreshToken != "" {
+			headers["X-Refresh-Token"] = refreshToken
+		}
+		return sendTestRequest(t, srv, http.MethodGet, "/api/v1/auth/session/current", headers, nil)
+	}
+	assertSessionNotFound := func(t *testing.T, resp *http.Response, body []byte) {
+		t.Helper()
+		if resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("❌ ステータスコード 期待値: 404, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var apiErr api.Error
+		if err := json.Unmarshal(body, &apiErr); err != nil || apiErr.Code != api.ErrorCodeSessionNotFound {
+			t.Errorf("❌ エラーコード 期待値: session_not_found, body: %s", body)
+		}
+	}
+
+	t.Run("有効なセッションの情報を返す", func(t *testing.T) {
+		resp, body := getSession(t, accountID, session.RefreshToken)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+
+		var info api.SessionInfo
+		if err := json.Unmarshal(body, &info); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if info.UserAgent == nil || *info.UserAgent != "session-test/1.0" {
+			t.Errorf("❌ user_agent 期待値: session-test/1.0, 実際: %v", info.UserAgent)
+		}
+		if info.IpAddress == nil || *info.IpAddress != "203.0.113.10" {
+			t.Errorf("❌ ip_address 期待値: 203.0.113.10, 実際: %v", info.IpAddress)
+		}
+		if info.CreatedAt.IsZero() || !info.ExpiresAt.After(info.CreatedAt) {
+			t.Errorf("❌ 作成日時・有効期限が不正です: %+v", info)
+		}
+		if info.LastUsedAt != nil {
+			t.Errorf("❌ 未使用のセッションにlast_used_atが設定されています: %v", info.LastUsedAt)
+		}
+
+		// トークンとハッシュはレスポンスに含めない
+		for _, secret := range []string{session.RefreshToken, auth.HashToken(session.RefreshToken)} {
+			if strings.Contains(string(body), secret) {
+				t.Errorf("❌ レスポンスにトークンまたはハッシュが含まれています: %s", body)
+			}
+		}
+	})
+
+	t.Run("他のアカウントのセッションは404", func(t *testing.T) {
+		resp, body := getSession(t, domain.NewID().String(), session.RefreshToken)
+		assertSessionNotFound(t, resp, body)
+	})
+
+	t.Run("リフレッシュトークンが無い場合は400", func(t *testing.T) {
+		resp, body := getSession(t, accountID, "")
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("❌ ステータスコード 期待値: 400, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+	})
+
+	t.Run("無効化したセッションは404", func(t *testing.T) {
+		if err := authUsecase.Logout(ctx, session.RefreshToken); err != nil {
+			t.Fatalf("❌ ログアウトに失敗: %v", err)
+		}
+		resp, body := getSession(t, accountID, session.RefreshToken)
+		assertSessionNotFound(t, resp, body)
+	})
+}
@@ -0,0 +1,159 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+)
+
+// TestListProjects_Cursor カーソルで前後のページを辿れることをテスト
+func TestListProjects_Cursor(t *testing.T) {
+	ctx := context.Background()
+	srv, accountRepo, projectRepo := newAdminTestServer(t)
+
+	owner := domain.NewAccount("cursor@example.com", "Cursor", "hash")
+	if err := accountRepo.Create(ctx, owner); err != nil {
+		t.Fatalf("❌ アカウント作成に失敗: %v", err)
+	}
+
+	// UUID v7のIDは作成順に大きくなるため、新しい順は Project 4 → Project 0
+	const projectCount = 5
+	for i := 0; i < projectCount; i++ {
+		if err := projectRepo.Create(ctx, domain.NewProject(owner.ID, fmt.Sprintf("Project %d", i), "")); err != nil {
+			t.Fatalf("❌ プロジェクト作成に失敗: %v", err)
+		}
+	}
+
+	path := "/api/v1/accounts/" + owner.ID.String() + "/projects"
+	list := func(t *testing.T, query string) api.ProjectList {
+		t.Helper()
+		resp, body := sendAsAccount(t, srv, http.MethodGet, path+query, owner.ID, nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ %s: ステータスコード 期待値: 200, 実際: %d, body: %s", query, resp.StatusCode, body)
+		}
+		var result api.ProjectList
+		if err := json.Unmarshal(body, &result); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if result.Total != projectCount {
+			t.Errorf("❌ %s: total 期待値: %d, 実際: %d", query, projectCount, result.Total)
+		}
+		return result
+	}
+	assertNames := func(t *testing.T, result api.ProjectList, want ...string) {
+		t.Helper()
+		names := make([]string, 0, len(result.Items))
+		for _, p := range result.Items {
+			names = append(names, p.Name)
+		}
+		if fmt.Sprint(names) != fmt.Sprint(want) {
+			t.Errorf("❌ 項目 期待値: %v, 実際: %v", want, names)
+		}
+	}
+
+	// 前方に辿り、最後のページから後方に戻る
+	first := list(t, "?limit=2")
+	assertNames(t, first, "Project 4", "Project 3")
+	if first.NextCursor == nil || first.PrevCursor != nil {
+		t.Fatalf("❌ 最初のページのカーソル next=%v prev=%v", first.NextCursor, first.PrevCursor)
+	}
+
+	second := list(t, "?limit=2&after="+url.QueryEscape(*first.NextCursor))
+	assertNames(t, second, "Project 2", "Project 1")
+	if second.NextCursor == nil || second.PrevCursor == nil {
+		t.Fatalf("❌ 2ページ目のカーソル next=%v prev=%v", second.NextCursor, second.PrevCursor)
+	}
+
+	last := list(t, "?limit=2&after="+url.QueryEscape(*second.NextCursor))
+	assertNames(t, last, "Project 0")
+	if last.NextCursor != nil || last.PrevCursor == nil {
+		t.Fatalf("❌ 最後のページのカーソル next=%v prev=%v", last.NextCursor, last.PrevCursor)
+	}
+
+	back := list(t, "?limit=2&before="+url.QueryEscape(*last.PrevCursor))
+	assertNames(t, back, "Project 2", "Project 1")
+	if back.PrevCursor == nil || back.NextCursor == nil {
+		t.Fatalf("❌ 戻った2ページ目のカーソル next=%v prev=%v", back.NextCursor, back.PrevCursor)
+	}
+
+	top := list(t, "?limit=2&before="+url.QueryEscape(*back.PrevCursor))
+	assertNames(t, top, "Project 4", "Project 3")
+	if top.PrevCursor != nil {
+		t.Errorf("❌ 先頭のページに前のカーソルがあります: %s", *top.PrevCursor)
+	}
+
+	t.Run("不正なカーソル指定は400", func(t *testing.T) {
+		cursor := url.QueryEscape(*first.NextCursor)
+		for _, query := range []string{
+			"?after=" + cursor + "&before=" + cursor,
+			"?after=" + cursor + "&offset=2",
+			"?after=not-a-cursor",
+		} {
+			resp, body := sendAsAccount(t, srv, http.MethodGet, path+query, owner.ID, nil)
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("❌ %s: ステータスコード 期待値: 400, 実際: %d, body: %s", query, resp.StatusCode, body)
+			}
+		}
+	})
+}
+
+// TestListAccountProjectCounts_Cursor 管理者向けアカウント一覧をカーソルで前後に辿れることをテスト
+func TestListAccountProjectCounts_Cursor(t *testing.T) {
+	ctx := context.Background()
+	srv, accountRepo, _ := newAdminTestServer(t)
+
+	const accountCount = 3
+	for i := 0; i < accountCount; i++ {
+		if err := accountRepo.Create(ctx, domain.NewAccount(fmt.Sprintf("cursor%d@example.com", i), "Cursor", "hash")); err != nil {
+			t.Fatalf("❌ アカウント作成に失敗: %v", err)
+		}
+	}
+
+	list := func(t *testing.T, query string) api.AccountProjectCountList {
+		t.Helper()
+		resp, body := sendAsRole(t, srv, http.MethodGet, "/api/v1/admin/accounts"+query, "admin", nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ %s: ステータスコード 期待値: 200, 実際: %d, body: %s", query, resp.StatusCode, body)
+		}
+		var result api.AccountProjectCountList
+		if err := json.Unmarshal(body, &result); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		return result
+	}
+
+	var emails []string
+	query := "?limit=1"
+	for page := 0; ; page++ {
+		if page > accountCount {
+			t.Fatal("❌ 次のページが終わりません")
+		}
+		result := list(t, query)
+		for _, item := range result.Items {
+			emails = append(emails, string(item.Account.Email))
+		}
+		if result.NextCursor == nil {
+			break
+		}
+		query = "?limit=1&after=" + url.QueryEscape(*result.NextCursor)
+	}
+	if want := "[cursor2@example.com cursor1@example.com cursor0@example.com]"; fmt.Sprint(emails) != want {
+		t.Fatalf("❌ 前方の順序 期待値: %s, 実際: %v", want, emails)
+	}
+
+	// 最後のページから前のカーソルで先頭まで戻る
+	last := list(t, query)
+	result := list(t, "?limit=2&before="+url.QueryEscape(*last.PrevCursor))
+	if len(result.Items) != 2 || result.Items[0].Account.Email != "cursor2@example.com" || result.Items[1].Account.Email != "cursor1@example.com" {
+		t.Errorf("❌ 後方のページが新しい順になっていません: %+v", result.Items)
+	}
+	if result.PrevCursor != nil {
+		t.Errorf("❌ 先頭のページに前のカーソルがあります: %s", *result.PrevCursor)
+	}
+}
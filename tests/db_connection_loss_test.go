@@ -0,0 +1,112 @@
+package tests_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/handler"
+	"github.com/aida0710/jwt-auth/internal/infrastructure/database"
+	"github.com/aida0710/jwt-auth/internal/logger"
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/aida0710/jwt-auth/internal/repository"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/labstack/echo/v4"
+)
+
+// lostConnector 接続のたびにdriver.ErrBadConnを返し、データベースとの接続が失われた状態を再現するConnector
+type lostConnector struct{}
+
+func (lostConnector) Connect(context.Context) (driver.Conn, error) { return nil, driver.ErrBadConn }
+func (lostConnector) Driver() driver.Driver                        { return lostDriver{} }
+
+type lostDriver struct{}
+
+func (lostDriver) Open(string) (driver.Conn, error) { return nil, driver.ErrBadConn }
+
+// openLostDB 接続できないデータベースを開く
+func openLostDB(t *testing.T) *database.DB {
+	t.Helper()
+	db := sqlx.NewDb(sql.OpenDB(lostConnector{}), "mysql")
+	t.Cleanup(func() { db.Close() })
+	return database.NewDB(db, 0)
+}
+
+// TestDBConnectionLoss データベースとの接続が失われた場合にErrServiceUnavailable（503）として扱うことをテスト
+func TestDBConnectionLoss(t *testing.T) {
+	db := openLostDB(t)
+	accountRepo := repository.NewAccountRepository(db)
+
+	t.Run("接続エラーはErrServiceUnavailableでラップする", func(t *testing.T) {
+		_, err := accountRepo.GetByID(context.Background(), uuid.Must(uuid.NewV7()))
+		if !errors.Is(err, domain.ErrServiceUnavailable) {
+			t.Fatalf("❌ ErrServiceUnavailableを期待しましたが %v", err)
+		}
+		if errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("❌ 接続エラーを「見つからない」として扱っています: %v", err)
+		}
+	})
+
+	t.Run("トランザクションの開始に失敗した場合もErrServiceUnavailable", func(t *testing.T) {
+		err := database.NewTransactionManager(db.DB).RunInTransaction(context.Background(), func(context.Context) error { return nil })
+		if !errors.Is(err, domain.ErrServiceUnavailable) {
+			t.Errorf("❌ ErrServiceUnavailableを期待しましたが %v", err)
+		}
+	})
+
+	t.Run("接続以外のエラーはラップしない", func(t *testing.T) {
+		for _, err := range []error{
+			sql.ErrNoRows,
+			errors.New("syntax error"),
+			context.DeadlineExceeded,
+			context.Canceled,
+			fmt.Errorf("%w: %w", database.ErrQueryTimeout, context.DeadlineExceeded),
+		} {
+			if database.IsConnectionError(err) {
+				t.Errorf("❌ 接続エラーとして判定されました: %v", err)
+			}
+		}
+	})
+
+	t.Run("APIは503とRetry-Afterを返し、ドライバーのエラーを含めない", func(t *testing.T) {
+		log := logger.NewLoggerWithOutput("error", "json", io.Discard)
+		accountUsecase := usecase.NewAccountUsecase(accountRepo, repository.NewProjectRepository(db), nil, nil, nil, nil, fakeTxManager{}, nil, usecase.AccountConfig{})
+		server := handler.NewServer(accountUsecase, nil, nil, handler.HealthConfig{}, handler.PrivacyConfig{}, log)
+
+		e := echo.New()
+		e.HTTPErrorHandler = middleware.NewErrorHandler(log).HTTPErrorHandler
+		api.RegisterHandlersWithBaseURL(e, server, "/api/v1")
+		srv := httptest.NewServer(e)
+		t.Cleanup(srv.Close)
+
+		resp, body := sendTestRequest(t, srv, http.MethodGet, "/api/v1/accounts/"+uuid.Must(uuid.NewV7()).String(), nil, nil)
+		if resp.StatusCode != http.StatusServiceUnavailable {
+			t.Fatalf("❌ ステータスコード 期待値: 503, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		if resp.Header.Get("Retry-After") == "" {
+			t.Error("❌ Retry-Afterヘッダーがありません")
+		}
+		var got api.Error
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if got.Code != api.ErrorCodeServiceUnavailable || got.RetryAfterSeconds == nil {
+			t.Errorf("❌ code 期待値: service_unavailable（retry_after_seconds付き）, 実際: %s, %v", got.Code, got.RetryAfterSeconds)
+		}
+		if strings.Contains(string(body), driver.ErrBadConn.Error()) {
+			t.Errorf("❌ レスポンスにドライバーのエラーが含まれています: %s", body)
+		}
+	})
+}
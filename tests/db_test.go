@@ -0,0 +1,45 @@
+package tests_test
+
+import (
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/infrastructure/database"
+	"github.com/jmoiron/sqlx"
+)
+
+// getTestEnv 環境変数を取得し、存在しない場合はデフォルト値を返す
+func getTestEnv(key, defaultValue string) string {
+	if value, exists := os.LookupEnv(key); exists {
+		return value
+	}
+	return defaultValue
+}
+
+// openTestDB テスト用のデータベース接続を開く
+// データベースに接続できない環境ではテストをスキップする
+func openTestDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	port, err := strconv.Atoi(getTestEnv("DB_PORT", "3306"))
+	if err != nil {
+		t.Fatalf("DB_PORTが不正です: %v", err)
+	}
+
+	db, err := database.NewMySQLConnection(&database.Config{
+		Host:     getTestEnv("DB_HOST", "localhost"),
+		Port:     port,
+		User:     getTestEnv("DB_USER", "root"),
+		Password: getTestEnv("DB_PASSWORD", "password"),
+		Database: getTestEnv("DB_NAME", "jwt_auth"),
+	})
+	if err != nil {
+		t.Skipf("データベースに接続できないためスキップします: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	return db
+}
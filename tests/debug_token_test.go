@@ -0,0 +1,107 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/cli"
+	"github.com/aida0710/jwt-auth/internal/config"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+)
+
+// TestTokenCommand tokenサブコマンドで発行したトークンが検証でき、本番環境では拒否されることをテスト
+func TestTokenCommand(t *testing.T) {
+	ctx := context.Background()
+	authUsecase, _, auditRepo := newTestAuthUsecase(t)
+	tokens, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+		Email:    "debug-token@example.com",
+		Password: "SecurePassword123!",
+		Name:     "Debug Token User",
+	})
+	if err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+	account := tokens.Account
+
+	// newTestAuthUsecaseと同じシークレットで検証する
+	jwtManager := auth.NewJWTManager(auth.JWTConfig{
+		AccessTokenSecret:  "test-access-secret-0123456789abcdef",
+		RefreshTokenSecret: "test-refresh-secret-0123456789abcdef",
+		AccessTokenExpiry:  time.Minute,
+		RefreshTokenExpiry: time.Hour,
+		Issuer:             "jwt-auth-test",
+		Audience:           []string{"jwt-auth-test"},
+	})
+	cfg := &config.Config{Env: "development", JWT: config.JWTConfig{AccessTokenExpiry: time.Minute}}
+
+	run := func(cfg *config.Config, args ...string) (string, string, error) {
+		var stdout, stderr bytes.Buffer
+		err := cli.RunToken(ctx, cfg, authUsecase, args, &stdout, &stderr)
+		return stdout.String(), stderr.String(), err
+	}
+
+	for _, args := range [][]string{
+		{"-email", account.Email},
+		{"-account-id", account.ID.String()},
+	} {
+		t.Run("発行したトークンを検証できる "+args[0], func(t *testing.T) {
+			stdout, stderr, err := run(cfg, args...)
+			if err != nil {
+				t.Fatalf("❌ トークンの発行に失敗: %v", err)
+			}
+			claims, err := jwtManager.ValidateAccessToken(strings.TrimSpace(stdout))
+			if err != nil {
+				t.Fatalf("❌ 発行したトークンの検証に失敗: %v, stdout: %q", err, stdout)
+			}
+			if claims.AccountID != account.ID.String() || claims.Email != account.Email || claims.Role != string(account.Role) {
+				t.Errorf("❌ クレームが正しくありません: %+v", claims)
+			}
+			if !strings.Contains(stderr, "WARNING") {
+				t.Errorf("❌ 認証を経ない旨の警告がありません: %q", stderr)
+			}
+		})
+	}
+
+	t.Run("発行をセキュリティ監査ログに記録する", func(t *testing.T) {
+		logs, _ := auditRepo.GetByEventType(ctx, domain.EventDebugTokenIssued, 10, 0)
+		if len(logs) == 0 {
+			t.Fatal("❌ DEBUG_TOKEN_ISSUEDが記録されていません")
+		}
+		if logs[0].AccountID != account.ID {
+			t.Errorf("❌ アカウントID 期待値: %s, 実際: %s", account.ID, logs[0].AccountID)
+		}
+	})
+
+	t.Run("本番環境では拒否する", func(t *testing.T) {
+		production := &config.Config{Env: "production"}
+		stdout, _, err := run(production, "-email", account.Email)
+		if !errors.Is(err, cli.ErrTokenCommandInProduction) {
+			t.Errorf("❌ エラー 期待値: %v, 実際: %v", cli.ErrTokenCommandInProduction, err)
+		}
+		if stdout != "" {
+			t.Errorf("❌ 本番環境でトークンが出力されました: %q", stdout)
+		}
+	})
+
+	t.Run("アカウントの指定が無いか両方ある場合は拒否する", func(t *testing.T) {
+		if _, _, err := run(cfg); err == nil {
+			t.Error("❌ アカウントの指定が無いのに発行されました")
+		}
+		if _, _, err := run(cfg, "-email", account.Email, "-account-id", account.ID.String()); err == nil {
+			t.Error("❌ アカウントを両方指定したのに発行されました")
+		}
+	})
+
+	t.Run("存在しないアカウントは拒否する", func(t *testing.T) {
+		_, _, err := run(cfg, "-email", "missing@example.com")
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("❌ エラー 期待値: %v, 実際: %v", domain.ErrNotFound, err)
+		}
+	})
+}
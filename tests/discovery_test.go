@@ -0,0 +1,118 @@
+package tests_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/config"
+	"github.com/aida0710/jwt-auth/internal/handler"
+	"github.com/labstack/echo/v4"
+)
+
+// TestDiscovery_Document ディスカバリードキュメントに必須の項目と設定した署名アルゴリズム・エンドポイントが含まれることをテスト
+func TestDiscovery_Document(t *testing.T) {
+	discovery := handler.NewDiscoveryHandler(handler.DiscoveryConfig{
+		Issuer:           "https://auth.example.com",
+		IssuerURL:        "https://auth.example.com/",
+		SigningAlgorithm: "HS384",
+	})
+	e := echo.New()
+	e.GET(handler.DiscoveryPath, discovery.GetConfiguration)
+	e.GET(handler.JWKSPath, discovery.GetJWKS)
+
+	get := func(t *testing.T, path string) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", rec.Code, rec.Body.String())
+		}
+		return rec
+	}
+
+	t.Run("必須の項目を含む", func(t *testing.T) {
+		rec := get(t, handler.DiscoveryPath)
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(rec.Body.Bytes(), &fields); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		for _, field := range []string{"issuer", "jwks_uri", "token_endpoint", "response_types_supported", "subject_types_supported", "id_token_signing_alg_values_supported"} {
+			if _, ok := fields[field]; !ok {
+				t.Errorf("❌ %s がありません: %s", field, rec.Body.String())
+			}
+		}
+		if cacheControl := rec.Header().Get("Cache-Control"); !strings.Contains(cacheControl, "max-age") {
+			t.Errorf("❌ Cache-Controlが設定されていません: %q", cacheControl)
+		}
+	})
+
+	t.Run("設定した署名アルゴリズムとエンドポイントを反映する", func(t *testing.T) {
+		var document handler.DiscoveryDocument
+		if err := json.Unmarshal(get(t, handler.DiscoveryPath).Body.Bytes(), &document); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if document.Issuer != "https://auth.example.com" {
+			t.Errorf("❌ issuer 期待値: https://auth.example.com, 実際: %s", document.Issuer)
+		}
+		if len(document.IDTokenSigningAlgValuesSupported) != 1 || document.IDTokenSigningAlgValuesSupported[0] != "HS384" {
+			t.Errorf("❌ 署名アルゴリズム 期待値: [HS384], 実際: %v", document.IDTokenSigningAlgValuesSupported)
+		}
+		endpoints := map[string]string{
+			"jwks_uri":            document.JWKSURI,
+			"token_endpoint":      document.TokenEndpoint,
+			"refresh_endpoint":    document.RefreshEndpoint,
+			"revocation_endpoint": document.RevocationEndpoint,
+			"userinfo_endpoint":   document.UserinfoEndpoint,
+		}
+		want := map[string]string{
+			"jwks_uri":            "https://auth.example.com" + handler.JWKSPath,
+			"token_endpoint":      "https://auth.example.com/api/v1/auth/login",
+			"refresh_endpoint":    "https://auth.example.com/api/v1/auth/refresh",
+			"revocation_endpoint": "https://auth.example.com/api/v1/auth/logout",
+			"userinfo_endpoint":   "https://auth.example.com/api/v1/auth/me",
+		}
+		for name, url := range want {
+			if endpoints[name] != url {
+				t.Errorf("❌ %s 期待値: %s, 実際: %s", name, url, endpoints[name])
+			}
+		}
+	})
+
+	t.Run("HMACの鍵は公開しない", func(t *testing.T) {
+		rec := get(t, handler.JWKSPath)
+		if body := strings.TrimSpace(rec.Body.String()); body != `{"keys":[]}` {
+			t.Errorf("❌ JWKS 期待値: 空の一覧, 実際: %s", body)
+		}
+	})
+}
+
+// TestConfig_JWTIssuerURL JWT_ISSUER_URLに絶対URL以外を指定した場合に起動を拒否することをテスト
+func TestConfig_JWTIssuerURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		issuerURL string
+		wantErr   bool
+	}{
+		{"未設定", "", false},
+		{"httpsのURL", "https://auth.example.com", false},
+		{"パス付きのURL", "https://example.com/auth/", false},
+		{"相対パス", "/auth", true},
+		{"スキームが不正", "ftp://auth.example.com", true},
+		{"クエリ付き", "https://auth.example.com?tenant=acme", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("JWT_ISSUER_URL", tt.issuerURL)
+			t.Setenv("JWT_ACCESS_TOKEN_SECRET", "test-access-secret-0123456789abcdef")
+			t.Setenv("JWT_REFRESH_TOKEN_SECRET", "test-refresh-secret-0123456789abcdef")
+			_, err := config.LoadConfig()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("❌ エラー 期待値: %v, 実際: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
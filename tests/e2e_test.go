@@ -33,9 +33,10 @@ type RefreshRequest struct {
 }
 
 type AuthResponse struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
-	ExpiresIn    int    `json:"expires_in"`
+	AccessToken  string          `json:"access_token"`
+	RefreshToken string          `json:"refresh_token"`
+	ExpiresIn    int             `json:"expires_in"`
+	Account      AccountResponse `json:"account"`
 }
 
 type ErrorResponse struct {
@@ -132,6 +133,39 @@ func sendRequest(t *testing.T, method, url string, body interface{}, headers map
 	return resp, respBody
 }
 
+// requireServer テスト対象のサーバーが起動していない場合はテストをスキップする
+func requireServer(t *testing.T) {
+	t.Helper()
+
+	client := &http.Client{Timeout: 2 * time.Second}
+	resp, err := client.Get(baseURL + "/health")
+	if err != nil {
+		t.Skipf("サーバーに接続できないためスキップします: %v", err)
+	}
+	resp.Body.Close()
+}
+
+// signUpTestAccount テスト用のアカウントを作成してトークンを返す
+func signUpTestAccount(t *testing.T, password string) (string, AuthResponse) {
+	t.Helper()
+
+	email := fmt.Sprintf("test_%d@example.com", time.Now().UnixNano())
+	resp, body := sendRequest(t, "POST", baseURL+"/auth/signup", SignUpRequest{
+		Email:    email,
+		Password: password,
+		Name:     "Test User",
+	}, nil)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("❌ サインアップ失敗: ステータスコード %d", resp.StatusCode)
+	}
+
+	var authResp AuthResponse
+	if err := json.Unmarshal(body, &authResp); err != nil {
+		t.Fatalf("❌ レスポンスのパースに失敗: %v", err)
+	}
+	return email, authResp
+}
+
 // JSONを整形して表示
 func prettyJSON(data []byte) string {
 	var result bytes.Buffer
@@ -0,0 +1,109 @@
+package tests_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+)
+
+// tokenPattern 通知本文から確認トークンを取り出す
+var tokenPattern = regexp.MustCompile(`[0-9a-f]{64}`)
+
+// TestEmailChange_Verification メールアドレス変更の確認フローをテスト
+func TestEmailChange_Verification(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (usecase.AccountUsecase, *fakeAccountRepository, *fakeRefreshTokenRepository, *fakeNotifier, *domain.Account) {
+		t.Helper()
+		accountRepo := newFakeAccountRepository()
+		refreshTokenRepo := newFakeRefreshTokenRepository()
+		notifier := &fakeNotifier{}
+		accountUsecase := usecase.NewAccountUsecase(accountRepo, nil, refreshTokenRepo, nil, nil, nil, nil, notifier, usecase.AccountConfig{})
+
+		account := domain.NewAccount("old@example.com", "Email User", "hash")
+		if err := accountRepo.Create(ctx, account); err != nil {
+			t.Fatalf("❌ アカウント作成に失敗: %v", err)
+		}
+		session := domain.NewRefreshToken(account.ID, "session-hash", time.Now().Add(time.Hour), nil, nil)
+		if err := refreshTokenRepo.Create(ctx, session); err != nil {
+			t.Fatalf("❌ セッション作成に失敗: %v", err)
+		}
+		return accountUsecase, accountRepo, refreshTokenRepo, notifier, account
+	}
+
+	requestChange := func(t *testing.T, accountUsecase usecase.AccountUsecase, account *domain.Account) {
+		t.Helper()
+		newEmail := "new@example.com"
+		updated, err := accountUsecase.Update(ctx, account.ID, usecase.UpdateInput{Email: &newEmail})
+		if err != nil {
+			t.Fatalf("❌ メールアドレス変更の要求に失敗: %v", err)
+		}
+		if updated.Email != "old@example.com" {
+			t.Errorf("❌ 確認前にメールアドレスが変更されています: %s", updated.Email)
+		}
+		if updated.PendingEmail == nil || *updated.PendingEmail != newEmail {
+			t.Errorf("❌ 確認待ちのメールアドレスが設定されていません: %v", updated.PendingEmail)
+		}
+	}
+
+	t.Run("確認トークンで変更が確定する", func(t *testing.T) {
+		accountUsecase, accountRepo, refreshTokenRepo, notifier, account := setup(t)
+		requestChange(t, accountUsecase, account)
+
+		msg, ok := notifier.last()
+		if !ok || msg.To != "new@example.com" {
+			t.Fatalf("❌ 新しいアドレスに確認メールが送信されていません: %+v", msg)
+		}
+		token := tokenPattern.FindString(msg.Body)
+		if token == "" {
+			t.Fatalf("❌ 確認メールにトークンが含まれていません: %s", msg.Body)
+		}
+
+		// 確認前は古いアドレスのまま
+		if _, err := accountRepo.GetByEmail(ctx, "old@example.com"); err != nil {
+			t.Errorf("❌ 確認前に古いアドレスが無効になっています: %v", err)
+		}
+
+		confirmed, err := accountUsecase.ConfirmEmailChange(ctx, account.ID, token)
+		if err != nil {
+			t.Fatalf("❌ 変更の確定に失敗: %v", err)
+		}
+		if confirmed.Email != "new@example.com" || confirmed.PendingEmail != nil {
+			t.Errorf("❌ 変更が確定していません: email=%s pending=%v", confirmed.Email, confirmed.PendingEmail)
+		}
+
+		session, _ := refreshTokenRepo.GetByTokenHash(ctx, "session-hash")
+		if session.RevokedAt == nil {
+			t.Errorf("❌ メールアドレス変更後もセッションが有効です")
+		}
+
+		// 同じトークンは再利用できない
+		if _, err := accountUsecase.ConfirmEmailChange(ctx, account.ID, token); !errors.Is(err, domain.ErrInvalidVerificationToken) {
+			t.Errorf("❌ 使用済みトークン 期待値: ErrInvalidVerificationToken, 実際: %v", err)
+		}
+	})
+
+	t.Run("一致しないトークンは拒否される", func(t *testing.T) {
+		accountUsecase, accountRepo, refreshTokenRepo, _, account := setup(t)
+		requestChange(t, accountUsecase, account)
+
+		_, err := accountUsecase.ConfirmEmailChange(ctx, account.ID, "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+		if !errors.Is(err, domain.ErrInvalidVerificationToken) {
+			t.Fatalf("❌ 期待値: ErrInvalidVerificationToken, 実際: %v", err)
+		}
+
+		stored, _ := accountRepo.GetByID(ctx, account.ID)
+		if stored.Email != "old@example.com" {
+			t.Errorf("❌ 不正なトークンでメールアドレスが変更されています: %s", stored.Email)
+		}
+		session, _ := refreshTokenRepo.GetByTokenHash(ctx, "session-hash")
+		if session.RevokedAt != nil {
+			t.Errorf("❌ 不正なトークンでセッションが無効化されています")
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package tests_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+)
+
+// fakeMXResolver ドメインごとに決まった結果を返すリゾルバー
+type fakeMXResolver struct {
+	records map[string][]*net.MX
+	errs    map[string]error
+}
+
+func (r *fakeMXResolver) LookupMX(_ context.Context, name string) ([]*net.MX, error) {
+	if err, ok := r.errs[name]; ok {
+		return nil, err
+	}
+	if records, ok := r.records[name]; ok {
+		return records, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+// TestEmailDomainChecker メールドメインのブロックリストとMXレコード検査をテスト
+func TestEmailDomainChecker(t *testing.T) {
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "blocklist.txt")
+	content := "# 使い捨てメール\nmailinator.com\n\nTempMail.example\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("❌ ブロックリストの作成に失敗: %v", err)
+	}
+	blocklist, err := auth.LoadDomainBlocklist(path)
+	if err != nil {
+		t.Fatalf("❌ ブロックリストの読み込みに失敗: %v", err)
+	}
+	if len(blocklist) != 2 {
+		t.Fatalf("❌ ブロックリストの件数 期待値: 2, 実際: %d (%v)", len(blocklist), blocklist)
+	}
+
+	resolver := &fakeMXResolver{
+		records: map[string][]*net.MX{
+			"example.com": {{Host: "mx.example.com.", Pref: 10}},
+		},
+		errs: map[string]error{
+			"flaky.example": &net.DNSError{Err: "i/o timeout", Name: "flaky.example", IsTimeout: true},
+		},
+	}
+	checker := auth.NewEmailDomainChecker(auth.EmailDomainConfig{
+		Blocklist: blocklist,
+		CheckMX:   true,
+		Resolver:  resolver,
+	})
+
+	cases := []struct {
+		name  string
+		email string
+		want  error
+	}{
+		{"通常のドメイン", "user@example.com", nil},
+		{"ブロックリストのドメイン", "user@mailinator.com", domain.ErrDisallowedEmailDomain},
+		{"ブロックリストのサブドメイン", "user@x.mailinator.com", domain.ErrDisallowedEmailDomain},
+		{"大文字小文字を区別しない", "user@TEMPMAIL.example", domain.ErrDisallowedEmailDomain},
+		{"MXレコードの無いドメイン", "user@no-mx.example", domain.ErrDisallowedEmailDomain},
+		{"DNSエラーは許可（fail-open）", "user@flaky.example", nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := checker.Check(ctx, tc.email); !errors.Is(err, tc.want) {
+				t.Errorf("❌ 期待値: %v, 実際: %v", tc.want, err)
+			}
+		})
+	}
+
+	t.Run("サインアップでブロックされたドメインを拒否", func(t *testing.T) {
+		authUsecase, _, _ := newTestAuthUsecaseWithConfig(t, usecase.AuthConfig{
+			RefreshTokenExpiry: time.Hour,
+			EmailDomainChecker: checker,
+		})
+
+		_, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+			Email:    "spam@mailinator.com",
+			Password: "SecurePassword123!",
+			Name:     "Spam",
+		})
+		if !errors.Is(err, domain.ErrDisallowedEmailDomain) {
+			t.Errorf("❌ 期待値: ErrDisallowedEmailDomain, 実際: %v", err)
+		}
+	})
+}
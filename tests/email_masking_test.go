@@ -0,0 +1,112 @@
+package tests_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/handler"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+)
+
+// TestMaskEmail メールアドレスを伏せる形式をテスト
+func TestMaskEmail(t *testing.T) {
+	cases := map[string]string{
+		"john@example.com": "j***@example.com",
+		"j@example.com":    "j***@example.com",
+		"太郎@example.jp":    "太***@example.jp",
+		"a@b@example.com":  "a***@example.com",
+		"not-an-email":     "***",
+		"@example.com":     "***",
+	}
+	for email, expected := range cases {
+		if masked := domain.MaskEmail(email); masked != expected {
+			t.Errorf("❌ %s 期待値: %s, 実際: %s", email, expected, masked)
+		}
+	}
+
+	if domain.EmailMaskMode("partial").IsValid() {
+		t.Error("❌ 未定義のモードが受け付けられました")
+	}
+}
+
+// TestListAccounts_EmailMasking 設定とロールに応じてアカウント一覧のメールアドレスを伏せることをテスト
+func TestListAccounts_EmailMasking(t *testing.T) {
+	// newServer 指定した対象のメールアドレスを伏せるテスト用サーバーを作成し、アカウントを1件登録する
+	newServer := func(t *testing.T, mode domain.EmailMaskMode) (*httptest.Server, *domain.Account) {
+		t.Helper()
+		srv, accountRepo, _ := newAdminTestServerWithConfig(t, usecase.ProjectConfig{}, handler.PrivacyConfig{ListEmailMasking: mode})
+		account := domain.NewAccount("john@example.com", "John", "hash")
+		if err := accountRepo.Create(t.Context(), account); err != nil {
+			t.Fatalf("❌ アカウント作成に失敗: %v", err)
+		}
+		return srv, account
+	}
+
+	listEmail := func(t *testing.T, srv *httptest.Server, role domain.Role) string {
+		t.Helper()
+		resp, body := sendAsRole(t, srv, http.MethodGet, "/api/v1/accounts", string(role), nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var accounts []api.Account
+		if err := json.Unmarshal(body, &accounts); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if len(accounts) != 1 {
+			t.Fatalf("❌ アカウント数 期待値: 1, 実際: %d", len(accounts))
+		}
+		return string(accounts[0].Email)
+	}
+
+	t.Run("既定では伏せない", func(t *testing.T) {
+		srv, _ := newServer(t, "")
+		if email := listEmail(t, srv, domain.RoleUser); email != "john@example.com" {
+			t.Errorf("❌ メールアドレス 期待値: john@example.com, 実際: %s", email)
+		}
+	})
+
+	t.Run("unprivilegedでは権限の無いロールにのみ伏せる", func(t *testing.T) {
+		srv, _ := newServer(t, domain.EmailMaskUnprivileged)
+		if email := listEmail(t, srv, domain.RoleUser); email != "j***@example.com" {
+			t.Errorf("❌ 一般ユーザーのメールアドレス 期待値: j***@example.com, 実際: %s", email)
+		}
+		if email := listEmail(t, srv, domain.RoleAdmin); email != "john@example.com" {
+			t.Errorf("❌ 管理者のメールアドレス 期待値: john@example.com, 実際: %s", email)
+		}
+	})
+
+	t.Run("allでは管理者にも伏せ、個別の取得では完全なメールアドレスを返す", func(t *testing.T) {
+		srv, account := newServer(t, domain.EmailMaskAll)
+		if email := listEmail(t, srv, domain.RoleAdmin); email != "j***@example.com" {
+			t.Errorf("❌ 管理者のメールアドレス 期待値: j***@example.com, 実際: %s", email)
+		}
+
+		resp, body := sendAsRole(t, srv, http.MethodGet, "/api/v1/admin/accounts", string(domain.RoleAdmin), nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var counts api.AccountProjectCountList
+		if err := json.Unmarshal(body, &counts); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if len(counts.Items) != 1 || counts.Items[0].Account.Email != "j***@example.com" {
+			t.Errorf("❌ 管理者用の一覧でメールアドレスが伏せられていません: %+v", counts.Items)
+		}
+
+		resp, body = sendAsRole(t, srv, http.MethodGet, "/api/v1/accounts/"+account.ID.String(), string(domain.RoleAdmin), nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var fetched api.Account
+		if err := json.Unmarshal(body, &fetched); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if fetched.Email != "john@example.com" {
+			t.Errorf("❌ 個別の取得のメールアドレス 期待値: john@example.com, 実際: %s", fetched.Email)
+		}
+	})
+}
@@ -0,0 +1,163 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/handler"
+	"github.com/aida0710/jwt-auth/internal/logger"
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// TestErrorCode_DomainErrors ドメインのエラーごとのエラーコードをテスト
+func TestErrorCode_DomainErrors(t *testing.T) {
+	cases := []struct {
+		err  error
+		code api.ErrorCode
+	}{
+		{domain.ErrAccountNotFound, api.ErrorCodeAccountNotFound},
+		{domain.ErrProjectNotFound, api.ErrorCodeProjectNotFound},
+		{domain.ErrSessionNotFound, api.ErrorCodeSessionNotFound},
+		{domain.ErrNotFound, api.ErrorCodeNotFound},
+		{domain.ErrInvalidCredentials, api.ErrorCodeInvalidCredentials},
+		{domain.ErrAccountLocked, api.ErrorCodeAccountLocked},
+		{&domain.AccountLockedError{RetryAfter: time.Minute}, api.ErrorCodeAccountLocked},
+		{domain.ErrAccountSuspended, api.ErrorCodeAccountSuspended},
+		{domain.ErrAccountPendingDeletion, api.ErrorCodeAccountPendingDeletion},
+		{domain.ErrAccountNotPendingDeletion, api.ErrorCodeAccountNotPendingDeletion},
+		{domain.ErrIncorrectPassword, api.ErrorCodeIncorrectPassword},
+		{domain.ErrPasswordReused, api.ErrorCodePasswordReused},
+		{domain.ErrTokenCompromised, api.ErrorCodeTokenCompromised},
+		{domain.ErrTokenExpired, api.ErrorCodeTokenExpired},
+		{domain.ErrInvalidToken, api.ErrorCodeInvalidToken},
+		{domain.ErrUnauthorized, api.ErrorCodeUnauthorized},
+		{domain.ErrForbidden, api.ErrorCodeForbidden},
+		{domain.ErrEmailAlreadyExists, api.ErrorCodeEmailExists},
+		{domain.ErrDuplicateEmail, api.ErrorCodeEmailExists},
+		{domain.ErrInvalidEmail, api.ErrorCodeInvalidEmail},
+		{domain.ErrDisallowedEmailDomain, api.ErrorCodeEmailDomainNotAllowed},
+		{domain.ErrSignupDisabled, api.ErrorCodeSignupDisabled},
+		{domain.ErrInvalidInvite, api.ErrorCodeInvalidInvite},
+		{domain.ErrInvalidVerificationToken, api.ErrorCodeInvalidVerificationToken},
+		{domain.ErrInvalidName, api.ErrorCodeInvalidName},
+		{domain.ErrInvalidDisplayName, api.ErrorCodeInvalidProfile},
+		{domain.ErrInvalidAvatarURL, api.ErrorCodeInvalidProfile},
+		{domain.ErrInvalidLocale, api.ErrorCodeInvalidProfile},
+		{domain.ErrInvalidTimezone, api.ErrorCodeInvalidProfile},
+		{domain.ErrInvalidRole, api.ErrorCodeInvalidRole},
+		{domain.ErrInvalidStatus, api.ErrorCodeInvalidStatus},
+		{domain.ErrInvalidAccountStatus, api.ErrorCodeInvalidStatus},
+		{domain.ErrProjectLimitExceeded, api.ErrorCodeProjectLimitExceeded},
+		{domain.ErrDuplicateProjectName, api.ErrorCodeProjectNameExists},
+		{domain.ErrInvalidID, api.ErrorCodeInvalidId},
+		{domain.ErrInvalidAccountID, api.ErrorCodeInvalidId},
+		{domain.ErrInvalidPagination, api.ErrorCodeInvalidPagination},
+		{domain.ErrInvalidSearch, api.ErrorCodeInvalidRequest},
+		{domain.ErrDuplicateToken, api.ErrorCodeInternalError},
+		{errors.New("connection refused"), api.ErrorCodeInternalError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.err.Error(), func(t *testing.T) {
+			if got := handler.ErrorCode(tc.err); got != tc.code {
+				t.Errorf("❌ エラーコード 期待値: %s, 実際: %s", tc.code, got)
+			}
+			// ラップされていても同じコードになる
+			if got := handler.ErrorCode(fmt.Errorf("usecase: %w", tc.err)); got != tc.code {
+				t.Errorf("❌ ラップ時のエラーコード 期待値: %s, 実際: %s", tc.code, got)
+			}
+		})
+	}
+}
+
+// TestErrorCode_Responses 各ハンドラーのエラーレスポンスにエラーコードが含まれることをテスト
+func TestErrorCode_Responses(t *testing.T) {
+	assertErrorCode := func(t *testing.T, resp *http.Response, body []byte, status int, code api.ErrorCode) {
+		t.Helper()
+		if resp.StatusCode != status {
+			t.Fatalf("❌ ステータスコード 期待値: %d, 実際: %d, body: %s", status, resp.StatusCode, body)
+		}
+		var apiErr api.Error
+		if err := json.Unmarshal(body, &apiErr); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if apiErr.Code != code {
+			t.Errorf("❌ エラーコード 期待値: %s, 実際: %s, body: %s", code, apiErr.Code, body)
+		}
+		if apiErr.Error == "" {
+			t.Error("❌ エラーメッセージが空です")
+		}
+	}
+
+	t.Run("認証", func(t *testing.T) {
+		srv := newAuthTestServer(t)
+		credentials := map[string]string{"email": "code@example.com", "password": "SecurePassword123!", "name": "Code User"}
+		if resp, body := sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/signup", nil, credentials); resp.StatusCode != http.StatusCreated {
+			t.Fatalf("❌ サインアップに失敗: %d, body: %s", resp.StatusCode, body)
+		}
+
+		resp, body := sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/signup", nil, credentials)
+		assertErrorCode(t, resp, body, http.StatusConflict, api.ErrorCodeEmailExists)
+
+		resp, body = sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/login", nil, map[string]string{
+			"email":    "code@example.com",
+			"password": "WrongPassword123!",
+		})
+		assertErrorCode(t, resp, body, http.StatusUnauthorized, api.ErrorCodeInvalidCredentials)
+
+		resp, body = sendRawRequest(t, srv, http.MethodPost, "/api/v1/auth/login", "text/plain", "email=code@example.com")
+		assertErrorCode(t, resp, body, http.StatusUnsupportedMediaType, api.ErrorCodeUnsupportedMediaType)
+	})
+
+	t.Run("アカウントとプロジェクト", func(t *testing.T) {
+		srv, accountRepo, _ := newAdminTestServer(t)
+		account := domain.NewAccount("owner@example.com", "Owner", "hash")
+		if err := accountRepo.Create(context.Background(), account); err != nil {
+			t.Fatalf("❌ アカウントの作成に失敗: %v", err)
+		}
+
+		resp, body := sendAsRole(t, srv, http.MethodGet, "/api/v1/accounts/"+domain.NewID().String(), "admin", nil)
+		assertErrorCode(t, resp, body, http.StatusNotFound, api.ErrorCodeAccountNotFound)
+
+		resp, body = sendAsAccount(t, srv, http.MethodGet, "/api/v1/accounts/"+account.ID.String()+"/projects/"+domain.NewID().String(), account.ID, nil)
+		assertErrorCode(t, resp, body, http.StatusNotFound, api.ErrorCodeProjectNotFound)
+
+		resp, body = sendAsAccount(t, srv, http.MethodPost, "/api/v1/accounts/"+account.ID.String()+"/projects", account.ID, map[string]string{
+			"name":   "Project",
+			"status": "deleted",
+		})
+		assertErrorCode(t, resp, body, http.StatusBadRequest, api.ErrorCodeInvalidStatus)
+	})
+
+	t.Run("ミドルウェアのエラーはステータスコードから決まる", func(t *testing.T) {
+		e := echo.New()
+		e.HTTPErrorHandler = middleware.NewErrorHandler(logger.NewLoggerWithOutput("error", "json", io.Discard)).HTTPErrorHandler
+		e.GET("/forbidden", func(c echo.Context) error {
+			return echo.NewHTTPError(http.StatusForbidden, "insufficient permissions")
+		})
+
+		cases := []struct {
+			path   string
+			status int
+			code   api.ErrorCode
+		}{
+			{"/forbidden", http.StatusForbidden, api.ErrorCodeForbidden},
+			{"/missing", http.StatusNotFound, api.ErrorCodeNotFound},
+		}
+		for _, tc := range cases {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+			assertErrorCode(t, rec.Result(), rec.Body.Bytes(), tc.status, tc.code)
+		}
+	})
+}
@@ -0,0 +1,180 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/handler"
+	"github.com/aida0710/jwt-auth/internal/logger"
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+)
+
+// TestErrorEnvelope_SameShape ハンドラー、ミドルウェア、Echoのエラーがすべて同じ形式のエラーレスポンスを返すことをテスト
+func TestErrorEnvelope_SameShape(t *testing.T) {
+	jwtManager := auth.NewJWTManager(auth.JWTConfig{
+		AccessTokenSecret:  "test-access-secret-0123456789abcdef",
+		RefreshTokenSecret: "test-refresh-secret-0123456789abcdef",
+		AccessTokenExpiry:  time.Minute,
+		RefreshTokenExpiry: time.Hour,
+		Issuer:             "jwt-auth-test",
+	})
+	authUsecase, _, _ := newTestAuthUsecase(t)
+	accountRepo := newFakeAccountRepository()
+	projectRepo := newFakeProjectRepository()
+	accountRepo.projects = projectRepo
+	accountUsecase := usecase.NewAccountUsecase(accountRepo, projectRepo, nil, nil, nil, nil, fakeTxManager{}, nil, usecase.AccountConfig{})
+	projectUsecase := usecase.NewProjectUsecase(projectRepo, accountRepo, fakeTxManager{}, usecase.ProjectConfig{})
+	server := handler.NewServer(accountUsecase, projectUsecase, handler.NewAuthHandler(authUsecase), handler.HealthConfig{}, handler.PrivacyConfig{}, logger.NewLoggerWithOutput("error", "json", io.Discard))
+
+	errorHandler := middleware.NewErrorHandler(logger.NewLoggerWithOutput("error", "json", io.Discard))
+	e := echo.New()
+	e.Logger.SetOutput(io.Discard)
+	e.HTTPErrorHandler = errorHandler.HTTPErrorHandler
+	e.Use(echomiddleware.RecoverWithConfig(errorHandler.RecoverConfig()))
+	shutdownGate := middleware.NewShutdownGate()
+	e.Use(shutdownGate.Middleware)
+	ipAccess, err := middleware.NewIPAccessMiddleware(middleware.IPAccessConfig{
+		PathPrefixes: []string{"/api/v1/admin"},
+		Denylist:     []string{"192.0.2.0/24"},
+	})
+	if err != nil {
+		t.Fatalf("❌ IPアクセス制御の作成に失敗: %v", err)
+	}
+	e.Use(ipAccess)
+	e.Use(middleware.NewRateLimitMiddleware(middleware.RateLimitConfig{
+		PathPrefixes: []string{"/api/v1/auth/magic-link"},
+		Requests:     1,
+		Window:       time.Minute,
+	}))
+	e.Use(middleware.NewAuthMiddleware(middleware.AuthConfig{
+		JWTManager:  jwtManager,
+		PublicPaths: []string{"/api/v1/auth/login", "/api/v1/auth/magic-link", "/panic", "/internal"},
+	}))
+	e.Use(middleware.NewRoleMiddleware(middleware.RoleConfig{
+		Rules: map[string]domain.Role{"POST /api/v1/accounts": domain.RoleAdmin},
+	}))
+	api.RegisterHandlersWithBaseURL(e, server, "/api/v1")
+	e.GET("/panic", func(c echo.Context) error {
+		panic("unexpected state")
+	})
+	e.GET("/internal", func(c echo.Context) error {
+		return errors.New("dial tcp 10.0.0.1:3306: connection refused")
+	})
+
+	self := domain.NewAccount("envelope@example.com", "Envelope User", "hash")
+	if err := accountRepo.Create(context.Background(), self); err != nil {
+		t.Fatalf("❌ アカウントの作成に失敗: %v", err)
+	}
+	tokenFor := func(role domain.Role) string {
+		token, err := jwtManager.GenerateAccessToken(self.ID, self.Email, string(role))
+		if err != nil {
+			t.Fatalf("❌ トークンの生成に失敗: %v", err)
+		}
+		return "Bearer " + token
+	}
+	userToken, adminToken := tokenFor(domain.RoleUser), tokenFor(domain.RoleAdmin)
+
+	cases := []struct {
+		name          string
+		method        string
+		path          string
+		authorization string
+		body          string
+		status        int
+		code          api.ErrorCode
+	}{
+		{"認証ハンドラー", http.MethodPost, "/api/v1/auth/login", "", `{"email":"missing@example.com","password":"WrongPassword123!"}`, http.StatusUnauthorized, api.ErrorCodeInvalidCredentials},
+		{"リクエストボディの構文エラー", http.MethodPost, "/api/v1/auth/login", "", `{"email":`, http.StatusBadRequest, api.ErrorCodeInvalidRequest},
+		{"アカウントハンドラー", http.MethodGet, "/api/v1/accounts/" + domain.NewID().String(), adminToken, "", http.StatusNotFound, api.ErrorCodeAccountNotFound},
+		{"プロジェクトハンドラー", http.MethodGet, "/api/v1/accounts/" + self.ID.String() + "/projects/" + domain.NewID().String(), userToken, "", http.StatusNotFound, api.ErrorCodeProjectNotFound},
+		{"パスパラメーターの形式エラー", http.MethodGet, "/api/v1/accounts/not-a-uuid", adminToken, "", http.StatusBadRequest, api.ErrorCodeInvalidRequest},
+		{"認証ミドルウェア", http.MethodGet, "/api/v1/auth/me", "", "", http.StatusUnauthorized, api.ErrorCodeUnauthorized},
+		{"ロールミドルウェア", http.MethodPost, "/api/v1/accounts", userToken, `{}`, http.StatusForbidden, api.ErrorCodeForbidden},
+		{"IPアクセス制御", http.MethodGet, "/api/v1/admin/projects", adminToken, "", http.StatusForbidden, api.ErrorCodeForbidden},
+		{"存在しないパス", http.MethodGet, "/api/v1/missing", userToken, "", http.StatusNotFound, api.ErrorCodeNotFound},
+		{"許可されていないメソッド", http.MethodDelete, "/api/v1/auth/me", userToken, "", http.StatusMethodNotAllowed, api.ErrorCodeMethodNotAllowed},
+		{"ハンドラーの内部エラー", http.MethodGet, "/internal", "", "", http.StatusInternalServerError, api.ErrorCodeInternalError},
+		{"panicからのリカバリー", http.MethodGet, "/panic", "", "", http.StatusInternalServerError, api.ErrorCodeInternalError},
+	}
+
+	serve := func(method, path, authorization, body string) *httptest.ResponseRecorder {
+		var reader io.Reader
+		if body != "" {
+			reader = strings.NewReader(body)
+		}
+		req := httptest.NewRequest(method, path, reader)
+		if body != "" {
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		}
+		if authorization != "" {
+			req.Header.Set(echo.HeaderAuthorization, authorization)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	assertEnvelope := func(t *testing.T, rec *httptest.ResponseRecorder, status int, code api.ErrorCode, extraKeys ...string) {
+		t.Helper()
+		if rec.Code != status {
+			t.Fatalf("❌ ステータスコード 期待値: %d, 実際: %d, body: %s", status, rec.Code, rec.Body.String())
+		}
+		if contentType := rec.Header().Get(echo.HeaderContentType); !strings.HasPrefix(contentType, echo.MIMEApplicationJSON) {
+			t.Errorf("❌ Content-Type 期待値: application/json, 実際: %q", contentType)
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &fields); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v, body: %s", err, rec.Body.String())
+		}
+		want := append([]string{"error", "code"}, extraKeys...)
+		if len(fields) != len(want) {
+			t.Errorf("❌ エラーレスポンスの項目 期待値: %v, 実際: %s", want, rec.Body.String())
+		}
+		for _, key := range want {
+			if _, ok := fields[key]; !ok {
+				t.Errorf("❌ %s がありません: %s", key, rec.Body.String())
+			}
+		}
+		if message, _ := fields["error"].(string); message == "" {
+			t.Errorf("❌ エラーメッセージが空です: %s", rec.Body.String())
+		}
+		if fields["code"] != string(code) {
+			t.Errorf("❌ エラーコード 期待値: %s, 実際: %v", code, fields["code"])
+		}
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := serve(tc.method, tc.path, tc.authorization, tc.body)
+			assertEnvelope(t, rec, tc.status, tc.code)
+			if strings.Contains(rec.Body.String(), "10.0.0.1") || strings.Contains(rec.Body.String(), "unexpected state") {
+				t.Errorf("❌ 内部エラーの詳細が含まれています: %s", rec.Body.String())
+			}
+		})
+	}
+
+	t.Run("レート制限", func(t *testing.T) {
+		body := `{"email":"envelope@example.com"}`
+		serve(http.MethodPost, "/api/v1/auth/magic-link", "", body)
+		rec := serve(http.MethodPost, "/api/v1/auth/magic-link", "", body)
+		assertEnvelope(t, rec, http.StatusTooManyRequests, api.ErrorCodeRateLimited, "retry_after_seconds")
+	})
+
+	t.Run("シャットダウン中", func(t *testing.T) {
+		shutdownGate.StartDraining()
+		rec := serve(http.MethodGet, "/api/v1/auth/me", userToken, "")
+		assertEnvelope(t, rec, http.StatusServiceUnavailable, api.ErrorCodeServiceUnavailable, "retry_after_seconds")
+	})
+}
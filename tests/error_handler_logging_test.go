@@ -0,0 +1,103 @@
+package tests_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/logger"
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+)
+
+// TestErrorHandler_StructuredLogging エラーハンドラーがリクエストIDとステータスを含む構造化ログを出力することをテスト
+func TestErrorHandler_StructuredLogging(t *testing.T) {
+	var logs bytes.Buffer
+	errorHandler := middleware.NewErrorHandler(logger.NewLoggerWithOutput("info", "json", &logs))
+
+	e := echo.New()
+	e.HTTPErrorHandler = errorHandler.HTTPErrorHandler
+	e.Use(echomiddleware.RequestID())
+	e.Use(middleware.Correlation)
+	e.GET("/internal", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusInternalServerError, "database is unavailable")
+	})
+	e.GET("/bad-request", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid input")
+	})
+
+	send := func(t *testing.T, path string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		t.Helper()
+		logs.Reset()
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+		var entry map[string]interface{}
+		line := strings.TrimSpace(logs.String())
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("❌ ログがJSONの1行ではありません: %v, log: %s", err, line)
+		}
+		return rec, entry
+	}
+
+	t.Run("5xxはスタックトレースを含めてERRORで出力", func(t *testing.T) {
+		rec, entry := send(t, "/internal")
+		if rec.Code != http.StatusInternalServerError {
+			t.Fatalf("❌ ステータスコード 期待値: 500, 実際: %d", rec.Code)
+		}
+		if entry["level"] != "ERROR" {
+			t.Errorf("❌ level 期待値: ERROR, 実際: %v", entry["level"])
+		}
+		if entry["status"] != float64(http.StatusInternalServerError) {
+			t.Errorf("❌ status 期待値: 500, 実際: %v", entry["status"])
+		}
+		if entry["path"] != "/internal" || entry["method"] != http.MethodGet {
+			t.Errorf("❌ リクエストの情報が不正です: %v %v", entry["method"], entry["path"])
+		}
+		if requestID := rec.Header().Get(echo.HeaderXRequestID); requestID == "" || entry["request_id"] != requestID {
+			t.Errorf("❌ request_id 期待値: %s, 実際: %v", requestID, entry["request_id"])
+		}
+		if errMsg, _ := entry["error"].(string); !strings.Contains(errMsg, "database is unavailable") {
+			t.Errorf("❌ errorにエラーの内容が含まれていません: %v", entry["error"])
+		}
+		if stack, _ := entry["stack"].(string); !strings.Contains(stack, "HTTPErrorHandler") {
+			t.Errorf("❌ stackにスタックトレースが含まれていません: %v", entry["stack"])
+		}
+	})
+
+	t.Run("4xxはスタックトレースを含めずWARNで出力", func(t *testing.T) {
+		rec, entry := send(t, "/bad-request")
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("❌ ステータスコード 期待値: 400, 実際: %d", rec.Code)
+		}
+		if entry["level"] != "WARN" || entry["status"] != float64(http.StatusBadRequest) {
+			t.Errorf("❌ level・status 期待値: WARN, 400, 実際: %v, %v", entry["level"], entry["status"])
+		}
+		if _, ok := entry["stack"]; ok {
+			t.Error("❌ 4xxのログにスタックトレースが含まれています")
+		}
+	})
+
+	t.Run("リカバリーしたpanicはpanicの箇所のスタックトレースを出力", func(t *testing.T) {
+		e.Use(echomiddleware.RecoverWithConfig(errorHandler.RecoverConfig()))
+		e.GET("/panic", func(c echo.Context) error {
+			panic(errors.New("unexpected state"))
+		})
+
+		rec, entry := send(t, "/panic")
+		if rec.Code != http.StatusInternalServerError {
+			t.Fatalf("❌ ステータスコード 期待値: 500, 実際: %d", rec.Code)
+		}
+		if errMsg, _ := entry["error"].(string); !strings.Contains(errMsg, "unexpected state") {
+			t.Errorf("❌ errorにpanicの内容が含まれていません: %v", entry["error"])
+		}
+		if stack, _ := entry["stack"].(string); stack == "" {
+			t.Error("❌ stackにスタックトレースが含まれていません")
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package tests_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+)
+
+// TestConditionalGet ETagの付与とIf-None-Matchによる304応答をテスト
+func TestConditionalGet(t *testing.T) {
+	ctx := context.Background()
+	srv, accountRepo, projectRepo := newAdminTestServer(t)
+
+	account := domain.NewAccount("etag@example.com", "ETag User", "hash")
+	if err := accountRepo.Create(ctx, account); err != nil {
+		t.Fatalf("❌ アカウント作成に失敗: %v", err)
+	}
+	project := domain.NewProject(account.ID, "ETag Project", "")
+	if err := projectRepo.Create(ctx, project); err != nil {
+		t.Fatalf("❌ プロジェクト作成に失敗: %v", err)
+	}
+
+	// getWithETag If-None-Matchを付けてGETし、ステータスとETagを返す
+	getWithETag := func(t *testing.T, path, ifNoneMatch string) (int, string) {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+		if err != nil {
+			t.Fatalf("❌ リクエスト作成に失敗: %v", err)
+		}
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("❌ リクエスト送信に失敗: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode, resp.Header.Get("ETag")
+	}
+
+	cases := []struct {
+		name   string
+		path   string
+		update func(t *testing.T) string
+	}{
+		{
+			name: "アカウント",
+			path: "/api/v1/accounts/" + account.ID.String(),
+			update: func(t *testing.T) string {
+				resp, body := sendAsRole(t, srv, http.MethodPatch, "/api/v1/accounts/"+account.ID.String(), "user",
+					map[string]string{"name": "Renamed User"})
+				if resp.StatusCode != http.StatusOK {
+					t.Fatalf("❌ 更新に失敗: %d %s", resp.StatusCode, body)
+				}
+				return resp.Header.Get("ETag")
+			},
+		},
+		{
+			name: "プロジェクト",
+			path: "/api/v1/accounts/" + account.ID.String() + "/projects/" + project.ID.String(),
+			update: func(t *testing.T) string {
+				resp, body := sendAsAccount(t, srv, http.MethodPut, "/api/v1/accounts/"+account.ID.String()+"/projects/"+project.ID.String(), account.ID,
+					map[string]string{"name": "Renamed Project"})
+				if resp.StatusCode != http.StatusOK {
+					t.Fatalf("❌ 更新に失敗: %d %s", resp.StatusCode, body)
+				}
+				return resp.Header.Get("ETag")
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, etag := getWithETag(t, tc.path, "")
+			if status != http.StatusOK || etag == "" {
+				t.Fatalf("❌ 初回取得 期待値: 200とETag, 実際: %d %q", status, etag)
+			}
+
+			status, _ = getWithETag(t, tc.path, etag)
+			if status != http.StatusNotModified {
+				t.Errorf("❌ 同じETagでの取得 期待値: 304, 実際: %d", status)
+			}
+
+			status, _ = getWithETag(t, tc.path, `"other", W/`+etag)
+			if status != http.StatusNotModified {
+				t.Errorf("❌ 複数指定・弱いETagでの取得 期待値: 304, 実際: %d", status)
+			}
+
+			updatedETag := tc.update(t)
+			if updatedETag == "" || updatedETag == etag {
+				t.Errorf("❌ 更新後のETagが変化していません: %q", updatedETag)
+			}
+
+			status, current := getWithETag(t, tc.path, etag)
+			if status != http.StatusOK {
+				t.Errorf("❌ 更新前のETagでの取得 期待値: 200, 実際: %d", status)
+			}
+			if current != updatedETag {
+				t.Errorf("❌ 取得時のETagが更新レスポンスと一致しません: %q != %q", current, updatedETag)
+			}
+		})
+	}
+}
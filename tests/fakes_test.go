@@ -0,0 +1,961 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/notification"
+	"github.com/google/uuid"
+)
+
+// fakeAccountRepository テスト用のインメモリアカウントリポジトリ
+// コンテキストにテナントが設定されている場合は、そのテナントのアカウントのみを対象とする
+type fakeAccountRepository struct {
+	mu       sync.Mutex
+	accounts map[uuid.UUID]*domain.Account
+	projects *fakeProjectRepository // プロジェクト数の集計に使用（nilの場合は0件）
+}
+
+func newFakeAccountRepository() *fakeAccountRepository {
+	return &fakeAccountRepository{accounts: make(map[uuid.UUID]*domain.Account)}
+}
+
+func (r *fakeAccountRepository) Create(_ context.Context, account *domain.Account) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, a := range r.accounts {
+		if a.Email == account.Email {
+			return domain.ErrDuplicateEmail
+		}
+		if account.Username != nil && a.Username != nil && *a.Username == *account.Username {
+			return domain.ErrDuplicateUsername
+		}
+	}
+	copied := *account
+	r.accounts[account.ID] = &copied
+	return nil
+}
+
+func (r *fakeAccountRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Account, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, ok := r.accounts[id]
+	if !ok || !a.BelongsTo(ctx) {
+		return nil, domain.ErrAccountNotFound
+	}
+	copied := *a
+	return &copied, nil
+}
+
+func (r *fakeAccountRepository) GetByEmail(ctx context.Context, email string) (*domain.Account, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, a := range r.accounts {
+		if a.Email == email && a.BelongsTo(ctx) {
+			copied := *a
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrAccountNotFound
+}
+
+func (r *fakeAccountRepository) GetByUsername(ctx context.Context, username string) (*domain.Account, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, a := range r.accounts {
+		if a.Username != nil && *a.Username == username && a.BelongsTo(ctx) {
+			copied := *a
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrAccountNotFound
+}
+
+// List 作成日時の新しい順にoffset件目からlimit件まで取得する
+func (r *fakeAccountRepository) List(ctx context.Context, limit, offset int) ([]*domain.Account, error) {
+	matched := r.match(ctx, domain.AccountFilter{})
+	if offset >= len(matched) {
+		return []*domain.Account{}, nil
+	}
+	matched = matched[offset:]
+	if limit < len(matched) {
+		matched = matched[:limit]
+	}
+	return matched, nil
+}
+
+// ListWithProjectCounts 作成日時の新しい順（カーソル指定時はID順）に絞り込み、プロジェクト数を集計する
+func (r *fakeAccountRepository) ListWithProjectCounts(ctx context.Context, filter domain.AccountFilter) ([]*domain.AccountProjectCount, error) {
+	matched := applyFakeCursor(r.match(ctx, filter), filter.Cursor, func(a *domain.Account) uuid.UUID { return a.ID })
+	if filter.Offset >= len(matched) {
+		return []*domain.AccountProjectCount{}, nil
+	}
+	matched = matched[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < len(matched) {
+		matched = matched[:filter.Limit]
+	}
+	reverseFakePage(filter.Cursor, matched)
+
+	counts := make([]*domain.AccountProjectCount, 0, len(matched))
+	for _, account := range matched {
+		count := 0
+		if r.projects != nil {
+			projects, _ := r.projects.GetByAccountID(ctx, account.ID)
+			count = len(projects)
+		}
+		counts = append(counts, &domain.AccountProjectCount{Account: account, ProjectCount: count})
+	}
+	return counts, nil
+}
+
+func (r *fakeAccountRepository) Count(ctx context.Context, filter domain.AccountFilter) (int, error) {
+	return len(r.match(ctx, filter)), nil
+}
+
+// SearchByEmailPrefix 大文字小文字を区別せずに前方一致で絞り込み、メールアドレス順に返す
+func (r *fakeAccountRepository) SearchByEmailPrefix(ctx context.Context, prefix string, limit int) ([]*domain.Account, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	matched := make([]*domain.Account, 0)
+	for _, a := range r.accounts {
+		if !a.BelongsTo(ctx) || !strings.HasPrefix(strings.ToLower(a.Email), strings.ToLower(prefix)) {
+			continue
+		}
+		copied := *a
+		matched = append(matched, &copied)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return strings.ToLower(matched[i].Email) < strings.ToLower(matched[j].Email)
+	})
+	if limit > 0 && limit < len(matched) {
+		matched = matched[:limit]
+	}
+	return matched, nil
+}
+
+// ListDeletionDue 削除予定日時を過ぎたアカウントを削除予定日時の古い順に返す
+func (r *fakeAccountRepository) ListDeletionDue(ctx context.Context, now time.Time, limit int) ([]*domain.Account, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	matched := make([]*domain.Account, 0)
+	for _, a := range r.accounts {
+		if !a.BelongsTo(ctx) || !a.IsDeletionDue(now) {
+			continue
+		}
+		copied := *a
+		matched = append(matched, &copied)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].DeletionScheduledAt.Before(*matched[j].DeletionScheduledAt)
+	})
+	if limit > 0 && limit < len(matched) {
+		matched = matched[:limit]
+	}
+	return matched, nil
+}
+
+// match 条件に一致するアカウントを作成日時の新しい順に返す
+func (r *fakeAccountRepository) match(ctx context.Context, filter domain.AccountFilter) []*domain.Account {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	matched := make([]*domain.Account, 0, len(r.accounts))
+	for _, a := range r.accounts {
+		if !a.BelongsTo(ctx) {
+			continue
+		}
+		if filter.Role != nil && a.Role != *filter.Role {
+			continue
+		}
+		copied := *a
+		matched = append(matched, &copied)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].CreatedAt.After(matched[j].CreatedAt)
+		}
+		return matched[i].ID.String() > matched[j].ID.String()
+	})
+	return matched
+}
+
+func (r *fakeAccountRepository) Update(ctx context.Context, account *domain.Account) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, ok := r.accounts[account.ID]
+	if !ok || !a.BelongsTo(ctx) {
+		return domain.ErrAccountNotFound
+	}
+	if a.Version != account.Version {
+		return domain.ErrVersionConflict
+	}
+	account.Version++
+	copied := *account
+	copied.LastLoginAt = a.LastLoginAt
+	copied.LastLoginIP = a.LastLoginIP
+	r.accounts[account.ID] = &copied
+	return nil
+}
+
+func (r *fakeAccountRepository) RecordLogin(ctx context.Context, id uuid.UUID, at time.Time, ipAddress *string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, ok := r.accounts[id]
+	if !ok || !a.BelongsTo(ctx) {
+		return domain.ErrAccountNotFound
+	}
+	a.LastLoginAt = &at
+	a.LastLoginIP = ipAddress
+	return nil
+}
+
+func (r *fakeAccountRepository) UpdatePasswordHash(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, ok := r.accounts[id]
+	if !ok || !a.BelongsTo(ctx) {
+		return domain.ErrAccountNotFound
+	}
+	a.PasswordHash = passwordHash
+	return nil
+}
+
+func (r *fakeAccountRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if a, ok := r.accounts[id]; !ok || !a.BelongsTo(ctx) {
+		return domain.ErrAccountNotFound
+	}
+	delete(r.accounts, id)
+	return nil
+}
+
+// fakeProjectRepository テスト用のインメモリプロジェクトリポジトリ
+// コンテキストにテナントが設定されている場合は、そのテナントのプロジェクトのみを対象とする
+type fakeProjectRepository struct {
+	mu       sync.Mutex
+	projects []*domain.Project
+}
+
+func newFakeProjectRepository() *fakeProjectRepository {
+	return &fakeProjectRepository{}
+}
+
+func (r *fakeProjectRepository) Create(_ context.Context, project *domain.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *project
+	r.projects = append(r.projects, &copied)
+	return nil
+}
+
+func (r *fakeProjectRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Project, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, p := range r.projects {
+		if p.ID == id && p.BelongsTo(ctx) {
+			copied := *p
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrProjectNotFound
+}
+
+func (r *fakeProjectRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.Project, error) {
+	return r.Search(ctx, domain.ProjectFilter{AccountID: &accountID})
+}
+
+func (r *fakeProjectRepository) List(ctx context.Context) ([]*domain.Project, error) {
+	return r.Search(ctx, domain.ProjectFilter{})
+}
+
+// Search 作成日時の新しい順（カーソル指定時はID順）に絞り込む（Limitが0の場合は件数を制限しない）
+func (r *fakeProjectRepository) Search(ctx context.Context, filter domain.ProjectFilter) ([]*domain.Project, error) {
+	matched := applyFakeCursor(r.match(ctx, filter), filter.Cursor, func(p *domain.Project) uuid.UUID { return p.ID })
+	if filter.Offset >= len(matched) {
+		return []*domain.Project{}, nil
+	}
+	matched = matched[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < len(matched) {
+		matched = matched[:filter.Limit]
+	}
+	reverseFakePage(filter.Cursor, matched)
+	return matched, nil
+}
+
+func (r *fakeProjectRepository) Count(ctx context.Context, filter domain.ProjectFilter) (int, error) {
+	return len(r.match(ctx, filter)), nil
+}
+
+// match 条件に一致するプロジェクトを作成日時の新しい順に返す
+func (r *fakeProjectRepository) match(ctx context.Context, filter domain.ProjectFilter) []*domain.Project {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	matched := make([]*domain.Project, 0, len(r.projects))
+	for _, p := range r.projects {
+		if !p.BelongsTo(ctx) {
+			continue
+		}
+		if filter.AccountID != nil && p.AccountID != *filter.AccountID {
+			continue
+		}
+		if filter.Status != nil && p.Status != *filter.Status {
+			continue
+		}
+		copied := *p
+		matched = append(matched, &copied)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].CreatedAt.After(matched[j].CreatedAt)
+		}
+		return matched[i].ID.String() > matched[j].ID.String()
+	})
+	return matched
+}
+
+func (r *fakeProjectRepository) Update(ctx context.Context, project *domain.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, p := range r.projects {
+		if p.ID == project.ID && p.BelongsTo(ctx) {
+			copied := *project
+			r.projects[i] = &copied
+			return nil
+		}
+	}
+	return domain.ErrProjectNotFound
+}
+
+func (r *fakeProjectRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, p := range r.projects {
+		if p.ID == id && p.BelongsTo(ctx) {
+			r.projects = append(r.projects[:i], r.projects[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrProjectNotFound
+}
+
+func (r *fakeProjectRepository) DeleteByAccountID(ctx context.Context, accountID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	kept := r.projects[:0]
+	for _, p := range r.projects {
+		if p.AccountID != accountID || !p.BelongsTo(ctx) {
+			kept = append(kept, p)
+		}
+	}
+	r.projects = kept
+	return nil
+}
+
+// fakeTxManager トランザクションを張らずに処理をそのまま実行する
+type fakeTxManager struct{}
+
+func (fakeTxManager) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// fakeRefreshTokenRepository テスト用のインメモリリフレッシュトークンリポジトリ
+type fakeRefreshTokenRepository struct {
+	mu     sync.Mutex
+	tokens map[uuid.UUID]*domain.RefreshToken
+
+	// beforeMarkAsRotated MarkAsRotatedの直前に呼ばれるフック（同時実行のテスト用）
+	beforeMarkAsRotated func()
+}
+
+func newFakeRefreshTokenRepository() *fakeRefreshTokenRepository {
+	return &fakeRefreshTokenRepository{tokens: make(map[uuid.UUID]*domain.RefreshToken)}
+}
+
+func (r *fakeRefreshTokenRepository) Create(_ context.Context, token *domain.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, t := range r.tokens {
+		if t.TokenHash == token.TokenHash {
+			return domain.ErrDuplicateToken
+		}
+	}
+	copied := *token
+	r.tokens[token.ID] = &copied
+	return nil
+}
+
+func (r *fakeRefreshTokenRepository) GetByTokenHash(_ context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, t := range r.tokens {
+		if t.TokenHash == tokenHash {
+			copied := *t
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *fakeRefreshTokenRepository) MarkAsUsed(_ context.Context, id uuid.UUID) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t, ok := r.tokens[id]
+	if !ok {
+		return false, domain.ErrNotFound
+	}
+	if t.UsedAt != nil {
+		return false, nil
+	}
+	now := time.Now()
+	t.UsedAt = &now
+	return true, nil
+}
+
+func (r *fakeRefreshTokenRepository) GetByID(_ context.Context, id uuid.UUID) (*domain.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t, ok := r.tokens[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	copied := *t
+	return &copied, nil
+}
+
+func (r *fakeRefreshTokenRepository) MarkAsRotated(_ context.Context, id, successorID uuid.UUID) (bool, error) {
+	if r.beforeMarkAsRotated != nil {
+		r.beforeMarkAsRotated()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t, ok := r.tokens[id]
+	if !ok {
+		return false, domain.ErrNotFound
+	}
+	if t.UsedAt != nil {
+		return false, nil
+	}
+	now := time.Now()
+	t.UsedAt = &now
+	t.SuccessorID = &successorID
+	return true, nil
+}
+
+func (r *fakeRefreshTokenRepository) Revoke(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t, ok := r.tokens[id]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	now := time.Now()
+	t.RevokedAt = &now
+	return nil
+}
+
+func (r *fakeRefreshTokenRepository) RevokeByAccountID(_ context.Context, accountID uuid.UUID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var revoked int64
+	now := time.Now()
+	for _, t := range r.tokens {
+		if t.AccountID == accountID && t.RevokedAt == nil {
+			t.RevokedAt = &now
+			revoked++
+		}
+	}
+	return revoked, nil
+}
+
+func (r *fakeRefreshTokenRepository) RevokeByIP(_ context.Context, ipAddress string) (int64, error) {
+	return r.revokeMatching(func(t *domain.RefreshToken) bool {
+		return t.IPAddress != nil && *t.IPAddress == ipAddress
+	}), nil
+}
+
+func (r *fakeRefreshTokenRepository) RevokeByUserAgent(_ context.Context, userAgent string) (int64, error) {
+	return r.revokeMatching(func(t *domain.RefreshToken) bool {
+		return t.UserAgent != nil && *t.UserAgent == userAgent
+	}), nil
+}
+
+// revokeMatching 条件に一致する未失効のトークンを無効化（テナントでは絞り込まない）
+func (r *fakeRefreshTokenRepository) revokeMatching(match func(*domain.RefreshToken) bool) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var revoked int64
+	now := time.Now()
+	for _, t := range r.tokens {
+		if t.RevokedAt != nil || !match(t) {
+			continue
+		}
+		t.RevokedAt = &now
+		revoked++
+	}
+	return revoked
+}
+
+func (r *fakeRefreshTokenRepository) CountRotatedSince(_ context.Context, accountID uuid.UUID, since time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	count := 0
+	for _, t := range r.tokens {
+		if t.AccountID == accountID && t.UsedAt != nil && !t.UsedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *fakeRefreshTokenRepository) ListRecentByAccountID(_ context.Context, accountID uuid.UUID, limit int) ([]*domain.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var tokens []*domain.RefreshToken
+	for _, t := range r.tokens {
+		if t.AccountID == accountID {
+			copied := *t
+			tokens = append(tokens, &copied)
+		}
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		if !tokens[i].CreatedAt.Equal(tokens[j].CreatedAt) {
+			return tokens[i].CreatedAt.After(tokens[j].CreatedAt)
+		}
+		return tokens[i].ID.String() > tokens[j].ID.String()
+	})
+	if len(tokens) > limit {
+		tokens = tokens[:limit]
+	}
+	return tokens, nil
+}
+
+func (r *fakeRefreshTokenRepository) DeleteExpired(_ context.Context, _ int, _ time.Duration) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	var deleted int64
+	for id, t := range r.tokens {
+		if t.ExpiresAt.Before(now) {
+			delete(r.tokens, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// fakeSecurityAuditLogRepository テスト用のインメモリ監査ログリポジトリ
+type fakeSecurityAuditLogRepository struct {
+	mu        sync.Mutex
+	logs      []*domain.SecurityAuditLog
+	createErr error // 設定するとCreateがこのエラーを返す（保存先の障害を再現する）
+	// rejectErr 設定するとCreateがイベントごとにこの関数の返すエラーを返す（特定のイベントの書き込み失敗を再現する）
+	rejectErr func(*domain.SecurityAuditLog) error
+}
+
+// setRejectErr イベントごとにCreateが返すエラーを決める関数を設定（nilで解除）
+func (r *fakeSecurityAuditLogRepository) setRejectErr(reject func(*domain.SecurityAuditLog) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rejectErr = reject
+}
+
+// setCreateErr Createが返すエラーを設定（nilで障害から回復させる）
+func (r *fakeSecurityAuditLogRepository) setCreateErr(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.createErr = err
+}
+
+func (r *fakeSecurityAuditLogRepository) Create(_ context.Context, log *domain.SecurityAuditLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.createErr != nil {
+		return r.createErr
+	}
+	if r.rejectErr != nil {
+		if err := r.rejectErr(log); err != nil {
+			return err
+		}
+	}
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+func (r *fakeSecurityAuditLogRepository) GetByAccountID(_ context.Context, accountID uuid.UUID, limit, offset int) ([]*domain.SecurityAuditLog, error) {
+	return r.filter(func(l *domain.SecurityAuditLog) bool { return l.AccountID == accountID }, limit, offset), nil
+}
+
+func (r *fakeSecurityAuditLogRepository) GetByEventType(_ context.Context, eventType domain.SecurityEventType, limit, offset int) ([]*domain.SecurityAuditLog, error) {
+	return r.filter(func(l *domain.SecurityAuditLog) bool { return l.EventType == eventType }, limit, offset), nil
+}
+
+func (r *fakeSecurityAuditLogRepository) CountByAccountID(_ context.Context, accountID uuid.UUID) (int, error) {
+	return len(r.filter(func(l *domain.SecurityAuditLog) bool { return l.AccountID == accountID }, -1, 0)), nil
+}
+
+// filter 条件に一致するログを新しい順に返す（limitが負の場合は全件）
+func (r *fakeSecurityAuditLogRepository) filter(match func(*domain.SecurityAuditLog) bool, limit, offset int) []*domain.SecurityAuditLog {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var matched []*domain.SecurityAuditLog
+	for i := len(r.logs) - 1; i >= 0; i-- {
+		if match(r.logs[i]) {
+			matched = append(matched, r.logs[i])
+		}
+	}
+	if offset >= len(matched) {
+		return []*domain.SecurityAuditLog{}
+	}
+	matched = matched[offset:]
+	if limit >= 0 && limit < len(matched) {
+		matched = matched[:limit]
+	}
+	return matched
+}
+
+// fakeLoginAttemptRepository テスト用のインメモリログイン失敗の記録リポジトリ
+type fakeLoginAttemptRepository struct {
+	mu       sync.Mutex
+	attempts map[uuid.UUID]*domain.LoginAttempt
+}
+
+func newFakeLoginAttemptRepository() *fakeLoginAttemptRepository {
+	return &fakeLoginAttemptRepository{attempts: make(map[uuid.UUID]*domain.LoginAttempt)}
+}
+
+func (r *fakeLoginAttemptRepository) GetByAccountID(_ context.Context, accountID uuid.UUID) (*domain.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	attempt, ok := r.attempts[accountID]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	copied := *attempt
+	return &copied, nil
+}
+
+func (r *fakeLoginAttemptRepository) Save(_ context.Context, attempt *domain.LoginAttempt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *attempt
+	r.attempts[attempt.AccountID] = &copied
+	return nil
+}
+
+// rewind 記録された時刻をdだけ過去にずらす（ロックの解除や静穏期間の経過を再現する）
+func (r *fakeLoginAttemptRepository) rewind(accountID uuid.UUID, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	attempt := r.attempts[accountID]
+	attempt.LastFailedAt = attempt.LastFailedAt.Add(-d)
+	if attempt.LockedUntil != nil {
+		lockedUntil := attempt.LockedUntil.Add(-d)
+		attempt.LockedUntil = &lockedUntil
+	}
+}
+
+// fakePasswordHistoryRepository テスト用のインメモリパスワード履歴リポジトリ
+type fakePasswordHistoryRepository struct {
+	mu        sync.Mutex
+	histories []*domain.PasswordHistory // 追加順
+}
+
+func (r *fakePasswordHistoryRepository) Create(_ context.Context, history *domain.PasswordHistory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *history
+	r.histories = append(r.histories, &copied)
+	return nil
+}
+
+func (r *fakePasswordHistoryRepository) ListRecent(_ context.Context, accountID uuid.UUID, limit int) ([]*domain.PasswordHistory, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var recent []*domain.PasswordHistory
+	for i := len(r.histories) - 1; i >= 0 && len(recent) < limit; i-- {
+		if r.histories[i].AccountID == accountID {
+			copied := *r.histories[i]
+			recent = append(recent, &copied)
+		}
+	}
+	return recent, nil
+}
+
+func (r *fakePasswordHistoryRepository) Prune(_ context.Context, accountID uuid.UUID, keep int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	kept := 0
+	var remaining []*domain.PasswordHistory
+	for i := len(r.histories) - 1; i >= 0; i-- {
+		h := r.histories[i]
+		if h.AccountID == accountID {
+			if kept >= keep {
+				continue
+			}
+			kept++
+		}
+		remaining = append([]*domain.PasswordHistory{h}, remaining...)
+	}
+	r.histories = remaining
+	return nil
+}
+
+// count アカウントの履歴件数を返す
+func (r *fakePasswordHistoryRepository) count(accountID uuid.UUID) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, h := range r.histories {
+		if h.AccountID == accountID {
+			n++
+		}
+	}
+	return n
+}
+
+// fakeNotifier 送信された通知を記録するNotifier
+type fakeNotifier struct {
+	mu       sync.Mutex
+	messages []notification.Message
+}
+
+func (n *fakeNotifier) Send(_ context.Context, msg notification.Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.messages = append(n.messages, msg)
+	return nil
+}
+
+// last 最後に送信された通知を返す
+func (n *fakeNotifier) last() (notification.Message, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if len(n.messages) == 0 {
+		return notification.Message{}, false
+	}
+	return n.messages[len(n.messages)-1], true
+}
+
+// fakeMagicLinkRepository テスト用のインメモリマジックリンクリポジトリ
+type fakeMagicLinkRepository struct {
+	mu    sync.Mutex
+	links map[uuid.UUID]*domain.MagicLink
+}
+
+func newFakeMagicLinkRepository() *fakeMagicLinkRepository {
+	return &fakeMagicLinkRepository{links: make(map[uuid.UUID]*domain.MagicLink)}
+}
+
+func (r *fakeMagicLinkRepository) Create(_ context.Context, link *domain.MagicLink) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *link
+	r.links[link.ID] = &copied
+	return nil
+}
+
+func (r *fakeMagicLinkRepository) GetByTokenHash(_ context.Context, tokenHash string) (*domain.MagicLink, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, link := range r.links {
+		if link.TokenHash == tokenHash {
+			copied := *link
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *fakeMagicLinkRepository) MarkAsConsumed(_ context.Context, id uuid.UUID) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	link, ok := r.links[id]
+	if !ok {
+		return false, nil
+	}
+	now := time.Now()
+	if !link.IsUsable(now) {
+		return false, nil
+	}
+	link.ConsumedAt = &now
+	return true, nil
+}
+
+func (r *fakeMagicLinkRepository) CountCreatedSince(_ context.Context, accountID uuid.UUID, since time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, link := range r.links {
+		if link.AccountID == accountID && !link.CreatedAt.Before(since) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// rewind 作成日時と有効期限をdだけ過去にずらす（期限切れや送信数を数える期間の経過を再現する）
+func (r *fakeMagicLinkRepository) rewind(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, link := range r.links {
+		link.CreatedAt = link.CreatedAt.Add(-d)
+		link.ExpiresAt = link.ExpiresAt.Add(-d)
+	}
+}
+
+// fakePasswordResetRepository テスト用のインメモリパスワード再設定トークンリポジトリ
+type fakePasswordResetRepository struct {
+	mu     sync.Mutex
+	resets map[uuid.UUID]*domain.PasswordReset
+}
+
+func newFakePasswordResetRepository() *fakePasswordResetRepository {
+	return &fakePasswordResetRepository{resets: make(map[uuid.UUID]*domain.PasswordReset)}
+}
+
+func (r *fakePasswordResetRepository) Create(_ context.Context, reset *domain.PasswordReset) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *reset
+	r.resets[reset.ID] = &copied
+	return nil
+}
+
+func (r *fakePasswordResetRepository) GetByTokenHash(_ context.Context, tokenHash string) (*domain.PasswordReset, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, reset := range r.resets {
+		if reset.TokenHash == tokenHash {
+			copied := *reset
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *fakePasswordResetRepository) MarkAsConsumed(_ context.Context, id uuid.UUID) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	reset, ok := r.resets[id]
+	if !ok {
+		return false, nil
+	}
+	now := time.Now()
+	if !reset.IsUsable(now) {
+		return false, nil
+	}
+	reset.ConsumedAt = &now
+	return true, nil
+}
+
+func (r *fakePasswordResetRepository) InvalidateByAccountID(_ context.Context, accountID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for _, reset := range r.resets {
+		if reset.AccountID == accountID && reset.ConsumedAt == nil {
+			reset.ConsumedAt = &now
+		}
+	}
+	return nil
+}
+
+// rewind 作成日時と有効期限をdだけ過去にずらす（期限切れを再現する）
+func (r *fakePasswordResetRepository) rewind(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, reset := range r.resets {
+		reset.CreatedAt = reset.CreatedAt.Add(-d)
+		reset.ExpiresAt = reset.ExpiresAt.Add(-d)
+	}
+}
+
+// fakeInviteRepository テスト用のインメモリ招待リポジトリ
+type fakeInviteRepository struct {
+	mu      sync.Mutex
+	invites map[uuid.UUID]*domain.Invite
+}
+
+func newFakeInviteRepository() *fakeInviteRepository {
+	return &fakeInviteRepository{invites: make(map[uuid.UUID]*domain.Invite)}
+}
+
+func (r *fakeInviteRepository) Create(_ context.Context, invite *domain.Invite) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *invite
+	r.invites[invite.ID] = &copied
+	return nil
+}
+
+func (r *fakeInviteRepository) GetByTokenHash(_ context.Context, tokenHash string) (*domain.Invite, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, invite := range r.invites {
+		if invite.TokenHash == tokenHash {
+			copied := *invite
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *fakeInviteRepository) MarkAsConsumed(_ context.Context, id, accountID uuid.UUID) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	invite, ok := r.invites[id]
+	if !ok {
+		return false, nil
+	}
+	now := time.Now()
+	if !invite.IsUsable(now) {
+		return false, nil
+	}
+	invite.ConsumedAt = &now
+	invite.ConsumedBy = &accountID
+	return true, nil
+}
+
+// get IDで招待を取得する（消費の状態の確認用）
+func (r *fakeInviteRepository) get(id uuid.UUID) domain.Invite {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return *r.invites[id]
+}
+
+// applyFakeCursor カーソルの前後の項目に絞り込み、カーソルに近い順に並べる
+// 前のページはLimitで切り出した後にreverseFakePageで新しい順に戻す
+func applyFakeCursor[T any](items []T, cursor *domain.PageCursor, idOf func(T) uuid.UUID) []T {
+	if cursor == nil {
+		return items
+	}
+	matched := make([]T, 0, len(items))
+	for _, item := range items {
+		id := idOf(item)
+		c := bytes.Compare(id[:], cursor.ID[:])
+		if (cursor.Before && c > 0) || (!cursor.Before && c < 0) {
+			matched = append(matched, item)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		a, b := idOf(matched[i]), idOf(matched[j])
+		if cursor.Before {
+			return bytes.Compare(a[:], b[:]) < 0
+		}
+		return bytes.Compare(a[:], b[:]) > 0
+	})
+	return matched
+}
+
+// reverseFakePage 前のページをカーソルに近い順から新しい順に並べ替える
+func reverseFakePage[T any](cursor *domain.PageCursor, items []T) {
+	if cursor != nil && cursor.Before {
+		slices.Reverse(items)
+	}
+}
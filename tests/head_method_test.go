@@ -0,0 +1,129 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/handler"
+	"github.com/aida0710/jwt-auth/internal/logger"
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// newHeadTestEcho HEADミドルウェアとエラーハンドラーを設定したテスト用のEchoを作成
+// X-Test-Roleヘッダーの値をロールとしてコンテキストに設定する
+func newHeadTestEcho(t *testing.T) *echo.Echo {
+	t.Helper()
+
+	log := logger.NewLoggerWithOutput("error", "json", io.Discard)
+	accountRepo := newFakeAccountRepository()
+	accountRepo.projects = newFakeProjectRepository()
+	if err := accountRepo.Create(context.Background(), domain.NewAccount("head@example.com", "Head User", "hash")); err != nil {
+		t.Fatalf("❌ アカウントの作成に失敗: %v", err)
+	}
+	accountUsecase := usecase.NewAccountUsecase(accountRepo, accountRepo.projects, nil, nil, nil, nil, fakeTxManager{}, nil, usecase.AccountConfig{})
+	server := handler.NewServer(accountUsecase, nil, nil, handler.HealthConfig{}, handler.PrivacyConfig{}, log)
+
+	e := echo.New()
+	e.Pre(middleware.Head)
+	e.HTTPErrorHandler = middleware.NewErrorHandler(log).HTTPErrorHandler
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(string(middleware.RoleKey), c.Request().Header.Get("X-Test-Role"))
+			return next(c)
+		}
+	})
+	api.RegisterHandlersWithBaseURL(e, server, "/api/v1")
+	return e
+}
+
+// serveHead テスト用のEchoにリクエストを送信（本文が書き込まれたかを確認するためレコーダーを使用）
+func serveHead(e *echo.Echo, method, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("X-Test-Role", string(domain.RoleAdmin))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+// TestHeadMethod GETのエンドポイントがHEADでヘッダーのみを返すことをテスト
+func TestHeadMethod(t *testing.T) {
+	e := newHeadTestEcho(t)
+
+	t.Run("HEAD /accountsはGETと同じステータスとヘッダーを本文なしで返す", func(t *testing.T) {
+		get := serveHead(e, http.MethodGet, "/api/v1/accounts")
+		if get.Code != http.StatusOK || get.Body.Len() == 0 {
+			t.Fatalf("❌ GET 期待値: 200で本文あり, 実際: %d, body: %s", get.Code, get.Body.String())
+		}
+
+		head := serveHead(e, http.MethodHead, "/api/v1/accounts")
+		if head.Code != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d", head.Code)
+		}
+		if head.Body.Len() != 0 {
+			t.Errorf("❌ HEADのレスポンスに本文が含まれています: %s", head.Body.String())
+		}
+		if got, want := head.Header().Get(echo.HeaderContentType), get.Header().Get(echo.HeaderContentType); got != want {
+			t.Errorf("❌ Content-Type 期待値: %q, 実際: %q", want, got)
+		}
+		if got, want := head.Header().Get("X-Page-Limit"), get.Header().Get("X-Page-Limit"); got != want {
+			t.Errorf("❌ X-Page-Limit 期待値: %q, 実際: %q", want, got)
+		}
+	})
+
+	t.Run("エラーの場合も本文を返さない", func(t *testing.T) {
+		rec := serveHead(e, http.MethodHead, "/api/v1/accounts/"+domain.NewID().String())
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("❌ ステータスコード 期待値: 404, 実際: %d", rec.Code)
+		}
+		if rec.Body.Len() != 0 {
+			t.Errorf("❌ HEADのレスポンスに本文が含まれています: %s", rec.Body.String())
+		}
+	})
+
+	t.Run("GETのないパスへのHEADは405", func(t *testing.T) {
+		rec := serveHead(e, http.MethodHead, "/api/v1/auth/login")
+		if rec.Code != http.StatusMethodNotAllowed {
+			t.Errorf("❌ ステータスコード 期待値: 405, 実際: %d", rec.Code)
+		}
+		if allow := rec.Header().Get(echo.HeaderAllow); strings.Contains(allow, http.MethodHead) {
+			t.Errorf("❌ GETのないパスのAllowにHEADが含まれています: %q", allow)
+		}
+	})
+}
+
+// TestMethodNotAllowed_AllowHeader 405レスポンスのAllowヘッダーに許可されたメソッドが含まれることをテスト
+func TestMethodNotAllowed_AllowHeader(t *testing.T) {
+	e := newHeadTestEcho(t)
+
+	rec := serveHead(e, http.MethodDelete, "/api/v1/accounts")
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("❌ ステータスコード 期待値: 405, 実際: %d, body: %s", rec.Code, rec.Body.String())
+	}
+
+	allowed := map[string]bool{}
+	for _, method := range strings.Split(rec.Header().Get(echo.HeaderAllow), ",") {
+		allowed[strings.TrimSpace(method)] = true
+	}
+	for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodPost} {
+		if !allowed[method] {
+			t.Errorf("❌ Allowに %s が含まれていません: %q", method, rec.Header().Get(echo.HeaderAllow))
+		}
+	}
+	if allowed[http.MethodDelete] {
+		t.Errorf("❌ AllowにDELETEが含まれています: %q", rec.Header().Get(echo.HeaderAllow))
+	}
+
+	var got api.Error
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil || got.Code != api.ErrorCodeMethodNotAllowed {
+		t.Errorf("❌ code 期待値: method_not_allowed, 実際: %s", rec.Body.String())
+	}
+}
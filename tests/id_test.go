@@ -0,0 +1,145 @@
+package tests_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// TestNewID_Version 設定したバージョンでIDが生成されることをテスト
+func TestNewID_Version(t *testing.T) {
+	if got := domain.DefaultIDConfig.Version; got != domain.IDVersion7 {
+		t.Errorf("❌ 既定のバージョン 期待値: 7, 実際: %d", got)
+	}
+	if got := domain.IDVersion(domain.NewID().Version()); got != domain.IDVersion7 {
+		t.Errorf("❌ 既定で生成したIDのバージョン 期待値: 7, 実際: %d", got)
+	}
+
+	for _, version := range []domain.IDVersion{domain.IDVersion4, domain.IDVersion7} {
+		t.Run(uuid.Version(version).String(), func(t *testing.T) {
+			t.Parallel()
+			ids := domain.IDConfig{Version: version}
+
+			accountRepo := newFakeAccountRepository()
+			authUsecase, refreshTokenRepo, _ := newTestAuthUsecaseWithAccountRepository(t, accountRepo, usecase.AuthConfig{RefreshTokenExpiry: time.Hour, IDs: ids})
+			tokens, err := authUsecase.SignUp(context.Background(), usecase.SignUpInput{
+				Email:    "id@example.com",
+				Password: "SecurePassword123!",
+				Name:     "ID",
+			})
+			if err != nil {
+				t.Fatalf("❌ サインアップに失敗: %v", err)
+			}
+			sessions, err := refreshTokenRepo.ListRecentByAccountID(context.Background(), tokens.Account.ID, 10)
+			if err != nil || len(sessions) != 1 {
+				t.Fatalf("❌ セッションの取得に失敗: %d件, %v", len(sessions), err)
+			}
+
+			projectUsecase := usecase.NewProjectUsecase(newFakeProjectRepository(), accountRepo, fakeTxManager{}, usecase.ProjectConfig{IDs: ids})
+			project, err := projectUsecase.Create(context.Background(), tokens.Account.ID, tokens.Account.ID, usecase.CreateProjectInput{Name: "ID"})
+			if err != nil {
+				t.Fatalf("❌ プロジェクトの作成に失敗: %v", err)
+			}
+
+			for name, id := range map[string]uuid.UUID{
+				"account":       tokens.Account.ID,
+				"refresh_token": sessions[0].ID,
+				"project":       project.ID,
+			} {
+				if got := domain.IDVersion(id.Version()); got != version {
+					t.Errorf("❌ %s のバージョン 期待値: %d, 実際: %d", name, version, got)
+				}
+			}
+		})
+	}
+
+	t.Run("未対応のバージョンは設定できない", func(t *testing.T) {
+		if err := (domain.IDConfig{Version: 1}).Validate(); err == nil {
+			t.Error("❌ v1の設定が受け付けられました")
+		}
+	})
+}
+
+// TestValidateAccountID IDのバージョン検証をテスト
+func TestValidateAccountID(t *testing.T) {
+	v4 := uuid.New()
+	v7 := uuid.Must(uuid.NewV7())
+	v1 := uuid.Must(uuid.NewUUID())
+
+	t.Run("移行期間はv4とv7の両方を受け付ける", func(t *testing.T) {
+		for _, id := range []uuid.UUID{v4, v7} {
+			if err := domain.ValidateAccountID(id); err != nil {
+				t.Errorf("❌ v%d が拒否されました: %v", id.Version(), err)
+			}
+		}
+		for _, id := range []uuid.UUID{v1, uuid.Nil} {
+			if err := domain.ValidateAccountID(id); !errors.Is(err, domain.ErrInvalidAccountID) {
+				t.Errorf("❌ %s が受け付けられました: %v", id, err)
+			}
+		}
+	})
+
+	t.Run("厳格モードでは設定したバージョンのみ受け付ける", func(t *testing.T) {
+		strict := domain.IDConfig{Version: domain.IDVersion7, StrictVersion: true}
+
+		if _, err := strict.ParseAccountID(v7.String()); err != nil {
+			t.Errorf("❌ v7が拒否されました: %v", err)
+		}
+		if _, err := strict.ParseAccountID(v4.String()); !errors.Is(err, domain.ErrInvalidAccountID) {
+			t.Errorf("❌ 厳格モードでv4が受け付けられました: %v", err)
+		}
+	})
+
+	t.Run("文字列からの解析", func(t *testing.T) {
+		if id, err := domain.ParseAccountID(v4.String()); err != nil || id != v4 {
+			t.Errorf("❌ 解析に失敗: %v", err)
+		}
+		if _, err := domain.ParseAccountID("not-a-uuid"); !errors.Is(err, domain.ErrInvalidAccountID) {
+			t.Errorf("❌ 不正な文字列が受け付けられました: %v", err)
+		}
+	})
+}
+
+// TestAuthMiddleware_StrictIDVersion 厳格モードでは設定したバージョン以外のアカウントIDを持つトークンを拒否することをテスト
+func TestAuthMiddleware_StrictIDVersion(t *testing.T) {
+	jwtManager := auth.NewJWTManager(auth.JWTConfig{
+		AccessTokenSecret:  "test-access-secret-0123456789abcdef",
+		RefreshTokenSecret: "test-refresh-secret-0123456789abcdef",
+		AccessTokenExpiry:  time.Minute,
+		RefreshTokenExpiry: time.Hour,
+		Issuer:             "jwt-auth-test",
+	})
+	e := echo.New()
+	e.Use(middleware.NewAuthMiddleware(middleware.AuthConfig{
+		JWTManager: jwtManager,
+		IDs:        domain.IDConfig{Version: domain.IDVersion7, StrictVersion: true},
+	}))
+	e.GET("/api/v1/accounts", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	for accountID, expected := range map[uuid.UUID]int{
+		uuid.Must(uuid.NewV7()): http.StatusOK,
+		uuid.New():              http.StatusUnauthorized,
+	} {
+		token, err := jwtManager.GenerateAccessToken(accountID, "strict@example.com", "user")
+		if err != nil {
+			t.Fatalf("❌ トークンの生成に失敗: %v", err)
+		}
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts", nil)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != expected {
+			t.Errorf("❌ v%d のアカウントID 期待値: %d, 実際: %d", accountID.Version(), expected, rec.Code)
+		}
+	}
+}
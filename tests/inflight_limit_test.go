@@ -0,0 +1,143 @@
+package tests_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/config"
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// TestInflightLimit_Saturation 上限まで処理中のリクエストがあると新しいリクエストを503で拒否することをテスト
+func TestInflightLimit_Saturation(t *testing.T) {
+	const capacity = 3
+	started := make(chan struct{}, capacity)
+	release := make(chan struct{})
+
+	e := echo.New()
+	e.Use(middleware.NewInflightLimitMiddleware(middleware.InflightLimitConfig{
+		Max:       capacity,
+		SkipPaths: []string{"/api/v1/health"},
+	}))
+	e.GET("/api/v1/slow", func(c echo.Context) error {
+		started <- struct{}{}
+		<-release
+		return c.String(http.StatusOK, "done")
+	})
+	e.GET("/api/v1/fast", func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+	e.GET("/api/v1/health", func(c echo.Context) error {
+		return c.String(http.StatusOK, "healthy")
+	})
+
+	srv := httptest.NewServer(e)
+	defer srv.Close()
+
+	// 上限と同じ数のリクエストで埋める
+	var wg sync.WaitGroup
+	statuses := make(chan int, capacity)
+	for i := 0; i < capacity; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := http.Get(srv.URL + "/api/v1/slow")
+			if err != nil {
+				t.Errorf("❌ リクエスト送信に失敗: %v", err)
+				return
+			}
+			defer resp.Body.Close()
+			statuses <- resp.StatusCode
+		}()
+	}
+	for i := 0; i < capacity; i++ {
+		<-started
+	}
+
+	t.Run("上限を超えたリクエストは503", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			resp, err := http.Get(srv.URL + "/api/v1/fast")
+			if err != nil {
+				t.Fatalf("❌ リクエスト送信に失敗: %v", err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+
+			if resp.StatusCode != http.StatusServiceUnavailable {
+				t.Fatalf("❌ ステータスコード 期待値: 503, 実際: %d", resp.StatusCode)
+			}
+			if resp.Header.Get(echo.HeaderRetryAfter) == "" {
+				t.Error("❌ Retry-Afterヘッダーがありません")
+			}
+			var apiErr api.Error
+			if err := json.Unmarshal(body, &apiErr); err != nil || apiErr.Code != api.ErrorCodeServiceUnavailable {
+				t.Errorf("❌ エラーコード 期待値: service_unavailable, 実際: %s (%v)", apiErr.Code, err)
+			}
+		}
+	})
+
+	t.Run("ヘルスチェックは制限しない", func(t *testing.T) {
+		resp, err := http.Get(srv.URL + "/api/v1/health")
+		if err != nil {
+			t.Fatalf("❌ リクエスト送信に失敗: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("❌ ステータスコード 期待値: 200, 実際: %d", resp.StatusCode)
+		}
+	})
+
+	// 処理中のリクエストが完了すると再び受け付ける
+	close(release)
+	wg.Wait()
+	close(statuses)
+	for status := range statuses {
+		if status != http.StatusOK {
+			t.Errorf("❌ 処理中のリクエストのステータスコード 期待値: 200, 実際: %d", status)
+		}
+	}
+
+	t.Run("完了後は受け付ける", func(t *testing.T) {
+		resp, err := http.Get(srv.URL + "/api/v1/fast")
+		if err != nil {
+			t.Fatalf("❌ リクエスト送信に失敗: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("❌ ステータスコード 期待値: 200, 実際: %d", resp.StatusCode)
+		}
+	})
+}
+
+// TestInflightLimit_Config 同時実行数の上限がコネクションプールの大きさから決まることをテスト
+func TestInflightLimit_Config(t *testing.T) {
+	tests := []struct {
+		name         string
+		max          int
+		maxOpenConns int
+		want         int
+	}{
+		{"省略時はコネクション数の倍数", 0, 25, 100},
+		{"明示した上限を優先", 50, 25, 50},
+		{"負の値で無効", -1, 25, 0},
+		{"コネクション数が無制限なら無効", 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Server:   config.ServerConfig{MaxInflightRequests: tt.max, InflightPerConnection: 4},
+				Database: config.DatabaseConfig{MaxOpenConns: tt.maxOpenConns},
+			}
+			if got := cfg.InflightLimit(); got != tt.want {
+				t.Errorf("❌ 上限 期待値: %d, 実際: %d", tt.want, got)
+			}
+		})
+	}
+}
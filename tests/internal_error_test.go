@@ -0,0 +1,131 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/handler"
+	"github.com/aida0710/jwt-auth/internal/logger"
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+)
+
+// failingAccountRepository 内部の詳細を含むエラーでラップして返すアカウントリポジトリ
+type failingAccountRepository struct {
+	*fakeAccountRepository
+	err error
+}
+
+func (r *failingAccountRepository) GetByID(_ context.Context, id uuid.UUID) (*domain.Account, error) {
+	return nil, fmt.Errorf("failed to select account %s from accounts_secret_shard: %w", id, r.err)
+}
+
+// TestInternalError_NoDetailLeak 5xxでは内部エラーの詳細を返さずにログに出力し、4xxでは安全なメッセージを返すことをテスト
+func TestInternalError_NoDetailLeak(t *testing.T) {
+	accountRepo := &failingAccountRepository{fakeAccountRepository: newFakeAccountRepository()}
+	projectRepo := newFakeProjectRepository()
+	accountUsecase := usecase.NewAccountUsecase(accountRepo, projectRepo, nil, nil, nil, nil, fakeTxManager{}, nil, usecase.AccountConfig{})
+	projectUsecase := usecase.NewProjectUsecase(projectRepo, accountRepo, fakeTxManager{}, usecase.ProjectConfig{})
+	authUsecase, _, _ := newTestAuthUsecase(t)
+	server := handler.NewServer(accountUsecase, projectUsecase, handler.NewAuthHandler(authUsecase), handler.HealthConfig{}, handler.PrivacyConfig{}, logger.NewLoggerWithOutput("error", "json", io.Discard))
+
+	var logs bytes.Buffer
+	e := echo.New()
+	e.HTTPErrorHandler = middleware.NewErrorHandler(logger.NewLoggerWithOutput("info", "json", &logs)).HTTPErrorHandler
+	e.Use(echomiddleware.RequestID())
+	e.Use(middleware.Correlation)
+	api.RegisterHandlersWithBaseURL(e, server, "/api/v1")
+
+	getAccount := func(t *testing.T) (*httptest.ResponseRecorder, api.Error) {
+		t.Helper()
+		logs.Reset()
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/accounts/"+domain.NewID().String(), nil))
+
+		var body api.Error
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v, body: %s", err, rec.Body.String())
+		}
+		return rec, body
+	}
+
+	t.Run("5xxは汎用のメッセージとリクエストIDのみを返す", func(t *testing.T) {
+		accountRepo.err = errors.New("dial tcp 10.0.0.1:3306: connection refused")
+		rec, body := getAccount(t)
+		if rec.Code != http.StatusInternalServerError || body.Code != api.ErrorCodeInternalError {
+			t.Fatalf("❌ 期待値: 500 internal_error, 実際: %d %s", rec.Code, body.Code)
+		}
+		for _, detail := range []string{"10.0.0.1", "accounts_secret_shard", "connection refused"} {
+			if strings.Contains(rec.Body.String(), detail) {
+				t.Errorf("❌ レスポンスに内部エラーの詳細 %q が含まれています: %s", detail, rec.Body.String())
+			}
+		}
+		if body.Error != http.StatusText(http.StatusInternalServerError) {
+			t.Errorf("❌ メッセージ 期待値: %s, 実際: %s", http.StatusText(http.StatusInternalServerError), body.Error)
+		}
+
+		requestID := rec.Header().Get(echo.HeaderXRequestID)
+		if body.RequestId == nil || *body.RequestId != requestID {
+			t.Fatalf("❌ request_id 期待値: %s, 実際: %v", requestID, body.RequestId)
+		}
+
+		var entry map[string]interface{}
+		if err := json.Unmarshal(bytes.TrimSpace(logs.Bytes()), &entry); err != nil {
+			t.Fatalf("❌ ログがJSONの1行ではありません: %v, log: %s", err, logs.String())
+		}
+		if entry["request_id"] != requestID {
+			t.Errorf("❌ ログのrequest_id 期待値: %s, 実際: %v", requestID, entry["request_id"])
+		}
+		if errMsg, _ := entry["error"].(string); !strings.Contains(errMsg, "accounts_secret_shard") || !strings.Contains(errMsg, "10.0.0.1:3306") {
+			t.Errorf("❌ ログにラップされたエラー全体が出力されていません: %v", entry["error"])
+		}
+	})
+
+	t.Run("4xxはドメインのエラーのメッセージのみを返す", func(t *testing.T) {
+		accountRepo.err = domain.ErrAccountNotFound
+		rec, body := getAccount(t)
+		if rec.Code != http.StatusNotFound || body.Code != api.ErrorCodeAccountNotFound {
+			t.Fatalf("❌ 期待値: 404 account_not_found, 実際: %d %s", rec.Code, body.Code)
+		}
+		if body.Error != domain.ErrAccountNotFound.Error() {
+			t.Errorf("❌ メッセージ 期待値: %s, 実際: %s", domain.ErrAccountNotFound.Error(), body.Error)
+		}
+		if body.RequestId != nil {
+			t.Errorf("❌ 4xxにrequest_idが含まれています: %s", *body.RequestId)
+		}
+	})
+}
+
+// TestErrorMessage ラップされたドメインのエラーから返して安全なメッセージを取り出すことをテスト
+func TestErrorMessage(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"ラップされていないエラー", domain.ErrProjectNotFound, domain.ErrProjectNotFound.Error()},
+		{"外側のラップを除く", fmt.Errorf("failed to update account %s: %w", uuid.Nil, domain.ErrDuplicateEmail), domain.ErrDuplicateEmail.Error()},
+		{"ドメインの検証の詳細は残す", fmt.Errorf("failed to create project: %w", fmt.Errorf("%w: must be at most 20 characters", domain.ErrInvalidDescription)), domain.ErrInvalidDescription.Error() + ": must be at most 20 characters"},
+		{"対応の無いエラー", errors.New("dial tcp 10.0.0.1:3306: connection refused"), http.StatusText(http.StatusInternalServerError)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if message := handler.ErrorMessage(tc.err); message != tc.expected {
+				t.Errorf("❌ 期待値: %s, 実際: %s", tc.expected, message)
+			}
+		})
+	}
+}
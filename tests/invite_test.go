@@ -0,0 +1,184 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+)
+
+// newInviteTestAuthUsecase サインアップを無効にし、招待を有効にした認証ユースケースを作成
+func newInviteTestAuthUsecase(t *testing.T) (*usecase.AuthUsecase, *fakeInviteRepository) {
+	t.Helper()
+
+	inviteRepo := newFakeInviteRepository()
+	jwtManager := auth.NewJWTManager(auth.JWTConfig{
+		AccessTokenSecret:  "test-access-secret-0123456789abcdef",
+		RefreshTokenSecret: "test-refresh-secret-0123456789abcdef",
+		AccessTokenExpiry:  time.Minute,
+		RefreshTokenExpiry: time.Hour,
+		Issuer:             "jwt-auth-test",
+		Audience:           []string{"jwt-auth-test"},
+	})
+
+	authUsecase := usecase.NewAuthUsecase(
+		newFakeAccountRepository(),
+		newFakeRefreshTokenRepository(),
+		&fakeSecurityAuditLogRepository{},
+		nil,
+		nil,
+		inviteRepo,
+		fakeTxManager{},
+		nil,
+		jwtManager,
+		usecase.AuthConfig{RefreshTokenExpiry: time.Hour, DisableSignup: true, InviteExpiry: time.Hour},
+	)
+	return authUsecase, inviteRepo
+}
+
+// TestInvite_SignUp 招待制のサインアップで、有効な招待のみ1回だけ使用できることをテスト
+func TestInvite_SignUp(t *testing.T) {
+	ctx := context.Background()
+	admin := usecase.Actor{ID: domain.NewID(), Role: domain.RoleAdmin}
+	signUp := func(authUsecase *usecase.AuthUsecase, email, token string) (*usecase.AuthTokens, error) {
+		return authUsecase.SignUp(ctx, usecase.SignUpInput{
+			Email:       email,
+			Password:    "SecurePassword123!",
+			Name:        "Invited User",
+			InviteToken: token,
+		})
+	}
+
+	t.Run("有効な招待でサインアップでき、招待のロールとテナントが割り当てられる", func(t *testing.T) {
+		authUsecase, inviteRepo := newInviteTestAuthUsecase(t)
+		created, err := authUsecase.CreateInvite(ctx, usecase.CreateInviteInput{Role: domain.RoleAdmin, TenantID: "acme", Actor: admin})
+		if err != nil {
+			t.Fatalf("❌ 招待の作成に失敗: %v", err)
+		}
+		if created.Token == "" || created.Invite.TokenHash != auth.HashToken(created.Token) {
+			t.Fatalf("❌ トークンのハッシュが保存されていません: %+v", created.Invite)
+		}
+
+		tokens, err := signUp(authUsecase, "invited@example.com", created.Token)
+		if err != nil {
+			t.Fatalf("❌ 招待でのサインアップに失敗: %v", err)
+		}
+		if tokens.Account.Role != domain.RoleAdmin || tokens.Account.TenantID != "acme" {
+			t.Errorf("❌ ロールとテナント 期待値: admin/acme, 実際: %s/%s", tokens.Account.Role, tokens.Account.TenantID)
+		}
+		invite := inviteRepo.get(created.Invite.ID)
+		if invite.ConsumedAt == nil || invite.ConsumedBy == nil || *invite.ConsumedBy != tokens.Account.ID {
+			t.Errorf("❌ 招待が消費済みになっていません: %+v", invite)
+		}
+	})
+
+	t.Run("招待なしでは拒否する", func(t *testing.T) {
+		authUsecase, _ := newInviteTestAuthUsecase(t)
+		if _, err := signUp(authUsecase, "uninvited@example.com", ""); !errors.Is(err, domain.ErrSignupDisabled) {
+			t.Errorf("❌ 期待値: ErrSignupDisabled, 実際: %v", err)
+		}
+	})
+
+	t.Run("使用済みの招待は拒否する", func(t *testing.T) {
+		authUsecase, _ := newInviteTestAuthUsecase(t)
+		created, err := authUsecase.CreateInvite(ctx, usecase.CreateInviteInput{Actor: admin})
+		if err != nil {
+			t.Fatalf("❌ 招待の作成に失敗: %v", err)
+		}
+		if _, err := signUp(authUsecase, "first@example.com", created.Token); err != nil {
+			t.Fatalf("❌ 1回目のサインアップに失敗: %v", err)
+		}
+		if _, err := signUp(authUsecase, "second@example.com", created.Token); !errors.Is(err, domain.ErrInvalidInvite) {
+			t.Errorf("❌ 2回目 期待値: ErrInvalidInvite, 実際: %v", err)
+		}
+	})
+
+	t.Run("期限切れの招待は拒否する", func(t *testing.T) {
+		authUsecase, inviteRepo := newInviteTestAuthUsecase(t)
+		const token = "expired-invite-token"
+		expired := domain.NewInvite(admin.ID, auth.HashToken(token), domain.RoleUser, domain.DefaultTenantID, time.Now().Add(-time.Minute))
+		if err := inviteRepo.Create(ctx, expired); err != nil {
+			t.Fatalf("❌ 招待の作成に失敗: %v", err)
+		}
+		if _, err := signUp(authUsecase, "expired@example.com", token); !errors.Is(err, domain.ErrInvalidInvite) {
+			t.Errorf("❌ 期待値: ErrInvalidInvite, 実際: %v", err)
+		}
+	})
+
+	t.Run("存在しない招待は拒否する", func(t *testing.T) {
+		authUsecase, _ := newInviteTestAuthUsecase(t)
+		if _, err := signUp(authUsecase, "unknown@example.com", "unknown-invite-token"); !errors.Is(err, domain.ErrInvalidInvite) {
+			t.Errorf("❌ 期待値: ErrInvalidInvite, 実際: %v", err)
+		}
+	})
+
+	t.Run("サインアップに失敗した場合は招待を消費しない", func(t *testing.T) {
+		authUsecase, inviteRepo := newInviteTestAuthUsecase(t)
+		first, _ := authUsecase.CreateInvite(ctx, usecase.CreateInviteInput{Actor: admin})
+		second, _ := authUsecase.CreateInvite(ctx, usecase.CreateInviteInput{Actor: admin})
+		if _, err := signUp(authUsecase, "taken@example.com", first.Token); err != nil {
+			t.Fatalf("❌ サインアップに失敗: %v", err)
+		}
+		if _, err := signUp(authUsecase, "taken@example.com", second.Token); !errors.Is(err, domain.ErrEmailAlreadyExists) {
+			t.Fatalf("❌ 期待値: ErrEmailAlreadyExists, 実際: %v", err)
+		}
+		if invite := inviteRepo.get(second.Invite.ID); invite.ConsumedAt != nil {
+			t.Errorf("❌ 失敗したサインアップで招待が消費されました: %+v", invite)
+		}
+	})
+}
+
+// TestInvite_HTTP 招待の作成と、招待を使用したサインアップのエンドポイントをテスト
+func TestInvite_HTTP(t *testing.T) {
+	authUsecase, _ := newInviteTestAuthUsecase(t)
+	srv := newAuthTestServerWithUsecase(t, authUsecase)
+	admin := map[string]string{
+		"X-Test-Account": domain.NewID().String(),
+		"X-Test-Role":    string(domain.RoleAdmin),
+	}
+
+	resp, body := sendTestRequest(t, srv, http.MethodPost, "/api/v1/admin/invites", admin, map[string]string{"role": "superuser"})
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("❌ 不正なロール 期待値: 400, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+
+	resp, body = sendTestRequest(t, srv, http.MethodPost, "/api/v1/admin/invites", admin, map[string]string{})
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("❌ ステータスコード 期待値: 201, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+	var invite api.Invite
+	if err := json.Unmarshal(body, &invite); err != nil {
+		t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+	}
+	if invite.Token == "" || invite.Role != api.InviteRoleUser || !invite.ExpiresAt.After(time.Now()) {
+		t.Fatalf("❌ 招待が不正です: %+v", invite)
+	}
+
+	signUp := map[string]string{
+		"email":        "http-invited@example.com",
+		"password":     "SecurePassword123!",
+		"name":         "Invited User",
+		"invite_token": invite.Token,
+	}
+	resp, body = sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/signup", nil, signUp)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("❌ 招待でのサインアップ 期待値: 201, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+
+	signUp["email"] = "http-reused@example.com"
+	resp, body = sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/signup", nil, signUp)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("❌ 使用済みの招待 期待値: 400, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+	var apiErr api.Error
+	if err := json.Unmarshal(body, &apiErr); err != nil || apiErr.Code != api.ErrorCodeInvalidInvite {
+		t.Errorf("❌ エラーコード 期待値: invalid_invite, 実際: %s (%v)", body, err)
+	}
+}
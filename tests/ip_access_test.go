@@ -0,0 +1,114 @@
+package tests_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// newIPAccessTestEcho IPアドレス制限を適用したテスト用のEchoを作成
+func newIPAccessTestEcho(t *testing.T, config middleware.IPAccessConfig, trustedProxies ...string) *echo.Echo {
+	t.Helper()
+
+	ipAccess, err := middleware.NewIPAccessMiddleware(config)
+	if err != nil {
+		t.Fatalf("❌ ミドルウェアの作成に失敗: %v", err)
+	}
+
+	e := newTrustedProxyTestEcho(t, trustedProxies...)
+	e.Use(ipAccess)
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	e.GET("/api/v1/admin/projects", ok)
+	e.GET("/api/v1/accounts", ok)
+	return e
+}
+
+// requestFrom 接続元アドレスとヘッダーを指定してリクエストを処理し、ステータスコードを返す
+func requestFrom(e *echo.Echo, path, remoteAddr string, headers map[string]string) int {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = remoteAddr
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+// TestIPAccessMiddleware 管理者エンドポイントのIPアドレス制限をテスト
+func TestIPAccessMiddleware(t *testing.T) {
+	e := newIPAccessTestEcho(t, middleware.IPAccessConfig{
+		PathPrefixes: []string{"/api/v1/admin"},
+		Allowlist:    []string{"10.0.0.0/8", "2001:db8::/32", "192.0.2.10"},
+		Denylist:     []string{"10.0.0.66", "2001:db8:bad::/48"},
+	}, "172.16.0.1")
+
+	cases := []struct {
+		name       string
+		path       string
+		remoteAddr string
+		headers    map[string]string
+		expected   int
+	}{
+		{"許可リストのIPv4は200", "/api/v1/admin/projects", "10.1.2.3:5000", nil, http.StatusOK},
+		{"単一アドレス指定のIPv4は200", "/api/v1/admin/projects", "192.0.2.10:5000", nil, http.StatusOK},
+		{"許可リスト外のIPv4は403", "/api/v1/admin/projects", "203.0.113.5:5000", nil, http.StatusForbidden},
+		{"拒否リストは許可リストより優先", "/api/v1/admin/projects", "10.0.0.66:5000", nil, http.StatusForbidden},
+		{"許可リストのIPv6は200", "/api/v1/admin/projects", "[2001:db8::1]:5000", nil, http.StatusOK},
+		{"拒否リストのIPv6は403", "/api/v1/admin/projects", "[2001:db8:bad::1]:5000", nil, http.StatusForbidden},
+		{"許可リスト外のIPv6は403", "/api/v1/admin/projects", "[2001:db9::1]:5000", nil, http.StatusForbidden},
+		{"IPv4射影IPv6アドレスはIPv4として判定", "/api/v1/admin/projects", "[::ffff:10.1.2.3]:5000", nil, http.StatusOK},
+		{"対象外のパスは制限しない", "/api/v1/accounts", "203.0.113.5:5000", nil, http.StatusOK},
+		{
+			"信頼しない接続元のX-Forwarded-Forは無視",
+			"/api/v1/admin/projects", "203.0.113.5:5000",
+			map[string]string{echo.HeaderXForwardedFor: "10.1.2.3"},
+			http.StatusForbidden,
+		},
+		{
+			"信頼するプロキシ経由はX-Forwarded-Forで判定",
+			"/api/v1/admin/projects", "172.16.0.1:5000",
+			map[string]string{echo.HeaderXForwardedFor: "10.1.2.3"},
+			http.StatusOK,
+		},
+		{
+			"信頼するプロキシ経由でも偽装された先頭の値は使わない",
+			"/api/v1/admin/projects", "172.16.0.1:5000",
+			map[string]string{echo.HeaderXForwardedFor: "10.1.2.3, 203.0.113.5"},
+			http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if code := requestFrom(e, tc.path, tc.remoteAddr, tc.headers); code != tc.expected {
+				t.Errorf("❌ ステータスコード 期待値: %d, 実際: %d", tc.expected, code)
+			}
+		})
+	}
+
+	t.Run("許可リストが空なら拒否リスト以外を許可", func(t *testing.T) {
+		e := newIPAccessTestEcho(t, middleware.IPAccessConfig{
+			PathPrefixes: []string{"/api/v1/admin"},
+			Denylist:     []string{"203.0.113.0/24"},
+		})
+		if code := requestFrom(e, "/api/v1/admin/projects", "198.51.100.1:5000", nil); code != http.StatusOK {
+			t.Errorf("❌ ステータスコード 期待値: 200, 実際: %d", code)
+		}
+		if code := requestFrom(e, "/api/v1/admin/projects", "203.0.113.5:5000", nil); code != http.StatusForbidden {
+			t.Errorf("❌ ステータスコード 期待値: 403, 実際: %d", code)
+		}
+	})
+
+	t.Run("不正なCIDRは作成時にエラー", func(t *testing.T) {
+		for _, invalid := range []string{"10.0.0.0/33", "not-an-ip", "2001:db8::/129"} {
+			_, err := middleware.NewIPAccessMiddleware(middleware.IPAccessConfig{Allowlist: []string{invalid}})
+			if err == nil {
+				t.Errorf("❌ %q でエラーになるべきです", invalid)
+			}
+		}
+	})
+}
@@ -0,0 +1,150 @@
+package tests_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/config"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// newAlgorithmTestJWTManager シークレットを共有し、署名アルゴリズムだけが異なるJWTManagerを作成
+func newAlgorithmTestJWTManager(alg string) *auth.JWTManager {
+	return auth.NewJWTManager(auth.JWTConfig{
+		AccessTokenSecret:  strings.Repeat("a", 64),
+		RefreshTokenSecret: strings.Repeat("r", 64),
+		AccessTokenExpiry:  time.Minute,
+		RefreshTokenExpiry: time.Hour,
+		Issuer:             "jwt-auth-test",
+		Audience:           []string{"jwt-auth-test"},
+		SigningAlgorithm:   alg,
+	})
+}
+
+// tokenAlg トークンのalgヘッダーを返す（署名は検証しない）
+func tokenAlg(t *testing.T, tokenString string) string {
+	t.Helper()
+	token, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+	if err != nil {
+		t.Fatalf("❌ トークンのパースに失敗: %v", err)
+	}
+	alg, _ := token.Header["alg"].(string)
+	return alg
+}
+
+// TestJWTManager_SigningAlgorithm 設定した署名アルゴリズムで発行・検証できることをテスト
+func TestJWTManager_SigningAlgorithm(t *testing.T) {
+	for _, alg := range []string{"HS256", "HS384", "HS512"} {
+		t.Run(alg, func(t *testing.T) {
+			m := newAlgorithmTestJWTManager(alg)
+
+			accessToken, err := m.GenerateAccessToken(uuid.New(), "alg@example.com", "user")
+			if err != nil {
+				t.Fatalf("❌ アクセストークンの生成に失敗: %v", err)
+			}
+			if got := tokenAlg(t, accessToken); got != alg {
+				t.Errorf("❌ アクセストークンのalg 期待値: %s, 実際: %s", alg, got)
+			}
+			if _, err := m.ValidateAccessToken(accessToken); err != nil {
+				t.Errorf("❌ アクセストークンの検証に失敗: %v", err)
+			}
+
+			now := time.Now()
+			refreshToken, err := m.SignRefreshToken(uuid.New(), uuid.New(), now, now.Add(time.Hour))
+			if err != nil {
+				t.Fatalf("❌ リフレッシュトークンの生成に失敗: %v", err)
+			}
+			if got := tokenAlg(t, refreshToken); got != alg {
+				t.Errorf("❌ リフレッシュトークンのalg 期待値: %s, 実際: %s", alg, got)
+			}
+			if _, err := m.ValidateRefreshToken(refreshToken); err != nil {
+				t.Errorf("❌ リフレッシュトークンの検証に失敗: %v", err)
+			}
+		})
+	}
+
+	t.Run("未指定の場合はHS256", func(t *testing.T) {
+		accessToken, err := newAlgorithmTestJWTManager("").GenerateAccessToken(uuid.New(), "alg@example.com", "user")
+		if err != nil {
+			t.Fatalf("❌ アクセストークンの生成に失敗: %v", err)
+		}
+		if got := tokenAlg(t, accessToken); got != "HS256" {
+			t.Errorf("❌ alg 期待値: HS256, 実際: %s", got)
+		}
+	})
+
+	t.Run("未対応のアルゴリズムでは発行しない", func(t *testing.T) {
+		if _, err := newAlgorithmTestJWTManager("HS1024").GenerateAccessToken(uuid.New(), "alg@example.com", "user"); err == nil {
+			t.Error("❌ 未対応のアルゴリズムでトークンが発行されました")
+		}
+	})
+}
+
+// TestJWTManager_RejectsOtherHMACSize 同じシークレットでも設定と異なるHMACで署名されたトークンを拒否することをテスト
+func TestJWTManager_RejectsOtherHMACSize(t *testing.T) {
+	hs256 := newAlgorithmTestJWTManager("HS256")
+	hs512 := newAlgorithmTestJWTManager("HS512")
+
+	accessToken, err := hs256.GenerateAccessToken(uuid.New(), "alg@example.com", "user")
+	if err != nil {
+		t.Fatalf("❌ アクセストークンの生成に失敗: %v", err)
+	}
+	if _, err := hs512.ValidateAccessToken(accessToken); err == nil || !strings.Contains(err.Error(), "invalid signing algorithm") {
+		t.Errorf("❌ HS256のトークンをHS512で検証 期待値: invalid signing algorithm, 実際: %v", err)
+	}
+
+	now := time.Now()
+	refreshToken, err := hs512.SignRefreshToken(uuid.New(), uuid.New(), now, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("❌ リフレッシュトークンの生成に失敗: %v", err)
+	}
+	if _, err := hs256.ValidateRefreshToken(refreshToken); err == nil || !strings.Contains(err.Error(), "invalid signing algorithm") {
+		t.Errorf("❌ HS512のトークンをHS256で検証 期待値: invalid signing algorithm, 実際: %v", err)
+	}
+}
+
+// TestConfig_SecretLengthPerAlgorithm シークレットの最小長が署名アルゴリズムに応じて変わることをテスト
+func TestConfig_SecretLengthPerAlgorithm(t *testing.T) {
+	load := func(t *testing.T, alg string, accessSecretLength, refreshSecretLength int) error {
+		t.Helper()
+		t.Setenv("APP_ENV", "development")
+		t.Setenv("JWT_SIGNING_ALGORITHM", alg)
+		t.Setenv("JWT_ACCESS_TOKEN_SECRET", strings.Repeat("a", accessSecretLength))
+		t.Setenv("JWT_REFRESH_TOKEN_SECRET", strings.Repeat("r", refreshSecretLength))
+		_, err := config.LoadConfig()
+		return err
+	}
+
+	tests := []struct {
+		alg       string
+		minLength int
+	}{
+		{"HS256", 32},
+		{"HS384", 48},
+		{"HS512", 64},
+	}
+	for _, tt := range tests {
+		t.Run(tt.alg, func(t *testing.T) {
+			if err := load(t, tt.alg, tt.minLength, tt.minLength); err != nil {
+				t.Errorf("❌ %dバイトのシークレットが拒否されました: %v", tt.minLength, err)
+			}
+			err := load(t, tt.alg, tt.minLength-1, tt.minLength)
+			if err == nil || !strings.Contains(err.Error(), "JWT_ACCESS_TOKEN_SECRET") || !strings.Contains(err.Error(), tt.alg) {
+				t.Errorf("❌ 短いアクセストークンシークレット 期待値: JWT_ACCESS_TOKEN_SECRETのエラー, 実際: %v", err)
+			}
+			err = load(t, tt.alg, tt.minLength, tt.minLength-1)
+			if err == nil || !strings.Contains(err.Error(), "JWT_REFRESH_TOKEN_SECRET") {
+				t.Errorf("❌ 短いリフレッシュトークンシークレット 期待値: JWT_REFRESH_TOKEN_SECRETのエラー, 実際: %v", err)
+			}
+		})
+	}
+
+	t.Run("未対応のアルゴリズムは起動時に拒否する", func(t *testing.T) {
+		if err := load(t, "RS256", 64, 64); err == nil || !strings.Contains(err.Error(), "JWT_SIGNING_ALGORITHM") {
+			t.Errorf("❌ 期待値: JWT_SIGNING_ALGORITHMのエラー, 実際: %v", err)
+		}
+	})
+}
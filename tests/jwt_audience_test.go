@@ -0,0 +1,123 @@
+package tests_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/google/uuid"
+)
+
+// newAudienceTestJWTManager Audienceと検証方法を指定したテスト用のJWTManagerを作成
+func newAudienceTestJWTManager(audience []string, mode auth.AudienceMatchMode) *auth.JWTManager {
+	return auth.NewJWTManager(auth.JWTConfig{
+		AccessTokenSecret:  "test-access-secret-0123456789abcdef",
+		RefreshTokenSecret: "test-refresh-secret-0123456789abcdef",
+		AccessTokenExpiry:  time.Minute,
+		RefreshTokenExpiry: time.Hour,
+		Issuer:             "jwt-auth-test",
+		Audience:           audience,
+		AudienceMatchMode:  mode,
+	})
+}
+
+// TestJWTManager_AudienceMatchMode Audienceの検証方法ごとの判定をテスト
+func TestJWTManager_AudienceMatchMode(t *testing.T) {
+	cases := []struct {
+		name      string
+		tokenAud  []string
+		configAud []string
+		mode      auth.AudienceMatchMode
+		valid     bool
+	}{
+		{"exact: 順序違いの完全一致は許可", []string{"web", "mobile"}, []string{"mobile", "web"}, auth.AudienceMatchExact, true},
+		{"exact: 一部のみ重なる場合は拒否", []string{"web", "mobile"}, []string{"web"}, auth.AudienceMatchExact, false},
+		{"exact: 重ならない場合は拒否", []string{"web"}, []string{"admin"}, auth.AudienceMatchExact, false},
+		{"any: 一部のみ重なる場合は許可", []string{"web", "mobile"}, []string{"web", "admin"}, auth.AudienceMatchAny, true},
+		{"any: 重ならない場合は拒否", []string{"web", "mobile"}, []string{"admin"}, auth.AudienceMatchAny, false},
+		{"未指定はexactとして扱う", []string{"web", "mobile"}, []string{"web"}, "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			issuer := newAudienceTestJWTManager(tc.tokenAud, auth.AudienceMatchExact)
+			token, err := issuer.GenerateAccessToken(uuid.New(), "aud@example.com", "user")
+			if err != nil {
+				t.Fatalf("❌ トークンの生成に失敗: %v", err)
+			}
+
+			_, err = newAudienceTestJWTManager(tc.configAud, tc.mode).ValidateAccessToken(token)
+			if tc.valid && err != nil {
+				t.Errorf("❌ 許可されるべきトークンが拒否されました: %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Error("❌ 拒否されるべきトークンが許可されました")
+			}
+		})
+	}
+}
+
+// TestJWTManager_AcceptedAudiences Audienceの移行中に、移行前のAudienceで発行したトークンも受け入れることをテスト
+func TestJWTManager_AcceptedAudiences(t *testing.T) {
+	// 移行前のAudienceで発行したトークン
+	oldToken, err := newAudienceTestJWTManager([]string{"old-api"}, auth.AudienceMatchExact).GenerateAccessToken(uuid.New(), "aud@example.com", "user")
+	if err != nil {
+		t.Fatalf("❌ トークンの生成に失敗: %v", err)
+	}
+	otherToken, err := newAudienceTestJWTManager([]string{"other-api"}, auth.AudienceMatchExact).GenerateAccessToken(uuid.New(), "aud@example.com", "user")
+	if err != nil {
+		t.Fatalf("❌ トークンの生成に失敗: %v", err)
+	}
+	mixedToken, err := newAudienceTestJWTManager([]string{"old-api", "other-api"}, auth.AudienceMatchExact).GenerateAccessToken(uuid.New(), "aud@example.com", "user")
+	if err != nil {
+		t.Fatalf("❌ トークンの生成に失敗: %v", err)
+	}
+
+	for _, mode := range []auth.AudienceMatchMode{auth.AudienceMatchExact, auth.AudienceMatchAny} {
+		t.Run(string(mode), func(t *testing.T) {
+			migrating := auth.NewJWTManager(auth.JWTConfig{
+				AccessTokenSecret:  "test-access-secret-0123456789abcdef",
+				RefreshTokenSecret: "test-refresh-secret-0123456789abcdef",
+				AccessTokenExpiry:  time.Minute,
+				RefreshTokenExpiry: time.Hour,
+				Issuer:             "jwt-auth-test",
+				Audience:           []string{"new-api"},
+				AudienceMatchMode:  mode,
+				AcceptedAudiences:  []string{"old-api"},
+			})
+
+			newToken, err := migrating.GenerateAccessToken(uuid.New(), "aud@example.com", "user")
+			if err != nil {
+				t.Fatalf("❌ トークンの生成に失敗: %v", err)
+			}
+			claims, err := migrating.ValidateAccessToken(newToken)
+			if err != nil {
+				t.Fatalf("❌ 新しいAudienceのトークンが拒否されました: %v", err)
+			}
+			if len(claims.Audience) != 1 || claims.Audience[0] != "new-api" {
+				t.Errorf("❌ 発行したトークンのAudience 期待値: [new-api], 実際: %v", claims.Audience)
+			}
+
+			if _, err := migrating.ValidateAccessToken(oldToken); err != nil {
+				t.Errorf("❌ 移行前のAudienceのトークンが拒否されました: %v", err)
+			}
+			if _, err := migrating.ValidateAccessToken(otherToken); err == nil {
+				t.Error("❌ 受け入れていないAudienceのトークンが許可されました")
+			}
+
+			_, err = migrating.ValidateAccessToken(mixedToken)
+			if mode == auth.AudienceMatchExact && err == nil {
+				t.Error("❌ exact: 受け入れていない値を含むトークンが許可されました")
+			}
+			if mode == auth.AudienceMatchAny && err != nil {
+				t.Errorf("❌ any: いずれかが一致するトークンが拒否されました: %v", err)
+			}
+		})
+	}
+
+	t.Run("移行後は移行前のAudienceのトークンを拒否する", func(t *testing.T) {
+		if _, err := newAudienceTestJWTManager([]string{"new-api"}, auth.AudienceMatchExact).ValidateAccessToken(oldToken); err == nil {
+			t.Error("❌ AcceptedAudiencesを外した後も移行前のトークンが許可されました")
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package tests_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/google/uuid"
+)
+
+// TestJWTManager_ExtraClaims アクセストークンの追加クレームをテスト
+func TestJWTManager_ExtraClaims(t *testing.T) {
+	manager := newAudienceTestJWTManager([]string{"jwt-auth-test"}, auth.AudienceMatchExact)
+	accountID := uuid.New()
+
+	t.Run("追加クレームが往復する", func(t *testing.T) {
+		token, err := manager.GenerateAccessTokenWithClaims(accountID, "tenant@example.com", "user", map[string]interface{}{
+			"org":  "acme",
+			"plan": map[string]interface{}{"tier": "pro", "seats": 5},
+		})
+		if err != nil {
+			t.Fatalf("❌ トークンの生成に失敗: %v", err)
+		}
+
+		claims, err := manager.ValidateAccessToken(token)
+		if err != nil {
+			t.Fatalf("❌ トークンの検証に失敗: %v", err)
+		}
+		if claims.Extra["org"] != "acme" {
+			t.Errorf("❌ org 期待値: acme, 実際: %v", claims.Extra["org"])
+		}
+		plan, ok := claims.Extra["plan"].(map[string]interface{})
+		if !ok || plan["tier"] != "pro" || plan["seats"] != float64(5) {
+			t.Errorf("❌ plan が復元されていません: %v", claims.Extra["plan"])
+		}
+		if len(claims.Extra) != 2 {
+			t.Errorf("❌ 追加クレームに標準クレームが混入しています: %v", claims.Extra)
+		}
+		if claims.AccountID != accountID.String() || claims.Email != "tenant@example.com" || claims.Role != "user" {
+			t.Errorf("❌ 標準のクレームが変化しています: %+v", claims)
+		}
+	})
+
+	t.Run("追加クレームが無い場合はnil", func(t *testing.T) {
+		token, err := manager.GenerateAccessToken(accountID, "plain@example.com", "user")
+		if err != nil {
+			t.Fatalf("❌ トークンの生成に失敗: %v", err)
+		}
+		claims, err := manager.ValidateAccessToken(token)
+		if err != nil {
+			t.Fatalf("❌ トークンの検証に失敗: %v", err)
+		}
+		if claims.Extra != nil {
+			t.Errorf("❌ 追加クレームが設定されています: %v", claims.Extra)
+		}
+	})
+
+	t.Run("予約済みのクレーム名は拒否する", func(t *testing.T) {
+		for _, name := range []string{"sub", "exp", "aud", "account_id", "email", "role", "tenant_id"} {
+			_, err := manager.GenerateAccessTokenWithClaims(accountID, "tenant@example.com", "user", map[string]interface{}{
+				name: "override",
+			})
+			if !errors.Is(err, auth.ErrReservedClaim) {
+				t.Errorf("❌ %s: 期待値: ErrReservedClaim, 実際: %v", name, err)
+			}
+		}
+	})
+}
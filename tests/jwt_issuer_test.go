@@ -0,0 +1,89 @@
+package tests_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/config"
+	"github.com/google/uuid"
+)
+
+// newIssuerTestJWTManager シークレットを共有し、Issuerだけが異なるJWTManagerを作成
+func newIssuerTestJWTManager(issuer string) *auth.JWTManager {
+	return auth.NewJWTManager(auth.JWTConfig{
+		AccessTokenSecret:  "test-access-secret-0123456789abcdef",
+		RefreshTokenSecret: "test-refresh-secret-0123456789abcdef",
+		AccessTokenExpiry:  time.Minute,
+		RefreshTokenExpiry: time.Hour,
+		Issuer:             issuer,
+		Audience:           []string{"jwt-auth-test"},
+	})
+}
+
+// TestJWTManager_RejectsOtherIssuer 別の環境のIssuerで発行されたトークンを拒否することをテスト
+func TestJWTManager_RejectsOtherIssuer(t *testing.T) {
+	staging := newIssuerTestJWTManager("jwt-auth-api-staging")
+	production := newIssuerTestJWTManager("jwt-auth-api-production")
+
+	accessToken, err := staging.GenerateAccessToken(uuid.New(), "issuer@example.com", "user")
+	if err != nil {
+		t.Fatalf("❌ アクセストークンの生成に失敗: %v", err)
+	}
+	if _, err := production.ValidateAccessToken(accessToken); err == nil || !strings.Contains(err.Error(), "invalid issuer") {
+		t.Errorf("❌ 別のIssuerのアクセストークン 期待値: invalid issuer, 実際: %v", err)
+	}
+	if _, err := staging.ValidateAccessToken(accessToken); err != nil {
+		t.Errorf("❌ 同じIssuerのアクセストークンが拒否されました: %v", err)
+	}
+
+	now := time.Now()
+	refreshToken, err := staging.SignRefreshToken(uuid.New(), uuid.New(), now, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("❌ リフレッシュトークンの生成に失敗: %v", err)
+	}
+	if _, err := production.ValidateRefreshToken(refreshToken); err == nil || !strings.Contains(err.Error(), "invalid issuer") {
+		t.Errorf("❌ 別のIssuerのリフレッシュトークン 期待値: invalid issuer, 実際: %v", err)
+	}
+}
+
+// TestConfig_JWTIssuerPerEnvironment 既定のIssuerを本番環境では拒否し、それ以外では警告することをテスト
+func TestConfig_JWTIssuerPerEnvironment(t *testing.T) {
+	load := func(t *testing.T, env, issuer string) (*config.Config, error) {
+		t.Helper()
+		t.Setenv("APP_ENV", env)
+		t.Setenv("JWT_ISSUER", issuer)
+		t.Setenv("JWT_ACCESS_TOKEN_SECRET", "test-access-secret-0123456789abcdef")
+		t.Setenv("JWT_REFRESH_TOKEN_SECRET", "test-refresh-secret-0123456789abcdef")
+		return config.LoadConfig()
+	}
+
+	t.Run("本番環境で既定値は起動時に拒否する", func(t *testing.T) {
+		_, err := load(t, "production", config.DefaultJWTIssuer)
+		if err == nil || !strings.Contains(err.Error(), "JWT_ISSUER") {
+			t.Errorf("❌ 期待値: JWT_ISSUERのエラー, 実際: %v", err)
+		}
+	})
+
+	t.Run("本番環境で環境ごとの値は許可する", func(t *testing.T) {
+		cfg, err := load(t, "production", "jwt-auth-api-production")
+		if err != nil {
+			t.Fatalf("❌ 設定の読み込みに失敗: %v", err)
+		}
+		if warnings := cfg.Warnings(); len(warnings) != 0 {
+			t.Errorf("❌ 警告 期待値: なし, 実際: %v", warnings)
+		}
+	})
+
+	t.Run("開発環境で既定値は警告に留める", func(t *testing.T) {
+		cfg, err := load(t, "development", config.DefaultJWTIssuer)
+		if err != nil {
+			t.Fatalf("❌ 設定の読み込みに失敗: %v", err)
+		}
+		warnings := cfg.Warnings()
+		if len(warnings) != 1 || !strings.Contains(warnings[0], "JWT_ISSUER") {
+			t.Errorf("❌ 警告 期待値: JWT_ISSUERの警告, 実際: %v", warnings)
+		}
+	})
+}
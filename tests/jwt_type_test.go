@@ -0,0 +1,154 @@
+package tests_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// newTypeTestJWTManager typヘッダーを指定したテスト用のJWTManagerを作成
+func newTypeTestJWTManager(accessType, refreshType string) *auth.JWTManager {
+	return auth.NewJWTManager(auth.JWTConfig{
+		AccessTokenSecret:  "test-access-secret-0123456789abcdef",
+		RefreshTokenSecret: "test-refresh-secret-0123456789abcdef",
+		AccessTokenExpiry:  time.Minute,
+		RefreshTokenExpiry: time.Hour,
+		Issuer:             "jwt-auth-test",
+		Audience:           []string{"jwt-auth-test"},
+		AccessTokenType:    accessType,
+		RefreshTokenType:   refreshType,
+	})
+}
+
+// forgeTypedToken typヘッダー以外は正しいトークンを正規のシークレットで署名して作成
+// typが空の場合はヘッダーからtypを取り除く
+func forgeTypedToken(t *testing.T, claims jwt.Claims, secret, typ string) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	if typ == "" {
+		delete(token.Header, "typ")
+	} else {
+		token.Header["typ"] = typ
+	}
+	signed, err := token.SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("❌ トークンの署名に失敗: %v", err)
+	}
+	return signed
+}
+
+// TestJWTManager_AccessTokenType アクセストークンのtypヘッダーの検証をテスト
+func TestJWTManager_AccessTokenType(t *testing.T) {
+	accountID := uuid.New()
+	now := time.Now()
+	claims := func() *auth.Claims {
+		return &auth.Claims{
+			AccountID: accountID.String(),
+			Email:     "typ@example.com",
+			Role:      "user",
+			RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+				IssuedAt:  jwt.NewNumericDate(now),
+				NotBefore: jwt.NewNumericDate(now),
+				Issuer:    "jwt-auth-test",
+				Subject:   accountID.String(),
+				ID:        uuid.NewString(),
+				Audience:  []string{"jwt-auth-test"},
+			},
+		}
+	}
+	const secret = "test-access-secret-0123456789abcdef"
+
+	cases := []struct {
+		name       string
+		configType string
+		tokenType  string
+		valid      bool
+	}{
+		{"既定: JWTは許可", "", "JWT", true},
+		{"既定: 大文字小文字は区別しない", "", "jwt", true},
+		{"既定: 異なるtypは拒否", "", "rt+jwt", false},
+		{"既定: typが無い場合は拒否", "", "", false},
+		{"カスタム: 一致するtypは許可", "at+jwt", "at+jwt", true},
+		{"カスタム: application/の省略は同一とみなす", "at+jwt", "application/at+jwt", true},
+		{"カスタム: JWTは拒否", "at+jwt", "JWT", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			token := forgeTypedToken(t, claims(), secret, tc.tokenType)
+
+			_, err := newTypeTestJWTManager(tc.configType, "").ValidateAccessToken(token)
+			if tc.valid && err != nil {
+				t.Errorf("❌ 許可されるべきトークンが拒否されました: %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Error("❌ 拒否されるべきトークンが許可されました")
+			}
+		})
+	}
+
+	t.Run("設定したtypで発行される", func(t *testing.T) {
+		manager := newTypeTestJWTManager("at+jwt", "")
+		token, err := manager.GenerateAccessToken(accountID, "typ@example.com", "user")
+		if err != nil {
+			t.Fatalf("❌ トークンの生成に失敗: %v", err)
+		}
+		parsed, _, err := jwt.NewParser().ParseUnverified(token, &auth.Claims{})
+		if err != nil {
+			t.Fatalf("❌ トークンの解析に失敗: %v", err)
+		}
+		if parsed.Header["typ"] != "at+jwt" {
+			t.Errorf("❌ typ 期待値: at+jwt, 実際: %v", parsed.Header["typ"])
+		}
+		if _, err := manager.ValidateAccessToken(token); err != nil {
+			t.Errorf("❌ 発行したトークンの検証に失敗: %v", err)
+		}
+	})
+}
+
+// TestJWTManager_RefreshTokenType リフレッシュトークンのtypヘッダーの検証をテスト
+func TestJWTManager_RefreshTokenType(t *testing.T) {
+	accountID := uuid.New()
+	tokenID := uuid.New()
+	now := time.Now()
+	claims := &auth.RefreshTokenClaims{
+		TokenID:   tokenID.String(),
+		AccountID: accountID.String(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    "jwt-auth-test",
+			Subject:   accountID.String(),
+			ID:        tokenID.String(),
+			Audience:  []string{"jwt-auth-test"},
+		},
+	}
+	const secret = "test-refresh-secret-0123456789abcdef"
+
+	t.Run("未設定の場合はtypを検証しない", func(t *testing.T) {
+		token := forgeTypedToken(t, claims, secret, "at+jwt")
+		if _, err := newTypeTestJWTManager("", "").ValidateRefreshToken(token); err != nil {
+			t.Errorf("❌ 許可されるべきトークンが拒否されました: %v", err)
+		}
+	})
+
+	t.Run("設定した場合は異なるtypを拒否", func(t *testing.T) {
+		manager := newTypeTestJWTManager("at+jwt", "rt+jwt")
+		if _, err := manager.ValidateRefreshToken(forgeTypedToken(t, claims, secret, "at+jwt")); err == nil {
+			t.Error("❌ 拒否されるべきトークンが許可されました")
+		}
+
+		token, _, err := manager.GenerateRefreshToken(accountID)
+		if err != nil {
+			t.Fatalf("❌ トークンの生成に失敗: %v", err)
+		}
+		if _, err := manager.ValidateRefreshToken(token); err != nil {
+			t.Errorf("❌ 発行したトークンの検証に失敗: %v", err)
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package tests_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/config"
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// TestJWTManager_VerifyOnly 検証のみの構成でアクセストークンを検証でき、発行は拒否することをテスト
+func TestJWTManager_VerifyOnly(t *testing.T) {
+	issuer := auth.NewJWTManager(auth.JWTConfig{
+		AccessTokenSecret:  "test-access-secret-0123456789abcdef",
+		RefreshTokenSecret: "test-refresh-secret-0123456789abcdef",
+		Issuer:             "auth-service",
+		Audience:           []string{"resource-service"},
+	})
+	// リフレッシュトークンのシークレットを持たない検証専用の構成
+	verifier := auth.NewJWTManager(auth.JWTConfig{
+		AccessTokenSecret: "test-access-secret-0123456789abcdef",
+		Issuer:            "auth-service",
+		Audience:          []string{"resource-service"},
+		VerifyOnly:        true,
+	})
+
+	accessToken, err := issuer.GenerateAccessToken(uuid.New(), "split@example.com", "user")
+	if err != nil {
+		t.Fatalf("❌ アクセストークンの生成に失敗: %v", err)
+	}
+	if _, err := verifier.ValidateAccessToken(accessToken); err != nil {
+		t.Errorf("❌ 検証専用の構成でアクセストークンを検証できません: %v", err)
+	}
+
+	now := time.Now()
+	refreshToken, err := issuer.SignRefreshToken(uuid.New(), uuid.New(), now, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("❌ リフレッシュトークンの生成に失敗: %v", err)
+	}
+
+	issuance := map[string]func() error{
+		"アクセストークンの発行": func() error {
+			_, err := verifier.GenerateAccessToken(uuid.New(), "split@example.com", "user")
+			return err
+		},
+		"テナント付きアクセストークンの発行": func() error {
+			_, err := verifier.GenerateTenantAccessToken("tenant", uuid.New(), "split@example.com", "user", nil)
+			return err
+		},
+		"リフレッシュトークンの発行": func() error {
+			_, _, err := verifier.GenerateRefreshToken(uuid.New())
+			return err
+		},
+		"リフレッシュトークンの署名": func() error {
+			_, err := verifier.SignRefreshToken(uuid.New(), uuid.New(), now, now.Add(time.Hour))
+			return err
+		},
+		"リフレッシュトークンの検証": func() error {
+			_, err := verifier.ValidateRefreshToken(refreshToken)
+			return err
+		},
+	}
+	for name, call := range issuance {
+		if err := call(); !errors.Is(err, auth.ErrIssuanceDisabled) {
+			t.Errorf("❌ %s 期待値: ErrIssuanceDisabled, 実際: %v", name, err)
+		}
+	}
+}
+
+// TestJWTManager_SeparateRefreshIssuer リフレッシュトークンのIssuer・Audienceをアクセストークンと分けられることをテスト
+func TestJWTManager_SeparateRefreshIssuer(t *testing.T) {
+	newManager := func(refreshIssuer string, acceptedIssuers ...string) *auth.JWTManager {
+		return auth.NewJWTManager(auth.JWTConfig{
+			AccessTokenSecret:    "test-access-secret-0123456789abcdef",
+			RefreshTokenSecret:   "test-refresh-secret-0123456789abcdef",
+			Issuer:               "auth-service",
+			Audience:             []string{"resource-service"},
+			RefreshTokenIssuer:   refreshIssuer,
+			RefreshTokenAudience: []string{"auth-service"},
+			AcceptedIssuers:      acceptedIssuers,
+		})
+	}
+	manager := newManager("auth-service-refresh")
+
+	now := time.Now()
+	refreshToken, err := manager.SignRefreshToken(uuid.New(), uuid.New(), now, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("❌ リフレッシュトークンの生成に失敗: %v", err)
+	}
+	if _, err := manager.ValidateRefreshToken(refreshToken); err != nil {
+		t.Errorf("❌ 分けたIssuerのリフレッシュトークンを検証できません: %v", err)
+	}
+
+	accessToken, err := manager.GenerateAccessToken(uuid.New(), "split@example.com", "user")
+	if err != nil {
+		t.Fatalf("❌ アクセストークンの生成に失敗: %v", err)
+	}
+	claims, err := manager.ValidateAccessToken(accessToken)
+	if err != nil {
+		t.Fatalf("❌ アクセストークンを検証できません: %v", err)
+	}
+	if claims.Issuer != "auth-service" {
+		t.Errorf("❌ アクセストークンのIssuer 期待値: auth-service, 実際: %s", claims.Issuer)
+	}
+
+	t.Run("Issuerの変更後は移行前のIssuerを受け入れる設定のみ許可", func(t *testing.T) {
+		rotated := newManager("auth-service-refresh-v2")
+		if _, err := rotated.ValidateRefreshToken(refreshToken); err == nil || !strings.Contains(err.Error(), "invalid issuer") {
+			t.Errorf("❌ 期待値: invalid issuer, 実際: %v", err)
+		}
+		migrating := newManager("auth-service-refresh-v2", "auth-service-refresh")
+		if _, err := migrating.ValidateRefreshToken(refreshToken); err != nil {
+			t.Errorf("❌ 移行前のIssuerのリフレッシュトークンが拒否されました: %v", err)
+		}
+	})
+}
+
+// TestConfig_JWTVerifyOnly 検証のみの構成ではリフレッシュトークンのシークレットを要求しないことをテスト
+func TestConfig_JWTVerifyOnly(t *testing.T) {
+	load := func(t *testing.T, verifyOnly string) (*config.Config, error) {
+		t.Helper()
+		t.Setenv("JWT_ISSUER", "jwt-auth-api-test")
+		t.Setenv("JWT_ACCESS_TOKEN_SECRET", "test-access-secret-0123456789abcdef")
+		t.Setenv("JWT_REFRESH_TOKEN_SECRET", "")
+		t.Setenv("JWT_VERIFY_ONLY", verifyOnly)
+		return config.LoadConfig()
+	}
+
+	if _, err := load(t, "false"); err == nil || !strings.Contains(err.Error(), "JWT_REFRESH_TOKEN_SECRET") {
+		t.Errorf("❌ 発行する構成 期待値: JWT_REFRESH_TOKEN_SECRETのエラー, 実際: %v", err)
+	}
+	cfg, err := load(t, "true")
+	if err != nil {
+		t.Fatalf("❌ 検証のみの構成の読み込みに失敗: %v", err)
+	}
+	if !cfg.JWT.VerifyOnly {
+		t.Error("❌ JWT_VERIFY_ONLYが反映されていません")
+	}
+}
+
+// TestVerifyOnlyMiddleware 検証のみの構成でトークンを発行するエンドポイントを503で拒否することをテスト
+func TestVerifyOnlyMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(middleware.NewVerifyOnlyMiddleware(middleware.VerifyOnlyConfig{
+		Paths: []string{"/api/v1/auth/login"},
+	}))
+	e.POST("/api/v1/auth/login", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/api/v1/auth/me", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", nil))
+	if rec.Code != http.StatusServiceUnavailable || !strings.Contains(rec.Body.String(), "service_unavailable") {
+		t.Errorf("❌ ログイン 期待値: 503 service_unavailable, 実際: %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/auth/me", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("❌ 検証のみのエンドポイント 期待値: 200, 実際: %d", rec.Code)
+	}
+}
@@ -0,0 +1,95 @@
+package tests_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/pkg/jwtverify"
+	"github.com/google/uuid"
+)
+
+// TestJWTVerify_MatchesServer 単体の検証器が認証サーバーと同じ判定をすることをテスト
+func TestJWTVerify_MatchesServer(t *testing.T) {
+	server := auth.NewJWTManager(auth.JWTConfig{
+		AccessTokenSecret:  "test-access-secret-0123456789abcdef",
+		RefreshTokenSecret: "test-refresh-secret-0123456789abcdef",
+		AccessTokenExpiry:  time.Minute,
+		RefreshTokenExpiry: time.Hour,
+		Issuer:             "jwt-auth-test",
+		Audience:           []string{"jwt-auth-test"},
+		SigningAlgorithm:   "HS384",
+	})
+	accountID := uuid.New()
+	accessToken, err := server.GenerateTenantAccessToken("acme", accountID, "verify@example.com", "admin", nil)
+	if err != nil {
+		t.Fatalf("❌ トークンの生成に失敗: %v", err)
+	}
+	refreshToken, _, err := server.GenerateRefreshToken(accountID)
+	if err != nil {
+		t.Fatalf("❌ トークンの生成に失敗: %v", err)
+	}
+
+	valid := jwtverify.Config{
+		Secret:           "test-access-secret-0123456789abcdef",
+		Issuer:           "jwt-auth-test",
+		Audience:         []string{"jwt-auth-test"},
+		SigningAlgorithm: "HS384",
+	}
+
+	t.Run("サーバーが発行したアクセストークンを検証できる", func(t *testing.T) {
+		verifier, err := jwtverify.New(valid)
+		if err != nil {
+			t.Fatalf("❌ 検証器の作成に失敗: %v", err)
+		}
+		claims, err := verifier.ValidateAccessToken(accessToken)
+		if err != nil {
+			t.Fatalf("❌ 検証に失敗: %v", err)
+		}
+		if claims.AccountID != accountID.String() || claims.Role != "admin" || claims.TenantID != "acme" {
+			t.Errorf("❌ クレームが不正です: %+v", claims)
+		}
+		if _, err := verifier.ValidateAccessToken(refreshToken); err == nil {
+			t.Error("❌ リフレッシュトークンがアクセストークンとして許可されました")
+		}
+	})
+
+	rejected := []struct {
+		name   string
+		modify func(c *jwtverify.Config)
+	}{
+		{"シークレットが異なる", func(c *jwtverify.Config) { c.Secret = "other-access-secret-0123456789abcdef" }},
+		{"Issuerが異なる", func(c *jwtverify.Config) { c.Issuer = "other-issuer" }},
+		{"Audienceが異なる", func(c *jwtverify.Config) { c.Audience = []string{"other-audience"} }},
+		{"署名アルゴリズムが異なる", func(c *jwtverify.Config) { c.SigningAlgorithm = "HS256" }},
+	}
+	for _, tc := range rejected {
+		t.Run(tc.name, func(t *testing.T) {
+			config := valid
+			tc.modify(&config)
+			verifier, err := jwtverify.New(config)
+			if err != nil {
+				t.Fatalf("❌ 検証器の作成に失敗: %v", err)
+			}
+			if _, err := verifier.ValidateAccessToken(accessToken); err == nil {
+				t.Error("❌ 拒否されるべきトークンが許可されました")
+			}
+		})
+	}
+
+	invalid := []struct {
+		name   string
+		config jwtverify.Config
+	}{
+		{"シークレットが空", jwtverify.Config{Issuer: "jwt-auth-test"}},
+		{"Issuerが空", jwtverify.Config{Secret: "test-access-secret-0123456789abcdef"}},
+		{"未対応の署名アルゴリズム", jwtverify.Config{Secret: "test-access-secret-0123456789abcdef", Issuer: "jwt-auth-test", SigningAlgorithm: "RS256"}},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := jwtverify.New(tc.config); err == nil {
+				t.Error("❌ 不正な設定で検証器が作成されました")
+			}
+		})
+	}
+}
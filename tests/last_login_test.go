@@ -0,0 +1,144 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+)
+
+// TestLastLogin ログインとリフレッシュで最終ログインの日時と接続元が更新されることをテスト
+func TestLastLogin(t *testing.T) {
+	ctx := context.Background()
+	authUsecase, _, _ := newTestAuthUsecase(t)
+
+	signedUp, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+		Email:    "last-login@example.com",
+		Password: "SecurePassword123!",
+		Name:     "Last Login User",
+	})
+	if err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+	accountID := signedUp.Account.ID
+
+	current := func(t *testing.T) *domain.Account {
+		t.Helper()
+		account, err := authUsecase.CurrentAccount(ctx, accountID)
+		if err != nil {
+			t.Fatalf("❌ アカウントの取得に失敗: %v", err)
+		}
+		return account
+	}
+
+	if account := current(t); account.LastLoginAt != nil {
+		t.Fatalf("❌ ログイン前に最終ログインが記録されています: %v", account.LastLoginAt)
+	}
+
+	before := time.Now().Add(-time.Second)
+	tokens, err := authUsecase.Login(ctx, usecase.LoginInput{
+		Email:     "last-login@example.com",
+		Password:  "SecurePassword123!",
+		IPAddress: "192.0.2.1",
+	})
+	if err != nil {
+		t.Fatalf("❌ ログインに失敗: %v", err)
+	}
+
+	t.Run("ログインで最終ログインの日時と接続元を記録する", func(t *testing.T) {
+		account := current(t)
+		if account.LastLoginAt == nil || account.LastLoginAt.Before(before) {
+			t.Fatalf("❌ 最終ログイン日時が更新されていません: %v", account.LastLoginAt)
+		}
+		if account.LastLoginIP == nil || *account.LastLoginIP != "192.0.2.1" {
+			t.Errorf("❌ 最終ログインの接続元 期待値: 192.0.2.1, 実際: %v", account.LastLoginIP)
+		}
+		if tokens.Account.LastLoginAt == nil || !tokens.Account.LastLoginAt.Equal(*account.LastLoginAt) {
+			t.Errorf("❌ レスポンスのアカウントに最終ログインが反映されていません: %v", tokens.Account.LastLoginAt)
+		}
+		if account.Version != signedUp.Account.Version {
+			t.Errorf("❌ 最終ログインの記録でバージョンが変わりました: %d → %d", signedUp.Account.Version, account.Version)
+		}
+	})
+
+	t.Run("同じ接続元からの直後のリフレッシュでは書き込まない", func(t *testing.T) {
+		recorded := *current(t).LastLoginAt
+		refreshed, err := authUsecase.RefreshToken(ctx, tokens.RefreshToken, "", "192.0.2.1", "")
+		if err != nil {
+			t.Fatalf("❌ リフレッシュに失敗: %v", err)
+		}
+		tokens = refreshed
+		if account := current(t); !account.LastLoginAt.Equal(recorded) {
+			t.Errorf("❌ 最終ログイン日時 期待値: %v, 実際: %v", recorded, account.LastLoginAt)
+		}
+	})
+
+	t.Run("別の接続元からのリフレッシュで更新する", func(t *testing.T) {
+		if _, err := authUsecase.RefreshToken(ctx, tokens.RefreshToken, "", "198.51.100.7", ""); err != nil {
+			t.Fatalf("❌ リフレッシュに失敗: %v", err)
+		}
+		account := current(t)
+		if account.LastLoginIP == nil || *account.LastLoginIP != "198.51.100.7" {
+			t.Errorf("❌ 最終ログインの接続元 期待値: 198.51.100.7, 実際: %v", account.LastLoginIP)
+		}
+	})
+}
+
+// TestLastLogin_AdminView 管理者のアカウント参照で最終ログインを返し、ETagに反映されることをテスト
+func TestLastLogin_AdminView(t *testing.T) {
+	ctx := context.Background()
+	srv, accountRepo, _ := newAdminTestServer(t)
+
+	account := domain.NewAccount("last-login-admin@example.com", "Last Login", "hash")
+	if err := accountRepo.Create(ctx, account); err != nil {
+		t.Fatalf("❌ アカウント作成に失敗: %v", err)
+	}
+	path := "/api/v1/accounts/" + account.ID.String()
+
+	resp, _ := sendAsRole(t, srv, http.MethodGet, path, "admin", nil)
+	etagBefore := resp.Header.Get("ETag")
+
+	ip := "203.0.113.10"
+	loggedInAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := accountRepo.RecordLogin(ctx, account.ID, loggedInAt, &ip); err != nil {
+		t.Fatalf("❌ 最終ログインの記録に失敗: %v", err)
+	}
+
+	resp, body := sendTestRequest(t, srv, http.MethodGet, path, map[string]string{
+		"X-Test-Role":   "admin",
+		"If-None-Match": etagBefore,
+	}, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("❌ ステータスコード 期待値: 200（最終ログインの変更でETagが変わる）, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+	var got api.Account
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+	}
+	if got.LastLoginAt == nil || !got.LastLoginAt.Equal(loggedInAt) {
+		t.Errorf("❌ last_login_at 期待値: %v, 実際: %v", loggedInAt, got.LastLoginAt)
+	}
+	if got.LastLoginIp == nil || *got.LastLoginIp != ip {
+		t.Errorf("❌ last_login_ip 期待値: %s, 実際: %v", ip, got.LastLoginIp)
+	}
+
+	// ログイン前のETagでもバージョンが同じなら更新できる
+	resp, body = sendTestRequest(t, srv, http.MethodPut, path, map[string]string{
+		"X-Test-Role": "admin",
+		"If-Match":    etagBefore,
+	}, map[string]string{"name": "Renamed"})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+	}
+	if got.LastLoginAt == nil || !got.LastLoginAt.Equal(loggedInAt) {
+		t.Errorf("❌ 更新で最終ログインが失われました: %v", got.LastLoginAt)
+	}
+}
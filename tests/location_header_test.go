@@ -0,0 +1,103 @@
+package tests_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+// TestLocationHeader_Create 作成したリソースのURLがLocationヘッダーで返され、そのURLで取得できることをテスト
+func TestLocationHeader_Create(t *testing.T) {
+	srv, accountRepo, _ := newAdminTestServer(t)
+
+	t.Run("管理者によるアカウント作成", func(t *testing.T) {
+		resp, body := sendAsRole(t, srv, http.MethodPost, "/api/v1/accounts", "admin", api.CreateAccountRequest{
+			Email:    "located@example.com",
+			Name:     "Located",
+			Password: "SecurePassword123!",
+		})
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("❌ ステータスコード 期待値: 201, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var created api.Account
+		if err := json.Unmarshal(body, &created); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+
+		location := resp.Header.Get(echo.HeaderLocation)
+		if expected := "/api/v1/accounts/" + created.Id.String(); location != expected {
+			t.Fatalf("❌ Location 期待値: %s, 実際: %s", expected, location)
+		}
+
+		resp, body = sendAsRole(t, srv, http.MethodGet, location, "admin", nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ Locationの取得 ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var fetched api.Account
+		if err := json.Unmarshal(body, &fetched); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if fetched.Id != created.Id {
+			t.Errorf("❌ 取得したアカウント 期待値: %s, 実際: %s", created.Id, fetched.Id)
+		}
+	})
+
+	t.Run("プロジェクト作成", func(t *testing.T) {
+		owner := domain.NewAccount("project-owner@example.com", "Owner", "hash")
+		if err := accountRepo.Create(t.Context(), owner); err != nil {
+			t.Fatalf("❌ アカウント作成に失敗: %v", err)
+		}
+
+		path := "/api/v1/accounts/" + owner.ID.String() + "/projects"
+		resp, body := sendAsAccount(t, srv, http.MethodPost, path, owner.ID, api.CreateProjectRequest{Name: "Located Project"})
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("❌ ステータスコード 期待値: 201, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var created api.Project
+		if err := json.Unmarshal(body, &created); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+
+		location := resp.Header.Get(echo.HeaderLocation)
+		if expected := path + "/" + created.Id.String(); location != expected {
+			t.Fatalf("❌ Location 期待値: %s, 実際: %s", expected, location)
+		}
+
+		resp, body = sendAsAccount(t, srv, http.MethodGet, location, owner.ID, nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ Locationの取得 ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var fetched api.Project
+		if err := json.Unmarshal(body, &fetched); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if fetched.Id != created.Id || fetched.Name != "Located Project" {
+			t.Errorf("❌ 取得したプロジェクト 期待値: %s, 実際: %s (%s)", created.Id, fetched.Id, fetched.Name)
+		}
+	})
+}
+
+// TestLocationHeader_SignUp サインアップで作成したアカウントのURLがLocationヘッダーで返されることをテスト
+func TestLocationHeader_SignUp(t *testing.T) {
+	srv := newAuthTestServer(t)
+
+	resp, body := sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/signup", nil, api.SignUpRequest{
+		Email:    "signup-location@example.com",
+		Name:     "Signup",
+		Password: "SecurePassword123!",
+	})
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("❌ ステータスコード 期待値: 201, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+	var auth api.AuthResponse
+	if err := json.Unmarshal(body, &auth); err != nil {
+		t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+	}
+	if expected := "/api/v1/accounts/" + auth.Account.Id.String(); resp.Header.Get(echo.HeaderLocation) != expected {
+		t.Errorf("❌ Location 期待値: %s, 実際: %s", expected, resp.Header.Get(echo.HeaderLocation))
+	}
+}
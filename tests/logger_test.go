@@ -0,0 +1,255 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/logger"
+)
+
+// TestLogger_Output ログ出力先の切り替えとファイルローテーションをテスト
+func TestLogger_Output(t *testing.T) {
+	t.Run("任意のWriterに出力できる", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := logger.NewLoggerWithOutput("info", "json", &buf)
+
+		log.Info(context.Background(), "hello", logger.F("key", "value"))
+
+		var entry map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("❌ JSONログのパースに失敗: %v (%s)", err, buf.String())
+		}
+		if entry["message"] != "hello" || entry["key"] != "value" {
+			t.Errorf("❌ ログ内容が想定と異なります: %v", entry)
+		}
+	})
+
+	t.Run("ファイル出力は上限サイズでローテーションされる", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "app.log")
+		const maxSize = 512
+
+		output, err := logger.OpenOutput(logger.OutputConfig{
+			Destination: path,
+			MaxSize:     maxSize,
+			MaxBackups:  2,
+		})
+		if err != nil {
+			t.Fatalf("❌ ログファイルのオープンに失敗: %v", err)
+		}
+		defer output.Close()
+
+		log := logger.NewLoggerWithOutput("info", "text", output)
+		for i := 0; i < 50; i++ {
+			log.Info(context.Background(), strings.Repeat("x", 64))
+		}
+
+		rotating, ok := output.(*logger.RotatingFile)
+		if !ok {
+			t.Fatalf("❌ ファイル出力がRotatingFileではありません: %T", output)
+		}
+		backups, err := rotating.Backups()
+		if err != nil {
+			t.Fatalf("❌ ローテーション済みファイルの取得に失敗: %v", err)
+		}
+		if len(backups) != 2 {
+			t.Errorf("❌ 保持されるローテーション済みファイル数 期待値: 2, 実際: %d", len(backups))
+		}
+
+		for _, file := range append([]string{path}, backups...) {
+			info, err := os.Stat(file)
+			if err != nil {
+				t.Fatalf("❌ ファイル情報の取得に失敗: %v", err)
+			}
+			if info.Size() > maxSize {
+				t.Errorf("❌ %s のサイズが上限を超えています: %d > %d", file, info.Size(), maxSize)
+			}
+		}
+	})
+
+	t.Run("stdoutとstderrはクローズされない", func(t *testing.T) {
+		for _, dest := range []string{logger.OutputStdout, logger.OutputStderr} {
+			output, err := logger.OpenOutput(logger.OutputConfig{Destination: dest})
+			if err != nil {
+				t.Fatalf("❌ %s のオープンに失敗: %v", dest, err)
+			}
+			if err := output.Close(); err != nil {
+				t.Errorf("❌ %s のクローズでエラー: %v", dest, err)
+			}
+		}
+		if _, err := os.Stdout.Stat(); err != nil {
+			t.Errorf("❌ 標準出力が閉じられています: %v", err)
+		}
+	})
+}
+
+// parseLogEntry JSONログを1行パースする
+func parseLogEntry(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	t.Helper()
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("❌ JSONログのパースに失敗: %v (%s)", err, buf.String())
+	}
+	buf.Reset()
+	return entry
+}
+
+// assertCaller ログのfile/lineが期待する呼び出し元を指しているか確認
+func assertCaller(t *testing.T, entry map[string]interface{}, wantLine int) {
+	t.Helper()
+
+	if entry["file"] != "tests/logger_test.go" {
+		t.Errorf("❌ file 期待値: tests/logger_test.go, 実際: %v", entry["file"])
+	}
+	if line, _ := entry["line"].(float64); int(line) != wantLine {
+		t.Errorf("❌ line 期待値: %d, 実際: %v", wantLine, entry["line"])
+	}
+}
+
+// logThroughHelper ロガーをラップするヘルパー関数の例
+func logThroughHelper(log logger.Logger, msg string) {
+	log.Info(context.Background(), msg)
+}
+
+// currentLine 呼び出し元の行番号を返す
+func currentLine() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+// blockingWriter Writeが解放されるまでブロックするWriter
+type blockingWriter struct {
+	release chan struct{}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.release
+	return len(p), nil
+}
+
+// TestLogger_Caller 呼び出し元の報告とスタックトレースをテスト
+func TestLogger_Caller(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Withで派生したロガーでも呼び出し元を指す", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := logger.NewLoggerWithOutput("debug", "json", &buf).With(logger.F("component", "test"))
+
+		line := currentLine() + 1
+		log.Info(ctx, "derived")
+		assertCaller(t, parseLogEntry(t, &buf), line)
+
+		line = currentLine() + 1
+		log.With(logger.F("nested", true)).Error(ctx, "nested", fmt.Errorf("boom"))
+		assertCaller(t, parseLogEntry(t, &buf), line)
+	})
+
+	t.Run("ヘルパー経由ではスキップ数を指定して呼び出し元を指す", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := logger.NewLoggerWithOutput("info", "json", &buf, logger.WithCallerSkip(1))
+
+		line := currentLine() + 1
+		logThroughHelper(log, "helper")
+		assertCaller(t, parseLogEntry(t, &buf), line)
+	})
+
+	t.Run("WithStackでスタックトレースを含める", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := logger.NewLoggerWithOutput("info", "json", &buf)
+
+		log.Error(ctx, "with stack", fmt.Errorf("boom"), logger.WithStack())
+		entry := parseLogEntry(t, &buf)
+		stack, _ := entry["stack"].(string)
+		if !strings.Contains(stack, "TestLogger_Caller") {
+			t.Errorf("❌ スタックトレースに呼び出し元が含まれていません: %q", stack)
+		}
+		if strings.Contains(stack, "internal/logger") {
+			t.Errorf("❌ スタックトレースにロガー内部のフレームが含まれています: %q", stack)
+		}
+
+		// Error未満のレベルでは無視される
+		log.Info(ctx, "without stack", logger.WithStack())
+		if _, ok := parseLogEntry(t, &buf)["stack"]; ok {
+			t.Errorf("❌ Infoログにスタックトレースが含まれています")
+		}
+	})
+
+	t.Run("デッドライン付きコンテキストでは書き込みでブロックしない", func(t *testing.T) {
+		writer := &blockingWriter{release: make(chan struct{})}
+		defer close(writer.release)
+		log := logger.NewLoggerWithOutput("info", "json", writer)
+
+		deadlineCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		log.Info(deadlineCtx, "blocked")
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("❌ デッドラインを過ぎても書き込みでブロックしました: %v", elapsed)
+		}
+	})
+}
+
+// TestLogger_Redaction 機密情報のマスクをテスト
+func TestLogger_Redaction(t *testing.T) {
+	ctx := context.Background()
+
+	for _, format := range []string{"json", "text"} {
+		t.Run(format+"形式でtokenがマスクされる", func(t *testing.T) {
+			var buf bytes.Buffer
+			log := logger.NewLoggerWithOutput("info", format, &buf).With(logger.F("Authorization", "Bearer secret-header"))
+
+			log.Info(ctx, "login", logger.F("token", "secret-token"), logger.F("account_id", "abc"))
+
+			out := buf.String()
+			if strings.Contains(out, "secret-token") || strings.Contains(out, "secret-header") {
+				t.Errorf("❌ 機密情報がログに出力されています: %s", out)
+			}
+			if !strings.Contains(out, "***") {
+				t.Errorf("❌ マスクされた値が出力されていません: %s", out)
+			}
+			if !strings.Contains(out, "abc") {
+				t.Errorf("❌ マスク対象外のフィールドが出力されていません: %s", out)
+			}
+		})
+	}
+
+	t.Run("マスク対象のキーを変更できる", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := logger.NewLoggerWithOutput("info", "json", &buf, logger.WithRedactKeys("secret"))
+
+		log.Info(ctx, "custom", logger.F("secret", "s1"), logger.F("token", "t1"))
+		entry := parseLogEntry(t, &buf)
+		if entry["secret"] != "***" {
+			t.Errorf("❌ secret 期待値: ***, 実際: %v", entry["secret"])
+		}
+		if entry["token"] != "t1" {
+			t.Errorf("❌ token 期待値: t1, 実際: %v", entry["token"])
+		}
+	})
+
+	t.Run("プライバシーモードではメールアドレスをハッシュ化する", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := logger.NewLoggerWithOutput("info", "json", &buf, logger.WithPrivacyMode(true))
+
+		log.Info(ctx, "signup", logger.F("email", "user@example.com"))
+		first := parseLogEntry(t, &buf)["email"]
+		if first == "user@example.com" || !strings.HasPrefix(fmt.Sprint(first), "sha256:") {
+			t.Errorf("❌ メールアドレスがハッシュ化されていません: %v", first)
+		}
+
+		// 同じメールアドレスは同じハッシュになり突き合わせ可能
+		log.Info(ctx, "login", logger.F("email", "user@example.com"))
+		if second := parseLogEntry(t, &buf)["email"]; second != first {
+			t.Errorf("❌ 同じメールアドレスのハッシュが一致しません: %v != %v", first, second)
+		}
+	})
+}
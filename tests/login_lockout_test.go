@@ -0,0 +1,204 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+)
+
+// testLockoutPolicy テスト用のロックアウト設定（3回の失敗で1分から倍増、上限4分）
+var testLockoutPolicy = domain.LockoutPolicy{
+	MaxFailedAttempts: 3,
+	BaseCooldown:      time.Minute,
+	MaxCooldown:       4 * time.Minute,
+	QuietPeriod:       time.Hour,
+}
+
+// newLockoutTestAuthUsecase ロックアウトを有効にした認証ユースケースを作成
+func newLockoutTestAuthUsecase(t *testing.T) (*usecase.AuthUsecase, *fakeLoginAttemptRepository, *fakeSecurityAuditLogRepository) {
+	t.Helper()
+
+	loginAttemptRepo := newFakeLoginAttemptRepository()
+	auditRepo := &fakeSecurityAuditLogRepository{}
+	jwtManager := auth.NewJWTManager(auth.JWTConfig{
+		AccessTokenSecret:  "test-access-secret-0123456789abcdef",
+		RefreshTokenSecret: "test-refresh-secret-0123456789abcdef",
+		AccessTokenExpiry:  time.Minute,
+		RefreshTokenExpiry: time.Hour,
+		Issuer:             "jwt-auth-test",
+		Audience:           []string{"jwt-auth-test"},
+	})
+
+	authUsecase := usecase.NewAuthUsecase(
+		newFakeAccountRepository(),
+		newFakeRefreshTokenRepository(),
+		auditRepo,
+		loginAttemptRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		jwtManager,
+		usecase.AuthConfig{RefreshTokenExpiry: time.Hour, Lockout: testLockoutPolicy},
+	)
+	return authUsecase, loginAttemptRepo, auditRepo
+}
+
+// TestLockoutPolicy_Cooldown ロックの回数ごとの期間が倍増し上限で止まることをテスト
+func TestLockoutPolicy_Cooldown(t *testing.T) {
+	expected := []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 4 * time.Minute, 4 * time.Minute}
+	for i, want := range expected {
+		if got := testLockoutPolicy.Cooldown(i + 1); got != want {
+			t.Errorf("❌ %d回目のロック期間 期待値: %s, 実際: %s", i+1, want, got)
+		}
+	}
+}
+
+// TestLogin_LockoutEscalation ロックを繰り返すたびにロック期間が倍増することをテスト
+func TestLogin_LockoutEscalation(t *testing.T) {
+	ctx := context.Background()
+	authUsecase, loginAttemptRepo, auditRepo := newLockoutTestAuthUsecase(t)
+
+	signedUp, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+		Email:    "lockout@example.com",
+		Password: "SecurePassword123!",
+		Name:     "Lockout User",
+	})
+	if err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+	accountID := signedUp.Account.ID
+
+	login := func(password string) error {
+		_, err := authUsecase.Login(ctx, usecase.LoginInput{
+			Email:     "lockout@example.com",
+			Password:  password,
+			IPAddress: "203.0.113.5",
+		})
+		return err
+	}
+	// lockOut 上限回数まで失敗させ、ロックされたときの期間を返す
+	lockOut := func(t *testing.T) time.Duration {
+		t.Helper()
+		for i := 1; i < testLockoutPolicy.MaxFailedAttempts; i++ {
+			if err := login("wrong-password"); !errors.Is(err, domain.ErrInvalidCredentials) {
+				t.Fatalf("❌ %d回目の失敗 期待値: ErrInvalidCredentials, 実際: %v", i, err)
+			}
+		}
+		var locked *domain.AccountLockedError
+		if err := login("wrong-password"); !errors.As(err, &locked) {
+			t.Fatalf("❌ 期待値: AccountLockedError, 実際: %v", err)
+		}
+		return locked.RetryAfter
+	}
+	// lockedEvents ACCOUNT_LOCKEDのメタデータを古い順に返す
+	lockedEvents := func(t *testing.T) []map[string]interface{} {
+		t.Helper()
+		logs, _ := auditRepo.GetByEventType(ctx, domain.EventAccountLocked, -1, 0)
+		events := make([]map[string]interface{}, len(logs))
+		for i, log := range logs {
+			if err := json.Unmarshal(log.Metadata, &events[len(logs)-1-i]); err != nil {
+				t.Fatalf("❌ メタデータのパースに失敗: %v", err)
+			}
+		}
+		return events
+	}
+
+	t.Run("ロックを繰り返すと期間が倍増する", func(t *testing.T) {
+		for i, want := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 4 * time.Minute} {
+			if got := lockOut(t); got != want {
+				t.Errorf("❌ %d回目のロック期間 期待値: %s, 実際: %s", i+1, want, got)
+			}
+			// ロックが解除されるまで時間を進める
+			loginAttemptRepo.rewind(accountID, want)
+		}
+
+		events := lockedEvents(t)
+		if len(events) != 4 {
+			t.Fatalf("❌ ACCOUNT_LOCKEDの件数 期待値: 4, 実際: %d", len(events))
+		}
+		for i, want := range []float64{60, 120, 240, 240} {
+			if events[i]["cooldown_seconds"] != want {
+				t.Errorf("❌ %d回目のcooldown_seconds 期待値: %v, 実際: %v", i+1, want, events[i]["cooldown_seconds"])
+			}
+			if events[i]["lockout_count"] != float64(i+1) {
+				t.Errorf("❌ %d回目のlockout_count 期待値: %d, 実際: %v", i+1, i+1, events[i]["lockout_count"])
+			}
+		}
+	})
+
+	t.Run("ロック中は正しいパスワードでも拒否する", func(t *testing.T) {
+		lockOut(t)
+		var locked *domain.AccountLockedError
+		if err := login("SecurePassword123!"); !errors.As(err, &locked) {
+			t.Fatalf("❌ 期待値: AccountLockedError, 実際: %v", err)
+		}
+		if locked.RetryAfter <= 0 || locked.RetryAfter > testLockoutPolicy.MaxCooldown {
+			t.Errorf("❌ 解除までの時間が不正です: %s", locked.RetryAfter)
+		}
+		loginAttemptRepo.rewind(accountID, testLockoutPolicy.MaxCooldown)
+	})
+
+	t.Run("静穏期間が経過すると倍増をリセットする", func(t *testing.T) {
+		loginAttemptRepo.rewind(accountID, testLockoutPolicy.QuietPeriod)
+		if got := lockOut(t); got != testLockoutPolicy.BaseCooldown {
+			t.Errorf("❌ ロック期間 期待値: %s, 実際: %s", testLockoutPolicy.BaseCooldown, got)
+		}
+		loginAttemptRepo.rewind(accountID, testLockoutPolicy.BaseCooldown)
+	})
+
+	t.Run("ログインに成功すると連続失敗回数をリセットする", func(t *testing.T) {
+		if err := login("wrong-password"); !errors.Is(err, domain.ErrInvalidCredentials) {
+			t.Fatalf("❌ 期待値: ErrInvalidCredentials, 実際: %v", err)
+		}
+		if err := login("SecurePassword123!"); err != nil {
+			t.Fatalf("❌ ログインに失敗: %v", err)
+		}
+		attempt, _ := loginAttemptRepo.GetByAccountID(ctx, accountID)
+		if attempt.FailedCount != 0 || attempt.LockedUntil != nil {
+			t.Errorf("❌ 連続失敗回数がリセットされていません: %+v", attempt)
+		}
+	})
+}
+
+// TestLogin_LockedResponse ロック中のログインが423とRetry-Afterを返すことをテスト
+func TestLogin_LockedResponse(t *testing.T) {
+	authUsecase, _, _ := newLockoutTestAuthUsecase(t)
+	srv := newAuthTestServerWithUsecase(t, authUsecase)
+
+	if _, err := authUsecase.SignUp(context.Background(), usecase.SignUpInput{
+		Email:    "locked-response@example.com",
+		Password: "SecurePassword123!",
+		Name:     "Locked Response",
+	}); err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+
+	var resp *http.Response
+	var body []byte
+	for i := 0; i < testLockoutPolicy.MaxFailedAttempts; i++ {
+		resp, body = sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/login", nil, map[string]string{
+			"email":    "locked-response@example.com",
+			"password": "wrong-password",
+		})
+	}
+
+	if resp.StatusCode != http.StatusLocked {
+		t.Fatalf("❌ ステータスコード 期待値: 423, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+	if got := resp.Header.Get("Retry-After"); got != "60" {
+		t.Errorf("❌ Retry-After 期待値: 60, 実際: %q", got)
+	}
+	var apiErr api.Error
+	if err := json.Unmarshal(body, &apiErr); err != nil || apiErr.Code != api.ErrorCodeAccountLocked {
+		t.Errorf("❌ エラーコード 期待値: account_locked, body: %s", body)
+	}
+}
@@ -0,0 +1,125 @@
+package tests_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+)
+
+// TestE2E_Logout リフレッシュトークンとアクセストークンの両方によるログアウトをテスト
+func TestE2E_Logout(t *testing.T) {
+	requireServer(t)
+
+	const password = "SecurePassword123!"
+
+	t.Run("リフレッシュトークンでログアウト", func(t *testing.T) {
+		_, tokens := signUpTestAccount(t, password)
+		headers := map[string]string{"Authorization": "Bearer " + tokens.AccessToken}
+
+		resp, _ := sendRequest(t, "POST", baseURL+"/auth/logout", RefreshRequest{RefreshToken: tokens.RefreshToken}, headers)
+		if resp.StatusCode != http.StatusNoContent {
+			t.Fatalf("❌ ログアウト失敗: ステータスコード %d", resp.StatusCode)
+		}
+
+		resp, _ = sendRequest(t, "POST", baseURL+"/auth/refresh", RefreshRequest{RefreshToken: tokens.RefreshToken}, nil)
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("❌ 無効化されたリフレッシュトークンが使用できてしまいます: ステータスコード %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("アクセストークンのみでログアウト", func(t *testing.T) {
+		email, tokens := signUpTestAccount(t, password)
+
+		// 同じアカウントで別セッションを作成
+		resp, body := sendRequest(t, "POST", baseURL+"/auth/login", LoginRequest{Email: email, Password: password}, nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ログイン失敗: ステータスコード %d", resp.StatusCode)
+		}
+		var second AuthResponse
+		if err := json.Unmarshal(body, &second); err != nil {
+			t.Fatalf("❌ レスポンスのパースに失敗: %v", err)
+		}
+
+		headers := map[string]string{"Authorization": "Bearer " + tokens.AccessToken}
+		resp, _ = sendRequest(t, "POST", baseURL+"/auth/logout", nil, headers)
+		if resp.StatusCode != http.StatusNoContent {
+			t.Fatalf("❌ ログアウト失敗: ステータスコード %d", resp.StatusCode)
+		}
+
+		for _, refreshToken := range []string{tokens.RefreshToken, second.RefreshToken} {
+			resp, _ = sendRequest(t, "POST", baseURL+"/auth/refresh", RefreshRequest{RefreshToken: refreshToken}, nil)
+			if resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("❌ 無効化されたリフレッシュトークンが使用できてしまいます: ステータスコード %d", resp.StatusCode)
+			}
+		}
+	})
+
+	t.Run("アクセストークンなしは拒否", func(t *testing.T) {
+		resp, _ := sendRequest(t, "POST", baseURL+"/auth/logout", nil, nil)
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("❌ 期待値: 401, 実際: %d", resp.StatusCode)
+		}
+	})
+}
+
+// TestE2E_LogoutAll 全セッションの無効化と監査ログの記録をテスト
+func TestE2E_LogoutAll(t *testing.T) {
+	requireServer(t)
+
+	const password = "SecurePassword123!"
+	email, tokens := signUpTestAccount(t, password)
+
+	// サインアップ分と合わせて3セッションを作成
+	refreshTokens := []string{tokens.RefreshToken}
+	for i := 0; i < 2; i++ {
+		resp, body := sendRequest(t, "POST", baseURL+"/auth/login", LoginRequest{Email: email, Password: password}, nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ログイン失敗: ステータスコード %d", resp.StatusCode)
+		}
+		var session AuthResponse
+		if err := json.Unmarshal(body, &session); err != nil {
+			t.Fatalf("❌ レスポンスのパースに失敗: %v", err)
+		}
+		refreshTokens = append(refreshTokens, session.RefreshToken)
+	}
+
+	headers := map[string]string{"Authorization": "Bearer " + tokens.AccessToken}
+	resp, body := sendRequest(t, "POST", baseURL+"/auth/logout-all", nil, headers)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("❌ 全セッションログアウト失敗: ステータスコード %d", resp.StatusCode)
+	}
+
+	var result struct {
+		RevokedSessions int `json:"revoked_sessions"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		t.Fatalf("❌ レスポンスのパースに失敗: %v", err)
+	}
+	if result.RevokedSessions != len(refreshTokens) {
+		t.Errorf("❌ 無効化セッション数 期待値: %d, 実際: %d", len(refreshTokens), result.RevokedSessions)
+	}
+
+	for _, refreshToken := range refreshTokens {
+		resp, _ = sendRequest(t, "POST", baseURL+"/auth/refresh", RefreshRequest{RefreshToken: refreshToken}, nil)
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("❌ 無効化されたリフレッシュトークンが使用できてしまいます: ステータスコード %d", resp.StatusCode)
+		}
+	}
+
+	t.Run("監査ログの記録", func(t *testing.T) {
+		db := openTestDB(t)
+
+		var count int
+		err := db.Get(&count,
+			"SELECT COUNT(*) FROM security_audit_logs WHERE account_id = ? AND event_type = ?",
+			tokens.Account.ID, domain.EventAllTokensRevoked)
+		if err != nil {
+			t.Fatalf("❌ 監査ログの取得に失敗: %v", err)
+		}
+		if count != 1 {
+			t.Errorf("❌ %sイベント数 期待値: 1, 実際: %d", domain.EventAllTokensRevoked, count)
+		}
+	})
+}
@@ -0,0 +1,211 @@
+package tests_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+)
+
+// newMagicLinkTestAuthUsecase マジックリンクを有効にした認証ユースケースを作成
+// 有効期限15分、1時間に3件までの設定で、アカウント magic@example.com を作成済みの状態にする
+func newMagicLinkTestAuthUsecase(t *testing.T) (*usecase.AuthUsecase, *fakeMagicLinkRepository, *fakeNotifier, *fakeSecurityAuditLogRepository) {
+	t.Helper()
+
+	magicLinkRepo := newFakeMagicLinkRepository()
+	notifier := &fakeNotifier{}
+	auditRepo := &fakeSecurityAuditLogRepository{}
+	jwtManager := auth.NewJWTManager(auth.JWTConfig{
+		AccessTokenSecret:  "test-access-secret-0123456789abcdef",
+		RefreshTokenSecret: "test-refresh-secret-0123456789abcdef",
+		AccessTokenExpiry:  time.Minute,
+		RefreshTokenExpiry: time.Hour,
+		Issuer:             "jwt-auth-test",
+		Audience:           []string{"jwt-auth-test"},
+	})
+
+	authUsecase := usecase.NewAuthUsecase(
+		newFakeAccountRepository(),
+		newFakeRefreshTokenRepository(),
+		auditRepo,
+		nil,
+		magicLinkRepo,
+		nil,
+		nil,
+		notifier,
+		jwtManager,
+		usecase.AuthConfig{
+			RefreshTokenExpiry:   time.Hour,
+			MagicLinkExpiry:      15 * time.Minute,
+			MagicLinkMaxRequests: 3,
+			MagicLinkWindow:      time.Hour,
+			MagicLinkURL:         "https://app.example.com/login/magic",
+		},
+	)
+
+	if _, err := authUsecase.SignUp(context.Background(), usecase.SignUpInput{
+		Email:    "magic@example.com",
+		Password: "SecurePassword123!",
+		Name:     "Magic User",
+	}); err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+	return authUsecase, magicLinkRepo, notifier, auditRepo
+}
+
+// requestMagicLinkToken マジックリンクを要求し、送信されたトークンを返す
+func requestMagicLinkToken(t *testing.T, authUsecase *usecase.AuthUsecase, notifier *fakeNotifier) string {
+	t.Helper()
+	if err := authUsecase.RequestMagicLink(context.Background(), usecase.MagicLinkInput{Email: "magic@example.com"}); err != nil {
+		t.Fatalf("❌ マジックリンクの要求に失敗: %v", err)
+	}
+	return lastMagicLinkToken(t, notifier)
+}
+
+// lastMagicLinkToken 最後に送信されたメールのリンクからトークンを取り出す
+func lastMagicLinkToken(t *testing.T, notifier *fakeNotifier) string {
+	t.Helper()
+	msg, ok := notifier.last()
+	if !ok {
+		t.Fatal("❌ マジックリンクが送信されていません")
+	}
+	const prefix = "https://app.example.com/login/magic?token="
+	i := strings.Index(msg.Body, prefix)
+	if i < 0 {
+		t.Fatalf("❌ メール本文にリンクが含まれていません: %s", msg.Body)
+	}
+	return msg.Body[i+len(prefix):]
+}
+
+// TestMagicLink_ConsumeOnce マジックリンクのトークンが1回だけ使用できることをテスト
+func TestMagicLink_ConsumeOnce(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("1回目はログインでき、2回目は拒否する", func(t *testing.T) {
+		authUsecase, _, notifier, _ := newMagicLinkTestAuthUsecase(t)
+		token := requestMagicLinkToken(t, authUsecase, notifier)
+
+		tokens, err := authUsecase.VerifyMagicLink(ctx, usecase.VerifyMagicLinkInput{Token: token})
+		if err != nil {
+			t.Fatalf("❌ マジックリンクでのログインに失敗: %v", err)
+		}
+		if tokens.Account.Email != "magic@example.com" || tokens.AccessToken == "" || tokens.RefreshToken == "" {
+			t.Errorf("❌ 発行されたトークンが不正です: %+v", tokens)
+		}
+
+		if _, err := authUsecase.VerifyMagicLink(ctx, usecase.VerifyMagicLinkInput{Token: token}); !errors.Is(err, domain.ErrInvalidToken) {
+			t.Errorf("❌ 2回目 期待値: ErrInvalidToken, 実際: %v", err)
+		}
+	})
+
+	t.Run("同時に使用しても1件だけ成功する", func(t *testing.T) {
+		authUsecase, _, notifier, _ := newMagicLinkTestAuthUsecase(t)
+		token := requestMagicLinkToken(t, authUsecase, notifier)
+
+		const concurrency = 5
+		errs := make([]error, concurrency)
+		var wg sync.WaitGroup
+		for i := 0; i < concurrency; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, errs[i] = authUsecase.VerifyMagicLink(ctx, usecase.VerifyMagicLinkInput{Token: token})
+			}(i)
+		}
+		wg.Wait()
+
+		succeeded := 0
+		for _, err := range errs {
+			switch {
+			case err == nil:
+				succeeded++
+			case !errors.Is(err, domain.ErrInvalidToken):
+				t.Errorf("❌ 予期しないエラー: %v", err)
+			}
+		}
+		if succeeded != 1 {
+			t.Errorf("❌ 成功した件数 期待値: 1, 実際: %d", succeeded)
+		}
+	})
+
+	t.Run("不明なトークンは拒否する", func(t *testing.T) {
+		authUsecase, _, _, _ := newMagicLinkTestAuthUsecase(t)
+		if _, err := authUsecase.VerifyMagicLink(ctx, usecase.VerifyMagicLinkInput{Token: "unknown-token"}); !errors.Is(err, domain.ErrInvalidToken) {
+			t.Errorf("❌ 期待値: ErrInvalidToken, 実際: %v", err)
+		}
+	})
+}
+
+// TestMagicLink_Expiry 有効期限を過ぎたマジックリンクを拒否することをテスト
+func TestMagicLink_Expiry(t *testing.T) {
+	authUsecase, magicLinkRepo, notifier, _ := newMagicLinkTestAuthUsecase(t)
+	token := requestMagicLinkToken(t, authUsecase, notifier)
+
+	magicLinkRepo.rewind(16 * time.Minute)
+
+	if _, err := authUsecase.VerifyMagicLink(context.Background(), usecase.VerifyMagicLinkInput{Token: token}); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("❌ 期待値: ErrInvalidToken, 実際: %v", err)
+	}
+}
+
+// TestMagicLink_RateLimitPerEmail メールアドレスごとの送信数の上限をテスト
+func TestMagicLink_RateLimitPerEmail(t *testing.T) {
+	ctx := context.Background()
+	authUsecase, magicLinkRepo, notifier, auditRepo := newMagicLinkTestAuthUsecase(t)
+
+	// 上限を超えた要求もエラーにはせず、送信だけを止める
+	for i := 0; i < 5; i++ {
+		if err := authUsecase.RequestMagicLink(ctx, usecase.MagicLinkInput{Email: "magic@example.com"}); err != nil {
+			t.Fatalf("❌ %d件目の要求に失敗: %v", i+1, err)
+		}
+	}
+	if len(notifier.messages) != 3 {
+		t.Errorf("❌ 送信数 期待値: 3, 実際: %d", len(notifier.messages))
+	}
+	if logs, _ := auditRepo.GetByEventType(ctx, domain.EventMagicLinkRateLimited, -1, 0); len(logs) != 2 {
+		t.Errorf("❌ MAGIC_LINK_RATE_LIMITEDの件数 期待値: 2, 実際: %d", len(logs))
+	}
+
+	// 期間が経過すると再び送信できる
+	magicLinkRepo.rewind(time.Hour)
+	if err := authUsecase.RequestMagicLink(ctx, usecase.MagicLinkInput{Email: "magic@example.com"}); err != nil {
+		t.Fatalf("❌ 期間経過後の要求に失敗: %v", err)
+	}
+	if len(notifier.messages) != 4 {
+		t.Errorf("❌ 期間経過後の送信数 期待値: 4, 実際: %d", len(notifier.messages))
+	}
+}
+
+// TestMagicLink_HTTP 登録の有無にかかわらず同じレスポンスを返し、トークンでログインできることをテスト
+func TestMagicLink_HTTP(t *testing.T) {
+	authUsecase, _, notifier, _ := newMagicLinkTestAuthUsecase(t)
+	srv := newAuthTestServerWithUsecase(t, authUsecase)
+
+	for _, email := range []string{"magic@example.com", "unknown@example.com"} {
+		resp, body := sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/magic-link", nil, map[string]string{"email": email})
+		if resp.StatusCode != http.StatusOK || len(body) != 0 {
+			t.Errorf("❌ %s: 期待値: 200で本文なし, 実際: %d, body: %s", email, resp.StatusCode, body)
+		}
+	}
+	if len(notifier.messages) != 1 || notifier.messages[0].To != "magic@example.com" {
+		t.Fatalf("❌ 登録済みのメールアドレスにのみ送信される必要があります: %+v", notifier.messages)
+	}
+
+	token := lastMagicLinkToken(t, notifier)
+	resp, body := sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/magic-link/verify", nil, map[string]string{"token": token})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+
+	resp, body = sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/magic-link/verify", nil, map[string]string{"token": token})
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("❌ 再使用 ステータスコード 期待値: 401, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+}
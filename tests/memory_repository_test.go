@@ -0,0 +1,326 @@
+package tests_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/config"
+	"github.com/aida0710/jwt-auth/internal/di"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/repository/memory"
+	"github.com/aida0710/jwt-auth/internal/selfcheck"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/google/uuid"
+)
+
+// TestMemoryRepository_Invariants インメモリのリポジトリがMySQLの実装と同じ制約を守ることをテスト
+func TestMemoryRepository_Invariants(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("メールアドレスは大文字小文字を区別せずに一意", func(t *testing.T) {
+		repo := memory.NewStore().Account()
+		if err := repo.Create(ctx, domain.NewAccount("unique@example.com", "First", "hash")); err != nil {
+			t.Fatalf("❌ アカウント作成に失敗: %v", err)
+		}
+
+		err := repo.Create(ctx, domain.NewAccount("UNIQUE@example.com", "Second", "hash"))
+		if !errors.Is(err, domain.ErrDuplicateEmail) {
+			t.Errorf("❌ 期待値: ErrDuplicateEmail, 実際: %v", err)
+		}
+
+		other := domain.NewAccount("other@example.com", "Other", "hash")
+		if err := repo.Create(ctx, other); err != nil {
+			t.Fatalf("❌ アカウント作成に失敗: %v", err)
+		}
+		other.Email = "Unique@Example.com"
+		if err := repo.Update(ctx, other); !errors.Is(err, domain.ErrDuplicateEmail) {
+			t.Errorf("❌ 更新時の期待値: ErrDuplicateEmail, 実際: %v", err)
+		}
+
+		found, err := repo.GetByEmail(ctx, "UNIQUE@EXAMPLE.COM")
+		if err != nil || found.Name != "First" {
+			t.Errorf("❌ 大文字小文字を区別せずに取得できませんでした: %+v, %v", found, err)
+		}
+	})
+
+	t.Run("読み込んだ後に更新されたアカウントの更新は競合エラー", func(t *testing.T) {
+		repo := memory.NewStore().Account()
+		account := domain.NewAccount("stale@example.com", "Original", "hash")
+		if err := repo.Create(ctx, account); err != nil {
+			t.Fatalf("❌ アカウント作成に失敗: %v", err)
+		}
+		first, _ := repo.GetByID(ctx, account.ID)
+		second, _ := repo.GetByID(ctx, account.ID)
+
+		first.Name = "First"
+		if err := repo.Update(ctx, first); err != nil || first.Version != 2 {
+			t.Fatalf("❌ 更新に失敗: version=%d, err=%v", first.Version, err)
+		}
+		second.Name = "Second"
+		if err := repo.Update(ctx, second); !errors.Is(err, domain.ErrVersionConflict) {
+			t.Errorf("❌ 期待値: ErrVersionConflict, 実際: %v", err)
+		}
+
+		found, err := repo.GetByID(ctx, account.ID)
+		if err != nil || found.Name != "First" || found.Version != 2 {
+			t.Errorf("❌ 古い更新で上書きされました: %+v, %v", found, err)
+		}
+	})
+
+	t.Run("見つからない場合はドメインのエラーを返す", func(t *testing.T) {
+		store := memory.NewStore()
+
+		_, err := store.Account().GetByID(ctx, uuid.New())
+		assertNotFound(t, "Account.GetByID", err, domain.ErrAccountNotFound)
+		missingAccount := domain.NewAccount("missing@example.com", "Missing", "hash")
+		assertNotFound(t, "Account.Update", store.Account().Update(ctx, missingAccount), domain.ErrAccountNotFound)
+		assertNotFound(t, "Account.Delete", store.Account().Delete(ctx, uuid.New()), domain.ErrAccountNotFound)
+
+		_, err = store.Project().GetByID(ctx, uuid.New())
+		assertNotFound(t, "Project.GetByID", err, domain.ErrProjectNotFound)
+		missingProject := domain.NewProject(uuid.New(), "Missing", "")
+		assertNotFound(t, "Project.Update", store.Project().Update(ctx, missingProject), domain.ErrProjectNotFound)
+
+		_, err = store.RefreshToken().GetByID(ctx, uuid.New())
+		assertNotFound(t, "RefreshToken.GetByID", err, domain.ErrNotFound)
+		_, err = store.RefreshToken().GetByTokenHash(ctx, "missing")
+		assertNotFound(t, "RefreshToken.GetByTokenHash", err, domain.ErrNotFound)
+		assertNotFound(t, "RefreshToken.Revoke", store.RefreshToken().Revoke(ctx, uuid.New()), domain.ErrNotFound)
+	})
+
+	t.Run("トークンハッシュの重複と二重のローテーションを拒否する", func(t *testing.T) {
+		repo := memory.NewStore().RefreshToken()
+		accountID := uuid.New()
+		token := domain.NewRefreshToken(accountID, "duplicate-hash", time.Now().Add(time.Hour), nil, nil)
+		if err := repo.Create(ctx, token); err != nil {
+			t.Fatalf("❌ トークン作成に失敗: %v", err)
+		}
+		if found, err := repo.GetByID(ctx, token.ID); err != nil || found.TokenHash != "duplicate-hash" {
+			t.Errorf("❌ IDで取得できませんでした: %+v, %v", found, err)
+		}
+
+		duplicate := domain.NewRefreshToken(accountID, "duplicate-hash", time.Now().Add(time.Hour), nil, nil)
+		if err := repo.Create(ctx, duplicate); !errors.Is(err, domain.ErrDuplicateToken) {
+			t.Errorf("❌ 期待値: ErrDuplicateToken, 実際: %v", err)
+		}
+
+		if ok, err := repo.MarkAsRotated(ctx, token.ID, uuid.New()); !ok || err != nil {
+			t.Fatalf("❌ 1回目のローテーションに失敗: %v, %v", ok, err)
+		}
+		if ok, err := repo.MarkAsRotated(ctx, token.ID, uuid.New()); ok || err != nil {
+			t.Errorf("❌ 使用済みのトークンがローテーションされました: %v, %v", ok, err)
+		}
+	})
+
+	t.Run("アカウントの削除で関連するデータも削除する", func(t *testing.T) {
+		store := memory.NewStore()
+		account := domain.NewAccount("cascade@example.com", "Cascade", "hash")
+		if err := store.Account().Create(ctx, account); err != nil {
+			t.Fatalf("❌ アカウント作成に失敗: %v", err)
+		}
+		project := domain.NewProject(account.ID, "Cascade Project", "")
+		if err := store.Project().Create(ctx, project); err != nil {
+			t.Fatalf("❌ プロジェクト作成に失敗: %v", err)
+		}
+		token := domain.NewRefreshToken(account.ID, "cascade-hash", time.Now().Add(time.Hour), nil, nil)
+		if err := store.RefreshToken().Create(ctx, token); err != nil {
+			t.Fatalf("❌ トークン作成に失敗: %v", err)
+		}
+		log, err := domain.NewSecurityAuditLog(account.ID, domain.EventSessionRevoked, "revoked", nil, nil, nil)
+		if err != nil {
+			t.Fatalf("❌ 監査ログの作成に失敗: %v", err)
+		}
+		if err := store.SecurityAuditLog().Create(ctx, log); err != nil {
+			t.Fatalf("❌ 監査ログの保存に失敗: %v", err)
+		}
+
+		if err := store.Account().Delete(ctx, account.ID); err != nil {
+			t.Fatalf("❌ アカウント削除に失敗: %v", err)
+		}
+
+		if _, err := store.Project().GetByID(ctx, project.ID); !errors.Is(err, domain.ErrProjectNotFound) {
+			t.Errorf("❌ プロジェクトが削除されていません: %v", err)
+		}
+		if _, err := store.RefreshToken().GetByID(ctx, token.ID); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("❌ リフレッシュトークンが削除されていません: %v", err)
+		}
+		if count, _ := store.SecurityAuditLog().CountByAccountID(ctx, account.ID); count != 0 {
+			t.Errorf("❌ 監査ログが削除されていません: %d件", count)
+		}
+	})
+}
+
+// TestMemoryRepository_Usecases インメモリのリポジトリでユースケースが動作することをテスト
+func TestMemoryRepository_Usecases(t *testing.T) {
+	ctx := context.Background()
+
+	store := memory.NewStore()
+	jwtManager := auth.NewJWTManager(auth.JWTConfig{
+		AccessTokenSecret:  "test-access-secret-0123456789abcdef",
+		RefreshTokenSecret: "test-refresh-secret-0123456789abcdef",
+		AccessTokenExpiry:  time.Minute,
+		RefreshTokenExpiry: time.Hour,
+		Issuer:             "jwt-auth-test",
+		Audience:           []string{"jwt-auth-test"},
+	})
+	authUsecase := usecase.NewAuthUsecase(
+		store.Account(), store.RefreshToken(), store.SecurityAuditLog(),
+		nil, nil, nil, memory.TransactionManager{}, nil, jwtManager,
+		usecase.AuthConfig{RefreshTokenExpiry: time.Hour},
+	)
+	accountUsecase := usecase.NewAccountUsecase(
+		store.Account(), store.Project(), store.RefreshToken(), store.PasswordHistory(), nil, store.SecurityAuditLog(),
+		memory.TransactionManager{}, nil,
+		usecase.AccountConfig{PasswordHistorySize: 2, DeletionGracePeriod: time.Hour},
+	)
+	projectUsecase := usecase.NewProjectUsecase(store.Project(), store.Account(), memory.TransactionManager{}, usecase.ProjectConfig{})
+
+	tokens, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+		Email:    "memory@example.com",
+		Password: "password123",
+		Name:     "Memory User",
+	})
+	if err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+	account := tokens.Account
+
+	t.Run("同じメールアドレスでサインアップできない", func(t *testing.T) {
+		_, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+			Email:    "Memory@Example.com",
+			Password: "password123",
+			Name:     "Another User",
+		})
+		if !errors.Is(err, domain.ErrEmailAlreadyExists) {
+			t.Errorf("❌ 期待値: ErrEmailAlreadyExists, 実際: %v", err)
+		}
+	})
+
+	t.Run("ログインとリフレッシュ、再利用の検出", func(t *testing.T) {
+		loggedIn, err := authUsecase.Login(ctx, usecase.LoginInput{Email: "memory@example.com", Password: "password123"})
+		if err != nil {
+			t.Fatalf("❌ ログインに失敗: %v", err)
+		}
+		if _, err := authUsecase.RefreshToken(ctx, loggedIn.RefreshToken, "", "", ""); err != nil {
+			t.Fatalf("❌ リフレッシュに失敗: %v", err)
+		}
+		if _, err := authUsecase.RefreshToken(ctx, loggedIn.RefreshToken, "", "", ""); !errors.Is(err, domain.ErrTokenCompromised) {
+			t.Errorf("❌ 期待値: ErrTokenCompromised, 実際: %v", err)
+		}
+
+		logs, _ := store.SecurityAuditLog().GetByEventType(ctx, domain.EventTokenReuseDetected, 10, 0)
+		if len(logs) != 1 {
+			t.Errorf("❌ 再利用の監査ログが記録されていません: %d件", len(logs))
+		}
+	})
+
+	t.Run("パスワード履歴による再利用の禁止", func(t *testing.T) {
+		err := accountUsecase.ChangePassword(ctx, account.ID, usecase.ChangePasswordInput{
+			CurrentPassword: "password123",
+			NewPassword:     "password456",
+		})
+		if err != nil {
+			t.Fatalf("❌ パスワード変更に失敗: %v", err)
+		}
+		err = accountUsecase.ChangePassword(ctx, account.ID, usecase.ChangePasswordInput{
+			CurrentPassword: "password456",
+			NewPassword:     "password123",
+		})
+		if !errors.Is(err, domain.ErrPasswordReused) {
+			t.Errorf("❌ 期待値: ErrPasswordReused, 実際: %v", err)
+		}
+	})
+
+	t.Run("他のアカウントのメールアドレスには変更できない", func(t *testing.T) {
+		other, err := accountUsecase.Create(ctx, usecase.CreateInput{
+			Email:    "other-memory@example.com",
+			Name:     "Other User",
+			Password: "password123",
+		})
+		if err != nil {
+			t.Fatalf("❌ アカウント作成に失敗: %v", err)
+		}
+		email := "MEMORY@example.com"
+		if _, err := accountUsecase.Update(ctx, other.ID, usecase.UpdateInput{Email: &email}); !errors.Is(err, domain.ErrDuplicateEmail) {
+			t.Errorf("❌ 期待値: ErrDuplicateEmail, 実際: %v", err)
+		}
+	})
+
+	t.Run("プロジェクトの作成と一覧", func(t *testing.T) {
+		for _, name := range []string{"First", "Second"} {
+			if _, err := projectUsecase.Create(ctx, account.ID, account.ID, usecase.CreateProjectInput{Name: name}); err != nil {
+				t.Fatalf("❌ プロジェクト作成に失敗: %v", err)
+			}
+		}
+
+		projects, page, err := projectUsecase.ListByAccountID(ctx, account.ID, usecase.ListProjectsInput{})
+		if err != nil {
+			t.Fatalf("❌ プロジェクト一覧の取得に失敗: %v", err)
+		}
+		if len(projects) != 2 || page.Total != 2 {
+			t.Errorf("❌ 期待値: 2件, 実際: %d件（合計 %d）", len(projects), page.Total)
+		}
+
+		_, err = projectUsecase.GetByID(ctx, account.ID, uuid.New())
+		if !errors.Is(err, domain.ErrProjectNotFound) {
+			t.Errorf("❌ 期待値: ErrProjectNotFound, 実際: %v", err)
+		}
+	})
+}
+
+// TestMemoryRepository_Config DB_DRIVERの設定とインメモリでのコンテナの初期化をテスト
+func TestMemoryRepository_Config(t *testing.T) {
+	load := func(t *testing.T, env, driver string) (*config.Config, error) {
+		t.Helper()
+		t.Setenv("APP_ENV", env)
+		t.Setenv("DB_DRIVER", driver)
+		t.Setenv("JWT_ACCESS_TOKEN_SECRET", "test-access-secret-0123456789abcdef")
+		t.Setenv("JWT_REFRESH_TOKEN_SECRET", "test-refresh-secret-0123456789abcdef")
+		return config.LoadConfig()
+	}
+
+	t.Run("本番環境ではmemoryを使用できない", func(t *testing.T) {
+		if _, err := load(t, "production", "memory"); err == nil {
+			t.Error("❌ 本番環境でDB_DRIVER=memoryが受け入れられました")
+		}
+	})
+
+	t.Run("未知のドライバーを拒否する", func(t *testing.T) {
+		if _, err := load(t, "development", "postgres"); err == nil {
+			t.Error("❌ 未知のDB_DRIVERが受け入れられました")
+		}
+	})
+
+	t.Run("memoryではデータベースに接続せずに起動する", func(t *testing.T) {
+		cfg, err := load(t, "development", "memory")
+		if err != nil {
+			t.Fatalf("❌ 設定の読み込みに失敗: %v", err)
+		}
+
+		container, err := di.NewContainer(cfg)
+		if err != nil {
+			t.Fatalf("❌ コンテナの初期化に失敗: %v", err)
+		}
+		if container.DB() != nil {
+			t.Error("❌ データベースに接続しています")
+		}
+		if err := container.Close(); err != nil {
+			t.Errorf("❌ コンテナの終了に失敗: %v", err)
+		}
+	})
+
+	t.Run("セルフチェックはデータベースを検査しない", func(t *testing.T) {
+		report := selfcheck.Run(context.Background(), nil, newSelfCheckConfig())
+		if !report.OK() {
+			t.Errorf("❌ 失敗したチェック: %+v", report.Failed())
+		}
+		for _, result := range report.Results {
+			if result.Name == selfcheck.CheckDatabase || result.Name == selfcheck.CheckTables {
+				t.Errorf("❌ データベースのチェックが含まれています: %s", result.Name)
+			}
+		}
+	})
+}
@@ -0,0 +1,165 @@
+package tests_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+)
+
+const (
+	chromeWindowsUA  = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36"
+	chromeWindowsUA2 = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/125.0.0.0 Safari/537.36"
+	firefoxMacUA     = "Mozilla/5.0 (Macintosh; Intel Mac OS X 14.4; rv:125.0) Gecko/20100101 Firefox/125.0"
+)
+
+// newDeviceTestAuthUsecase 新しい端末の判定を指定した厳しさで有効にした認証ユースケースを作成
+// アカウント device@example.com を作成済みの状態にする
+func newDeviceTestAuthUsecase(t *testing.T, mode domain.DeviceMatchMode) (*usecase.AuthUsecase, *fakeNotifier, *fakeSecurityAuditLogRepository) {
+	t.Helper()
+
+	notifier := &fakeNotifier{}
+	auditRepo := &fakeSecurityAuditLogRepository{}
+	jwtManager := auth.NewJWTManager(auth.JWTConfig{
+		AccessTokenSecret:  "test-access-secret-0123456789abcdef",
+		RefreshTokenSecret: "test-refresh-secret-0123456789abcdef",
+		AccessTokenExpiry:  time.Minute,
+		RefreshTokenExpiry: time.Hour,
+		Issuer:             "jwt-auth-test",
+		Audience:           []string{"jwt-auth-test"},
+	})
+
+	authUsecase := usecase.NewAuthUsecase(
+		newFakeAccountRepository(),
+		newFakeRefreshTokenRepository(),
+		auditRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		notifier,
+		jwtManager,
+		usecase.AuthConfig{
+			RefreshTokenExpiry: time.Hour,
+			NewDeviceMatch:     mode,
+		},
+	)
+
+	if _, err := authUsecase.SignUp(context.Background(), usecase.SignUpInput{
+		Email:    "device@example.com",
+		Password: "SecurePassword123!",
+		Name:     "Device User",
+	}); err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+	return authUsecase, notifier, auditRepo
+}
+
+// loginFrom 指定したUser-AgentとIPアドレスからログイン
+func loginFrom(t *testing.T, authUsecase *usecase.AuthUsecase, userAgent, ipAddress string) {
+	t.Helper()
+	if _, err := authUsecase.Login(context.Background(), usecase.LoginInput{
+		Email:     "device@example.com",
+		Password:  "SecurePassword123!",
+		UserAgent: userAgent,
+		IPAddress: ipAddress,
+	}); err != nil {
+		t.Fatalf("❌ ログインに失敗: %v", err)
+	}
+}
+
+// newDeviceEvents 記録されたNEW_DEVICE_LOGINを返す
+func newDeviceEvents(auditRepo *fakeSecurityAuditLogRepository) []*domain.SecurityAuditLog {
+	logs, _ := auditRepo.GetByEventType(context.Background(), domain.EventNewDeviceLogin, 100, 0)
+	return logs
+}
+
+// TestNewDeviceLogin_FirstSeenAndReturning 初めての端末からのログインは記録・通知し、既知の端末からのログインは通知しないことをテスト
+func TestNewDeviceLogin_FirstSeenAndReturning(t *testing.T) {
+	authUsecase, notifier, auditRepo := newDeviceTestAuthUsecase(t, domain.DeviceMatchLenient)
+
+	t.Run("比較できるセッションが無い最初のログインは通知しない", func(t *testing.T) {
+		loginFrom(t, authUsecase, chromeWindowsUA, "203.0.113.10")
+		if len(notifier.messages) != 0 || len(newDeviceEvents(auditRepo)) != 0 {
+			t.Errorf("❌ 最初のログインで通知されました: %+v", notifier.messages)
+		}
+	})
+
+	t.Run("同じ端末・サブネットからのログインは通知しない", func(t *testing.T) {
+		// ブラウザの更新や同じ回線内でのアドレスの変化は既知として扱う
+		loginFrom(t, authUsecase, chromeWindowsUA2, "203.0.113.42")
+		if len(notifier.messages) != 0 || len(newDeviceEvents(auditRepo)) != 0 {
+			t.Errorf("❌ 既知の端末で通知されました: %+v", notifier.messages)
+		}
+	})
+
+	t.Run("初めての端末・サブネットからのログインは記録して通知する", func(t *testing.T) {
+		loginFrom(t, authUsecase, firefoxMacUA, "198.51.100.7")
+
+		msg, ok := notifier.last()
+		if !ok {
+			t.Fatal("❌ 通知が送信されていません")
+		}
+		if msg.To != "device@example.com" || !strings.Contains(msg.Body, "Firefox on macOS") || !strings.Contains(msg.Body, "198.51.100.0/24") {
+			t.Errorf("❌ 通知の内容が不正です: %+v", msg)
+		}
+
+		events := newDeviceEvents(auditRepo)
+		if len(events) != 1 {
+			t.Fatalf("❌ NEW_DEVICE_LOGIN 期待値: 1件, 実際: %d件", len(events))
+		}
+		metadata := string(events[0].Metadata)
+		for _, want := range []string{`"browser":"Firefox"`, `"os":"macOS"`, `"subnet":"198.51.100.0/24"`, `"match_mode":"lenient"`} {
+			if !strings.Contains(metadata, want) {
+				t.Errorf("❌ メタデータに %s がありません: %s", want, metadata)
+			}
+		}
+	})
+
+	t.Run("一度ログインした端末は次回から既知として扱う", func(t *testing.T) {
+		loginFrom(t, authUsecase, firefoxMacUA, "198.51.100.7")
+		if len(notifier.messages) != 1 {
+			t.Errorf("❌ 通知数 期待値: 1, 実際: %d", len(notifier.messages))
+		}
+	})
+}
+
+// TestNewDeviceLogin_MatchMode 判定の厳しさによって端末とサブネットの一致の扱いが変わることをテスト
+func TestNewDeviceLogin_MatchMode(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       domain.DeviceMatchMode
+		userAgent  string
+		ipAddress  string
+		wantNotify bool
+	}{
+		{"lenient: 同じ端末・別のサブネットは既知", domain.DeviceMatchLenient, chromeWindowsUA, "198.51.100.7", false},
+		{"lenient: 別の端末・同じサブネットは既知", domain.DeviceMatchLenient, firefoxMacUA, "203.0.113.99", false},
+		{"lenient: 端末もサブネットも異なる場合は通知", domain.DeviceMatchLenient, firefoxMacUA, "198.51.100.7", true},
+		{"strict: 同じ端末・別のサブネットは通知", domain.DeviceMatchStrict, chromeWindowsUA, "198.51.100.7", true},
+		{"strict: 別の端末・同じサブネットは通知", domain.DeviceMatchStrict, firefoxMacUA, "203.0.113.99", true},
+		{"strict: 同じ端末・同じサブネットは既知", domain.DeviceMatchStrict, chromeWindowsUA2, "203.0.113.99", false},
+		{"IPv6は/48単位で比較", domain.DeviceMatchStrict, chromeWindowsUA, "2001:db8:1:ffff::2", false},
+		{"off: 判定しない", domain.DeviceMatchOff, firefoxMacUA, "198.51.100.7", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authUsecase, notifier, auditRepo := newDeviceTestAuthUsecase(t, tt.mode)
+			loginFrom(t, authUsecase, chromeWindowsUA, "203.0.113.10")
+			loginFrom(t, authUsecase, chromeWindowsUA, "2001:db8:1::1")
+			sent, events := len(notifier.messages), len(newDeviceEvents(auditRepo))
+
+			loginFrom(t, authUsecase, tt.userAgent, tt.ipAddress)
+			if notified := len(notifier.messages) > sent; notified != tt.wantNotify {
+				t.Errorf("❌ 通知 期待値: %v, 実際: %v", tt.wantNotify, notified)
+			}
+			if recorded := len(newDeviceEvents(auditRepo)) > events; recorded != tt.wantNotify {
+				t.Errorf("❌ NEW_DEVICE_LOGINの記録 期待値: %v, 実際: %v", tt.wantNotify, recorded)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package tests_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+)
+
+// TestChangePassword_History 直近のパスワードの再利用禁止をテスト
+func TestChangePassword_History(t *testing.T) {
+	ctx := context.Background()
+	const historySize = 2
+
+	historyRepo := &fakePasswordHistoryRepository{}
+	refreshTokenRepo := newFakeRefreshTokenRepository()
+	accountUsecase := usecase.NewAccountUsecase(
+		newFakeAccountRepository(), nil, refreshTokenRepo, historyRepo, nil, nil, fakeTxManager{}, nil,
+		usecase.AccountConfig{PasswordHistorySize: historySize},
+	)
+
+	account, err := accountUsecase.Create(ctx, usecase.CreateInput{
+		Email:    "history@example.com",
+		Name:     "History User",
+		Password: "password-0",
+	})
+	if err != nil {
+		t.Fatalf("❌ アカウント作成に失敗: %v", err)
+	}
+
+	current := "password-0"
+	change := func(newPassword string) error {
+		return accountUsecase.ChangePassword(ctx, account.ID, usecase.ChangePasswordInput{
+			CurrentPassword: current,
+			NewPassword:     newPassword,
+		})
+	}
+	mustChange := func(t *testing.T, newPassword string) {
+		t.Helper()
+		if err := change(newPassword); err != nil {
+			t.Fatalf("❌ %s への変更に失敗: %v", newPassword, err)
+		}
+		current = newPassword
+	}
+
+	t.Run("現在のパスワードが誤っている場合は拒否", func(t *testing.T) {
+		err := accountUsecase.ChangePassword(ctx, account.ID, usecase.ChangePasswordInput{
+			CurrentPassword: "wrong-password",
+			NewPassword:     "password-1",
+		})
+		if !errors.Is(err, domain.ErrIncorrectPassword) {
+			t.Errorf("❌ ErrIncorrectPasswordを期待しましたが %v", err)
+		}
+	})
+
+	t.Run("現在のパスワードへの変更は拒否", func(t *testing.T) {
+		if err := change(current); !errors.Is(err, domain.ErrPasswordReused) {
+			t.Errorf("❌ ErrPasswordReusedを期待しましたが %v", err)
+		}
+	})
+
+	t.Run("直近のパスワードの再利用は拒否", func(t *testing.T) {
+		mustChange(t, "password-1")
+		mustChange(t, "password-2")
+
+		if err := change("password-0"); !errors.Is(err, domain.ErrPasswordReused) {
+			t.Errorf("❌ ErrPasswordReusedを期待しましたが %v", err)
+		}
+		if n := historyRepo.count(account.ID); n != historySize {
+			t.Errorf("❌ 履歴件数 期待値: %d, 実際: %d", historySize, n)
+		}
+	})
+
+	t.Run("履歴件数より古いパスワードは再利用できる", func(t *testing.T) {
+		mustChange(t, "password-3")
+		if n := historyRepo.count(account.ID); n != historySize {
+			t.Errorf("❌ 古い履歴が削除されていません: %d件", n)
+		}
+
+		mustChange(t, "password-0")
+	})
+}
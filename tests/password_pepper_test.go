@@ -0,0 +1,197 @@
+package tests_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+)
+
+const (
+	testPepperV1 = "pepper-v1-0123456789abcdef0123456789abcdef"
+	testPepperV2 = "pepper-v2-0123456789abcdef0123456789abcdef"
+)
+
+// newPasswordHasherForTest ペッパーを指定してパスワードのハッシュ化を作成
+func newPasswordHasherForTest(t *testing.T, peppers ...auth.Pepper) auth.PasswordHasher {
+	t.Helper()
+	hasher, err := auth.NewPasswordHasher(peppers)
+	if err != nil {
+		t.Fatalf("❌ ペッパーの設定に失敗: %v", err)
+	}
+	return hasher
+}
+
+// TestPasswordPepper_HashAndVerify ペッパーの有無によるハッシュ化と検証をテスト
+func TestPasswordPepper_HashAndVerify(t *testing.T) {
+	const password = "SecurePassword123!"
+
+	t.Run("ペッパーなしでは従来どおりbcryptのハッシュ", func(t *testing.T) {
+		hasher := auth.PasswordHasher{}
+		hash, err := hasher.Hash(password)
+		if err != nil {
+			t.Fatalf("❌ ハッシュ化に失敗: %v", err)
+		}
+		if !strings.HasPrefix(hash, "$2a$") {
+			t.Errorf("❌ bcryptのハッシュではありません: %s", hash)
+		}
+		if err := hasher.Verify(password, hash); err != nil {
+			t.Errorf("❌ 正しいパスワードの検証に失敗: %v", err)
+		}
+		if hasher.Verify("WrongPassword123!", hash) == nil {
+			t.Error("❌ 誤ったパスワードが検証を通過しました")
+		}
+		if hasher.NeedsRehash(hash) {
+			t.Error("❌ ペッパーなしでハッシュし直しが必要と判定されました")
+		}
+	})
+
+	t.Run("ペッパーありではバージョンを記録し、ペッパーなしでは検証できない", func(t *testing.T) {
+		hasher := newPasswordHasherForTest(t, auth.Pepper{Version: 1, Secret: testPepperV1})
+
+		hash, err := hasher.Hash(password)
+		if err != nil {
+			t.Fatalf("❌ ハッシュ化に失敗: %v", err)
+		}
+		if !strings.HasPrefix(hash, "$pepper-v1$2a$") {
+			t.Errorf("❌ ペッパーのバージョンが記録されていません: %s", hash)
+		}
+		if err := hasher.Verify(password, hash); err != nil {
+			t.Errorf("❌ 正しいパスワードの検証に失敗: %v", err)
+		}
+		if hasher.Verify("WrongPassword123!", hash) == nil {
+			t.Error("❌ 誤ったパスワードが検証を通過しました")
+		}
+		if hasher.NeedsRehash(hash) {
+			t.Error("❌ 現在のペッパーのハッシュでハッシュし直しが必要と判定されました")
+		}
+
+		// ペッパーを知らなければハッシュが漏洩しても検証できない
+		if err := (auth.PasswordHasher{}).Verify(password, hash); !errors.Is(err, auth.ErrUnknownPepperVersion) {
+			t.Errorf("❌ 期待値: ErrUnknownPepperVersion, 実際: %v", err)
+		}
+	})
+
+	t.Run("不正な設定は拒否する", func(t *testing.T) {
+		invalid := map[string][]auth.Pepper{
+			"短い秘密の値":    {{Version: 1, Secret: "short"}},
+			"0以下のバージョン": {{Version: 0, Secret: testPepperV1}},
+			"重複したバージョン": {{Version: 1, Secret: testPepperV1}, {Version: 1, Secret: testPepperV2}},
+		}
+		for name, peppers := range invalid {
+			if _, err := auth.NewPasswordHasher(peppers); err == nil {
+				t.Errorf("❌ %s: 不正な設定が受け付けられました", name)
+			}
+		}
+
+		for _, entry := range []string{"secret-without-version", "v1:" + testPepperV1, "-1:" + testPepperV1} {
+			if _, err := auth.ParsePeppers([]string{entry}); err == nil {
+				t.Errorf("❌ 不正な形式が受け付けられました: %s", entry)
+			}
+		}
+		peppers, err := auth.ParsePeppers([]string{"2:" + testPepperV2, " 1:" + testPepperV1 + ":with-colon ", ""})
+		if err != nil || len(peppers) != 2 || peppers[0].Version != 2 || peppers[1].Secret != testPepperV1+":with-colon" {
+			t.Errorf("❌ ペッパーの解析結果が不正です: %+v (%v)", peppers, err)
+		}
+	})
+}
+
+// TestPasswordPepper_Rotation ペッパーのローテーション後、ログインの成功時に現在のペッパーでハッシュし直すことをテスト
+func TestPasswordPepper_Rotation(t *testing.T) {
+	ctx := context.Background()
+	const password = "SecurePassword123!"
+	accountRepo := newFakeAccountRepository()
+	jwtManager := auth.NewJWTManager(auth.JWTConfig{
+		AccessTokenSecret:  "test-access-secret-0123456789abcdef",
+		RefreshTokenSecret: "test-refresh-secret-0123456789abcdef",
+		AccessTokenExpiry:  time.Minute,
+		RefreshTokenExpiry: time.Hour,
+		Issuer:             "jwt-auth-test",
+		Audience:           []string{"jwt-auth-test"},
+	})
+	// newAuthUsecase 指定したペッパーを使う認証ユースケースを作成（同じアカウントリポジトリを共有する）
+	newAuthUsecase := func(peppers ...auth.Pepper) *usecase.AuthUsecase {
+		return usecase.NewAuthUsecase(
+			accountRepo, newFakeRefreshTokenRepository(), &fakeSecurityAuditLogRepository{},
+			nil, nil, nil, fakeTxManager{}, nil, jwtManager,
+			usecase.AuthConfig{RefreshTokenExpiry: time.Hour, Passwords: newPasswordHasherForTest(t, peppers...)},
+		)
+	}
+	authUsecase := newAuthUsecase(auth.Pepper{Version: 1, Secret: testPepperV1})
+
+	tokens, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+		Email:    "pepper@example.com",
+		Password: password,
+		Name:     "Pepper User",
+	})
+	if err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+	storedAccount := func(t *testing.T) *domain.Account {
+		t.Helper()
+		account, err := accountRepo.GetByID(ctx, tokens.Account.ID)
+		if err != nil {
+			t.Fatalf("❌ アカウントの取得に失敗: %v", err)
+		}
+		return account
+	}
+	storedHash := func(t *testing.T) string {
+		t.Helper()
+		return storedAccount(t).PasswordHash
+	}
+	login := func(password string) error {
+		_, err := authUsecase.Login(ctx, usecase.LoginInput{Email: "pepper@example.com", Password: password})
+		return err
+	}
+
+	oldHash := storedHash(t)
+	if !strings.HasPrefix(oldHash, "$pepper-v1$") {
+		t.Fatalf("❌ サインアップ時のハッシュに現在のペッパーが適用されていません: %s", oldHash)
+	}
+
+	// 新しいペッパーを先頭に追加し、古いペッパーは検証用に残す
+	rotated := []auth.Pepper{{Version: 2, Secret: testPepperV2}, {Version: 1, Secret: testPepperV1}}
+	authUsecase = newAuthUsecase(rotated...)
+	if !newPasswordHasherForTest(t, rotated...).NeedsRehash(oldHash) {
+		t.Fatal("❌ 古いペッパーのハッシュでハッシュし直しが不要と判定されました")
+	}
+
+	t.Run("失敗したログインではハッシュし直さない", func(t *testing.T) {
+		if err := login("WrongPassword123!"); err == nil {
+			t.Fatal("❌ 誤ったパスワードでログインできました")
+		}
+		if storedHash(t) != oldHash {
+			t.Error("❌ 失敗したログインでハッシュが変更されました")
+		}
+	})
+
+	t.Run("成功したログインで現在のペッパーのハッシュに置き換える", func(t *testing.T) {
+		before := storedAccount(t)
+		if err := login(password); err != nil {
+			t.Fatalf("❌ 古いペッパーのハッシュでログインできません: %v", err)
+		}
+		newHash := storedHash(t)
+		if !strings.HasPrefix(newHash, "$pepper-v2$") {
+			t.Fatalf("❌ 現在のペッパーでハッシュし直されていません: %s", newHash)
+		}
+		// パスワード自体は変わらないため、If-Matchで使うバージョンと更新日時は変えない
+		if after := storedAccount(t); after.Version != before.Version || !after.UpdatedAt.Equal(before.UpdatedAt) {
+			t.Errorf("❌ ハッシュし直しでバージョンまたは更新日時が変わりました: version %d → %d, updated_at %s → %s",
+				before.Version, after.Version, before.UpdatedAt, after.UpdatedAt)
+		}
+
+		// 古いペッパーを取り除いてもログインできる
+		authUsecase = newAuthUsecase(auth.Pepper{Version: 2, Secret: testPepperV2})
+		if err := login(password); err != nil {
+			t.Errorf("❌ ハッシュし直した後にログインできません: %v", err)
+		}
+		if storedHash(t) != newHash {
+			t.Error("❌ 現在のペッパーのハッシュが再度変更されました")
+		}
+	})
+}
@@ -0,0 +1,253 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/handler"
+	"github.com/aida0710/jwt-auth/internal/logger"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	passwordResetEmail    = "reset@example.com"
+	passwordResetPassword = "SecurePassword123!"
+	passwordResetURL      = "https://app.example.com/reset-password"
+)
+
+// passwordResetTest パスワード再設定のテストで使用するユースケースとリポジトリ
+type passwordResetTest struct {
+	accountUsecase usecase.AccountUsecase
+	authUsecase    *usecase.AuthUsecase
+	resets         *fakePasswordResetRepository
+	notifier       *fakeNotifier
+	audit          *fakeSecurityAuditLogRepository
+}
+
+// newPasswordResetTest パスワード再設定を有効にしたユースケースを作成
+// 有効期限は既定値（1時間）のまま、アカウント reset@example.com を作成済みの状態にする
+func newPasswordResetTest(t *testing.T) *passwordResetTest {
+	t.Helper()
+
+	accountRepo := newFakeAccountRepository()
+	refreshTokenRepo := newFakeRefreshTokenRepository()
+	test := &passwordResetTest{
+		resets:   newFakePasswordResetRepository(),
+		notifier: &fakeNotifier{},
+		audit:    &fakeSecurityAuditLogRepository{},
+	}
+	test.accountUsecase = usecase.NewAccountUsecase(accountRepo, nil, refreshTokenRepo, nil, test.resets, test.audit, fakeTxManager{}, test.notifier,
+		usecase.AccountConfig{PasswordResetURL: passwordResetURL})
+	test.authUsecase = usecase.NewAuthUsecase(accountRepo, refreshTokenRepo, nil, nil, nil, nil, nil, nil,
+		newAudienceTestJWTManager([]string{"jwt-auth-test"}, auth.AudienceMatchExact), usecase.AuthConfig{RefreshTokenExpiry: time.Hour})
+
+	if _, err := test.authUsecase.SignUp(context.Background(), usecase.SignUpInput{
+		Email:    passwordResetEmail,
+		Password: passwordResetPassword,
+		Name:     "Reset User",
+	}); err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+	return test
+}
+
+// requestToken パスワード再設定を要求し、送信されたトークンを返す
+func (p *passwordResetTest) requestToken(t *testing.T) string {
+	t.Helper()
+	if err := p.accountUsecase.RequestPasswordReset(context.Background(), usecase.PasswordResetInput{Email: passwordResetEmail}); err != nil {
+		t.Fatalf("❌ パスワード再設定の要求に失敗: %v", err)
+	}
+	msg, ok := p.notifier.last()
+	if !ok {
+		t.Fatal("❌ パスワード再設定のメールが送信されていません")
+	}
+	const prefix = passwordResetURL + "?token="
+	i := strings.Index(msg.Body, prefix)
+	if i < 0 {
+		t.Fatalf("❌ メール本文にリンクが含まれていません: %s", msg.Body)
+	}
+	return msg.Body[i+len(prefix):]
+}
+
+// reset トークンでパスワードを変更
+func (p *passwordResetTest) reset(token, password string) error {
+	return p.accountUsecase.ResetPassword(context.Background(), usecase.ResetPasswordInput{Token: token, NewPassword: password})
+}
+
+// login 指定したパスワードでログイン
+func (p *passwordResetTest) login(password string) (*usecase.AuthTokens, error) {
+	return p.authUsecase.Login(context.Background(), usecase.LoginInput{Email: passwordResetEmail, Password: password})
+}
+
+// TestPasswordReset_ConsumeOnce パスワード再設定のトークンが1回だけ使用できることをテスト
+func TestPasswordReset_ConsumeOnce(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("1回目でパスワードを変更し、2回目は拒否する", func(t *testing.T) {
+		test := newPasswordResetTest(t)
+		session, err := test.login(passwordResetPassword)
+		if err != nil {
+			t.Fatalf("❌ ログインに失敗: %v", err)
+		}
+		token := test.requestToken(t)
+
+		if err := test.reset(token, "NewPassword456!"); err != nil {
+			t.Fatalf("❌ パスワードの再設定に失敗: %v", err)
+		}
+		if _, err := test.login("NewPassword456!"); err != nil {
+			t.Errorf("❌ 新しいパスワードでログインできません: %v", err)
+		}
+		if _, err := test.login(passwordResetPassword); !errors.Is(err, domain.ErrInvalidCredentials) {
+			t.Errorf("❌ 古いパスワード 期待値: ErrInvalidCredentials, 実際: %v", err)
+		}
+		if _, err := test.authUsecase.RefreshToken(ctx, session.RefreshToken, "", "", ""); err == nil {
+			t.Error("❌ 再設定前のセッションが無効化されていません")
+		}
+		if logs, _ := test.audit.GetByEventType(ctx, domain.EventPasswordChanged, -1, 0); len(logs) != 1 {
+			t.Errorf("❌ PASSWORD_CHANGEDの件数 期待値: 1, 実際: %d", len(logs))
+		}
+
+		if err := test.reset(token, "AnotherPassword789!"); !errors.Is(err, domain.ErrInvalidPasswordResetToken) {
+			t.Errorf("❌ 2回目 期待値: ErrInvalidPasswordResetToken, 実際: %v", err)
+		}
+	})
+
+	t.Run("同時に使用しても1件だけ成功する", func(t *testing.T) {
+		test := newPasswordResetTest(t)
+		token := test.requestToken(t)
+
+		const concurrency = 5
+		errs := make([]error, concurrency)
+		var wg sync.WaitGroup
+		for i := 0; i < concurrency; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = test.reset(token, "NewPassword456!")
+			}(i)
+		}
+		wg.Wait()
+
+		succeeded := 0
+		for _, err := range errs {
+			switch {
+			case err == nil:
+				succeeded++
+			case !errors.Is(err, domain.ErrInvalidPasswordResetToken):
+				t.Errorf("❌ 予期しないエラー: %v", err)
+			}
+		}
+		if succeeded != 1 {
+			t.Errorf("❌ 成功した件数 期待値: 1, 実際: %d", succeeded)
+		}
+	})
+
+	t.Run("現在のパスワードへの変更は拒否し、トークンは消費しない", func(t *testing.T) {
+		test := newPasswordResetTest(t)
+		token := test.requestToken(t)
+
+		if err := test.reset(token, passwordResetPassword); !errors.Is(err, domain.ErrPasswordReused) {
+			t.Fatalf("❌ 期待値: ErrPasswordReused, 実際: %v", err)
+		}
+		if err := test.reset(token, "NewPassword456!"); err != nil {
+			t.Errorf("❌ 拒否した後の再設定に失敗: %v", err)
+		}
+	})
+}
+
+// TestPasswordReset_Expiry 有効期限（既定1時間）を過ぎたトークンを拒否することをテスト
+func TestPasswordReset_Expiry(t *testing.T) {
+	test := newPasswordResetTest(t)
+	token := test.requestToken(t)
+
+	reset, err := test.resets.GetByTokenHash(context.Background(), auth.HashToken(token))
+	if err != nil {
+		t.Fatalf("❌ トークンの取得に失敗: %v", err)
+	}
+	if lifetime := reset.ExpiresAt.Sub(reset.CreatedAt); lifetime < time.Hour-time.Second || lifetime > time.Hour+time.Second {
+		t.Errorf("❌ 有効期限 期待値: 1h, 実際: %s", lifetime)
+	}
+
+	test.resets.rewind(61 * time.Minute)
+
+	if err := test.reset(token, "NewPassword456!"); !errors.Is(err, domain.ErrInvalidPasswordResetToken) {
+		t.Errorf("❌ 期待値: ErrInvalidPasswordResetToken, 実際: %v", err)
+	}
+	if _, err := test.login(passwordResetPassword); err != nil {
+		t.Errorf("❌ 期限切れのトークンでパスワードが変更されました: %v", err)
+	}
+}
+
+// TestPasswordReset_Supersede 新しいトークンを要求すると以前のトークンが無効になることをテスト
+func TestPasswordReset_Supersede(t *testing.T) {
+	test := newPasswordResetTest(t)
+	first := test.requestToken(t)
+	second := test.requestToken(t)
+
+	if err := test.reset(first, "NewPassword456!"); !errors.Is(err, domain.ErrInvalidPasswordResetToken) {
+		t.Errorf("❌ 以前のトークン 期待値: ErrInvalidPasswordResetToken, 実際: %v", err)
+	}
+	if err := test.reset(second, "NewPassword456!"); err != nil {
+		t.Errorf("❌ 新しいトークンでの再設定に失敗: %v", err)
+	}
+}
+
+// TestPasswordReset_HTTP 登録の有無や無効になった理由をレスポンスから区別できないことをテスト
+func TestPasswordReset_HTTP(t *testing.T) {
+	test := newPasswordResetTest(t)
+	server := handler.NewServer(test.accountUsecase, nil, nil, handler.HealthConfig{}, handler.PrivacyConfig{}, logger.NewLoggerWithOutput("error", "json", io.Discard))
+	e := echo.New()
+	api.RegisterHandlersWithBaseURL(e, server, "/api/v1")
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	for _, email := range []string{passwordResetEmail, "unknown@example.com"} {
+		resp, body := sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/password-reset", nil, map[string]string{"email": email})
+		if resp.StatusCode != http.StatusOK || len(body) != 0 {
+			t.Errorf("❌ %s: 期待値: 200で本文なし, 実際: %d, body: %s", email, resp.StatusCode, body)
+		}
+	}
+	if len(test.notifier.messages) != 1 || test.notifier.messages[0].To != passwordResetEmail {
+		t.Fatalf("❌ 登録済みのメールアドレスにのみ送信される必要があります: %+v", test.notifier.messages)
+	}
+
+	superseded := test.requestToken(t)
+	used := test.requestToken(t)
+	confirm := func(token string) (*http.Response, []byte) {
+		return sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/password-reset/confirm", nil,
+			map[string]string{"token": token, "new_password": "NewPassword456!"})
+	}
+	if resp, body := confirm(used); resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("❌ ステータスコード 期待値: 204, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+
+	var want []byte
+	for name, token := range map[string]string{"使用済み": used, "無効化済み": superseded, "不明": "unknown-token"} {
+		resp, body := confirm(token)
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("❌ %s: ステータスコード 期待値: 400, 実際: %d, body: %s", name, resp.StatusCode, body)
+			continue
+		}
+		var got api.Error
+		if err := json.Unmarshal(body, &got); err != nil || got.Code != api.ErrorCodeInvalidPasswordResetToken {
+			t.Errorf("❌ %s: code 期待値: invalid_password_reset_token, 実際: %s", name, body)
+		}
+		if want == nil {
+			want = body
+		} else if string(body) != string(want) {
+			t.Errorf("❌ %s: トークンの状態によってレスポンスが異なります: %s / %s", name, want, body)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+)
+
+// TestProjectActors プロジェクトの作成者・最終更新者の記録をテスト
+func TestProjectActors(t *testing.T) {
+	ctx := context.Background()
+	srv, accountRepo, _ := newAdminTestServer(t)
+
+	owner := domain.NewAccount("actor-owner@example.com", "Owner", "hash")
+	collaborator := domain.NewAccount("actor-collaborator@example.com", "Collaborator", "hash")
+	for _, a := range []*domain.Account{owner, collaborator} {
+		if err := accountRepo.Create(ctx, a); err != nil {
+			t.Fatalf("❌ アカウント作成に失敗: %v", err)
+		}
+	}
+
+	decode := func(t *testing.T, body []byte) api.Project {
+		t.Helper()
+		var project api.Project
+		if err := json.Unmarshal(body, &project); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		return project
+	}
+
+	projectsPath := "/api/v1/accounts/" + owner.ID.String() + "/projects"
+	resp, body := sendAsAccount(t, srv, http.MethodPost, projectsPath, owner.ID, map[string]string{"name": "Shared"})
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("❌ 作成 ステータスコード 期待値: 201, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+	created := decode(t, body)
+
+	if created.CreatedBy == nil || *created.CreatedBy != owner.ID {
+		t.Errorf("❌ created_by 期待値: %s, 実際: %v", owner.ID, created.CreatedBy)
+	}
+	if created.UpdatedBy == nil || *created.UpdatedBy != owner.ID {
+		t.Errorf("❌ 作成時のupdated_by 期待値: %s, 実際: %v", owner.ID, created.UpdatedBy)
+	}
+
+	// 別のアカウントが更新すると最終更新者のみ変わる
+	resp, body = sendAsAccount(t, srv, http.MethodPut, projectsPath+"/"+created.Id.String(), collaborator.ID,
+		map[string]string{"description": "edited"})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("❌ 更新 ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+	updated := decode(t, body)
+
+	if updated.CreatedBy == nil || *updated.CreatedBy != owner.ID {
+		t.Errorf("❌ 更新後にcreated_byが変わっています: %v", updated.CreatedBy)
+	}
+	if updated.UpdatedBy == nil || *updated.UpdatedBy != collaborator.ID {
+		t.Errorf("❌ updated_by 期待値: %s, 実際: %v", collaborator.ID, updated.UpdatedBy)
+	}
+
+	// 認証情報の無いリクエストは拒否される
+	resp, body = sendAsRole(t, srv, http.MethodPost, projectsPath, "user", map[string]string{"name": "Anonymous"})
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("❌ ステータスコード 期待値: 401, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,135 @@
+package tests_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/config"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/google/uuid"
+)
+
+// TestProjectDescription_Validate プロジェクト説明の文字数の検証と制御文字の除去をテスト
+func TestProjectDescription_Validate(t *testing.T) {
+	cases := []struct {
+		name        string
+		description string
+		expected    string
+		valid       bool
+	}{
+		{"空文字", "", "", true},
+		{"最大文字数", strings.Repeat("a", 10), strings.Repeat("a", 10), true},
+		{"マルチバイトの最大文字数", strings.Repeat("あ", 10), strings.Repeat("あ", 10), true},
+		{"改行とタブは残す", "a\r\nb\tc", "a\nb\tc", true},
+		{"制御文字を除去", "a\x00b\x1bc\x7f", "abc", true},
+		{"除去後の文字数で判定", strings.Repeat("a", 10) + "\x00\x00", strings.Repeat("a", 10), true},
+		{"最大文字数超過", strings.Repeat("a", 11), "", false},
+		{"不正なUTF-8", "bad\xff", "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			description, err := domain.NormalizeProjectDescription(tc.description, 10)
+			if !tc.valid {
+				if !errors.Is(err, domain.ErrInvalidDescription) {
+					t.Errorf("❌ 期待値: ErrInvalidDescription, 実際: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("❌ 許可されるべき説明が拒否されました: %v", err)
+			}
+			if description != tc.expected {
+				t.Errorf("❌ 説明 期待値: %q, 実際: %q", tc.expected, description)
+			}
+		})
+	}
+
+	t.Run("エンティティの検証は保存できる上限のみ確認する", func(t *testing.T) {
+		project := domain.NewProject(uuid.New(), "Project", strings.Repeat("a", domain.MaxProjectDescriptionLength))
+		if err := project.Validate(); err != nil {
+			t.Errorf("❌ 上限以内の説明が拒否されました: %v", err)
+		}
+		project.Description += "a"
+		if err := project.Validate(); !errors.Is(err, domain.ErrInvalidDescription) {
+			t.Errorf("❌ 期待値: ErrInvalidDescription, 実際: %v", err)
+		}
+	})
+
+	t.Run("不正な設定は拒否する", func(t *testing.T) {
+		t.Setenv("JWT_ACCESS_TOKEN_SECRET", "test-access-secret-0123456789abcdef")
+		t.Setenv("JWT_REFRESH_TOKEN_SECRET", "test-refresh-secret-0123456789abcdef")
+		for _, maxLength := range []string{"-1", strconv.Itoa(domain.MaxProjectDescriptionLength + 1)} {
+			t.Setenv("PROJECT_DESCRIPTION_MAX_LENGTH", maxLength)
+			if _, err := config.LoadConfig(); err == nil {
+				t.Errorf("❌ 不正な最大文字数が受け付けられました: %s", maxLength)
+			}
+		}
+	})
+}
+
+// TestProjectDescription_Requests 作成・更新で長すぎる説明を400で拒否することをテスト
+func TestProjectDescription_Requests(t *testing.T) {
+	srv, accountRepo, _ := newAdminTestServerWithProjectConfig(t, usecase.ProjectConfig{DescriptionMaxLength: 20})
+
+	owner := domain.NewAccount("description@example.com", "Owner", "hash")
+	if err := accountRepo.Create(t.Context(), owner); err != nil {
+		t.Fatalf("❌ アカウント作成に失敗: %v", err)
+	}
+	path := "/api/v1/accounts/" + owner.ID.String() + "/projects"
+	tooLong := strings.Repeat("あ", 21)
+
+	assertDescriptionRejected := func(t *testing.T, resp *http.Response, body []byte) {
+		t.Helper()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("❌ ステータスコード 期待値: 400, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var apiErr api.Error
+		if err := json.Unmarshal(body, &apiErr); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if apiErr.Code != api.ErrorCodeInvalidRequest || !strings.Contains(apiErr.Error, "at most 20 characters") {
+			t.Errorf("❌ エラー 期待値: 最大文字数を含むinvalid_request, 実際: %s (%s)", apiErr.Error, apiErr.Code)
+		}
+	}
+
+	t.Run("作成時に長すぎる説明を拒否", func(t *testing.T) {
+		description := tooLong
+		resp, body := sendAsAccount(t, srv, http.MethodPost, path, owner.ID, api.CreateProjectRequest{
+			Name:        "Too Long",
+			Description: &description,
+		})
+		assertDescriptionRejected(t, resp, body)
+	})
+
+	t.Run("制御文字を取り除いて保存", func(t *testing.T) {
+		description := "line1\r\nline2\x00"
+		resp, body := sendAsAccount(t, srv, http.MethodPost, path, owner.ID, api.CreateProjectRequest{
+			Name:        "Sanitized",
+			Description: &description,
+		})
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("❌ ステータスコード 期待値: 201, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var created api.Project
+		if err := json.Unmarshal(body, &created); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if created.Description == nil || *created.Description != "line1\nline2" {
+			t.Errorf("❌ 説明 期待値: %q, 実際: %v", "line1\nline2", created.Description)
+		}
+
+		t.Run("更新時に長すぎる説明を拒否", func(t *testing.T) {
+			resp, body := sendAsAccount(t, srv, http.MethodPut, path+"/"+created.Id.String(), owner.ID, map[string]string{
+				"description": tooLong,
+			})
+			assertDescriptionRejected(t, resp, body)
+		})
+	})
+}
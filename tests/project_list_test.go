@@ -0,0 +1,103 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+)
+
+// TestListProjects_Pagination アカウントのプロジェクト一覧のページングと総数をテスト
+func TestListProjects_Pagination(t *testing.T) {
+	ctx := context.Background()
+	srv, accountRepo, projectRepo := newAdminTestServer(t)
+
+	owner := domain.NewAccount("pager@example.com", "Pager", "hash")
+	other := domain.NewAccount("pager-other@example.com", "Other", "hash")
+	for _, a := range []*domain.Account{owner, other} {
+		if err := accountRepo.Create(ctx, a); err != nil {
+			t.Fatalf("❌ アカウント作成に失敗: %v", err)
+		}
+	}
+
+	// 作成日時の新しい順に並ぶよう1分ずつずらす
+	const projectCount = 5
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < projectCount; i++ {
+		project := domain.NewProject(owner.ID, fmt.Sprintf("Project %d", i), "")
+		project.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+		if err := projectRepo.Create(ctx, project); err != nil {
+			t.Fatalf("❌ プロジェクト作成に失敗: %v", err)
+		}
+	}
+	if err := projectRepo.Create(ctx, domain.NewProject(other.ID, "Other", "")); err != nil {
+		t.Fatalf("❌ プロジェクト作成に失敗: %v", err)
+	}
+
+	path := "/api/v1/accounts/" + owner.ID.String() + "/projects"
+	list := func(t *testing.T, query string) api.ProjectList {
+		t.Helper()
+		resp, body := sendAsAccount(t, srv, http.MethodGet, path+query, owner.ID, nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var result api.ProjectList
+		if err := json.Unmarshal(body, &result); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		return result
+	}
+
+	t.Run("既定値では全件と総数を返す", func(t *testing.T) {
+		result := list(t, "")
+		if result.Total != projectCount || len(result.Items) != projectCount {
+			t.Errorf("❌ 件数 期待値: %d, 実際: total=%d, items=%d", projectCount, result.Total, len(result.Items))
+		}
+		if result.Limit != 20 || result.Offset != 0 {
+			t.Errorf("❌ limit=%d offset=%d", result.Limit, result.Offset)
+		}
+	})
+
+	t.Run("どのページでも総数は同じ", func(t *testing.T) {
+		seen := make(map[string]bool, projectCount)
+		for offset := 0; offset < projectCount; offset += 2 {
+			result := list(t, fmt.Sprintf("?limit=2&offset=%d", offset))
+			if result.Total != projectCount {
+				t.Errorf("❌ offset=%d: total 期待値: %d, 実際: %d", offset, projectCount, result.Total)
+			}
+			if want := min(2, projectCount-offset); len(result.Items) != want {
+				t.Errorf("❌ offset=%d: 件数 期待値: %d, 実際: %d", offset, want, len(result.Items))
+			}
+			for _, p := range result.Items {
+				if seen[p.Name] {
+					t.Errorf("❌ %s が複数のページに含まれています", p.Name)
+				}
+				seen[p.Name] = true
+			}
+		}
+		if len(seen) != projectCount {
+			t.Errorf("❌ 全ページの件数 期待値: %d, 実際: %d", projectCount, len(seen))
+		}
+	})
+
+	t.Run("範囲外のページは空で総数を返す", func(t *testing.T) {
+		result := list(t, "?offset=10")
+		if result.Total != projectCount || len(result.Items) != 0 {
+			t.Errorf("❌ total=%d items=%d", result.Total, len(result.Items))
+		}
+	})
+
+	t.Run("不正なパラメータは400", func(t *testing.T) {
+		for _, query := range []string{"?limit=0", "?limit=101", "?offset=-1"} {
+			resp, body := sendAsAccount(t, srv, http.MethodGet, path+query, owner.ID, nil)
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("❌ %s: ステータスコード 期待値: 400, 実際: %d, body: %s", query, resp.StatusCode, body)
+			}
+		}
+	})
+}
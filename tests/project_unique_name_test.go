@@ -0,0 +1,106 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/google/uuid"
+)
+
+// TestProject_UniqueNames PROJECT_UNIQUE_NAMESが有効な場合はアカウント内で同じ名前のプロジェクトを拒否し、無効な場合は許可することをテスト
+func TestProject_UniqueNames(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T, uniqueNames bool) (*httptest.Server, *domain.Account, *domain.Account) {
+		t.Helper()
+		srv, accountRepo, _ := newAdminTestServerWithProjectConfig(t, usecase.ProjectConfig{UniqueNames: uniqueNames})
+		owner := domain.NewAccount("unique-owner@example.com", "Owner", "hash")
+		other := domain.NewAccount("unique-other@example.com", "Other", "hash")
+		for _, a := range []*domain.Account{owner, other} {
+			if err := accountRepo.Create(ctx, a); err != nil {
+				t.Fatalf("❌ アカウント作成に失敗: %v", err)
+			}
+		}
+		return srv, owner, other
+	}
+
+	createProject := func(t *testing.T, srv *httptest.Server, accountID uuid.UUID, name string) (*http.Response, []byte) {
+		t.Helper()
+		return sendAsAccount(t, srv, http.MethodPost, "/api/v1/accounts/"+accountID.String()+"/projects", accountID, map[string]string{"name": name})
+	}
+	renameProject := func(t *testing.T, srv *httptest.Server, accountID uuid.UUID, projectID, name string) (*http.Response, []byte) {
+		t.Helper()
+		return sendAsAccount(t, srv, http.MethodPut, "/api/v1/accounts/"+accountID.String()+"/projects/"+projectID, accountID, map[string]string{"name": name})
+	}
+	mustCreate := func(t *testing.T, srv *httptest.Server, accountID uuid.UUID, name string) string {
+		t.Helper()
+		resp, body := createProject(t, srv, accountID, name)
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("❌ ステータスコード 期待値: 201, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var project api.Project
+		if err := json.Unmarshal(body, &project); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		return project.Id.String()
+	}
+	assertNameExists := func(t *testing.T, resp *http.Response, body []byte) {
+		t.Helper()
+		if resp.StatusCode != http.StatusConflict {
+			t.Fatalf("❌ ステータスコード 期待値: 409, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var apiErr api.Error
+		if err := json.Unmarshal(body, &apiErr); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if apiErr.Code != api.ErrorCodeProjectNameExists {
+			t.Errorf("❌ エラーコード 期待値: %s, 実際: %s", api.ErrorCodeProjectNameExists, apiErr.Code)
+		}
+	}
+
+	t.Run("有効な場合", func(t *testing.T) {
+		srv, owner, other := setup(t, true)
+		alphaID := mustCreate(t, srv, owner.ID, "Alpha")
+		betaID := mustCreate(t, srv, owner.ID, "Beta")
+
+		t.Run("同じ名前のプロジェクトの作成を拒否する（大文字・小文字を区別しない）", func(t *testing.T) {
+			for _, name := range []string{"Alpha", "ALPHA"} {
+				resp, body := createProject(t, srv, owner.ID, name)
+				assertNameExists(t, resp, body)
+			}
+		})
+
+		t.Run("他のプロジェクトと同じ名前への変更を拒否する", func(t *testing.T) {
+			resp, body := renameProject(t, srv, owner.ID, betaID, "alpha")
+			assertNameExists(t, resp, body)
+		})
+
+		t.Run("自分の名前のままの更新は許可する", func(t *testing.T) {
+			resp, body := renameProject(t, srv, owner.ID, alphaID, "Alpha")
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+			}
+		})
+
+		t.Run("他のアカウントでは同じ名前を使える", func(t *testing.T) {
+			mustCreate(t, srv, other.ID, "Alpha")
+		})
+	})
+
+	t.Run("無効な場合は同じ名前を許可する", func(t *testing.T) {
+		srv, owner, _ := setup(t, false)
+		mustCreate(t, srv, owner.ID, "Alpha")
+		betaID := mustCreate(t, srv, owner.ID, "Alpha")
+
+		resp, body := renameProject(t, srv, owner.ID, betaID, "Alpha")
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package tests_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/handler"
+	"github.com/aida0710/jwt-auth/internal/infrastructure/database"
+	"github.com/aida0710/jwt-auth/internal/logger"
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/aida0710/jwt-auth/internal/repository"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/labstack/echo/v4"
+)
+
+// TestQueryTimeout 遅いクエリがタイムアウトで打ち切られることをテスト
+func TestQueryTimeout(t *testing.T) {
+	db := database.NewDB(openTestDB(t), 200*time.Millisecond)
+
+	t.Run("タイムアウトを超えたクエリはErrQueryTimeout", func(t *testing.T) {
+		ctx := context.Background()
+		var slept int
+
+		start := time.Now()
+		err := database.GetExecutor(ctx, db).GetContext(ctx, &slept, "SELECT SLEEP(3)")
+		elapsed := time.Since(start)
+
+		if !errors.Is(err, database.ErrQueryTimeout) {
+			t.Fatalf("❌ ErrQueryTimeoutを期待しましたが %v", err)
+		}
+		if elapsed > 2*time.Second {
+			t.Errorf("❌ タイムアウト後もクエリの完了を待っています: %s", elapsed)
+		}
+	})
+
+	t.Run("呼び出し元のキャンセルはタイムアウトとして扱わない", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		var slept int
+
+		err := database.GetExecutor(ctx, db).GetContext(ctx, &slept, "SELECT SLEEP(3)")
+		if err == nil || errors.Is(err, database.ErrQueryTimeout) {
+			t.Errorf("❌ 呼び出し元のエラーをそのまま返すべきです: %v", err)
+		}
+	})
+
+	t.Run("タイムアウト内のクエリは成功", func(t *testing.T) {
+		ctx := context.Background()
+		var one int
+		if err := database.GetExecutor(ctx, db).GetContext(ctx, &one, "SELECT 1"); err != nil || one != 1 {
+			t.Errorf("❌ クエリが失敗しました: %v", err)
+		}
+	})
+}
+
+// stalledConnector 接続がコンテキストの終了まで応答せず、クエリが完了しない状態を再現するConnector
+type stalledConnector struct{}
+
+func (stalledConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+func (stalledConnector) Driver() driver.Driver { return lostDriver{} }
+
+// openStalledDB クエリが完了しないデータベースを開く
+func openStalledDB(t *testing.T, queryTimeout time.Duration) *database.DB {
+	t.Helper()
+	db := sqlx.NewDb(sql.OpenDB(stalledConnector{}), "mysql")
+	t.Cleanup(func() { db.Close() })
+	return database.NewDB(db, queryTimeout)
+}
+
+// TestQueryTimeout_NotConnectionError タイムアウトしたクエリを接続の障害（ErrServiceUnavailable）として扱わないことをテスト
+func TestQueryTimeout_NotConnectionError(t *testing.T) {
+	db := openStalledDB(t, 50*time.Millisecond)
+
+	_, err := repository.NewAccountRepository(db).GetByID(context.Background(), uuid.Must(uuid.NewV7()))
+	if !errors.Is(err, database.ErrQueryTimeout) {
+		t.Fatalf("❌ ErrQueryTimeoutを期待しましたが %v", err)
+	}
+	if errors.Is(err, domain.ErrServiceUnavailable) {
+		t.Errorf("❌ タイムアウトをErrServiceUnavailableでラップしています: %v", err)
+	}
+}
+
+// TestQueryTimeout_API クエリのタイムアウトを504とquery_timeoutとして返すことをテスト
+func TestQueryTimeout_API(t *testing.T) {
+	db := openStalledDB(t, 50*time.Millisecond)
+	accountRepo := repository.NewAccountRepository(db)
+
+	log := logger.NewLoggerWithOutput("error", "json", io.Discard)
+	accountUsecase := usecase.NewAccountUsecase(accountRepo, repository.NewProjectRepository(db), nil, nil, nil, nil, fakeTxManager{}, nil, usecase.AccountConfig{})
+	server := handler.NewServer(accountUsecase, nil, nil, handler.HealthConfig{}, handler.PrivacyConfig{}, log)
+
+	e := echo.New()
+	e.HTTPErrorHandler = middleware.NewErrorHandler(log).HTTPErrorHandler
+	api.RegisterHandlersWithBaseURL(e, server, "/api/v1")
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	resp, body := sendTestRequest(t, srv, http.MethodGet, "/api/v1/accounts/"+uuid.Must(uuid.NewV7()).String(), nil, nil)
+	if resp.StatusCode != http.StatusGatewayTimeout {
+		t.Fatalf("❌ ステータスコード 期待値: 504, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+	if resp.Header.Get("Retry-After") != "" {
+		t.Errorf("❌ タイムアウトにRetry-Afterヘッダーを付けています: %s", resp.Header.Get("Retry-After"))
+	}
+	var got api.Error
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+	}
+	if got.Code != api.ErrorCodeQueryTimeout {
+		t.Errorf("❌ code 期待値: query_timeout, 実際: %s", got.Code)
+	}
+}
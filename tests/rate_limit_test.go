@@ -0,0 +1,252 @@
+package tests_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// newRateLimitTestEcho レート制限を適用したテスト用のEchoを作成
+// 返却する関数で時刻を進められる
+func newRateLimitTestEcho(t *testing.T, requests int, window time.Duration) (*echo.Echo, func(time.Duration)) {
+	t.Helper()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	e := newTrustedProxyTestEcho(t)
+	e.Use(middleware.NewRateLimitMiddleware(middleware.RateLimitConfig{
+		PathPrefixes: []string{"/api/v1/auth/login"},
+		Requests:     requests,
+		Window:       window,
+		Now:          func() time.Time { return now },
+	}))
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	e.POST("/api/v1/auth/login", ok)
+	e.GET("/api/v1/health", ok)
+	return e, func(d time.Duration) { now = now.Add(d) }
+}
+
+// serveFrom 接続元アドレスを指定してリクエストを処理する
+func serveFrom(e *echo.Echo, method, path, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = remoteAddr
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+// TestRateLimitMiddleware レート制限のヘッダーと429レスポンスをテスト
+func TestRateLimitMiddleware(t *testing.T) {
+	e, advance := newRateLimitTestEcho(t, 3, time.Minute)
+	const client = "198.51.100.7:5000"
+
+	t.Run("許可されたリクエストでも残り回数が減っていく", func(t *testing.T) {
+		for i, expected := range []string{"2", "1", "0"} {
+			rec := serveFrom(e, http.MethodPost, "/api/v1/auth/login", client)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("❌ %d件目 ステータスコード 期待値: 200, 実際: %d", i+1, rec.Code)
+			}
+			if got := rec.Header().Get(middleware.HeaderRateLimitLimit); got != "3" {
+				t.Errorf("❌ X-RateLimit-Limit 期待値: 3, 実際: %q", got)
+			}
+			if got := rec.Header().Get(middleware.HeaderRateLimitRemaining); got != expected {
+				t.Errorf("❌ %d件目 X-RateLimit-Remaining 期待値: %s, 実際: %q", i+1, expected, got)
+			}
+			if got := rec.Header().Get(echo.HeaderRetryAfter); got != "" {
+				t.Errorf("❌ 許可されたリクエストにRetry-Afterが付与されています: %q", got)
+			}
+		}
+	})
+
+	t.Run("上限を超えると429とRetry-Afterを返す", func(t *testing.T) {
+		advance(15 * time.Second)
+		rec := serveFrom(e, http.MethodPost, "/api/v1/auth/login", client)
+		if rec.Code != http.StatusTooManyRequests {
+			t.Fatalf("❌ ステータスコード 期待値: 429, 実際: %d", rec.Code)
+		}
+		if got := rec.Header().Get(echo.HeaderRetryAfter); got != "45" {
+			t.Errorf("❌ Retry-After 期待値: 45, 実際: %q", got)
+		}
+		if got := rec.Header().Get(middleware.HeaderRateLimitRemaining); got != "0" {
+			t.Errorf("❌ X-RateLimit-Remaining 期待値: 0, 実際: %q", got)
+		}
+
+		var body map[string]interface{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if len(body) != 3 || body["code"] != "rate_limited" || body["error"] == "" || body["retry_after_seconds"] != float64(45) {
+			t.Errorf("❌ レスポンスボディが想定と異なります: %s", rec.Body.String())
+		}
+	})
+
+	t.Run("接続元ごとに数える", func(t *testing.T) {
+		rec := serveFrom(e, http.MethodPost, "/api/v1/auth/login", "198.51.100.8:5000")
+		if rec.Code != http.StatusOK {
+			t.Errorf("❌ ステータスコード 期待値: 200, 実際: %d", rec.Code)
+		}
+	})
+
+	t.Run("対象外のパスは制限せずヘッダーも付与しない", func(t *testing.T) {
+		rec := serveFrom(e, http.MethodGet, "/api/v1/health", client)
+		if rec.Code != http.StatusOK {
+			t.Errorf("❌ ステータスコード 期待値: 200, 実際: %d", rec.Code)
+		}
+		if got := rec.Header().Get(middleware.HeaderRateLimitLimit); got != "" {
+			t.Errorf("❌ 対象外のパスにX-RateLimit-Limitが付与されています: %q", got)
+		}
+	})
+
+	t.Run("期間が過ぎると再び許可される", func(t *testing.T) {
+		advance(45 * time.Second)
+		rec := serveFrom(e, http.MethodPost, "/api/v1/auth/login", client)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d", rec.Code)
+		}
+		if got, _ := strconv.Atoi(rec.Header().Get(middleware.HeaderRateLimitRemaining)); got != 2 {
+			t.Errorf("❌ X-RateLimit-Remaining 期待値: 2, 実際: %d", got)
+		}
+	})
+}
+
+// TestRateLimitMiddleware_Principals APIキーとアカウントは接続元アドレスではなく主体ごとに数えることをテスト
+func TestRateLimitMiddleware_Principals(t *testing.T) {
+	const (
+		sharedIP   = "203.0.113.10:5000"
+		trustedKey = "billing-service-key-0123456789abcdef"
+	)
+	jwtManager := auth.NewJWTManager(auth.JWTConfig{
+		AccessTokenSecret:  "test-access-secret-0123456789abcdef",
+		RefreshTokenSecret: "test-refresh-secret-0123456789abcdef",
+		AccessTokenExpiry:  time.Minute,
+		RefreshTokenExpiry: time.Hour,
+		Issuer:             "jwt-auth-test",
+	})
+	tokenFor := func(t *testing.T) string {
+		t.Helper()
+		token, err := jwtManager.GenerateAccessToken(uuid.New(), "limited@example.com", "user")
+		if err != nil {
+			t.Fatalf("❌ トークンの生成に失敗: %v", err)
+		}
+		return token
+	}
+
+	e := echo.New()
+	e.Use(middleware.NewRateLimitMiddleware(middleware.RateLimitConfig{
+		PathPrefixes: []string{"/api/v1/auth/login", "/api/v1/accounts"},
+		Requests:     2,
+		Window:       time.Minute,
+		Keys:         []middleware.RateLimitKey{{Name: "billing", Key: trustedKey, Requests: 5}},
+		JWTManager:   jwtManager,
+		PublicPaths:  []string{"/api/v1/auth/login"},
+	}))
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	e.POST("/api/v1/auth/login", ok)
+	e.GET("/api/v1/accounts", ok)
+
+	serveOn := func(method, path, header, value string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = sharedIP
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	serve := func(header, value string) *httptest.ResponseRecorder {
+		return serveOn(http.MethodGet, "/api/v1/accounts", header, value)
+	}
+	exhaust := func(t *testing.T, header, value string, limit int) {
+		t.Helper()
+		for i := 0; i < limit; i++ {
+			if rec := serve(header, value); rec.Code != http.StatusOK {
+				t.Fatalf("❌ %d件目 ステータスコード 期待値: 200, 実際: %d", i+1, rec.Code)
+			}
+		}
+		if rec := serve(header, value); rec.Code != http.StatusTooManyRequests {
+			t.Fatalf("❌ 上限超過 期待値: 429, 実際: %d", rec.Code)
+		}
+	}
+
+	// 同じアドレスからの認証されていないリクエストで上限を使い切る
+	exhaust(t, "", "", 2)
+
+	t.Run("無効なトークンや未登録のAPIキーは接続元アドレスで数える", func(t *testing.T) {
+		if rec := serve(echo.HeaderAuthorization, "Bearer invalid-token"); rec.Code != http.StatusTooManyRequests {
+			t.Errorf("❌ 無効なトークン 期待値: 429, 実際: %d", rec.Code)
+		}
+		if rec := serve(middleware.HeaderAPIKey, "unknown-key-0123456789abcdef0123456789"); rec.Code != http.StatusTooManyRequests {
+			t.Errorf("❌ 未登録のAPIキー 期待値: 429, 実際: %d", rec.Code)
+		}
+	})
+
+	t.Run("アカウントごとに数える", func(t *testing.T) {
+		first, second := "Bearer "+tokenFor(t), "Bearer "+tokenFor(t)
+		exhaust(t, echo.HeaderAuthorization, first, 2)
+		if rec := serve(echo.HeaderAuthorization, second); rec.Code != http.StatusOK {
+			t.Errorf("❌ 別のアカウント 期待値: 200, 実際: %d", rec.Code)
+		}
+	})
+
+	t.Run("信頼済みのAPIキーはキーごとの上限で数える", func(t *testing.T) {
+		rec := serve(middleware.HeaderAPIKey, trustedKey)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d", rec.Code)
+		}
+		if got := rec.Header().Get(middleware.HeaderRateLimitLimit); got != "5" {
+			t.Errorf("❌ X-RateLimit-Limit 期待値: 5, 実際: %q", got)
+		}
+		exhaust(t, middleware.HeaderAPIKey, trustedKey, 4)
+	})
+
+	t.Run("認証不要のパスではトークンを送信しても接続元アドレスで数える", func(t *testing.T) {
+		// 接続元アドレスの上限は最初に使い切っているため、使い捨てのアカウントのトークンを送っても回復しない
+		for i := 0; i < 2; i++ {
+			rec := serveOn(http.MethodPost, "/api/v1/auth/login", echo.HeaderAuthorization, "Bearer "+tokenFor(t))
+			if rec.Code != http.StatusTooManyRequests {
+				t.Errorf("❌ トークンを送信したログイン 期待値: 429, 実際: %d", rec.Code)
+			}
+		}
+	})
+}
+
+// TestParseRateLimitKeys 信頼済みのAPIキーの設定の解析をテスト
+func TestParseRateLimitKeys(t *testing.T) {
+	keys, err := middleware.ParseRateLimitKeys([]string{
+		"billing:0123456789abcdef0123456789abcdef:1000",
+		" reports:key:with:colons-0123456789abcdef0123:50 ",
+		"",
+	})
+	if err != nil {
+		t.Fatalf("❌ 解析に失敗: %v", err)
+	}
+	if len(keys) != 2 || keys[0].Name != "billing" || keys[0].Requests != 1000 ||
+		keys[1].Key != "key:with:colons-0123456789abcdef0123" || keys[1].Requests != 50 {
+		t.Errorf("❌ 解析結果が不正です: %+v", keys)
+	}
+
+	invalid := map[string][]string{
+		"区切りが無い":   {"billing"},
+		"上限が無い":    {"billing:0123456789abcdef0123456789abcdef"},
+		"上限が数値でない": {"billing:0123456789abcdef0123456789abcdef:many"},
+		"上限が0":     {"billing:0123456789abcdef0123456789abcdef:0"},
+		"キーが短い":    {"billing:short:10"},
+		"名前が重複":    {"billing:0123456789abcdef0123456789abcdef:10", "billing:fedcba9876543210fedcba9876543210:10"},
+		"キーが重複":    {"billing:0123456789abcdef0123456789abcdef:10", "reports:0123456789abcdef0123456789abcdef:10"},
+	}
+	for name, entries := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := middleware.ParseRateLimitKeys(entries); err == nil {
+				t.Error("❌ 不正な設定が受け付けられました")
+			}
+		})
+	}
+}
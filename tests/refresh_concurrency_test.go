@@ -0,0 +1,93 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+)
+
+// TestRefreshToken_Concurrent 同じトークンでの同時リフレッシュが再利用として扱われないことをテスト
+func TestRefreshToken_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	// 猶予期間は無効のままでも、同時リフレッシュは再試行として扱う
+	authUsecase, refreshTokenRepo, auditRepo := newTestAuthUsecase(t)
+
+	signedUp, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+		Email:    "concurrent@example.com",
+		Password: "SecurePassword123!",
+		Name:     "Concurrent User",
+	})
+	if err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+
+	// 両方のリクエストが使用済みの確認を通過してから使用済みにマークするよう揃える
+	const concurrency = 2
+	var arrived sync.WaitGroup
+	arrived.Add(concurrency)
+	refreshTokenRepo.beforeMarkAsRotated = func() {
+		arrived.Done()
+		arrived.Wait()
+	}
+
+	results := make([]*usecase.AuthTokens, concurrency)
+	errs := make([]error, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = authUsecase.RefreshToken(ctx, signedUp.RefreshToken, "concurrent-test", "192.0.2.20", "")
+		}(i)
+	}
+	wg.Wait()
+	refreshTokenRepo.beforeMarkAsRotated = nil
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("❌ %d番目のリフレッシュに失敗: %v", i+1, err)
+		}
+	}
+	if results[0].RefreshToken != results[1].RefreshToken {
+		t.Error("❌ 同時リフレッシュで異なるリフレッシュトークンが返されました")
+	}
+
+	// 返されたトークンは有効で、通常どおりローテーションできる
+	if _, err := authUsecase.RefreshToken(ctx, results[0].RefreshToken, "concurrent-test", "192.0.2.20", ""); err != nil {
+		t.Errorf("❌ 返されたトークンでのリフレッシュに失敗: %v", err)
+	}
+
+	// 競合に負けた側で発行したトークン以外は無効化されていない
+	revoked := 0
+	for _, token := range refreshTokenRepo.tokens {
+		if token.RevokedAt != nil {
+			revoked++
+		}
+	}
+	if revoked != 1 {
+		t.Errorf("❌ 無効化されたトークン数 期待値: 1, 実際: %d", revoked)
+	}
+	if stored, err := refreshTokenRepo.GetByTokenHash(ctx, auth.HashToken(results[0].RefreshToken)); err != nil || stored.RevokedAt != nil {
+		t.Errorf("❌ 返されたトークンが無効化されています: %v", err)
+	}
+
+	if logs, _ := auditRepo.GetByEventType(ctx, domain.EventTokenReuseDetected, -1, 0); len(logs) != 0 {
+		t.Errorf("❌ 同時リフレッシュが再利用として記録されました: %d件", len(logs))
+	}
+	logs, _ := auditRepo.GetByEventType(ctx, domain.EventTokenRetryAccepted, -1, 0)
+	if len(logs) != 1 {
+		t.Fatalf("❌ TOKEN_RETRY_ACCEPTEDの件数 期待値: 1, 実際: %d", len(logs))
+	}
+	var metadata map[string]interface{}
+	if err := json.Unmarshal(logs[0].Metadata, &metadata); err != nil {
+		t.Fatalf("❌ メタデータのパースに失敗: %v", err)
+	}
+	if metadata["concurrent"] != true {
+		t.Errorf("❌ concurrent 期待値: true, 実際: %v", metadata["concurrent"])
+	}
+}
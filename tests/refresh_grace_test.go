@@ -0,0 +1,111 @@
+package tests_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+)
+
+// TestRefreshToken_ReuseGrace ローテーション直後の再試行と再利用攻撃の区別をテスト
+func TestRefreshToken_ReuseGrace(t *testing.T) {
+	ctx := context.Background()
+	const ua, ip = "grace-test", "192.0.2.10"
+
+	// setup 猶予期間を指定してサインアップし、1回ローテーションした状態を作る
+	setup := func(t *testing.T, grace time.Duration) (*usecase.AuthUsecase, *fakeRefreshTokenRepository, string, *usecase.AuthTokens) {
+		t.Helper()
+		authUsecase, refreshTokenRepo, _ := newTestAuthUsecaseWithConfig(t, usecase.AuthConfig{
+			RefreshTokenExpiry:     time.Hour,
+			RefreshTokenReuseGrace: grace,
+		})
+		initial, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+			Email:    "grace@example.com",
+			Password: "SecurePassword123!",
+			Name:     "Grace User",
+		})
+		if err != nil {
+			t.Fatalf("❌ サインアップに失敗: %v", err)
+		}
+		rotated, err := authUsecase.RefreshToken(ctx, initial.RefreshToken, ua, ip, "")
+		if err != nil {
+			t.Fatalf("❌ リフレッシュに失敗: %v", err)
+		}
+		return authUsecase, refreshTokenRepo, initial.RefreshToken, rotated
+	}
+
+	// expectCompromised 再利用として全トークンが無効化されたことを確認
+	expectCompromised := func(t *testing.T, repo *fakeRefreshTokenRepository, err error, rotated *usecase.AuthTokens) {
+		t.Helper()
+		if !errors.Is(err, domain.ErrTokenCompromised) {
+			t.Fatalf("❌ 期待値: ErrTokenCompromised, 実際: %v", err)
+		}
+		stored, getErr := repo.GetByTokenHash(ctx, auth.HashToken(rotated.RefreshToken))
+		if getErr != nil {
+			t.Fatalf("❌ トークンの取得に失敗: %v", getErr)
+		}
+		if stored.RevokedAt == nil {
+			t.Error("❌ ローテーション後のトークンが無効化されていません")
+		}
+	}
+
+	t.Run("猶予期間内の再試行は発行済みのトークンを返す", func(t *testing.T) {
+		authUsecase, _, old, rotated := setup(t, 10*time.Second)
+
+		retried, err := authUsecase.RefreshToken(ctx, old, ua, ip, "")
+		if err != nil {
+			t.Fatalf("❌ 再試行が拒否されました: %v", err)
+		}
+		if retried.RefreshToken != rotated.RefreshToken {
+			t.Error("❌ 発行済みのリフレッシュトークンと異なるトークンが返されました")
+		}
+		if retried.AccessToken == "" || retried.Account == nil || retried.Account.PasswordHash != "" {
+			t.Error("❌ 再試行のレスポンスが不完全です")
+		}
+
+		// 再送されたトークンで通常どおりローテーションできる
+		if _, err := authUsecase.RefreshToken(ctx, retried.RefreshToken, ua, ip, ""); err != nil {
+			t.Errorf("❌ 再送されたトークンでのリフレッシュに失敗: %v", err)
+		}
+	})
+
+	t.Run("猶予期間を過ぎた再提示は再利用として扱う", func(t *testing.T) {
+		authUsecase, repo, old, rotated := setup(t, 10*time.Second)
+
+		// 使用日時を猶予期間より前にずらす
+		stored, err := repo.GetByTokenHash(ctx, auth.HashToken(old))
+		if err != nil {
+			t.Fatalf("❌ トークンの取得に失敗: %v", err)
+		}
+		usedAt := time.Now().Add(-time.Minute)
+		repo.mu.Lock()
+		repo.tokens[stored.ID].UsedAt = &usedAt
+		repo.mu.Unlock()
+
+		_, err = authUsecase.RefreshToken(ctx, old, ua, ip, "")
+		expectCompromised(t, repo, err, rotated)
+	})
+
+	t.Run("次のトークンが使用済みなら猶予期間内でも再利用として扱う", func(t *testing.T) {
+		authUsecase, repo, old, rotated := setup(t, 10*time.Second)
+
+		next, err := authUsecase.RefreshToken(ctx, rotated.RefreshToken, ua, ip, "")
+		if err != nil {
+			t.Fatalf("❌ リフレッシュに失敗: %v", err)
+		}
+
+		_, err = authUsecase.RefreshToken(ctx, old, ua, ip, "")
+		expectCompromised(t, repo, err, next)
+	})
+
+	t.Run("猶予期間が0の場合は即座に再利用として扱う", func(t *testing.T) {
+		authUsecase, repo, old, rotated := setup(t, 0)
+
+		_, err := authUsecase.RefreshToken(ctx, old, ua, ip, "")
+		expectCompromised(t, repo, err, rotated)
+	})
+}
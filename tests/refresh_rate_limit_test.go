@@ -0,0 +1,145 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/config"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+)
+
+// TestRefreshRateLimit_TripsLimit 1つのアカウントのリフレッシュが上限を超えると記録して一時的に拒否することをテスト
+func TestRefreshRateLimit_TripsLimit(t *testing.T) {
+	ctx := context.Background()
+	const limit = 3
+
+	authUsecase, _, auditRepo := newTestAuthUsecaseWithConfig(t, usecase.AuthConfig{
+		RefreshTokenExpiry: time.Hour,
+		RefreshRateLimit:   limit,
+		RefreshRateWindow:  time.Minute,
+	})
+	tokens, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+		Email:    "farming@example.com",
+		Password: "SecurePassword123!",
+		Name:     "Farming User",
+	})
+	if err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+
+	refreshToken := tokens.RefreshToken
+	for i := 0; i < limit; i++ {
+		refreshed, err := authUsecase.RefreshToken(ctx, refreshToken, "farming-client", "203.0.113.10", "")
+		if err != nil {
+			t.Fatalf("❌ %d回目のリフレッシュに失敗: %v", i+1, err)
+		}
+		refreshToken = refreshed.RefreshToken
+	}
+
+	t.Run("上限を超えたリフレッシュは拒否して記録する", func(t *testing.T) {
+		_, err := authUsecase.RefreshToken(ctx, refreshToken, "farming-client", "203.0.113.10", "")
+		var limited *domain.RefreshRateLimitedError
+		if !errors.As(err, &limited) || !errors.Is(err, domain.ErrRefreshRateLimited) {
+			t.Fatalf("❌ エラー 期待値: RefreshRateLimitedError, 実際: %v", err)
+		}
+		if limited.RetryAfterSeconds() != 60 {
+			t.Errorf("❌ 再試行までの秒数 期待値: 60, 実際: %d", limited.RetryAfterSeconds())
+		}
+
+		logs, _ := auditRepo.GetByEventType(ctx, domain.EventRefreshRateLimited, 10, 0)
+		if len(logs) != 1 || logs[0].AccountID != tokens.Account.ID {
+			t.Fatalf("❌ REFRESH_RATE_LIMITEDが記録されていません: %+v", logs)
+		}
+	})
+
+	t.Run("拒否したトークンは無効化されず、再利用として扱われない", func(t *testing.T) {
+		_, err := authUsecase.RefreshToken(ctx, refreshToken, "farming-client", "203.0.113.10", "")
+		if !errors.Is(err, domain.ErrRefreshRateLimited) {
+			t.Errorf("❌ エラー 期待値: ErrRefreshRateLimited, 実際: %v", err)
+		}
+		if logs, _ := auditRepo.GetByEventType(ctx, domain.EventTokenReuseDetected, 10, 0); len(logs) != 0 {
+			t.Errorf("❌ 再利用として記録されました: %+v", logs)
+		}
+	})
+
+	t.Run("他のアカウントのリフレッシュには影響しない", func(t *testing.T) {
+		other, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+			Email:    "other-farming@example.com",
+			Password: "SecurePassword123!",
+			Name:     "Other User",
+		})
+		if err != nil {
+			t.Fatalf("❌ サインアップに失敗: %v", err)
+		}
+		if _, err := authUsecase.RefreshToken(ctx, other.RefreshToken, "", "", ""); err != nil {
+			t.Errorf("❌ 他のアカウントのリフレッシュに失敗: %v", err)
+		}
+	})
+}
+
+// TestRefreshRateLimit_Response 上限を超えたリフレッシュに429とRetry-Afterを返すことをテスト
+func TestRefreshRateLimit_Response(t *testing.T) {
+	authUsecase, _, _ := newTestAuthUsecaseWithConfig(t, usecase.AuthConfig{
+		RefreshTokenExpiry: time.Hour,
+		RefreshRateLimit:   1,
+		RefreshRateWindow:  30 * time.Second,
+	})
+	srv := newAuthTestServerWithUsecase(t, authUsecase)
+
+	tokens, err := authUsecase.SignUp(context.Background(), usecase.SignUpInput{
+		Email:    "farming-http@example.com",
+		Password: "SecurePassword123!",
+		Name:     "Farming User",
+	})
+	if err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+
+	refresh := func(refreshToken string) (*http.Response, []byte) {
+		return sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/refresh", nil, map[string]string{"refresh_token": refreshToken})
+	}
+	resp, body := refresh(tokens.RefreshToken)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+	var refreshed api.AuthResponse
+	if err := json.Unmarshal(body, &refreshed); err != nil {
+		t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+	}
+
+	resp, body = refresh(refreshed.RefreshToken)
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("❌ ステータスコード 期待値: 429, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "30" {
+		t.Errorf("❌ Retry-After 期待値: 30, 実際: %q", retryAfter)
+	}
+	var apiErr api.Error
+	if err := json.Unmarshal(body, &apiErr); err != nil {
+		t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+	}
+	if apiErr.Code != api.ErrorCodeRateLimited || apiErr.RetryAfterSeconds == nil || *apiErr.RetryAfterSeconds != 30 {
+		t.Errorf("❌ エラーレスポンスが不正です: %s", body)
+	}
+}
+
+// TestRefreshRateLimit_DefaultOff 環境変数を指定しない場合はリフレッシュの上限を適用しないことをテスト
+func TestRefreshRateLimit_DefaultOff(t *testing.T) {
+	t.Setenv("JWT_ACCESS_TOKEN_SECRET", "test-access-secret-0123456789abcdef")
+	t.Setenv("JWT_REFRESH_TOKEN_SECRET", "test-refresh-secret-0123456789abcdef")
+	t.Setenv("JWT_REFRESH_RATE_LIMIT", "")
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("❌ 設定の読み込みに失敗: %v", err)
+	}
+	if cfg.JWT.RefreshRateLimit != 0 {
+		t.Errorf("❌ 既定のリフレッシュの上限 期待値: 0, 実際: %d", cfg.JWT.RefreshRateLimit)
+	}
+}
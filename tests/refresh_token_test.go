@@ -0,0 +1,57 @@
+package tests_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/google/uuid"
+)
+
+// スライディング方式のリフレッシュトークン有効期限のテスト
+func TestRefreshToken_SlidingExpiry(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	idle := 7 * 24 * time.Hour
+
+	t.Run("絶対有効期限内ではアイドル期間分延長される", func(t *testing.T) {
+		absolute := now.Add(90 * 24 * time.Hour)
+
+		got := domain.SlidingExpiresAt(now, idle, absolute)
+		if want := now.Add(idle); !got.Equal(want) {
+			t.Errorf("❌ 有効期限が想定と異なります: got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("絶対有効期限を超える延長は上限で切り詰められる", func(t *testing.T) {
+		absolute := now.Add(3 * 24 * time.Hour)
+
+		got := domain.SlidingExpiresAt(now, idle, absolute)
+		if !got.Equal(absolute) {
+			t.Errorf("❌ 有効期限が絶対有効期限を超えています: got %v, want %v", got, absolute)
+		}
+	})
+
+	t.Run("絶対有効期限を過ぎたファミリーは期限切れ", func(t *testing.T) {
+		token := domain.NewRefreshToken(uuid.New(), "hash", now.Add(idle), nil, nil)
+		token.AbsoluteExpiresAt = now.Add(time.Hour)
+
+		if token.IsAbsoluteExpired(now) {
+			t.Errorf("❌ 絶対有効期限内なのに期限切れと判定されました")
+		}
+		if !token.IsAbsoluteExpired(now.Add(time.Hour)) {
+			t.Errorf("❌ 絶対有効期限を過ぎても有効と判定されました")
+		}
+	})
+
+	t.Run("新しいトークンは自身を先頭とするファミリーになる", func(t *testing.T) {
+		expiresAt := now.Add(idle)
+		token := domain.NewRefreshToken(uuid.New(), "hash", expiresAt, nil, nil)
+
+		if token.FamilyID != token.ID {
+			t.Errorf("❌ FamilyIDがトークンIDと一致しません: %s != %s", token.FamilyID, token.ID)
+		}
+		if !token.AbsoluteExpiresAt.Equal(expiresAt) {
+			t.Errorf("❌ 絶対有効期限が有効期限と一致しません: %v != %v", token.AbsoluteExpiresAt, expiresAt)
+		}
+	})
+}
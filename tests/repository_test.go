@@ -0,0 +1,511 @@
+package tests_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/infrastructure/database"
+	"github.com/aida0710/jwt-auth/internal/repository"
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+)
+
+// 各リポジトリの「見つからない」場合のエラーのテスト
+func TestRepository_NotFound(t *testing.T) {
+	db := database.NewDB(openTestDB(t), 0)
+	ctx := context.Background()
+
+	t.Run("アカウント", func(t *testing.T) {
+		repo := repository.NewAccountRepository(db)
+
+		account, err := repo.GetByID(ctx, uuid.New())
+		if account != nil {
+			t.Errorf("❌ GetByID: 見つからない場合にnil以外が返されました")
+		}
+		assertNotFound(t, "GetByID", err, domain.ErrAccountNotFound)
+
+		account, err = repo.GetByEmail(ctx, "missing_"+uuid.NewString()+"@example.com")
+		if account != nil {
+			t.Errorf("❌ GetByEmail: 見つからない場合にnil以外が返されました")
+		}
+		assertNotFound(t, "GetByEmail", err, domain.ErrAccountNotFound)
+
+		missing := domain.NewAccount("missing@example.com", "Missing", "hash")
+		assertNotFound(t, "Update", repo.Update(ctx, missing), domain.ErrAccountNotFound)
+		assertNotFound(t, "Delete", repo.Delete(ctx, uuid.New()), domain.ErrAccountNotFound)
+	})
+
+	t.Run("プロジェクト", func(t *testing.T) {
+		repo := repository.NewProjectRepository(db)
+
+		project, err := repo.GetByID(ctx, uuid.New())
+		if project != nil {
+			t.Errorf("❌ GetByID: 見つからない場合にnil以外が返されました")
+		}
+		assertNotFound(t, "GetByID", err, domain.ErrProjectNotFound)
+
+		missing := domain.NewProject(uuid.New(), "Missing", "")
+		assertNotFound(t, "Update", repo.Update(ctx, missing), domain.ErrProjectNotFound)
+		assertNotFound(t, "Delete", repo.Delete(ctx, uuid.New()), domain.ErrProjectNotFound)
+	})
+
+	t.Run("リフレッシュトークン", func(t *testing.T) {
+		repo := repository.NewRefreshTokenRepository(db)
+
+		token, err := repo.GetByID(ctx, uuid.New())
+		if token != nil {
+			t.Errorf("❌ GetByID: 見つからない場合にnil以外が返されました")
+		}
+		assertNotFound(t, "GetByID", err, domain.ErrNotFound)
+
+		token, err = repo.GetByTokenHash(ctx, "missing-"+uuid.NewString())
+		if token != nil {
+			t.Errorf("❌ GetByTokenHash: 見つからない場合にnil以外が返されました")
+		}
+		assertNotFound(t, "GetByTokenHash", err, domain.ErrNotFound)
+
+		marked, err := repo.MarkAsUsed(ctx, uuid.New())
+		if marked {
+			t.Errorf("❌ MarkAsUsed: 見つからない場合にtrueが返されました")
+		}
+		assertNotFound(t, "MarkAsUsed", err, domain.ErrNotFound)
+		assertNotFound(t, "Revoke", repo.Revoke(ctx, uuid.New()), domain.ErrNotFound)
+	})
+}
+
+// assertNotFound 期待する「見つからない」エラーが返されたことを確認
+func assertNotFound(t *testing.T, op string, err error, want error) {
+	t.Helper()
+
+	if !errors.Is(err, want) {
+		t.Errorf("❌ %s: 期待するエラー %v ではなく %v が返されました", op, want, err)
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("❌ %s: エラーがErrNotFoundとして判定できません: %v", op, err)
+	}
+}
+
+// 一意制約違反の判定のテスト
+func TestDatabase_IsUniqueViolation(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"MySQLの重複エラー", &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}, true},
+		{"ラップされたMySQLの重複エラー", fmt.Errorf("insert: %w", &mysql.MySQLError{Number: 1062}), true},
+		{"MySQLのその他のエラー", &mysql.MySQLError{Number: 1452}, false},
+		{"PostgreSQLの一意制約違反", sqlStateError("23505"), true},
+		{"PostgreSQLのその他のエラー", sqlStateError("23503"), false},
+		{"通常のエラー", errors.New("boom"), false},
+		{"nil", nil, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := database.IsUniqueViolation(tc.err); got != tc.want {
+				t.Errorf("❌ IsUniqueViolation(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+// 再試行しても成功しないエラーの判定のテスト
+func TestDatabase_IsPermanentError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"MySQLの外部キー違反", fmt.Errorf("insert: %w", &mysql.MySQLError{Number: 1452}), true},
+		{"MySQLのデータ長超過", &mysql.MySQLError{Number: 1406}, true},
+		{"MySQLの重複エラー", &mysql.MySQLError{Number: 1062}, false},
+		{"MySQLのロック待ちタイムアウト", &mysql.MySQLError{Number: 1205}, false},
+		{"PostgreSQLの外部キー違反", sqlStateError("23503"), true},
+		{"PostgreSQLのデータ例外", sqlStateError("22001"), true},
+		{"PostgreSQLの一意制約違反", sqlStateError("23505"), false},
+		{"PostgreSQLの接続エラー", sqlStateError("08006"), false},
+		{"通常のエラー", errors.New("boom"), false},
+		{"nil", nil, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := database.IsPermanentError(tc.err); got != tc.want {
+				t.Errorf("❌ IsPermanentError(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+// sqlStateError SQLSTATEを持つドライバーエラーの代替
+type sqlStateError string
+
+func (e sqlStateError) Error() string    { return "sqlstate " + string(e) }
+func (e sqlStateError) SQLState() string { return string(e) }
+
+// TestAccountRepository_RoundTrip 保存したロールと確認待ちのメールアドレスが読み込まれることをテスト
+func TestAccountRepository_RoundTrip(t *testing.T) {
+	db := database.NewDB(openTestDB(t), 0)
+	ctx := context.Background()
+	accountRepo := repository.NewAccountRepository(db)
+
+	account := domain.NewAccount(fmt.Sprintf("roundtrip_%s@example.com", uuid.NewString()), "Round Trip", "hash")
+	account.Role = domain.RoleAdmin
+	account.RequestEmailChange(fmt.Sprintf("pending_%s@example.com", uuid.NewString()), "token-hash", time.Now().Add(time.Hour))
+	if err := accountRepo.Create(ctx, account); err != nil {
+		t.Fatalf("❌ アカウント作成に失敗: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = accountRepo.Delete(ctx, account.ID)
+	})
+
+	loaders := map[string]func() (*domain.Account, error){
+		"GetByID":    func() (*domain.Account, error) { return accountRepo.GetByID(ctx, account.ID) },
+		"GetByEmail": func() (*domain.Account, error) { return accountRepo.GetByEmail(ctx, account.Email) },
+	}
+	for name, load := range loaders {
+		loaded, err := load()
+		if err != nil {
+			t.Fatalf("❌ %s: %v", name, err)
+		}
+		if loaded.Role != domain.RoleAdmin {
+			t.Errorf("❌ %s: ロール 期待値: admin, 実際: %q", name, loaded.Role)
+		}
+		if loaded.PendingEmail == nil || *loaded.PendingEmail != *account.PendingEmail {
+			t.Errorf("❌ %s: 確認待ちのメールアドレスが読み込まれていません", name)
+		}
+	}
+}
+
+// 同じメールアドレスでの並行作成は1件のみ成功することのテスト
+func TestAccountRepository_ConcurrentCreate(t *testing.T) {
+	db := database.NewDB(openTestDB(t), 0)
+	ctx := context.Background()
+	repo := repository.NewAccountRepository(db)
+
+	email := fmt.Sprintf("concurrent_%s@example.com", uuid.NewString())
+	const concurrency = 5
+
+	var wg sync.WaitGroup
+	errs := make(chan error, concurrency)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- repo.Create(ctx, domain.NewAccount(email, "Concurrent User", "hash"))
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case errors.Is(err, domain.ErrDuplicateEmail):
+		default:
+			t.Errorf("❌ 重複エラー以外のエラーが返されました: %v", err)
+		}
+	}
+
+	if succeeded != 1 {
+		t.Errorf("❌ 成功したのは%d件です（期待値: 1件）", succeeded)
+	}
+
+	t.Cleanup(func() {
+		if account, err := repo.GetByEmail(ctx, email); err == nil {
+			_ = repo.Delete(ctx, account.ID)
+		}
+	})
+}
+
+// 同じトークンの並行した使用済みマークは1件のみ成功することのテスト
+func TestRefreshTokenRepository_ConcurrentMarkAsUsed(t *testing.T) {
+	db := database.NewDB(openTestDB(t), 0)
+	ctx := context.Background()
+	accountRepo := repository.NewAccountRepository(db)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
+
+	account := domain.NewAccount(fmt.Sprintf("mark_%s@example.com", uuid.NewString()), "Mark User", "hash")
+	if err := accountRepo.Create(ctx, account); err != nil {
+		t.Fatalf("❌ アカウント作成に失敗: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = accountRepo.Delete(ctx, account.ID)
+	})
+
+	token := domain.NewRefreshToken(account.ID, "mark-"+uuid.NewString(), time.Now().Add(time.Hour), nil, nil)
+	if err := refreshTokenRepo.Create(ctx, token); err != nil {
+		t.Fatalf("❌ リフレッシュトークン作成に失敗: %v", err)
+	}
+
+	const concurrency = 5
+	var wg sync.WaitGroup
+	results := make(chan bool, concurrency)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			marked, err := refreshTokenRepo.MarkAsUsed(ctx, token.ID)
+			if err != nil {
+				t.Errorf("❌ MarkAsUsed: %v", err)
+			}
+			results <- marked
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	succeeded := 0
+	for marked := range results {
+		if marked {
+			succeeded++
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("❌ 使用済みにできたのは%d件です（期待値: 1件）", succeeded)
+	}
+
+	stored, err := refreshTokenRepo.GetByID(ctx, token.ID)
+	if err != nil {
+		t.Fatalf("❌ GetByID: %v", err)
+	}
+	if stored.UsedAt == nil {
+		t.Error("❌ トークンが使用済みになっていません")
+	}
+}
+
+// プロジェクトの条件検索と件数取得のテスト
+func TestProjectRepository_SearchAndCount(t *testing.T) {
+	db := database.NewDB(openTestDB(t), 0)
+	ctx := context.Background()
+	accountRepo := repository.NewAccountRepository(db)
+	projectRepo := repository.NewProjectRepository(db)
+
+	account := domain.NewAccount(fmt.Sprintf("search_%s@example.com", uuid.NewString()), "Search User", "hash")
+	if err := accountRepo.Create(ctx, account); err != nil {
+		t.Fatalf("❌ アカウント作成に失敗: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = projectRepo.DeleteByAccountID(ctx, account.ID)
+		_ = accountRepo.Delete(ctx, account.ID)
+	})
+
+	statuses := []domain.ProjectStatus{domain.ProjectStatusActive, domain.ProjectStatusActive, domain.ProjectStatusArchived}
+	for i, status := range statuses {
+		project := domain.NewProject(account.ID, fmt.Sprintf("Search %d", i), "")
+		project.Status = status
+		if err := projectRepo.Create(ctx, project); err != nil {
+			t.Fatalf("❌ プロジェクト作成に失敗: %v", err)
+		}
+	}
+
+	active := domain.ProjectStatusActive
+	filter := domain.ProjectFilter{AccountID: &account.ID, Status: &active, Limit: 1}
+
+	count, err := projectRepo.Count(ctx, filter)
+	if err != nil {
+		t.Fatalf("❌ Count: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("❌ Count 期待値: 2, 実際: %d", count)
+	}
+
+	projects, err := projectRepo.Search(ctx, filter)
+	if err != nil {
+		t.Fatalf("❌ Search: %v", err)
+	}
+	if len(projects) != 1 || projects[0].Status != domain.ProjectStatusActive {
+		t.Errorf("❌ Search: 条件とLimitが反映されていません: %v", projects)
+	}
+}
+
+// TestPasswordHistoryRepository_Prune パスワード履歴の取得順と削除をテスト
+func TestPasswordHistoryRepository_Prune(t *testing.T) {
+	db := database.NewDB(openTestDB(t), 0)
+	ctx := context.Background()
+	accountRepo := repository.NewAccountRepository(db)
+	historyRepo := repository.NewPasswordHistoryRepository(db)
+
+	account := domain.NewAccount(fmt.Sprintf("history_%s@example.com", uuid.NewString()), "History User", "hash")
+	if err := accountRepo.Create(ctx, account); err != nil {
+		t.Fatalf("❌ アカウント作成に失敗: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = accountRepo.Delete(ctx, account.ID)
+	})
+
+	for i := 0; i < 3; i++ {
+		history := domain.NewPasswordHistory(account.ID, fmt.Sprintf("hash-%d", i))
+		history.CreatedAt = history.CreatedAt.Add(time.Duration(i) * time.Second)
+		if err := historyRepo.Create(ctx, history); err != nil {
+			t.Fatalf("❌ 履歴の作成に失敗: %v", err)
+		}
+	}
+
+	if err := historyRepo.Prune(ctx, account.ID, 2); err != nil {
+		t.Fatalf("❌ Prune: %v", err)
+	}
+
+	histories, err := historyRepo.ListRecent(ctx, account.ID, 10)
+	if err != nil {
+		t.Fatalf("❌ ListRecent: %v", err)
+	}
+	if len(histories) != 2 || histories[0].PasswordHash != "hash-2" || histories[1].PasswordHash != "hash-1" {
+		t.Errorf("❌ 新しい順に2件だけ残るべきです: %v", histories)
+	}
+}
+
+// TestRefreshTokenRepository_DeleteExpired 有効期限切れのトークンが複数のバッチに分けて削除されることをテスト
+func TestRefreshTokenRepository_DeleteExpired(t *testing.T) {
+	db := database.NewDB(openTestDB(t), 0)
+	ctx := context.Background()
+	accountRepo := repository.NewAccountRepository(db)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
+
+	account := domain.NewAccount(fmt.Sprintf("cleanup_%s@example.com", uuid.NewString()), "Cleanup User", "hash")
+	if err := accountRepo.Create(ctx, account); err != nil {
+		t.Fatalf("❌ アカウント作成に失敗: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = accountRepo.Delete(ctx, account.ID)
+	})
+
+	const expiredCount = 25
+	const batchSize = 10
+	expired := make([]*domain.RefreshToken, expiredCount)
+	for i := range expired {
+		expired[i] = domain.NewRefreshToken(account.ID, "expired-"+uuid.NewString(), time.Now().Add(-time.Hour), nil, nil)
+		if err := refreshTokenRepo.Create(ctx, expired[i]); err != nil {
+			t.Fatalf("❌ リフレッシュトークン作成に失敗: %v", err)
+		}
+	}
+	valid := domain.NewRefreshToken(account.ID, "valid-"+uuid.NewString(), time.Now().Add(time.Hour), nil, nil)
+	if err := refreshTokenRepo.Create(ctx, valid); err != nil {
+		t.Fatalf("❌ リフレッシュトークン作成に失敗: %v", err)
+	}
+
+	t.Run("キャンセルされた場合は削除済みのバッチまでで終了する", func(t *testing.T) {
+		cancelCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+		defer cancel()
+
+		deleted, err := refreshTokenRepo.DeleteExpired(cancelCtx, batchSize, time.Hour)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("❌ キャンセルのエラーが返されていません: %v", err)
+		}
+		if deleted != batchSize {
+			t.Errorf("❌ 削除件数 期待値: %d（1バッチ分）, 実際: %d", batchSize, deleted)
+		}
+	})
+
+	t.Run("複数のバッチに分けてすべて削除する", func(t *testing.T) {
+		deleted, err := refreshTokenRepo.DeleteExpired(ctx, batchSize, time.Millisecond)
+		if err != nil {
+			t.Fatalf("❌ DeleteExpired: %v", err)
+		}
+		if deleted < expiredCount-batchSize {
+			t.Errorf("❌ 削除件数 期待値: %d件以上, 実際: %d", expiredCount-batchSize, deleted)
+		}
+
+		for _, token := range expired {
+			if _, err := refreshTokenRepo.GetByID(ctx, token.ID); !errors.Is(err, domain.ErrNotFound) {
+				t.Fatalf("❌ 有効期限切れのトークンが残っています: %v", err)
+			}
+		}
+		if _, err := refreshTokenRepo.GetByID(ctx, valid.ID); err != nil {
+			t.Errorf("❌ 有効なトークンが削除されました: %v", err)
+		}
+	})
+
+	t.Run("バッチサイズが0以下の場合はエラーを返す", func(t *testing.T) {
+		if _, err := refreshTokenRepo.DeleteExpired(ctx, 0, 0); err == nil {
+			t.Error("❌ バッチサイズ0でエラーが返されませんでした")
+		}
+	})
+}
+
+// TestAccountRepository_ListWithProjectCounts プロジェクト数の集計をテスト
+func TestAccountRepository_ListWithProjectCounts(t *testing.T) {
+	db := database.NewDB(openTestDB(t), 0)
+	ctx := context.Background()
+	accountRepo := repository.NewAccountRepository(db)
+	projectRepo := repository.NewProjectRepository(db)
+
+	busy := domain.NewAccount(fmt.Sprintf("busy_%s@example.com", uuid.NewString()), "Busy", "hash")
+	idle := domain.NewAccount(fmt.Sprintf("idle_%s@example.com", uuid.NewString()), "Idle", "hash")
+	for _, account := range []*domain.Account{busy, idle} {
+		// 他のテストデータより新しい順で先頭に来るようにする
+		account.CreatedAt = time.Now().Add(time.Hour)
+		if err := accountRepo.Create(ctx, account); err != nil {
+			t.Fatalf("❌ アカウント作成に失敗: %v", err)
+		}
+	}
+	t.Cleanup(func() {
+		_ = projectRepo.DeleteByAccountID(ctx, busy.ID)
+		_ = accountRepo.Delete(ctx, busy.ID)
+		_ = accountRepo.Delete(ctx, idle.ID)
+	})
+	for i := 0; i < 2; i++ {
+		if err := projectRepo.Create(ctx, domain.NewProject(busy.ID, fmt.Sprintf("Busy %d", i), "")); err != nil {
+			t.Fatalf("❌ プロジェクト作成に失敗: %v", err)
+		}
+	}
+
+	counts, err := accountRepo.ListWithProjectCounts(ctx, domain.AccountFilter{Limit: 2})
+	if err != nil {
+		t.Fatalf("❌ ListWithProjectCounts: %v", err)
+	}
+	got := make(map[uuid.UUID]int, len(counts))
+	for _, c := range counts {
+		got[c.Account.ID] = c.ProjectCount
+	}
+	if got[busy.ID] != 2 {
+		t.Errorf("❌ プロジェクト数 期待値: 2, 実際: %d", got[busy.ID])
+	}
+	if count, ok := got[idle.ID]; !ok || count != 0 {
+		t.Errorf("❌ プロジェクトの無いアカウントが0件で含まれていません: %v", got)
+	}
+}
+
+// TestAccountRepository_SearchByEmailPrefix メールアドレスの前方一致検索とワイルドカードのエスケープをテスト
+func TestAccountRepository_SearchByEmailPrefix(t *testing.T) {
+	db := database.NewDB(openTestDB(t), 0)
+	ctx := context.Background()
+	accountRepo := repository.NewAccountRepository(db)
+
+	// 他のテストデータと衝突しない接頭辞を使う
+	prefix := "search_" + strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+	emails := []string{prefix + "_b@example.com", prefix + "_a@example.com", prefix + "x@example.com"}
+	for _, email := range emails {
+		account := domain.NewAccount(email, "Search", "hash")
+		if err := accountRepo.Create(ctx, account); err != nil {
+			t.Fatalf("❌ アカウント作成に失敗: %v", err)
+		}
+		t.Cleanup(func() { _ = accountRepo.Delete(ctx, account.ID) })
+	}
+
+	accounts, err := accountRepo.SearchByEmailPrefix(ctx, prefix+"_", 10)
+	if err != nil {
+		t.Fatalf("❌ SearchByEmailPrefix: %v", err)
+	}
+	// "_"は任意の1文字ではなく文字どおりに一致する
+	if len(accounts) != 2 || accounts[0].Email != emails[1] || accounts[1].Email != emails[0] {
+		t.Errorf("❌ メールアドレス順の前方一致になっていません: %v", accounts)
+	}
+
+	accounts, err = accountRepo.SearchByEmailPrefix(ctx, strings.ToUpper(prefix), 1)
+	if err != nil {
+		t.Fatalf("❌ SearchByEmailPrefix: %v", err)
+	}
+	if len(accounts) != 1 {
+		t.Errorf("❌ 大文字小文字を区別せずlimit件に制限されていません: %v", accounts)
+	}
+}
@@ -0,0 +1,165 @@
+package tests_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+)
+
+// sendRawRequest Content-Typeとボディをそのまま指定してテスト用サーバーにリクエストを送信
+// contentTypeが空の場合はContent-Typeヘッダーを付与しない
+func sendRawRequest(t *testing.T, srv *httptest.Server, method, path, contentType, body string) (*http.Response, []byte) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("❌ リクエスト作成に失敗: %v", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("❌ リクエスト送信に失敗: %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(resp.Body)
+	return resp, respBody
+}
+
+// TestAuthRequestBody_ContentTypes サインアップ・ログインのContent-Type別の受け付けをテスト
+func TestAuthRequestBody_ContentTypes(t *testing.T) {
+	srv := newAuthTestServer(t)
+
+	assertStatus := func(t *testing.T, resp *http.Response, body []byte, expected int) {
+		t.Helper()
+		if resp.StatusCode != expected {
+			t.Errorf("❌ ステータスコード 期待値: %d, 実際: %d, body: %s", expected, resp.StatusCode, body)
+		}
+	}
+
+	t.Run("JSONでサインアップできる", func(t *testing.T) {
+		resp, body := sendRawRequest(t, srv, http.MethodPost, "/api/v1/auth/signup", "application/json",
+			`{"email":"json@example.com","password":"SecurePassword123!","name":"JSON User"}`)
+		assertStatus(t, resp, body, http.StatusCreated)
+	})
+
+	t.Run("charset付きのJSONも受け付ける", func(t *testing.T) {
+		resp, body := sendRawRequest(t, srv, http.MethodPost, "/api/v1/auth/login", "application/json; charset=utf-8",
+			`{"email":"json@example.com","password":"SecurePassword123!"}`)
+		assertStatus(t, resp, body, http.StatusOK)
+	})
+
+	t.Run("フォームでサインアップできる", func(t *testing.T) {
+		form := url.Values{
+			"email":    {"form@example.com"},
+			"password": {"SecurePassword123!"},
+			"name":     {"Form User"},
+		}
+		resp, body := sendRawRequest(t, srv, http.MethodPost, "/api/v1/auth/signup", "application/x-www-form-urlencoded", form.Encode())
+		assertStatus(t, resp, body, http.StatusCreated)
+	})
+
+	t.Run("フォームでログインできる", func(t *testing.T) {
+		form := url.Values{
+			"email":    {"form@example.com"},
+			"password": {"SecurePassword123!"},
+		}
+		resp, body := sendRawRequest(t, srv, http.MethodPost, "/api/v1/auth/login", "application/x-www-form-urlencoded", form.Encode())
+		assertStatus(t, resp, body, http.StatusOK)
+	})
+
+	t.Run("不正なフォームの値は400", func(t *testing.T) {
+		form := url.Values{
+			"email":    {"not-an-email"},
+			"password": {"SecurePassword123!"},
+		}
+		resp, body := sendRawRequest(t, srv, http.MethodPost, "/api/v1/auth/login", "application/x-www-form-urlencoded", form.Encode())
+		assertStatus(t, resp, body, http.StatusBadRequest)
+	})
+
+	t.Run("壊れたJSONは400", func(t *testing.T) {
+		resp, body := sendRawRequest(t, srv, http.MethodPost, "/api/v1/auth/login", "application/json", `{"email":`)
+		assertStatus(t, resp, body, http.StatusBadRequest)
+	})
+
+	t.Run("未対応のContent-Typeは415", func(t *testing.T) {
+		cases := map[string]string{
+			"text/plain":     "text/plain",
+			"multipart":      "multipart/form-data; boundary=xyz",
+			"Content-Type無し": "",
+		}
+		for name, contentType := range cases {
+			t.Run(name, func(t *testing.T) {
+				for _, path := range []string{"/api/v1/auth/signup", "/api/v1/auth/login"} {
+					resp, body := sendRawRequest(t, srv, http.MethodPost, path, contentType,
+						`{"email":"json@example.com","password":"SecurePassword123!","name":"JSON User"}`)
+					assertStatus(t, resp, body, http.StatusUnsupportedMediaType)
+				}
+			})
+		}
+	})
+}
+
+// TestRequestBody_DecodeErrors 壊れたJSONや型の異なる値に対して、原因の位置やフィールドを含む400を返すことをテスト
+func TestRequestBody_DecodeErrors(t *testing.T) {
+	srv := newAuthTestServer(t)
+
+	cases := []struct {
+		name     string
+		path     string
+		body     string
+		contains []string
+	}{
+		{"構文エラーは位置を含む", "/api/v1/auth/login",
+			`{"email": "a@example.com",, "password": "x"}`, []string{"malformed JSON", "offset 27"}},
+		{"途中で終わるJSON", "/api/v1/auth/signup",
+			`{"email":`, []string{"unexpected end of input"}},
+		{"型の不一致はフィールド名と型を含む", "/api/v1/auth/signup",
+			`{"email":"a@example.com","password":12345678,"name":"A"}`, []string{`field "password"`, "expected string, got number"}},
+		{"オブジェクト以外のボディ", "/api/v1/auth/refresh",
+			`["token"]`, []string{"must be a JSON object, got array"}},
+	}
+
+	assertDecodeError := func(t *testing.T, resp *http.Response, body []byte, contains []string) {
+		t.Helper()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("❌ ステータスコード 期待値: 400, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var apiErr api.Error
+		if err := json.Unmarshal(body, &apiErr); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if apiErr.Code != api.ErrorCodeInvalidRequest {
+			t.Errorf("❌ エラーコード 期待値: invalid_request, 実際: %s", apiErr.Code)
+		}
+		for _, s := range contains {
+			if !strings.Contains(apiErr.Error, s) {
+				t.Errorf("❌ メッセージに %q が含まれていません: %s", s, apiErr.Error)
+			}
+		}
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, body := sendRawRequest(t, srv, http.MethodPost, tc.path, "application/json", tc.body)
+			assertDecodeError(t, resp, body, tc.contains)
+		})
+	}
+
+	t.Run("c.Bindを使うエンドポイントでもフィールド名を含む", func(t *testing.T) {
+		adminSrv, _, _ := newAdminTestServer(t)
+		resp, body := sendTestRequest(t, adminSrv, http.MethodPut, "/api/v1/admin/accounts/"+domain.NewID().String()+"/status",
+			map[string]string{"X-Test-Role": string(domain.RoleAdmin), "X-Test-Account": domain.NewID().String()},
+			map[string]interface{}{"status": true})
+		assertDecodeError(t, resp, body, []string{`field "status"`, "expected string, got bool"})
+	})
+}
@@ -0,0 +1,73 @@
+package tests_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/handler"
+	"github.com/google/uuid"
+)
+
+// toJSONObject 値をJSONに変換してキーごとのマップとして返す
+func toJSONObject(t *testing.T, v interface{}) map[string]interface{} {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("❌ JSON変換に失敗: %v", err)
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal(b, &obj); err != nil {
+		t.Fatalf("❌ JSONの読み込みに失敗: %v", err)
+	}
+	return obj
+}
+
+// TestResponseShape_Project 値の無い任意項目はnullではなく省略されることをテスト
+func TestResponseShape_Project(t *testing.T) {
+	t.Run("空の説明は省略される", func(t *testing.T) {
+		project := domain.NewProject(uuid.New(), "No Description", "")
+		obj := toJSONObject(t, handler.NewAPIProjectFromEntity(project))
+
+		if _, ok := obj["description"]; ok {
+			t.Errorf("❌ descriptionが含まれています: %v", obj["description"])
+		}
+		for _, key := range []string{"id", "account_id", "name", "status", "created_at", "updated_at"} {
+			if _, ok := obj[key]; !ok {
+				t.Errorf("❌ 必須項目%sがありません", key)
+			}
+		}
+	})
+
+	t.Run("説明がある場合は文字列で含まれる", func(t *testing.T) {
+		project := domain.NewProject(uuid.New(), "With Description", "details")
+		obj := toJSONObject(t, handler.NewAPIProjectFromEntity(project))
+
+		if obj["description"] != "details" {
+			t.Errorf("❌ description 期待値: details, 実際: %v", obj["description"])
+		}
+	})
+}
+
+// TestResponseShape_Account 未設定と空文字のプロフィール項目が同じ形で返されることをテスト
+func TestResponseShape_Account(t *testing.T) {
+	empty := ""
+	unset := domain.NewAccount("shape@example.com", "Shape", "hash")
+	blank := domain.NewAccount("shape@example.com", "Shape", "hash")
+	blank.DisplayName = &empty
+	blank.AvatarURL = &empty
+	blank.Locale = &empty
+	blank.Timezone = &empty
+	blank.PendingEmail = &empty
+
+	for name, account := range map[string]*domain.Account{"未設定": unset, "空文字": blank} {
+		t.Run(name, func(t *testing.T) {
+			obj := toJSONObject(t, handler.NewAPIAccountFromEntity(account))
+			for _, key := range []string{"display_name", "avatar_url", "locale", "timezone", "pending_email"} {
+				if v, ok := obj[key]; ok {
+					t.Errorf("❌ %sが含まれています: %v", key, v)
+				}
+			}
+		})
+	}
+}
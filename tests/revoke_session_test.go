@@ -0,0 +1,186 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+)
+
+// TestRevokeSession セッションID・トークン・ハッシュによるセッションの無効化と権限をテスト
+func TestRevokeSession(t *testing.T) {
+	ctx := context.Background()
+	authUsecase, refreshTokenRepo, auditRepo := newTestAuthUsecase(t)
+	srv := newAuthTestServerWithUsecase(t, authUsecase)
+
+	owner, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+		Email:    "session-owner@example.com",
+		Password: "SecurePassword123!",
+		Name:     "Session Owner",
+	})
+	if err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+	ownerID := owner.Account.ID.String()
+	otherID := domain.NewID().String()
+	adminID := domain.NewID().String()
+
+	// 所有者のセッションをもう1つ作成
+	second, err := authUsecase.Login(ctx, usecase.LoginInput{
+		Email:    "session-owner@example.com",
+		Password: "SecurePassword123!",
+	})
+	if err != nil {
+		t.Fatalf("❌ ログインに失敗: %v", err)
+	}
+	secondHash := auth.HashToken(second.RefreshToken)
+
+	revoke := func(t *testing.T, accountID, role string, body map[string]string) (*http.Response, []byte) {
+		t.Helper()
+		headers := map[string]string{}
+		if accountID != "" {
+			headers["X-Test-Account"] = accountID
+			headers["X-Test-Role"] = role
+		}
+		return sendTestRequest(t, srv, http.MethodDelete, "/api/v1/auth/sessions", headers, body)
+	}
+	isRevoked := func(t *testing.T, tokenHash string) bool {
+		t.Helper()
+		token, err := refreshTokenRepo.GetByTokenHash(ctx, tokenHash)
+		if err != nil {
+			t.Fatalf("❌ トークンの取得に失敗: %v", err)
+		}
+		return token.RevokedAt != nil
+	}
+
+	t.Run("他のアカウントのセッションは無効化できない", func(t *testing.T) {
+		resp, body := revoke(t, otherID, "user", map[string]string{"token_hash": secondHash})
+		if resp.StatusCode != http.StatusForbidden {
+			t.Fatalf("❌ ステータスコード 期待値: 403, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		if isRevoked(t, secondHash) {
+			t.Error("❌ 権限のないリクエストでセッションが無効化されています")
+		}
+	})
+
+	t.Run("未認証は401", func(t *testing.T) {
+		resp, body := revoke(t, "", "", map[string]string{"token_hash": secondHash})
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("❌ ステータスコード 期待値: 401, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+	})
+
+	t.Run("所有者はリフレッシュトークンで無効化できる", func(t *testing.T) {
+		resp, body := revoke(t, ownerID, "user", map[string]string{"refresh_token": owner.RefreshToken})
+		if resp.StatusCode != http.StatusNoContent {
+			t.Fatalf("❌ ステータスコード 期待値: 204, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		if !isRevoked(t, auth.HashToken(owner.RefreshToken)) {
+			t.Error("❌ セッションが無効化されていません")
+		}
+		if isRevoked(t, secondHash) {
+			t.Error("❌ 指定していないセッションまで無効化されています")
+		}
+		if _, err := authUsecase.RefreshToken(ctx, owner.RefreshToken, "", "", ""); err == nil {
+			t.Error("❌ 無効化したトークンでリフレッシュできます")
+		}
+	})
+
+	t.Run("管理者はハッシュで他のアカウントのセッションを無効化できる", func(t *testing.T) {
+		resp, body := revoke(t, adminID, "admin", map[string]string{"token_hash": secondHash})
+		if resp.StatusCode != http.StatusNoContent {
+			t.Fatalf("❌ ステータスコード 期待値: 204, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		if !isRevoked(t, secondHash) {
+			t.Error("❌ セッションが無効化されていません")
+		}
+
+		logs, _ := auditRepo.GetByAccountID(ctx, owner.Account.ID, 10, 0)
+		found := false
+		for _, l := range logs {
+			if l.EventType != domain.EventSessionRevoked {
+				continue
+			}
+			var metadata map[string]interface{}
+			if err := json.Unmarshal(l.Metadata, &metadata); err != nil {
+				t.Fatalf("❌ メタデータのパースに失敗: %v", err)
+			}
+			if metadata["revoked_by"] == adminID && metadata["revoked_by_role"] == "admin" {
+				found = true
+			}
+		}
+		if !found {
+			t.Error("❌ 管理者による無効化が監査ログに記録されていません")
+		}
+	})
+
+	t.Run("存在しないトークンは404", func(t *testing.T) {
+		resp, body := revoke(t, adminID, "admin", map[string]string{"token_hash": auth.HashToken("missing")})
+		if resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("❌ ステータスコード 期待値: 404, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var apiErr api.Error
+		if err := json.Unmarshal(body, &apiErr); err != nil || apiErr.Code != api.ErrorCodeSessionNotFound {
+			t.Errorf("❌ エラーコード 期待値: session_not_found, body: %s", body)
+		}
+	})
+
+	t.Run("所有者はセッションIDで無効化できる", func(t *testing.T) {
+		third, err := authUsecase.Login(ctx, usecase.LoginInput{
+			Email:    "session-owner@example.com",
+			Password: "SecurePassword123!",
+		})
+		if err != nil {
+			t.Fatalf("❌ ログインに失敗: %v", err)
+		}
+		thirdHash := auth.HashToken(third.RefreshToken)
+		session, err := refreshTokenRepo.GetByTokenHash(ctx, thirdHash)
+		if err != nil {
+			t.Fatalf("❌ トークンの取得に失敗: %v", err)
+		}
+
+		resp, body := revoke(t, otherID, "user", map[string]string{"session_id": session.ID.String()})
+		if resp.StatusCode != http.StatusForbidden {
+			t.Fatalf("❌ 他のアカウント ステータスコード 期待値: 403, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+
+		resp, body = revoke(t, ownerID, "user", map[string]string{"session_id": session.ID.String()})
+		if resp.StatusCode != http.StatusNoContent {
+			t.Fatalf("❌ ステータスコード 期待値: 204, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		if !isRevoked(t, thirdHash) {
+			t.Error("❌ セッションが無効化されていません")
+		}
+	})
+
+	t.Run("存在しないセッションIDは404", func(t *testing.T) {
+		resp, body := revoke(t, adminID, "admin", map[string]string{"session_id": domain.NewID().String()})
+		if resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("❌ ステータスコード 期待値: 404, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var apiErr api.Error
+		if err := json.Unmarshal(body, &apiErr); err != nil || apiErr.Code != api.ErrorCodeSessionNotFound {
+			t.Errorf("❌ エラーコード 期待値: session_not_found, body: %s", body)
+		}
+	})
+
+	t.Run("セッションID・トークン・ハッシュはいずれか1つのみ指定する", func(t *testing.T) {
+		for name, body := range map[string]map[string]string{
+			"両方":      {"refresh_token": second.RefreshToken, "token_hash": secondHash},
+			"IDとハッシュ": {"session_id": domain.NewID().String(), "token_hash": secondHash},
+			"なし":      {},
+		} {
+			t.Run(name, func(t *testing.T) {
+				resp, respBody := revoke(t, ownerID, "user", body)
+				if resp.StatusCode != http.StatusBadRequest {
+					t.Errorf("❌ ステータスコード 期待値: 400, 実際: %d, body: %s", resp.StatusCode, respBody)
+				}
+			})
+		}
+	})
+}
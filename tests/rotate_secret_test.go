@@ -0,0 +1,104 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/cli"
+	"github.com/aida0710/jwt-auth/internal/config"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/repository/memory"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/google/uuid"
+)
+
+// TestRotateSecretCommand rotate-secretサブコマンドの前後に署名したトークンがどちらも検証できることをテスト
+func TestRotateSecretCommand(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	keys := auth.NewKeyRing()
+	// 新しい鍵をすぐに署名に使用するよう、読み込みを待つ期間を0にする
+	signingKeys := usecase.NewSigningKeyUsecase(store.SigningKey(), memory.TransactionManager{}, keys, usecase.SigningKeyConfig{
+		Algorithm:        "HS256",
+		RotationInterval: time.Hour,
+		Overlap:          time.Hour,
+		EncryptionSecret: rotationTestSecret,
+	})
+	jwtManager := newRotationTestJWTManager(keys)
+	cfg := &config.Config{JWT: config.JWTConfig{KeyRotationInterval: time.Hour, AccessTokenExpiry: time.Hour}}
+	accountID := uuid.Must(uuid.NewV7())
+
+	if _, err := signingKeys.Rotate(ctx, time.Now()); err != nil {
+		t.Fatalf("❌ 最初の鍵の作成に失敗: %v", err)
+	}
+	before, err := jwtManager.GenerateAccessToken(accountID, "rotate-secret@example.com", "user")
+	if err != nil {
+		t.Fatalf("❌ トークンの生成に失敗: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := cli.RunRotateSecret(ctx, cfg, signingKeys, nil, &stdout, &stderr); err != nil {
+		t.Fatalf("❌ rotate-secretの実行に失敗: %v, stderr: %s", err, stderr.String())
+	}
+	after, err := jwtManager.GenerateAccessToken(accountID, "rotate-secret@example.com", "user")
+	if err != nil {
+		t.Fatalf("❌ トークンの生成に失敗: %v", err)
+	}
+
+	t.Run("ローテーション後は新しい鍵で署名する", func(t *testing.T) {
+		beforeKID, afterKID := tokenKID(t, before), tokenKID(t, after)
+		if afterKID == beforeKID {
+			t.Fatalf("❌ ローテーション後も同じ鍵で署名しています: %s", afterKID)
+		}
+		if !strings.Contains(stdout.String(), "kid: "+afterKID) {
+			t.Errorf("❌ 新しい鍵のkidが出力されていません: %q", stdout.String())
+		}
+	})
+
+	t.Run("ローテーションの前後に署名したトークンをどちらも検証できる", func(t *testing.T) {
+		for name, token := range map[string]string{"前": before, "後": after} {
+			if _, err := jwtManager.ValidateAccessToken(token); err != nil {
+				t.Errorf("❌ ローテーションの%sに署名したトークンが拒否されました: %v", name, err)
+			}
+		}
+	})
+
+	t.Run("他のインスタンスも保存された鍵で前後のトークンを検証できる", func(t *testing.T) {
+		otherKeys := auth.NewKeyRing()
+		if _, err := newRotationTestUsecase(store.SigningKey(), otherKeys).Rotate(ctx, time.Now()); err != nil {
+			t.Fatalf("❌ 鍵の読み込みに失敗: %v", err)
+		}
+		other := newRotationTestJWTManager(otherKeys)
+		for name, token := range map[string]string{"前": before, "後": after} {
+			if _, err := other.ValidateAccessToken(token); err != nil {
+				t.Errorf("❌ ローテーションの%sに署名したトークンが拒否されました: %v", name, err)
+			}
+		}
+	})
+
+	t.Run("ローテーションを予定済みの場合は拒否する", func(t *testing.T) {
+		pending := newRotationTestUsecase(memory.NewStore().SigningKey(), auth.NewKeyRing())
+		now := time.Now()
+		if _, err := pending.Rotate(ctx, now); err != nil {
+			t.Fatalf("❌ 最初の鍵の作成に失敗: %v", err)
+		}
+		if _, err := pending.RotateNow(ctx, now); err != nil {
+			t.Fatalf("❌ ローテーションの予定に失敗: %v", err)
+		}
+		if _, err := pending.RotateNow(ctx, now); !errors.Is(err, domain.ErrSigningKeyRotationScheduled) {
+			t.Errorf("❌ エラー 期待値: %v, 実際: %v", domain.ErrSigningKeyRotationScheduled, err)
+		}
+	})
+
+	t.Run("ローテーションが無効の場合は拒否する", func(t *testing.T) {
+		err := cli.RunRotateSecret(ctx, &config.Config{}, signingKeys, nil, &stdout, &stderr)
+		if !errors.Is(err, cli.ErrKeyRotationDisabled) {
+			t.Errorf("❌ エラー 期待値: %v, 実際: %v", cli.ErrKeyRotationDisabled, err)
+		}
+	})
+}
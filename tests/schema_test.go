@@ -0,0 +1,77 @@
+package tests_test
+
+import (
+	"testing"
+)
+
+// TestSchema_RefreshTokenIndexes refresh_tokensテーブルのインデックス構成をテスト
+func TestSchema_RefreshTokenIndexes(t *testing.T) {
+	db := openTestDB(t)
+
+	type indexColumn struct {
+		IndexName  string `db:"INDEX_NAME"`
+		ColumnName string `db:"COLUMN_NAME"`
+		NonUnique  int    `db:"NON_UNIQUE"`
+		SeqInIndex int    `db:"SEQ_IN_INDEX"`
+	}
+
+	var columns []indexColumn
+	err := db.Select(&columns, `
+		SELECT INDEX_NAME, COLUMN_NAME, NON_UNIQUE, SEQ_IN_INDEX
+		FROM information_schema.STATISTICS
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'refresh_tokens'
+		ORDER BY INDEX_NAME, SEQ_IN_INDEX
+	`)
+	if err != nil {
+		t.Fatalf("❌ インデックス情報の取得に失敗: %v", err)
+	}
+
+	indexes := make(map[string][]indexColumn)
+	for _, c := range columns {
+		indexes[c.IndexName] = append(indexes[c.IndexName], c)
+	}
+
+	t.Run("token_hashのユニークインデックス", func(t *testing.T) {
+		idx, ok := indexes["uq_refresh_tokens_token_hash"]
+		if !ok {
+			t.Fatal("❌ uq_refresh_tokens_token_hashが存在しません")
+		}
+		if len(idx) != 1 || idx[0].ColumnName != "token_hash" || idx[0].NonUnique != 0 {
+			t.Errorf("❌ token_hashの単一列ユニークインデックスである必要があります: %+v", idx)
+		}
+	})
+
+	t.Run("account_idとexpires_atの複合インデックス", func(t *testing.T) {
+		idx, ok := indexes["idx_account_id_expires_at"]
+		if !ok {
+			t.Fatal("❌ idx_account_id_expires_atが存在しません")
+		}
+		if len(idx) != 2 || idx[0].ColumnName != "account_id" || idx[1].ColumnName != "expires_at" {
+			t.Errorf("❌ (account_id, expires_at)の複合インデックスである必要があります: %+v", idx)
+		}
+	})
+}
+
+// TestSchema_AccountEmailIndex accounts.emailが単一列のユニークインデックスで保護されていることをテスト
+func TestSchema_AccountEmailIndex(t *testing.T) {
+	db := openTestDB(t)
+
+	type indexColumn struct {
+		IndexName  string `db:"INDEX_NAME"`
+		ColumnName string `db:"COLUMN_NAME"`
+		NonUnique  int    `db:"NON_UNIQUE"`
+	}
+
+	var columns []indexColumn
+	err := db.Select(&columns, `
+		SELECT INDEX_NAME, COLUMN_NAME, NON_UNIQUE
+		FROM information_schema.STATISTICS
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'accounts' AND INDEX_NAME = 'uq_accounts_email'
+	`)
+	if err != nil {
+		t.Fatalf("❌ インデックス情報の取得に失敗: %v", err)
+	}
+	if len(columns) != 1 || columns[0].ColumnName != "email" || columns[0].NonUnique != 0 {
+		t.Errorf("❌ emailの単一列ユニークインデックスである必要があります: %+v", columns)
+	}
+}
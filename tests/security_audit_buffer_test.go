@@ -0,0 +1,183 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/logger"
+	"github.com/aida0710/jwt-auth/internal/repository"
+	"github.com/aida0710/jwt-auth/internal/selfcheck"
+	"github.com/go-sql-driver/mysql"
+)
+
+// TestSecurityAuditBuffer 監査ログの保存先の障害中もイベントを保持し、回復後に保存することをテスト
+func TestSecurityAuditBuffer(t *testing.T) {
+	ctx := context.Background()
+	primary := &fakeSecurityAuditLogRepository{}
+	var logs bytes.Buffer
+	buffer := repository.NewSecurityAuditBuffer(primary, 2, logger.NewLoggerWithOutput("info", "json", &logs))
+
+	newEvent := func(t *testing.T, description string) *domain.SecurityAuditLog {
+		t.Helper()
+		event, err := domain.NewSecurityAuditLog(domain.NewID(), domain.EventSessionRevoked, description, nil, nil, nil)
+		if err != nil {
+			t.Fatalf("❌ 監査ログの作成に失敗: %v", err)
+		}
+		return event
+	}
+	stored := func() []string {
+		primary.mu.Lock()
+		defer primary.mu.Unlock()
+		descriptions := make([]string, len(primary.logs))
+		for i, log := range primary.logs {
+			descriptions[i] = log.EventDescription
+		}
+		return descriptions
+	}
+
+	primary.setCreateErr(errors.New("database is unavailable"))
+
+	t.Run("保存に失敗したイベントを保持して劣化を通知", func(t *testing.T) {
+		if err := buffer.Create(ctx, newEvent(t, "first")); err != nil {
+			t.Fatalf("❌ 保持したイベントでエラーが返されました: %v", err)
+		}
+		if buffer.Pending() != 1 {
+			t.Errorf("❌ 保持しているイベント 期待値: 1, 実際: %d", buffer.Pending())
+		}
+		if len(stored()) != 0 {
+			t.Errorf("❌ 障害中にイベントが保存されました: %v", stored())
+		}
+		if !strings.Contains(logs.String(), "Security audit pipeline degraded") {
+			t.Errorf("❌ 劣化のアラートがログに出力されていません: %s", logs.String())
+		}
+		if result := selfcheck.CheckAuditBuffer(buffer); result.OK || result.Name != selfcheck.CheckAudit {
+			t.Errorf("❌ 劣化中のレディネスチェックが成功しました: %+v", result)
+		}
+	})
+
+	t.Run("バッファが一杯の場合は最も古いイベントを破棄", func(t *testing.T) {
+		_ = buffer.Create(ctx, newEvent(t, "second"))
+		_ = buffer.Create(ctx, newEvent(t, "third"))
+		if buffer.Pending() != 2 || buffer.Dropped() != 1 {
+			t.Errorf("❌ 保持・破棄した件数 期待値: 2, 1, 実際: %d, %d", buffer.Pending(), buffer.Dropped())
+		}
+		if !strings.Contains(logs.String(), "fallback buffer is full") {
+			t.Errorf("❌ 破棄のアラートがログに出力されていません: %s", logs.String())
+		}
+	})
+
+	t.Run("障害中の再試行では保持し続ける", func(t *testing.T) {
+		flushed, err := buffer.Flush(ctx)
+		if err == nil || flushed != 0 {
+			t.Errorf("❌ 再試行の結果 期待値: エラーと0件, 実際: %v, %d件", err, flushed)
+		}
+		if buffer.Pending() != 2 {
+			t.Errorf("❌ 保持しているイベント 期待値: 2, 実際: %d", buffer.Pending())
+		}
+	})
+
+	t.Run("回復後の再試行で古い順に保存", func(t *testing.T) {
+		primary.setCreateErr(nil)
+		flushed, err := buffer.Flush(ctx)
+		if err != nil || flushed != 2 {
+			t.Fatalf("❌ 再試行の結果 期待値: 2件, 実際: %d件 (%v)", flushed, err)
+		}
+		if got := strings.Join(stored(), ","); got != "second,third" {
+			t.Errorf("❌ 保存したイベント 期待値: second,third, 実際: %s", got)
+		}
+		if buffer.Pending() != 0 {
+			t.Errorf("❌ 保存後もイベントが残っています: %d", buffer.Pending())
+		}
+		if result := selfcheck.CheckAuditBuffer(buffer); !result.OK {
+			t.Errorf("❌ 回復後のレディネスチェックが失敗しました: %s", result.Message)
+		}
+
+		_ = buffer.Create(ctx, newEvent(t, "fourth"))
+		if buffer.Pending() != 0 || len(stored()) != 3 {
+			t.Errorf("❌ 回復後のイベントが直接保存されていません: %v", stored())
+		}
+	})
+
+	t.Run("シャットダウン時に保存できなかったイベントをログに出力", func(t *testing.T) {
+		primary.setCreateErr(errors.New("database is unavailable"))
+		_ = buffer.Create(ctx, newEvent(t, "unsaved-at-shutdown"))
+		logs.Reset()
+
+		if written := buffer.LogPending(ctx); written != 1 {
+			t.Errorf("❌ ログに出力した件数 期待値: 1, 実際: %d", written)
+		}
+		if !strings.Contains(logs.String(), "unsaved-at-shutdown") {
+			t.Errorf("❌ 保存できなかったイベントがログに出力されていません: %s", logs.String())
+		}
+		if buffer.Pending() != 0 {
+			t.Errorf("❌ ログに出力した後もイベントが残っています: %d", buffer.Pending())
+		}
+	})
+}
+
+// TestSecurityAuditBuffer_PermanentFailure 再試行しても書き込めないイベントが後続のイベントの書き込みを塞がないことをテスト
+func TestSecurityAuditBuffer_PermanentFailure(t *testing.T) {
+	ctx := context.Background()
+	primary := &fakeSecurityAuditLogRepository{}
+	var logs bytes.Buffer
+	buffer := repository.NewSecurityAuditBuffer(primary, 10, logger.NewLoggerWithOutput("info", "json", &logs))
+
+	// 存在しないアカウントのイベントは外部キー違反になる
+	foreignKeyErr := &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails"}
+	rejectOrphan := func(log *domain.SecurityAuditLog) error {
+		if log.EventDescription == "orphan" {
+			return foreignKeyErr
+		}
+		return nil
+	}
+	newEvent := func(t *testing.T, description string) *domain.SecurityAuditLog {
+		t.Helper()
+		event, err := domain.NewSecurityAuditLog(domain.NewID(), domain.EventSuspiciousLogin, description, nil, nil, nil)
+		if err != nil {
+			t.Fatalf("❌ 監査ログの作成に失敗: %v", err)
+		}
+		return event
+	}
+
+	t.Run("先頭のイベントが書き込めなくても後続のイベントを保存", func(t *testing.T) {
+		primary.setCreateErr(errors.New("database is unavailable"))
+		for _, description := range []string{"orphan", "second", "third"} {
+			_ = buffer.Create(ctx, newEvent(t, description))
+		}
+		if buffer.Pending() != 3 {
+			t.Fatalf("❌ 保持しているイベント 期待値: 3, 実際: %d", buffer.Pending())
+		}
+
+		primary.setCreateErr(nil)
+		primary.setRejectErr(rejectOrphan)
+		flushed, err := buffer.Flush(ctx)
+		if err != nil || flushed != 2 {
+			t.Fatalf("❌ 再試行の結果 期待値: 2件, 実際: %d件 (%v)", flushed, err)
+		}
+		if buffer.Pending() != 0 {
+			t.Errorf("❌ 書き込めないイベントがバッファに残っています: %d", buffer.Pending())
+		}
+		primary.mu.Lock()
+		stored := len(primary.logs)
+		primary.mu.Unlock()
+		if stored != 2 {
+			t.Errorf("❌ 保存したイベント 期待値: 2件, 実際: %d件", stored)
+		}
+		if !strings.Contains(logs.String(), "write failed permanently") || !strings.Contains(logs.String(), "orphan") {
+			t.Errorf("❌ 破棄したイベントがログに出力されていません: %s", logs.String())
+		}
+	})
+
+	t.Run("書き込めないイベントは保持せずにエラーを返す", func(t *testing.T) {
+		if err := buffer.Create(ctx, newEvent(t, "orphan")); !errors.Is(err, foreignKeyErr) {
+			t.Errorf("❌ 期待したエラー: %v, 実際: %v", foreignKeyErr, err)
+		}
+		if buffer.Pending() != 0 {
+			t.Errorf("❌ 書き込めないイベントがバッファに保持されました: %d", buffer.Pending())
+		}
+	})
+}
@@ -0,0 +1,165 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/config"
+	"github.com/aida0710/jwt-auth/internal/di"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+)
+
+// newTestAuthUsecase インメモリリポジトリを使った認証ユースケースを作成
+func newTestAuthUsecase(t *testing.T) (*usecase.AuthUsecase, *fakeRefreshTokenRepository, *fakeSecurityAuditLogRepository) {
+	t.Helper()
+	return newTestAuthUsecaseWithConfig(t, usecase.AuthConfig{RefreshTokenExpiry: time.Hour})
+}
+
+// newTestAuthUsecaseWithConfig 設定を指定してインメモリリポジトリを使った認証ユースケースを作成
+func newTestAuthUsecaseWithConfig(t *testing.T, config usecase.AuthConfig) (*usecase.AuthUsecase, *fakeRefreshTokenRepository, *fakeSecurityAuditLogRepository) {
+	t.Helper()
+	return newTestAuthUsecaseWithAccountRepository(t, newFakeAccountRepository(), config)
+}
+
+// newTestAuthUsecaseWithAccountRepository アカウントリポジトリと設定を指定して認証ユースケースを作成
+// 競合の再現などで、アカウントリポジトリの振る舞いを差し替えるテストに使う
+func newTestAuthUsecaseWithAccountRepository(t *testing.T, accountRepo domain.AccountRepository, config usecase.AuthConfig) (*usecase.AuthUsecase, *fakeRefreshTokenRepository, *fakeSecurityAuditLogRepository) {
+	t.Helper()
+
+	refreshTokenRepo := newFakeRefreshTokenRepository()
+	auditRepo := &fakeSecurityAuditLogRepository{}
+	jwtManager := auth.NewJWTManager(auth.JWTConfig{
+		AccessTokenSecret:  "test-access-secret-0123456789abcdef",
+		RefreshTokenSecret: "test-refresh-secret-0123456789abcdef",
+		AccessTokenExpiry:  time.Minute,
+		RefreshTokenExpiry: time.Hour,
+		Issuer:             "jwt-auth-test",
+		Audience:           []string{"jwt-auth-test"},
+	})
+
+	authUsecase := usecase.NewAuthUsecase(
+		accountRepo,
+		refreshTokenRepo,
+		auditRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		jwtManager,
+		config,
+	)
+	return authUsecase, refreshTokenRepo, auditRepo
+}
+
+// TestSecurityAudit_ReuseMetadata トークン再利用検出時のメタデータをテスト
+func TestSecurityAudit_ReuseMetadata(t *testing.T) {
+	ctx := context.Background()
+	authUsecase, refreshTokenRepo, auditRepo := newTestAuthUsecase(t)
+
+	tokens, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+		Email:    "reuse@example.com",
+		Password: "SecurePassword123!",
+		Name:     "Reuse User",
+	})
+	if err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+
+	stored, err := refreshTokenRepo.GetByTokenHash(ctx, auth.HashToken(tokens.RefreshToken))
+	if err != nil {
+		t.Fatalf("❌ 保存されたトークンの取得に失敗: %v", err)
+	}
+
+	// 1回目のリフレッシュは成功し、2回目は再利用として検出される
+	if _, err := authUsecase.RefreshToken(ctx, tokens.RefreshToken, "original-agent", "192.0.2.1", ""); err != nil {
+		t.Fatalf("❌ リフレッシュに失敗: %v", err)
+	}
+	_, err = authUsecase.RefreshToken(ctx, tokens.RefreshToken, "replay-agent", "198.51.100.7", "")
+	if !errors.Is(err, domain.ErrTokenCompromised) {
+		t.Fatalf("❌ 期待値: ErrTokenCompromised, 実際: %v", err)
+	}
+
+	logs, _ := auditRepo.GetByEventType(ctx, domain.EventTokenReuseDetected, 10, 0)
+	if len(logs) != 1 {
+		t.Fatalf("❌ 再利用イベント数 期待値: 1, 実際: %d", len(logs))
+	}
+
+	var metadata map[string]interface{}
+	if err := json.Unmarshal(logs[0].Metadata, &metadata); err != nil {
+		t.Fatalf("❌ メタデータのパースに失敗: %v", err)
+	}
+	if metadata["token_id"] != stored.ID.String() {
+		t.Errorf("❌ token_id 期待値: %s, 実際: %v", stored.ID, metadata["token_id"])
+	}
+	if metadata["family_id"] != stored.FamilyID.String() {
+		t.Errorf("❌ family_id 期待値: %s, 実際: %v", stored.FamilyID, metadata["family_id"])
+	}
+	if metadata["replay_ip_address"] != "198.51.100.7" {
+		t.Errorf("❌ replay_ip_address 期待値: 198.51.100.7, 実際: %v", metadata["replay_ip_address"])
+	}
+}
+
+// TestSecurityAudit_Disabled 監査ログを無効にした場合に保存しないリポジトリが使われることをテスト
+func TestSecurityAudit_Disabled(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("SECURITY_AUDIT_ENABLED=falseで保存しないリポジトリを使う", func(t *testing.T) {
+		for _, enabled := range []string{"true", "false"} {
+			t.Setenv("DB_DRIVER", "memory")
+			t.Setenv("SECURITY_AUDIT_ENABLED", enabled)
+			t.Setenv("JWT_ACCESS_TOKEN_SECRET", "test-access-secret-0123456789abcdef")
+			t.Setenv("JWT_REFRESH_TOKEN_SECRET", "test-refresh-secret-0123456789abcdef")
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				t.Fatalf("❌ 設定の読み込みに失敗: %v", err)
+			}
+			container, err := di.NewContainer(cfg)
+			if err != nil {
+				t.Fatalf("❌ コンテナの初期化に失敗: %v", err)
+			}
+			_ = container.Close()
+
+			_, isNop := container.GetSecurityAuditRepo().(domain.NopSecurityAuditLogRepository)
+			if isNop != (enabled == "false") {
+				t.Errorf("❌ SECURITY_AUDIT_ENABLED=%s で保存しないリポジトリの使用: %v", enabled, isNop)
+			}
+		}
+	})
+
+	t.Run("リポジトリを省略してもイベントを記録する操作が成功する", func(t *testing.T) {
+		refreshTokenRepo := newFakeRefreshTokenRepository()
+		authUsecase := usecase.NewAuthUsecase(
+			newFakeAccountRepository(), refreshTokenRepo, nil,
+			nil, nil, nil, nil, nil,
+			auth.NewJWTManager(auth.JWTConfig{
+				AccessTokenSecret:  "test-access-secret-0123456789abcdef",
+				RefreshTokenSecret: "test-refresh-secret-0123456789abcdef",
+				AccessTokenExpiry:  time.Minute,
+				RefreshTokenExpiry: time.Hour,
+			}),
+			usecase.AuthConfig{},
+		)
+
+		tokens, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+			Email:    "no-audit@example.com",
+			Password: "SecurePassword123!",
+			Name:     "No Audit User",
+		})
+		if err != nil {
+			t.Fatalf("❌ サインアップに失敗: %v", err)
+		}
+		if _, err := authUsecase.RefreshToken(ctx, tokens.RefreshToken, "", "", ""); err != nil {
+			t.Fatalf("❌ リフレッシュに失敗: %v", err)
+		}
+		// 再利用の検出はイベントを記録したうえで全トークンを無効化する
+		if _, err := authUsecase.RefreshToken(ctx, tokens.RefreshToken, "", "", ""); !errors.Is(err, domain.ErrTokenCompromised) {
+			t.Errorf("❌ 期待値: ErrTokenCompromised, 実際: %v", err)
+		}
+	})
+}
@@ -0,0 +1,236 @@
+package tests_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/config"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/handler"
+	"github.com/aida0710/jwt-auth/internal/logger"
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/aida0710/jwt-auth/internal/selfcheck"
+	"github.com/labstack/echo/v4"
+)
+
+// fakeSelfCheckDB 存在するテーブルを指定できるセルフチェック用のデータベース
+type fakeSelfCheckDB struct {
+	pingErr error
+	tables  []string
+}
+
+func (db *fakeSelfCheckDB) PingContext(ctx context.Context) error {
+	return db.pingErr
+}
+
+func (db *fakeSelfCheckDB) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	*dest.(*[]string) = slices.Clone(db.tables)
+	return nil
+}
+
+// newSelfCheckConfig セルフチェックがすべて成功する設定を作成
+func newSelfCheckConfig() *config.Config {
+	return &config.Config{
+		JWT: config.JWTConfig{
+			AccessTokenSecret:  "test-access-secret-0123456789abcdef",
+			RefreshTokenSecret: "test-refresh-secret-0123456789abcdef",
+			AccessTokenExpiry:  time.Minute,
+			RefreshTokenExpiry: time.Hour,
+		},
+	}
+}
+
+// findSelfCheckResult 指定した名前のチェック結果を返す
+func findSelfCheckResult(t *testing.T, report selfcheck.Report, name string) selfcheck.Result {
+	t.Helper()
+	for _, result := range report.Results {
+		if result.Name == name {
+			return result
+		}
+	}
+	t.Fatalf("❌ %s のチェック結果がありません: %+v", name, report.Results)
+	return selfcheck.Result{}
+}
+
+// TestSelfCheck_MissingTable 存在しないテーブルがセルフチェックで報告されることをテスト
+func TestSelfCheck_MissingTable(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("すべてのテーブルがあれば成功", func(t *testing.T) {
+		report := selfcheck.Run(ctx, &fakeSelfCheckDB{tables: selfcheck.RequiredTables}, newSelfCheckConfig())
+		if !report.OK() || report.Error() != nil {
+			t.Errorf("❌ 失敗したチェック: %+v", report.Failed())
+		}
+	})
+
+	t.Run("テーブルの欠落を報告する", func(t *testing.T) {
+		tables := slices.DeleteFunc(slices.Clone(selfcheck.RequiredTables), func(table string) bool {
+			return table == "magic_link_tokens"
+		})
+		report := selfcheck.Run(ctx, &fakeSelfCheckDB{tables: tables}, newSelfCheckConfig())
+		if report.OK() {
+			t.Fatal("❌ テーブルが欠落しているのに成功しました")
+		}
+		result := findSelfCheckResult(t, report, selfcheck.CheckTables)
+		if result.OK || !strings.Contains(result.Message, "magic_link_tokens") {
+			t.Errorf("❌ 欠落したテーブルが報告されていません: %+v", result)
+		}
+		if len(report.Failed()) != 1 {
+			t.Errorf("❌ 失敗したチェック 期待値: 1件, 実際: %+v", report.Failed())
+		}
+	})
+
+	t.Run("接続できない場合はテーブルの確認を省略する", func(t *testing.T) {
+		report := selfcheck.Run(ctx, &fakeSelfCheckDB{pingErr: errors.New("dial tcp 10.0.0.1:3306: connection refused")}, newSelfCheckConfig())
+		if result := findSelfCheckResult(t, report, selfcheck.CheckDatabase); result.OK || strings.Contains(result.Message, "10.0.0.1") {
+			t.Errorf("❌ 接続エラーの報告が不正です: %+v", result)
+		}
+		if result := findSelfCheckResult(t, report, selfcheck.CheckTables); result.OK {
+			t.Errorf("❌ 接続できないのにテーブルの確認が成功しました: %+v", result)
+		}
+	})
+
+	t.Run("同じシークレットを報告する", func(t *testing.T) {
+		cfg := newSelfCheckConfig()
+		cfg.JWT.RefreshTokenSecret = cfg.JWT.AccessTokenSecret
+		report := selfcheck.Run(ctx, &fakeSelfCheckDB{tables: selfcheck.RequiredTables}, cfg)
+		if result := findSelfCheckResult(t, report, selfcheck.CheckSecrets); result.OK {
+			t.Errorf("❌ 同じシークレットが報告されていません: %+v", result)
+		}
+	})
+
+	t.Run("レディネスチェックは503と結果を返す", func(t *testing.T) {
+		db := &fakeSelfCheckDB{tables: []string{"accounts"}}
+		readiness := func(ctx context.Context) selfcheck.Report {
+			return selfcheck.Run(ctx, db, newSelfCheckConfig())
+		}
+		e := echo.New()
+		api.RegisterHandlersWithBaseURL(e, handler.NewServer(nil, nil, nil, handler.HealthConfig{Readiness: readiness}, handler.PrivacyConfig{}, logger.NewLoggerWithOutput("error", "json", io.Discard)), "/api/v1")
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/ready", nil))
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Fatalf("❌ ステータスコード 期待値: 503, 実際: %d, body: %s", rec.Code, rec.Body.String())
+		}
+		var report api.ReadinessReport
+		if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if report.Ready {
+			t.Error("❌ ready 期待値: false")
+		}
+		for _, check := range report.Checks {
+			if check.Name == selfcheck.CheckTables && (check.Ok || check.Message == nil || !strings.Contains(*check.Message, "projects")) {
+				t.Errorf("❌ tablesの結果が不正です: %+v", check)
+			}
+		}
+
+		db.tables = selfcheck.RequiredTables
+		rec = httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/ready", nil))
+		if rec.Code != http.StatusOK {
+			t.Errorf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", rec.Code, rec.Body.String())
+		}
+	})
+}
+
+// TestReadiness_PoolStats コネクションプールの統計が管理者または有効化した場合のみレディネスチェックに含まれることをテスト
+func TestReadiness_PoolStats(t *testing.T) {
+	jwtManager := auth.NewJWTManager(auth.JWTConfig{
+		AccessTokenSecret:  "test-access-secret-0123456789abcdef",
+		RefreshTokenSecret: "test-refresh-secret-0123456789abcdef",
+		AccessTokenExpiry:  time.Minute,
+		RefreshTokenExpiry: time.Hour,
+		Issuer:             "jwt-auth-test",
+	})
+	poolStats := func() sql.DBStats {
+		return sql.DBStats{
+			MaxOpenConnections: 25,
+			OpenConnections:    8,
+			InUse:              3,
+			Idle:               5,
+			WaitCount:          4,
+			WaitDuration:       1500 * time.Millisecond,
+			MaxLifetimeClosed:  12,
+		}
+	}
+
+	newEcho := func(expose bool) *echo.Echo {
+		e := echo.New()
+		e.Use(middleware.NewAuthMiddleware(middleware.AuthConfig{
+			JWTManager:    jwtManager,
+			OptionalPaths: []string{"/api/v1/ready"},
+		}))
+		server := handler.NewServer(nil, nil, nil, handler.HealthConfig{PoolStats: poolStats, ExposePoolStats: expose}, handler.PrivacyConfig{}, logger.NewLoggerWithOutput("error", "json", io.Discard))
+		api.RegisterHandlersWithBaseURL(e, server, "/api/v1")
+		return e
+	}
+	getReadiness := func(t *testing.T, e *echo.Echo, token string) (api.ReadinessReport, string) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/ready", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", rec.Code, rec.Body.String())
+		}
+		var report api.ReadinessReport
+		if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		return report, rec.Body.String()
+	}
+	tokenFor := func(t *testing.T, role domain.Role) string {
+		t.Helper()
+		token, err := jwtManager.GenerateAccessToken(domain.NewID(), "pool@example.com", string(role))
+		if err != nil {
+			t.Fatalf("❌ トークンの生成に失敗: %v", err)
+		}
+		return token
+	}
+
+	t.Run("有効化した場合は認証なしでも統計を返す", func(t *testing.T) {
+		report, body := getReadiness(t, newEcho(true), "")
+		if report.Pool == nil {
+			t.Fatalf("❌ プールの統計がありません: %s", body)
+		}
+		for _, field := range []string{"max_open_connections", "open_connections", "in_use", "idle", "wait_count", "wait_duration_ms"} {
+			if !strings.Contains(body, `"`+field+`"`) {
+				t.Errorf("❌ %s がありません: %s", field, body)
+			}
+		}
+		pool := *report.Pool
+		if pool.MaxOpenConnections != 25 || pool.OpenConnections != 8 || pool.InUse != 3 || pool.Idle != 5 || pool.WaitCount != 4 || pool.WaitDurationMs != 1500 || pool.MaxLifetimeClosed != 12 {
+			t.Errorf("❌ プールの統計が不正です: %+v", pool)
+		}
+	})
+
+	t.Run("無効の場合は管理者のトークンにのみ統計を返す", func(t *testing.T) {
+		e := newEcho(false)
+		if report, body := getReadiness(t, e, ""); report.Pool != nil || strings.Contains(body, "open_connections") {
+			t.Errorf("❌ 認証なしのリクエストに統計が含まれています: %s", body)
+		}
+		if report, body := getReadiness(t, e, tokenFor(t, domain.RoleUser)); report.Pool != nil {
+			t.Errorf("❌ 一般ユーザーに統計が含まれています: %s", body)
+		}
+		if report, body := getReadiness(t, e, "invalid-token"); report.Pool != nil {
+			t.Errorf("❌ 無効なトークンで統計が含まれています: %s", body)
+		}
+		if report, body := getReadiness(t, e, tokenFor(t, domain.RoleAdmin)); report.Pool == nil || report.Pool.InUse != 3 {
+			t.Errorf("❌ 管理者に統計が含まれていません: %s", body)
+		}
+	})
+}
@@ -0,0 +1,184 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/repository/memory"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/google/uuid"
+)
+
+// TestRevokeSessionsByOrigin 送信元のIPアドレス・User-Agentが一致するセッションのみを一括で無効化することをテスト
+func TestRevokeSessionsByOrigin(t *testing.T) {
+	ctx := context.Background()
+	authUsecase, refreshRepo, auditRepo := newTestAuthUsecaseWithConfig(t, usecase.AuthConfig{})
+	srv := newAuthTestServerWithUsecase(t, authUsecase)
+	adminID := domain.NewID()
+	admin := map[string]string{
+		"X-Test-Account": adminID.String(),
+		"X-Test-Role":    string(domain.RoleAdmin),
+	}
+
+	const (
+		suspiciousIP = "203.0.113.7"
+		otherIP      = "198.51.100.1"
+		suspiciousUA = "curl/8.0"
+		browserUA    = "Mozilla/5.0 (X11; Linux x86_64) Firefox/128.0"
+	)
+	seed := func(t *testing.T, ipAddress, userAgent string) *domain.RefreshToken {
+		t.Helper()
+		token := domain.NewRefreshToken(domain.NewID(), uuid.NewString(), time.Now().Add(time.Hour), &userAgent, &ipAddress)
+		if err := refreshRepo.Create(ctx, token); err != nil {
+			t.Fatalf("❌ トークンの作成に失敗: %v", err)
+		}
+		return token
+	}
+	isRevoked := func(t *testing.T, token *domain.RefreshToken) bool {
+		t.Helper()
+		stored, err := refreshRepo.GetByID(ctx, token.ID)
+		if err != nil {
+			t.Fatalf("❌ トークンの取得に失敗: %v", err)
+		}
+		return stored.RevokedAt != nil
+	}
+	revoke := func(t *testing.T, headers map[string]string, body map[string]string) (*http.Response, []byte) {
+		t.Helper()
+		return sendTestRequest(t, srv, http.MethodPost, "/api/v1/admin/sessions/revoke", headers, body)
+	}
+
+	t.Run("IPアドレスが一致するセッションのみを無効化", func(t *testing.T) {
+		matching := []*domain.RefreshToken{seed(t, suspiciousIP, browserUA), seed(t, suspiciousIP, suspiciousUA)}
+		unrelated := []*domain.RefreshToken{seed(t, otherIP, browserUA), seed(t, otherIP, suspiciousUA)}
+		alreadyRevoked := seed(t, suspiciousIP, browserUA)
+		if err := refreshRepo.Revoke(ctx, alreadyRevoked.ID); err != nil {
+			t.Fatalf("❌ トークンの無効化に失敗: %v", err)
+		}
+
+		resp, body := revoke(t, admin, map[string]string{"ip_address": suspiciousIP})
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var result api.RevokeSessionsByOriginResponse
+		if err := json.Unmarshal(body, &result); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if result.RevokedCount != int64(len(matching)) {
+			t.Errorf("❌ 無効化した件数 期待値: %d, 実際: %d", len(matching), result.RevokedCount)
+		}
+		for _, token := range matching {
+			if !isRevoked(t, token) {
+				t.Errorf("❌ 一致するセッションが無効化されていません: %s", token.ID)
+			}
+		}
+		for _, token := range unrelated {
+			if isRevoked(t, token) {
+				t.Errorf("❌ 一致しないセッションが無効化されました: %s (%s)", token.ID, *token.IPAddress)
+			}
+		}
+
+		logs, _ := auditRepo.GetByEventType(ctx, domain.EventAdminAction, 10, 0)
+		if len(logs) != 1 || logs[0].AccountID != adminID {
+			t.Fatalf("❌ 管理者の監査ログが記録されていません: %+v", logs)
+		}
+		var metadata struct {
+			Action       string            `json:"action"`
+			Criteria     map[string]string `json:"criteria"`
+			RevokedCount int64             `json:"revoked_count"`
+		}
+		if err := json.Unmarshal(logs[0].Metadata, &metadata); err != nil {
+			t.Fatalf("❌ メタデータのパースに失敗: %v", err)
+		}
+		if metadata.Action != string(domain.AdminActionBulkRevokeSession) ||
+			metadata.Criteria["ip_address"] != suspiciousIP || metadata.RevokedCount != int64(len(matching)) {
+			t.Errorf("❌ 監査ログのメタデータ 期待値: 条件と件数, 実際: %+v", metadata)
+		}
+	})
+
+	t.Run("User-Agentが完全一致するセッションのみを無効化", func(t *testing.T) {
+		matching := seed(t, otherIP, suspiciousUA)
+		partial := seed(t, otherIP, suspiciousUA+" (compatible)")
+
+		resp, body := revoke(t, admin, map[string]string{"user_agent": suspiciousUA})
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		if !isRevoked(t, matching) {
+			t.Error("❌ 一致するセッションが無効化されていません")
+		}
+		if isRevoked(t, partial) {
+			t.Error("❌ 部分一致のセッションが無効化されました")
+		}
+	})
+
+	t.Run("条件が不正な場合は400", func(t *testing.T) {
+		invalid := map[string]map[string]string{
+			"条件なし":      {},
+			"両方を指定":     {"ip_address": suspiciousIP, "user_agent": suspiciousUA},
+			"不正なIPアドレス": {"ip_address": "not-an-ip"},
+		}
+		for name, req := range invalid {
+			if resp, body := revoke(t, admin, req); resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("❌ %s: ステータスコード 期待値: 400, 実際: %d, body: %s", name, resp.StatusCode, body)
+			}
+		}
+	})
+
+	t.Run("管理者以外は403", func(t *testing.T) {
+		token := seed(t, "192.0.2.55", browserUA)
+		resp, body := revoke(t, map[string]string{
+			"X-Test-Account": domain.NewID().String(),
+			"X-Test-Role":    string(domain.RoleUser),
+		}, map[string]string{"ip_address": "192.0.2.55"})
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("❌ ステータスコード 期待値: 403, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		if isRevoked(t, token) {
+			t.Error("❌ 拒否されたリクエストでセッションが無効化されました")
+		}
+	})
+}
+
+// TestRevokeSessionsByOrigin_Tenant 一括無効化がコンテキストのテナントのアカウントに限られることをテスト
+func TestRevokeSessionsByOrigin_Tenant(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	accountRepo, refreshRepo := store.Account(), store.RefreshToken()
+
+	const ipAddress = "203.0.113.7"
+	seed := func(t *testing.T, tenantID string) *domain.RefreshToken {
+		t.Helper()
+		account := domain.NewAccount(tenantID+"@example.com", "Tenant User", "hash")
+		account.TenantID = tenantID
+		if err := accountRepo.Create(ctx, account); err != nil {
+			t.Fatalf("❌ アカウント作成に失敗: %v", err)
+		}
+		ip := ipAddress
+		token := domain.NewRefreshToken(account.ID, uuid.NewString(), time.Now().Add(time.Hour), nil, &ip)
+		if err := refreshRepo.Create(ctx, token); err != nil {
+			t.Fatalf("❌ トークンの作成に失敗: %v", err)
+		}
+		return token
+	}
+	own := seed(t, "tenant-a")
+	other := seed(t, "tenant-b")
+
+	revoked, err := refreshRepo.RevokeByIP(domain.WithTenantID(ctx, "tenant-a"), ipAddress)
+	if err != nil {
+		t.Fatalf("❌ 一括無効化に失敗: %v", err)
+	}
+	if revoked != 1 {
+		t.Errorf("❌ 無効化した件数 期待値: 1, 実際: %d", revoked)
+	}
+	if stored, _ := refreshRepo.GetByID(ctx, own.ID); stored.RevokedAt == nil {
+		t.Error("❌ 同じテナントのセッションが無効化されていません")
+	}
+	if stored, _ := refreshRepo.GetByID(ctx, other.ID); stored.RevokedAt != nil {
+		t.Error("❌ 他のテナントのセッションが無効化されました")
+	}
+}
@@ -0,0 +1,159 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+)
+
+const (
+	testChromeMacUA    = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36"
+	testSafariIPhoneUA = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Mobile/15E148 Safari/604.1"
+)
+
+// TestSessionDeviceName ログイン・リフレッシュ時のセッションの端末名をテスト
+func TestSessionDeviceName(t *testing.T) {
+	ctx := context.Background()
+	authUsecase, _, _ := newTestAuthUsecase(t)
+
+	signedUp, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+		Email:    "device@example.com",
+		Password: "SecurePassword123!",
+		Name:     "Device User",
+	})
+	if err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+	accountID := signedUp.Account.ID
+
+	login := func(t *testing.T, userAgent, deviceName string) *usecase.AuthTokens {
+		t.Helper()
+		tokens, err := authUsecase.Login(ctx, usecase.LoginInput{
+			Email:      "device@example.com",
+			Password:   "SecurePassword123!",
+			UserAgent:  userAgent,
+			DeviceName: deviceName,
+		})
+		if err != nil {
+			t.Fatalf("❌ ログインに失敗: %v", err)
+		}
+		return tokens
+	}
+	assertDeviceName := func(t *testing.T, refreshToken string, want *string) {
+		t.Helper()
+		session, err := authUsecase.CurrentSession(ctx, accountID, refreshToken)
+		if err != nil {
+			t.Fatalf("❌ セッションの取得に失敗: %v", err)
+		}
+		switch {
+		case want == nil && session.DeviceName != nil:
+			t.Errorf("❌ 端末名 期待値: nil, 実際: %q", *session.DeviceName)
+		case want != nil && (session.DeviceName == nil || *session.DeviceName != *want):
+			t.Errorf("❌ 端末名 期待値: %q, 実際: %v", *want, session.DeviceName)
+		}
+	}
+	ptr := func(s string) *string { return &s }
+
+	t.Run("指定した端末名を保存する", func(t *testing.T) {
+		tokens := login(t, testChromeMacUA, "  MacBook  ")
+		assertDeviceName(t, tokens.RefreshToken, ptr("MacBook"))
+	})
+
+	t.Run("未指定の場合はUser-Agentの要約", func(t *testing.T) {
+		tokens := login(t, testSafariIPhoneUA, "")
+		assertDeviceName(t, tokens.RefreshToken, ptr("Safari on iOS"))
+	})
+
+	t.Run("User-Agentも無い場合は保存しない", func(t *testing.T) {
+		tokens := login(t, "", "")
+		assertDeviceName(t, tokens.RefreshToken, nil)
+	})
+
+	t.Run("リフレッシュ時は端末名を引き継ぎ、指定すれば変更できる", func(t *testing.T) {
+		tokens := login(t, testChromeMacUA, "Work laptop")
+
+		rotated, err := authUsecase.RefreshToken(ctx, tokens.RefreshToken, testSafariIPhoneUA, "", "")
+		if err != nil {
+			t.Fatalf("❌ リフレッシュに失敗: %v", err)
+		}
+		assertDeviceName(t, rotated.RefreshToken, ptr("Work laptop"))
+
+		renamed, err := authUsecase.RefreshToken(ctx, rotated.RefreshToken, testChromeMacUA, "", "Home laptop")
+		if err != nil {
+			t.Fatalf("❌ リフレッシュに失敗: %v", err)
+		}
+		assertDeviceName(t, renamed.RefreshToken, ptr("Home laptop"))
+	})
+
+	t.Run("不正な端末名は拒否する", func(t *testing.T) {
+		for _, name := range []string{strings.Repeat("a", domain.MaxDeviceNameLength+1), "Mac\nBook"} {
+			_, err := authUsecase.Login(ctx, usecase.LoginInput{
+				Email:      "device@example.com",
+				Password:   "SecurePassword123!",
+				DeviceName: name,
+			})
+			if !errors.Is(err, domain.ErrInvalidDeviceName) {
+				t.Errorf("❌ 期待値: ErrInvalidDeviceName, 実際: %v", err)
+			}
+		}
+	})
+}
+
+// TestSessionDeviceName_Requests リクエストで指定した端末名がセッション情報に含まれることをテスト
+func TestSessionDeviceName_Requests(t *testing.T) {
+	authUsecase, _, _ := newTestAuthUsecase(t)
+	srv := newAuthTestServerWithUsecase(t, authUsecase)
+
+	signedUp, err := authUsecase.SignUp(context.Background(), usecase.SignUpInput{
+		Email:    "device-request@example.com",
+		Password: "SecurePassword123!",
+		Name:     "Device Request",
+	})
+	if err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+
+	resp, body := sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/login", nil, map[string]string{
+		"email":       "device-request@example.com",
+		"password":    "SecurePassword123!",
+		"device_name": "iPhone",
+	})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+	var tokens api.AuthResponse
+	if err := json.Unmarshal(body, &tokens); err != nil {
+		t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+	}
+
+	resp, body = sendTestRequest(t, srv, http.MethodGet, "/api/v1/auth/session/current", map[string]string{
+		"X-Test-Account":  signedUp.Account.ID.String(),
+		"X-Refresh-Token": tokens.RefreshToken,
+	}, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+	var info api.SessionInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+	}
+	if info.DeviceName == nil || *info.DeviceName != "iPhone" {
+		t.Errorf("❌ device_name 期待値: iPhone, 実際: %v", info.DeviceName)
+	}
+
+	resp, body = sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/login", nil, map[string]string{
+		"email":       "device-request@example.com",
+		"password":    "SecurePassword123!",
+		"device_name": strings.Repeat("a", domain.MaxDeviceNameLength+1),
+	})
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("❌ ステータスコード 期待値: 400, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+}
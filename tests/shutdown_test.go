@@ -0,0 +1,102 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// TestShutdownGate シャットダウン中の新規リクエスト拒否と処理中リクエストの完了をテスト
+func TestShutdownGate(t *testing.T) {
+	gate := middleware.NewShutdownGate()
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	e := echo.New()
+	e.Use(gate.Middleware)
+	e.GET("/slow", func(c echo.Context) error {
+		close(started)
+		<-release
+		return c.String(http.StatusOK, "done")
+	})
+	e.GET("/fast", func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+
+	srv := httptest.NewServer(e)
+	defer srv.Close()
+
+	// 処理に時間のかかるリクエストを開始
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	slowResult := make(chan result, 1)
+	go func() {
+		resp, err := http.Get(srv.URL + "/slow")
+		if err != nil {
+			slowResult <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		slowResult <- result{status: resp.StatusCode, body: string(body)}
+	}()
+	<-started
+
+	// シャットダウン開始
+	if inFlight := gate.StartDraining(); inFlight != 1 {
+		t.Errorf("❌ 処理中のリクエスト数 期待値: 1, 実際: %d", inFlight)
+	}
+
+	// 新規リクエストは503で拒否される
+	resp, err := http.Get(srv.URL + "/fast")
+	if err != nil {
+		t.Fatalf("❌ リクエスト送信に失敗: %v", err)
+	}
+	var apiErr api.Error
+	decodeErr := json.NewDecoder(resp.Body).Decode(&apiErr)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("❌ ドレイン中のリクエスト 期待値: 503, 実際: %d", resp.StatusCode)
+	}
+	if decodeErr != nil || apiErr.Code != api.ErrorCodeServiceUnavailable ||
+		apiErr.RetryAfterSeconds == nil || *apiErr.RetryAfterSeconds != 5 {
+		t.Errorf("❌ ドレイン中のレスポンスボディが想定と異なります: %+v, err: %v", apiErr, decodeErr)
+	}
+	if got := resp.Header.Get(echo.HeaderRetryAfter); got != "5" {
+		t.Errorf("❌ Retry-After 期待値: 5, 実際: %q", got)
+	}
+
+	// サーバーを停止し、処理中のリクエストの完了を待つ
+	shutdownDone := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		shutdownDone <- srv.Config.Shutdown(ctx)
+	}()
+	close(release)
+
+	r := <-slowResult
+	if r.err != nil {
+		t.Fatalf("❌ 処理中のリクエストが失敗しました: %v", r.err)
+	}
+	if r.status != http.StatusOK || r.body != "done" {
+		t.Errorf("❌ 処理中のリクエスト 期待値: 200 done, 実際: %d %s", r.status, r.body)
+	}
+	if err := <-shutdownDone; err != nil {
+		t.Errorf("❌ シャットダウンに失敗: %v", err)
+	}
+	if gate.InFlight() != 0 {
+		t.Errorf("❌ シャットダウン後の処理中リクエスト数 期待値: 0, 実際: %d", gate.InFlight())
+	}
+}
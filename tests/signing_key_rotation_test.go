@@ -0,0 +1,192 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/handler"
+	"github.com/aida0710/jwt-auth/internal/repository/memory"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const rotationTestSecret = "test-access-secret-0123456789abcdef"
+
+// newRotationTestJWTManager 鍵リングで署名・検証するテスト用のJWTManagerを作成
+func newRotationTestJWTManager(keys *auth.KeyRing) *auth.JWTManager {
+	return auth.NewJWTManager(auth.JWTConfig{
+		AccessTokenSecret:  rotationTestSecret,
+		RefreshTokenSecret: "test-refresh-secret-0123456789abcdef",
+		AccessTokenExpiry:  time.Hour,
+		RefreshTokenExpiry: time.Hour,
+		Issuer:             "jwt-auth-test",
+		SigningKeys:        keys,
+	})
+}
+
+// newRotationTestUsecase 1時間ごとにローテーションし、1時間重複させるテスト用の署名鍵ユースケースを作成
+func newRotationTestUsecase(repo domain.SigningKeyRepository, keys *auth.KeyRing) *usecase.SigningKeyUsecase {
+	return usecase.NewSigningKeyUsecase(repo, memory.TransactionManager{}, keys, usecase.SigningKeyConfig{
+		Algorithm:        "HS256",
+		RotationInterval: time.Hour,
+		Overlap:          time.Hour,
+		PropagationDelay: time.Minute,
+		EncryptionSecret: rotationTestSecret,
+	})
+}
+
+// tokenKID トークンのkidヘッダーを返す
+func tokenKID(t *testing.T, token string) string {
+	t.Helper()
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	if err != nil {
+		t.Fatalf("❌ トークンのデコードに失敗: %v", err)
+	}
+	kid, _ := parsed.Header["kid"].(string)
+	return kid
+}
+
+// TestSigningKeyRotation_Lifecycle 署名鍵が有効→引退中→引退の順に遷移し、引退中の鍵のトークンは検証でき、引退した鍵のトークンは拒否されることをテスト
+func TestSigningKeyRotation_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	store := memory.NewStore()
+	keys := auth.NewKeyRing()
+	signingKeys := newRotationTestUsecase(store.SigningKey(), keys)
+	jwtManager := newRotationTestJWTManager(keys)
+	accountID := uuid.Must(uuid.NewV7())
+
+	// 90分前に最初の鍵を作成
+	created, err := signingKeys.Rotate(ctx, now.Add(-90*time.Minute))
+	if err != nil || !created {
+		t.Fatalf("❌ 最初の鍵の作成に失敗: created=%v, err=%v", created, err)
+	}
+	oldToken, err := jwtManager.GenerateAccessToken(accountID, "rotation@example.com", "user")
+	if err != nil {
+		t.Fatalf("❌ トークンの生成に失敗: %v", err)
+	}
+	oldKID := tokenKID(t, oldToken)
+	if oldKID == "" {
+		t.Fatal("❌ 鍵リングで署名したトークンにkidがありません")
+	}
+
+	// 30分前にローテーションの期限を迎え、次の鍵を作成
+	created, err = signingKeys.Rotate(ctx, now.Add(-30*time.Minute))
+	if err != nil || !created {
+		t.Fatalf("❌ 次の鍵の作成に失敗: created=%v, err=%v", created, err)
+	}
+
+	t.Run("引退中の鍵のトークンを検証でき、新しいトークンは次の鍵で署名する", func(t *testing.T) {
+		if _, err := jwtManager.ValidateAccessToken(oldToken); err != nil {
+			t.Errorf("❌ 引退中の鍵のトークンが拒否されました: %v", err)
+		}
+		newToken, err := jwtManager.GenerateAccessToken(accountID, "rotation@example.com", "user")
+		if err != nil {
+			t.Fatalf("❌ トークンの生成に失敗: %v", err)
+		}
+		if kid := tokenKID(t, newToken); kid == "" || kid == oldKID {
+			t.Errorf("❌ 次の鍵で署名されていません: kid=%q, 前の鍵=%q", kid, oldKID)
+		}
+		if _, err := jwtManager.ValidateAccessToken(newToken); err != nil {
+			t.Errorf("❌ 次の鍵のトークンが拒否されました: %v", err)
+		}
+	})
+
+	t.Run("鍵の状態が有効・引退中・引退に遷移する", func(t *testing.T) {
+		stored, err := store.SigningKey().List(ctx)
+		if err != nil {
+			t.Fatalf("❌ 鍵の一覧の取得に失敗: %v", err)
+		}
+		if len(stored) != 2 {
+			t.Fatalf("❌ 鍵の数 期待値: 2, 実際: %d", len(stored))
+		}
+		next, old := stored[0], stored[1]
+		if old.ID.String() != oldKID {
+			t.Fatalf("❌ 前の鍵 期待値: %s, 実際: %s", oldKID, old.ID)
+		}
+		if status := next.Status(now); status != domain.SigningKeyStatusActive {
+			t.Errorf("❌ 次の鍵の状態 期待値: active, 実際: %s", status)
+		}
+		if status := next.Status(now.Add(-30 * time.Minute)); status != domain.SigningKeyStatusPending {
+			t.Errorf("❌ 有効になる前の次の鍵の状態 期待値: pending, 実際: %s", status)
+		}
+		if status := old.Status(now); status != domain.SigningKeyStatusRetiring {
+			t.Errorf("❌ 前の鍵の状態 期待値: retiring, 実際: %s", status)
+		}
+		if status := old.Status(now.Add(time.Hour)); status != domain.SigningKeyStatusRetired {
+			t.Errorf("❌ 重複期間後の前の鍵の状態 期待値: retired, 実際: %s", status)
+		}
+		if old.EncryptedSecret == nil || strings.Contains(string(old.EncryptedSecret), rotationTestSecret) {
+			t.Error("❌ 鍵が暗号化されて保存されていません")
+		}
+	})
+
+	t.Run("再起動しても保存された鍵を読み込んでトークンを検証できる", func(t *testing.T) {
+		restartedKeys := auth.NewKeyRing()
+		created, err := newRotationTestUsecase(store.SigningKey(), restartedKeys).Rotate(ctx, now)
+		if err != nil {
+			t.Fatalf("❌ 鍵の読み込みに失敗: %v", err)
+		}
+		if created {
+			t.Error("❌ 有効な鍵があるのに新しい鍵が作成されました")
+		}
+		if _, err := newRotationTestJWTManager(restartedKeys).ValidateAccessToken(oldToken); err != nil {
+			t.Errorf("❌ 再起動後に引退中の鍵のトークンが拒否されました: %v", err)
+		}
+	})
+
+	t.Run("JWKSは検証に使用する鍵のkidのみを返し、鍵そのものは含めない", func(t *testing.T) {
+		discovery := handler.NewDiscoveryHandler(handler.DiscoveryConfig{
+			IssuerURL:        "https://auth.example.com",
+			SigningAlgorithm: "HS256",
+			SigningKeys:      keys,
+		})
+		e := echo.New()
+		e.GET(handler.JWKSPath, discovery.GetJWKS)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, handler.JWKSPath, nil))
+
+		var jwks handler.JWKS
+		if err := json.Unmarshal(rec.Body.Bytes(), &jwks); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if len(jwks.Keys) != 2 {
+			t.Fatalf("❌ 鍵の数 期待値: 2, 実際: %d, body: %s", len(jwks.Keys), rec.Body.String())
+		}
+		for _, key := range jwks.Keys {
+			if _, ok := key["k"]; ok {
+				t.Errorf("❌ 鍵そのものが含まれています: %v", key)
+			}
+			if key["kid"] == "" || key["alg"] != "HS256" {
+				t.Errorf("❌ kidまたはalgが正しくありません: %v", key)
+			}
+		}
+	})
+
+	t.Run("引退した鍵は削除され、そのトークンは拒否される", func(t *testing.T) {
+		if _, err := signingKeys.Rotate(ctx, now.Add(40*time.Minute)); err != nil {
+			t.Fatalf("❌ ローテーションに失敗: %v", err)
+		}
+		stored, err := store.SigningKey().List(ctx)
+		if err != nil {
+			t.Fatalf("❌ 鍵の一覧の取得に失敗: %v", err)
+		}
+		for _, key := range stored {
+			if key.ID.String() == oldKID {
+				t.Error("❌ 引退した鍵が削除されていません")
+			}
+		}
+		if _, err := jwtManager.ValidateAccessToken(oldToken); err == nil {
+			t.Error("❌ 引退した鍵のトークンが受け入れられました")
+		}
+	})
+}
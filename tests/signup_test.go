@@ -0,0 +1,272 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/config"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+)
+
+// TestSignUp_ConcurrentSameEmail 同じメールアドレスでの並行サインアップは1件のみ成功し、残りは409になることをテスト
+func TestSignUp_ConcurrentSameEmail(t *testing.T) {
+	srv := newAuthTestServer(t)
+	const concurrency = 5
+
+	var wg sync.WaitGroup
+	statuses := make(chan int, concurrency)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// t.Fatalはゴルーチンから呼べないため、送信失敗はステータス0として扱う
+			resp, err := http.Post(srv.URL+"/api/v1/auth/signup", "application/json", strings.NewReader(
+				`{"email":"race@example.com","password":"SecurePassword123!","name":"Race User"}`))
+			if err != nil {
+				statuses <- 0
+				return
+			}
+			resp.Body.Close()
+			statuses <- resp.StatusCode
+		}()
+	}
+	wg.Wait()
+	close(statuses)
+
+	created := 0
+	for status := range statuses {
+		switch status {
+		case http.StatusCreated:
+			created++
+		case http.StatusConflict:
+		default:
+			t.Errorf("❌ 201または409以外のステータスコードが返されました: %d", status)
+		}
+	}
+	if created != 1 {
+		t.Errorf("❌ 成功したのは%d件です（期待値: 1件）", created)
+	}
+}
+
+// racingAccountRepository 指定した数のGetByEmailがそろうまで待たせ、同時のサインアップが両方とも存在チェックを通過する状況を再現する
+type racingAccountRepository struct {
+	*fakeAccountRepository
+	waiting sync.WaitGroup
+}
+
+func newRacingAccountRepository(concurrency int) *racingAccountRepository {
+	r := &racingAccountRepository{fakeAccountRepository: newFakeAccountRepository()}
+	r.waiting.Add(concurrency)
+	return r
+}
+
+func (r *racingAccountRepository) GetByEmail(ctx context.Context, email string) (*domain.Account, error) {
+	r.waiting.Done()
+	r.waiting.Wait()
+	return r.fakeAccountRepository.GetByEmail(ctx, email)
+}
+
+// TestSignUp_DuplicateInsertRace 両方が存在チェックを通過した同時のサインアップで、保存の競合に負けた側がErrEmailAlreadyExists（409）になることをテスト
+func TestSignUp_DuplicateInsertRace(t *testing.T) {
+	const concurrency = 2
+	input := usecase.SignUpInput{Email: "race@example.com", Password: "SecurePassword123!", Name: "Race User"}
+	config := usecase.AuthConfig{RefreshTokenExpiry: time.Hour}
+
+	t.Run("ユースケースは一意制約違反をErrEmailAlreadyExistsとして返す", func(t *testing.T) {
+		accountRepo := newRacingAccountRepository(concurrency)
+		authUsecase, _, _ := newTestAuthUsecaseWithAccountRepository(t, accountRepo, config)
+
+		errs := make([]error, concurrency)
+		var wg sync.WaitGroup
+		for i := 0; i < concurrency; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, errs[i] = authUsecase.SignUp(context.Background(), input)
+			}(i)
+		}
+		wg.Wait()
+
+		succeeded, conflicted := 0, 0
+		for _, err := range errs {
+			switch {
+			case err == nil:
+				succeeded++
+			case errors.Is(err, domain.ErrEmailAlreadyExists):
+				conflicted++
+			default:
+				t.Errorf("❌ 予期しないエラー: %v", err)
+			}
+		}
+		if succeeded != 1 || conflicted != 1 {
+			t.Errorf("❌ 成功・重複の件数 期待値: 1, 1, 実際: %d, %d", succeeded, conflicted)
+		}
+		if count, _ := accountRepo.Count(context.Background(), domain.AccountFilter{}); count != 1 {
+			t.Errorf("❌ 作成されたアカウント数 期待値: 1, 実際: %d", count)
+		}
+	})
+
+	t.Run("APIは201と409を1件ずつ返す", func(t *testing.T) {
+		authUsecase, _, _ := newTestAuthUsecaseWithAccountRepository(t, newRacingAccountRepository(concurrency), config)
+		srv := newAuthTestServerWithUsecase(t, authUsecase)
+
+		statuses := make([]int, concurrency)
+		bodies := make([][]byte, concurrency)
+		var wg sync.WaitGroup
+		for i := 0; i < concurrency; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				resp, body := sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/signup", nil, map[string]string{
+					"email": input.Email, "password": input.Password, "name": input.Name,
+				})
+				statuses[i], bodies[i] = resp.StatusCode, body
+			}(i)
+		}
+		wg.Wait()
+
+		counts := map[int]int{}
+		for i, status := range statuses {
+			counts[status]++
+			if status != http.StatusConflict {
+				continue
+			}
+			var got api.Error
+			if err := json.Unmarshal(bodies[i], &got); err != nil || got.Code != api.ErrorCodeEmailExists {
+				t.Errorf("❌ code 期待値: email_exists, 実際: %s", bodies[i])
+			}
+		}
+		if counts[http.StatusCreated] != 1 || counts[http.StatusConflict] != 1 {
+			t.Errorf("❌ ステータスコード 期待値: 201と409が1件ずつ, 実際: %v", statuses)
+		}
+	})
+}
+
+// TestSignUp_Disabled サインアップを無効にすると403 signup_disabledを返し、管理者によるアカウント作成は可能なことをテスト
+func TestSignUp_Disabled(t *testing.T) {
+	authUsecase, _, _ := newTestAuthUsecaseWithConfig(t, usecase.AuthConfig{DisableSignup: true})
+	srv := newAuthTestServerWithUsecase(t, authUsecase)
+
+	if _, err := authUsecase.SignUp(context.Background(), usecase.SignUpInput{
+		Email:    "invite-only@example.com",
+		Password: "SecurePassword123!",
+		Name:     "Invite Only",
+	}); !errors.Is(err, domain.ErrSignupDisabled) {
+		t.Errorf("❌ 期待値: ErrSignupDisabled, 実際: %v", err)
+	}
+
+	resp, body := sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/signup", nil, api.SignUpRequest{
+		Email:    "invite-only@example.com",
+		Password: "SecurePassword123!",
+		Name:     "Invite Only",
+	})
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("❌ ステータスコード 期待値: 403, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+	var apiErr api.Error
+	if err := json.Unmarshal(body, &apiErr); err != nil {
+		t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+	}
+	if apiErr.Code != api.ErrorCodeSignupDisabled {
+		t.Errorf("❌ code 期待値: signup_disabled, 実際: %s", apiErr.Code)
+	}
+
+	// 管理者によるアカウント作成はサインアップの設定に影響されない
+	adminSrv, _, _ := newAdminTestServer(t)
+	resp, body = sendAsRole(t, adminSrv, http.MethodPost, "/api/v1/accounts", "admin", api.CreateAccountRequest{
+		Email:    "invited@example.com",
+		Password: "SecurePassword123!",
+		Name:     "Invited User",
+	})
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("❌ 管理者による作成 期待値: 201, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+}
+
+// TestSignUp_RoleCannotBeSelfAssigned サインアップで管理者ロールを指定しても一般ユーザーとして作成されることをテスト
+func TestSignUp_RoleCannotBeSelfAssigned(t *testing.T) {
+	srv := newAuthTestServer(t)
+
+	adminRole := string(domain.RoleAdmin)
+	resp, body := sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/signup", nil, api.SignUpRequest{
+		Email:    "escalation@example.com",
+		Password: "SecurePassword123!",
+		Name:     "Escalation",
+		Role:     &adminRole,
+	})
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("❌ ステータスコード 期待値: 201, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+	var authResp api.AuthResponse
+	if err := json.Unmarshal(body, &authResp); err != nil {
+		t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+	}
+	if authResp.Account.Role != api.AccountRole(domain.RoleUser) {
+		t.Errorf("❌ ロール 期待値: user, 実際: %s", authResp.Account.Role)
+	}
+
+	t.Run("方針", func(t *testing.T) {
+		policy := domain.SignupRolePolicy{DefaultRole: domain.RoleUser, SelfAssignable: []domain.Role{domain.RoleUser}}
+		if err := policy.Validate(); err != nil {
+			t.Errorf("❌ 一般ユーザーのみの方針が拒否されました: %v", err)
+		}
+		for requested, expected := range map[domain.Role]domain.Role{
+			"":               domain.RoleUser,
+			domain.RoleUser:  domain.RoleUser,
+			domain.RoleAdmin: domain.RoleUser,
+			"owner":          domain.RoleUser,
+		} {
+			if role := policy.Resolve(requested); role != expected {
+				t.Errorf("❌ %q の指定 期待値: %s, 実際: %s", requested, expected, role)
+			}
+		}
+
+		for name, invalid := range map[string]domain.SignupRolePolicy{
+			"既定のロールが管理者":     {DefaultRole: domain.RoleAdmin},
+			"管理者を自分で選べる":     {SelfAssignable: []domain.Role{domain.RoleAdmin}},
+			"未定義のロールを自分で選べる": {SelfAssignable: []domain.Role{"owner"}},
+		} {
+			if err := invalid.Validate(); !errors.Is(err, domain.ErrInvalidRole) {
+				t.Errorf("❌ %s 期待値: ErrInvalidRole, 実際: %v", name, err)
+			}
+		}
+	})
+}
+
+// TestConfig_SignupRoles サインアップで割り当てるロールの設定を定義済みのロールで検証し、管理者ロールのみ拒否することをテスト
+func TestConfig_SignupRoles(t *testing.T) {
+	load := func(t *testing.T, defaultRole, selfAssignable string) (*config.Config, error) {
+		t.Helper()
+		t.Setenv("JWT_ACCESS_TOKEN_SECRET", "test-access-secret-0123456789abcdef")
+		t.Setenv("JWT_REFRESH_TOKEN_SECRET", "test-refresh-secret-0123456789abcdef")
+		t.Setenv("SIGNUP_DEFAULT_ROLE", defaultRole)
+		t.Setenv("SIGNUP_SELF_ASSIGNABLE_ROLES", selfAssignable)
+		return config.LoadConfig()
+	}
+
+	cfg, err := load(t, "user", "user")
+	if err != nil {
+		t.Fatalf("❌ 設定の読み込みに失敗: %v", err)
+	}
+	if policy := cfg.Signup.RolePolicy(); policy.DefaultRole != domain.RoleUser || len(policy.SelfAssignable) != 1 {
+		t.Errorf("❌ ロールの方針が想定と異なります: %+v", policy)
+	}
+
+	for name, roles := range map[string][2]string{
+		"既定のロールが管理者":     {"admin", ""},
+		"管理者を自分で選べる":     {"user", "user,admin"},
+		"未定義のロールを自分で選べる": {"user", "owner"},
+	} {
+		if _, err := load(t, roles[0], roles[1]); err == nil || !errors.Is(err, domain.ErrInvalidRole) {
+			t.Errorf("❌ %s 期待値: ErrInvalidRole, 実際: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// TestTenant_CrossTenantAccess 他のテナントのアカウント・プロジェクトにアクセスできないことをテスト
+func TestTenant_CrossTenantAccess(t *testing.T) {
+	srv, accountRepo, projectRepo := newAdminTestServer(t)
+	ctx := context.Background()
+
+	newTenantAccount := func(t *testing.T, tenantID, email string) (*domain.Account, *domain.Project) {
+		t.Helper()
+		account := domain.NewAccount(email, "Tenant User", "hash")
+		account.TenantID = tenantID
+		if err := accountRepo.Create(ctx, account); err != nil {
+			t.Fatalf("❌ アカウントの作成に失敗: %v", err)
+		}
+		project := domain.NewProject(account.ID, "Project of "+tenantID, "")
+		project.TenantID = tenantID
+		if err := projectRepo.Create(ctx, project); err != nil {
+			t.Fatalf("❌ プロジェクトの作成に失敗: %v", err)
+		}
+		return account, project
+	}
+	acme, acmeProject := newTenantAccount(t, "acme", "user@acme.example.com")
+	globex, globexProject := newTenantAccount(t, "globex", "user@globex.example.com")
+
+	sendAs := func(t *testing.T, method, path string, account *domain.Account, role domain.Role, body interface{}) (*http.Response, []byte) {
+		t.Helper()
+		return sendTestRequest(t, srv, method, path, map[string]string{
+			"X-Test-Role":    string(role),
+			"X-Test-Account": account.ID.String(),
+			"X-Test-Tenant":  account.TenantID,
+		}, body)
+	}
+	assertNotFound := func(t *testing.T, resp *http.Response, body []byte, code api.ErrorCode) {
+		t.Helper()
+		if resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("❌ ステータスコード 期待値: 404, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var apiErr api.Error
+		if err := json.Unmarshal(body, &apiErr); err != nil || apiErr.Code != code {
+			t.Errorf("❌ エラーコード 期待値: %s, body: %s", code, body)
+		}
+	}
+
+	t.Run("同じテナントのアカウントは取得できる", func(t *testing.T) {
+		resp, body := sendAs(t, http.MethodGet, "/api/v1/accounts/"+acme.ID.String(), acme, domain.RoleUser, nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var account api.Account
+		if err := json.Unmarshal(body, &account); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if account.TenantId != "acme" {
+			t.Errorf("❌ tenant_id 期待値: acme, 実際: %s", account.TenantId)
+		}
+	})
+
+	t.Run("他のテナントのアカウントは404", func(t *testing.T) {
+		path := "/api/v1/accounts/" + globex.ID.String()
+		resp, body := sendAs(t, http.MethodGet, path, acme, domain.RoleAdmin, nil)
+		assertNotFound(t, resp, body, api.ErrorCodeAccountNotFound)
+
+		resp, body = sendAs(t, http.MethodPatch, path, acme, domain.RoleAdmin, map[string]string{"name": "Hijacked"})
+		assertNotFound(t, resp, body, api.ErrorCodeAccountNotFound)
+
+		resp, body = sendAs(t, http.MethodDelete, path, acme, domain.RoleAdmin, nil)
+		assertNotFound(t, resp, body, api.ErrorCodeAccountNotFound)
+
+		stored, err := accountRepo.GetByID(ctx, globex.ID)
+		if err != nil || stored.Name != "Tenant User" {
+			t.Errorf("❌ 他のテナントのアカウントが変更されています: %+v, err: %v", stored, err)
+		}
+	})
+
+	t.Run("他のテナントのプロジェクトは404", func(t *testing.T) {
+		// 自分のアカウント配下のパスに他のテナントのプロジェクトIDを指定
+		resp, body := sendAs(t, http.MethodGet, "/api/v1/accounts/"+acme.ID.String()+"/projects/"+globexProject.ID.String(), acme, domain.RoleUser, nil)
+		assertNotFound(t, resp, body, api.ErrorCodeProjectNotFound)
+
+		resp, body = sendAs(t, http.MethodGet, "/api/v1/accounts/"+globex.ID.String()+"/projects", acme, domain.RoleAdmin, nil)
+		assertNotFound(t, resp, body, api.ErrorCodeAccountNotFound)
+
+		resp, body = sendAs(t, http.MethodDelete, "/api/v1/accounts/"+globex.ID.String()+"/projects/"+globexProject.ID.String(), acme, domain.RoleAdmin, nil)
+		assertNotFound(t, resp, body, api.ErrorCodeAccountNotFound)
+
+		if _, err := projectRepo.GetByID(ctx, globexProject.ID); err != nil {
+			t.Errorf("❌ 他のテナントのプロジェクトが削除されています: %v", err)
+		}
+	})
+
+	t.Run("管理者の一覧は自分のテナントのみ", func(t *testing.T) {
+		resp, body := sendAs(t, http.MethodGet, "/api/v1/admin/projects", acme, domain.RoleAdmin, nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var projects api.ProjectList
+		if err := json.Unmarshal(body, &projects); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if projects.Total != 1 || len(projects.Items) != 1 || projects.Items[0].Id != acmeProject.ID {
+			t.Errorf("❌ 自分のテナントのプロジェクトのみが返される必要があります: %s", body)
+		}
+
+		resp, body = sendAs(t, http.MethodGet, "/api/v1/admin/accounts", acme, domain.RoleAdmin, nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var accounts api.AccountProjectCountList
+		if err := json.Unmarshal(body, &accounts); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if accounts.Total != 1 || len(accounts.Items) != 1 || accounts.Items[0].Account.Id != acme.ID {
+			t.Errorf("❌ 自分のテナントのアカウントのみが返される必要があります: %s", body)
+		}
+	})
+
+	t.Run("管理者が作成したアカウントは同じテナントに所属する", func(t *testing.T) {
+		resp, body := sendAs(t, http.MethodPost, "/api/v1/accounts", acme, domain.RoleAdmin, map[string]string{
+			"email":    "new@acme.example.com",
+			"password": "password123",
+			"name":     "New Member",
+		})
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("❌ ステータスコード 期待値: 201, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var account api.Account
+		if err := json.Unmarshal(body, &account); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if account.TenantId != "acme" {
+			t.Errorf("❌ tenant_id 期待値: acme, 実際: %s", account.TenantId)
+		}
+	})
+}
+
+// TestTenant_SameEmailAcrossTenants メールアドレスはテナントをまたいで一意で、ログインは既存のテナントのアカウントになることをテスト
+// ログインやマジックリンクはテナントを指定せずにメールアドレスで検索するため、他のテナントでの重複を許すと区別できない
+func TestTenant_SameEmailAcrossTenants(t *testing.T) {
+	accountRepo := newFakeAccountRepository()
+	authUsecase, _, _ := newTestAuthUsecaseWithAccountRepository(t, accountRepo, usecase.AuthConfig{RefreshTokenExpiry: time.Hour})
+	accountUsecase := usecase.NewAccountUsecase(accountRepo, nil, nil, nil, nil, nil, fakeTxManager{}, nil, usecase.AccountConfig{})
+	const (
+		email    = "shared@example.com"
+		password = "SecurePassword123!"
+	)
+	signUp := func(tenantID string) (*usecase.AuthTokens, error) {
+		return authUsecase.SignUp(domain.WithTenantID(context.Background(), tenantID), usecase.SignUpInput{
+			Email:    email,
+			Password: password,
+			Name:     "Tenant User",
+		})
+	}
+
+	acme, err := signUp("acme")
+	if err != nil {
+		t.Fatalf("❌ テナントacmeのサインアップに失敗: %v", err)
+	}
+
+	t.Run("他のテナントでは同じメールアドレスでサインアップできない", func(t *testing.T) {
+		if _, err := signUp("globex"); !errors.Is(err, domain.ErrEmailAlreadyExists) {
+			t.Errorf("❌ 期待値: ErrEmailAlreadyExists, 実際: %v", err)
+		}
+	})
+
+	t.Run("管理者も他のテナントで使用されているメールアドレスのアカウントを作成できない", func(t *testing.T) {
+		_, err := accountUsecase.Create(domain.WithTenantID(context.Background(), "globex"), usecase.CreateInput{
+			Email:    email,
+			Password: password,
+			Name:     "Globex User",
+		})
+		if !errors.Is(err, domain.ErrDuplicateEmail) {
+			t.Errorf("❌ 期待値: ErrDuplicateEmail, 実際: %v", err)
+		}
+	})
+
+	t.Run("テナントを指定せずにログインすると既存のテナントのアカウントになる", func(t *testing.T) {
+		tokens, err := authUsecase.Login(context.Background(), usecase.LoginInput{Email: email, Password: password})
+		if err != nil || tokens.Account.ID != acme.Account.ID || tokens.Account.TenantID != "acme" {
+			t.Errorf("❌ ログイン結果が想定と異なります: %+v, %v", tokens, err)
+		}
+	})
+}
+
+// TestTenant_TokenAndMiddleware トークンのテナントがミドルウェアでコンテキストに設定されることをテスト
+func TestTenant_TokenAndMiddleware(t *testing.T) {
+	authUsecase, _, _ := newTestAuthUsecase(t)
+	jwtManager := newAudienceTestJWTManager([]string{"jwt-auth-test"}, auth.AudienceMatchExact)
+
+	tokens, err := authUsecase.SignUp(domain.WithTenantID(context.Background(), "acme"), usecase.SignUpInput{
+		Email:    "member@acme.example.com",
+		Password: "SecurePassword123!",
+		Name:     "Acme Member",
+	})
+	if err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+
+	// ログインはテナントの指定なしで行い、アカウントのテナントがトークンに含まれる
+	loggedIn, err := authUsecase.Login(context.Background(), usecase.LoginInput{
+		Email:    "member@acme.example.com",
+		Password: "SecurePassword123!",
+	})
+	if err != nil {
+		t.Fatalf("❌ ログインに失敗: %v", err)
+	}
+	claims, err := jwtManager.ValidateAccessToken(loggedIn.AccessToken)
+	if err != nil {
+		t.Fatalf("❌ トークンの検証に失敗: %v", err)
+	}
+	if claims.TenantID != "acme" {
+		t.Errorf("❌ tenant_id 期待値: acme, 実際: %q", claims.TenantID)
+	}
+
+	e := echo.New()
+	e.Use(middleware.NewAuthMiddleware(middleware.AuthConfig{JWTManager: jwtManager}))
+	e.GET("/tenant", func(c echo.Context) error {
+		fromContext, _ := domain.TenantIDFromContext(c.Request().Context())
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"context": fromContext,
+			"echo":    c.Get(string(middleware.TenantIDKey)),
+		})
+	})
+
+	cases := []struct {
+		name   string
+		token  string
+		tenant string
+	}{
+		{"トークンのテナント", tokens.AccessToken, "acme"},
+		{"テナントの無いトークンはdefault", mustGenerateAccessToken(t, jwtManager), domain.DefaultTenantID},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/tenant", nil)
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			var got map[string]string
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("❌ レスポンスのデコードに失敗: %v, body: %s", err, rec.Body.String())
+			}
+			if got["context"] != tc.tenant || got["echo"] != tc.tenant {
+				t.Errorf("❌ テナント 期待値: %s, 実際: %v", tc.tenant, got)
+			}
+		})
+	}
+
+	t.Run("他のテナントの管理者はセッションを無効化できない", func(t *testing.T) {
+		err := authUsecase.RevokeSession(domain.WithTenantID(context.Background(), "globex"), usecase.RevokeSessionInput{
+			RefreshToken: loggedIn.RefreshToken,
+			ActorID:      domain.NewID(),
+			ActorRole:    domain.RoleAdmin,
+		})
+		if !errors.Is(err, domain.ErrSessionNotFound) {
+			t.Errorf("❌ 期待値: ErrSessionNotFound, 実際: %v", err)
+		}
+		if _, err := authUsecase.RefreshToken(context.Background(), loggedIn.RefreshToken, "", "", ""); err != nil {
+			t.Errorf("❌ セッションが無効化されています: %v", err)
+		}
+	})
+}
+
+// mustGenerateAccessToken テナントを含まないアクセストークンを生成
+func mustGenerateAccessToken(t *testing.T, jwtManager *auth.JWTManager) string {
+	t.Helper()
+	token, err := jwtManager.GenerateAccessToken(domain.NewID(), "legacy@example.com", "user")
+	if err != nil {
+		t.Fatalf("❌ トークンの生成に失敗: %v", err)
+	}
+	return token
+}
@@ -0,0 +1,141 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/auth"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/google/uuid"
+)
+
+// TestTokenAudience ログイン・リフレッシュで要求したAudienceのトークンを発行し、許可されていないAudienceは拒否することをテスト
+func TestTokenAudience(t *testing.T) {
+	ctx := context.Background()
+	accountRepo := newFakeAccountRepository()
+	authServer := newAudienceTestJWTManager([]string{"jwt-auth-test"}, auth.AudienceMatchExact)
+	authUsecase := usecase.NewAuthUsecase(accountRepo, newFakeRefreshTokenRepository(), nil, nil, nil, nil, nil, nil,
+		authServer, usecase.AuthConfig{RefreshTokenExpiry: time.Hour})
+	accountUsecase := usecase.NewAccountUsecase(accountRepo, nil, nil, nil, nil, nil, fakeTxManager{}, nil, usecase.AccountConfig{})
+
+	signedUp, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+		Email:    "audience@example.com",
+		Password: "SecurePassword123!",
+		Name:     "Audience User",
+	})
+	if err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+	admin := usecase.Actor{ID: uuid.Must(uuid.NewV7()), Role: domain.RoleAdmin}
+	if _, err := accountUsecase.UpdateAllowedAudiences(ctx, signedUp.Account.ID, []string{" billing-api ", "billing-api"}, admin); err != nil {
+		t.Fatalf("❌ Audienceの設定に失敗: %v", err)
+	}
+
+	billing := newAudienceTestJWTManager([]string{"billing-api"}, auth.AudienceMatchExact)
+	reports := newAudienceTestJWTManager([]string{"reports-api"}, auth.AudienceMatchExact)
+	login := func(audience string) (*usecase.AuthTokens, error) {
+		return authUsecase.Login(ctx, usecase.LoginInput{
+			Email:    "audience@example.com",
+			Password: "SecurePassword123!",
+			Audience: audience,
+		})
+	}
+
+	t.Run("許可されたAudienceのトークンはそのサービスでのみ受け入れられる", func(t *testing.T) {
+		tokens, err := login("billing-api")
+		if err != nil {
+			t.Fatalf("❌ ログインに失敗: %v", err)
+		}
+		claims, err := billing.ValidateAccessToken(tokens.AccessToken)
+		if err != nil {
+			t.Fatalf("❌ 対象のサービスでトークンが拒否されました: %v", err)
+		}
+		if len(claims.Audience) != 1 || claims.Audience[0] != "billing-api" {
+			t.Errorf("❌ aud 期待値: [billing-api], 実際: %v", claims.Audience)
+		}
+		if _, err := reports.ValidateAccessToken(tokens.AccessToken); err == nil {
+			t.Error("❌ 他のサービスでトークンが受け入れられました")
+		}
+		if _, err := authServer.ValidateAccessToken(tokens.AccessToken); err == nil {
+			t.Error("❌ 既定のAudienceのサービスでトークンが受け入れられました")
+		}
+
+		refreshed, err := authUsecase.Refresh(ctx, usecase.RefreshInput{RefreshToken: tokens.RefreshToken, Audience: "billing-api"})
+		if err != nil {
+			t.Fatalf("❌ リフレッシュに失敗: %v", err)
+		}
+		if _, err := billing.ValidateAccessToken(refreshed.AccessToken); err != nil {
+			t.Errorf("❌ リフレッシュしたトークンが対象のサービスで拒否されました: %v", err)
+		}
+	})
+
+	t.Run("許可されていないAudienceは拒否する", func(t *testing.T) {
+		if _, err := login("reports-api"); !errors.Is(err, domain.ErrAudienceNotAllowed) {
+			t.Errorf("❌ ログインのエラー 期待値: %v, 実際: %v", domain.ErrAudienceNotAllowed, err)
+		}
+
+		tokens, err := login("")
+		if err != nil {
+			t.Fatalf("❌ ログインに失敗: %v", err)
+		}
+		if _, err := authServer.ValidateAccessToken(tokens.AccessToken); err != nil {
+			t.Errorf("❌ 既定のAudienceのトークンが拒否されました: %v", err)
+		}
+		_, err = authUsecase.Refresh(ctx, usecase.RefreshInput{RefreshToken: tokens.RefreshToken, Audience: "reports-api"})
+		if !errors.Is(err, domain.ErrAudienceNotAllowed) {
+			t.Errorf("❌ リフレッシュのエラー 期待値: %v, 実際: %v", domain.ErrAudienceNotAllowed, err)
+		}
+		// 拒否したリフレッシュではトークンを使用済みにしない
+		if _, err := authUsecase.RefreshToken(ctx, tokens.RefreshToken, "", "", ""); err != nil {
+			t.Errorf("❌ 拒否した後のリフレッシュに失敗: %v", err)
+		}
+	})
+}
+
+// TestUpdateAccountAudiences 管理者のみがアカウントの要求できるAudienceを変更できることをテスト
+func TestUpdateAccountAudiences(t *testing.T) {
+	ctx := context.Background()
+	srv, accountRepo, _ := newAdminTestServer(t)
+	account := domain.NewAccount("audience-admin@example.com", "Audience", "hash")
+	if err := accountRepo.Create(ctx, account); err != nil {
+		t.Fatalf("❌ アカウント作成に失敗: %v", err)
+	}
+	path := "/api/v1/admin/accounts/" + account.ID.String() + "/audiences"
+	adminID := uuid.Must(uuid.NewV7())
+
+	t.Run("管理者はAudienceを設定できる", func(t *testing.T) {
+		resp, body := sendAsAdmin(t, srv, http.MethodPut, path, adminID, api.UpdateAccountAudiencesRequest{
+			Audiences: []string{"reports-api", "billing-api"},
+		})
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var got api.Account
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		if got.AllowedAudiences == nil || len(*got.AllowedAudiences) != 2 || (*got.AllowedAudiences)[0] != "billing-api" {
+			t.Errorf("❌ allowed_audiences 期待値: [billing-api reports-api], 実際: %v", got.AllowedAudiences)
+		}
+	})
+
+	t.Run("空白を含むAudienceは拒否する", func(t *testing.T) {
+		resp, body := sendAsAdmin(t, srv, http.MethodPut, path, adminID, api.UpdateAccountAudiencesRequest{Audiences: []string{"billing api"}})
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("❌ ステータスコード 期待値: 400, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+	})
+
+	t.Run("一般ユーザーは変更できない", func(t *testing.T) {
+		resp, body := sendAsAccount(t, srv, http.MethodPut, path, account.ID, api.UpdateAccountAudiencesRequest{Audiences: []string{"billing-api"}})
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("❌ ステータスコード 期待値: 403, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package tests_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// newTrustedProxyTestEcho 信頼するプロキシを設定したテスト用のEchoを作成
+func newTrustedProxyTestEcho(t *testing.T, trustedProxies ...string) *echo.Echo {
+	t.Helper()
+
+	ipExtractor, err := middleware.NewIPExtractor(trustedProxies)
+	if err != nil {
+		t.Fatalf("❌ IP抽出関数の作成に失敗: %v", err)
+	}
+
+	e := echo.New()
+	e.IPExtractor = ipExtractor
+	return e
+}
+
+// TestRealIP_TrustedProxies 信頼するプロキシからのみ転送ヘッダーを採用することをテスト
+func TestRealIP_TrustedProxies(t *testing.T) {
+	realIP := func(e *echo.Echo, remoteAddr string, headers map[string]string) string {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = remoteAddr
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		return e.NewContext(req, httptest.NewRecorder()).RealIP()
+	}
+
+	spoofed := map[string]string{
+		echo.HeaderXForwardedFor: "198.51.100.7",
+		echo.HeaderXRealIP:       "198.51.100.8",
+	}
+
+	t.Run("信頼するプロキシ未設定なら転送ヘッダーを無視", func(t *testing.T) {
+		e := newTrustedProxyTestEcho(t)
+		if ip := realIP(e, "203.0.113.5:5000", spoofed); ip != "203.0.113.5" {
+			t.Errorf("❌ 接続元アドレスを期待しましたが %s", ip)
+		}
+	})
+
+	e := newTrustedProxyTestEcho(t, "10.0.0.0/24", "2001:db8::1")
+
+	cases := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string]string
+		expected   string
+	}{
+		{"信頼しない接続元からの偽装は無視", "203.0.113.5:5000", spoofed, "203.0.113.5"},
+		{"信頼するプロキシ経由はX-Forwarded-Forを採用", "10.0.0.2:5000", spoofed, "198.51.100.7"},
+		{"信頼するIPv6プロキシ経由もX-Forwarded-Forを採用", "[2001:db8::1]:5000", spoofed, "198.51.100.7"},
+		{
+			"多段プロキシは信頼しない最初のアドレスを採用",
+			"10.0.0.2:5000",
+			map[string]string{echo.HeaderXForwardedFor: "192.0.2.99, 198.51.100.7, 10.0.0.3"},
+			"198.51.100.7",
+		},
+		{"転送ヘッダーが無ければ接続元アドレス", "10.0.0.2:5000", nil, "10.0.0.2"},
+		{
+			"プライベートアドレスでも設定外のプロキシは信頼しない",
+			"192.168.1.1:5000",
+			spoofed,
+			"192.168.1.1",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if ip := realIP(e, tc.remoteAddr, tc.headers); ip != tc.expected {
+				t.Errorf("❌ RealIP 期待値: %s, 実際: %s", tc.expected, ip)
+			}
+		})
+	}
+
+	t.Run("不正なCIDRは作成時にエラー", func(t *testing.T) {
+		if _, err := middleware.NewIPExtractor([]string{"10.0.0.0/99"}); err == nil {
+			t.Error("❌ 不正なCIDRでエラーになるべきです")
+		}
+	})
+}
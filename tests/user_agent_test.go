@@ -0,0 +1,146 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+)
+
+// TestParseUserAgent 代表的なUser-Agentからブラウザ・OS・端末の種類を推定できることをテスト
+func TestParseUserAgent(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		expected  domain.UserAgentInfo
+	}{
+		{
+			name:      "Chrome on macOS",
+			userAgent: testChromeMacUA,
+			expected:  domain.UserAgentInfo{Browser: "Chrome", BrowserVersion: "124", OS: "macOS", Device: domain.DeviceDesktop},
+		},
+		{
+			name:      "Safari on iPhone",
+			userAgent: testSafariIPhoneUA,
+			expected:  domain.UserAgentInfo{Browser: "Safari", BrowserVersion: "17", OS: "iOS", Device: domain.DeviceMobile},
+		},
+		{
+			name:      "Safari on iPad",
+			userAgent: "Mozilla/5.0 (iPad; CPU OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Mobile/15E148 Safari/604.1",
+			expected:  domain.UserAgentInfo{Browser: "Safari", BrowserVersion: "17", OS: "iPadOS", Device: domain.DeviceTablet},
+		},
+		{
+			name:      "Edge on Windows",
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36 Edg/124.0.2478.51",
+			expected:  domain.UserAgentInfo{Browser: "Edge", BrowserVersion: "124", OS: "Windows", Device: domain.DeviceDesktop},
+		},
+		{
+			name:      "Firefox on Linux",
+			userAgent: "Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:125.0) Gecko/20100101 Firefox/125.0",
+			expected:  domain.UserAgentInfo{Browser: "Firefox", BrowserVersion: "125", OS: "Linux", Device: domain.DeviceDesktop},
+		},
+		{
+			name:      "Chrome on Androidスマートフォン",
+			userAgent: "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.6367.82 Mobile Safari/537.36",
+			expected:  domain.UserAgentInfo{Browser: "Chrome", BrowserVersion: "124", OS: "Android", Device: domain.DeviceMobile},
+		},
+		{
+			name:      "Chrome on Androidタブレット",
+			userAgent: "Mozilla/5.0 (Linux; Android 14; SM-X710) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.6367.82 Safari/537.36",
+			expected:  domain.UserAgentInfo{Browser: "Chrome", BrowserVersion: "124", OS: "Android", Device: domain.DeviceTablet},
+		},
+		{
+			name:      "Googlebot",
+			userAgent: "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			expected:  domain.UserAgentInfo{Device: domain.DeviceBot},
+		},
+		{
+			name:      "curl",
+			userAgent: "curl/8.5.0",
+			expected:  domain.UserAgentInfo{Browser: "curl", BrowserVersion: "8"},
+		},
+		{
+			name:      "空文字",
+			userAgent: "",
+			expected:  domain.UserAgentInfo{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := domain.ParseUserAgent(tt.userAgent); got != tt.expected {
+				t.Errorf("❌ 期待値: %+v, 実際: %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestSessionUserAgentInfo セッション情報に生のUser-Agentと解析結果が含まれることをテスト
+func TestSessionUserAgentInfo(t *testing.T) {
+	authUsecase, _, _ := newTestAuthUsecase(t)
+	srv := newAuthTestServerWithUsecase(t, authUsecase)
+	ctx := context.Background()
+
+	signedUp, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+		Email:    "ua-info@example.com",
+		Password: "SecurePassword123!",
+		Name:     "UA Info",
+	})
+	if err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+
+	currentSession := func(t *testing.T, userAgent string) api.SessionInfo {
+		t.Helper()
+		tokens, err := authUsecase.Login(ctx, usecase.LoginInput{
+			Email:     "ua-info@example.com",
+			Password:  "SecurePassword123!",
+			UserAgent: userAgent,
+		})
+		if err != nil {
+			t.Fatalf("❌ ログインに失敗: %v", err)
+		}
+		resp, body := sendTestRequest(t, srv, http.MethodGet, "/api/v1/auth/session/current", map[string]string{
+			"X-Test-Account":  signedUp.Account.ID.String(),
+			"X-Refresh-Token": tokens.RefreshToken,
+		}, nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var info api.SessionInfo
+		if err := json.Unmarshal(body, &info); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		return info
+	}
+
+	t.Run("解析結果を返し、生のUser-Agentも保持する", func(t *testing.T) {
+		info := currentSession(t, testSafariIPhoneUA)
+		if info.UserAgent == nil || *info.UserAgent != testSafariIPhoneUA {
+			t.Errorf("❌ user_agent 期待値: %q, 実際: %v", testSafariIPhoneUA, info.UserAgent)
+		}
+		ua := info.UserAgentInfo
+		if ua == nil {
+			t.Fatal("❌ user_agent_infoが含まれていません")
+		}
+		if ua.Browser == nil || *ua.Browser != "Safari" {
+			t.Errorf("❌ browser 期待値: Safari, 実際: %v", ua.Browser)
+		}
+		if ua.Os == nil || *ua.Os != "iOS" {
+			t.Errorf("❌ os 期待値: iOS, 実際: %v", ua.Os)
+		}
+		if ua.Device == nil || *ua.Device != api.Mobile {
+			t.Errorf("❌ device 期待値: mobile, 実際: %v", ua.Device)
+		}
+	})
+
+	t.Run("User-Agentが無い場合は含めない", func(t *testing.T) {
+		if info := currentSession(t, ""); info.UserAgentInfo != nil {
+			t.Errorf("❌ user_agent_info 期待値: nil, 実際: %+v", info.UserAgentInfo)
+		}
+	})
+}
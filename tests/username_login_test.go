@@ -0,0 +1,212 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/aida0710/jwt-auth/internal/handler"
+	"github.com/aida0710/jwt-auth/internal/logger"
+	"github.com/aida0710/jwt-auth/internal/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	usernameLoginEmail    = "username@example.com"
+	usernameLoginPassword = "SecurePassword123!"
+)
+
+// newUsernameLoginUsecase ユーザー名でのログインの有効・無効を指定して認証ユースケースを作成
+func newUsernameLoginUsecase(t *testing.T, enabled bool) *usecase.AuthUsecase {
+	t.Helper()
+	authUsecase, _, _ := newTestAuthUsecaseWithConfig(t, usecase.AuthConfig{RefreshTokenExpiry: time.Hour, UsernameLogin: enabled})
+	return authUsecase
+}
+
+// TestUsernameLogin メールアドレスとユーザー名のどちらでもログインできることをテスト
+func TestUsernameLogin(t *testing.T) {
+	ctx := context.Background()
+	authUsecase := newUsernameLoginUsecase(t, true)
+
+	signedUp, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+		Email:    usernameLoginEmail,
+		Password: usernameLoginPassword,
+		Name:     "Username User",
+		Username: " John_Doe ",
+	})
+	if err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+	if signedUp.Account.Username == nil || *signedUp.Account.Username != "john_doe" {
+		t.Fatalf("❌ ユーザー名 期待値: john_doe, 実際: %v", signedUp.Account.Username)
+	}
+
+	for _, identifier := range []string{"john_doe", "JOHN_DOE", usernameLoginEmail} {
+		t.Run(identifier+"でログインできる", func(t *testing.T) {
+			tokens, err := authUsecase.Login(ctx, usecase.LoginInput{Identifier: identifier, Password: usernameLoginPassword})
+			if err != nil {
+				t.Fatalf("❌ ログインに失敗: %v", err)
+			}
+			if tokens.Account.ID != signedUp.Account.ID {
+				t.Errorf("❌ アカウント 期待値: %s, 実際: %s", signedUp.Account.ID, tokens.Account.ID)
+			}
+		})
+	}
+
+	t.Run("Identifierが空の場合はEmailでログインできる", func(t *testing.T) {
+		if _, err := authUsecase.Login(ctx, usecase.LoginInput{Email: usernameLoginEmail, Password: usernameLoginPassword}); err != nil {
+			t.Errorf("❌ ログインに失敗: %v", err)
+		}
+	})
+
+	t.Run("パスワードの誤りと存在しないユーザー名は区別しない", func(t *testing.T) {
+		for _, input := range []usecase.LoginInput{
+			{Identifier: "john_doe", Password: "WrongPassword123!"},
+			{Identifier: "jane_doe", Password: usernameLoginPassword},
+		} {
+			if _, err := authUsecase.Login(ctx, input); !errors.Is(err, domain.ErrInvalidCredentials) {
+				t.Errorf("❌ %s: 期待値: ErrInvalidCredentials, 実際: %v", input.Identifier, err)
+			}
+		}
+	})
+}
+
+// TestUsernameLogin_SignUpValidation サインアップで指定したユーザー名の検証をテスト
+func TestUsernameLogin_SignUpValidation(t *testing.T) {
+	ctx := context.Background()
+	authUsecase := newUsernameLoginUsecase(t, true)
+	if _, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+		Email: usernameLoginEmail, Password: usernameLoginPassword, Name: "Username User", Username: "john_doe",
+	}); err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+
+	cases := []struct {
+		name     string
+		username string
+		want     error
+	}{
+		{"短すぎる", "ab", domain.ErrInvalidUsername},
+		{"長すぎる", "abcdefghijklmnopqrstuvwxyz01234", domain.ErrInvalidUsername},
+		{"@を含む", "john@doe", domain.ErrInvalidUsername},
+		{"空白を含む", "john doe", domain.ErrInvalidUsername},
+		{"大文字小文字違いの重複", "JOHN_DOE", domain.ErrDuplicateUsername},
+	}
+	for i, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+				Email:    "other" + string(rune('a'+i)) + "@example.com",
+				Password: usernameLoginPassword,
+				Name:     "Other User",
+				Username: tc.username,
+			})
+			if !errors.Is(err, tc.want) {
+				t.Errorf("❌ 期待値: %v, 実際: %v", tc.want, err)
+			}
+		})
+	}
+
+	t.Run("ユーザー名は省略できる", func(t *testing.T) {
+		tokens, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+			Email: "nousername@example.com", Password: usernameLoginPassword, Name: "No Username",
+		})
+		if err != nil {
+			t.Fatalf("❌ サインアップに失敗: %v", err)
+		}
+		if tokens.Account.Username != nil {
+			t.Errorf("❌ ユーザー名 期待値: nil, 実際: %s", *tokens.Account.Username)
+		}
+	})
+}
+
+// TestUsernameLogin_Disabled ユーザー名でのログインが無効な場合はユーザー名を受け付けないことをテスト
+func TestUsernameLogin_Disabled(t *testing.T) {
+	ctx := context.Background()
+	authUsecase := newUsernameLoginUsecase(t, false)
+
+	if _, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+		Email: usernameLoginEmail, Password: usernameLoginPassword, Name: "Username User", Username: "john_doe",
+	}); !errors.Is(err, domain.ErrInvalidUsername) {
+		t.Fatalf("❌ サインアップ 期待値: ErrInvalidUsername, 実際: %v", err)
+	}
+
+	if _, err := authUsecase.SignUp(ctx, usecase.SignUpInput{
+		Email: usernameLoginEmail, Password: usernameLoginPassword, Name: "Username User",
+	}); err != nil {
+		t.Fatalf("❌ サインアップに失敗: %v", err)
+	}
+	if _, err := authUsecase.Login(ctx, usecase.LoginInput{Identifier: usernameLoginEmail, Password: usernameLoginPassword}); err != nil {
+		t.Errorf("❌ メールアドレスでのログインに失敗: %v", err)
+	}
+	if _, err := authUsecase.Login(ctx, usecase.LoginInput{Identifier: "username", Password: usernameLoginPassword}); !errors.Is(err, domain.ErrInvalidCredentials) {
+		t.Errorf("❌ ユーザー名でのログイン 期待値: ErrInvalidCredentials, 実際: %v", err)
+	}
+}
+
+// TestUsernameLogin_HTTP APIでidentifierとemailのどちらでもログインでき、ユーザー名の重複は409を返すことをテスト
+func TestUsernameLogin_HTTP(t *testing.T) {
+	authUsecase := newUsernameLoginUsecase(t, true)
+	server := handler.NewServer(nil, nil, handler.NewAuthHandler(authUsecase), handler.HealthConfig{}, handler.PrivacyConfig{}, logger.NewLoggerWithOutput("error", "json", io.Discard))
+	e := echo.New()
+	api.RegisterHandlersWithBaseURL(e, server, "/api/v1")
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	resp, body := sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/signup", nil, map[string]string{
+		"email": usernameLoginEmail, "password": usernameLoginPassword, "name": "Username User", "username": "john_doe",
+	})
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("❌ サインアップ 期待値: 201, 実際: %d, body: %s", resp.StatusCode, body)
+	}
+
+	logins := []struct {
+		name string
+		body map[string]string
+	}{
+		{"identifierにユーザー名", map[string]string{"identifier": "john_doe", "password": usernameLoginPassword}},
+		{"identifierにメールアドレス", map[string]string{"identifier": usernameLoginEmail, "password": usernameLoginPassword}},
+		{"従来のemail", map[string]string{"email": usernameLoginEmail, "password": usernameLoginPassword}},
+	}
+	for _, tc := range logins {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, body := sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/login", nil, tc.body)
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("❌ ステータスコード 期待値: 200, 実際: %d, body: %s", resp.StatusCode, body)
+			}
+			var got api.AuthResponse
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+			}
+			if got.Account.Username == nil || *got.Account.Username != "john_doe" {
+				t.Errorf("❌ username 期待値: john_doe, 実際: %v", got.Account.Username)
+			}
+		})
+	}
+
+	t.Run("identifierとemailが無い場合は400", func(t *testing.T) {
+		resp, body := sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/login", nil, map[string]string{"password": usernameLoginPassword})
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("❌ ステータスコード 期待値: 400, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+	})
+
+	t.Run("ユーザー名の重複は409", func(t *testing.T) {
+		resp, body := sendTestRequest(t, srv, http.MethodPost, "/api/v1/auth/signup", nil, map[string]string{
+			"email": "other@example.com", "password": usernameLoginPassword, "name": "Other User", "username": "John_Doe",
+		})
+		if resp.StatusCode != http.StatusConflict {
+			t.Fatalf("❌ ステータスコード 期待値: 409, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var got api.Error
+		if err := json.Unmarshal(body, &got); err != nil || got.Code != api.ErrorCodeUsernameExists {
+			t.Errorf("❌ code 期待値: username_exists, 実際: %s", body)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package tests_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aida0710/jwt-auth/internal/api"
+	"github.com/aida0710/jwt-auth/internal/domain"
+	"github.com/google/uuid"
+)
+
+// setLocalTimeZoneForTest テスト中だけサーバーのタイムゾーンをUTC以外に変更
+func setLocalTimeZoneForTest(t *testing.T) {
+	t.Helper()
+	previous := time.Local
+	time.Local = time.FixedZone("JST", 9*60*60)
+	t.Cleanup(func() {
+		time.Local = previous
+	})
+}
+
+// TestTimestamps_UTCOnCreate サーバーのタイムゾーンに関わらず作成時のタイムスタンプがUTCになることをテスト
+func TestTimestamps_UTCOnCreate(t *testing.T) {
+	setLocalTimeZoneForTest(t)
+
+	assertUTC := func(t *testing.T, name string, ts time.Time) {
+		t.Helper()
+		if ts.Location() != time.UTC {
+			t.Errorf("❌ %s のタイムゾーン 期待値: UTC, 実際: %s", name, ts.Location())
+		}
+	}
+
+	t.Run("エンティティの作成", func(t *testing.T) {
+		account := domain.NewAccount("utc@example.com", "UTC", "hash")
+		assertUTC(t, "Account.CreatedAt", account.CreatedAt)
+		assertUTC(t, "Account.UpdatedAt", account.UpdatedAt)
+
+		project := domain.NewProject(uuid.New(), "Project", "")
+		assertUTC(t, "Project.CreatedAt", project.CreatedAt)
+		assertUTC(t, "Project.UpdatedAt", project.UpdatedAt)
+
+		token := domain.NewRefreshToken(account.ID, uuid.NewString(), domain.Now().Add(time.Hour), nil, nil)
+		assertUTC(t, "RefreshToken.CreatedAt", token.CreatedAt)
+		token.MarkAsUsed()
+		assertUTC(t, "RefreshToken.UsedAt", *token.UsedAt)
+	})
+
+	t.Run("レスポンスはRFC 3339のZ表記", func(t *testing.T) {
+		srv, accountRepo, _ := newAdminTestServer(t)
+		owner := domain.NewAccount("utc-owner@example.com", "Owner", "hash")
+		if err := accountRepo.Create(t.Context(), owner); err != nil {
+			t.Fatalf("❌ アカウント作成に失敗: %v", err)
+		}
+
+		path := "/api/v1/accounts/" + owner.ID.String() + "/projects"
+		resp, body := sendAsAccount(t, srv, http.MethodPost, path, owner.ID, api.CreateProjectRequest{Name: "UTC Project"})
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("❌ ステータスコード 期待値: 201, 実際: %d, body: %s", resp.StatusCode, body)
+		}
+		var raw map[string]interface{}
+		if err := json.Unmarshal(body, &raw); err != nil {
+			t.Fatalf("❌ レスポンスのデコードに失敗: %v", err)
+		}
+		for _, key := range []string{"created_at", "updated_at"} {
+			value, _ := raw[key].(string)
+			if _, err := time.Parse(time.RFC3339, value); err != nil || !strings.HasSuffix(value, "Z") {
+				t.Errorf("❌ %s 期待値: RFC 3339のUTC（Z）, 実際: %q", key, value)
+			}
+		}
+	})
+}